@@ -0,0 +1,366 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"runtime"
+	"sync"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// minParBatchWork is the approximate number of floating point operations
+// below which a batched call is computed serially.
+const minParBatchWork = 1 << 15
+
+// DgemmBatched computes
+//  C_l = beta * C_l + alpha * A_l * B_l,  l = 0, …, batchCount-1,
+// where A_l, B_l and C_l are dense matrices stored in a, b and c, and alpha
+// and beta are scalars. tA and tB specify whether the A_l or B_l are transposed.
+//
+// The l-th matrix of each batch begins at l times the corresponding batch
+// stride, so A_l is stored in a[l*strideA:] with row stride lda, and similarly
+// for B_l and C_l. strideA and strideB may be zero to use the same matrix
+// for every product. The C_l must not overlap.
+//
+// The products are distributed across goroutines when the batch is large enough.
+// Square products of order 2, 3 and 4 with neither operand transposed are
+// computed by specialized kernels.
+func (Implementation) DgemmBatched(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, beta float64, c []float64, ldc, strideC int, batchCount int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if batchCount < 0 {
+		panic(batchLT0)
+	}
+	aTrans := tA == blas.Trans || tA == blas.ConjTrans
+	if aTrans {
+		checkDBatch('a', k, m, a, lda, strideA, batchCount, false)
+	} else {
+		checkDBatch('a', m, k, a, lda, strideA, batchCount, false)
+	}
+	bTrans := tB == blas.Trans || tB == blas.ConjTrans
+	if bTrans {
+		checkDBatch('b', n, k, b, ldb, strideB, batchCount, false)
+	} else {
+		checkDBatch('b', k, n, b, ldb, strideB, batchCount, false)
+	}
+	checkDBatch('c', m, n, c, ldc, strideC, batchCount, true)
+
+	if batchCount == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	if blocks(m, blockSize)*blocks(n, blockSize) >= minParBlock {
+		// Each product is large enough to be computed concurrently
+		// by dgemmParallel, so work through the batch in order.
+		for l := 0; l < batchCount; l++ {
+			dgemmBatchElem(aTrans, bTrans, m, n, k, alpha, a[l*strideA:], lda, b[l*strideB:], ldb, beta, c[l*strideC:], ldc, true)
+		}
+		return
+	}
+	parallelBatch(batchCount, m*n*k, func(lo, hi int) {
+		for l := lo; l < hi; l++ {
+			dgemmBatchElem(aTrans, bTrans, m, n, k, alpha, a[l*strideA:], lda, b[l*strideB:], ldb, beta, c[l*strideC:], ldc, false)
+		}
+	})
+}
+
+// dgemmBatchElem computes a single product of a DgemmBatched call.
+func dgemmBatchElem(aTrans, bTrans bool, m, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int, par bool) {
+	// The small kernels always read A and B, so they are only used
+	// when alpha is non-zero. This ensures that NaN and Inf values in
+	// A and B do not propagate into C when alpha is zero.
+	if alpha != 0 && !aTrans && !bTrans && m == n && n == k {
+		switch n {
+		case 2:
+			dgemm2x2(alpha, a, lda, b, ldb, beta, c, ldc)
+			return
+		case 3:
+			dgemm3x3(alpha, a, lda, b, ldb, beta, c, ldc)
+			return
+		case 4:
+			dgemm4x4(alpha, a, lda, b, ldb, beta, c, ldc)
+			return
+		}
+	}
+
+	if beta != 1 {
+		for i := 0; i < m; i++ {
+			ctmp := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ctmp {
+					ctmp[j] = 0
+				}
+				continue
+			}
+			for j := range ctmp {
+				ctmp[j] *= beta
+			}
+		}
+	}
+	if alpha == 0 || k == 0 {
+		return
+	}
+	if par {
+		dgemmParallel(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+		return
+	}
+	dgemmSerial(aTrans, bTrans, m, n, k, a, lda, b, ldb, c, ldc, alpha)
+}
+
+// dgemm2x2 computes C = beta * C + alpha * A * B for 2×2 matrices.
+func dgemm2x2(alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	b00, b01 := b[0], b[1]
+	b10, b11 := b[ldb], b[ldb+1]
+	for i := 0; i < 2; i++ {
+		ai := a[i*lda : i*lda+2]
+		ci := c[i*ldc : i*ldc+2]
+		c0 := alpha * (ai[0]*b00 + ai[1]*b10)
+		c1 := alpha * (ai[0]*b01 + ai[1]*b11)
+		if beta != 0 {
+			c0 += beta * ci[0]
+			c1 += beta * ci[1]
+		}
+		ci[0], ci[1] = c0, c1
+	}
+}
+
+// dgemm3x3 computes C = beta * C + alpha * A * B for 3×3 matrices.
+func dgemm3x3(alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	b0 := b[:3]
+	b1 := b[ldb : ldb+3]
+	b2 := b[2*ldb : 2*ldb+3]
+	b00, b01, b02 := b0[0], b0[1], b0[2]
+	b10, b11, b12 := b1[0], b1[1], b1[2]
+	b20, b21, b22 := b2[0], b2[1], b2[2]
+	for i := 0; i < 3; i++ {
+		ai := a[i*lda : i*lda+3]
+		ci := c[i*ldc : i*ldc+3]
+		a0, a1, a2 := ai[0], ai[1], ai[2]
+		c0 := alpha * (a0*b00 + a1*b10 + a2*b20)
+		c1 := alpha * (a0*b01 + a1*b11 + a2*b21)
+		c2 := alpha * (a0*b02 + a1*b12 + a2*b22)
+		if beta != 0 {
+			c0 += beta * ci[0]
+			c1 += beta * ci[1]
+			c2 += beta * ci[2]
+		}
+		ci[0], ci[1], ci[2] = c0, c1, c2
+	}
+}
+
+// dgemm4x4 computes C = beta * C + alpha * A * B for 4×4 matrices.
+func dgemm4x4(alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	b0 := b[:4]
+	b1 := b[ldb : ldb+4]
+	b2 := b[2*ldb : 2*ldb+4]
+	b3 := b[3*ldb : 3*ldb+4]
+	b00, b01, b02, b03 := b0[0], b0[1], b0[2], b0[3]
+	b10, b11, b12, b13 := b1[0], b1[1], b1[2], b1[3]
+	b20, b21, b22, b23 := b2[0], b2[1], b2[2], b2[3]
+	b30, b31, b32, b33 := b3[0], b3[1], b3[2], b3[3]
+	for i := 0; i < 4; i++ {
+		ai := a[i*lda : i*lda+4]
+		ci := c[i*ldc : i*ldc+4]
+		a0, a1, a2, a3 := ai[0], ai[1], ai[2], ai[3]
+		c0 := alpha * (a0*b00 + a1*b10 + a2*b20 + a3*b30)
+		c1 := alpha * (a0*b01 + a1*b11 + a2*b21 + a3*b31)
+		c2 := alpha * (a0*b02 + a1*b12 + a2*b22 + a3*b32)
+		c3 := alpha * (a0*b03 + a1*b13 + a2*b23 + a3*b33)
+		if beta != 0 {
+			c0 += beta * ci[0]
+			c1 += beta * ci[1]
+			c2 += beta * ci[2]
+			c3 += beta * ci[3]
+		}
+		ci[0], ci[1], ci[2], ci[3] = c0, c1, c2, c3
+	}
+}
+
+// DtrsmBatched solves
+//  A_l * X_l = alpha * B_l,   if tA == blas.NoTrans and side == blas.Left,
+//  A_l^T * X_l = alpha * B_l, if tA == blas.Trans or blas.ConjTrans, and side == blas.Left,
+//  X_l * A_l = alpha * B_l,   if tA == blas.NoTrans and side == blas.Right,
+//  X_l * A_l^T = alpha * B_l, if tA == blas.Trans or blas.ConjTrans, and side == blas.Right,
+// for l = 0, …, batchCount-1, where each A_l is an n×n or m×m triangular matrix,
+// and X_l and B_l are m×n matrices. On return each B_l is overwritten by X_l.
+//
+// The l-th matrix of each batch begins at l times the corresponding batch
+// stride, so A_l is stored in a[l*strideA:] with row stride lda and B_l is
+// stored in b[l*strideB:] with row stride ldb. strideA may be zero to use the
+// same triangular matrix for every solve. The B_l must not overlap.
+//
+// The solves are distributed across goroutines when the batch is large enough.
+// Left-side solves with a single right-hand side are computed by a specialized
+// kernel.
+func (impl Implementation) DtrsmBatched(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda, strideA int, b []float64, ldb, strideB int, batchCount int) {
+	if s != blas.Left && s != blas.Right {
+		panic(badSide)
+	}
+	if ul != blas.Lower && ul != blas.Upper {
+		panic(badUplo)
+	}
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if d != blas.NonUnit && d != blas.Unit {
+		panic(badDiag)
+	}
+	if batchCount < 0 {
+		panic(batchLT0)
+	}
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	checkDBatch('a', k, k, a, lda, strideA, batchCount, false)
+	checkDBatch('b', m, n, b, ldb, strideB, batchCount, true)
+
+	if batchCount == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	if alpha == 0 {
+		// As in Dtrsm, A is not referenced when alpha is zero, so a
+		// singular A or NaN values in A and B do not reach the result.
+		for l := 0; l < batchCount; l++ {
+			for i := 0; i < m; i++ {
+				bi := b[l*strideB+i*ldb : l*strideB+i*ldb+n]
+				for j := range bi {
+					bi[j] = 0
+				}
+			}
+		}
+		return
+	}
+	if s == blas.Left && n == 1 {
+		parallelBatch(batchCount, m*m, func(lo, hi int) {
+			for l := lo; l < hi; l++ {
+				dtrsvBatchElem(ul, tA, d, m, alpha, a[l*strideA:], lda, b[l*strideB:], ldb)
+			}
+		})
+		return
+	}
+	parallelBatch(batchCount, m*n*k, func(lo, hi int) {
+		for l := lo; l < hi; l++ {
+			impl.Dtrsm(s, ul, tA, d, m, n, alpha, a[l*strideA:], lda, b[l*strideB:], ldb)
+		}
+	})
+}
+
+// dtrsvBatchElem solves A * x = alpha * b or A^T * x = alpha * b for the
+// m-vector x stored in the first column of b with stride ldb.
+func dtrsvBatchElem(ul blas.Uplo, tA blas.Transpose, d blas.Diag, m int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	nonUnit := d == blas.NonUnit
+	if tA == blas.NoTrans {
+		if ul == blas.Upper {
+			for i := m - 1; i >= 0; i-- {
+				sum := alpha * b[i*ldb]
+				for j, v := range a[i*lda+i+1 : i*lda+m] {
+					sum -= v * b[(i+j+1)*ldb]
+				}
+				if nonUnit {
+					sum /= a[i*lda+i]
+				}
+				b[i*ldb] = sum
+			}
+			return
+		}
+		for i := 0; i < m; i++ {
+			sum := alpha * b[i*ldb]
+			for j, v := range a[i*lda : i*lda+i] {
+				sum -= v * b[j*ldb]
+			}
+			if nonUnit {
+				sum /= a[i*lda+i]
+			}
+			b[i*ldb] = sum
+		}
+		return
+	}
+	if ul == blas.Upper {
+		for i := 0; i < m; i++ {
+			sum := alpha * b[i*ldb]
+			for j := 0; j < i; j++ {
+				sum -= a[j*lda+i] * b[j*ldb]
+			}
+			if nonUnit {
+				sum /= a[i*lda+i]
+			}
+			b[i*ldb] = sum
+		}
+		return
+	}
+	for i := m - 1; i >= 0; i-- {
+		sum := alpha * b[i*ldb]
+		for j := i + 1; j < m; j++ {
+			sum -= a[j*lda+i] * b[j*ldb]
+		}
+		if nonUnit {
+			sum /= a[i*lda+i]
+		}
+		b[i*ldb] = sum
+	}
+}
+
+// checkDBatch checks that a holds count m×n matrices with row stride lda,
+// consecutive matrices beginning stride elements apart. If out is true, the
+// matrices are written to and so must not overlap.
+func checkDBatch(name byte, m, n int, a []float64, lda, stride, count int, out bool) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if lda < max(1, n) {
+		panic("blas: illegal stride of " + string(name))
+	}
+	if stride < 0 {
+		panic("blas: illegal batch stride of " + string(name))
+	}
+	if count == 0 || m == 0 || n == 0 {
+		return
+	}
+	size := (m-1)*lda + n
+	if out && count > 1 && stride < size {
+		panic("blas: overlapping batch matrices in " + string(name))
+	}
+	if len(a) < (count-1)*stride+size {
+		panic("blas: index of " + string(name) + " out of range")
+	}
+}
+
+// parallelBatch calls fn on contiguous subranges covering [0, count),
+// using as many goroutines as is worthwhile given the approximate number
+// of floating point operations needed for each element of the batch.
+func parallelBatch(count, work int, fn func(lo, hi int)) {
+	nWorkers := runtime.GOMAXPROCS(0)
+	if w := count * work / minParBatchWork; w < nWorkers {
+		nWorkers = w
+	}
+	if nWorkers > count {
+		nWorkers = count
+	}
+	if nWorkers <= 1 {
+		fn(0, count)
+		return
+	}
+	chunk := (count + nWorkers - 1) / nWorkers
+	var wg sync.WaitGroup
+	for lo := 0; lo < count; lo += chunk {
+		hi := min(lo+chunk, count)
+		wg.Add(1)
+		go func(lo, hi int) {
+			defer wg.Done()
+			fn(lo, hi)
+		}(lo, hi)
+	}
+	wg.Wait()
+}
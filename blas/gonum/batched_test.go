@@ -0,0 +1,312 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+// batchGuard is the value stored in the elements of a batch that lie
+// outside the matrices.
+const batchGuard = -999
+
+// randBatch returns a slice holding count r×c matrices with row stride ld
+// and batch stride stride. Elements outside the matrices are set to batchGuard.
+func randBatch(r, c, ld, stride, count int, rnd *rand.Rand) []float64 {
+	s := make([]float64, max(0, (count-1)*stride+r*ld))
+	for i := range s {
+		s[i] = batchGuard
+	}
+	for l := 0; l < count; l++ {
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				s[l*stride+i*ld+j] = rnd.NormFloat64()
+			}
+		}
+	}
+	return s
+}
+
+func TestDgemmBatched(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tB := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				m, n, k int
+			}{
+				{0, 3, 3}, {3, 0, 3}, {3, 3, 0},
+				{1, 1, 1}, {2, 2, 2}, {3, 3, 3}, {4, 4, 4}, {5, 5, 5},
+				{2, 3, 4}, {4, 2, 3}, {3, 4, 2}, {16, 16, 16},
+				{blockSize*minParBlock + 1, 3, 2},
+			} {
+				for _, count := range []int{0, 1, 3, 200} {
+					for _, shared := range []bool{false, true} {
+						for _, ab := range [][2]float64{{1, 0}, {2.5, 0}, {0, 0.5}, {-1.5, 2}, {1, 1}} {
+							testDgemmBatched(t, tA, tB, test.m, test.n, test.k, count, shared, ab[0], ab[1], rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgemmBatched(t *testing.T, tA, tB blas.Transpose, m, n, k, count int, shared bool, alpha, beta float64, rnd *rand.Rand) {
+	if count > 3 && m > blockSize {
+		return
+	}
+	ar, ac := m, k
+	if tA != blas.NoTrans {
+		ar, ac = k, m
+	}
+	br, bc := k, n
+	if tB != blas.NoTrans {
+		br, bc = n, k
+	}
+	lda := ac + 1
+	ldb := bc + 2
+	ldc := n + 3
+	strideA := ar*lda + 5
+	strideB := br*ldb + 1
+	strideC := m*ldc + 7
+	if shared {
+		strideA = 0
+		strideB = 0
+	}
+	a := randBatch(ar, ac, lda, strideA, count, rnd)
+	b := randBatch(br, bc, ldb, strideB, count, rnd)
+	c := randBatch(m, n, ldc, strideC, count, rnd)
+	if beta == 0 {
+		// C must not be read when beta is zero.
+		for l := 0; l < count; l++ {
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					c[l*strideC+i*ldc+j] = math.NaN()
+				}
+			}
+		}
+	}
+	want := make([]float64, len(c))
+	copy(want, c)
+	for l := 0; l < count; l++ {
+		if m == 0 || n == 0 {
+			break
+		}
+		if beta == 0 {
+			for i := 0; i < m; i++ {
+				for j := 0; j < n; j++ {
+					want[l*strideC+i*ldc+j] = 0
+				}
+			}
+		}
+		impl.Dgemm(tA, tB, m, n, k, alpha, a[l*strideA:], lda, b[l*strideB:], ldb, beta, want[l*strideC:], ldc)
+	}
+
+	impl.DgemmBatched(tA, tB, m, n, k, alpha, a, lda, strideA, b, ldb, strideB, beta, c, ldc, strideC, count)
+
+	name := fmt.Sprintf("tA=%v,tB=%v,m=%d,n=%d,k=%d,count=%d,shared=%t,alpha=%v,beta=%v", tA, tB, m, n, k, count, shared, alpha, beta)
+	if !floats.EqualApprox(c, want, 1e-13) {
+		t.Errorf("unexpected result for %s", name)
+	}
+}
+
+func TestDgemmBatchedAlphaZero(t *testing.T) {
+	// When alpha is zero, A and B must not be referenced, so NaN values
+	// in them must not reach C.
+	for _, n := range []int{1, 2, 3, 4, 5} {
+		for _, beta := range []float64{0, 0.5, 1} {
+			const count = 3
+			a := make([]float64, count*n*n)
+			b := make([]float64, count*n*n)
+			for i := range a {
+				a[i] = math.NaN()
+				b[i] = math.Inf(1)
+			}
+			c := make([]float64, count*n*n)
+			for i := range c {
+				c[i] = float64(i + 1)
+			}
+			want := make([]float64, len(c))
+			for i, v := range c {
+				want[i] = beta * v
+			}
+			Implementation{}.DgemmBatched(blas.NoTrans, blas.NoTrans, n, n, n, 0, a, n, n*n, b, n, n*n, beta, c, n, n*n, count)
+			if !floats.Equal(c, want) {
+				t.Errorf("unexpected result for n=%d beta=%v: got %v want %v", n, beta, c, want)
+			}
+		}
+	}
+}
+
+func TestDtrsmBatchedAlphaZero(t *testing.T) {
+	// When alpha is zero, A must not be referenced and B must be set to
+	// zero even though A is singular and A and B contain NaN values.
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{1, 3} {
+				const (
+					count = 3
+					m     = 4
+				)
+				k := n
+				if s == blas.Left {
+					k = m
+				}
+				a := make([]float64, count*k*k)
+				for i := range a {
+					a[i] = math.NaN()
+				}
+				for l := 0; l < count; l++ {
+					a[l*k*k] = 0
+				}
+				b := make([]float64, count*m*n)
+				for i := range b {
+					b[i] = float64(i + 1)
+				}
+				b[0] = math.Inf(1)
+				b[len(b)-1] = math.NaN()
+				Implementation{}.DtrsmBatched(s, ul, blas.NoTrans, blas.NonUnit, m, n, 0, a, k, k*k, b, n, m*n, count)
+				for i, v := range b {
+					if v != 0 {
+						t.Errorf("unexpected result for side=%v uplo=%v n=%d: b[%d]=%v want 0", s, ul, n, i, v)
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestDtrsmBatched(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, s := range []blas.Side{blas.Left, blas.Right} {
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+					for _, test := range []struct {
+						m, n int
+					}{
+						{0, 3}, {3, 0}, {1, 1}, {3, 1}, {4, 4}, {5, 3}, {3, 5}, {16, 1}, {16, 16},
+					} {
+						for _, count := range []int{0, 1, 3, 200} {
+							for _, shared := range []bool{false, true} {
+								for _, alpha := range []float64{0, 1, -2.5} {
+									testDtrsmBatched(t, s, ul, tA, d, test.m, test.n, count, shared, alpha, rnd)
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtrsmBatched(t *testing.T, s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n, count int, shared bool, alpha float64, rnd *rand.Rand) {
+	k := n
+	if s == blas.Left {
+		k = m
+	}
+	lda := k + 2
+	ldb := n + 1
+	strideA := k*lda + 3
+	strideB := m*ldb + 4
+	if shared {
+		strideA = 0
+	}
+	a := randBatch(k, k, lda, strideA, count, rnd)
+	// Make the triangular matrices well conditioned.
+	for l := 0; l < count; l++ {
+		for i := 0; i < k; i++ {
+			a[l*strideA+i*lda+i] += math.Copysign(float64(k), a[l*strideA+i*lda+i])
+		}
+	}
+	b := randBatch(m, n, ldb, strideB, count, rnd)
+	want := make([]float64, len(b))
+	copy(want, b)
+	for l := 0; l < count; l++ {
+		impl.Dtrsm(s, ul, tA, d, m, n, alpha, a[l*strideA:], lda, want[l*strideB:], ldb)
+	}
+
+	impl.DtrsmBatched(s, ul, tA, d, m, n, alpha, a, lda, strideA, b, ldb, strideB, count)
+
+	name := fmt.Sprintf("s=%v,ul=%v,tA=%v,d=%v,m=%d,n=%d,count=%d,shared=%t,alpha=%v", s, ul, tA, d, m, n, count, shared, alpha)
+	if !floats.EqualApprox(b, want, 1e-12) {
+		t.Errorf("unexpected result for %s", name)
+	}
+}
+
+func TestBatchedPanics(t *testing.T) {
+	a := make([]float64, 20)
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{
+			name: "negative batch count",
+			fn: func() {
+				impl.DgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, 4, a, 2, 4, 0, a, 2, 4, -1)
+			},
+		},
+		{
+			name: "short batch",
+			fn: func() {
+				impl.DgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, 4, a, 2, 4, 0, a, 2, 4, 6)
+			},
+		},
+		{
+			name: "overlapping output",
+			fn: func() {
+				impl.DgemmBatched(blas.NoTrans, blas.NoTrans, 2, 2, 2, 1, a, 2, 0, a, 2, 0, 0, a, 2, 3, 2)
+			},
+		},
+		{
+			name: "negative stride",
+			fn: func() {
+				impl.DtrsmBatched(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, 2, 2, 1, a, 2, -4, a, 2, 4, 2)
+			},
+		},
+	} {
+		if !panics(test.fn) {
+			t.Errorf("%s: expected panic", test.name)
+		}
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return
+}
+
+func BenchmarkDgemmBatched(b *testing.B) {
+	for _, n := range []int{3, 4, 8, 16} {
+		const count = 10000
+		rnd := rand.New(rand.NewSource(1))
+		x := randBatch(n, n, n, n*n, count, rnd)
+		y := randBatch(n, n, n, n*n, count, rnd)
+		z := make([]float64, count*n*n)
+		b.Run(fmt.Sprintf("Batched%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				impl.DgemmBatched(blas.NoTrans, blas.NoTrans, n, n, n, 1, x, n, n*n, y, n, n*n, 0, z, n, n*n, count)
+			}
+		})
+		b.Run(fmt.Sprintf("Loop%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for l := 0; l < count; l++ {
+					impl.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, x[l*n*n:], n, y[l*n*n:], n, 0, z[l*n*n:], n)
+				}
+			}
+		})
+	}
+}
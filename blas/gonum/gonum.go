@@ -24,6 +24,8 @@ const (
 	kLLT0 = "blas: kL < 0"
 	kULT0 = "blas: kU < 0"
 
+	batchLT0 = "blas: batchCount < 0"
+
 	badUplo      = "blas: illegal triangle"
 	badTranspose = "blas: illegal transpose"
 	badDiag      = "blas: illegal diagonal"
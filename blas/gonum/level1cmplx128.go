@@ -4,11 +4,7 @@
 
 package gonum
 
-import (
-	"math"
-
-	"gonum.org/v1/gonum/internal/asm/c128"
-)
+import "gonum.org/v1/gonum/internal/asm/c128"
 
 // Dzasum returns the sum of the absolute values of the elements of x
 //  \sum_i |Re(x[i])| + |Im(x[i])|
@@ -23,24 +19,16 @@ func (Implementation) Dzasum(n int, x []complex128, incX int) float64 {
 		}
 		return 0
 	}
-	var sum float64
 	if incX == 1 {
 		if len(x) < n {
 			panic(badX)
 		}
-		for _, v := range x[:n] {
-			sum += dcabs1(v)
-		}
-		return sum
+		return c128.L1Norm(x[:n])
 	}
 	if (n-1)*incX >= len(x) {
 		panic(badX)
 	}
-	for i := 0; i < n; i++ {
-		v := x[i*incX]
-		sum += dcabs1(v)
-	}
-	return sum
+	return c128.L1NormInc(x, n, incX)
 }
 
 // Dznrm2 computes the Euclidean norm of the complex vector x,
@@ -62,58 +50,10 @@ func (Implementation) Dznrm2(n int, x []complex128, incX int) float64 {
 	if (n-1)*incX >= len(x) {
 		panic(badX)
 	}
-	var (
-		scale float64
-		ssq   float64 = 1
-	)
 	if incX == 1 {
-		for _, v := range x[:n] {
-			re, im := math.Abs(real(v)), math.Abs(imag(v))
-			if re != 0 {
-				if re > scale {
-					ssq = 1 + ssq*(scale/re)*(scale/re)
-					scale = re
-				} else {
-					ssq += (re / scale) * (re / scale)
-				}
-			}
-			if im != 0 {
-				if im > scale {
-					ssq = 1 + ssq*(scale/im)*(scale/im)
-					scale = im
-				} else {
-					ssq += (im / scale) * (im / scale)
-				}
-			}
-		}
-		if math.IsInf(scale, 1) {
-			return math.Inf(1)
-		}
-		return scale * math.Sqrt(ssq)
-	}
-	for ix := 0; ix < n*incX; ix += incX {
-		re, im := math.Abs(real(x[ix])), math.Abs(imag(x[ix]))
-		if re != 0 {
-			if re > scale {
-				ssq = 1 + ssq*(scale/re)*(scale/re)
-				scale = re
-			} else {
-				ssq += (re / scale) * (re / scale)
-			}
-		}
-		if im != 0 {
-			if im > scale {
-				ssq = 1 + ssq*(scale/im)*(scale/im)
-				scale = im
-			} else {
-				ssq += (im / scale) * (im / scale)
-			}
-		}
-	}
-	if math.IsInf(scale, 1) {
-		return math.Inf(1)
+		return c128.L2NormUnitary(x[:n])
 	}
-	return scale * math.Sqrt(ssq)
+	return c128.L2NormInc(x, n, incX)
 }
 
 // Izamax returns the index of the first element of x having largest |Re(·)|+|Im(·)|.
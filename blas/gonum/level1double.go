@@ -89,7 +89,6 @@ func (Implementation) Dnrm2(n int, x []float64, incX int) float64 {
 //  \sum_i |x[i]|
 // Dasum returns 0 if incX is negative.
 func (Implementation) Dasum(n int, x []float64, incX int) float64 {
-	var sum float64
 	if n < 0 {
 		panic(negativeN)
 	}
@@ -103,16 +102,9 @@ func (Implementation) Dasum(n int, x []float64, incX int) float64 {
 		panic(badX)
 	}
 	if incX == 1 {
-		x = x[:n]
-		for _, v := range x {
-			sum += math.Abs(v)
-		}
-		return sum
-	}
-	for i := 0; i < n; i++ {
-		sum += math.Abs(x[i*incX])
+		return f64.L1Norm(x[:n])
 	}
-	return sum
+	return f64.L1NormInc(x, n, incX)
 }
 
 // Idamax returns the index of an element of x with the largest absolute value.
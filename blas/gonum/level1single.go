@@ -95,7 +95,6 @@ func (Implementation) Snrm2(n int, x []float32, incX int) float32 {
 //
 // Float32 implementations are autogenerated and not directly tested.
 func (Implementation) Sasum(n int, x []float32, incX int) float32 {
-	var sum float32
 	if n < 0 {
 		panic(negativeN)
 	}
@@ -109,16 +108,9 @@ func (Implementation) Sasum(n int, x []float32, incX int) float32 {
 		panic(badX)
 	}
 	if incX == 1 {
-		x = x[:n]
-		for _, v := range x {
-			sum += math.Abs(v)
-		}
-		return sum
-	}
-	for i := 0; i < n; i++ {
-		sum += math.Abs(x[i*incX])
+		return f32.L1Norm(x[:n])
 	}
-	return sum
+	return f32.L1NormInc(x, n, incX)
 }
 
 // Isamax returns the index of an element of x with the largest absolute value.
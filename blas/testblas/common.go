@@ -147,6 +147,24 @@ func unflatten(a []float64, m, n int) [][]float64 {
 	return s
 }
 
+// flattenStride turns a dense slice of slice into a single slice with rows
+// ld elements apart, filling the elements between the rows with pad.
+func flattenStride(a [][]float64, ld int, pad float64) []float64 {
+	if len(a) == 0 {
+		return nil
+	}
+	m := len(a)
+	n := len(a[0])
+	s := make([]float64, (m-1)*ld+n)
+	for i := range s {
+		s[i] = pad
+	}
+	for i := 0; i < m; i++ {
+		copy(s[i*ld:i*ld+n], a[i])
+	}
+	return s
+}
+
 // flattenTriangular turns the upper or lower triangle of a dense slice of slice
 // into a single slice with packed storage. a must be a square matrix.
 func flattenTriangular(a [][]float64, ul blas.Uplo) []float64 {
@@ -807,5 +807,29 @@ func DtrsmTest(t *testing.T, blasser Dtrsmer) {
 		if !floats.EqualApprox(ansFlat, bFlat, 1e-13) {
 			t.Errorf("Case %v: Want %v, got %v.", i, ansFlat, bFlat)
 		}
+
+		// Repeat with padded storage so that lda and ldb differ from each
+		// other and from the number of columns.
+		const pad = -1000
+		ldaPad := lda + 2
+		ldbPad := test.n + 5
+		aPad := flattenStride(test.a, ldaPad, pad)
+		bPad := flattenStride(test.b, ldbPad, pad)
+		blasser.Dtrsm(test.s, test.ul, test.tA, test.d, test.m, test.n, test.alpha, aPad, ldaPad, bPad, ldbPad)
+		for r := 0; r < test.m; r++ {
+			row := bPad[r*ldbPad : r*ldbPad+test.n]
+			if !floats.EqualApprox(test.ans[r], row, 1e-13) {
+				t.Errorf("Case %v with lda=%v ldb=%v: row %v: Want %v, got %v.", i, ldaPad, ldbPad, r, test.ans[r], row)
+			}
+			if r == test.m-1 {
+				break
+			}
+			for _, v := range bPad[r*ldbPad+test.n : (r+1)*ldbPad] {
+				if v != pad {
+					t.Errorf("Case %v with lda=%v ldb=%v: padding of b modified after row %v", i, ldaPad, ldbPad, r)
+					break
+				}
+			}
+		}
 	}
 }
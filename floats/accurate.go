@@ -0,0 +1,362 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package floats
+
+import "math"
+
+// In the error bounds below, u = 2^-53 is the unit roundoff of float64,
+// γ_k = k*u/(1-k*u) and n is the length of the input.
+
+// pairwiseBlock is the length below which pairwise reductions use
+// recursive summation.
+const pairwiseBlock = 128
+
+// SumCompensated returns the sum of the elements of s computed with
+// Neumaier's improvement of Kahan compensated summation. The computed sum ŝ
+// satisfies
+//  |ŝ - Σ s[i]| <= 2*u*|Σ s[i]| + 2*n*u^2 * Σ |s[i]|,
+// so the result is accurate to nearly full precision unless the sum is
+// extremely ill-conditioned.
+func SumCompensated(s []float64) float64 {
+	var sum, c float64
+	for _, v := range s {
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			c += (sum - t) + v
+		} else {
+			c += (v - t) + sum
+		}
+		sum = t
+	}
+	if math.IsInf(sum, 0) || math.IsNaN(sum) {
+		return sum
+	}
+	return sum + c
+}
+
+// SumPairwise returns the sum of the elements of s computed by pairwise
+// summation. The computed sum ŝ satisfies
+//  |ŝ - Σ s[i]| <= γ_(b-1+⌈log2(n/b)⌉) * Σ |s[i]|
+// where b = 128 is the length of the blocks that are summed recursively.
+// SumPairwise is nearly as fast as Sum.
+func SumPairwise(s []float64) float64 {
+	if len(s) <= pairwiseBlock {
+		var sum float64
+		for _, v := range s {
+			sum += v
+		}
+		return sum
+	}
+	m := len(s) / 2
+	return SumPairwise(s[:m]) + SumPairwise(s[m:])
+}
+
+// CumSumCompensated finds the cumulative sum of the first i elements in s
+// using Neumaier compensated summation and puts them in place into the ith
+// element of the destination dst. Each element of dst satisfies the error
+// bound given for SumCompensated. A panic will occur if the lengths of
+// arguments do not match.
+func CumSumCompensated(dst, s []float64) []float64 {
+	if len(dst) != len(s) {
+		panic("floats: length of destination does not match length of the source")
+	}
+	var sum, c float64
+	for i, v := range s {
+		t := sum + v
+		if math.Abs(sum) >= math.Abs(v) {
+			c += (sum - t) + v
+		} else {
+			c += (v - t) + sum
+		}
+		sum = t
+		if math.IsInf(sum, 0) || math.IsNaN(sum) {
+			dst[i] = sum
+		} else {
+			dst[i] = sum + c
+		}
+	}
+	return dst
+}
+
+// CumSumPairwise finds the cumulative sum of the first i elements in s
+// using pairwise summation and puts them in place into the ith element of
+// the destination dst. Each element of dst satisfies the error bound given
+// for SumPairwise, with s replaced by the elements summed into it, and the
+// last element is equal to SumPairwise(s). CumSumPairwise takes
+// O(n*log(n/b)) time. A panic will occur if the lengths of arguments do
+// not match.
+func CumSumPairwise(dst, s []float64) []float64 {
+	if len(dst) != len(s) {
+		panic("floats: length of destination does not match length of the source")
+	}
+	cumSumPairwise(dst, s)
+	return dst
+}
+
+func cumSumPairwise(dst, s []float64) {
+	if len(s) <= pairwiseBlock {
+		var sum float64
+		for i, v := range s {
+			sum += v
+			dst[i] = sum
+		}
+		return
+	}
+	m := len(s) / 2
+	cumSumPairwise(dst[:m], s[:m])
+	cumSumPairwise(dst[m:], s[m:])
+	offset := dst[m-1]
+	for i := m; i < len(dst); i++ {
+		dst[i] += offset
+	}
+}
+
+// DotCompensated computes the dot product of s1 and s2 using the compensated
+// algorithm Dot2 of Ogita, Rump and Oishi, in which the products are formed
+// exactly and their sum is accumulated with compensation. The computed
+// result d̂ satisfies
+//  |d̂ - Σ s1[i]*s2[i]| <= u*|Σ s1[i]*s2[i]| + γ_n^2 * Σ |s1[i]*s2[i]|,
+// so the result is as accurate as if it were computed in twice the working
+// precision and then rounded. The bound does not hold if any product
+// overflows. A panic will occur if lengths of arguments do not match.
+func DotCompensated(s1, s2 []float64) float64 {
+	if len(s1) != len(s2) {
+		panic("floats: lengths of the slices do not match")
+	}
+	var p, c float64
+	for i, v := range s1 {
+		h, r := twoProd(v, s2[i])
+		var q float64
+		p, q = twoSum(p, h)
+		c += q + r
+	}
+	if math.IsInf(p, 0) || math.IsNaN(p) {
+		return p
+	}
+	return p + c
+}
+
+// DotPairwise computes the dot product of s1 and s2 by pairwise summation of
+// the products. The computed result d̂ satisfies
+//  |d̂ - Σ s1[i]*s2[i]| <= γ_(b+⌈log2(n/b)⌉) * Σ |s1[i]*s2[i]|
+// where b = 128 is the length of the blocks that are summed recursively.
+// A panic will occur if lengths of arguments do not match.
+func DotPairwise(s1, s2 []float64) float64 {
+	if len(s1) != len(s2) {
+		panic("floats: lengths of the slices do not match")
+	}
+	return dotPairwise(s1, s2)
+}
+
+func dotPairwise(s1, s2 []float64) float64 {
+	if len(s1) <= pairwiseBlock {
+		var sum float64
+		for i, v := range s1 {
+			sum += v * s2[i]
+		}
+		return sum
+	}
+	m := len(s1) / 2
+	return dotPairwise(s1[:m], s2[:m]) + dotPairwise(s1[m:], s2[m:])
+}
+
+// NormCompensated returns the L2 norm of s. The elements are scaled by a
+// power of two to avoid overflow and underflow, and the sum of squares is
+// computed with the compensated algorithm of DotCompensated, so the result
+// has a relative error of at most about 2*u for any input that does not
+// underflow after scaling. NormCompensated returns NaN if s contains a NaN
+// and +Inf if it contains an infinity but no NaN.
+func NormCompensated(s []float64) float64 {
+	exp, norm, ok := normScale(s)
+	if !ok {
+		return norm
+	}
+	var p, c float64
+	for _, v := range s {
+		v = math.Ldexp(v, -exp)
+		h, r := twoProd(v, v)
+		var q float64
+		p, q = twoSum(p, h)
+		c += q + r
+	}
+	return math.Ldexp(math.Sqrt(p+c), exp)
+}
+
+// NormPairwise returns the L2 norm of s. The elements are scaled by a power
+// of two to avoid overflow and underflow, and the sum of squares is computed
+// by pairwise summation, so the result has a relative error of at most about
+//  γ_(b+⌈log2(n/b)⌉)/2 + u
+// where b = 128 is the length of the blocks that are summed recursively, for
+// any input that does not underflow after scaling. NormPairwise returns NaN
+// if s contains a NaN and +Inf if it contains an infinity but no NaN.
+func NormPairwise(s []float64) float64 {
+	exp, norm, ok := normScale(s)
+	if !ok {
+		return norm
+	}
+	return math.Ldexp(math.Sqrt(sumSquaresPairwise(s, exp)), exp)
+}
+
+func sumSquaresPairwise(s []float64, exp int) float64 {
+	if len(s) <= pairwiseBlock {
+		var sum float64
+		for _, v := range s {
+			v = math.Ldexp(v, -exp)
+			sum += v * v
+		}
+		return sum
+	}
+	m := len(s) / 2
+	return sumSquaresPairwise(s[:m], exp) + sumSquaresPairwise(s[m:], exp)
+}
+
+// normScale returns the binary exponent exp such that the largest element
+// of s scaled by 2^-exp is in [0.5, 1). If the L2 norm of s is zero,
+// infinite or NaN, normScale returns it as norm with ok false.
+func normScale(s []float64) (exp int, norm float64, ok bool) {
+	var amax float64
+	var inf bool
+	for _, v := range s {
+		if math.IsNaN(v) {
+			return 0, math.NaN(), false
+		}
+		if math.IsInf(v, 0) {
+			inf = true
+		}
+		amax = math.Max(amax, math.Abs(v))
+	}
+	if inf {
+		return 0, math.Inf(1), false
+	}
+	if amax == 0 {
+		return 0, 0, false
+	}
+	_, exp = math.Frexp(amax)
+	return exp, 0, true
+}
+
+// Accumulator is a double-double accumulator. It represents its running
+// total as the unevaluated sum of two float64 values, giving about 106 bits
+// of precision. Adding n values x[i] to an Accumulator gives a total whose
+// rounded Value v satisfies
+//  |v - Σ x[i]| <= u*|Σ x[i]| + 2*n*u^2 * Σ |x[i]|,
+// and the same bound holds for sums of products added with AddProduct, with
+// x[i] replaced by the products.
+//
+// The zero value of Accumulator is a zero total and is ready to use.
+type Accumulator struct {
+	hi, lo float64
+}
+
+// Add adds v to the total.
+func (a *Accumulator) Add(v float64) {
+	s, e := twoSum(a.hi, v)
+	if math.IsInf(s, 0) || math.IsNaN(s) {
+		a.hi, a.lo = s, 0
+		return
+	}
+	a.hi, a.lo = fastTwoSum(s, e+a.lo)
+}
+
+// AddProduct adds the exact product x*y to the total. The result is not
+// exact if the product overflows or its low order part underflows.
+func (a *Accumulator) AddProduct(x, y float64) {
+	p, ep := twoProd(x, y)
+	s, e := twoSum(a.hi, p)
+	if math.IsInf(s, 0) || math.IsNaN(s) {
+		a.hi, a.lo = s, 0
+		return
+	}
+	t, f := twoSum(a.lo, ep)
+	s, e = fastTwoSum(s, e+t)
+	a.hi, a.lo = fastTwoSum(s, e+f)
+}
+
+// AddSlice adds the elements of s to the total.
+func (a *Accumulator) AddSlice(s []float64) {
+	for _, v := range s {
+		a.Add(v)
+	}
+}
+
+// AddDot adds the dot product of s1 and s2 to the total. A panic will occur
+// if lengths of arguments do not match.
+func (a *Accumulator) AddDot(s1, s2 []float64) {
+	if len(s1) != len(s2) {
+		panic("floats: lengths of the slices do not match")
+	}
+	for i, v := range s1 {
+		a.AddProduct(v, s2[i])
+	}
+}
+
+// Value returns the total rounded to a float64.
+func (a *Accumulator) Value() float64 {
+	return a.hi + a.lo
+}
+
+// DoubleDouble returns the total as the unevaluated sum hi + lo with
+// |lo| <= ulp(hi)/2.
+func (a *Accumulator) DoubleDouble() (hi, lo float64) {
+	return a.hi, a.lo
+}
+
+// Reset sets the total to zero.
+func (a *Accumulator) Reset() {
+	a.hi, a.lo = 0, 0
+}
+
+// twoSum returns s = fl(a+b) and the rounding error e such that
+// s + e = a + b exactly.
+func twoSum(a, b float64) (s, e float64) {
+	s = a + b
+	bb := s - a
+	e = (a - (s - bb)) + (b - bb)
+	return s, e
+}
+
+// fastTwoSum returns s = fl(a+b) and the rounding error e such that
+// s + e = a + b exactly, provided that |a| >= |b| or a is zero.
+func fastTwoSum(a, b float64) (s, e float64) {
+	s = a + b
+	e = b - (s - a)
+	return s, e
+}
+
+// twoProd returns p = fl(a*b) and the rounding error e such that
+// p + e = a * b exactly, provided that no overflow or underflow occurs.
+// It uses Dekker's algorithm with Veltkamp splitting. The explicit
+// conversions prevent the products from being fused with the additions.
+func twoProd(a, b float64) (p, e float64) {
+	p = a * b
+	// Splitting a factor larger than about 2^996 overflows, so scale it
+	// down by a power of two and the other factor up by the same amount.
+	// This leaves the product unchanged and is exact unless the product
+	// overflows anyway.
+	const (
+		large = 1e299
+		scale = 1 << 28
+	)
+	switch {
+	case math.Abs(a) > large:
+		a, b = a/scale, b*scale
+	case math.Abs(b) > large:
+		a, b = a*scale, b/scale
+	}
+	ah, al := split(a)
+	bh, bl := split(b)
+	e = ((float64(ah*bh) - p) + float64(ah*bl) + float64(al*bh)) + float64(al*bl)
+	return p, e
+}
+
+// split splits a into two non-overlapping halves of 26 significant bits
+// such that hi + lo = a.
+func split(a float64) (hi, lo float64) {
+	const splitter = 1<<27 + 1
+	c := float64(splitter * a)
+	hi = c - (c - a)
+	lo = a - hi
+	return hi, lo
+}
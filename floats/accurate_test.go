@@ -0,0 +1,410 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package floats
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+const unitRoundoff = 1.0 / (1 << 53)
+
+// exactSum returns the exact sum of the elements of s.
+func exactSum(s []float64) *big.Rat {
+	sum := new(big.Rat)
+	var v big.Rat
+	for _, x := range s {
+		sum.Add(sum, v.SetFloat64(x))
+	}
+	return sum
+}
+
+// exactDot returns the exact dot product of s1 and s2.
+func exactDot(s1, s2 []float64) *big.Rat {
+	sum := new(big.Rat)
+	var a, b big.Rat
+	for i, x := range s1 {
+		a.SetFloat64(x)
+		sum.Add(sum, a.Mul(&a, b.SetFloat64(s2[i])))
+	}
+	return sum
+}
+
+// absErr returns |x - exact| as a float64.
+func absErr(x float64, exact *big.Rat) float64 {
+	var d big.Rat
+	d.SetFloat64(x)
+	d.Sub(&d, exact)
+	f, _ := d.Float64()
+	return math.Abs(f)
+}
+
+func ratFloat(r *big.Rat) float64 {
+	f, _ := r.Float64()
+	return f
+}
+
+// illConditioned returns a random slice of length n with elements spanning
+// many orders of magnitude whose sum suffers from heavy cancellation.
+func illConditioned(rnd *rand.Rand, n int) []float64 {
+	s := make([]float64, 0, n)
+	for len(s) < n-1 {
+		v := math.Ldexp(rnd.Float64()+0.5, rnd.Intn(80)-40)
+		s = append(s, v, -v*(1+math.Ldexp(rnd.NormFloat64(), -40)))
+	}
+	for len(s) < n {
+		s = append(s, rnd.NormFloat64())
+	}
+	shuffled := make([]float64, n)
+	for i, j := range rnd.Perm(n) {
+		shuffled[i] = s[j]
+	}
+	return shuffled
+}
+
+// gamma returns γ_k.
+func gamma(k int) float64 {
+	ku := float64(k) * unitRoundoff
+	return ku / (1 - ku)
+}
+
+func ceilLog2(x float64) int {
+	if x <= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log2(x)))
+}
+
+func TestSumCompensated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 10, 100, 1000, 10000} {
+		for trial := 0; trial < 5; trial++ {
+			s := illConditioned(rnd, n)
+			exact := exactSum(s)
+			absSum := SumCompensated(absSlice(s))
+			sum := math.Abs(ratFloat(exact))
+
+			bound := 2*unitRoundoff*sum + 2*float64(n)*unitRoundoff*unitRoundoff*absSum
+			if err := absErr(SumCompensated(s), exact); err > bound {
+				t.Errorf("SumCompensated n=%d: error %v exceeds bound %v", n, err, bound)
+			}
+			dst := make([]float64, n)
+			CumSumCompensated(dst, s)
+			for i := range dst {
+				partial := exactSum(s[:i+1])
+				bound := 2*unitRoundoff*math.Abs(ratFloat(partial)) + 2*float64(i+1)*unitRoundoff*unitRoundoff*absSum
+				if err := absErr(dst[i], partial); err > bound {
+					t.Errorf("CumSumCompensated n=%d index %d: error %v exceeds bound %v", n, i, err, bound)
+					break
+				}
+				if n > 100 {
+					// Checking every prefix is slow.
+					break
+				}
+			}
+			if n > 0 && absErr(dst[n-1], exact) > bound {
+				t.Errorf("CumSumCompensated n=%d: final error exceeds bound", n)
+			}
+
+			b := pairwiseBlock
+			pbound := gamma(b-1+ceilLog2(float64(n)/float64(b))) * absSum
+			if err := absErr(SumPairwise(s), exact); err > pbound {
+				t.Errorf("SumPairwise n=%d: error %v exceeds bound %v", n, err, pbound)
+			}
+			CumSumPairwise(dst, s)
+			partial := new(big.Rat)
+			var v big.Rat
+			var absPartial float64
+			for i := range dst {
+				partial.Add(partial, v.SetFloat64(s[i]))
+				absPartial += math.Abs(s[i])
+				pbound := gamma(b-1+ceilLog2(float64(i+1)/float64(b))) * absPartial
+				if err := absErr(dst[i], partial); err > pbound {
+					t.Errorf("CumSumPairwise n=%d index %d: error %v exceeds bound %v", n, i, err, pbound)
+					break
+				}
+			}
+			if n > 0 && dst[n-1] != SumPairwise(s) {
+				t.Errorf("CumSumPairwise n=%d: final element %v not equal to SumPairwise %v", n, dst[n-1], SumPairwise(s))
+			}
+		}
+	}
+}
+
+func TestSumCompensatedImproves(t *testing.T) {
+	// The naive sum of these values is zero.
+	s := []float64{1, 1e100, 1, -1e100}
+	if got := SumCompensated(s); got != 2 {
+		t.Errorf("unexpected compensated sum: got %v want 2", got)
+	}
+	if got := Sum(s); got == 2 {
+		t.Errorf("naive sum unexpectedly exact")
+	}
+	var acc Accumulator
+	acc.AddSlice(s)
+	if got := acc.Value(); got != 2 {
+		t.Errorf("unexpected accumulated sum: got %v want 2", got)
+	}
+}
+
+func TestDotCompensated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 10, 100, 1000, 10000} {
+		for trial := 0; trial < 5; trial++ {
+			x := illConditioned(rnd, n)
+			y := make([]float64, n)
+			for i := range y {
+				y[i] = 1 + math.Ldexp(rnd.NormFloat64(), -30)
+			}
+			exact := exactDot(x, y)
+			prod := make([]float64, n)
+			for i := range prod {
+				prod[i] = math.Abs(x[i] * y[i])
+			}
+			absDot := SumCompensated(prod)
+			dot := math.Abs(ratFloat(exact))
+			g := gamma(n)
+
+			bound := unitRoundoff*dot + g*g*absDot
+			if err := absErr(DotCompensated(x, y), exact); err > bound {
+				t.Errorf("DotCompensated n=%d: error %v exceeds bound %v", n, err, bound)
+			}
+
+			b := pairwiseBlock
+			pbound := gamma(b+ceilLog2(float64(n)/float64(b))) * absDot
+			if err := absErr(DotPairwise(x, y), exact); err > pbound {
+				t.Errorf("DotPairwise n=%d: error %v exceeds bound %v", n, err, pbound)
+			}
+		}
+	}
+}
+
+func TestAccumulator(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 10, 1000, 10000} {
+		x := illConditioned(rnd, n)
+		y := illConditioned(rnd, n)
+		u2 := unitRoundoff * unitRoundoff
+
+		var acc Accumulator
+		acc.AddSlice(x)
+		exact := exactSum(x)
+		bound := unitRoundoff*math.Abs(ratFloat(exact)) + 2*float64(n)*u2*SumCompensated(absSlice(x))
+		if err := absErr(acc.Value(), exact); err > bound {
+			t.Errorf("Accumulator sum n=%d: error %v exceeds bound %v", n, err, bound)
+		}
+		hi, lo := acc.DoubleDouble()
+		if hi+lo != hi {
+			t.Errorf("Accumulator n=%d: double-double not normalized: hi=%v lo=%v", n, hi, lo)
+		}
+
+		acc.Reset()
+		acc.AddDot(x, y)
+		exact = exactDot(x, y)
+		prod := make([]float64, n)
+		for i := range prod {
+			prod[i] = math.Abs(x[i] * y[i])
+		}
+		bound = unitRoundoff*math.Abs(ratFloat(exact)) + 2*float64(n)*u2*SumCompensated(prod)
+		if err := absErr(acc.Value(), exact); err > bound {
+			t.Errorf("Accumulator dot n=%d: error %v exceeds bound %v", n, err, bound)
+		}
+	}
+}
+
+func TestAccurateSpecialValues(t *testing.T) {
+	inf := math.Inf(1)
+	for _, test := range []struct {
+		s    []float64
+		want float64
+	}{
+		{s: []float64{1, inf, 2}, want: inf},
+		{s: []float64{1, -inf, 2}, want: -inf},
+		{s: []float64{1, inf, -inf}, want: math.NaN()},
+		{s: []float64{math.NaN(), 1}, want: math.NaN()},
+	} {
+		same := func(a, b float64) bool {
+			return a == b || (math.IsNaN(a) && math.IsNaN(b))
+		}
+		if got := SumCompensated(test.s); !same(got, test.want) {
+			t.Errorf("SumCompensated(%v) = %v, want %v", test.s, got, test.want)
+		}
+		if got := SumPairwise(test.s); !same(got, test.want) {
+			t.Errorf("SumPairwise(%v) = %v, want %v", test.s, got, test.want)
+		}
+		if got := CumSumPairwise(make([]float64, len(test.s)), test.s); !same(got[len(got)-1], test.want) {
+			t.Errorf("CumSumPairwise(%v) = %v, want last element %v", test.s, got, test.want)
+		}
+		ones := make([]float64, len(test.s))
+		for i := range ones {
+			ones[i] = 1
+		}
+		if got := DotCompensated(test.s, ones); !same(got, test.want) {
+			t.Errorf("DotCompensated(%v) = %v, want %v", test.s, got, test.want)
+		}
+		var acc Accumulator
+		acc.AddSlice(test.s)
+		if got := acc.Value(); !same(got, test.want) {
+			t.Errorf("Accumulator(%v) = %v, want %v", test.s, got, test.want)
+		}
+	}
+}
+
+func TestAccurateLargeFactors(t *testing.T) {
+	// Products of factors of large magnitude are exact even though
+	// the factors themselves would overflow if split into halves.
+	pow2 := func(k int) float64 { return math.Ldexp(1, k) }
+	for _, test := range []struct {
+		x, y []float64
+		want float64
+	}{
+		{x: []float64{1e301, 1}, y: []float64{1e-301, 2}, want: 1e301*1e-301 + 2},
+		{x: []float64{pow2(1000), 1}, y: []float64{pow2(-1000), -1}, want: 0},
+		{x: []float64{math.MaxFloat64, -math.MaxFloat64}, y: []float64{0.5, 0.5}, want: 0},
+		{x: []float64{pow2(1000) + pow2(948), -pow2(1000)}, y: []float64{pow2(-1000) + pow2(-1052), pow2(-1000)}, want: pow2(-52) + pow2(-52) + pow2(-104)},
+		{x: []float64{pow2(-1000) + pow2(-1052), pow2(-1000)}, y: []float64{pow2(1000) + pow2(948), -pow2(1000)}, want: pow2(-52) + pow2(-52) + pow2(-104)},
+	} {
+		if got := DotCompensated(test.x, test.y); got != test.want {
+			t.Errorf("DotCompensated(%v, %v) = %v, want %v", test.x, test.y, got, test.want)
+		}
+		var acc Accumulator
+		acc.AddDot(test.x, test.y)
+		if got := acc.Value(); got != test.want {
+			t.Errorf("Accumulator.AddDot(%v, %v) = %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+
+	x, y := 1e301, 1e-301
+	var acc Accumulator
+	acc.AddProduct(x, y)
+	if got, want := acc.Value(), x*y; got != want {
+		t.Errorf("Accumulator.AddProduct(1e301, 1e-301) = %v, want %v", got, want)
+	}
+	hi, lo := acc.DoubleDouble()
+	if math.IsNaN(hi) || math.IsNaN(lo) {
+		t.Errorf("Accumulator.AddProduct(1e301, 1e-301) gave NaN parts: hi=%v lo=%v", hi, lo)
+	}
+}
+
+func TestNormCompensated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		s    []float64
+		want float64
+	}{
+		{s: nil, want: 0},
+		{s: []float64{0, 0}, want: 0},
+		{s: []float64{3, -4}, want: 5},
+		{s: []float64{3e300, 4e300}, want: 5e300},
+		{s: []float64{3e-300, -4e-300}, want: 5e-300},
+		{s: []float64{1, math.Inf(-1)}, want: math.Inf(1)},
+	} {
+		if got := NormCompensated(test.s); math.Abs(got-test.want) > 2*unitRoundoff*test.want && got != test.want {
+			t.Errorf("NormCompensated(%v) = %v, want %v", test.s, got, test.want)
+		}
+	}
+	if got := NormCompensated([]float64{1, math.NaN()}); !math.IsNaN(got) {
+		t.Errorf("NormCompensated with NaN = %v, want NaN", got)
+	}
+
+	for _, n := range []int{1, 10, 1000} {
+		s := make([]float64, n)
+		for i := range s {
+			s[i] = math.Ldexp(rnd.NormFloat64(), rnd.Intn(40)-20)
+		}
+		// The square root of the correctly rounded sum of squares is
+		// within u of the exact norm.
+		exact := exactDot(s, s)
+		got := NormCompensated(s)
+		want := math.Sqrt(ratFloat(exact))
+		if math.Abs(got-want) > 2*unitRoundoff*want {
+			t.Errorf("NormCompensated n=%d: got %v want %v", n, got, want)
+		}
+	}
+}
+
+func TestNormPairwise(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		s    []float64
+		want float64
+	}{
+		{s: nil, want: 0},
+		{s: []float64{0, 0}, want: 0},
+		{s: []float64{3, -4}, want: 5},
+		{s: []float64{3e300, 4e300}, want: 5e300},
+		{s: []float64{3e-300, -4e-300}, want: 5e-300},
+		{s: []float64{1, math.Inf(-1)}, want: math.Inf(1)},
+	} {
+		if got := NormPairwise(test.s); math.Abs(got-test.want) > 2*unitRoundoff*test.want && got != test.want {
+			t.Errorf("NormPairwise(%v) = %v, want %v", test.s, got, test.want)
+		}
+	}
+	if got := NormPairwise([]float64{1, math.NaN()}); !math.IsNaN(got) {
+		t.Errorf("NormPairwise with NaN = %v, want NaN", got)
+	}
+
+	for _, n := range []int{1, 10, 1000, 10000} {
+		s := make([]float64, n)
+		for i := range s {
+			s[i] = math.Ldexp(rnd.NormFloat64(), rnd.Intn(40)-20)
+		}
+		// The sum of squares has a relative error of at most γ and its
+		// square root halves it. Rounding the exact sum of squares and
+		// taking its square root adds at most 2*u.
+		exact := exactDot(s, s)
+		got := NormPairwise(s)
+		want := math.Sqrt(ratFloat(exact))
+		b := pairwiseBlock
+		bound := (gamma(b+ceilLog2(float64(n)/float64(b)))/2 + 3*unitRoundoff) * want
+		if math.Abs(got-want) > bound {
+			t.Errorf("NormPairwise n=%d: got %v want %v", n, got, want)
+		}
+	}
+}
+
+func absSlice(s []float64) []float64 {
+	a := make([]float64, len(s))
+	for i, v := range s {
+		a[i] = math.Abs(v)
+	}
+	return a
+}
+
+func benchmarkSumCompensated(b *testing.B, size int) {
+	s := randomSlice(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumCompensated(s)
+	}
+}
+func BenchmarkSumCompensatedSmall(b *testing.B) { benchmarkSumCompensated(b, Small) }
+func BenchmarkSumCompensatedMed(b *testing.B)   { benchmarkSumCompensated(b, Medium) }
+func BenchmarkSumCompensatedLarge(b *testing.B) { benchmarkSumCompensated(b, Large) }
+
+func benchmarkSumPairwise(b *testing.B, size int) {
+	s := randomSlice(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SumPairwise(s)
+	}
+}
+func BenchmarkSumPairwiseSmall(b *testing.B) { benchmarkSumPairwise(b, Small) }
+func BenchmarkSumPairwiseMed(b *testing.B)   { benchmarkSumPairwise(b, Medium) }
+func BenchmarkSumPairwiseLarge(b *testing.B) { benchmarkSumPairwise(b, Large) }
+
+func benchmarkDotCompensated(b *testing.B, size int) {
+	s1 := randomSlice(size)
+	s2 := randomSlice(size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DotCompensated(s1, s2)
+	}
+}
+func BenchmarkDotCompensatedSmall(b *testing.B) { benchmarkDotCompensated(b, Small) }
+func BenchmarkDotCompensatedMed(b *testing.B)   { benchmarkDotCompensated(b, Medium) }
+func BenchmarkDotCompensatedLarge(b *testing.B) { benchmarkDotCompensated(b, Large) }
@@ -0,0 +1,12 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package floats32 provides a set of helper routines for dealing with slices
+// of float32. The functions avoid allocations to allow for use within tight
+// loops without garbage collection overhead. The API mirrors that of the
+// floats package.
+//
+// The convention used is that when a slice is being modified in place, it has
+// the name dst.
+package floats32 // import "gonum.org/v1/gonum/floats32"
@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c128
+
+// CumSum is
+//  if len(s) == 0 {
+//  	return dst
+//  }
+//  dst[0] = s[0]
+//  for i, v := range s[1:] {
+//  	dst[i+1] = dst[i] + v
+//  }
+//  return dst
+func CumSum(dst, s []complex128) []complex128 {
+	if len(s) == 0 {
+		return dst
+	}
+	dst[0] = s[0]
+	for i, v := range s[1:] {
+		dst[i+1] = dst[i] + v
+	}
+	return dst
+}
+
+// Div is
+//  for i, v := range s {
+//  	dst[i] /= v
+//  }
+func Div(dst, s []complex128) {
+	for i, v := range s {
+		dst[i] /= v
+	}
+}
+
+// DivTo is
+//  for i, v := range x {
+//  	dst[i] = v / y[i]
+//  }
+//  return dst
+func DivTo(dst, x, y []complex128) []complex128 {
+	for i, v := range x {
+		dst[i] = v / y[i]
+	}
+	return dst
+}
+
+// Mul is
+//  for i, v := range s {
+//  	dst[i] *= v
+//  }
+func Mul(dst, s []complex128) {
+	for i, v := range s {
+		dst[i] *= v
+	}
+}
+
+// MulTo is
+//  for i, v := range x {
+//  	dst[i] = v * y[i]
+//  }
+//  return dst
+func MulTo(dst, x, y []complex128) []complex128 {
+	for i, v := range x {
+		dst[i] = v * y[i]
+	}
+	return dst
+}
@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c128
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var (
+	nan = math.NaN()
+	inf = math.Inf(1)
+)
+
+var reduceTests = []struct {
+	x    []complex128
+	sum  complex128
+	l1   float64
+	l2   float64
+	name string
+}{
+	{x: []complex128{}, sum: 0, l1: 0, l2: 0, name: "empty"},
+	{x: []complex128{3 - 4i}, sum: 3 - 4i, l1: 7, l2: 5, name: "one"},
+	{x: []complex128{1 + 1i, -2 + 2i, 3 - 3i}, sum: 2, l1: 12, l2: math.Sqrt(28), name: "three"},
+	{x: []complex128{1, 1i, -1, -1i, 1, 1i, -1, -1i, 2}, sum: 2, l1: 10, l2: math.Sqrt(12), name: "nine"},
+	{x: []complex128{3e200, 4e200i}, sum: 3e200 + 4e200i, l1: 7e200, l2: 5e200, name: "large"},
+	{x: []complex128{3e-200, 4e-200i}, sum: 3e-200 + 4e-200i, l1: 7e-200, l2: 5e-200, name: "small"},
+	{x: []complex128{1, complex(nan, 0), 2i}, sum: complex(nan, 2), l1: nan, l2: nan, name: "nan"},
+	{x: []complex128{1, complex(inf, 1), 2, 3, 4}, sum: complex(inf, 1), l1: inf, l2: inf, name: "inf"},
+}
+
+func sameFloat(a, b float64) bool {
+	return a == b || math.IsNaN(a) && math.IsNaN(b) || math.Abs(a-b) <= 1e-14*math.Abs(b)
+}
+
+func TestSum(t *testing.T) {
+	const gd = 1 + 1i
+	for j, test := range reduceTests {
+		gdLn := 4 + j%2
+		xg := guardVector(test.x, gd, gdLn)
+		x := xg[gdLn : len(xg)-gdLn]
+		if got := Sum(x); !same(got, test.sum) {
+			t.Errorf(msgVal, "Sum", test.name, got, test.sum)
+		}
+		if got := L1Norm(x); !sameFloat(got, test.l1) {
+			t.Errorf(msgVal, "L1Norm", test.name, got, test.l1)
+		}
+		if got := L2NormUnitary(x); !sameFloat(got, test.l2) {
+			t.Errorf(msgVal, "L2NormUnitary", test.name, got, test.l2)
+		}
+		if !isValidGuard(xg, gd, gdLn) {
+			t.Errorf(msgGuard, test.name, "x", xg[:gdLn], xg[len(xg)-gdLn:])
+		}
+
+		for _, inc := range []int{1, 2, 5} {
+			name := fmt.Sprintf("%s inc=%d", test.name, inc)
+			xg := guardIncVector(test.x, gd, inc, gdLn)
+			x := xg[gdLn : len(xg)-gdLn]
+			if got := L1NormInc(x, len(test.x), inc); !sameFloat(got, test.l1) {
+				t.Errorf(msgVal, "L1NormInc", name, got, test.l1)
+			}
+			if got := L2NormInc(x, len(test.x), inc); !sameFloat(got, test.l2) {
+				t.Errorf(msgVal, "L2NormInc", name, got, test.l2)
+			}
+			checkValidIncGuard(t, xg, gd, inc, gdLn)
+		}
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	const gd = -1
+	for j, v := range []struct {
+		src, want []complex128
+	}{
+		{src: []complex128{}, want: []complex128{}},
+		{src: []complex128{1i}, want: []complex128{1i}},
+		{src: []complex128{1, 2i, 3, 4i, 5}, want: []complex128{1, 1 + 2i, 4 + 2i, 4 + 6i, 9 + 6i}},
+		{src: []complex128{1, complex(nan, 1), 1}, want: []complex128{1, complex(nan, 1), complex(nan, 1)}},
+	} {
+		gdLn := 4 + j%2
+		sg, dg := guardVector(v.src, gd, gdLn), guardVector(make([]complex128, len(v.src)), gd, gdLn)
+		src, dst := sg[gdLn:len(sg)-gdLn], dg[gdLn:len(dg)-gdLn]
+		ret := CumSum(dst, src)
+		for i := range v.want {
+			if !same(ret[i], v.want[i]) {
+				t.Errorf(msgVal, "CumSum", i, ret[i], v.want[i])
+			}
+		}
+		if !isValidGuard(sg, gd, gdLn) || !isValidGuard(dg, gd, gdLn) {
+			t.Errorf("CumSum test %d: guard violated", j)
+		}
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	x := []complex128{1 + 2i, -3 + 1i, 2, 4i, 1e200 + 1e200i}
+	y := []complex128{3 - 1i, 1i, -2, 2i, 1e200 - 1e200i}
+	mul := []complex128{5 + 5i, -1 - 3i, -4, -8, 0}
+	div := []complex128{0.1 + 0.7i, 1 + 3i, -1, 2, 1i}
+	mul[4] = x[4] * y[4]
+
+	dst := make([]complex128, len(x))
+	MulTo(dst, x, y)
+	for i := range mul {
+		if !same(dst[i], mul[i]) {
+			t.Errorf(msgVal, "MulTo", i, dst[i], mul[i])
+		}
+	}
+	copy(dst, x)
+	Mul(dst, y)
+	for i := range mul {
+		if !same(dst[i], mul[i]) {
+			t.Errorf(msgVal, "Mul", i, dst[i], mul[i])
+		}
+	}
+	DivTo(dst, x, y)
+	for i := range div {
+		if !sameFloat(real(dst[i]), real(div[i])) || !sameFloat(imag(dst[i]), imag(div[i])) {
+			t.Errorf(msgVal, "DivTo", i, dst[i], div[i])
+		}
+	}
+	copy(dst, x)
+	Div(dst, y)
+	for i := range div {
+		if !sameFloat(real(dst[i]), real(div[i])) || !sameFloat(imag(dst[i]), imag(div[i])) {
+			t.Errorf(msgVal, "Div", i, dst[i], div[i])
+		}
+	}
+}
@@ -282,3 +282,50 @@ func BenchmarkLC128AxpyIncToN100000IncM4(b *testing.B) { benchaxpyincto(b, 10000
 func BenchmarkLC128AxpyIncToN100000IncM10(b *testing.B) {
 	benchaxpyincto(b, 100000, -10, naiveaxpyincto)
 }
+
+var (
+	benchSink    complex128
+	benchSinkAbs float64
+)
+
+func benchsum(t *testing.B, n int, f func(x []complex128) complex128) {
+	x := x[:n]
+	for i := 0; i < t.N; i++ {
+		benchSink = f(x)
+	}
+}
+
+func naivesum(x []complex128) (sum complex128) {
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
+
+func BenchmarkC128Sum1(t *testing.B)      { benchsum(t, 1, Sum) }
+func BenchmarkC128Sum10(t *testing.B)     { benchsum(t, 10, Sum) }
+func BenchmarkC128Sum100(t *testing.B)    { benchsum(t, 100, Sum) }
+func BenchmarkC128Sum1000(t *testing.B)   { benchsum(t, 1000, Sum) }
+func BenchmarkC128Sum100000(t *testing.B) { benchsum(t, 100000, Sum) }
+
+func BenchmarkLC128Sum1(t *testing.B)      { benchsum(t, 1, naivesum) }
+func BenchmarkLC128Sum10(t *testing.B)     { benchsum(t, 10, naivesum) }
+func BenchmarkLC128Sum100(t *testing.B)    { benchsum(t, 100, naivesum) }
+func BenchmarkLC128Sum1000(t *testing.B)   { benchsum(t, 1000, naivesum) }
+func BenchmarkLC128Sum100000(t *testing.B) { benchsum(t, 100000, naivesum) }
+
+func benchl1norm(t *testing.B, n int, f func(x []complex128) float64) {
+	x := x[:n]
+	for i := 0; i < t.N; i++ {
+		benchSinkAbs = f(x)
+	}
+}
+
+func BenchmarkC128L1Norm1(t *testing.B)      { benchl1norm(t, 1, L1Norm) }
+func BenchmarkC128L1Norm10(t *testing.B)     { benchl1norm(t, 10, L1Norm) }
+func BenchmarkC128L1Norm100(t *testing.B)    { benchl1norm(t, 100, L1Norm) }
+func BenchmarkC128L1Norm1000(t *testing.B)   { benchl1norm(t, 1000, L1Norm) }
+func BenchmarkC128L1Norm100000(t *testing.B) { benchl1norm(t, 100000, L1Norm) }
+
+func BenchmarkC128L2NormUnitary1000(t *testing.B)   { benchl1norm(t, 1000, L2NormUnitary) }
+func BenchmarkC128L2NormUnitary100000(t *testing.B) { benchl1norm(t, 100000, L2NormUnitary) }
@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define LEN CX
+#define TAIL BX
+#define SUM X0
+#define SUM_1 X1
+#define ABS_MASK X7
+
+// func L1Norm(x []complex128) (sum float64)
+TEXT ·L1Norm(SB), NOSPLIT, $0
+	MOVQ    x_base+0(FP), X_PTR // X_PTR = &x
+	MOVQ    x_len+8(FP), LEN    // LEN = len(x)
+	PXOR    SUM, SUM            // p_sum_i = { 0, 0 }
+	PXOR    SUM_1, SUM_1
+	PCMPEQL ABS_MASK, ABS_MASK  // ABS_MASK = { 0x7FFFFFFFFFFFFFFF, 0x7FFFFFFFFFFFFFFF }
+	PSRLQ   $1, ABS_MASK
+	CMPQ    LEN, $0             // if LEN == 0 { return 0 }
+	JE      l1_end
+
+	MOVQ LEN, TAIL
+	ANDQ $3, TAIL  // TAIL = LEN % 4
+	SHRQ $2, LEN   // LEN = floor( LEN / 4 )
+	JZ   l1_tail   // if LEN == 0 { goto l1_tail }
+
+l1_loop: // Loop unrolled 4x  do {
+	MOVUPS (X_PTR), X2     // X_i = { real(x[i]), imag(x[i]) }
+	MOVUPS 16(X_PTR), X3
+	MOVUPS 32(X_PTR), X4
+	MOVUPS 48(X_PTR), X5
+	ANDPD  ABS_MASK, X2    // X_i = |X_i|
+	ANDPD  ABS_MASK, X3
+	ANDPD  ABS_MASK, X4
+	ANDPD  ABS_MASK, X5
+	ADDPD  X2, SUM         // p_sum_i += X_i
+	ADDPD  X3, SUM_1
+	ADDPD  X4, SUM
+	ADDPD  X5, SUM_1
+	ADDQ   $64, X_PTR      // X_PTR = &x[i+4]
+	DECQ   LEN
+	JNZ    l1_loop         // } while --LEN > 0
+
+	ADDPD SUM_1, SUM // p_sum_0 += p_sum_1
+	CMPQ  TAIL, $0   // if TAIL == 0 { return }
+	JE    l1_end
+
+l1_tail: // do {
+	MOVUPS (X_PTR), X2  // X_2 = { real(x[i]), imag(x[i]) }
+	ANDPD  ABS_MASK, X2 // X_2 = |X_2|
+	ADDPD  X2, SUM      // p_sum_0 += X_2
+	ADDQ   $16, X_PTR   // X_PTR = &x[i+1]
+	DECQ   TAIL
+	JNZ    l1_tail      // } while --TAIL > 0
+
+l1_end: // return p_sum_0[0] + p_sum_0[1]
+	MOVAPS   SUM, SUM_1
+	UNPCKHPD SUM_1, SUM_1
+	ADDSD    SUM_1, SUM
+	MOVSD    SUM, sum+24(FP)
+	RET
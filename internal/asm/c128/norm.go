@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c128
+
+import "math"
+
+// L1NormInc is
+//  for i := 0; i < n*incX; i += incX {
+//  	sum += math.Abs(real(x[i])) + math.Abs(imag(x[i]))
+//  }
+//  return sum
+func L1NormInc(x []complex128, n, incX int) (sum float64) {
+	for i := 0; i < n*incX; i += incX {
+		sum += math.Abs(real(x[i])) + math.Abs(imag(x[i]))
+	}
+	return sum
+}
+
+// L2NormUnitary returns the Euclidean norm of x,
+//  sqrt(\sum_i x[i] * conj(x[i])).
+// The sum of squares is scaled to avoid overflow and underflow.
+func L2NormUnitary(x []complex128) float64 {
+	var (
+		scale float64
+		ssq   float64 = 1
+	)
+	for _, v := range x {
+		scale, ssq = updateSsq(scale, ssq, real(v))
+		scale, ssq = updateSsq(scale, ssq, imag(v))
+	}
+	if math.IsInf(scale, 1) {
+		return math.Inf(1)
+	}
+	return scale * math.Sqrt(ssq)
+}
+
+// L2NormInc returns the Euclidean norm of the n elements of x with stride
+// incX,
+//  sqrt(\sum_i x[i*incX] * conj(x[i*incX])).
+// The sum of squares is scaled to avoid overflow and underflow.
+func L2NormInc(x []complex128, n, incX int) float64 {
+	var (
+		scale float64
+		ssq   float64 = 1
+	)
+	for i := 0; i < n*incX; i += incX {
+		scale, ssq = updateSsq(scale, ssq, real(x[i]))
+		scale, ssq = updateSsq(scale, ssq, imag(x[i]))
+	}
+	if math.IsInf(scale, 1) {
+		return math.Inf(1)
+	}
+	return scale * math.Sqrt(ssq)
+}
+
+// updateSsq returns the scale and scaled sum of squares after v has been
+// included.
+func updateSsq(scale, ssq, v float64) (float64, float64) {
+	if v == 0 {
+		return scale, ssq
+	}
+	v = math.Abs(v)
+	if v > scale {
+		return v, 1 + ssq*(scale/v)*(scale/v)
+	}
+	return scale, ssq + (v/scale)*(v/scale)
+}
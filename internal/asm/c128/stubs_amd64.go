@@ -62,3 +62,17 @@ func ScalInc(alpha complex128, x []complex128, n, inc uintptr)
 //  	x[i] *= alpha
 //  }
 func ScalUnitary(alpha complex128, x []complex128)
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math.Abs(real(v)) + math.Abs(imag(v))
+//  }
+//  return sum
+func L1Norm(x []complex128) (sum float64)
+
+// Sum is
+//  for _, v := range x {
+//  	sum += v
+//  }
+//  return sum
+func Sum(x []complex128) (sum complex128)
@@ -6,6 +6,8 @@
 
 package c128
 
+import "math"
+
 // AxpyUnitary is
 //  for i, v := range x {
 //  	y[i] += alpha * v
@@ -103,3 +105,27 @@ func ScalUnitary(alpha complex128, x []complex128) {
 		x[i] *= alpha
 	}
 }
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math.Abs(real(v)) + math.Abs(imag(v))
+//  }
+//  return sum
+func L1Norm(x []complex128) (sum float64) {
+	for _, v := range x {
+		sum += math.Abs(real(v)) + math.Abs(imag(v))
+	}
+	return sum
+}
+
+// Sum is
+//  for _, v := range x {
+//  	sum += v
+//  }
+//  return sum
+func Sum(x []complex128) (sum complex128) {
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
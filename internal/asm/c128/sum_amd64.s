@@ -0,0 +1,61 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define LEN CX
+#define TAIL BX
+#define SUM X0
+#define SUM_1 X1
+#define SUM_2 X2
+#define SUM_3 X3
+
+// func Sum(x []complex128) (sum complex128)
+TEXT ·Sum(SB), NOSPLIT, $0
+	MOVQ x_base+0(FP), X_PTR // X_PTR = &x
+	MOVQ x_len+8(FP), LEN    // LEN = len(x)
+	PXOR SUM, SUM            // p_sum_i = { 0, 0 }
+	PXOR SUM_1, SUM_1
+	PXOR SUM_2, SUM_2
+	PXOR SUM_3, SUM_3
+	CMPQ LEN, $0             // if LEN == 0 { return 0 }
+	JE   sum_end
+
+	MOVQ LEN, TAIL
+	ANDQ $3, TAIL  // TAIL = LEN % 4
+	SHRQ $2, LEN   // LEN = floor( LEN / 4 )
+	JZ   sum_tail  // if LEN == 0 { goto sum_tail }
+
+sum_loop: // Loop unrolled 4x  do {
+	MOVUPS (X_PTR), X4   // X_i = { real(x[i]), imag(x[i]) }
+	MOVUPS 16(X_PTR), X5
+	MOVUPS 32(X_PTR), X6
+	MOVUPS 48(X_PTR), X7
+	ADDPD  X4, SUM       // p_sum_i += X_i
+	ADDPD  X5, SUM_1
+	ADDPD  X6, SUM_2
+	ADDPD  X7, SUM_3
+	ADDQ   $64, X_PTR    // X_PTR = &x[i+4]
+	DECQ   LEN
+	JNZ    sum_loop      // } while --LEN > 0
+
+	ADDPD SUM_1, SUM // p_sum_0 += \sum_{i=1}^{3} p_sum_i
+	ADDPD SUM_3, SUM_2
+	ADDPD SUM_2, SUM
+	CMPQ  TAIL, $0   // if TAIL == 0 { return }
+	JE    sum_end
+
+sum_tail: // do {
+	MOVUPS (X_PTR), X4 // X_4 = { real(x[i]), imag(x[i]) }
+	ADDPD  X4, SUM     // p_sum_0 += X_4
+	ADDQ   $16, X_PTR  // X_PTR = &x[i+1]
+	DECQ   TAIL
+	JNZ    sum_tail    // } while --TAIL > 0
+
+sum_end:
+	MOVUPS SUM, sum+24(FP)
+	RET
@@ -0,0 +1,138 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c64
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var (
+	nan = float32(math.NaN())
+	inf = float32(math.Inf(1))
+)
+
+func isNaN(x float32) bool { return x != x }
+
+func same(x, y complex64) bool {
+	return (real(x) == real(y) || isNaN(real(x)) && isNaN(real(y))) &&
+		(imag(x) == imag(y) || isNaN(imag(x)) && isNaN(imag(y)))
+}
+
+func sameFloat(a, b float32) bool {
+	return a == b || isNaN(a) && isNaN(b) || math.Abs(float64(a-b)) <= 1e-6*math.Abs(float64(b))
+}
+
+var reduceTests = []struct {
+	x    []complex64
+	sum  complex64
+	l1   float32
+	l2   float32
+	name string
+}{
+	{x: []complex64{}, sum: 0, l1: 0, l2: 0, name: "empty"},
+	{x: []complex64{3 - 4i}, sum: 3 - 4i, l1: 7, l2: 5, name: "one"},
+	{x: []complex64{1 + 1i, -2 + 2i, 3 - 3i}, sum: 2, l1: 12, l2: float32(math.Sqrt(28)), name: "three"},
+	{x: []complex64{1, 1i, -1, -1i, 1, 1i, -1, -1i, 2}, sum: 2, l1: 10, l2: float32(math.Sqrt(12)), name: "nine"},
+	{x: []complex64{3e30, 4e30i}, sum: 3e30 + 4e30i, l1: 7e30, l2: 5e30, name: "large"},
+	{x: []complex64{3e-30, 4e-30i}, sum: 3e-30 + 4e-30i, l1: 7e-30, l2: 5e-30, name: "small"},
+	{x: []complex64{1, complex(nan, 0), 2i}, sum: complex(nan, 2), l1: nan, l2: nan, name: "nan"},
+	{x: []complex64{1, complex(inf, 1), 2, 3, 4}, sum: complex(inf, 1), l1: inf, l2: inf, name: "inf"},
+}
+
+func TestSum(t *testing.T) {
+	const gd = 1 + 1i
+	for j, test := range reduceTests {
+		gdLn := 4 + j%2
+		xg := guardVector(test.x, gd, gdLn)
+		x := xg[gdLn : len(xg)-gdLn]
+		if got := Sum(x); !same(got, test.sum) {
+			t.Errorf("Sum %s: got %v want %v", test.name, got, test.sum)
+		}
+		if got := L1Norm(x); !sameFloat(got, test.l1) {
+			t.Errorf("L1Norm %s: got %v want %v", test.name, got, test.l1)
+		}
+		if got := L2NormUnitary(x); !sameFloat(got, test.l2) {
+			t.Errorf("L2NormUnitary %s: got %v want %v", test.name, got, test.l2)
+		}
+		if !isValidGuard(xg, gd, gdLn) {
+			t.Errorf("%s: guard violated in x vector %v %v", test.name, xg[:gdLn], xg[len(xg)-gdLn:])
+		}
+
+		for _, inc := range []int{1, 2, 5} {
+			name := fmt.Sprintf("%s inc=%d", test.name, inc)
+			xg := guardIncVector(test.x, gd, uintptr(inc), gdLn)
+			x := xg[gdLn : len(xg)-gdLn]
+			if got := L1NormInc(x, len(test.x), inc); !sameFloat(got, test.l1) {
+				t.Errorf("L1NormInc %s: got %v want %v", name, got, test.l1)
+			}
+			if got := L2NormInc(x, len(test.x), inc); !sameFloat(got, test.l2) {
+				t.Errorf("L2NormInc %s: got %v want %v", name, got, test.l2)
+			}
+			checkValidIncGuard(t, xg, gd, uintptr(inc), gdLn)
+		}
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	const gd = -1
+	for j, v := range []struct {
+		src, want []complex64
+	}{
+		{src: []complex64{}, want: []complex64{}},
+		{src: []complex64{1i}, want: []complex64{1i}},
+		{src: []complex64{1, 2i, 3, 4i, 5}, want: []complex64{1, 1 + 2i, 4 + 2i, 4 + 6i, 9 + 6i}},
+		{src: []complex64{1, complex(nan, 1), 1}, want: []complex64{1, complex(nan, 1), complex(nan, 1)}},
+	} {
+		gdLn := 4 + j%2
+		sg, dg := guardVector(v.src, gd, gdLn), guardVector(make([]complex64, len(v.src)), gd, gdLn)
+		src, dst := sg[gdLn:len(sg)-gdLn], dg[gdLn:len(dg)-gdLn]
+		ret := CumSum(dst, src)
+		for i := range v.want {
+			if !same(ret[i], v.want[i]) {
+				t.Errorf("CumSum test %d: unexpected value at %d got %v want %v", j, i, ret[i], v.want[i])
+			}
+		}
+		if !isValidGuard(sg, gd, gdLn) || !isValidGuard(dg, gd, gdLn) {
+			t.Errorf("CumSum test %d: guard violated", j)
+		}
+	}
+}
+
+func TestMulDiv(t *testing.T) {
+	x := []complex64{1 + 2i, -3 + 1i, 2, 4i}
+	y := []complex64{3 - 1i, 1i, -2, 2i}
+	mul := []complex64{5 + 5i, -1 - 3i, -4, -8}
+	div := []complex64{0.1 + 0.7i, 1 + 3i, -1, 2}
+
+	dst := make([]complex64, len(x))
+	MulTo(dst, x, y)
+	for i := range mul {
+		if !same(dst[i], mul[i]) {
+			t.Errorf("MulTo: unexpected value at %d got %v want %v", i, dst[i], mul[i])
+		}
+	}
+	copy(dst, x)
+	Mul(dst, y)
+	for i := range mul {
+		if !same(dst[i], mul[i]) {
+			t.Errorf("Mul: unexpected value at %d got %v want %v", i, dst[i], mul[i])
+		}
+	}
+	DivTo(dst, x, y)
+	for i := range div {
+		if !sameFloat(real(dst[i]), real(div[i])) || !sameFloat(imag(dst[i]), imag(div[i])) {
+			t.Errorf("DivTo: unexpected value at %d got %v want %v", i, dst[i], div[i])
+		}
+	}
+	copy(dst, x)
+	Div(dst, y)
+	for i := range div {
+		if !sameFloat(real(dst[i]), real(div[i])) || !sameFloat(imag(dst[i]), imag(div[i])) {
+			t.Errorf("Div: unexpected value at %d got %v want %v", i, dst[i], div[i])
+		}
+	}
+}
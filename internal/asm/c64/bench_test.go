@@ -280,3 +280,50 @@ func BenchmarkLC64AxpyIncToN100000IncM1(b *testing.B)  { benchaxpyincto(b, 10000
 func BenchmarkLC64AxpyIncToN100000IncM2(b *testing.B)  { benchaxpyincto(b, 100000, -2, naiveaxpyincto) }
 func BenchmarkLC64AxpyIncToN100000IncM4(b *testing.B)  { benchaxpyincto(b, 100000, -4, naiveaxpyincto) }
 func BenchmarkLC64AxpyIncToN100000IncM10(b *testing.B) { benchaxpyincto(b, 100000, -10, naiveaxpyincto) }
+
+var (
+	benchSink    complex64
+	benchSinkAbs float32
+)
+
+func benchsum(t *testing.B, n int, f func(x []complex64) complex64) {
+	x := x[:n]
+	for i := 0; i < t.N; i++ {
+		benchSink = f(x)
+	}
+}
+
+func naivesum(x []complex64) (sum complex64) {
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
+
+func BenchmarkC64Sum1(t *testing.B)      { benchsum(t, 1, Sum) }
+func BenchmarkC64Sum10(t *testing.B)     { benchsum(t, 10, Sum) }
+func BenchmarkC64Sum100(t *testing.B)    { benchsum(t, 100, Sum) }
+func BenchmarkC64Sum1000(t *testing.B)   { benchsum(t, 1000, Sum) }
+func BenchmarkC64Sum100000(t *testing.B) { benchsum(t, 100000, Sum) }
+
+func BenchmarkLC64Sum1(t *testing.B)      { benchsum(t, 1, naivesum) }
+func BenchmarkLC64Sum10(t *testing.B)     { benchsum(t, 10, naivesum) }
+func BenchmarkLC64Sum100(t *testing.B)    { benchsum(t, 100, naivesum) }
+func BenchmarkLC64Sum1000(t *testing.B)   { benchsum(t, 1000, naivesum) }
+func BenchmarkLC64Sum100000(t *testing.B) { benchsum(t, 100000, naivesum) }
+
+func benchl1norm(t *testing.B, n int, f func(x []complex64) float32) {
+	x := x[:n]
+	for i := 0; i < t.N; i++ {
+		benchSinkAbs = f(x)
+	}
+}
+
+func BenchmarkC64L1Norm1(t *testing.B)      { benchl1norm(t, 1, L1Norm) }
+func BenchmarkC64L1Norm10(t *testing.B)     { benchl1norm(t, 10, L1Norm) }
+func BenchmarkC64L1Norm100(t *testing.B)    { benchl1norm(t, 100, L1Norm) }
+func BenchmarkC64L1Norm1000(t *testing.B)   { benchl1norm(t, 1000, L1Norm) }
+func BenchmarkC64L1Norm100000(t *testing.B) { benchl1norm(t, 100000, L1Norm) }
+
+func BenchmarkC64L2NormUnitary1000(t *testing.B)   { benchl1norm(t, 1000, L2NormUnitary) }
+func BenchmarkC64L2NormUnitary100000(t *testing.B) { benchl1norm(t, 100000, L2NormUnitary) }
@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+#define SUM X0
+#define SUM_1 X1
+#define ABS_MASK X7
+
+// func L1Norm(x []complex64) (sum float32)
+TEXT ·L1Norm(SB), NOSPLIT, $0
+	MOVQ    x_base+0(FP), X_PTR // X_PTR = &x
+	MOVQ    x_len+8(FP), LEN    // LEN = 2 * len(x), the number of float32 values
+	SHLQ    $1, LEN
+	XORQ    IDX, IDX            // i = 0
+	PXOR    SUM, SUM            // p_sum_i = 0
+	PXOR    SUM_1, SUM_1
+	PCMPEQL ABS_MASK, ABS_MASK  // ABS_MASK = { 0x7FFFFFFF, ... }
+	PSRLL   $1, ABS_MASK
+	CMPQ    LEN, $0             // if LEN == 0 { return 0 }
+	JE      l1_end
+
+	MOVQ LEN, TAIL
+	ANDQ $7, TAIL      // TAIL = LEN % 8
+	SHRQ $3, LEN       // LEN = floor( LEN / 8 )
+	JZ   l1_tail_start // if LEN == 0 { goto l1_tail_start }
+
+l1_loop: // Loop unrolled 8x  do {
+	MOVUPS (X_PTR)(IDX*4), X2   // X_i = { real(x[i/2]), imag(x[i/2]), ... }
+	MOVUPS 16(X_PTR)(IDX*4), X3
+	ANDPS  ABS_MASK, X2         // X_i = |X_i|
+	ANDPS  ABS_MASK, X3
+	ADDPS  X2, SUM              // p_sum_i += X_i
+	ADDPS  X3, SUM_1
+	ADDQ   $8, IDX              // i += 8
+	DECQ   LEN
+	JNZ    l1_loop              // } while --LEN > 0
+
+	ADDPS SUM_1, SUM // p_sum_0 += p_sum_1
+	CMPQ  TAIL, $0   // if TAIL == 0 { return }
+	JE    l1_end
+
+l1_tail_start:
+	PXOR SUM_1, SUM_1 // p_sum_1 = 0
+
+l1_tail: // do {
+	MOVSS (X_PTR)(IDX*4), X2 // X_2 = x[i/2] real or imaginary part
+	ANDPS ABS_MASK, X2       // X_2 = |X_2|
+	ADDSS X2, SUM_1          // p_sum_1 += X_2
+	INCQ  IDX                // i++
+	DECQ  TAIL
+	JNZ   l1_tail            // } while --TAIL > 0
+	ADDPS SUM_1, SUM         // p_sum_0 += p_sum_1
+
+l1_end: // return \sum{ p_sum_0[i] }
+	HADDPS SUM, SUM
+	HADDPS SUM, SUM
+	MOVSS  SUM, sum+24(FP)
+	RET
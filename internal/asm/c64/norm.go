@@ -0,0 +1,48 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package c64
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/internal/math32"
+)
+
+// L1NormInc is
+//  for i := 0; i < n*incX; i += incX {
+//  	sum += math32.Abs(real(x[i])) + math32.Abs(imag(x[i]))
+//  }
+//  return sum
+func L1NormInc(x []complex64, n, incX int) (sum float32) {
+	for i := 0; i < n*incX; i += incX {
+		sum += math32.Abs(real(x[i])) + math32.Abs(imag(x[i]))
+	}
+	return sum
+}
+
+// L2NormUnitary returns the Euclidean norm of x,
+//  sqrt(\sum_i x[i] * conj(x[i])).
+// The sum of squares is accumulated in float64, which can neither overflow
+// nor underflow for complex64 input, so no scaling is needed.
+func L2NormUnitary(x []complex64) float32 {
+	var sum float64
+	for _, v := range x {
+		re, im := float64(real(v)), float64(imag(v))
+		sum += re*re + im*im
+	}
+	return float32(math.Sqrt(sum))
+}
+
+// L2NormInc returns the Euclidean norm of the n elements of x with stride
+// incX,
+//  sqrt(\sum_i x[i*incX] * conj(x[i*incX])).
+func L2NormInc(x []complex64, n, incX int) float32 {
+	var sum float64
+	for i := 0; i < n*incX; i += incX {
+		re, im := float64(real(x[i])), float64(imag(x[i]))
+		sum += re*re + im*im
+	}
+	return float32(math.Sqrt(sum))
+}
@@ -34,3 +34,17 @@ func AxpyInc(alpha complex64, x, y []complex64, n, incX, incY, ix, iy uintptr)
 //  	idst += incDst
 //  }
 func AxpyIncTo(dst []complex64, incDst, idst uintptr, alpha complex64, x, y []complex64, n, incX, incY, ix, iy uintptr)
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math32.Abs(real(v)) + math32.Abs(imag(v))
+//  }
+//  return sum
+func L1Norm(x []complex64) (sum float32)
+
+// Sum is
+//  for _, v := range x {
+//  	sum += v
+//  }
+//  return sum
+func Sum(x []complex64) (sum complex64)
@@ -6,6 +6,8 @@
 
 package c64
 
+import "gonum.org/v1/gonum/internal/math32"
+
 // AxpyUnitary is
 //  for i, v := range x {
 //  	y[i] += alpha * v
@@ -55,3 +57,27 @@ func AxpyIncTo(dst []complex64, incDst, idst uintptr, alpha complex64, x, y []co
 		idst += incDst
 	}
 }
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math32.Abs(real(v)) + math32.Abs(imag(v))
+//  }
+//  return sum
+func L1Norm(x []complex64) (sum float32) {
+	for _, v := range x {
+		sum += math32.Abs(real(v)) + math32.Abs(imag(v))
+	}
+	return sum
+}
+
+// Sum is
+//  for _, v := range x {
+//  	sum += v
+//  }
+//  return sum
+func Sum(x []complex64) (sum complex64) {
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
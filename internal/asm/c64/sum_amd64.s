@@ -0,0 +1,55 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+#define SUM X0
+#define SUM_1 X1
+
+// func Sum(x []complex64) (sum complex64)
+TEXT ·Sum(SB), NOSPLIT, $0
+	MOVQ x_base+0(FP), X_PTR // X_PTR = &x
+	MOVQ x_len+8(FP), LEN    // LEN = len(x)
+	XORQ IDX, IDX            // i = 0
+	PXOR SUM, SUM            // p_sum_i = { 0, 0, 0, 0 }
+	PXOR SUM_1, SUM_1
+	CMPQ LEN, $0             // if LEN == 0 { return 0 }
+	JE   sum_end
+
+	MOVQ LEN, TAIL
+	ANDQ $3, TAIL  // TAIL = LEN % 4
+	SHRQ $2, LEN   // LEN = floor( LEN / 4 )
+	JZ   sum_tail  // if LEN == 0 { goto sum_tail }
+
+sum_loop: // Loop unrolled 4x  do {
+	MOVUPS (X_PTR)(IDX*8), X2   // X_i = { real(x[i]), imag(x[i]), real(x[i+1]), imag(x[i+1]) }
+	MOVUPS 16(X_PTR)(IDX*8), X3
+	ADDPS  X2, SUM              // p_sum_i += X_i
+	ADDPS  X3, SUM_1
+	ADDQ   $4, IDX              // i += 4
+	DECQ   LEN
+	JNZ    sum_loop             // } while --LEN > 0
+
+	ADDPS SUM_1, SUM // p_sum_0 += p_sum_1
+	CMPQ  TAIL, $0   // if TAIL == 0 { return }
+	JE    sum_end
+
+sum_tail: // do {
+	MOVSD (X_PTR)(IDX*8), X2 // X_2 = { real(x[i]), imag(x[i]), 0, 0 }
+	ADDPS X2, SUM            // p_sum_0 += X_2
+	INCQ  IDX                // i++
+	DECQ  TAIL
+	JNZ   sum_tail           // } while --TAIL > 0
+
+sum_end: // return { p_sum_0[0] + p_sum_0[2], p_sum_0[1] + p_sum_0[3] }
+	MOVHLPS SUM, SUM_1
+	ADDPS   SUM_1, SUM
+	MOVSD   SUM, sum+24(FP)
+	RET
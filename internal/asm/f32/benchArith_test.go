@@ -0,0 +1,133 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// +build go1.7
+
+package f32
+
+import (
+	"fmt"
+	"testing"
+)
+
+var benchLens = []int64{1, 2, 3, 4, 5, 10, 100, 1e3, 5e3, 1e4, 5e4}
+
+func benchReduce(t *testing.B, name string, f func(x []float32) float32) {
+	for _, v := range benchLens {
+		t.Run(fmt.Sprintf("%s-%d", name, v), func(b *testing.B) {
+			x := x[:v]
+			b.SetBytes(4 * v)
+			for i := 0; i < b.N; i++ {
+				benchSink = f(x)
+			}
+		})
+	}
+}
+
+func naiveSum(x []float32) (sum float32) {
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
+
+func naiveL1Norm(x []float32) (sum float32) {
+	for _, v := range x {
+		if v < 0 {
+			v = -v
+		}
+		sum += v
+	}
+	return sum
+}
+
+func BenchmarkSum(t *testing.B)           { benchReduce(t, "Sum", Sum) }
+func BenchmarkLSum(t *testing.B)          { benchReduce(t, "Sum", naiveSum) }
+func BenchmarkL1Norm(t *testing.B)        { benchReduce(t, "L1Norm", L1Norm) }
+func BenchmarkLL1Norm(t *testing.B)       { benchReduce(t, "L1Norm", naiveL1Norm) }
+func BenchmarkL2NormUnitary(t *testing.B) { benchReduce(t, "L2NormUnitary", L2NormUnitary) }
+
+func BenchmarkCumSum(t *testing.B)        { benchBinary(t, "CumSum", cumSum) }
+func BenchmarkLCumSum(t *testing.B)       { benchBinary(t, "CumSum", naiveCumSum) }
+func BenchmarkDiv(t *testing.B)           { benchBinary(t, "Div", Div) }
+func BenchmarkLDiv(t *testing.B)          { benchBinary(t, "Div", naiveDiv) }
+func BenchmarkMul(t *testing.B)           { benchBinary(t, "Mul", Mul) }
+func BenchmarkLMul(t *testing.B)          { benchBinary(t, "Mul", naiveMul) }
+func BenchmarkScalUnitary(t *testing.B)   { benchBinary(t, "ScalUnitary", scalUnitary) }
+func BenchmarkScalUnitaryTo(t *testing.B) { benchBinary(t, "ScalUnitaryTo", scalUnitaryTo) }
+
+func BenchmarkDivTo(t *testing.B)  { benchTernary(t, "DivTo", DivTo) }
+func BenchmarkLDivTo(t *testing.B) { benchTernary(t, "DivTo", naiveDivTo) }
+func BenchmarkMulTo(t *testing.B)  { benchTernary(t, "MulTo", MulTo) }
+func BenchmarkLMulTo(t *testing.B) { benchTernary(t, "MulTo", naiveMulTo) }
+
+func cumSum(dst, s []float32)        { CumSum(dst, s) }
+func scalUnitary(_, x []float32)     { ScalUnitary(1, x) }
+func scalUnitaryTo(dst, x []float32) { ScalUnitaryTo(dst, 1, x) }
+
+func benchBinary(t *testing.B, name string, f func(dst, s []float32)) {
+	for _, v := range benchLens {
+		t.Run(fmt.Sprintf("%s-%d", name, v), func(b *testing.B) {
+			// Use unit values so that repeated in-place
+			// operations do not overflow or underflow.
+			dst, s := z[:v], make([]float32, v)
+			for i := range dst {
+				dst[i] = 1
+				s[i] = 1
+			}
+			b.SetBytes(8 * v)
+			for i := 0; i < b.N; i++ {
+				f(dst, s)
+			}
+		})
+	}
+}
+
+func benchTernary(t *testing.B, name string, f func(dst, x, y []float32) []float32) {
+	for _, v := range benchLens {
+		t.Run(fmt.Sprintf("%s-%d", name, v), func(b *testing.B) {
+			dst, x, y := z[:v], x[:v], y[:v]
+			b.SetBytes(12 * v)
+			for i := 0; i < b.N; i++ {
+				f(dst, x, y)
+			}
+		})
+	}
+}
+
+func naiveCumSum(dst, s []float32) {
+	if len(s) == 0 {
+		return
+	}
+	dst[0] = s[0]
+	for i, v := range s[1:] {
+		dst[i+1] = dst[i] + v
+	}
+}
+
+func naiveDiv(dst, s []float32) {
+	for i, v := range s {
+		dst[i] /= v
+	}
+}
+
+func naiveMul(dst, s []float32) {
+	for i, v := range s {
+		dst[i] *= v
+	}
+}
+
+func naiveDivTo(dst, x, y []float32) []float32 {
+	for i, v := range x {
+		dst[i] = v / y[i]
+	}
+	return dst
+}
+
+func naiveMulTo(dst, x, y []float32) []float32 {
+	for i, v := range x {
+		dst[i] = v * y[i]
+	}
+	return dst
+}
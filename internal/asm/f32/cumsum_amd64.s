@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define DST_PTR DI
+#define X_PTR SI
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+#define P_SUM X5
+
+// func CumSum(dst, s []float32) []float32
+TEXT ·CumSum(SB), NOSPLIT, $0
+	MOVQ    dst_base+0(FP), DST_PTR // DST_PTR = &dst
+	MOVQ    dst_len+8(FP), LEN      // LEN = len(dst)
+	MOVQ    s_base+24(FP), X_PTR    // X_PTR = &s
+	CMPQ    s_len+32(FP), LEN       // LEN = min( LEN, len(s) )
+	CMOVQLE s_len+32(FP), LEN
+	MOVQ    LEN, ret_len+56(FP)     // len(ret) = LEN
+	CMPQ    LEN, $0                 // if LEN == 0 { return }
+	JE      cs_end
+	XORQ    IDX, IDX                // i = 0
+	PXOR    P_SUM, P_SUM            // p_sum = { 0, 0, 0, 0 }
+	MOVQ    LEN, TAIL
+	ANDQ    $3, TAIL                // TAIL = LEN % 4
+	SHRQ    $2, LEN                 // LEN = floor( LEN / 4 )
+	JZ      cs_tail_start           // if LEN == 0 { goto cs_tail_start }
+
+cs_loop: // Loop unrolled 4x  do {
+	// Form the prefix sums of s[i:i+4] within the register.
+	MOVUPS (X_PTR)(IDX*4), X0  // X0 = { s[i], s[i+1], s[i+2], s[i+3] }
+	MOVAPS X0, X1
+	PSLLO  $4, X1              // X1 = { 0, s[i], s[i+1], s[i+2] }
+	ADDPS  X1, X0              // X0 += X1
+	MOVAPS X0, X1
+	PSLLO  $8, X1              // X1 = { 0, 0, X0[0], X0[1] }
+	ADDPS  X1, X0              // X0 += X1
+	ADDPS  P_SUM, X0           // X0 += p_sum
+	MOVUPS X0, (DST_PTR)(IDX*4) // dst[i:i+4] = X0
+	MOVAPS X0, P_SUM
+	SHUFPS $0xFF, P_SUM, P_SUM // p_sum = { X0[3], X0[3], X0[3], X0[3] }
+	ADDQ   $4, IDX             // i += 4
+	DECQ   LEN
+	JNZ    cs_loop             // } while --LEN > 0
+
+	CMPQ TAIL, $0 // if TAIL == 0 { return }
+	JE   cs_end
+
+cs_tail_start:
+cs_tail: // do {
+	ADDSS (X_PTR)(IDX*4), P_SUM  // p_sum += s[i]
+	MOVSS P_SUM, (DST_PTR)(IDX*4) // dst[i] = p_sum
+	INCQ  IDX                    // i++
+	DECQ  TAIL
+	JNZ   cs_tail                // } while --TAIL > 0
+
+cs_end:
+	MOVQ DST_PTR, ret_base+48(FP) // &ret = &dst
+	MOVQ dst_cap+16(FP), X_PTR    // cap(ret) = cap(dst)
+	MOVQ X_PTR, ret_cap+64(FP)
+	RET
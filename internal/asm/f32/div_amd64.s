@@ -0,0 +1,54 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define DST_PTR DI
+#define X_PTR SI
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+
+// func Div(dst, s []float32)
+TEXT ·Div(SB), NOSPLIT, $0
+	MOVQ    dst_base+0(FP), DST_PTR // DST_PTR = &dst
+	MOVQ    dst_len+8(FP), LEN      // LEN = len(dst)
+	MOVQ    s_base+24(FP), X_PTR    // X_PTR = &s
+	CMPQ    s_len+32(FP), LEN       // LEN = min( LEN, len(s) )
+	CMOVQLE s_len+32(FP), LEN
+	CMPQ    LEN, $0                 // if LEN == 0 { return }
+	JE      div_end
+	XORQ    IDX, IDX                // i = 0
+	MOVQ    LEN, TAIL
+	ANDQ    $7, TAIL                // TAIL = LEN % 8
+	SHRQ    $3, LEN                 // LEN = floor( LEN / 8 )
+	JZ      div_tail                // if LEN == 0 { goto div_tail }
+
+div_loop: // Loop unrolled 8x  do {
+	MOVUPS (DST_PTR)(IDX*4), X0   // X_i = dst[i:i+4]
+	MOVUPS 16(DST_PTR)(IDX*4), X1
+	MOVUPS (X_PTR)(IDX*4), X2     // X_(i+2) = s[i:i+4]
+	MOVUPS 16(X_PTR)(IDX*4), X3
+	DIVPS  X2, X0                 // X_i /= X_(i+2)
+	DIVPS  X3, X1
+	MOVUPS X0, (DST_PTR)(IDX*4)   // dst[i:i+4] = X_i
+	MOVUPS X1, 16(DST_PTR)(IDX*4)
+	ADDQ   $8, IDX                // i += 8
+	DECQ   LEN
+	JNZ    div_loop               // } while --LEN > 0
+	CMPQ   TAIL, $0               // if TAIL == 0 { return }
+	JE     div_end
+
+div_tail: // do {
+	MOVSS (DST_PTR)(IDX*4), X0 // X0 = dst[i]
+	DIVSS (X_PTR)(IDX*4), X0   // X0 /= s[i]
+	MOVSS X0, (DST_PTR)(IDX*4) // dst[i] = X0
+	INCQ  IDX                  // i++
+	DECQ  TAIL
+	JNZ   div_tail             // } while --TAIL > 0
+
+div_end:
+	RET
@@ -0,0 +1,62 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define DST_PTR DI
+#define X_PTR SI
+#define Y_PTR DX
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+
+// func DivTo(dst, x, y []float32) []float32
+TEXT ·DivTo(SB), NOSPLIT, $0
+	MOVQ    dst_base+0(FP), DST_PTR // DST_PTR = &dst
+	MOVQ    dst_len+8(FP), LEN      // LEN = len(dst)
+	MOVQ    x_base+24(FP), X_PTR    // X_PTR = &x
+	MOVQ    y_base+48(FP), Y_PTR    // Y_PTR = &y
+	CMPQ    x_len+32(FP), LEN       // LEN = min( len(dst), len(x), len(y) )
+	CMOVQLE x_len+32(FP), LEN
+	CMPQ    y_len+56(FP), LEN
+	CMOVQLE y_len+56(FP), LEN
+	MOVQ    LEN, ret_len+80(FP)     // len(ret) = LEN
+	CMPQ    LEN, $0                 // if LEN == 0 { return }
+	JE      div_end
+	XORQ    IDX, IDX                // i = 0
+	MOVQ    LEN, TAIL
+	ANDQ    $7, TAIL                // TAIL = LEN % 8
+	SHRQ    $3, LEN                 // LEN = floor( LEN / 8 )
+	JZ      div_tail                // if LEN == 0 { goto div_tail }
+
+div_loop: // Loop unrolled 8x  do {
+	MOVUPS (X_PTR)(IDX*4), X0     // X_i = x[i:i+4]
+	MOVUPS 16(X_PTR)(IDX*4), X1
+	MOVUPS (Y_PTR)(IDX*4), X2     // X_(i+2) = y[i:i+4]
+	MOVUPS 16(Y_PTR)(IDX*4), X3
+	DIVPS  X2, X0                 // X_i /= X_(i+2)
+	DIVPS  X3, X1
+	MOVUPS X0, (DST_PTR)(IDX*4)   // dst[i:i+4] = X_i
+	MOVUPS X1, 16(DST_PTR)(IDX*4)
+	ADDQ   $8, IDX                // i += 8
+	DECQ   LEN
+	JNZ    div_loop               // } while --LEN > 0
+	CMPQ   TAIL, $0               // if TAIL == 0 { return }
+	JE     div_end
+
+div_tail: // do {
+	MOVSS (X_PTR)(IDX*4), X0   // X0 = x[i]
+	DIVSS (Y_PTR)(IDX*4), X0   // X0 /= y[i]
+	MOVSS X0, (DST_PTR)(IDX*4) // dst[i] = X0
+	INCQ  IDX                  // i++
+	DECQ  TAIL
+	JNZ   div_tail             // } while --TAIL > 0
+
+div_end:
+	MOVQ DST_PTR, ret_base+72(FP) // &ret = &dst
+	MOVQ dst_cap+16(FP), DST_PTR  // cap(ret) = cap(dst)
+	MOVQ DST_PTR, ret_cap+88(FP)
+	RET
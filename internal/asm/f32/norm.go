@@ -0,0 +1,47 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package f32
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/internal/math32"
+)
+
+// L1NormInc is
+//  for i := 0; i < n*incX; i += incX {
+//  	sum += math32.Abs(x[i])
+//  }
+//  return sum
+func L1NormInc(x []float32, n, incX int) (sum float32) {
+	for i := 0; i < n*incX; i += incX {
+		sum += math32.Abs(x[i])
+	}
+	return sum
+}
+
+// L2NormUnitary returns the Euclidean norm of x,
+//  sqrt(\sum_i x[i] * x[i]).
+// The sum of squares is accumulated in float64, which can neither overflow
+// nor underflow for float32 input, so no scaling is needed.
+func L2NormUnitary(x []float32) float32 {
+	var sum float64
+	for _, v := range x {
+		sum += float64(v) * float64(v)
+	}
+	return float32(math.Sqrt(sum))
+}
+
+// L2NormInc returns the Euclidean norm of the n elements of x with stride
+// incX,
+//  sqrt(\sum_i x[i*incX] * x[i*incX]).
+func L2NormInc(x []float32, n, incX int) float32 {
+	var sum float64
+	for i := 0; i < n*incX; i += incX {
+		v := float64(x[i])
+		sum += v * v
+	}
+	return float32(math.Sqrt(sum))
+}
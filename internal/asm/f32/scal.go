@@ -4,26 +4,6 @@
 
 package f32
 
-// ScalUnitary is
-//  for i := range x {
-//  	x[i] *= alpha
-//  }
-func ScalUnitary(alpha float32, x []float32) {
-	for i := range x {
-		x[i] *= alpha
-	}
-}
-
-// ScalUnitaryTo is
-//  for i, v := range x {
-//  	dst[i] = alpha * v
-//  }
-func ScalUnitaryTo(dst []float32, alpha float32, x []float32) {
-	for i, v := range x {
-		dst[i] = alpha * v
-	}
-}
-
 // ScalInc is
 //  var ix uintptr
 //  for i := 0; i < int(n); i++ {
@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define DST_PTR DI
+#define X_PTR SI
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+#define ALPHA X0
+
+// func ScalUnitary(alpha float32, x []float32)
+TEXT ·ScalUnitary(SB), NOSPLIT, $0
+	MOVQ   x_base+8(FP), X_PTR     // X_PTR = &x
+	MOVQ   X_PTR, DST_PTR          // DST_PTR = &x
+	MOVQ   x_len+16(FP), LEN       // LEN = len(x)
+	MOVSS  alpha+0(FP), ALPHA      // ALPHA = { alpha, alpha, alpha, alpha }
+	SHUFPS $0, ALPHA, ALPHA
+	CMPQ   LEN, $0
+	JE     scal_end                // if LEN == 0 { return }
+	XORQ   IDX, IDX                // i = 0
+	MOVQ   LEN, TAIL
+	ANDQ   $7, TAIL                // TAIL = LEN % 8
+	SHRQ   $3, LEN                 // LEN = floor( LEN / 8 )
+	JZ     scal_tail               // if LEN == 0 { goto scal_tail }
+
+scal_loop: // Loop unrolled 8x  do {
+	MOVUPS (X_PTR)(IDX*4), X2     // X_i = x[i:i+4]
+	MOVUPS 16(X_PTR)(IDX*4), X3
+	MULPS  ALPHA, X2              // X_i *= ALPHA
+	MULPS  ALPHA, X3
+	MOVUPS X2, (DST_PTR)(IDX*4)   // dst[i:i+4] = X_i
+	MOVUPS X3, 16(DST_PTR)(IDX*4)
+	ADDQ   $8, IDX                // i += 8
+	DECQ   LEN
+	JNZ    scal_loop              // } while --LEN > 0
+	CMPQ   TAIL, $0               // if TAIL == 0 { return }
+	JE     scal_end
+
+scal_tail: // do {
+	MOVSS (X_PTR)(IDX*4), X2   // X_2 = x[i]
+	MULSS ALPHA, X2            // X_2 *= ALPHA
+	MOVSS X2, (DST_PTR)(IDX*4) // dst[i] = X_2
+	INCQ  IDX                  // i++
+	DECQ  TAIL
+	JNZ   scal_tail            // } while --TAIL > 0
+
+scal_end:
+	RET
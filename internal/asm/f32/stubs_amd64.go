@@ -66,3 +66,66 @@ func DotUnitary(x, y []float32) (sum float32)
 //  }
 //  return sum
 func DotInc(x, y []float32, n, incX, incY, ix, iy uintptr) (sum float32)
+
+// CumSum is
+//  if len(s) == 0 {
+//  	return dst
+//  }
+//  dst[0] = s[0]
+//  for i, v := range s[1:] {
+//  	dst[i+1] = dst[i] + v
+//  }
+//  return dst
+func CumSum(dst, s []float32) []float32
+
+// Div is
+//  for i, v := range s {
+//  	dst[i] /= v
+//  }
+func Div(dst, s []float32)
+
+// DivTo is
+//  for i, v := range x {
+//  	dst[i] = v / y[i]
+//  }
+//  return dst
+func DivTo(dst, x, y []float32) []float32
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math32.Abs(v)
+//  }
+//  return sum
+func L1Norm(x []float32) (sum float32)
+
+// Mul is
+//  for i, v := range s {
+//  	dst[i] *= v
+//  }
+func Mul(dst, s []float32)
+
+// MulTo is
+//  for i, v := range x {
+//  	dst[i] = v * y[i]
+//  }
+//  return dst
+func MulTo(dst, x, y []float32) []float32
+
+// ScalUnitary is
+//  for i := range x {
+//  	x[i] *= alpha
+//  }
+func ScalUnitary(alpha float32, x []float32)
+
+// ScalUnitaryTo is
+//  for i, v := range x {
+//  	dst[i] = alpha * v
+//  }
+func ScalUnitaryTo(dst []float32, alpha float32, x []float32)
+
+// Sum is
+//  for _, v := range x {
+//  	sum += v
+//  }
+//  return sum
+func Sum(x []float32) (sum float32)
@@ -6,6 +6,8 @@
 
 package f32
 
+import "gonum.org/v1/gonum/internal/math32"
+
 // AxpyUnitary is
 //  for i, v := range x {
 //  	y[i] += alpha * v
@@ -111,3 +113,111 @@ func DdotInc(x, y []float32, n, incX, incY, ix, iy uintptr) (sum float64) {
 	}
 	return
 }
+
+// CumSum is
+//  if len(s) == 0 {
+//  	return dst
+//  }
+//  dst[0] = s[0]
+//  for i, v := range s[1:] {
+//  	dst[i+1] = dst[i] + v
+//  }
+//  return dst
+func CumSum(dst, s []float32) []float32 {
+	if len(s) == 0 {
+		return dst
+	}
+	dst[0] = s[0]
+	for i, v := range s[1:] {
+		dst[i+1] = dst[i] + v
+	}
+	return dst
+}
+
+// Div is
+//  for i, v := range s {
+//  	dst[i] /= v
+//  }
+func Div(dst, s []float32) {
+	for i, v := range s {
+		dst[i] /= v
+	}
+}
+
+// DivTo is
+//  for i, v := range x {
+//  	dst[i] = v / y[i]
+//  }
+//  return dst
+func DivTo(dst, x, y []float32) []float32 {
+	for i, v := range x {
+		dst[i] = v / y[i]
+	}
+	return dst
+}
+
+// L1Norm is
+//  for _, v := range x {
+//  	sum += math32.Abs(v)
+//  }
+//  return sum
+func L1Norm(x []float32) (sum float32) {
+	for _, v := range x {
+		sum += math32.Abs(v)
+	}
+	return sum
+}
+
+// Mul is
+//  for i, v := range s {
+//  	dst[i] *= v
+//  }
+func Mul(dst, s []float32) {
+	for i, v := range s {
+		dst[i] *= v
+	}
+}
+
+// MulTo is
+//  for i, v := range x {
+//  	dst[i] = v * y[i]
+//  }
+//  return dst
+func MulTo(dst, x, y []float32) []float32 {
+	for i, v := range x {
+		dst[i] = v * y[i]
+	}
+	return dst
+}
+
+// ScalUnitary is
+//  for i := range x {
+//  	x[i] *= alpha
+//  }
+func ScalUnitary(alpha float32, x []float32) {
+	for i := range x {
+		x[i] *= alpha
+	}
+}
+
+// ScalUnitaryTo is
+//  for i, v := range x {
+//  	dst[i] = alpha * v
+//  }
+func ScalUnitaryTo(dst []float32, alpha float32, x []float32) {
+	for i, v := range x {
+		dst[i] = alpha * v
+	}
+}
+
+// Sum is
+//  for _, v := range x {
+//  	sum += v
+//  }
+//  return sum
+func Sum(x []float32) (sum float32) {
+	for _, v := range x {
+		sum += v
+	}
+	return sum
+}
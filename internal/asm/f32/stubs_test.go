@@ -146,3 +146,345 @@ func TestAxpyIncTo(t *testing.T) {
 		checkValidIncGuard(t, v.dst, 0, int(v.incDst), gdLn)
 	}
 }
+
+func TestSum(t *testing.T) {
+	var srcGd float32 = -1
+	for j, v := range []struct {
+		want float32
+		x    []float32
+	}{
+		{want: 0, x: []float32{}},
+		{want: 2, x: []float32{2}},
+		{want: 6, x: []float32{1, 2, 3}},
+		{want: -6, x: []float32{-1, -2, -3}},
+		{want: nan, x: []float32{nan}},
+		{want: nan, x: []float32{1, inf, 3, -inf, 5, 6, 7, 8, 9}},
+		{want: 8, x: []float32{8, -8, 8, -8, 8}},
+		{want: 3, x: []float32{0, 1, 0, -1, 0, 1, 0, -1, 0, 1, 1, 1}},
+		{want: 190, x: []float32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}},
+	} {
+		gdLn := 4 + j%2
+		v.x = guardVector(v.x, srcGd, gdLn)
+		src := v.x[gdLn : len(v.x)-gdLn]
+		ret := Sum(src)
+		if !same(ret, v.want) {
+			t.Errorf("Test %d Sum error Got: %v Expected: %v", j, ret, v.want)
+		}
+		if !isValidGuard(v.x, srcGd, gdLn) {
+			t.Errorf("Test %d Guard violated in src vector %v %v", j, v.x[:gdLn], v.x[len(v.x)-gdLn:])
+		}
+	}
+}
+
+func TestL1Norm(t *testing.T) {
+	var srcGd float32 = 1
+	for j, v := range []struct {
+		want float32
+		x    []float32
+	}{
+		{want: 0, x: []float32{}},
+		{want: 2, x: []float32{2}},
+		{want: 6, x: []float32{1, 2, 3}},
+		{want: 6, x: []float32{-1, -2, -3}},
+		{want: nan, x: []float32{nan}},
+		{want: inf, x: []float32{1, -inf, 3, 4, 5, 6, 7, 8, 9}},
+		{want: 40, x: []float32{8, -8, 8, -8, 8}},
+		{want: 7, x: []float32{0, 1, 0, -1, 0, 1, 0, -1, 0, 1, -1, 1}},
+		{want: 190, x: []float32{0, -1, 2, -3, 4, -5, 6, -7, 8, -9, 10, -11, 12, -13, 14, -15, 16, -17, 18, -19}},
+	} {
+		gdLn := 4 + j%2
+		v.x = guardVector(v.x, srcGd, gdLn)
+		src := v.x[gdLn : len(v.x)-gdLn]
+		ret := L1Norm(src)
+		if !same(ret, v.want) {
+			t.Errorf("Test %d L1Norm error Got: %v Expected: %v", j, ret, v.want)
+		}
+		if !isValidGuard(v.x, srcGd, gdLn) {
+			t.Errorf("Test %d Guard violated in src vector %v %v", j, v.x[:gdLn], v.x[len(v.x)-gdLn:])
+		}
+	}
+}
+
+func TestL1NormInc(t *testing.T) {
+	var srcGd float32 = 1
+	for j, v := range []struct {
+		inc  int
+		want float32
+		x    []float32
+	}{
+		{inc: 2, want: 0, x: []float32{}},
+		{inc: 3, want: 2, x: []float32{2}},
+		{inc: 10, want: 6, x: []float32{1, 2, 3}},
+		{inc: 5, want: 6, x: []float32{-1, -2, -3}},
+		{inc: 3, want: nan, x: []float32{nan}},
+		{inc: 15, want: 40, x: []float32{8, -8, 8, -8, 8}},
+		{inc: 1, want: 5, x: []float32{0, 1, 0, -1, 0, 1, 0, -1, 0, 1}},
+	} {
+		gdLn, ln := 4+j%2, len(v.x)
+		v.x = guardIncVector(v.x, srcGd, v.inc, gdLn)
+		src := v.x[gdLn : len(v.x)-gdLn]
+		ret := L1NormInc(src, ln, v.inc)
+		if !same(ret, v.want) {
+			t.Errorf("Test %d L1NormInc error Got: %v Expected: %v", j, ret, v.want)
+		}
+		checkValidIncGuard(t, v.x, srcGd, v.inc, gdLn)
+	}
+}
+
+func TestL2Norm(t *testing.T) {
+	var srcGd float32 = 1
+	for j, v := range []struct {
+		inc  int
+		want float32
+		x    []float32
+	}{
+		{inc: 2, want: 0, x: []float32{}},
+		{inc: 3, want: 2, x: []float32{-2}},
+		{inc: 1, want: 5, x: []float32{3, 4}},
+		{inc: 4, want: 13, x: []float32{-5, 12}},
+		{inc: 3, want: nan, x: []float32{nan, 1}},
+		{inc: 2, want: inf, x: []float32{1, inf}},
+		{inc: 5, want: 5e30, x: []float32{3e30, -4e30}},
+		{inc: 1, want: 5e-30, x: []float32{3e-30, 4e-30}},
+		{inc: 7, want: 4, x: []float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}},
+	} {
+		gdLn, ln := 4+j%2, len(v.x)
+		u := guardVector(v.x, srcGd, gdLn)
+		ret := L2NormUnitary(u[gdLn : len(u)-gdLn])
+		if !same(ret, v.want) {
+			t.Errorf("Test %d L2NormUnitary error Got: %v Expected: %v", j, ret, v.want)
+		}
+		v.x = guardIncVector(v.x, srcGd, v.inc, gdLn)
+		ret = L2NormInc(v.x[gdLn:len(v.x)-gdLn], ln, v.inc)
+		if !same(ret, v.want) {
+			t.Errorf("Test %d L2NormInc error Got: %v Expected: %v", j, ret, v.want)
+		}
+		checkValidIncGuard(t, v.x, srcGd, v.inc, gdLn)
+	}
+}
+
+func TestCumSum(t *testing.T) {
+	var srcGd, dstGd float32 = -1, 0
+	for j, v := range []struct {
+		dst, src, expect []float32
+	}{
+		{
+			dst:    []float32{},
+			src:    []float32{},
+			expect: []float32{},
+		},
+		{
+			dst:    []float32{0},
+			src:    []float32{1},
+			expect: []float32{1},
+		},
+		{
+			dst:    []float32{nan},
+			src:    []float32{nan},
+			expect: []float32{nan},
+		},
+		{
+			dst:    []float32{0, 0, 0},
+			src:    []float32{1, 2, 3},
+			expect: []float32{1, 3, 6},
+		},
+		{
+			dst:    []float32{0, 0, 0, 0},
+			src:    []float32{1, 2, 3},
+			expect: []float32{1, 3, 6},
+		},
+		{
+			dst:    []float32{0, 0, 0, 0},
+			src:    []float32{1, 2, 3, 4},
+			expect: []float32{1, 3, 6, 10},
+		},
+		{
+			dst:    []float32{1, nan, nan, 1, 1},
+			src:    []float32{1, 1, nan, 1, 1},
+			expect: []float32{1, 2, nan, nan, nan},
+		},
+		{
+			dst:    []float32{nan, 4, inf, -inf, 9},
+			src:    []float32{inf, 4, nan, -inf, 9},
+			expect: []float32{inf, inf, nan, nan, nan},
+		},
+		{
+			dst:    make([]float32, 19),
+			src:    []float32{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+			expect: []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19},
+		},
+	} {
+		gdLn := 4 + j%2
+		v.src, v.dst = guardVector(v.src, srcGd, gdLn), guardVector(v.dst, dstGd, gdLn)
+		src, dst := v.src[gdLn:len(v.src)-gdLn], v.dst[gdLn:len(v.dst)-gdLn]
+		ret := CumSum(dst, src)
+		for i := range v.expect {
+			if !same(ret[i], v.expect[i]) {
+				t.Errorf("Test %d CumSum error at %d Got: %v Expected: %v", j, i, ret[i], v.expect[i])
+			}
+			if !same(ret[i], dst[i]) {
+				t.Errorf("Test %d CumSum ret/dst mismatch %d Ret: %v Dst: %v", j, i, ret[i], dst[i])
+			}
+		}
+		if !isValidGuard(v.src, srcGd, gdLn) {
+			t.Errorf("Test %d Guard violated in src vector %v %v", j, v.src[:gdLn], v.src[len(v.src)-gdLn:])
+		}
+		if !isValidGuard(v.dst, dstGd, gdLn) {
+			t.Errorf("Test %d Guard violated in dst vector %v %v", j, v.dst[:gdLn], v.dst[len(v.dst)-gdLn:])
+		}
+	}
+}
+
+var elemTests = []struct {
+	x, y, div, mul []float32
+}{
+	{
+		x:   []float32{},
+		y:   []float32{},
+		div: []float32{},
+		mul: []float32{},
+	},
+	{
+		x:   []float32{1},
+		y:   []float32{2},
+		div: []float32{0.5},
+		mul: []float32{2},
+	},
+	{
+		x:   []float32{nan, 2, 3},
+		y:   []float32{1, nan, 3},
+		div: []float32{nan, nan, 1},
+		mul: []float32{nan, nan, 9},
+	},
+	{
+		x:   []float32{1, 2, 3, 4, 2, 4, 6, 8},
+		y:   []float32{1, 2, 3, 4, 1, 2, 3, 4},
+		div: []float32{1, 1, 1, 1, 2, 2, 2, 2},
+		mul: []float32{1, 4, 9, 16, 2, 8, 18, 32},
+	},
+	{
+		x:   []float32{inf, 4, nan, -inf, 9, inf, 4, nan, -inf, 9, 0},
+		y:   []float32{inf, 4, nan, -inf, 3, inf, 4, nan, -inf, 3, 0},
+		div: []float32{nan, 1, nan, nan, 3, nan, 1, nan, nan, 3, nan},
+		mul: []float32{inf, 16, nan, inf, 27, inf, 16, nan, inf, 27, 0},
+	},
+	{
+		x:   []float32{-1, 2, -3, 4, -5, 6, -7, 8, -9, 10, -11, 12, -13, 14, -15, 16, -17},
+		y:   []float32{1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1},
+		div: []float32{-1, 1, -3, 2, -5, 3, -7, 4, -9, 5, -11, 6, -13, 7, -15, 8, -17},
+		mul: []float32{-1, 4, -3, 8, -5, 12, -7, 16, -9, 20, -11, 24, -13, 28, -15, 32, -17},
+	},
+}
+
+func TestDivMul(t *testing.T) {
+	var srcGd, dstGd float32 = -1, 0.5
+	for j, v := range elemTests {
+		for _, test := range []struct {
+			name   string
+			fn     func(dst, s []float32)
+			expect []float32
+		}{
+			{name: "Div", fn: Div, expect: v.div},
+			{name: "Mul", fn: Mul, expect: v.mul},
+		} {
+			sgLn, dgLn := 4+j%2, 4+j%3
+			gs, gd := guardVector(v.y, srcGd, sgLn), guardVector(v.x, dstGd, dgLn)
+			src, dst := gs[sgLn:len(gs)-sgLn], gd[dgLn:len(gd)-dgLn]
+			test.fn(dst, src)
+			for i := range test.expect {
+				if !same(dst[i], test.expect[i]) {
+					t.Errorf("Test %d %s error at %d Got: %v Expected: %v", j, test.name, i, dst[i], test.expect[i])
+				}
+			}
+			if !isValidGuard(gs, srcGd, sgLn) {
+				t.Errorf("Test %d %s guard violated in src vector %v %v", j, test.name, gs[:sgLn], gs[len(gs)-sgLn:])
+			}
+			if !isValidGuard(gd, dstGd, dgLn) {
+				t.Errorf("Test %d %s guard violated in dst vector %v %v", j, test.name, gd[:dgLn], gd[len(gd)-dgLn:])
+			}
+		}
+	}
+}
+
+func TestDivMulTo(t *testing.T) {
+	var xGd, yGd, dstGd float32 = 1, -1, 0
+	for j, v := range elemTests {
+		for _, test := range []struct {
+			name   string
+			fn     func(dst, x, y []float32) []float32
+			expect []float32
+		}{
+			{name: "DivTo", fn: DivTo, expect: v.div},
+			{name: "MulTo", fn: MulTo, expect: v.mul},
+		} {
+			xgLn, ygLn := 4+j%2, 4+j%3
+			gx, gy := guardVector(v.x, xGd, xgLn), guardVector(v.y, yGd, ygLn)
+			x, y := gx[xgLn:len(gx)-xgLn], gy[ygLn:len(gy)-ygLn]
+			gd := guardVector(make([]float32, len(v.x)), dstGd, xgLn)
+			dst := gd[xgLn : len(gd)-xgLn]
+			ret := test.fn(dst, x, y)
+			for i := range test.expect {
+				if !same(ret[i], test.expect[i]) {
+					t.Errorf("Test %d %s error at %d Got: %v Expected: %v", j, test.name, i, ret[i], test.expect[i])
+				}
+				if !same(ret[i], dst[i]) {
+					t.Errorf("Test %d %s ret/dst mismatch %d Ret: %v Dst: %v", j, test.name, i, ret[i], dst[i])
+				}
+			}
+			if !isValidGuard(gx, xGd, xgLn) {
+				t.Errorf("Test %d %s guard violated in x vector %v %v", j, test.name, gx[:xgLn], gx[len(gx)-xgLn:])
+			}
+			if !isValidGuard(gy, yGd, ygLn) {
+				t.Errorf("Test %d %s guard violated in y vector %v %v", j, test.name, gy[:ygLn], gy[len(gy)-ygLn:])
+			}
+			if !isValidGuard(gd, dstGd, xgLn) {
+				t.Errorf("Test %d %s guard violated in dst vector %v %v", j, test.name, gd[:xgLn], gd[len(gd)-xgLn:])
+			}
+		}
+	}
+}
+
+func TestScalUnitary(t *testing.T) {
+	const xGd = 1
+	for j, v := range []struct {
+		alpha float32
+		x     []float32
+		want  []float32
+	}{
+		{alpha: 0, x: []float32{}, want: []float32{}},
+		{alpha: 0, x: []float32{1}, want: []float32{0}},
+		{alpha: 1, x: []float32{1}, want: []float32{1}},
+		{alpha: 2, x: []float32{1, -2}, want: []float32{2, -4}},
+		{alpha: 2, x: []float32{1, 2, 3}, want: []float32{2, 4, 6}},
+		{alpha: -3, x: []float32{1, 2, 3, 4}, want: []float32{-3, -6, -9, -12}},
+		{alpha: 0, x: []float32{1, 2, 3, 4, 5}, want: []float32{0, 0, 0, 0, 0}},
+		{alpha: 0.5, x: []float32{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22}, want: []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+		{alpha: inf, x: []float32{1, 0, -1, nan}, want: []float32{inf, nan, -inf, nan}},
+	} {
+		gdLn := 4 + j%2
+		xg := guardVector(v.x, xGd, gdLn)
+		x := xg[gdLn : len(xg)-gdLn]
+		dg := guardVector(make([]float32, len(v.x)), 0, gdLn)
+		dst := dg[gdLn : len(dg)-gdLn]
+
+		ScalUnitaryTo(dst, v.alpha, x)
+		for i := range v.want {
+			if !same(dst[i], v.want[i]) {
+				t.Errorf("Test %d ScalUnitaryTo error at %d Got: %v Expected: %v", j, i, dst[i], v.want[i])
+			}
+		}
+		if !isValidGuard(dg, 0, gdLn) {
+			t.Errorf("Test %d ScalUnitaryTo guard violated in dst vector %v %v", j, dg[:gdLn], dg[len(dg)-gdLn:])
+		}
+
+		ScalUnitary(v.alpha, x)
+		for i := range v.want {
+			if !same(x[i], v.want[i]) {
+				t.Errorf("Test %d ScalUnitary error at %d Got: %v Expected: %v", j, i, x[i], v.want[i])
+			}
+		}
+		if !isValidGuard(xg, xGd, gdLn) {
+			t.Errorf("Test %d ScalUnitary guard violated in x vector %v %v", j, xg[:gdLn], xg[len(xg)-gdLn:])
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//+build !noasm,!appengine
+
+#include "textflag.h"
+
+#define X_PTR SI
+#define IDX AX
+#define LEN CX
+#define TAIL BX
+#define SUM X0
+#define SUM_1 X1
+
+// func Sum(x []float32) (sum float32)
+TEXT ·Sum(SB), NOSPLIT, $0
+	MOVQ x_base+0(FP), X_PTR // X_PTR = &x
+	MOVQ x_len+8(FP), LEN    // LEN = len(x)
+	XORQ IDX, IDX            // i = 0
+	PXOR SUM, SUM            // p_sum_i = 0
+	PXOR SUM_1, SUM_1
+	CMPQ LEN, $0             // if LEN == 0 { return 0 }
+	JE   sum_end
+
+	MOVQ LEN, TAIL
+	ANDQ $7, TAIL       // TAIL = LEN % 8
+	SHRQ $3, LEN        // LEN = floor( LEN / 8 )
+	JZ   sum_tail_start // if LEN == 0 { goto sum_tail_start }
+
+sum_loop: // Loop unrolled 8x  do {
+	MOVUPS (X_PTR)(IDX*4), X2   // X_i = x[i:i+4]
+	MOVUPS 16(X_PTR)(IDX*4), X3
+	ADDPS  X2, SUM              // p_sum_i += X_i
+	ADDPS  X3, SUM_1
+	ADDQ   $8, IDX              // i += 8
+	DECQ   LEN
+	JNZ    sum_loop             // } while --LEN > 0
+
+	ADDPS SUM_1, SUM // p_sum_0 += p_sum_1
+	CMPQ  TAIL, $0   // if TAIL == 0 { return }
+	JE    sum_end
+
+sum_tail_start:
+	PXOR SUM_1, SUM_1 // p_sum_1 = 0
+
+sum_tail: // do {
+	ADDSS (X_PTR)(IDX*4), SUM_1 // p_sum_1 += x[i]
+	INCQ  IDX                   // i++
+	DECQ  TAIL
+	JNZ   sum_tail              // } while --TAIL > 0
+	ADDPS SUM_1, SUM            // p_sum_0 += p_sum_1
+
+sum_end: // return \sum{ p_sum_0[i] }
+	HADDPS SUM, SUM
+	HADDPS SUM, SUM
+	MOVSS  SUM, sum+24(FP)
+	RET
@@ -0,0 +1,7 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fourier provides the fast Fourier transforms used by gonum/stat
+// packages.
+package fourier // import "gonum.org/v1/gonum/internal/fourier"
@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fourier
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// DFT returns the discrete Fourier transform of c,
+//  X_k = \sum_{t=0}^{n-1} c_t exp(-2πikt/n)
+// for k = 0, ..., n-1. Sequences with power of two length are transformed
+// in place by FFT and c is returned. Other lengths are transformed into a
+// new slice by Bluestein's algorithm, so that the transform takes
+// O(n log n) time for any n.
+func DFT(c []complex128) []complex128 {
+	n := len(c)
+	if n&(n-1) == 0 {
+		FFT(c, false)
+		return c
+	}
+	return bluestein(c)
+}
+
+// FFT computes the discrete Fourier transform of c in place using the
+// iterative radix-2 Cooley-Tukey algorithm,
+//  X_k = \sum_{t=0}^{n-1} c_t exp(-2πikt/n).
+// If inverse is true, the inverse transform is computed without the 1/n
+// normalization. FFT panics if len(c) is not a power of two.
+func FFT(c []complex128, inverse bool) {
+	n := len(c)
+	if n&(n-1) != 0 {
+		panic("fourier: length not a power of two")
+	}
+	if n <= 1 {
+		return
+	}
+	// Bit-reversal permutation.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			c[i], c[j] = c[j], c[i]
+		}
+	}
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Rect(1, sign*2*math.Pi/float64(size))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				u := c[start+k]
+				v := c[start+k+size/2] * w
+				c[start+k] = u + v
+				c[start+k+size/2] = u - v
+				w *= step
+			}
+		}
+	}
+}
+
+// bluestein returns the discrete Fourier transform of c of any length,
+// expressed as a convolution that is evaluated with power of two fast
+// Fourier transforms.
+func bluestein(c []complex128) []complex128 {
+	n := len(c)
+	m := 1
+	for m < 2*n-1 {
+		m <<= 1
+	}
+	// chirp[k] = exp(-πik²/n), with k² reduced modulo 2n to preserve
+	// accuracy for large k.
+	chirp := make([]complex128, n)
+	for k := range chirp {
+		k2 := (k * k) % (2 * n)
+		chirp[k] = cmplx.Rect(1, -math.Pi*float64(k2)/float64(n))
+	}
+	a := make([]complex128, m)
+	b := make([]complex128, m)
+	for k, v := range c {
+		a[k] = v * chirp[k]
+	}
+	b[0] = cmplx.Conj(chirp[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(chirp[k])
+		b[m-k] = b[k]
+	}
+	FFT(a, false)
+	FFT(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	FFT(a, true)
+	out := make([]complex128, n)
+	for k := range out {
+		out[k] = a[k] * chirp[k] / complex(float64(m), 0)
+	}
+	return out
+}
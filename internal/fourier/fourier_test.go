@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fourier
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// naiveDFT returns the discrete Fourier transform of c, or the unnormalized
+// inverse transform if inverse is true, computed directly from the
+// definition.
+func naiveDFT(c []complex128, inverse bool) []complex128 {
+	n := len(c)
+	sign := -1.0
+	if inverse {
+		sign = 1
+	}
+	out := make([]complex128, n)
+	for k := range out {
+		for t, v := range c {
+			out[k] += v * cmplx.Rect(1, sign*2*math.Pi*float64(k*t)/float64(n))
+		}
+	}
+	return out
+}
+
+func randComplex(n int, src *rand.Rand) []complex128 {
+	c := make([]complex128, n)
+	for i := range c {
+		c[i] = complex(src.NormFloat64(), src.NormFloat64())
+	}
+	return c
+}
+
+func TestDFT(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 12, 64, 97, 100} {
+		c := randComplex(n, src)
+		want := naiveDFT(c, false)
+		got := DFT(c)
+		if len(got) != n {
+			t.Errorf("unexpected length for n=%d: got %d", n, len(got))
+			continue
+		}
+		for k := range got {
+			if cmplx.Abs(got[k]-want[k]) > 1e-10*math.Sqrt(float64(n)) {
+				t.Errorf("DFT mismatch for n=%d at k=%d: want %v, got %v", n, k, want[k], got[k])
+			}
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 4, 8, 64, 256} {
+		for _, inverse := range []bool{false, true} {
+			c := randComplex(n, src)
+			want := naiveDFT(c, inverse)
+			FFT(c, inverse)
+			for k := range c {
+				if cmplx.Abs(c[k]-want[k]) > 1e-10*math.Sqrt(float64(n)) {
+					t.Errorf("FFT mismatch for n=%d inverse=%t at k=%d: want %v, got %v", n, inverse, k, want[k], c[k])
+				}
+			}
+		}
+	}
+	for _, n := range []int{3, 6, 100} {
+		c := make([]complex128, n)
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for length %d", n)
+				}
+			}()
+			FFT(c, false)
+		}()
+	}
+}
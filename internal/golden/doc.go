@@ -0,0 +1,7 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package golden provides the golden-section search used by gonum/stat
+// packages.
+package golden // import "gonum.org/v1/gonum/internal/golden"
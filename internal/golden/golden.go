@@ -0,0 +1,30 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golden
+
+// invPhi is the reciprocal of the golden ratio.
+const invPhi = 0.61803398874989484820458683436563811772030917980576286213544862
+
+// Max returns the location of the maximum of f within [a, b] found by
+// golden-section search to within tol. f is assumed to be unimodal on the
+// interval.
+func Max(f func(float64) float64, a, b, tol float64) float64 {
+	c := b - invPhi*(b-a)
+	d := a + invPhi*(b-a)
+	fc := f(c)
+	fd := f(d)
+	for b-a > tol {
+		if fc >= fd {
+			b, d, fd = d, c, fc
+			c = b - invPhi*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + invPhi*(b-a)
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2
+}
@@ -0,0 +1,29 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package golden
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMax(t *testing.T) {
+	for _, test := range []struct {
+		f    func(float64) float64
+		a, b float64
+		want float64
+	}{
+		{f: func(x float64) float64 { return -(x - 1) * (x - 1) }, a: -3, b: 4, want: 1},
+		{f: func(x float64) float64 { return -math.Abs(x + 2.5) }, a: -10, b: 10, want: -2.5},
+		{f: math.Sin, a: 0, b: 3, want: math.Pi / 2},
+		// The maximum at an end of the interval.
+		{f: func(x float64) float64 { return x }, a: 0, b: 1, want: 1},
+	} {
+		const tol = 1e-8
+		if got := Max(test.f, test.a, test.b, tol); math.Abs(got-test.want) > tol {
+			t.Errorf("unexpected maximum on [%v, %v]: got %v want %v", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -43,6 +43,15 @@ func Copysign(x, y float32) float32 {
 	return math.Float32frombits(math.Float32bits(x)&^sign | math.Float32bits(y)&sign)
 }
 
+// Exp returns e**x, the base-e exponential of x, computed in float64 and
+// rounded to float32. Special cases are as for math.Exp.
+func Exp(x float32) float32 {
+	return float32(math.Exp(float64(x)))
+}
+
+// Float32bits returns the IEEE 754 binary representation of f.
+func Float32bits(f float32) uint32 { return math.Float32bits(f) }
+
 // Hypot returns Sqrt(p*p + q*q), taking care to avoid
 // unnecessary overflow and underflow.
 //
@@ -107,5 +116,29 @@ func IsNaN(f float32) (is bool) {
 	return f != f
 }
 
+// Log returns the natural logarithm of x, computed in float64 and rounded
+// to float32. Special cases are as for math.Log.
+func Log(x float32) float32 {
+	return float32(math.Log(float64(x)))
+}
+
+// Max returns the larger of x or y.
+//
+// Special cases are:
+//	Max(x, +Inf) = Max(+Inf, x) = +Inf
+//	Max(x, NaN) = Max(NaN, x) = NaN
+//	Max(+0, ±0) = Max(±0, +0) = +0
+//	Max(-0, -0) = -0
+func Max(x, y float32) float32 {
+	// Conversion to float64 is exact, so the result is too.
+	return float32(math.Max(float64(x), float64(y)))
+}
+
 // NaN returns an IEEE 754 ``not-a-number'' value.
 func NaN() float32 { return math.Float32frombits(unan) }
+
+// Pow returns x**y, the base-x exponential of y, computed in float64
+// and rounded to float32. Special cases are as for math.Pow.
+func Pow(x, y float32) float32 {
+	return float32(math.Pow(float64(x), float64(y)))
+}
@@ -34,6 +34,31 @@ func TestCopySign(t *testing.T) {
 	}
 }
 
+func TestExp(t *testing.T) {
+	f := func(x float32) bool {
+		y := Exp(x)
+		return y == float32(math.Exp(float64(x))) || IsNaN(y) && IsNaN(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if Exp(0) != 1 || !IsInf(Exp(100), 1) || Exp(-200) != 0 {
+		t.Error("unexpected Exp special case value")
+	}
+}
+
+func TestFloat32bits(t *testing.T) {
+	f := func(x float32) bool {
+		return math.Float32frombits(Float32bits(x)) == x || IsNaN(x)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if Float32bits(1) != 0x3f800000 || Float32bits(Inf(-1)) != uneginf {
+		t.Error("unexpected Float32bits value")
+	}
+}
+
 func TestHypot(t *testing.T) {
 	// tol is increased for Hypot to avoid failures
 	// related to https://github.com/gonum/gonum/issues/110.
@@ -84,12 +109,60 @@ func TestIsNaN(t *testing.T) {
 	}
 }
 
+func TestLog(t *testing.T) {
+	f := func(x float32) bool {
+		y := Log(x)
+		want := float32(math.Log(float64(x)))
+		return y == want || IsNaN(y) && IsNaN(want)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	if Log(1) != 0 || !IsInf(Log(0), -1) || !IsNaN(Log(-1)) {
+		t.Error("unexpected Log special case value")
+	}
+}
+
+func TestMax(t *testing.T) {
+	f := func(x struct{ X, Y float32 }) bool {
+		y := Max(x.X, x.Y)
+		return y == float32(math.Max(float64(x.X), float64(x.Y)))
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	inf := Inf(1)
+	if !IsInf(Max(1, inf), 1) || !IsNaN(Max(NaN(), 1)) || Signbit(Max(-0, Copysign(0, -1))) {
+		t.Error("unexpected Max special case value")
+	}
+}
+
 func TestNaN(t *testing.T) {
 	if !math.IsNaN(float64(NaN())) {
 		t.Errorf("float32(nan) is a number: %f", NaN())
 	}
 }
 
+func TestPow(t *testing.T) {
+	f := func(x struct{ X, Y float32 }) bool {
+		y := Pow(x.X, x.Y)
+		want := float32(math.Pow(float64(x.X), float64(x.Y)))
+		return y == want || IsNaN(y) && IsNaN(want)
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+	for _, test := range []struct{ x, y, want float32 }{
+		{x: 2, y: 10, want: 1024},
+		{x: 4, y: 0.5, want: 2},
+		{x: 10, y: -2, want: 0.01},
+	} {
+		if got := Pow(test.x, test.y); got != test.want {
+			t.Errorf("unexpected Pow(%v, %v): got:%v want:%v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
 func TestSignbit(t *testing.T) {
 	f := func(x float32) bool {
 		return Signbit(x) == math.Signbit(float64(x))
@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"runtime"
+	"sync"
+)
+
+const (
+	// smallBatchOrder is the largest matrix order for which the batched
+	// routines use their unblocked fast paths instead of calling the
+	// corresponding single matrix routine for each element of the batch.
+	smallBatchOrder = 16
+
+	// minParBatchWork is the approximate number of floating point
+	// operations below which a batched call is computed serially.
+	minParBatchWork = 1 << 15
+)
+
+// checkBatch verifies the parameters of a batch of count m×n matrices with
+// row stride lda, consecutive matrices beginning stride elements apart. If
+// out is true, the matrices are written to and so must not overlap.
+func checkBatch(m, n int, a []float64, lda, stride, count int, out bool) {
+	if count < 0 {
+		panic(badBatchCount)
+	}
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if lda < max(1, n) {
+		panic("lapack: stride less than number of columns")
+	}
+	if stride < 0 {
+		panic(badBatchStride)
+	}
+	if count == 0 || m == 0 || n == 0 {
+		return
+	}
+	size := (m-1)*lda + n
+	if out && count > 1 && stride < size {
+		panic(badBatchStride)
+	}
+	if len(a) < (count-1)*stride+size {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+// checkIpivBatch verifies the parameters of a batch of count pivot vectors
+// of length n, consecutive vectors beginning stride elements apart.
+func checkIpivBatch(n int, ipiv []int, stride, count int, out bool) {
+	if stride < 0 || (out && count > 1 && stride < n) {
+		panic(badBatchStride)
+	}
+	if count > 0 && len(ipiv) < (count-1)*stride+n {
+		panic(badIpiv)
+	}
+}
+
+// parallelBatch calls fn on contiguous subranges covering [0, count),
+// using as many goroutines as is worthwhile given the approximate number
+// of floating point operations needed for each element of the batch.
+// parallelBatch returns whether all of the calls to fn returned true.
+func parallelBatch(count, work int, fn func(lo, hi int) bool) bool {
+	nWorkers := runtime.GOMAXPROCS(0)
+	if w := count * work / minParBatchWork; w < nWorkers {
+		nWorkers = w
+	}
+	if nWorkers > count {
+		nWorkers = count
+	}
+	if nWorkers <= 1 {
+		return fn(0, count)
+	}
+	chunk := (count + nWorkers - 1) / nWorkers
+	ok := make([]bool, (count+chunk-1)/chunk)
+	var wg sync.WaitGroup
+	for lo := 0; lo < count; lo += chunk {
+		hi := min(lo+chunk, count)
+		wg.Add(1)
+		go func(i, lo, hi int) {
+			defer wg.Done()
+			ok[i] = fn(lo, hi)
+		}(lo/chunk, lo, hi)
+	}
+	wg.Wait()
+	for _, v := range ok {
+		if !v {
+			return false
+		}
+	}
+	return true
+}
+
+// batchOK calls fn for each l in [lo, hi), recording the result in ok[l] if
+// ok is not nil, and reports whether all of the calls returned true.
+func batchOK(ok []bool, lo, hi int, fn func(l int) bool) bool {
+	all := true
+	for l := lo; l < hi; l++ {
+		okl := fn(l)
+		if ok != nil {
+			ok[l] = okl
+		}
+		all = all && okl
+	}
+	return all
+}
@@ -13,3 +13,5 @@ import (
 )
 
 func BenchmarkDgeev(b *testing.B) { testlapack.DgeevBenchmark(b, impl) }
+
+func BenchmarkDgetrfBatched(b *testing.B) { testlapack.DgetrfBatchedBenchmark(b, impl) }
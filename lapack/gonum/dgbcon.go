@@ -0,0 +1,88 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgbcon estimates the reciprocal of the condition number of an n×n band
+// matrix A with kl sub-diagonals and ku super-diagonals given its LU
+// factorization as computed by Dgbtrf. The condition number computed may be
+// based on the 1-norm or the ∞-norm.
+//
+// ab, ldab and ipiv hold the factorization as returned by Dgbtrf.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A as
+// computed by Dlangb.
+//
+// work is a temporary data slice of length at least 3*n and Dgbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgbcon will panic otherwise.
+func (impl Implementation) Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	checkBandMatrix(n, n, kl, kl+ku, ab, ldab)
+	if norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum {
+		panic(badNorm)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return 1
+	} else if anorm == 0 {
+		return 0
+	}
+
+	var ainvnm float64
+	var kase int
+	isave := new([3]int)
+	trans := blas.Trans
+	if norm == lapack.MaxColumnSum {
+		trans = blas.NoTrans
+	}
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, isave)
+		if kase == 0 {
+			if ainvnm == 0 {
+				return 0
+			}
+			return (1 / ainvnm) / anorm
+		}
+		// Multiply by inv(A) or inv(A^T).
+		t := trans
+		if kase != 1 {
+			if t == blas.NoTrans {
+				t = blas.Trans
+			} else {
+				t = blas.NoTrans
+			}
+		}
+		impl.Dgbtrs(t, n, kl, ku, 1, ab, ldab, ipiv, work[:n], 1)
+		if !allFinite(work[:n]) {
+			// The matrix is singular to working precision.
+			return 0
+		}
+	}
+}
+
+// allFinite returns whether all elements of x are finite.
+func allFinite(x []float64) bool {
+	for _, v := range x {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dgbtrf computes the LU factorization of an m×n band matrix A with kl
+// sub-diagonals and ku super-diagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix with
+// at most kl non-zero elements below the diagonal in each column, and U is an
+// upper triangular band matrix with kl+ku super-diagonals.
+//
+// On entry, ab holds A in row-major band format with kl additional
+// super-diagonals to hold the fill-in of U, so that A[i,j] is stored in
+// ab[i*ldab+kl+j-i], and ldab must be at least 2*kl+ku+1. The elements of the
+// additional super-diagonals need not be set on entry. On return, U is stored
+// in the upper kl+ku+1 bands of ab, and the multipliers of L are stored below
+// the diagonal in the lower kl bands.
+//
+// ipiv is a zero-indexed permutation vector indicating that row i of the
+// matrix was interchanged with row ipiv[i]. ipiv must have length at least
+// min(m,n), and Dgbtrf will panic otherwise.
+//
+// Dgbtrf returns whether the matrix A is non-singular. The factorization is
+// computed regardless of the singularity of A, but division by zero will occur
+// if false is returned and the result is used to solve a system of equations.
+//
+// Dgbtrf uses the unblocked algorithm.
+func (impl Implementation) Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	checkBandMatrix(m, n, kl, kl+ku, ab, ldab)
+	mn := min(m, n)
+	if len(ipiv) < mn {
+		panic(badIpiv)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	kv := kl + ku
+	rows := min(m, n+kl)
+	// Zero the fill-in elements.
+	for i := 0; i < rows; i++ {
+		for j := i + ku + 1; j < min(n, i+kv+1); j++ {
+			ab[i*ldab+kl+j-i] = 0
+		}
+	}
+
+	ok = true
+	// ju is the index of the last column affected by the current stage
+	// of the factorization.
+	ju := 0
+	for j := 0; j < mn; j++ {
+		// Find a pivot and test for singularity.
+		km := min(kl, m-j-1)
+		jp := j
+		amax := math.Abs(ab[j*ldab+kl])
+		for i := j + 1; i <= j+km; i++ {
+			if v := math.Abs(ab[i*ldab+kl+j-i]); v > amax {
+				jp = i
+				amax = v
+			}
+		}
+		ipiv[j] = jp
+		if ab[jp*ldab+kl+j-jp] == 0 {
+			ok = false
+			continue
+		}
+		ju = max(ju, min(jp+ku, n-1))
+
+		// Swap the rows if necessary.
+		if jp != j {
+			rj := ab[j*ldab+kl : j*ldab+kl+ju-j+1]
+			rp := ab[jp*ldab+kl+j-jp : jp*ldab+kl+ju-jp+1]
+			for k, v := range rp {
+				rp[k] = rj[k]
+				rj[k] = v
+			}
+		}
+
+		// Compute the multipliers and update the trailing submatrix.
+		ajj := ab[j*ldab+kl]
+		uj := ab[j*ldab+kl+1 : j*ldab+kl+ju-j+1]
+		for i := j + 1; i <= j+km; i++ {
+			lij := ab[i*ldab+kl+j-i]
+			if math.Abs(ajj) >= dlamchS {
+				lij *= 1 / ajj
+			} else {
+				lij /= ajj
+			}
+			ab[i*ldab+kl+j-i] = lij
+			if lij == 0 {
+				continue
+			}
+			ui := ab[i*ldab+kl+j+1-i : i*ldab+kl+ju-i+1]
+			for k, v := range uj {
+				ui[k] -= lij * v
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dgbtrs solves a system of equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// where A is an n×n band matrix with kl sub-diagonals and ku super-diagonals
+// and B is an n×nrhs matrix, using the LU factorization of A computed by
+// Dgbtrf.
+//
+// ab and ipiv hold the factorization and permutation as returned by Dgbtrf,
+// and ldab must be at least 2*kl+ku+1.
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+func (impl Implementation) Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) {
+	checkBandMatrix(n, n, kl, kl+ku, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if trans != blas.Trans && trans != blas.NoTrans {
+		panic(badTrans)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	kv := kl + ku
+	if trans == blas.NoTrans {
+		// Solve L * Y = B, applying the row interchanges as they occur.
+		for j := 0; j < n-1; j++ {
+			bj := b[j*ldb : j*ldb+nrhs]
+			if jp := ipiv[j]; jp != j {
+				bi.Dswap(nrhs, bj, 1, b[jp*ldb:], 1)
+			}
+			for i := j + 1; i <= min(n-1, j+kl); i++ {
+				if lij := ab[i*ldab+kl+j-i]; lij != 0 {
+					bi.Daxpy(nrhs, -lij, bj, 1, b[i*ldb:], 1)
+				}
+			}
+		}
+		// Solve U * X = Y, updating b.
+		for k := 0; k < nrhs; k++ {
+			bi.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, kv, ab[kl:], ldab, b[k:], ldb)
+		}
+		return
+	}
+	// Solve U^T * Y = B, updating b.
+	for k := 0; k < nrhs; k++ {
+		bi.Dtbsv(blas.Upper, blas.Trans, blas.NonUnit, n, kv, ab[kl:], ldab, b[k:], ldb)
+	}
+	// Solve L^T * X = Y, applying the row interchanges in reverse order.
+	for j := n - 2; j >= 0; j-- {
+		bj := b[j*ldb : j*ldb+nrhs]
+		for i := j + 1; i <= min(n-1, j+kl); i++ {
+			if lij := ab[i*ldab+kl+j-i]; lij != 0 {
+				bi.Daxpy(nrhs, -lij, b[i*ldb:], 1, bj, 1)
+			}
+		}
+		if jp := ipiv[j]; jp != j {
+			bi.Dswap(nrhs, bj, 1, b[jp*ldb:], 1)
+		}
+	}
+}
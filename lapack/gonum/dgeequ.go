@@ -0,0 +1,89 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dgeequ computes row and column scalings intended to equilibrate an m×n
+// matrix A and reduce its condition number. The scale factors are returned in
+// r and c such that the matrix
+//  B = diag(r) * A * diag(c)
+// has the largest element in each row and column of absolute value 1.
+//
+// r must have length at least m and c must have length at least n, and Dgeequ
+// will panic otherwise.
+//
+// rowcnd is the ratio of the smallest to the largest r[i]. If rowcnd >= 0.1
+// and amax is neither too large nor too small, it is not worth scaling by r.
+// colcnd is the ratio of the smallest to the largest c[j]. If colcnd >= 0.1 it
+// is not worth scaling by c. amax is the absolute value of the largest element
+// of A.
+//
+// Dgeequ returns false if a row or a column of A is exactly zero, in which case
+// rowcnd and colcnd, and r and c respectively, are not valid.
+func (impl Implementation) Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badR)
+	}
+	if len(c) < n {
+		panic(badC)
+	}
+
+	if m == 0 || n == 0 {
+		return 1, 1, 0, true
+	}
+
+	smlnum := dlamchS
+	bignum := 1 / smlnum
+
+	// Compute the row scale factors.
+	for i := 0; i < m; i++ {
+		var rmax float64
+		for _, v := range a[i*lda : i*lda+n] {
+			rmax = math.Max(rmax, math.Abs(v))
+		}
+		r[i] = rmax
+	}
+	rcmin := bignum
+	var rcmax float64
+	for _, v := range r[:m] {
+		rcmax = math.Max(rcmax, v)
+		rcmin = math.Min(rcmin, v)
+	}
+	amax = rcmax
+	if rcmin == 0 {
+		return 0, 0, amax, false
+	}
+	for i := range r[:m] {
+		r[i] = 1 / math.Min(math.Max(r[i], smlnum), bignum)
+	}
+	rowcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+
+	// Compute the column scale factors assuming the row scaling above.
+	for j := range c[:n] {
+		c[j] = 0
+	}
+	for i := 0; i < m; i++ {
+		ri := r[i]
+		for j, v := range a[i*lda : i*lda+n] {
+			c[j] = math.Max(c[j], math.Abs(v)*ri)
+		}
+	}
+	rcmin = bignum
+	rcmax = 0
+	for _, v := range c[:n] {
+		rcmax = math.Max(rcmax, v)
+		rcmin = math.Min(rcmin, v)
+	}
+	if rcmin == 0 {
+		return rowcnd, 0, amax, false
+	}
+	for j := range c[:n] {
+		c[j] = 1 / math.Min(math.Max(c[j], smlnum), bignum)
+	}
+	colcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+	return rowcnd, colcnd, amax, true
+}
@@ -0,0 +1,203 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dgerfs improves the computed solution to a system of linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// using iterative refinement, and provides error bounds and backward error
+// estimates for the solution.
+//
+// a contains the original n×n matrix A, and af contains its LU factorization
+// and ipiv the pivot indices as computed by Dgetrf.
+//
+// b contains the n×nrhs right-hand side matrix B. On entry, x contains the
+// solution matrix X as computed by Dgetrs. On exit, x contains the improved
+// solution.
+//
+// On return, ferr[j] contains the estimated forward error bound for the j-th
+// column of X, that is, an upper bound on the largest element of (X_j - XTRUE_j)
+// divided by the largest element of X_j, where XTRUE is the true solution. The
+// estimate is almost always a slight overestimate of the true error.
+// berr[j] contains the componentwise relative backward error of the j-th column
+// of X, that is, the smallest relative change in any element of A or B that
+// makes X_j an exact solution. ferr and berr must have length at least nrhs,
+// and Dgerfs will panic otherwise.
+//
+// work must have length at least 3*n and iwork must have length at least n, and
+// Dgerfs will panic otherwise.
+func (impl Implementation) Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	if trans != blas.NoTrans && trans != blas.Trans {
+		panic(badTrans)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(ferr) < nrhs {
+		panic(badFerr)
+	}
+	if len(berr) < nrhs {
+		panic(badBerr)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	if n == 0 || nrhs == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+
+	// itmax is the maximum number of steps of iterative refinement.
+	const itmax = 5
+
+	transt := blas.Trans
+	if trans == blas.Trans {
+		transt = blas.NoTrans
+	}
+
+	// nz is the maximum number of nonzero elements in each row of A, plus 1.
+	nz := float64(n + 1)
+	eps := dlamchE
+	safmin := dlamchS
+	safe1 := nz * safmin
+	safe2 := safe1 / eps
+
+	bi := blas64.Implementation()
+	isave := new([3]int)
+	for j := 0; j < nrhs; j++ {
+		count := 1
+		lstres := 3.0
+		for {
+			// Loop until stopping criterion is satisfied.
+
+			// Compute the residual R = B - op(A) * X, where op(A) = A or A^T
+			// depending on trans.
+			bi.Dcopy(n, b[j:], ldb, work[n:], 1)
+			bi.Dgemv(trans, n, n, -1, a, lda, x[j:], ldx, 1, work[n:], 1)
+
+			// Compute componentwise relative backward error from the formula
+			//  max(i) ( abs(R(i)) / ( abs(op(A))*abs(X) + abs(B) )(i) )
+			// where abs(Z) is the componentwise absolute value of the matrix
+			// or vector Z. If the i-th component of the denominator is less
+			// than safe2, then safe1 is added to the i-th components of the
+			// numerator and denominator before dividing.
+			for i := 0; i < n; i++ {
+				work[i] = math.Abs(b[i*ldb+j])
+			}
+			// Compute abs(op(A))*abs(X) + abs(B).
+			if trans == blas.NoTrans {
+				for i := 0; i < n; i++ {
+					var s float64
+					for k := 0; k < n; k++ {
+						s += math.Abs(a[i*lda+k]) * math.Abs(x[k*ldx+j])
+					}
+					work[i] += s
+				}
+			} else {
+				for i := 0; i < n; i++ {
+					xi := math.Abs(x[i*ldx+j])
+					for k := 0; k < n; k++ {
+						work[k] += math.Abs(a[i*lda+k]) * xi
+					}
+				}
+			}
+			var s float64
+			for i := 0; i < n; i++ {
+				if work[i] > safe2 {
+					s = math.Max(s, math.Abs(work[n+i])/work[i])
+				} else {
+					s = math.Max(s, (math.Abs(work[n+i])+safe1)/(work[i]+safe1))
+				}
+			}
+			berr[j] = s
+
+			// Test stopping criterion. Continue iterating if
+			//  1) The residual berr[j] is larger than machine epsilon, and
+			//  2) berr[j] decreased by at least a factor of 2 during the
+			//     last iteration, and
+			//  3) At most itmax iterations tried.
+			if berr[j] <= eps || 2*berr[j] > lstres || count > itmax {
+				break
+			}
+			// Update solution and try again.
+			impl.Dgetrs(trans, n, 1, af, ldaf, ipiv, work[n:2*n], 1)
+			bi.Daxpy(n, 1, work[n:], 1, x[j:], ldx)
+			lstres = berr[j]
+			count++
+		}
+
+		// Bound error from formula
+		//  norm(X - XTRUE) / norm(X) <= ferr = norm( abs(inv(op(A)))*
+		//    ( abs(R) + nz*eps*( abs(op(A))*abs(X)+abs(B) ))) / norm(X)
+		// where
+		//  norm(Z) is the magnitude of the largest component of Z,
+		//  inv(op(A)) is the inverse of op(A),
+		//  abs(Z) is the componentwise absolute value of the matrix or vector Z,
+		//  nz is the maximum number of nonzeros in any row of A, plus 1,
+		//  eps is machine epsilon.
+		//
+		// The i-th component of abs(R)+nz*eps*(abs(op(A))*abs(X)+abs(B))
+		// is incremented by safe1 if the i-th component of
+		// abs(op(A))*abs(X) + abs(B) is less than safe2.
+		//
+		// Use Dlacn2 to estimate the infinity-norm of the matrix
+		//  inv(op(A)) * diag(W),
+		// where W = abs(R) + nz*eps*( abs(op(A))*abs(X)+abs(B) ).
+		for i := 0; i < n; i++ {
+			if work[i] > safe2 {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i]
+			} else {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i] + safe1
+			}
+		}
+		var kase int
+		for {
+			ferr[j], kase = impl.Dlacn2(n, work[2*n:], work[n:], iwork, ferr[j], kase, isave)
+			if kase == 0 {
+				break
+			}
+			if kase == 1 {
+				// Multiply by diag(W)*inv(op(A)^T).
+				impl.Dgetrs(transt, n, 1, af, ldaf, ipiv, work[n:2*n], 1)
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+			} else {
+				// Multiply by inv(op(A))*diag(W).
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+				impl.Dgetrs(trans, n, 1, af, ldaf, ipiv, work[n:2*n], 1)
+			}
+		}
+
+		// Normalize error.
+		lstres = 0
+		for i := 0; i < n; i++ {
+			lstres = math.Max(lstres, math.Abs(x[i*ldx+j]))
+		}
+		if lstres != 0 {
+			ferr[j] /= lstres
+		}
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesvx uses the LU factorization to compute the solution to a real system of
+// linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// where A is an n×n matrix and X and B are n×nrhs matrices. Error bounds on the
+// solution and a condition estimate are also provided.
+//
+// The steps taken by Dgesvx are
+//  1. If fact == lapack.EquilibrateFactorize, real scaling factors are
+//     computed to equilibrate the system:
+//      trans == blas.NoTrans: diag(r)*A*diag(c) * inv(diag(c))*X = diag(r)*B
+//      trans == blas.Trans:   (diag(r)*A*diag(c))^T * inv(diag(r))*X = diag(c)*B
+//     Whether or not the system will be equilibrated depends on the scaling of
+//     the matrix A, but if equilibration is used, A is overwritten by
+//     diag(r)*A*diag(c) and B by diag(r)*B or diag(c)*B.
+//  2. If fact is not lapack.Factored, the LU decomposition is used to factor
+//     the matrix A (after equilibration if fact == lapack.EquilibrateFactorize)
+//     as A = P * L * U.
+//  3. If some U[i,i] is exactly zero, U is exactly singular and Dgesvx returns
+//     ok == false. Otherwise, the factored form of A is used to estimate the
+//     reciprocal condition number of A.
+//  4. The system of equations is solved for X using the factored form of A.
+//  5. Iterative refinement is applied to improve the computed solution matrix
+//     and calculate error bounds and backward error estimates for it.
+//  6. If equilibration was used, the matrix X is premultiplied by diag(c) if
+//     trans == blas.NoTrans or diag(r) if trans == blas.Trans so that it solves
+//     the original system before equilibration.
+//
+// If fact == lapack.Factored, af and ipiv must contain the factored form of A
+// as computed by Dgetrf, and equed specifies the form of equilibration that was
+// done on A, which must then already be equilibrated. If equed is not
+// lapack.NoEquilibration, r and c must contain the scale factors used.
+// Otherwise af and ipiv are overwritten with the factorization of the
+// (possibly equilibrated) A, equed is ignored and r and c are set to the
+// computed scale factors. r and c must have length at least n, and Dgesvx will
+// panic otherwise.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On exit, b is
+// overwritten by diag(r)*B or diag(c)*B if equilibration was done, and is
+// unchanged otherwise. On exit, x contains the n×nrhs solution matrix X to the
+// original system of equations.
+//
+// ferr and berr must have length at least nrhs, and Dgesvx will panic
+// otherwise. On return, they contain the forward error bounds and the
+// backward errors for each column of X as described in the documentation of
+// Dgerfs.
+//
+// work must have length at least 4*n and iwork must have length at least n, and
+// Dgesvx will panic otherwise.
+//
+// Dgesvx returns the type of equilibration that was done on A, the estimate of
+// the reciprocal condition number of A after equilibration, and the reciprocal
+// pivot growth factor max_j |A_j| / max_j |U_j|, where A_j and U_j are the
+// j-th columns of A and U. A small pivot growth factor indicates that the
+// computed rcond, solution and error bounds could be unreliable.
+//
+// If U is exactly singular, Dgesvx returns ok == false, rcond is zero, and the
+// solution and error bounds are not computed. In that case rpvgrw is computed
+// using the leading non-singular columns of A. If ok is true but rcond is less
+// than machine precision, the matrix is singular to working precision but the
+// solution and error bounds have still been computed.
+func (impl Implementation) Dgesvx(fact lapack.FactJob, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	switch fact {
+	case lapack.Factored, lapack.FactorizeOnly, lapack.EquilibrateFactorize:
+	default:
+		panic(badFact)
+	}
+	if trans != blas.NoTrans && trans != blas.Trans {
+		panic(badTrans)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(r) < n {
+		panic(badR)
+	}
+	if len(c) < n {
+		panic(badC)
+	}
+	if len(ferr) < nrhs {
+		panic(badFerr)
+	}
+	if len(berr) < nrhs {
+		panic(badBerr)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return lapack.NoEquilibration, 1, 1, true
+	}
+
+	smlnum := dlamchS
+	bignum := 1 / smlnum
+
+	var rowequ, colequ bool
+	var rowcnd, colcnd float64
+	if fact == lapack.Factored {
+		switch equed {
+		case lapack.NoEquilibration:
+		case lapack.RowEquilibration:
+			rowequ = true
+		case lapack.ColumnEquilibration:
+			colequ = true
+		case lapack.RowColEquilibration:
+			rowequ = true
+			colequ = true
+		default:
+			panic(badEquil)
+		}
+		if rowequ {
+			rcmin := bignum
+			var rcmax float64
+			for _, v := range r[:n] {
+				rcmin = math.Min(rcmin, v)
+				rcmax = math.Max(rcmax, v)
+			}
+			if rcmin <= 0 {
+				panic(badR)
+			}
+			rowcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+		}
+		if colequ {
+			rcmin := bignum
+			var rcmax float64
+			for _, v := range c[:n] {
+				rcmin = math.Min(rcmin, v)
+				rcmax = math.Max(rcmax, v)
+			}
+			if rcmin <= 0 {
+				panic(badC)
+			}
+			colcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+		}
+	} else {
+		equed = lapack.NoEquilibration
+	}
+
+	if fact == lapack.EquilibrateFactorize {
+		// Compute row and column scalings to equilibrate the matrix A.
+		var amax float64
+		var eqok bool
+		rowcnd, colcnd, amax, eqok = impl.Dgeequ(n, n, a, lda, r, c)
+		if eqok {
+			// Equilibrate the matrix.
+			equed = impl.Dlaqge(n, n, a, lda, r, c, rowcnd, colcnd, amax)
+			rowequ = equed == lapack.RowEquilibration || equed == lapack.RowColEquilibration
+			colequ = equed == lapack.ColumnEquilibration || equed == lapack.RowColEquilibration
+		}
+	}
+
+	// Scale the right-hand side.
+	if trans == blas.NoTrans {
+		if rowequ {
+			for i := 0; i < n; i++ {
+				for j := 0; j < nrhs; j++ {
+					b[i*ldb+j] *= r[i]
+				}
+			}
+		}
+	} else if colequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= c[i]
+			}
+		}
+	}
+
+	if fact != lapack.Factored {
+		// Compute the LU factorization of A.
+		impl.Dlacpy(blas.All, n, n, a, lda, af, ldaf)
+		if !impl.Dgetrf(n, n, af, ldaf, ipiv) {
+			// The factor U is exactly singular, so the solution and error
+			// bounds could not be computed. Compute the reciprocal pivot
+			// growth factor of the leading rank-deficient k columns of A.
+			k := 0
+			for k < n-1 && af[k*ldaf+k] != 0 {
+				k++
+			}
+			k++
+			rpvgrw = impl.Dlantr(lapack.MaxAbs, blas.Upper, blas.NonUnit, k, k, af, ldaf, nil)
+			if rpvgrw == 0 {
+				rpvgrw = 1
+			} else {
+				rpvgrw = impl.Dlange(lapack.MaxAbs, n, k, a, lda, nil) / rpvgrw
+			}
+			return equed, 0, rpvgrw, false
+		}
+	}
+
+	// Compute the norm of the matrix A and the reciprocal pivot growth factor.
+	norm := lapack.MaxColumnSum
+	if trans == blas.Trans {
+		norm = lapack.MaxRowSum
+	}
+	anorm := impl.Dlange(norm, n, n, a, lda, work)
+	rpvgrw = impl.Dlantr(lapack.MaxAbs, blas.Upper, blas.NonUnit, n, n, af, ldaf, nil)
+	if rpvgrw == 0 {
+		rpvgrw = 1
+	} else {
+		rpvgrw = impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil) / rpvgrw
+	}
+
+	// Compute the reciprocal of the condition number of A.
+	rcond = impl.Dgecon(norm, n, af, ldaf, anorm, work, iwork)
+
+	// Compute the solution matrix X.
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dgetrs(trans, n, nrhs, af, ldaf, ipiv, x, ldx)
+
+	// Use iterative refinement to improve the computed solution and compute
+	// error bounds and backward error estimates for it.
+	impl.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	// Transform the solution matrix X to a solution of the original system.
+	if trans == blas.NoTrans {
+		if colequ {
+			for i := 0; i < n; i++ {
+				for j := 0; j < nrhs; j++ {
+					x[i*ldx+j] *= c[i]
+				}
+			}
+			for j := 0; j < nrhs; j++ {
+				ferr[j] /= colcnd
+			}
+		}
+	} else if rowequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= r[i]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= rowcnd
+		}
+	}
+	return equed, rcond, rpvgrw, true
+}
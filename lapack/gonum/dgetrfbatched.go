@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// DgetrfBatched computes the LU decompositions of a batch of m×n matrices
+//  A_l = P_l * L_l * U_l,  l = 0, …, batchCount-1,
+// as Dgetrf does for a single matrix. On exit, L_l and U_l are stored in
+// place of A_l.
+//
+// The l-th matrix A_l is stored in a[l*strideA:] with row stride lda, and its
+// zero-indexed permutation vector is stored in ipiv[l*strideIpiv:] which must
+// have room for min(m,n) elements. The matrices, and the permutation vectors,
+// must not overlap.
+//
+// If ok is not nil, it must have length at least batchCount, and ok[l] is set
+// to whether A_l is non-singular. DgetrfBatched returns whether all of the
+// matrices in the batch are non-singular.
+//
+// The factorizations are distributed across goroutines when the batch is
+// large enough, and matrices of order at most 16 are factorized by an
+// unblocked kernel that does not call BLAS routines.
+func (impl Implementation) DgetrfBatched(m, n int, a []float64, lda, strideA int, ipiv []int, strideIpiv int, ok []bool, batchCount int) bool {
+	mn := min(m, n)
+	checkBatch(m, n, a, lda, strideA, batchCount, true)
+	checkIpivBatch(mn, ipiv, strideIpiv, batchCount, true)
+	if ok != nil && len(ok) < batchCount {
+		panic(badOk)
+	}
+	if m == 0 || n == 0 {
+		for l := 0; l < batchCount && ok != nil; l++ {
+			ok[l] = true
+		}
+		return true
+	}
+
+	small := max(m, n) <= smallBatchOrder
+	return parallelBatch(batchCount, m*n*mn, func(lo, hi int) bool {
+		return batchOK(ok, lo, hi, func(l int) bool {
+			al := a[l*strideA:]
+			ipivl := ipiv[l*strideIpiv : l*strideIpiv+mn]
+			if small {
+				return dgetrfSmall(m, n, al, lda, ipivl)
+			}
+			return impl.Dgetrf(m, n, al, lda, ipivl)
+		})
+	})
+}
+
+// dgetrfSmall computes the LU decomposition of the m×n matrix A in the same
+// way as Dgetf2, but without calling BLAS routines.
+func dgetrfSmall(m, n int, a []float64, lda int, ipiv []int) (ok bool) {
+	ok = true
+	for j := 0; j < min(m, n); j++ {
+		// Find a pivot and test for singularity.
+		jp := j
+		amax := math.Abs(a[j*lda+j])
+		for i := j + 1; i < m; i++ {
+			if v := math.Abs(a[i*lda+j]); v > amax {
+				jp = i
+				amax = v
+			}
+		}
+		ipiv[j] = jp
+		if a[jp*lda+j] == 0 {
+			ok = false
+		} else {
+			// Swap the rows if necessary.
+			if jp != j {
+				rj := a[j*lda : j*lda+n]
+				for k, v := range a[jp*lda : jp*lda+n] {
+					a[jp*lda+k] = rj[k]
+					rj[k] = v
+				}
+			}
+			aj := a[j*lda+j]
+			if math.Abs(aj) >= dlamchS {
+				r := 1 / aj
+				for i := j + 1; i < m; i++ {
+					a[i*lda+j] *= r
+				}
+			} else {
+				for i := j + 1; i < m; i++ {
+					a[i*lda+j] /= aj
+				}
+			}
+		}
+		// Update the trailing submatrix.
+		uj := a[j*lda+j+1 : j*lda+n]
+		for i := j + 1; i < m; i++ {
+			lij := a[i*lda+j]
+			if lij == 0 {
+				continue
+			}
+			ui := a[i*lda+j+1 : i*lda+n]
+			for k, v := range uj {
+				ui[k] -= lij * v
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,124 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// DgetrsBatched solves a batch of systems of equations
+//  A_l * X_l = B_l    if trans == blas.NoTrans,
+//  A_l^T * X_l = B_l  if trans == blas.Trans,
+// for l = 0, …, batchCount-1, using the LU factorizations of the n×n matrices
+// A_l computed by DgetrfBatched (or Dgetrf). The B_l are n×nrhs matrices.
+//
+// The l-th factorization is stored in a[l*strideA:] with row stride lda and
+// its permutation in ipiv[l*strideIpiv:]. The l-th right-hand side is stored
+// in b[l*strideB:] with row stride ldb and is overwritten by the solution X_l.
+// strideA and strideIpiv may both be zero to use the same factorization for
+// every system. The B_l must not overlap.
+//
+// The solves are distributed across goroutines when the batch is large enough,
+// and systems of order at most 16 are solved by a kernel that does not call
+// BLAS routines.
+func (impl Implementation) DgetrsBatched(trans blas.Transpose, n, nrhs int, a []float64, lda, strideA int, ipiv []int, strideIpiv int, b []float64, ldb, strideB int, batchCount int) {
+	if trans != blas.Trans && trans != blas.NoTrans {
+		panic(badTrans)
+	}
+	checkBatch(n, n, a, lda, strideA, batchCount, false)
+	checkIpivBatch(n, ipiv, strideIpiv, batchCount, false)
+	checkBatch(n, nrhs, b, ldb, strideB, batchCount, true)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	small := n <= smallBatchOrder
+	parallelBatch(batchCount, 2*n*n*nrhs, func(lo, hi int) bool {
+		for l := lo; l < hi; l++ {
+			al := a[l*strideA:]
+			ipivl := ipiv[l*strideIpiv : l*strideIpiv+n]
+			bl := b[l*strideB:]
+			if small {
+				dgetrsSmall(trans, n, nrhs, al, lda, ipivl, bl, ldb)
+			} else {
+				impl.Dgetrs(trans, n, nrhs, al, lda, ipivl, bl, ldb)
+			}
+		}
+		return true
+	})
+}
+
+// dgetrsSmall solves a system of equations using an LU factorization in the
+// same way as Dgetrs, but without calling BLAS routines.
+func dgetrsSmall(trans blas.Transpose, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) {
+	if trans == blas.NoTrans {
+		// Apply the row interchanges to B.
+		for i, ip := range ipiv {
+			if ip != i {
+				swapRows(b[i*ldb:i*ldb+nrhs], b[ip*ldb:ip*ldb+nrhs])
+			}
+		}
+		// Solve L * Y = B, updating b.
+		for i := 1; i < n; i++ {
+			bi := b[i*ldb : i*ldb+nrhs]
+			for k, v := range a[i*lda : i*lda+i] {
+				subScaledRow(bi, v, b[k*ldb:k*ldb+nrhs])
+			}
+		}
+		// Solve U * X = Y, updating b.
+		for i := n - 1; i >= 0; i-- {
+			bi := b[i*ldb : i*ldb+nrhs]
+			for k, v := range a[i*lda+i+1 : i*lda+n] {
+				subScaledRow(bi, v, b[(i+k+1)*ldb:(i+k+1)*ldb+nrhs])
+			}
+			divRow(bi, a[i*lda+i])
+		}
+		return
+	}
+	// Solve U^T * Y = B, updating b.
+	for i := 0; i < n; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for k := 0; k < i; k++ {
+			subScaledRow(bi, a[k*lda+i], b[k*ldb:k*ldb+nrhs])
+		}
+		divRow(bi, a[i*lda+i])
+	}
+	// Solve L^T * Z = Y, updating b.
+	for i := n - 2; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for k := i + 1; k < n; k++ {
+			subScaledRow(bi, a[k*lda+i], b[k*ldb:k*ldb+nrhs])
+		}
+	}
+	// Undo the row interchanges.
+	for i := n - 1; i >= 0; i-- {
+		if ip := ipiv[i]; ip != i {
+			swapRows(b[i*ldb:i*ldb+nrhs], b[ip*ldb:ip*ldb+nrhs])
+		}
+	}
+}
+
+// subScaledRow computes dst -= alpha * x.
+func subScaledRow(dst []float64, alpha float64, x []float64) {
+	if alpha == 0 {
+		return
+	}
+	for j, v := range x {
+		dst[j] -= alpha * v
+	}
+}
+
+// divRow divides the elements of dst by d.
+func divRow(dst []float64, d float64) {
+	for j := range dst {
+		dst[j] /= d
+	}
+}
+
+// swapRows exchanges the elements of x and y.
+func swapRows(x, y []float64) {
+	for j, v := range x {
+		x[j] = y[j]
+		y[j] = v
+	}
+}
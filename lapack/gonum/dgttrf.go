@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dgttrf computes an LU factorization of an n×n real tridiagonal matrix A using
+// elimination with partial pivoting and row interchanges. The factorization has
+// the form
+//  A = L * U
+// where L is a product of permutation and unit lower bidiagonal matrices and U
+// is upper triangular with nonzeros in only the main diagonal and first two
+// superdiagonals.
+//
+// On entry, dl, d and du contain the sub-diagonal, diagonal and super-diagonal
+// elements of A, respectively. dl and du must have length at least n-1 and d
+// must have length at least n, otherwise Dgttrf will panic.
+//
+// On return, dl contains the n-1 multipliers that define the matrix L, d
+// contains the n diagonal elements of U, du contains the n-1 elements of the
+// first superdiagonal of U, and du2 contains the n-2 elements of the second
+// superdiagonal of U. du2 must have length at least n-2.
+//
+// ipiv contains the pivot indices. For 0 <= i < n, row i of the matrix was
+// interchanged with row ipiv[i], where ipiv[i] is always either i or i+1.
+// ipiv must have length at least n.
+//
+// Dgttrf returns whether the factor U is non-singular. If ok is false, the
+// factorization has been completed but U is exactly singular, and division by
+// zero will occur if it is used to solve a system of equations.
+func (impl Implementation) Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Initialize ipiv(i) = i and du2(i) = 0.
+	for i := 0; i < n; i++ {
+		ipiv[i] = i
+	}
+	for i := 0; i < n-2; i++ {
+		du2[i] = 0
+	}
+
+	for i := 0; i < n-1; i++ {
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			// No row interchange required, eliminate dl[i].
+			if d[i] != 0 {
+				fact := dl[i] / d[i]
+				dl[i] = fact
+				d[i+1] -= fact * du[i]
+			}
+			continue
+		}
+		// Interchange rows i and i+1, eliminate dl[i].
+		fact := d[i] / dl[i]
+		d[i] = dl[i]
+		dl[i] = fact
+		temp := du[i]
+		du[i] = d[i+1]
+		d[i+1] = temp - fact*d[i+1]
+		if i < n-2 {
+			du2[i] = du[i+1]
+			du[i+1] *= -fact
+		}
+		ipiv[i] = i + 1
+	}
+
+	// Check for a zero on the diagonal of U.
+	for i := 0; i < n; i++ {
+		if d[i] == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dgttrs solves one of the systems of equations
+//  A * X = B   if trans == blas.NoTrans,
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans,
+// with an n×n tridiagonal matrix A using the LU factorization computed by
+// Dgttrf.
+//
+// dl, d, du, du2 and ipiv contain the factorization of A as returned by Dgttrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, b
+// contains the solution matrix X.
+func (impl Implementation) Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int) {
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic(badTrans)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 0 {
+		panic(negDimension)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	if trans == blas.NoTrans {
+		// Solve L * X = B.
+		for i := 0; i < n-1; i++ {
+			ip := ipiv[i]
+			// Row other is the row of the pair i, i+1 not selected by ip.
+			other := 2*i + 1 - ip
+			bi := b[i*ldb : i*ldb+nrhs]
+			bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+			bip := b[ip*ldb : ip*ldb+nrhs]
+			bo := b[other*ldb : other*ldb+nrhs]
+			for j := 0; j < nrhs; j++ {
+				temp := bo[j] - dl[i]*bip[j]
+				bi[j] = bip[j]
+				bi1[j] = temp
+			}
+		}
+		// Solve U * X = B.
+		bn := b[(n-1)*ldb : (n-1)*ldb+nrhs]
+		for j := range bn {
+			bn[j] /= d[n-1]
+		}
+		if n > 1 {
+			bi := b[(n-2)*ldb : (n-2)*ldb+nrhs]
+			for j := range bi {
+				bi[j] = (bi[j] - du[n-2]*bn[j]) / d[n-2]
+			}
+		}
+		for i := n - 3; i >= 0; i-- {
+			bi := b[i*ldb : i*ldb+nrhs]
+			bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+			bi2 := b[(i+2)*ldb : (i+2)*ldb+nrhs]
+			for j := range bi {
+				bi[j] = (bi[j] - du[i]*bi1[j] - du2[i]*bi2[j]) / d[i]
+			}
+		}
+		return
+	}
+
+	// Solve U^T * X = B.
+	b0 := b[:nrhs]
+	for j := range b0 {
+		b0[j] /= d[0]
+	}
+	if n > 1 {
+		b1 := b[ldb : ldb+nrhs]
+		for j := range b1 {
+			b1[j] = (b1[j] - du[0]*b0[j]) / d[1]
+		}
+	}
+	for i := 2; i < n; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		bi1 := b[(i-1)*ldb : (i-1)*ldb+nrhs]
+		bi2 := b[(i-2)*ldb : (i-2)*ldb+nrhs]
+		for j := range bi {
+			bi[j] = (bi[j] - du[i-1]*bi1[j] - du2[i-2]*bi2[j]) / d[i]
+		}
+	}
+	// Solve L^T * X = B.
+	for i := n - 2; i >= 0; i-- {
+		ip := ipiv[i]
+		bi := b[i*ldb : i*ldb+nrhs]
+		bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+		bip := b[ip*ldb : ip*ldb+nrhs]
+		for j := 0; j < nrhs; j++ {
+			temp := bi[j] - dl[i]*bi1[j]
+			bi[j] = bip[j]
+			bip[j] = temp
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlangb returns the given norm of an m×n band matrix with kl sub-diagonals
+// and ku super-diagonals. The matrix is stored in ab in row-major band format
+// so that A[i,j] is stored in ab[i*ldab+kl+j-i].
+//
+// If norm == lapack.MaxColumnSum work must have length at least n, otherwise
+// work is unused.
+func (impl Implementation) Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64 {
+	checkBandMatrix(m, n, kl, ku, ab, ldab)
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if norm == lapack.MaxColumnSum && len(work) < n {
+		panic(badWork)
+	}
+
+	if m == 0 || n == 0 {
+		return 0
+	}
+	rows := min(m, n+kl)
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var value float64
+		for i := 0; i < rows; i++ {
+			jl := max(0, i-kl)
+			for _, v := range ab[i*ldab+kl+jl-i : i*ldab+kl+min(n, i+ku+1)-i] {
+				v = math.Abs(v)
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				if v > value {
+					value = v
+				}
+			}
+		}
+		return value
+	case lapack.MaxRowSum:
+		var value float64
+		for i := 0; i < rows; i++ {
+			jl := max(0, i-kl)
+			var sum float64
+			for _, v := range ab[i*ldab+kl+jl-i : i*ldab+kl+min(n, i+ku+1)-i] {
+				sum += math.Abs(v)
+			}
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			if sum > value {
+				value = sum
+			}
+		}
+		return value
+	case lapack.MaxColumnSum:
+		for j := 0; j < n; j++ {
+			work[j] = 0
+		}
+		for i := 0; i < rows; i++ {
+			jl := max(0, i-kl)
+			for k, v := range ab[i*ldab+kl+jl-i : i*ldab+kl+min(n, i+ku+1)-i] {
+				work[jl+k] += math.Abs(v)
+			}
+		}
+		var value float64
+		for _, v := range work[:n] {
+			if math.IsNaN(v) {
+				return math.NaN()
+			}
+			if v > value {
+				value = v
+			}
+		}
+		return value
+	case lapack.NormFrob:
+		scale := 0.0
+		sum := 1.0
+		for i := 0; i < rows; i++ {
+			jl := max(0, i-kl)
+			ju := min(n, i+ku+1)
+			scale, sum = impl.Dlassq(ju-jl, ab[i*ldab+kl+jl-i:], 1, scale, sum)
+		}
+		return scale * math.Sqrt(sum)
+	}
+}
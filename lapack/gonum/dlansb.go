@@ -0,0 +1,115 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlansb returns the given norm of an n×n symmetric band matrix with kd
+// super-diagonals. If uplo == blas.Upper, the upper triangle of A is stored
+// in ab so that A[i,j] is stored in ab[i*ldab+j-i] for j >= i, and if
+// uplo == blas.Lower, the lower triangle of A is stored so that A[i,j] is
+// stored in ab[i*ldab+kd+j-i] for j <= i. This is the storage used by Dpbtrf.
+//
+// If norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum work must have
+// length at least n, otherwise work is unused.
+func (impl Implementation) Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64 {
+	checkSymBanded(ab, n, kd, ldab)
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if (norm == lapack.MaxColumnSum || norm == lapack.MaxRowSum) && len(work) < n {
+		panic(badWork)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+
+	if n == 0 {
+		return 0
+	}
+	// band returns the stored elements of row i and the column index of
+	// the first of them.
+	band := func(i int) (row []float64, j0 int) {
+		if uplo == blas.Upper {
+			return ab[i*ldab : i*ldab+min(n-i, kd+1)], i
+		}
+		j0 = max(0, i-kd)
+		return ab[i*ldab+kd+j0-i : i*ldab+kd+1], j0
+	}
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var value float64
+		for i := 0; i < n; i++ {
+			row, _ := band(i)
+			for _, v := range row {
+				v = math.Abs(v)
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				if v > value {
+					value = v
+				}
+			}
+		}
+		return value
+	case lapack.MaxRowSum, lapack.MaxColumnSum:
+		// A symmetric matrix has the same 1-norm and ∞-norm.
+		for i := 0; i < n; i++ {
+			work[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			row, j0 := band(i)
+			for k, v := range row {
+				j := j0 + k
+				v = math.Abs(v)
+				work[i] += v
+				if j != i {
+					work[j] += v
+				}
+			}
+		}
+		var value float64
+		for _, v := range work[:n] {
+			if math.IsNaN(v) {
+				return math.NaN()
+			}
+			if v > value {
+				value = v
+			}
+		}
+		return value
+	case lapack.NormFrob:
+		// Sum the off-diagonal elements twice and the diagonal once.
+		scale := 0.0
+		sum := 1.0
+		diag := 0
+		if uplo == blas.Lower {
+			diag = kd
+		}
+		if kd > 0 {
+			for i := 0; i < n; i++ {
+				row, j0 := band(i)
+				if uplo == blas.Upper {
+					row = row[1:]
+				} else {
+					row = row[:i-j0]
+				}
+				scale, sum = impl.Dlassq(len(row), row, 1, scale, sum)
+			}
+			sum *= 2
+		}
+		scale, sum = impl.Dlassq(n, ab[diag:], ldab, scale, sum)
+		return scale * math.Sqrt(sum)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/lapack"
+
+// Dlaqge equilibrates a general m×n matrix A using the row and column scaling
+// factors in r and c, as computed by Dgeequ. rowcnd, colcnd and amax must be
+// the values returned by Dgeequ.
+//
+// Dlaqge decides whether row and/or column scaling is worth doing, scales A in
+// place accordingly and returns the type of scaling that was applied.
+//
+// r must have length at least m and c must have length at least n, and Dlaqge
+// will panic otherwise.
+//
+// Dlaqge is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqge(m, n int, a []float64, lda int, r, c []float64, rowcnd, colcnd, amax float64) lapack.EquilibrationType {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badR)
+	}
+	if len(c) < n {
+		panic(badC)
+	}
+
+	if m == 0 || n == 0 {
+		return lapack.NoEquilibration
+	}
+
+	// thresh is the threshold value for the scaling ratios. If a ratio
+	// is above thresh, scaling is not worth doing.
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	if rowcnd >= thresh && amax >= small && amax <= large {
+		if colcnd >= thresh {
+			return lapack.NoEquilibration
+		}
+		// Column scaling only.
+		for i := 0; i < m; i++ {
+			for j, cj := range c[:n] {
+				a[i*lda+j] *= cj
+			}
+		}
+		return lapack.ColumnEquilibration
+	}
+	if colcnd >= thresh {
+		// Row scaling only.
+		for i, ri := range r[:m] {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] *= ri
+			}
+		}
+		return lapack.RowEquilibration
+	}
+	// Row and column scaling.
+	for i, ri := range r[:m] {
+		for j, cj := range c[:n] {
+			a[i*lda+j] *= ri * cj
+		}
+	}
+	return lapack.RowColEquilibration
+}
@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlaqsy equilibrates a symmetric n×n matrix A using the scaling factors in s,
+// as computed by Dpoequ. scond and amax must be the values returned by Dpoequ.
+//
+// Dlaqsy decides whether scaling is worth doing and, if so, replaces the
+// triangle of A specified by uplo with the corresponding triangle of
+//  diag(s) * A * diag(s).
+// It returns lapack.SymmetricEquilibration if A was scaled and
+// lapack.NoEquilibration otherwise.
+//
+// s must have length at least n, and Dlaqsy will panic otherwise.
+func (impl Implementation) Dlaqsy(uplo blas.Uplo, n int, a []float64, lda int, s []float64, scond, amax float64) lapack.EquilibrationType {
+	checkMatrix(n, n, a, lda)
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(s) < n {
+		panic(badS)
+	}
+
+	if n == 0 {
+		return lapack.NoEquilibration
+	}
+
+	// thresh is the threshold value for the scaling ratio. If scond is
+	// above thresh, scaling is not worth doing.
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	if scond >= thresh && amax >= small && amax <= large {
+		return lapack.NoEquilibration
+	}
+	if uplo == blas.Upper {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := i; j < n; j++ {
+				a[i*lda+j] *= si * s[j]
+			}
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := 0; j <= i; j++ {
+				a[i*lda+j] *= si * s[j]
+			}
+		}
+	}
+	return lapack.SymmetricEquilibration
+}
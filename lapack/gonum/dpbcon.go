@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dpbcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite band matrix A with kd super-diagonals given its Cholesky
+// factorization as computed by Dpbtrf. The condition number computed is based
+// on the 1-norm and the ∞-norm.
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A as computed
+// by Dlansb.
+//
+// work is a temporary data slice of length at least 3*n and Dpbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dpbcon will panic otherwise.
+func (impl Implementation) Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64 {
+	checkSymBanded(ab, n, kd, ldab)
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return 1
+	} else if anorm == 0 {
+		return 0
+	}
+
+	bi := blas64.Implementation()
+	var ainvnm float64
+	var kase int
+	isave := new([3]int)
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, isave)
+		if kase == 0 {
+			if ainvnm == 0 {
+				return 0
+			}
+			return (1 / ainvnm) / anorm
+		}
+		// Multiply by inv(A), which is symmetric.
+		if uplo == blas.Upper {
+			bi.Dtbsv(blas.Upper, blas.Trans, blas.NonUnit, n, kd, ab, ldab, work, 1)
+			bi.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, kd, ab, ldab, work, 1)
+		} else {
+			bi.Dtbsv(blas.Lower, blas.NoTrans, blas.NonUnit, n, kd, ab, ldab, work, 1)
+			bi.Dtbsv(blas.Lower, blas.Trans, blas.NonUnit, n, kd, ab, ldab, work, 1)
+		}
+		if !allFinite(work[:n]) {
+			// The matrix is singular to working precision.
+			return 0
+		}
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dpbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix with kd super-diagonals. The factorization has the form
+//  A = U^T * U if ul == blas.Upper
+//  A = L * L^T if ul == blas.Lower
+// The storage of ab is described in the documentation of Dpbtf2. On exit,
+// U or L is stored in place into ab. Dpbtrf returns whether the factorization
+// was successfully completed.
+//
+// Dpbtrf currently uses the unblocked algorithm of Dpbtf2.
+func (impl Implementation) Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool) {
+	return impl.Dpbtf2(ul, n, kd, ab, ldab)
+}
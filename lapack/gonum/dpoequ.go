@@ -0,0 +1,52 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dpoequ computes row and column scalings intended to equilibrate a symmetric
+// positive definite n×n matrix A and reduce its condition number with respect
+// to the two-norm. The scale factors are returned in s such that the matrix
+//  B = diag(s) * A * diag(s)
+// has ones on the diagonal. This choice of s puts the condition number of B
+// within a factor n of the smallest possible condition number over all
+// possible diagonal scalings.
+//
+// Only the diagonal of A is referenced. s must have length at least n, and
+// Dpoequ will panic otherwise.
+//
+// scond is the ratio of the smallest to the largest s[i]. If scond >= 0.1 and
+// amax is neither too large nor too small, it is not worth scaling by s. amax
+// is the absolute value of the largest element of A.
+//
+// Dpoequ returns false if a diagonal element of A is not positive, in which
+// case scond and s are not valid.
+func (impl Implementation) Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool) {
+	checkMatrix(n, n, a, lda)
+	if len(s) < n {
+		panic(badS)
+	}
+
+	if n == 0 {
+		return 1, 0, true
+	}
+
+	// Find the minimum and maximum diagonal elements.
+	s[0] = a[0]
+	smin := s[0]
+	amax = s[0]
+	for i := 1; i < n; i++ {
+		s[i] = a[i*lda+i]
+		smin = math.Min(smin, s[i])
+		amax = math.Max(amax, s[i])
+	}
+	if smin <= 0 {
+		return 0, amax, false
+	}
+	for i := range s[:n] {
+		s[i] = 1 / math.Sqrt(s[i])
+	}
+	return math.Sqrt(smin) / math.Sqrt(amax), amax, true
+}
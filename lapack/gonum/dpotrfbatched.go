@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// DpotrfBatched computes the Cholesky decompositions of a batch of n×n
+// symmetric positive definite matrices A_l, l = 0, …, batchCount-1, as
+// Dpotrf does for a single matrix. If ul == blas.Upper, A_l = U_l^T * U_l is
+// computed and U_l is stored in place of the upper triangle of A_l. If
+// ul == blas.Lower, A_l = L_l * L_l^T is computed and L_l is stored in place
+// of the lower triangle of A_l.
+//
+// The l-th matrix A_l is stored in a[l*strideA:] with row stride lda. The
+// matrices must not overlap.
+//
+// If ok is not nil, it must have length at least batchCount, and ok[l] is set
+// to whether A_l is positive definite. DpotrfBatched returns whether all of
+// the matrices in the batch are positive definite.
+//
+// The factorizations are distributed across goroutines when the batch is
+// large enough, and matrices of order at most 16 are factorized by an
+// unblocked kernel that does not call BLAS routines.
+func (impl Implementation) DpotrfBatched(ul blas.Uplo, n int, a []float64, lda, strideA int, ok []bool, batchCount int) bool {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkBatch(n, n, a, lda, strideA, batchCount, true)
+	if ok != nil && len(ok) < batchCount {
+		panic(badOk)
+	}
+
+	small := n <= smallBatchOrder
+	return parallelBatch(batchCount, n*n*n/3, func(lo, hi int) bool {
+		return batchOK(ok, lo, hi, func(l int) bool {
+			if small {
+				return dpotrfSmall(ul, n, a[l*strideA:], lda)
+			}
+			return impl.Dpotrf(ul, n, a[l*strideA:], lda)
+		})
+	})
+}
+
+// dpotrfSmall computes the Cholesky decomposition of the n×n symmetric
+// positive definite matrix A in the same way as Dpotf2, but without calling
+// BLAS routines.
+func dpotrfSmall(ul blas.Uplo, n int, a []float64, lda int) (ok bool) {
+	if ul == blas.Upper {
+		for j := 0; j < n; j++ {
+			ajj := a[j*lda+j]
+			for k := 0; k < j; k++ {
+				ajj -= a[k*lda+j] * a[k*lda+j]
+			}
+			if ajj <= 0 || math.IsNaN(ajj) {
+				a[j*lda+j] = ajj
+				return false
+			}
+			ajj = math.Sqrt(ajj)
+			a[j*lda+j] = ajj
+			r := 1 / ajj
+			for i := j + 1; i < n; i++ {
+				aji := a[j*lda+i]
+				for k := 0; k < j; k++ {
+					aji -= a[k*lda+j] * a[k*lda+i]
+				}
+				a[j*lda+i] = aji * r
+			}
+		}
+		return true
+	}
+	for j := 0; j < n; j++ {
+		aj := a[j*lda : j*lda+j]
+		ajj := a[j*lda+j]
+		for _, v := range aj {
+			ajj -= v * v
+		}
+		if ajj <= 0 || math.IsNaN(ajj) {
+			a[j*lda+j] = ajj
+			return false
+		}
+		ajj = math.Sqrt(ajj)
+		a[j*lda+j] = ajj
+		r := 1 / ajj
+		for i := j + 1; i < n; i++ {
+			aij := a[i*lda+j]
+			for k, v := range a[i*lda : i*lda+j] {
+				aij -= v * aj[k]
+			}
+			a[i*lda+j] = aij * r
+		}
+	}
+	return true
+}
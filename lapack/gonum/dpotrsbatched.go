@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// DpotrsBatched solves a batch of systems of equations A_l * X_l = B_l,
+// l = 0, …, batchCount-1, where each A_l is an n×n symmetric positive definite
+// matrix represented by its Cholesky factorization
+//  A_l = U_l^T * U_l  if uplo == blas.Upper
+//  A_l = L_l * L_l^T  if uplo == blas.Lower
+// as computed by DpotrfBatched (or Dpotrf), and the B_l are n×nrhs matrices.
+//
+// The l-th factorization is stored in a[l*strideA:] with row stride lda. The
+// l-th right-hand side is stored in b[l*strideB:] with row stride ldb and is
+// overwritten by the solution X_l. strideA may be zero to use the same
+// factorization for every system. The B_l must not overlap.
+//
+// The solves are distributed across goroutines when the batch is large enough,
+// and systems of order at most 16 are solved by a kernel that does not call
+// BLAS routines.
+func (impl Implementation) DpotrsBatched(uplo blas.Uplo, n, nrhs int, a []float64, lda, strideA int, b []float64, ldb, strideB int, batchCount int) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkBatch(n, n, a, lda, strideA, batchCount, false)
+	checkBatch(n, nrhs, b, ldb, strideB, batchCount, true)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	small := n <= smallBatchOrder
+	parallelBatch(batchCount, 2*n*n*nrhs, func(lo, hi int) bool {
+		for l := lo; l < hi; l++ {
+			if small {
+				dpotrsSmall(uplo, n, nrhs, a[l*strideA:], lda, b[l*strideB:], ldb)
+			} else {
+				impl.Dpotrs(uplo, n, nrhs, a[l*strideA:], lda, b[l*strideB:], ldb)
+			}
+		}
+		return true
+	})
+}
+
+// dpotrsSmall solves a system of equations using a Cholesky factorization in
+// the same way as Dpotrs, but without calling BLAS routines.
+func dpotrsSmall(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) {
+	if uplo == blas.Upper {
+		// Solve U^T * Y = B, updating b.
+		for i := 0; i < n; i++ {
+			bi := b[i*ldb : i*ldb+nrhs]
+			for k := 0; k < i; k++ {
+				subScaledRow(bi, a[k*lda+i], b[k*ldb:k*ldb+nrhs])
+			}
+			divRow(bi, a[i*lda+i])
+		}
+		// Solve U * X = Y, updating b.
+		for i := n - 1; i >= 0; i-- {
+			bi := b[i*ldb : i*ldb+nrhs]
+			for k, v := range a[i*lda+i+1 : i*lda+n] {
+				subScaledRow(bi, v, b[(i+k+1)*ldb:(i+k+1)*ldb+nrhs])
+			}
+			divRow(bi, a[i*lda+i])
+		}
+		return
+	}
+	// Solve L * Y = B, updating b.
+	for i := 0; i < n; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for k, v := range a[i*lda : i*lda+i] {
+			subScaledRow(bi, v, b[k*ldb:k*ldb+nrhs])
+		}
+		divRow(bi, a[i*lda+i])
+	}
+	// Solve L^T * X = Y, updating b.
+	for i := n - 1; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for k := i + 1; k < n; k++ {
+			subScaledRow(bi, a[k*lda+i], b[k*ldb:k*ldb+nrhs])
+		}
+		divRow(bi, a[i*lda+i])
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dpttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A. The factorization may also be regarded as
+// having the form A = U^T*D*U.
+//
+// On entry, d contains the n diagonal elements of A and e contains the n-1
+// off-diagonal elements of A. On return, d contains the n diagonal elements of
+// the diagonal matrix D and e contains the n-1 sub-diagonal elements of the unit
+// bidiagonal factor L.
+//
+// Dpttrf returns whether the factorization was successfully completed. If ok is
+// false, the leading minor of some order is not positive definite, and the
+// factorization could not be completed.
+func (impl Implementation) Dpttrf(n int, d, e []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the L*D*L^T factorization of A.
+	for i := 0; i < n-1; i++ {
+		// Drop out of the loop if d[i] <= 0: the matrix is not positive
+		// definite.
+		if d[i] <= 0 {
+			return false
+		}
+		ei := e[i]
+		e[i] = ei / d[i]
+		d[i+1] -= e[i] * ei
+	}
+	return d[n-1] > 0
+}
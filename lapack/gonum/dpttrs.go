@@ -0,0 +1,56 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dpttrs solves a system of linear equations
+//  A * X = B
+// with an n×n symmetric positive definite tridiagonal matrix A using the
+// L*D*L^T factorization of A computed by Dpttrf.
+//
+// d and e contain the factorization of A as returned by Dpttrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, b
+// contains the solution matrix X.
+func (impl Implementation) Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 0 {
+		panic(negDimension)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Solve L * X = B.
+	for i := 1; i < n; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		bprev := b[(i-1)*ldb : (i-1)*ldb+nrhs]
+		for j := range bi {
+			bi[j] -= bprev[j] * e[i-1]
+		}
+	}
+	// Solve D * L^T * X = B.
+	bn := b[(n-1)*ldb : (n-1)*ldb+nrhs]
+	for j := range bn {
+		bn[j] /= d[n-1]
+	}
+	for i := n - 2; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+nrhs]
+		bnext := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+		for j := range bi {
+			bi[j] = bi[j]/d[i] - bnext[j]*e[i]
+		}
+	}
+}
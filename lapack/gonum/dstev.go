@@ -0,0 +1,102 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstev computes all eigenvalues and, optionally, eigenvectors of an n×n real
+// symmetric tridiagonal matrix A.
+//
+// On entry, d contains the n diagonal elements of A. On return, if ok is true,
+// d contains the eigenvalues in ascending order.
+//
+// On entry, e contains the n-1 off-diagonal elements of A. On return, the
+// contents of e are destroyed.
+//
+// If jobz == lapack.ComputeEV, z must have dimensions n×n and on return it
+// will contain the orthonormal eigenvectors of A, with the i-th column of z
+// holding the eigenvector associated with d[i]. If jobz == lapack.None, z is
+// not referenced.
+//
+// work must have length at least max(1, 2*n-2) if jobz == lapack.ComputeEV,
+// and Dstev will panic otherwise. work is not used if jobz == lapack.None.
+//
+// Dstev returns whether the algorithm converged. If ok is false, the
+// eigenvalues and eigenvectors are not valid.
+func (impl Implementation) Dstev(jobz lapack.EVJob, n int, d, e, z []float64, ldz int, work []float64) (ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	if !wantz && jobz != lapack.EVJob(lapack.None) {
+		panic(badEVJob)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+		if len(work) < max(1, 2*n-2) {
+			panic(badWork)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		if wantz {
+			z[0] = 1
+		}
+		return true
+	}
+
+	// Get machine constants.
+	const (
+		safmin = dlamchS
+		eps    = dlamchP
+		smlnum = safmin / eps
+		bignum = 1 / smlnum
+	)
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(bignum)
+
+	// Scale matrix to allowable range, if necessary.
+	var sigma float64
+	tnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if tnrm > 0 && tnrm < rmin {
+		sigma = rmin / tnrm
+	} else if tnrm > rmax {
+		sigma = rmax / tnrm
+	}
+	if sigma != 0 {
+		bi := blas64.Implementation()
+		bi.Dscal(n, sigma, d, 1)
+		bi.Dscal(n-1, sigma, e, 1)
+	}
+
+	// For eigenvalues only, call Dsterf. For eigenvalues and eigenvectors,
+	// call Dsteqr.
+	if wantz {
+		ok = impl.Dsteqr(lapack.TridiagEV, n, d, e, z, ldz, work)
+	} else {
+		ok = impl.Dsterf(n, d, e)
+	}
+
+	// If matrix was scaled, then rescale eigenvalues appropriately.
+	if sigma != 0 {
+		blas64.Implementation().Dscal(n, 1/sigma, d, 1)
+	}
+	return ok
+}
@@ -20,16 +20,26 @@ const (
 	absIncNotOne    = "lapack: increment not one or negative one"
 	badAlpha        = "lapack: bad alpha length"
 	badAuxv         = "lapack: auxv has insufficient length"
+	badBatchCount   = "lapack: batchCount < 0"
+	badBatchStride  = "lapack: bad batch stride"
 	badBeta         = "lapack: bad beta length"
+	badBerr         = "lapack: berr has insufficient length"
+	badC            = "lapack: c has insufficient length or bad values"
 	badD            = "lapack: d has insufficient length"
 	badDecompUpdate = "lapack: bad decomp update"
 	badDiag         = "lapack: bad diag"
 	badDims         = "lapack: bad input dimensions"
 	badDirect       = "lapack: bad direct"
+	badDL           = "lapack: dl has insufficient length"
+	badDU           = "lapack: du has insufficient length"
+	badDU2          = "lapack: du2 has insufficient length"
 	badE            = "lapack: e has insufficient length"
 	badEVComp       = "lapack: bad EVComp"
 	badEVJob        = "lapack: bad EVJob"
 	badEVSide       = "lapack: bad EVSide"
+	badEquil        = "lapack: bad equilibration type"
+	badFact         = "lapack: bad FactJob"
+	badFerr         = "lapack: ferr has insufficient length"
 	badGSVDJob      = "lapack: bad GSVDJob"
 	badHowMany      = "lapack: bad HowMany"
 	badIlo          = "lapack: ilo out of range"
@@ -42,7 +52,9 @@ const (
 	badLdA          = "lapack: index of a out of range"
 	badNb           = "lapack: nb out of range"
 	badNorm         = "lapack: bad norm"
+	badOk           = "lapack: ok has insufficient length"
 	badPivot        = "lapack: bad pivot"
+	badR            = "lapack: r has insufficient length or bad values"
 	badS            = "lapack: s has insufficient length"
 	badShifts       = "lapack: bad shifts"
 	badSide         = "lapack: bad side"
@@ -88,6 +100,29 @@ func checkMatrix(m, n int, a []float64, lda int) {
 	}
 }
 
+// checkBandMatrix verifies the parameters of an m×n band matrix with kl
+// sub-diagonals and ku super-diagonals stored in row-major band format.
+func checkBandMatrix(m, n, kl, ku int, ab []float64, ldab int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if kl < 0 {
+		panic("lapack: negative number of sub-diagonals")
+	}
+	if ku < 0 {
+		panic("lapack: negative number of super-diagonals")
+	}
+	if ldab < kl+ku+1 {
+		panic("lapack: stride less than number of bands")
+	}
+	if rows := min(m, n+kl); rows > 0 && len(ab) < (rows-1)*ldab+kl+ku+1 {
+		panic("lapack: insufficient band matrix slice length")
+	}
+}
+
 func checkVector(n int, v []float64, inc int) {
 	if n < 0 {
 		panic("lapack: negative vector length")
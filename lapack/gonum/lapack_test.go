@@ -18,6 +18,14 @@ func TestDbdsqr(t *testing.T) {
 	testlapack.DbdsqrTest(t, impl)
 }
 
+func TestDgttrf(t *testing.T) {
+	testlapack.DgttrfTest(t, impl)
+}
+
+func TestDgttrs(t *testing.T) {
+	testlapack.DgttrsTest(t, impl)
+}
+
 func TestDhseqr(t *testing.T) {
 	testlapack.DhseqrTest(t, impl)
 }
@@ -41,6 +49,18 @@ func TestDgebrd(t *testing.T) {
 	testlapack.DgebrdTest(t, impl)
 }
 
+func TestDgbcon(t *testing.T) {
+	testlapack.DgbconTest(t, impl)
+}
+
+func TestDgbtrf(t *testing.T) {
+	testlapack.DgbtrfTest(t, impl)
+}
+
+func TestDgbtrs(t *testing.T) {
+	testlapack.DgbtrsTest(t, impl)
+}
+
 func TestDgecon(t *testing.T) {
 	testlapack.DgeconTest(t, impl)
 }
@@ -52,6 +72,10 @@ func TestDgeev(t *testing.T) {
 	testlapack.DgeevTest(t, impl)
 }
 
+func TestDgeequ(t *testing.T) {
+	testlapack.DgeequTest(t, impl)
+}
+
 func TestDgehd2(t *testing.T) {
 	testlapack.Dgehd2Test(t, impl)
 }
@@ -96,10 +120,18 @@ func TestDgerqf(t *testing.T) {
 	testlapack.DgerqfTest(t, impl)
 }
 
+func TestDgerfs(t *testing.T) {
+	testlapack.DgerfsTest(t, impl)
+}
+
 func TestDgesvd(t *testing.T) {
 	testlapack.DgesvdTest(t, impl)
 }
 
+func TestDgesvx(t *testing.T) {
+	testlapack.DgesvxTest(t, impl)
+}
+
 func TestDgetri(t *testing.T) {
 	testlapack.DgetriTest(t, impl)
 }
@@ -116,6 +148,10 @@ func TestDgetrs(t *testing.T) {
 	testlapack.DgetrsTest(t, impl)
 }
 
+func TestDgetrfBatched(t *testing.T) {
+	testlapack.DgetrfBatchedTest(t, impl)
+}
+
 func TestDggsvd3(t *testing.T) {
 	testlapack.Dggsvd3Test(t, impl)
 }
@@ -164,6 +200,10 @@ func TestDlaln2(t *testing.T) {
 	testlapack.Dlaln2Test(t, impl)
 }
 
+func TestDlangb(t *testing.T) {
+	testlapack.DlangbTest(t, impl)
+}
+
 func TestDlange(t *testing.T) {
 	testlapack.DlangeTest(t, impl)
 }
@@ -208,6 +248,10 @@ func TestDlanst(t *testing.T) {
 	testlapack.DlanstTest(t, impl)
 }
 
+func TestDlansb(t *testing.T) {
+	testlapack.DlansbTest(t, impl)
+}
+
 func TestDlansy(t *testing.T) {
 	testlapack.DlansyTest(t, impl)
 }
@@ -372,10 +416,26 @@ func TestDpbtf2(t *testing.T) {
 	testlapack.Dpbtf2Test(t, impl)
 }
 
+func TestDpbcon(t *testing.T) {
+	testlapack.DpbconTest(t, impl)
+}
+
+func TestDpbtrf(t *testing.T) {
+	testlapack.DpbtrfTest(t, impl)
+}
+
 func TestDpocon(t *testing.T) {
 	testlapack.DpoconTest(t, impl)
 }
 
+func TestDpoequ(t *testing.T) {
+	testlapack.DpoequTest(t, impl)
+}
+
+func TestDporfs(t *testing.T) {
+	testlapack.DporfsTest(t, impl)
+}
+
 func TestDpotf2(t *testing.T) {
 	testlapack.Dpotf2Test(t, impl)
 }
@@ -384,6 +444,30 @@ func TestDpotrf(t *testing.T) {
 	testlapack.DpotrfTest(t, impl)
 }
 
+func TestDpotrs(t *testing.T) {
+	testlapack.DpotrsTest(t, impl)
+}
+
+func TestDpotrfBatched(t *testing.T) {
+	testlapack.DpotrfBatchedTest(t, impl)
+}
+
+func TestDpstf2(t *testing.T) {
+	testlapack.Dpstf2Test(t, impl)
+}
+
+func TestDpstrf(t *testing.T) {
+	testlapack.DpstrfTest(t, impl)
+}
+
+func TestDpttrf(t *testing.T) {
+	testlapack.DpttrfTest(t, impl)
+}
+
+func TestDpttrs(t *testing.T) {
+	testlapack.DpttrsTest(t, impl)
+}
+
 func TestDrscl(t *testing.T) {
 	testlapack.DrsclTest(t, impl)
 }
@@ -396,6 +480,10 @@ func TestDsterf(t *testing.T) {
 	testlapack.DsterfTest(t, impl)
 }
 
+func TestDstev(t *testing.T) {
+	testlapack.DstevTest(t, impl)
+}
+
 func TestDsyev(t *testing.T) {
 	testlapack.DsyevTest(t, impl)
 }
@@ -17,24 +17,44 @@ type Complex128 interface{}
 
 // Float64 defines the public float64 LAPACK API supported by gonum/lapack.
 type Float64 interface {
+	Dgbcon(norm MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
 	Dgecon(norm MatrixNorm, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
 	Dgeev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (first int)
 	Dgels(trans blas.Transpose, m, n, nrhs int, a []float64, lda int, b []float64, ldb int, work []float64, lwork int) bool
 	Dgelqf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
 	Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
 	Dgesvd(jobU, jobVT SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int) (ok bool)
+	Dgesvx(fact FactJob, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed EquilibrationType, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut EquilibrationType, rcond, rpvgrw float64, ok bool)
 	Dgetrf(m, n int, a []float64, lda int, ipiv []int) (ok bool)
 	Dgetri(n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
 	Dgetrs(trans blas.Transpose, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool)
+	Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int)
 	Dggsvd3(jobU, jobV, jobQ GSVDJob, m, n, p int, a []float64, lda int, b []float64, ldb int, alpha, beta, u []float64, ldu int, v []float64, ldv int, q []float64, ldq int, work []float64, lwork int, iwork []int) (k, l int, ok bool)
+	Dlangb(norm MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+	Dlansb(norm MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
 	Dlantr(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, m, n int, a []float64, lda int, work []float64) float64
 	Dlange(norm MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
 	Dlansy(norm MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dlaqsy(uplo blas.Uplo, n int, a []float64, lda int, s []float64, scond, amax float64) EquilibrationType
 	Dlapmt(forward bool, m, n int, x []float64, ldx int, k []int)
 	Dormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
 	Dormlq(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+	Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+	Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
 	Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool)
+	Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
 	Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok bool)
+	Dpotrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int)
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+	Dpttrf(n int, d, e []float64) (ok bool)
+	Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int)
+	Dstev(jobz EVJob, n int, d, e, z []float64, ldz int, work []float64) (ok bool)
 	Dsyev(jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool)
 	Dtrcon(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int, work []float64, iwork []int) float64
 	Dtrtri(uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int) (ok bool)
@@ -186,3 +206,26 @@ const (
 	AllEVMulQ  HowMany = 'B' // Compute all right and/or left eigenvectors multiplied by an input matrix.
 	SelectedEV HowMany = 'S' // Compute selected right and/or left eigenvectors.
 )
+
+// EquilibrationType specifies the scaling that has been applied to a matrix
+// to improve its condition.
+type EquilibrationType byte
+
+// EquilibrationType constants for Dlaqge, Dlaqsy and Dgesvx.
+const (
+	NoEquilibration        EquilibrationType = 'N' // No equilibration was done.
+	RowEquilibration       EquilibrationType = 'R' // A was replaced by diag(r)*A.
+	ColumnEquilibration    EquilibrationType = 'C' // A was replaced by A*diag(c).
+	RowColEquilibration    EquilibrationType = 'B' // A was replaced by diag(r)*A*diag(c).
+	SymmetricEquilibration EquilibrationType = 'Y' // A was replaced by diag(s)*A*diag(s).
+)
+
+// FactJob specifies how an expert driver obtains the factorization of A.
+type FactJob byte
+
+// FactJob constants for Dgesvx.
+const (
+	Factored             FactJob = 'F' // The factorization of A is supplied on entry.
+	FactorizeOnly        FactJob = 'N' // A is copied and factorized.
+	EquilibrateFactorize FactJob = 'E' // A is equilibrated if necessary, then copied and factorized.
+)
@@ -37,6 +37,48 @@ func Potrf(a blas64.Symmetric) (t blas64.Triangular, ok bool) {
 	return
 }
 
+// Gbcon estimates the reciprocal of the condition number of the n×n band
+// matrix A given the LU decomposition of the matrix computed by Gbtrf. The
+// condition number computed may be based on the 1-norm or the ∞-norm.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Gbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Gbcon will panic otherwise.
+func Gbcon(norm lapack.MatrixNorm, a blas64.Band, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dgbcon(norm, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
+// Gbtrf computes the LU factorization of the m×n band matrix A using partial
+// pivoting with row interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is an upper triangular band matrix with a.KL+a.KU super-diagonals.
+//
+// The fill-in of U is stored to the right of the a.KU super-diagonals of A,
+// so a.Stride must be at least 2*a.KL+a.KU+1 and Gbtrf will panic otherwise.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// changed with ipiv[i]. ipiv must have length at least min(m,n), and Gbtrf
+// will panic otherwise. ipiv is zero-indexed.
+//
+// Gbtrf returns whether the matrix A is non-singular.
+func Gbtrf(a blas64.Band, ipiv []int) bool {
+	return lapack64.Dgbtrf(a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv)
+}
+
+// Gbtrs solves a system of equations using the LU factorization of the n×n
+// band matrix A computed by Gbtrf. The system of equations solved is
+//  A * X = B if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+func Gbtrs(trans blas.Transpose, a blas64.Band, b blas64.General, ipiv []int) {
+	lapack64.Dgbtrs(trans, a.Cols, a.KL, a.KU, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
 // Gecon estimates the reciprocal of the condition number of the n×n matrix A
 // given the LU decomposition of the matrix. The condition number computed may
 // be based on the 1-norm or the ∞-norm.
@@ -52,6 +94,22 @@ func Gecon(norm lapack.MatrixNorm, a blas64.General, anorm float64, work []float
 	return lapack64.Dgecon(norm, a.Cols, a.Data, a.Stride, anorm, work, iwork)
 }
 
+// Geequ computes row and column scalings intended to equilibrate an m×n
+// matrix A and reduce its condition number. The scale factors are returned in
+// r and c such that the matrix
+//  B = diag(r) * A * diag(c)
+// has the largest element in each row and column of absolute value 1.
+//
+// r must have length at least m and c must have length at least n, and Geequ
+// will panic otherwise.
+//
+// rowcnd and colcnd are the ratios of the smallest to the largest row and
+// column scale factors, and amax is the absolute value of the largest element
+// of A. Geequ returns false if a row or a column of A is exactly zero.
+func Geequ(a blas64.General, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	return lapack64.Dgeequ(a.Rows, a.Cols, a.Data, a.Stride, r, c)
+}
+
 // Gels finds a minimum-norm solution based on the matrices A and B using the
 // QR or LQ factorization. Gels returns false if the matrix
 // A is singular, and true if this solution was successfully found.
@@ -131,6 +189,26 @@ func Gelqf(a blas64.General, tau, work []float64, lwork int) {
 	lapack64.Dgelqf(a.Rows, a.Cols, a.Data, a.Stride, tau, work, lwork)
 }
 
+// Gerfs improves the computed solution to a system of linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// using iterative refinement, and provides error bounds and backward error
+// estimates for the solution.
+//
+// a contains the original n×n matrix A, and af and ipiv contain its LU
+// factorization as computed by Getrf. On entry, x contains the solution as
+// computed by Getrs and on exit contains the improved solution.
+//
+// On return, ferr[j] and berr[j] contain the estimated forward error bound and
+// the componentwise relative backward error of the j-th column of X. ferr and
+// berr must have length at least nrhs.
+//
+// work must have length at least 3*n and iwork must have length at least n, and
+// Gerfs will panic otherwise.
+func Gerfs(trans blas.Transpose, a, af blas64.General, ipiv []int, b, x blas64.General, ferr, berr, work []float64, iwork []int) {
+	lapack64.Dgerfs(trans, a.Cols, b.Cols, a.Data, a.Stride, af.Data, af.Stride, ipiv, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
 // Gesvd computes the singular value decomposition of the input matrix A.
 //
 // The singular value decomposition is
@@ -177,6 +255,23 @@ func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt blas64.General, s, work []float64
 	return lapack64.Dgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork)
 }
 
+// Gesvx uses the LU factorization to compute the solution to a real system of
+// linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// optionally equilibrating A first, and refines the solution iteratively.
+// Error bounds on the solution and a condition estimate are also provided.
+//
+// See the documentation of the lapack.Float64 implementation of Dgesvx for
+// the full description of the parameters.
+//
+// Gesvx returns the type of equilibration that was done on A, the estimate of
+// the reciprocal condition number of the equilibrated A, the reciprocal pivot
+// growth factor, and whether A was found to be non-singular.
+func Gesvx(fact lapack.FactJob, trans blas.Transpose, a, af blas64.General, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b, x blas64.General, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	return lapack64.Dgesvx(fact, trans, a.Cols, b.Cols, a.Data, a.Stride, af.Data, af.Stride, ipiv, equed, r, c, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
 // Getrf computes the LU decomposition of the m×n matrix A.
 // The LU decomposition is a factorization of A into
 //  A = P * L * U
@@ -229,6 +324,32 @@ func Getrs(trans blas.Transpose, a blas64.General, b blas64.General, ipiv []int)
 	lapack64.Dgetrs(trans, a.Cols, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
 }
 
+// Gttrf computes an LU factorization of an n×n tridiagonal matrix A using
+// elimination with partial pivoting and row interchanges, where n = len(d).
+// dl, d and du contain the sub-diagonal, diagonal and super-diagonal of A on
+// entry, and the factorization of A on return. du2 receives the second
+// super-diagonal of U. dl and du must have length n-1, du2 must have length at
+// least n-2 and ipiv must have length n.
+//
+// Gttrf returns whether the factor U is non-singular.
+func Gttrf(dl, d, du, du2 []float64, ipiv []int) (ok bool) {
+	n := len(d)
+	if n > 0 && (len(dl) != n-1 || len(du) != n-1) || len(ipiv) != n {
+		panic("lapack64: bad tridiagonal length")
+	}
+	return lapack64.Dgttrf(n, dl, d, du, du2, ipiv)
+}
+
+// Gttrs solves one of the systems of equations
+//  A * X = B   if trans == blas.NoTrans,
+//  A^T * X = B if trans == blas.Trans or blas.ConjTrans,
+// with an n×n tridiagonal matrix A using the LU factorization computed by
+// Gttrf. On entry, b contains the elements of B and on exit contains the
+// solution X.
+func Gttrs(trans blas.Transpose, dl, d, du, du2 []float64, ipiv []int, b blas64.General) {
+	lapack64.Dgttrs(trans, len(d), b.Cols, dl, d, du, du2, ipiv, b.Data, b.Stride)
+}
+
 // Ggsvd3 computes the generalized singular value decomposition (GSVD)
 // of an m×n matrix A and p×n matrix B:
 //  U^T*A*Q = D1*[ 0 R ]
@@ -341,6 +462,22 @@ func Lange(norm lapack.MatrixNorm, a blas64.General, work []float64) float64 {
 	return lapack64.Dlange(norm, a.Rows, a.Cols, a.Data, a.Stride, work)
 }
 
+// Langb computes the matrix norm of the general m×n band matrix A. The input
+// norm specifies the norm computed.
+// If norm == lapack.MaxColumnSum, work must be of length n, and this function will panic otherwise.
+// There are no restrictions on work for the other matrix norms.
+func Langb(norm lapack.MatrixNorm, a blas64.Band, work []float64) float64 {
+	return lapack64.Dlangb(norm, a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, work)
+}
+
+// Lansb computes the specified norm of an n×n symmetric band matrix. If
+// norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum work must have length
+// at least n and this function will panic otherwise.
+// There are no restrictions on work for the other matrix norms.
+func Lansb(norm lapack.MatrixNorm, a blas64.SymmetricBand, work []float64) float64 {
+	return lapack64.Dlansb(norm, a.Uplo, a.N, a.K, a.Data, a.Stride, work)
+}
+
 // Lansy computes the specified norm of an n×n symmetric matrix. If
 // norm == lapack.MaxColumnSum or norm == lapackMaxRowSum work must have length
 // at least n and this function will panic otherwise.
@@ -356,6 +493,14 @@ func Lantr(norm lapack.MatrixNorm, a blas64.Triangular, work []float64) float64
 	return lapack64.Dlantr(norm, a.Uplo, a.Diag, a.N, a.N, a.Data, a.Stride, work)
 }
 
+// Laqsy equilibrates a symmetric matrix A using the scaling factors in s, as
+// computed by Poequ. scond and amax must be the values returned by Poequ.
+// Laqsy returns lapack.SymmetricEquilibration if A was replaced by
+// diag(s)*A*diag(s), and lapack.NoEquilibration if scaling was not worth doing.
+func Laqsy(a blas64.Symmetric, s []float64, scond, amax float64) lapack.EquilibrationType {
+	return lapack64.Dlaqsy(a.Uplo, a.N, a.Data, a.Stride, s, scond, amax)
+}
+
 // Lapmt rearranges the columns of the m×n matrix X as specified by the
 // permutation k_0, k_1, ..., k_{n-1} of the integers 0, ..., n-1.
 //
@@ -425,6 +570,38 @@ func Ormqr(side blas.Side, trans blas.Transpose, a blas64.General, tau []float64
 	lapack64.Dormqr(side, trans, c.Rows, c.Cols, a.Cols, a.Data, a.Stride, tau, c.Data, c.Stride, work, lwork)
 }
 
+// Pbcon estimates the reciprocal of the condition number of a positive-definite
+// band matrix A given the Cholesky decomposition of A computed by Pbtrf. The
+// condition number computed is based on the 1-norm and the ∞-norm.
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Pbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Pbcon will panic otherwise.
+func Pbcon(a blas64.SymmetricBand, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dpbcon(a.Uplo, a.N, a.K, a.Data, a.Stride, anorm, work, iwork)
+}
+
+// Pbtrf computes the Cholesky factorization of the symmetric positive definite
+// band matrix a. The factorization has the form
+//  A = U^T * U if a.Uplo == blas.Upper, or
+//  A = L * L^T if a.Uplo == blas.Lower,
+// where U and L are triangular band matrices with a.K diagonals off the main
+// diagonal. The triangular matrix is returned in t, and the underlying data
+// between a and t is shared. The returned bool indicates whether a is positive
+// definite and the factorization could be finished.
+func Pbtrf(a blas64.SymmetricBand) (t blas64.TriangularBand, ok bool) {
+	ok = lapack64.Dpbtrf(a.Uplo, a.N, a.K, a.Data, a.Stride)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.K = a.K
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, ok
+}
+
 // Pocon estimates the reciprocal of the condition number of a positive-definite
 // matrix A given the Cholesky decmposition of A. The condition number computed
 // is based on the 1-norm and the ∞-norm.
@@ -438,6 +615,117 @@ func Pocon(a blas64.Symmetric, anorm float64, work []float64, iwork []int) float
 	return lapack64.Dpocon(a.Uplo, a.N, a.Data, a.Stride, anorm, work, iwork)
 }
 
+// Poequ computes row and column scalings intended to equilibrate a symmetric
+// positive definite matrix A and reduce its condition number. The scale
+// factors are returned in s such that the matrix
+//  B = diag(s) * A * diag(s)
+// has ones on the diagonal.
+//
+// scond is the ratio of the smallest to the largest s[i] and amax is the
+// largest element of A. Poequ returns false if a diagonal element of A is not
+// positive.
+func Poequ(a blas64.Symmetric, s []float64) (scond, amax float64, ok bool) {
+	return lapack64.Dpoequ(a.N, a.Data, a.Stride, s)
+}
+
+// Porfs improves the computed solution to a system of linear equations
+//  A * X = B
+// where A is symmetric positive definite, using iterative refinement, and
+// provides error bounds and backward error estimates for the solution.
+//
+// t must contain the Cholesky factorization of A as computed by Potrf, with
+// t.Uplo equal to a.Uplo. On entry, x contains the solution as computed by
+// Potrs and on exit contains the improved solution.
+//
+// On return, ferr[j] and berr[j] contain the estimated forward error bound and
+// the componentwise relative backward error of the j-th column of X. ferr and
+// berr must have length at least nrhs.
+//
+// work must have length at least 3*n and iwork must have length at least n, and
+// Porfs will panic otherwise.
+func Porfs(a blas64.Symmetric, t blas64.Triangular, b, x blas64.General, ferr, berr, work []float64, iwork []int) {
+	if t.Uplo != a.Uplo {
+		panic("lapack64: mismatched triangles")
+	}
+	lapack64.Dporfs(a.Uplo, a.N, b.Cols, a.Data, a.Stride, t.Data, t.Stride, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
+// Potrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization of A contained in t as computed by Potrf. On entry, b
+// contains the elements of B and on exit contains the solution X.
+func Potrs(t blas64.Triangular, b blas64.General) {
+	lapack64.Dpotrs(t.Uplo, t.N, b.Cols, t.Data, t.Stride, b.Data, b.Stride)
+}
+
+// Pstrf computes the Cholesky factorization with complete pivoting of an n×n
+// symmetric positive semidefinite matrix A. The factorization has the form
+//  P^T * A * P = U^T * U  if a.Uplo == blas.Upper,
+//  P^T * A * P = L * L^T  if a.Uplo == blas.Lower,
+// where U is an upper triangular matrix, L is lower triangular, and P is a
+// permutation matrix. The permutation is stored in piv such that P[piv[k],k] = 1.
+//
+// tol is the user-defined tolerance used to determine the rank of A. If tol is
+// negative, n*eps*max(A[k,k]) is used instead.
+//
+// work must have length at least 2*n, and Pstrf will panic otherwise.
+//
+// The returned rank is the computed rank of A and only the leading rank rows
+// of U (or columns of L) are valid. Pstrf returns false if A is rank deficient
+// or not positive semidefinite.
+func Pstrf(a blas64.Symmetric, piv []int, tol float64, work []float64) (t blas64.Triangular, rank int, ok bool) {
+	rank, ok = lapack64.Dpstrf(a.Uplo, a.N, a.Data, a.Stride, piv, tol, work)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, rank, ok
+}
+
+// Pttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A, where n = len(d). d and e contain the diagonal
+// and off-diagonal elements of A on entry, and the diagonal of D and the
+// sub-diagonal of the unit bidiagonal factor L on return. e must have length
+// n-1.
+//
+// Pttrf returns whether A is positive definite.
+func Pttrf(d, e []float64) (ok bool) {
+	n := len(d)
+	if n > 0 && len(e) != n-1 {
+		panic("lapack64: bad tridiagonal length")
+	}
+	return lapack64.Dpttrf(n, d, e)
+}
+
+// Pttrs solves a system of linear equations A * X = B with an n×n symmetric
+// positive definite tridiagonal matrix A using the L*D*L^T factorization of A
+// computed by Pttrf. On entry, b contains the elements of B and on exit
+// contains the solution X.
+func Pttrs(d, e []float64, b blas64.General) {
+	lapack64.Dpttrs(len(d), b.Cols, d, e, b.Data, b.Stride)
+}
+
+// Stev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// real symmetric tridiagonal matrix A, where n = len(d).
+//
+// On entry, d and e contain the diagonal and off-diagonal elements of A. e must
+// have length n-1. On return, d contains the eigenvalues in ascending order and
+// the contents of e are destroyed.
+//
+// If jobz == lapack.ComputeEV, z must be n×n and on return contains the
+// orthonormal eigenvectors of A in its columns, and work must have length at
+// least max(1, 2*n-2).
+//
+// Stev returns whether the algorithm converged.
+func Stev(jobz lapack.EVJob, d, e []float64, z blas64.General, work []float64) (ok bool) {
+	n := len(d)
+	if n > 0 && len(e) != n-1 {
+		panic("lapack64: bad tridiagonal length")
+	}
+	return lapack64.Dstev(jobz, n, d, e, z.Data, z.Stride, work)
+}
+
 // Syev computes all eigenvalues and, optionally, the eigenvectors of a real
 // symmetric matrix A.
 //
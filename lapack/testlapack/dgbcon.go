@@ -0,0 +1,130 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgbconer interface {
+	Dgeconer
+	Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DgbconTest(t *testing.T, impl Dgbconer) {
+	// Compare against the reference LAPACK values for full matrices stored
+	// in band format.
+	for i, test := range []struct {
+		n       int
+		a       []float64
+		condOne float64
+		condInf float64
+	}{
+		{
+			a: []float64{
+				8, 1, 6,
+				3, 5, 7,
+				4, 9, 2,
+			},
+			n:       3,
+			condOne: 3.0 / 16,
+			condInf: 3.0 / 16,
+		},
+		{
+			a: []float64{
+				2, 9, 3, 2,
+				10, 9, 9, 3,
+				1, 1, 5, 2,
+				8, 4, 10, 2,
+			},
+			n:       4,
+			condOne: 0.024740155174938,
+			condInf: 0.012034465570035,
+		},
+		{
+			a: []float64{
+				2.9995576045549965, -2.0898894566158663, 3.965560740124006,
+				-2.0898894566158663, 1.9634729526261008, -2.8681002706874104,
+				3.965560740124006, -2.8681002706874104, 5.502416670471008,
+			},
+			n:       3,
+			condOne: 0.024054837369015203,
+			condInf: 0.024054837369015203,
+		},
+	} {
+		n := test.n
+		kl, ku := n-1, n-1
+		ldab := 2*kl + ku + 1
+		for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+			ab := make([]float64, n*ldab)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					ab[i*ldab+kl+j-i] = test.a[i*n+j]
+				}
+			}
+			work := make([]float64, 3*n)
+			iwork := make([]int, n)
+			ipiv := make([]int, n)
+			anorm := impl.Dlangb(norm, n, n, kl, ku, ab, ldab, work)
+			impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+			got := impl.Dgbcon(norm, n, kl, ku, ab, ldab, ipiv, anorm, work, iwork)
+			want := test.condOne
+			if norm == lapack.MaxRowSum {
+				want = test.condInf
+			}
+			if !floats.EqualWithinAbsOrRel(got, want, 1e-14, 1e-14) {
+				t.Errorf("Case %d: unexpected rcond for norm=%c: want %v, got %v", i, norm, want, got)
+			}
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, test := range []struct {
+			n, kl, ku int
+		}{
+			{0, 0, 0},
+			{1, 0, 0},
+			{5, 0, 0},
+			{5, 1, 1},
+			{5, 0, 1},
+			{5, 2, 0},
+			{6, 2, 1},
+			{6, 1, 3},
+			{10, 9, 9},
+			{30, 4, 3},
+		} {
+			for _, ldoff := range []int{0, 3} {
+				n, kl, ku := test.n, test.kl, test.ku
+				ldab := 2*kl + ku + 1 + ldoff
+				ab, a := randBand(n, n, kl, ku, ldab, rnd)
+
+				work := make([]float64, 4*n)
+				iwork := make([]int, n)
+				anorm := impl.Dlangb(norm, n, n, kl, ku, ab, ldab, work)
+				ipiv := make([]int, n)
+				impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+				got := impl.Dgbcon(norm, n, kl, ku, ab, ldab, ipiv, anorm, work, iwork)
+
+				// The band and dense factorizations are identical, so the
+				// estimates of Dgbcon and Dgecon should agree.
+				impl.Dgetrf(n, n, a.Data, a.Stride, ipiv)
+				want := impl.Dgecon(norm, n, a.Data, a.Stride, anorm, work, iwork)
+
+				if !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+					prefix := fmt.Sprintf("norm=%c,n=%v,kl=%v,ku=%v,ldab=%v", norm, n, kl, ku, ldab)
+					t.Errorf("%v: unexpected rcond: want %v, got %v", prefix, want, got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dgbtrfer interface {
+	Dgetrfer
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+}
+
+func DgbtrfTest(t *testing.T, impl Dgbtrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, kl, ku int
+	}{
+		{0, 0, 0, 0},
+		{1, 1, 0, 0},
+		{5, 5, 0, 0},
+		{5, 5, 1, 1},
+		{6, 6, 2, 1},
+		{6, 6, 1, 3},
+		{4, 7, 2, 2},
+		{7, 4, 2, 2},
+		{8, 5, 3, 0},
+		{10, 10, 9, 9},
+		{30, 30, 4, 3},
+	} {
+		for _, ldoff := range []int{0, 3} {
+			m, n, kl, ku := test.m, test.n, test.kl, test.ku
+			ldab := 2*kl + ku + 1 + ldoff
+			ab, a := randBand(m, n, kl, ku, ldab, rnd)
+
+			// Compute the LU factorization of the dense matrix. Partial
+			// pivoting selects the same pivots in both cases.
+			mn := min(m, n)
+			ipivWant := make([]int, mn)
+			okWant := impl.Dgetrf(m, n, a.Data, a.Stride, ipivWant)
+
+			ipiv := make([]int, mn)
+			ok := impl.Dgbtrf(m, n, kl, ku, ab, ldab, ipiv)
+
+			prefix := fmt.Sprintf("m=%v,n=%v,kl=%v,ku=%v,ldab=%v", m, n, kl, ku, ldab)
+			// Dgetrf reports an empty matrix as singular.
+			if mn > 0 && ok != okWant {
+				t.Errorf("%v: unexpected ok: want %v, got %v", prefix, okWant, ok)
+			}
+			if !intsEqual(ipiv, ipivWant) {
+				t.Errorf("%v: unexpected ipiv: want %v, got %v", prefix, ipivWant, ipiv)
+			}
+			// Compare U.
+			for i := 0; i < mn; i++ {
+				for j := i; j < min(n, i+kl+ku+1); j++ {
+					got := ab[i*ldab+kl+j-i]
+					want := a.Data[i*a.Stride+j]
+					if !floats.EqualWithinAbsOrRel(got, want, 1e-13, 1e-13) {
+						t.Errorf("%v: unexpected U[%v,%v]: want %v, got %v", prefix, i, j, want, got)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgbtrser interface {
+	Dgbtrfer
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
+}
+
+func DgbtrsTest(t *testing.T, impl Dgbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, test := range []struct {
+			n, kl, ku, nrhs int
+		}{
+			{0, 0, 0, 1},
+			{1, 0, 0, 1},
+			{5, 0, 0, 2},
+			{5, 1, 1, 1},
+			{5, 0, 1, 2},
+			{5, 2, 0, 2},
+			{6, 2, 1, 3},
+			{6, 1, 3, 3},
+			{10, 9, 9, 2},
+			{30, 4, 3, 5},
+		} {
+			for _, ldoff := range []int{0, 3} {
+				n, kl, ku, nrhs := test.n, test.kl, test.ku, test.nrhs
+				ldab := 2*kl + ku + 1 + ldoff
+				ab, a := randBand(n, n, kl, ku, ldab, rnd)
+				// Make A diagonally dominant so that the solution is accurate.
+				for i := 0; i < n; i++ {
+					a.Data[i*a.Stride+i] += float64(kl + ku + 1)
+					ab[i*ldab+kl] = a.Data[i*a.Stride+i]
+				}
+
+				// Construct the right-hand side from a known solution.
+				ldb := nrhs + ldoff
+				want := randomGeneral(n, nrhs, ldb, rnd)
+				b := zeros(n, nrhs, ldb)
+				if n > 0 && nrhs > 0 {
+					blas64.Gemm(trans, blas.NoTrans, 1, a, want, 0, b)
+				}
+
+				ipiv := make([]int, n)
+				impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+				impl.Dgbtrs(trans, n, kl, ku, nrhs, ab, ldab, ipiv, b.Data, b.Stride)
+
+				if !equalApproxGeneral(b, want, 1e-12) {
+					prefix := fmt.Sprintf("trans=%v,n=%v,kl=%v,ku=%v,nrhs=%v,ldab=%v", trans, n, kl, ku, nrhs, ldab)
+					t.Errorf("%v: unexpected solution", prefix)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dgeequer interface {
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
+}
+
+func DgeequTest(t *testing.T, impl Dgeequer) {
+	const tol = 1e-14
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 21} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 21} {
+			for _, lda := range []int{n, n + 5} {
+				lda = max(1, lda)
+				name := fmt.Sprintf("m=%v,n=%v,lda=%v", m, n, lda)
+
+				// Construct a badly scaled matrix.
+				a := make([]float64, m*lda)
+				for i := 0; i < m; i++ {
+					rs := math.Pow(10, float64(rnd.Intn(21)-10))
+					for j := 0; j < n; j++ {
+						a[i*lda+j] = rs * rnd.NormFloat64()
+					}
+				}
+				for j := 0; j < n; j++ {
+					cs := math.Pow(10, float64(rnd.Intn(21)-10))
+					for i := 0; i < m; i++ {
+						a[i*lda+j] *= cs
+					}
+				}
+				aCopy := make([]float64, len(a))
+				copy(aCopy, a)
+
+				r := make([]float64, m)
+				c := make([]float64, n)
+				rowcnd, colcnd, amax, ok := impl.Dgeequ(m, n, a, lda, r, c)
+				if !ok {
+					t.Errorf("%v: unexpected failure", name)
+					continue
+				}
+				if !floats.Equal(a, aCopy) {
+					t.Errorf("%v: unexpected modification of A", name)
+				}
+				if m == 0 || n == 0 {
+					if rowcnd != 1 || colcnd != 1 || amax != 0 {
+						t.Errorf("%v: unexpected result for empty matrix", name)
+					}
+					continue
+				}
+
+				var wantAmax float64
+				for i := 0; i < m; i++ {
+					for j := 0; j < n; j++ {
+						wantAmax = math.Max(wantAmax, math.Abs(a[i*lda+j]))
+					}
+				}
+				if amax != wantAmax {
+					t.Errorf("%v: unexpected amax; got %v, want %v", name, amax, wantAmax)
+				}
+				if want := floats.Min(r) / floats.Max(r); math.Abs(rowcnd-want) > tol*want {
+					t.Errorf("%v: unexpected rowcnd; got %v, want %v", name, rowcnd, want)
+				}
+				if want := floats.Min(c) / floats.Max(c); math.Abs(colcnd-want) > tol*want {
+					t.Errorf("%v: unexpected colcnd; got %v, want %v", name, colcnd, want)
+				}
+
+				// Check that every row and column of diag(r)*A*diag(c)
+				// has its largest element of absolute value at most 1
+				// and that every column attains it.
+				rowMax := make([]float64, m)
+				colMax := make([]float64, n)
+				for i := 0; i < m; i++ {
+					for j := 0; j < n; j++ {
+						v := math.Abs(r[i] * a[i*lda+j] * c[j])
+						rowMax[i] = math.Max(rowMax[i], v)
+						colMax[j] = math.Max(colMax[j], v)
+					}
+				}
+				for i, v := range rowMax {
+					if v > 1+tol {
+						t.Errorf("%v: row %v of scaled matrix has max %v", name, i, v)
+					}
+				}
+				for j, v := range colMax {
+					if math.Abs(v-1) > tol {
+						t.Errorf("%v: column %v of scaled matrix has max %v, want 1", name, j, v)
+					}
+				}
+
+				// Check that a zero row or column is detected.
+				if m > 1 {
+					i := rnd.Intn(m)
+					for j := 0; j < n; j++ {
+						a[i*lda+j] = 0
+					}
+					_, _, _, ok = impl.Dgeequ(m, n, a, lda, r, c)
+					if ok {
+						t.Errorf("%v: unexpected success with zero row", name)
+					}
+					copy(a, aCopy)
+				}
+				if n > 1 {
+					j := rnd.Intn(n)
+					for i := 0; i < m; i++ {
+						a[i*lda+j] = 0
+					}
+					_, _, _, ok = impl.Dgeequ(m, n, a, lda, r, c)
+					if ok {
+						t.Errorf("%v: unexpected success with zero column", name)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgerfser interface {
+	Dgetrser
+	Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+}
+
+func DgerfsTest(t *testing.T, impl Dgerfser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 30, 100} {
+			for _, nrhs := range []int{0, 1, 2, 5} {
+				for _, ld := range []int{0, 5} {
+					dgerfsTest(t, impl, rnd, trans, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func dgerfsTest(t *testing.T, impl Dgerfser, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ld int) {
+	lda := max(1, n+ld)
+	ldaf := max(1, n+ld)
+	ldb := max(1, nrhs+ld)
+	ldx := max(1, nrhs+ld)
+	name := fmt.Sprintf("trans=%v,n=%v,nrhs=%v,ld=%v", trans == blas.Trans, n, nrhs, ld)
+
+	// Construct a random matrix A with a moderate condition number.
+	d := make([]float64, n)
+	Dlatm1(d, 3, 1000, false, 1, rnd)
+	a := make([]float64, n*lda)
+	Dlagge(n, n, max(0, n-1), max(0, n-1), d, a, lda, rnd, make([]float64, 3*n))
+
+	// Construct the right-hand side from a known solution.
+	xWant := make([]float64, n*ldx)
+	for i := range xWant {
+		xWant[i] = rnd.NormFloat64()
+	}
+	b := make([]float64, n*ldb)
+	bi := blas64.Implementation()
+	if trans == blas.NoTrans {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nrhs, n, 1, a, lda, xWant, ldx, 0, b, ldb)
+	} else {
+		bi.Dgemm(blas.Trans, blas.NoTrans, n, nrhs, n, 1, a, lda, xWant, ldx, 0, b, ldb)
+	}
+
+	af := make([]float64, n*ldaf)
+	for i := 0; i < n; i++ {
+		copy(af[i*ldaf:i*ldaf+n], a[i*lda:i*lda+n])
+	}
+	ipiv := make([]int, n)
+	ok := impl.Dgetrf(n, n, af, ldaf, ipiv)
+	if !ok && n > 0 {
+		t.Errorf("%v: unexpected failure of Dgetrf", name)
+		return
+	}
+	x := make([]float64, n*ldx)
+	for i := 0; i < n; i++ {
+		copy(x[i*ldx:i*ldx+nrhs], b[i*ldb:i*ldb+nrhs])
+	}
+	impl.Dgetrs(trans, n, nrhs, af, ldaf, ipiv, x, ldx)
+
+	// Perturb the solution so that refinement has work to do.
+	for i := range x {
+		x[i] *= 1 + 1e-6*rnd.NormFloat64()
+	}
+
+	ferr := make([]float64, nrhs)
+	berr := make([]float64, nrhs)
+	work := make([]float64, 3*n)
+	iwork := make([]int, n)
+	impl.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	checkRefinedSolution(t, name, n, nrhs, x, ldx, xWant, ldx, ferr, berr)
+}
+
+// checkRefinedSolution checks that the solution x computed by an iterative
+// refinement routine is close to xWant, that the backward errors are small and
+// that the forward error bounds are not exceeded.
+func checkRefinedSolution(t *testing.T, name string, n, nrhs int, x []float64, ldx int, xWant []float64, ldxWant int, ferr, berr []float64) {
+	const (
+		berrTol = 1e-14
+		ferrTol = 1e-10
+	)
+	for j := 0; j < nrhs; j++ {
+		if berr[j] > berrTol {
+			t.Errorf("%v: backward error %v too large for column %v", name, berr[j], j)
+		}
+		if ferr[j] > ferrTol {
+			t.Errorf("%v: forward error bound %v too large for column %v", name, ferr[j], j)
+		}
+		var diff, xmax float64
+		for i := 0; i < n; i++ {
+			diff = math.Max(diff, math.Abs(x[i*ldx+j]-xWant[i*ldxWant+j]))
+			xmax = math.Max(xmax, math.Abs(x[i*ldx+j]))
+		}
+		if xmax == 0 {
+			continue
+		}
+		if err := diff / xmax; err > ferr[j] {
+			t.Errorf("%v: forward error %v exceeds bound %v for column %v", name, err, ferr[j], j)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesvxer interface {
+	Dgesvx(fact lapack.FactJob, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool)
+}
+
+func DgesvxTest(t *testing.T, impl Dgesvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 30} {
+			for _, nrhs := range []int{1, 2, 5} {
+				for _, ld := range []int{0, 5} {
+					for _, scaled := range []bool{false, true} {
+						dgesvxTest(t, impl, rnd, trans, n, nrhs, ld, scaled)
+					}
+				}
+			}
+		}
+	}
+	dgesvxSingularTest(t, impl)
+}
+
+func dgesvxTest(t *testing.T, impl Dgesvxer, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ld int, scaled bool) {
+	const berrTol = 1e-14
+
+	lda := max(1, n+ld)
+	ldaf := max(1, n+ld)
+	ldb := max(1, nrhs+ld)
+	ldx := max(1, nrhs+ld)
+
+	// Construct a random matrix A with a moderate condition number and
+	// optionally scale its rows and columns badly.
+	d := make([]float64, n)
+	Dlatm1(d, 3, 100, false, 1, rnd)
+	aOrig := make([]float64, n*lda)
+	Dlagge(n, n, max(0, n-1), max(0, n-1), d, aOrig, lda, rnd, make([]float64, 2*n))
+	if scaled {
+		for i := 0; i < n; i++ {
+			rs := math.Pow(10, float64(rnd.Intn(13)-6))
+			for j := 0; j < n; j++ {
+				aOrig[i*lda+j] *= rs
+			}
+		}
+		for j := 0; j < n; j++ {
+			cs := math.Pow(10, float64(rnd.Intn(13)-6))
+			for i := 0; i < n; i++ {
+				aOrig[i*lda+j] *= cs
+			}
+		}
+	}
+
+	// Construct the right-hand side from a known solution.
+	xWant := make([]float64, n*ldx)
+	for i := range xWant {
+		xWant[i] = rnd.NormFloat64()
+	}
+	bOrig := make([]float64, n*ldb)
+	bi := blas64.Implementation()
+	bi.Dgemm(trans, blas.NoTrans, n, nrhs, n, 1, aOrig, lda, xWant, ldx, 0, bOrig, ldb)
+
+	a := make([]float64, len(aOrig))
+	af := make([]float64, n*ldaf)
+	ipiv := make([]int, n)
+	r := make([]float64, n)
+	c := make([]float64, n)
+	b := make([]float64, len(bOrig))
+	x := make([]float64, n*ldx)
+	ferr := make([]float64, nrhs)
+	berr := make([]float64, nrhs)
+	work := make([]float64, 4*n)
+	iwork := make([]int, n)
+
+	check := func(name string) {
+		for j := 0; j < nrhs; j++ {
+			if berr[j] > berrTol {
+				t.Errorf("%v: backward error %v too large for column %v", name, berr[j], j)
+			}
+			var diff, xmax float64
+			for i := 0; i < n; i++ {
+				diff = math.Max(diff, math.Abs(x[i*ldx+j]-xWant[i*ldx+j]))
+				xmax = math.Max(xmax, math.Abs(x[i*ldx+j]))
+			}
+			if xmax == 0 {
+				continue
+			}
+			if err := diff / xmax; err > ferr[j] {
+				t.Errorf("%v: forward error %v exceeds bound %v for column %v", name, err, ferr[j], j)
+			}
+		}
+	}
+
+	for _, fact := range []lapack.FactJob{lapack.FactorizeOnly, lapack.EquilibrateFactorize} {
+		name := fmt.Sprintf("fact=%c,trans=%v,n=%v,nrhs=%v,ld=%v,scaled=%v", fact, trans == blas.Trans, n, nrhs, ld, scaled)
+
+		copy(a, aOrig)
+		copy(b, bOrig)
+		equed, rcond, rpvgrw, ok := impl.Dgesvx(fact, trans, n, nrhs, a, lda, af, ldaf, ipiv, lapack.NoEquilibration, r, c, b, ldb, x, ldx, ferr, berr, work, iwork)
+		if !ok {
+			t.Errorf("%v: unexpected failure", name)
+			continue
+		}
+		if fact == lapack.FactorizeOnly && equed != lapack.NoEquilibration {
+			t.Errorf("%v: unexpected equilibration %c", name, equed)
+		}
+		if rcond <= 0 || rcond > 1 {
+			t.Errorf("%v: unexpected rcond %v", name, rcond)
+		}
+		if rpvgrw <= 0 {
+			t.Errorf("%v: unexpected rpvgrw %v", name, rpvgrw)
+		}
+		if fact == lapack.EquilibrateFactorize && rcond < 1e-6 {
+			t.Errorf("%v: equilibrated matrix badly conditioned; rcond=%v", name, rcond)
+		}
+		check(name)
+
+		// Solve again reusing the factorization and equilibration.
+		name = fmt.Sprintf("fact=F(%c),trans=%v,n=%v,nrhs=%v,ld=%v,scaled=%v", fact, trans == blas.Trans, n, nrhs, ld, scaled)
+		copy(b, bOrig)
+		for i := range x {
+			x[i] = math.NaN()
+		}
+		equed2, rcond2, _, ok := impl.Dgesvx(lapack.Factored, trans, n, nrhs, a, lda, af, ldaf, ipiv, equed, r, c, b, ldb, x, ldx, ferr, berr, work, iwork)
+		if !ok {
+			t.Errorf("%v: unexpected failure", name)
+			continue
+		}
+		if equed2 != equed {
+			t.Errorf("%v: unexpected equilibration; got %c, want %c", name, equed2, equed)
+		}
+		if math.Abs(rcond2-rcond) > 1e-14*rcond {
+			t.Errorf("%v: unexpected rcond; got %v, want %v", name, rcond2, rcond)
+		}
+		check(name)
+	}
+}
+
+func dgesvxSingularTest(t *testing.T, impl Dgesvxer) {
+	const n = 4
+	a := []float64{
+		1, 2, 0, 4,
+		5, 6, 0, 8,
+		9, 10, 0, 12,
+		13, 14, 0, 16,
+	}
+	b := []float64{1, 2, 3, 4}
+	x := make([]float64, n)
+	af := make([]float64, n*n)
+	ipiv := make([]int, n)
+	r := make([]float64, n)
+	c := make([]float64, n)
+	ferr := make([]float64, 1)
+	berr := make([]float64, 1)
+	work := make([]float64, 4*n)
+	iwork := make([]int, n)
+	for _, fact := range []lapack.FactJob{lapack.FactorizeOnly, lapack.EquilibrateFactorize} {
+		_, rcond, rpvgrw, ok := impl.Dgesvx(fact, blas.NoTrans, n, 1, a, n, af, n, ipiv, lapack.NoEquilibration, r, c, b, 1, x, 1, ferr, berr, work, iwork)
+		if ok {
+			t.Errorf("fact=%c: unexpected success for singular matrix", fact)
+		}
+		if rcond != 0 {
+			t.Errorf("fact=%c: unexpected rcond for singular matrix; got %v, want 0", fact, rcond)
+		}
+		if rpvgrw <= 0 {
+			t.Errorf("fact=%c: unexpected rpvgrw %v", fact, rpvgrw)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+)
+
+type DgetrfBatcheder interface {
+	Dgetrser
+	DgetrfBatched(m, n int, a []float64, lda, strideA int, ipiv []int, strideIpiv int, ok []bool, batchCount int) bool
+	DgetrsBatched(trans blas.Transpose, n, nrhs int, a []float64, lda, strideA int, ipiv []int, strideIpiv int, b []float64, ldb, strideB int, batchCount int)
+}
+
+func DgetrfBatchedTest(t *testing.T, impl DgetrfBatcheder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{0, 0}, {0, 3}, {3, 0},
+		{1, 1}, {2, 2}, {3, 3}, {4, 4}, {5, 5}, {8, 8}, {16, 16}, {17, 17}, {70, 70},
+		{5, 3}, {3, 5}, {20, 17},
+	} {
+		for _, count := range []int{0, 1, 4, 100} {
+			if count > 4 && test.n > 20 {
+				continue
+			}
+			for _, pad := range []int{0, 3} {
+				dgetrfBatchedTest(t, impl, rnd, test.m, test.n, test.n+pad, test.m*(test.n+pad)+pad, count)
+			}
+		}
+	}
+}
+
+func dgetrfBatchedTest(t *testing.T, impl DgetrfBatcheder, rnd *rand.Rand, m, n, lda, strideA, count int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("m=%v,n=%v,lda=%v,strideA=%v,count=%v", m, n, lda, strideA, count)
+
+	mn := min(m, n)
+	lda = max(1, lda)
+	a := batchGuarded(m, n, lda, strideA, count, rnd)
+	// Make every third matrix singular by zeroing its first column.
+	for l := 0; l < count; l += 3 {
+		for i := 0; i < m && n > 0; i++ {
+			a[l*strideA+i*lda] = 0
+		}
+	}
+	strideIpiv := mn + 1
+	ipiv := make([]int, max(0, (count-1)*strideIpiv+mn))
+
+	want := make([]float64, len(a))
+	copy(want, a)
+	wantIpiv := make([]int, len(ipiv))
+	wantOK := make([]bool, count)
+	for l := range wantOK {
+		wantOK[l] = m == 0 || n == 0 || impl.Dgetrf(m, n, want[l*strideA:], lda, wantIpiv[l*strideIpiv:])
+	}
+
+	ok := make([]bool, count)
+	allOK := impl.DgetrfBatched(m, n, a, lda, strideA, ipiv, strideIpiv, ok, count)
+
+	if !equalApproxBatch(a, want, tol) {
+		t.Errorf("%v: unexpected factorization", name)
+	}
+	for l := 0; l < count; l++ {
+		if ok[l] != wantOK[l] {
+			t.Errorf("%v: unexpected ok for matrix %d: got %t want %t", name, l, ok[l], wantOK[l])
+		}
+		for i := 0; i < mn; i++ {
+			if ipiv[l*strideIpiv+i] != wantIpiv[l*strideIpiv+i] {
+				t.Errorf("%v: unexpected pivots for matrix %d", name, l)
+				break
+			}
+		}
+	}
+	wantAllOK := true
+	for _, v := range wantOK {
+		wantAllOK = wantAllOK && v
+	}
+	if allOK != wantAllOK {
+		t.Errorf("%v: unexpected return value: got %t want %t", name, allOK, wantAllOK)
+	}
+
+	if m != n || n == 0 || count == 0 {
+		return
+	}
+	// Solve using the non-singular factorizations.
+	for l := 0; l < count; l += 3 {
+		copy(a[l*strideA:l*strideA+(n-1)*lda+n], batchGuarded(n, n, lda, 0, 1, rnd))
+		for i := 0; i < n; i++ {
+			a[l*strideA+i*lda+i] += float64(n)
+		}
+		impl.Dgetrf(n, n, a[l*strideA:], lda, ipiv[l*strideIpiv:])
+	}
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, nrhs := range []int{0, 1, 3} {
+			ldb := max(1, nrhs+2)
+			strideB := n*ldb + 1
+			b := batchGuarded(n, nrhs, ldb, strideB, count, rnd)
+			want := make([]float64, len(b))
+			copy(want, b)
+			for l := 0; l < count; l++ {
+				impl.Dgetrs(trans, n, nrhs, a[l*strideA:], lda, ipiv[l*strideIpiv:l*strideIpiv+n], want[l*strideB:], ldb)
+			}
+			impl.DgetrsBatched(trans, n, nrhs, a, lda, strideA, ipiv, strideIpiv, b, ldb, strideB, count)
+			if !equalApproxBatch(b, want, 1e-10) {
+				t.Errorf("%v,trans=%v,nrhs=%v: unexpected solution", name, trans, nrhs)
+			}
+		}
+	}
+}
+
+// batchGuarded returns a slice holding count random r×c matrices with row
+// stride ld and batch stride stride. Elements outside the matrices are NaN.
+func batchGuarded(r, c, ld, stride, count int, rnd *rand.Rand) []float64 {
+	s := nanSlice(max(0, (count-1)*stride+r*ld))
+	for l := 0; l < count; l++ {
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				s[l*stride+i*ld+j] = rnd.NormFloat64()
+			}
+		}
+	}
+	return s
+}
+
+// equalApproxBatch returns whether the elements of a and b are equal to
+// within tol, treating NaN elements as equal to each other.
+func equalApproxBatch(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if math.IsNaN(v) && math.IsNaN(b[i]) {
+			continue
+		}
+		if !floats.EqualWithinAbsOrRel(v, b[i], tol, tol) {
+			return false
+		}
+	}
+	return true
+}
+
+func DgetrfBatchedBenchmark(b *testing.B, impl DgetrfBatcheder) {
+	rnd := rand.New(rand.NewSource(1))
+	const count = 10000
+	for _, n := range []int{3, 4, 8, 16} {
+		a := batchGuarded(n, n, n, n*n, count, rnd)
+		work := make([]float64, len(a))
+		ipiv := make([]int, count*n)
+		rhs := batchGuarded(n, 1, 1, n, count, rnd)
+		x := make([]float64, len(rhs))
+		b.Run(fmt.Sprintf("Batched%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(work, a)
+				copy(x, rhs)
+				impl.DgetrfBatched(n, n, work, n, n*n, ipiv, n, nil, count)
+				impl.DgetrsBatched(blas.NoTrans, n, 1, work, n, n*n, ipiv, n, x, 1, n, count)
+			}
+		})
+		b.Run(fmt.Sprintf("Loop%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				copy(work, a)
+				copy(x, rhs)
+				for l := 0; l < count; l++ {
+					impl.Dgetrf(n, n, work[l*n*n:], n, ipiv[l*n:])
+					impl.Dgetrs(blas.NoTrans, n, 1, work[l*n*n:], n, ipiv[l*n:l*n+n], x[l*n:], 1)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dgttrfer interface {
+	Dgetrfer
+	Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool)
+}
+
+func DgttrfTest(t *testing.T, impl Dgttrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, singular := range []bool{false, true} {
+			if singular && n == 0 {
+				continue
+			}
+			dgttrfTest(t, impl, rnd, n, singular)
+		}
+	}
+}
+
+func dgttrfTest(t *testing.T, impl Dgttrfer, rnd *rand.Rand, n int, singular bool) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("n=%v,singular=%v", n, singular)
+
+	dl, d, du := randomTridiag(n, rnd)
+	if singular {
+		// Make the last column of A zero.
+		d[n-1] = 0
+		if n > 1 {
+			du[n-2] = 0
+		}
+	}
+	a := tridiagToGeneral(n, dl, d, du)
+
+	du2 := nanSlice(max(0, n-2))
+	ipiv := make([]int, n)
+	ok := impl.Dgttrf(n, dl, d, du, du2, ipiv)
+	if ok == singular {
+		t.Errorf("%v: unexpected ok; got %v, want %v", name, ok, !singular)
+	}
+	if singular {
+		return
+	}
+
+	for i, p := range ipiv {
+		if p != i && p != i+1 {
+			t.Errorf("%v: unexpected ipiv[%v]=%v", name, i, p)
+		}
+	}
+	for i, v := range du2 {
+		if math.IsNaN(v) {
+			t.Errorf("%v: du2[%v] not set", name, i)
+		}
+	}
+
+	// Compare the determinant of A computed from the factorization with
+	// the one computed from a dense LU factorization.
+	var logdet float64
+	sign := 1.0
+	for i, v := range d {
+		logdet += math.Log(math.Abs(v))
+		if v < 0 {
+			sign *= -1
+		}
+		if ipiv[i] != i {
+			sign *= -1
+		}
+	}
+	dipiv := make([]int, n)
+	impl.Dgetrf(n, n, a.Data, a.Stride, dipiv)
+	var logdetWant float64
+	signWant := 1.0
+	for i := 0; i < n; i++ {
+		v := a.Data[i*a.Stride+i]
+		logdetWant += math.Log(math.Abs(v))
+		if v < 0 {
+			signWant *= -1
+		}
+		if dipiv[i] != i {
+			signWant *= -1
+		}
+	}
+	if sign != signWant {
+		t.Errorf("%v: unexpected sign of determinant; got %v, want %v", name, sign, signWant)
+	}
+	if math.Abs(logdet-logdetWant) > tol*math.Max(1, math.Abs(logdetWant))*float64(n) {
+		t.Errorf("%v: unexpected log determinant; got %v, want %v", name, logdet, logdetWant)
+	}
+}
+
+// randomTridiag returns the sub-diagonal, diagonal and super-diagonal of a
+// random n×n tridiagonal matrix.
+func randomTridiag(n int, rnd *rand.Rand) (dl, d, du []float64) {
+	d = randomSlice(n, rnd)
+	if n > 1 {
+		dl = randomSlice(n-1, rnd)
+		du = randomSlice(n-1, rnd)
+	}
+	return dl, d, du
+}
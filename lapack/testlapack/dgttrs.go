@@ -0,0 +1,78 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgttrser interface {
+	Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool)
+	Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int)
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+}
+
+func DgttrsTest(t *testing.T, impl Dgttrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			for _, nrhs := range []int{0, 1, 2, 5} {
+				for _, ldb := range []int{max(1, nrhs), nrhs + 3} {
+					dgttrsTest(t, impl, rnd, trans, n, nrhs, ldb)
+				}
+			}
+		}
+	}
+}
+
+func dgttrsTest(t *testing.T, impl Dgttrser, rnd *rand.Rand, trans blas.Transpose, n, nrhs, ldb int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("trans=%v,n=%v,nrhs=%v,ldb=%v", trans == blas.Trans, n, nrhs, ldb)
+
+	dl, d, du := randomTridiag(n, rnd)
+	a := tridiagToGeneral(n, dl, d, du)
+
+	du2 := make([]float64, max(0, n-2))
+	ipiv := make([]int, n)
+	ok := impl.Dgttrf(n, dl, d, du, du2, ipiv)
+	if !ok {
+		t.Errorf("%v: unexpected singular matrix", name)
+		return
+	}
+
+	// Generate the right-hand side as B = op(A) * X.
+	x := randomGeneral(n, nrhs, max(1, nrhs), rnd)
+	b := randomGeneral(n, nrhs, ldb, rnd)
+	if n == 0 || nrhs == 0 {
+		b = blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	}
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, x, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	impl.Dgttrs(trans, n, nrhs, dl, d, du, du2, ipiv, b.Data, b.Stride)
+
+	// Check that the solution has a small residual
+	//  |op(A)*X - B| / (|A|*|X|*n*eps).
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	blas64.Gemm(trans, blas.NoTrans, 1, a, b, -1, bCopy)
+	work := make([]float64, max(n, nrhs))
+	resid := impl.Dlange(lapack.MaxColumnSum, n, nrhs, bCopy.Data, bCopy.Stride, work)
+	anorm := impl.Dlange(lapack.MaxColumnSum, n, n, a.Data, a.Stride, work)
+	xnorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, b.Data, b.Stride, work)
+	if resid/(anorm*xnorm*float64(n)) > tol {
+		t.Errorf("%v: residual too large; got %v", name, resid/(anorm*xnorm*float64(n)))
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dlangber interface {
+	Dlanger
+	Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+}
+
+func DlangbTest(t *testing.T, impl Dlangber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, test := range []struct {
+			m, n, kl, ku int
+		}{
+			{0, 0, 0, 0},
+			{1, 1, 0, 0},
+			{4, 4, 0, 0},
+			{4, 4, 1, 1},
+			{5, 5, 2, 1},
+			{5, 5, 0, 3},
+			{3, 6, 1, 2},
+			{6, 3, 2, 1},
+			{7, 4, 3, 0},
+			{4, 7, 0, 4},
+			{10, 10, 9, 9},
+		} {
+			for _, ldoff := range []int{0, 3} {
+				m, n, kl, ku := test.m, test.n, test.kl, test.ku
+				ldab := kl + ku + 1 + ldoff
+				ab, a := randBand(m, n, kl, ku, ldab, rnd)
+				work := make([]float64, n)
+				got := impl.Dlangb(norm, m, n, kl, ku, ab, ldab, work)
+				want := impl.Dlange(norm, m, n, a.Data, a.Stride, work)
+				if math.Abs(got-want) > 1e-14*math.Max(1, want) {
+					t.Errorf("Norm mismatch. norm = %c, m = %v, n = %v, kl = %v, ku = %v, ldab = %v, want %v, got %v.",
+						norm, m, n, kl, ku, ldab, want, got)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dlansber interface {
+	Dlansyer
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+}
+
+func DlansbTest(t *testing.T, impl Dlansber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+			for _, n := range []int{1, 3, 5, 10} {
+				for _, kd := range []int{0, 1, 2, n - 1} {
+					for _, ldoff := range []int{0, 4} {
+						ldab := kd + 1 + ldoff
+						_, band := randSymBand(uplo, n, ldab, kd, rnd)
+						sb := symBandToSym(uplo, band.Data, n, kd, ldab)
+						work := make([]float64, n)
+						got := impl.Dlansb(norm, uplo, n, kd, band.Data, band.Stride, work)
+						want := impl.Dlansy(norm, uplo, n, sb.Data, sb.Stride, work)
+						if math.Abs(got-want) > 1e-14*math.Max(1, want) {
+							t.Errorf("Norm mismatch. norm = %c, upper = %v, n = %v, kd = %v, ldab = %v, want %v, got %v.",
+								norm, uplo == blas.Upper, n, kd, ldab, want, got)
+						}
+					}
+				}
+			}
+		}
+	}
+}
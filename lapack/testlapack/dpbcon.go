@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dpbconer interface {
+	Dpoconer
+	Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+	Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DpbconTest(t *testing.T, impl Dpbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 5, 10, 30} {
+			for _, kd := range []int{0, 1, 3, n - 1} {
+				if kd < 0 {
+					continue
+				}
+				for _, ldoff := range []int{0, 4} {
+					ldab := kd + 1 + ldoff
+					_, band := randSymBand(uplo, n, ldab, kd, rnd)
+					sym := symBandToSym(uplo, band.Data, n, kd, ldab)
+
+					work := make([]float64, 3*n)
+					iwork := make([]int, n)
+					anorm := impl.Dlansb(lapack.MaxColumnSum, uplo, n, kd, band.Data, band.Stride, work)
+					if !impl.Dpbtrf(uplo, n, kd, band.Data, band.Stride) {
+						panic("bad test: matrix not positive definite")
+					}
+					got := impl.Dpbcon(uplo, n, kd, band.Data, band.Stride, anorm, work, iwork)
+
+					// The band and dense Cholesky factors are identical, so the
+					// estimates of Dpbcon and Dpocon should agree.
+					if !impl.Dpotrf(uplo, n, sym.Data, sym.Stride) {
+						panic("bad test: matrix not positive definite")
+					}
+					want := impl.Dpocon(uplo, n, sym.Data, sym.Stride, anorm, work, iwork)
+
+					if !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+						prefix := fmt.Sprintf("uplo=%v,n=%v,kd=%v,ldab=%v", uplo, n, kd, ldab)
+						t.Errorf("%v: unexpected rcond: want %v, got %v", prefix, want, got)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+type Dpoequer interface {
+	Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool)
+}
+
+func DpoequTest(t *testing.T, impl Dpoequer) {
+	const tol = 1e-14
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 5, 10, 21} {
+		for _, lda := range []int{n, n + 5} {
+			lda = max(1, lda)
+			name := fmt.Sprintf("n=%v,lda=%v", n, lda)
+
+			// Construct a badly scaled symmetric positive definite matrix.
+			d := make([]float64, n)
+			for i := range d {
+				d[i] = 1 + rnd.Float64()
+			}
+			a := make([]float64, n*lda)
+			Dlagsy(n, 0, d, a, lda, rnd, make([]float64, 2*n))
+			scale := make([]float64, n)
+			for i := range scale {
+				scale[i] = math.Pow(10, float64(rnd.Intn(13)-6))
+			}
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					a[i*lda+j] *= scale[i] * scale[j]
+				}
+			}
+
+			s := make([]float64, n)
+			scond, amax, ok := impl.Dpoequ(n, a, lda, s)
+			if !ok {
+				t.Errorf("%v: unexpected failure", name)
+				continue
+			}
+			if n == 0 {
+				if scond != 1 || amax != 0 {
+					t.Errorf("%v: unexpected result for empty matrix", name)
+				}
+				continue
+			}
+
+			var wantAmax float64
+			for i := 0; i < n; i++ {
+				wantAmax = math.Max(wantAmax, a[i*lda+i])
+			}
+			if amax != wantAmax {
+				t.Errorf("%v: unexpected amax; got %v, want %v", name, amax, wantAmax)
+			}
+			if want := floats.Min(s) / floats.Max(s); math.Abs(scond-want) > tol*want {
+				t.Errorf("%v: unexpected scond; got %v, want %v", name, scond, want)
+			}
+			// The diagonal of diag(s)*A*diag(s) must be all ones.
+			for i := 0; i < n; i++ {
+				v := s[i] * a[i*lda+i] * s[i]
+				if math.Abs(v-1) > tol {
+					t.Errorf("%v: unexpected diagonal element %v of scaled matrix; got %v, want 1", name, i, v)
+				}
+			}
+
+			// Check that a non-positive diagonal element is detected.
+			a[(n-1)*lda+n-1] = 0
+			_, _, ok = impl.Dpoequ(n, a, lda, s)
+			if ok {
+				t.Errorf("%v: unexpected success with zero diagonal element", name)
+			}
+		}
+	}
+}
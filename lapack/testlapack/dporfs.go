@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dporfser interface {
+	Dpotrser
+	Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+}
+
+func DporfsTest(t *testing.T, impl Dporfser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 30, 100} {
+			for _, nrhs := range []int{0, 1, 2, 5} {
+				for _, ld := range []int{0, 5} {
+					dporfsTest(t, impl, rnd, uplo, n, nrhs, ld)
+				}
+			}
+		}
+	}
+}
+
+func dporfsTest(t *testing.T, impl Dporfser, rnd *rand.Rand, uplo blas.Uplo, n, nrhs, ld int) {
+	lda := max(1, n+ld)
+	ldaf := max(1, n+ld)
+	ldb := max(1, nrhs+ld)
+	ldx := max(1, nrhs+ld)
+	name := fmt.Sprintf("uplo=%v,n=%v,nrhs=%v,ld=%v", uplo == blas.Upper, n, nrhs, ld)
+
+	// Construct a symmetric positive definite matrix A with a moderate
+	// condition number.
+	d := make([]float64, n)
+	Dlatm1(d, 3, 1000, false, 1, rnd)
+	a := make([]float64, n*lda)
+	Dlagsy(n, 0, d, a, lda, rnd, make([]float64, 2*n))
+
+	// Construct the right-hand side from a known solution.
+	xWant := make([]float64, n*ldx)
+	for i := range xWant {
+		xWant[i] = rnd.NormFloat64()
+	}
+	b := make([]float64, n*ldb)
+	bi := blas64.Implementation()
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nrhs, n, 1, a, lda, xWant, ldx, 0, b, ldb)
+
+	af := make([]float64, n*ldaf)
+	for i := 0; i < n; i++ {
+		copy(af[i*ldaf:i*ldaf+n], a[i*lda:i*lda+n])
+	}
+	ok := impl.Dpotrf(uplo, n, af, ldaf)
+	if !ok {
+		t.Errorf("%v: unexpected failure of Dpotrf", name)
+		return
+	}
+	x := make([]float64, n*ldx)
+	for i := 0; i < n; i++ {
+		copy(x[i*ldx:i*ldx+nrhs], b[i*ldb:i*ldb+nrhs])
+	}
+	impl.Dpotrs(uplo, n, nrhs, af, ldaf, x, ldx)
+
+	// Perturb the solution so that refinement has work to do.
+	for i := range x {
+		x[i] *= 1 + 1e-6*rnd.NormFloat64()
+	}
+
+	// Only the triangle of A specified by uplo may be referenced, so
+	// invalidate the other one.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				a[i*lda+j] = 0
+			}
+		}
+	}
+
+	ferr := make([]float64, nrhs)
+	berr := make([]float64, nrhs)
+	work := make([]float64, 3*n)
+	iwork := make([]int, n)
+	impl.Dporfs(uplo, n, nrhs, a, lda, af, ldaf, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	checkRefinedSolution(t, name, n, nrhs, x, ldx, xWant, ldx, ferr, berr)
+}
@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type DpotrfBatcheder interface {
+	Dpotrser
+	DpotrfBatched(ul blas.Uplo, n int, a []float64, lda, strideA int, ok []bool, batchCount int) bool
+	DpotrsBatched(uplo blas.Uplo, n, nrhs int, a []float64, lda, strideA int, b []float64, ldb, strideB int, batchCount int)
+}
+
+func DpotrfBatchedTest(t *testing.T, impl DpotrfBatcheder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 8, 16, 17, 70} {
+			for _, count := range []int{0, 1, 4, 100} {
+				if count > 4 && n > 20 {
+					continue
+				}
+				for _, pad := range []int{0, 3} {
+					lda := max(1, n+pad)
+					dpotrfBatchedTest(t, impl, rnd, uplo, n, lda, n*lda+pad, count)
+				}
+			}
+		}
+	}
+}
+
+func dpotrfBatchedTest(t *testing.T, impl DpotrfBatcheder, rnd *rand.Rand, uplo blas.Uplo, n, lda, strideA, count int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("uplo=%v,n=%v,lda=%v,strideA=%v,count=%v", uplo == blas.Upper, n, lda, strideA, count)
+
+	a := nanSlice(max(0, (count-1)*strideA+n*lda))
+	d := make([]float64, n)
+	work := make([]float64, 2*n)
+	for l := 0; l < count; l++ {
+		for i := range d {
+			d[i] = 1 + rnd.Float64()
+		}
+		if l%3 == 2 && n > 0 {
+			// Make the matrix indefinite.
+			d[n-1] = -1
+		}
+		Dlagsy(n, 0, d, a[l*strideA:], lda, rnd, work)
+	}
+
+	want := make([]float64, len(a))
+	copy(want, a)
+	wantOK := make([]bool, count)
+	wantAllOK := true
+	for l := range wantOK {
+		wantOK[l] = impl.Dpotrf(uplo, n, want[l*strideA:], lda)
+		wantAllOK = wantAllOK && wantOK[l]
+	}
+
+	ok := make([]bool, count)
+	allOK := impl.DpotrfBatched(uplo, n, a, lda, strideA, ok, count)
+	if allOK != wantAllOK {
+		t.Errorf("%v: unexpected return value: got %t want %t", name, allOK, wantAllOK)
+	}
+	for l := 0; l < count; l++ {
+		if ok[l] != wantOK[l] {
+			t.Errorf("%v: unexpected ok for matrix %d: got %t want %t", name, l, ok[l], wantOK[l])
+			continue
+		}
+		if !ok[l] {
+			// The partial factorizations may differ in the failing column.
+			continue
+		}
+		if !equalApproxBatch(a[l*strideA:l*strideA+max(0, (n-1)*lda+n)], want[l*strideA:l*strideA+max(0, (n-1)*lda+n)], tol) {
+			t.Errorf("%v: unexpected factorization of matrix %d", name, l)
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+	for _, nrhs := range []int{0, 1, 3} {
+		ldb := max(1, nrhs+2)
+		strideB := n*ldb + 1
+		b := batchGuarded(n, nrhs, ldb, strideB, count, rnd)
+		want := make([]float64, len(b))
+		copy(want, b)
+		for l := 0; l < count; l++ {
+			if ok[l] {
+				impl.Dpotrs(uplo, n, nrhs, a[l*strideA:], lda, want[l*strideB:], ldb)
+			}
+		}
+		impl.DpotrsBatched(uplo, n, nrhs, a, lda, strideA, b, ldb, strideB, count)
+		for l := 0; l < count; l++ {
+			if !ok[l] {
+				continue
+			}
+			bl := b[l*strideB : l*strideB+(n-1)*ldb+nrhs]
+			wl := want[l*strideB : l*strideB+(n-1)*ldb+nrhs]
+			if !equalApproxBatch(bl, wl, 1e-10) {
+				t.Errorf("%v,nrhs=%v: unexpected solution for matrix %d", name, nrhs, l)
+			}
+		}
+	}
+}
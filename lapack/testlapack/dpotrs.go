@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpotrser interface {
+	Dpotrfer
+	Dpotrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int)
+}
+
+func DpotrsTest(t *testing.T, impl Dpotrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 30, 100} {
+			for _, nrhs := range []int{0, 1, 2, 5} {
+				for _, ld := range []struct{ a, b int }{{0, 0}, {5, 3}} {
+					lda := max(1, n+ld.a)
+					ldb := max(1, nrhs+ld.b)
+					dpotrsTest(t, impl, rnd, uplo, n, nrhs, lda, ldb)
+				}
+			}
+		}
+	}
+}
+
+func dpotrsTest(t *testing.T, impl Dpotrser, rnd *rand.Rand, uplo blas.Uplo, n, nrhs, lda, ldb int) {
+	const tol = 1e-12
+
+	name := fmt.Sprintf("uplo=%v,n=%v,nrhs=%v,lda=%v,ldb=%v", uplo == blas.Upper, n, nrhs, lda, ldb)
+
+	// Construct a symmetric positive definite matrix A.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 1 + rnd.Float64()
+	}
+	a := make([]float64, n*lda)
+	Dlagsy(n, 0, d, a, lda, rnd, make([]float64, 2*n))
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	// Construct the right-hand side from a known solution.
+	xWant := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	for i := range xWant.Data {
+		xWant.Data[i] = rnd.NormFloat64()
+	}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	bi := blas64.Implementation()
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nrhs, n, 1, aCopy, lda, xWant.Data, ldb, 0, b.Data, ldb)
+
+	ok := impl.Dpotrf(uplo, n, a, lda)
+	if !ok {
+		t.Errorf("%v: unexpected failure of Dpotrf", name)
+		return
+	}
+	impl.Dpotrs(uplo, n, nrhs, a, lda, b.Data, ldb)
+	if !equalApproxGeneral(b, xWant, tol) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpstrfer interface {
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func DpstrfTest(t *testing.T, impl Dpstrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 20, 50, 100, 150, 300} {
+			for _, lda := range []int{n, n + 11} {
+				lda = max(1, lda)
+				for _, rank := range []int{n, n / 2, n / 10} {
+					dpstrfTest(t, impl.Dpstrf, rnd, uplo, n, lda, rank)
+				}
+			}
+		}
+	}
+}
+
+type pstrfFunc func(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+
+func dpstrfTest(t *testing.T, pstrf pstrfFunc, rnd *rand.Rand, uplo blas.Uplo, n, lda, rankWant int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("uplo=%v,n=%v,lda=%v,rank=%v", uplo == blas.Upper, n, lda, rankWant)
+
+	// Generate a random symmetric positive semidefinite matrix A of the
+	// given rank as A = G * G^T where G is an n×rank matrix.
+	a := make([]float64, n*lda)
+	for i := range a {
+		a[i] = math.NaN()
+	}
+	if n > 0 {
+		var g []float64
+		if rankWant > 0 {
+			g = make([]float64, n*rankWant)
+			for i := range g {
+				g[i] = rnd.NormFloat64()
+			}
+		}
+		bi := blas64.Implementation()
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] = 0
+			}
+		}
+		if rankWant > 0 {
+			bi.Dsyrk(blas.Upper, blas.NoTrans, n, rankWant, 1, g, rankWant, 0, a, lda)
+		}
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				a[j*lda+i] = a[i*lda+j]
+			}
+		}
+	}
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	piv := make([]int, n)
+	work := make([]float64, 2*n)
+	rank, ok := pstrf(uplo, n, a, lda, piv, -1, work)
+	if rank != rankWant {
+		t.Errorf("%v: unexpected rank; got %v, want %v", name, rank, rankWant)
+		return
+	}
+	if ok != (rank == n) {
+		t.Errorf("%v: unexpected ok; got %v, want %v", name, ok, rank == n)
+	}
+
+	// Check that piv is a permutation.
+	seen := make([]bool, n)
+	for _, p := range piv {
+		if p < 0 || n <= p || seen[p] {
+			t.Errorf("%v: piv is not a permutation: %v", name, piv)
+			return
+		}
+		seen[p] = true
+	}
+
+	// Check that P^T * A * P = U^T * U or P^T * A * P = L * L^T using the
+	// leading rank rows of U or columns of L.
+	ff := make([]float64, n*n)
+	for i := 0; i < rank; i++ {
+		for j := i; j < n; j++ {
+			if uplo == blas.Upper {
+				ff[i*n+j] = a[i*lda+j]
+			} else {
+				ff[i*n+j] = a[j*lda+i]
+			}
+		}
+	}
+	got := make([]float64, n*n)
+	if n > 0 {
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.Trans, blas.NoTrans, n, n, n, 1, ff, n, ff, n, 0, got, n)
+	}
+	var anorm float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			anorm = math.Max(anorm, math.Abs(aCopy[i*lda+j]))
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			want := aCopy[piv[i]*lda+piv[j]]
+			if math.Abs(got[i*n+j]-want) > tol*float64(n)*anorm {
+				t.Errorf("%v: unexpected reconstruction at (%v,%v); got %v, want %v", name, i, j, got[i*n+j], want)
+				return
+			}
+		}
+	}
+
+	// Check that the diagonal of the factor is non-increasing.
+	for i := 1; i < rank; i++ {
+		if a[i*lda+i] > a[(i-1)*lda+(i-1)]*(1+tol) {
+			t.Errorf("%v: diagonal of factor not non-increasing at %v", name, i)
+			break
+		}
+	}
+
+	if n > 0 {
+		// A negative definite matrix must be detected.
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a[i*lda+j] = -aCopy[i*lda+j]
+			}
+		}
+		rank, ok = pstrf(uplo, n, a, lda, piv, -1, work)
+		if rankWant > 0 && (ok || rank != 0) {
+			t.Errorf("%v: unexpected result for negative semidefinite matrix: rank=%v, ok=%v", name, rank, ok)
+		}
+	}
+}
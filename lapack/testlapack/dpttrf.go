@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dpttrfer interface {
+	Dpttrf(n int, d, e []float64) (ok bool)
+}
+
+func DpttrfTest(t *testing.T, impl Dpttrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		dpttrfTest(t, impl, rnd, n)
+	}
+}
+
+func dpttrfTest(t *testing.T, impl Dpttrfer, rnd *rand.Rand, n int) {
+	const tol = 1e-14
+
+	name := fmt.Sprintf("n=%v", n)
+
+	d, e := randomSPDTridiag(n, rnd)
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	ok := impl.Dpttrf(n, d, e)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+
+	// Reconstruct A from L*D*L^T and compare. The diagonal of L*D*L^T is
+	//  d[i] + l[i-1]^2*d[i-1]
+	// and its off-diagonal is l[i]*d[i].
+	for i := 0; i < n; i++ {
+		got := d[i]
+		if i > 0 {
+			got += e[i-1] * e[i-1] * d[i-1]
+		}
+		if math.Abs(got-dCopy[i]) > tol*math.Abs(dCopy[i]) {
+			t.Errorf("%v: unexpected diagonal element %v; got %v, want %v", name, i, got, dCopy[i])
+		}
+		if i < n-1 {
+			got = e[i] * d[i]
+			if math.Abs(got-eCopy[i]) > tol*math.Max(1, math.Abs(eCopy[i])) {
+				t.Errorf("%v: unexpected off-diagonal element %v; got %v, want %v", name, i, got, eCopy[i])
+			}
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+	// An indefinite matrix must be detected.
+	copy(d, dCopy)
+	copy(e, eCopy)
+	d[n-1] = -1
+	if impl.Dpttrf(n, d, e) {
+		t.Errorf("%v: unexpected success for indefinite matrix", name)
+	}
+}
+
+// randomSPDTridiag returns the diagonal and off-diagonal of a random n×n
+// symmetric positive definite tridiagonal matrix.
+func randomSPDTridiag(n int, rnd *rand.Rand) (d, e []float64) {
+	d = make([]float64, n)
+	if n > 1 {
+		e = randomSlice(n-1, rnd)
+	}
+	// Make A strictly diagonally dominant with positive diagonal.
+	for i := range d {
+		d[i] = 1 + rnd.Float64()
+		if i > 0 {
+			d[i] += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			d[i] += math.Abs(e[i])
+		}
+	}
+	return d, e
+}
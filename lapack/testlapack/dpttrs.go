@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpttrser interface {
+	Dpttrf(n int, d, e []float64) (ok bool)
+	Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int)
+}
+
+func DpttrsTest(t *testing.T, impl Dpttrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, nrhs := range []int{0, 1, 2, 5} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 3} {
+				dpttrsTest(t, impl, rnd, n, nrhs, ldb)
+			}
+		}
+	}
+}
+
+func dpttrsTest(t *testing.T, impl Dpttrser, rnd *rand.Rand, n, nrhs, ldb int) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("n=%v,nrhs=%v,ldb=%v", n, nrhs, ldb)
+
+	d, e := randomSPDTridiag(n, rnd)
+	a := tridiagToGeneral(n, e, d, e)
+
+	ok := impl.Dpttrf(n, d, e)
+	if !ok {
+		t.Errorf("%v: unexpected failure for positive definite matrix", name)
+		return
+	}
+
+	// Generate the right-hand side as B = A * X.
+	x := randomGeneral(n, nrhs, max(1, nrhs), rnd)
+	b := randomGeneral(n, nrhs, ldb, rnd)
+	if n == 0 || nrhs == 0 {
+		b = blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	}
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, b)
+	}
+
+	impl.Dpttrs(n, nrhs, d, e, b.Data, b.Stride)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// A is diagonally dominant, so the solution must be accurate.
+	if !equalApproxGeneral(b, x, tol) {
+		t.Errorf("%v: unexpected solution", name)
+	}
+}
@@ -53,6 +53,29 @@ func DsterfTest(t *testing.T, impl Dsterfer) {
 		}
 	}
 
+	// Test matrices with norms large and small enough to be scaled
+	// before the iteration.
+	for _, scale := range []float64{1e200, 1e-200} {
+		d := []float64{1, 3, 4, 6}
+		e := []float64{2, 4, 5}
+		ans := []float64{-2.546379458290125, 0.704229756383872, 4.795922173417400, 11.046227528488854}
+		for i := range d {
+			d[i] *= scale
+		}
+		for i := range e {
+			e[i] *= scale
+		}
+		ok := impl.Dsterf(len(d), d, e)
+		if !ok {
+			t.Errorf("Eigenvalue decomposition failed for scale %v", scale)
+			continue
+		}
+		floats.Scale(1/scale, d)
+		if !floats.EqualApprox(ans, d, 1e-10) {
+			t.Errorf("eigenvalue mismatch for scale %v: want %v, got %v", scale, ans, d)
+		}
+	}
+
 	rnd := rand.New(rand.NewSource(1))
 	// Probabilistic tests.
 	for _, n := range []int{4, 6, 10} {
@@ -0,0 +1,101 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstever interface {
+	Dstev(jobz lapack.EVJob, n int, d, e, z []float64, ldz int, work []float64) (ok bool)
+}
+
+func DstevTest(t *testing.T, impl Dstever) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, ldz := range []int{max(1, n), n + 3} {
+			for _, scale := range []float64{1, 1e-160, 1e160} {
+				dstevTest(t, impl, rnd, n, ldz, scale)
+			}
+		}
+	}
+}
+
+func dstevTest(t *testing.T, impl Dstever, rnd *rand.Rand, n, ldz int, scale float64) {
+	const tol = 1e-13
+
+	name := fmt.Sprintf("n=%v,ldz=%v,scale=%v", n, ldz, scale)
+
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = scale * rnd.NormFloat64()
+	}
+	var e []float64
+	if n > 1 {
+		e = make([]float64, n-1)
+		for i := range e {
+			e[i] = scale * rnd.NormFloat64()
+		}
+	}
+	a := tridiagToGeneral(n, e, d, e)
+
+	// Compute eigenvalues and eigenvectors.
+	w := make([]float64, n)
+	copy(w, d)
+	ework := make([]float64, len(e))
+	copy(ework, e)
+	z := nanGeneral(n, n, ldz)
+	if n == 0 {
+		z.Data = make([]float64, 1)
+	}
+	work := make([]float64, max(1, 2*n-2))
+	ok := impl.Dstev(lapack.ComputeEV, n, w, ework, z.Data, z.Stride, work)
+	if !ok {
+		t.Errorf("%v: algorithm did not converge", name)
+		return
+	}
+	if !sort.Float64sAreSorted(w) {
+		t.Errorf("%v: eigenvalues not sorted", name)
+	}
+	if n == 0 {
+		return
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("%v: eigenvectors not orthonormal", name)
+	}
+	// Check that A*Z = Z*Λ relative to the norm of A.
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			var az float64
+			for k := max(0, i-1); k <= min(n-1, i+1); k++ {
+				az += a.Data[i*a.Stride+k] * z.Data[k*z.Stride+j]
+			}
+			if math.Abs(az-w[j]*z.Data[i*z.Stride+j]) > tol*float64(n)*scale*10 {
+				t.Errorf("%v: eigenvector %v does not match eigenvalue", name, j)
+				return
+			}
+		}
+	}
+
+	// Compute eigenvalues only and compare.
+	wOnly := make([]float64, n)
+	copy(wOnly, d)
+	copy(ework, e)
+	ok = impl.Dstev(lapack.EVJob(lapack.None), n, wOnly, ework, nil, 1, nil)
+	if !ok {
+		t.Errorf("%v: algorithm did not converge for eigenvalues only", name)
+		return
+	}
+	if !floats.EqualApprox(w, wOnly, tol*float64(n)*scale*10) {
+		t.Errorf("%v: eigenvalue mismatch when eigenvectors not computed", name)
+	}
+}
@@ -361,6 +361,25 @@ func constructBidiagonal(uplo blas.Uplo, n int, d, e []float64) blas64.General {
 	return bMat
 }
 
+// tridiagToGeneral constructs a dense n×n tridiagonal matrix with the given
+// sub-diagonal, diagonal and super-diagonal elements.
+func tridiagToGeneral(n int, dl, d, du []float64) blas64.General {
+	a := blas64.General{
+		Rows:   n,
+		Cols:   n,
+		Stride: max(1, n),
+		Data:   make([]float64, n*max(1, n)),
+	}
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] = d[i]
+		if i < n-1 {
+			a.Data[(i+1)*a.Stride+i] = dl[i]
+			a.Data[i*a.Stride+i+1] = du[i]
+		}
+	}
+	return a
+}
+
 // constructVMat transforms the v matrix based on the storage.
 func constructVMat(vMat blas64.General, store lapack.StoreV, direct lapack.Direct) blas64.General {
 	m := vMat.Rows
@@ -1000,6 +1019,27 @@ func randSymBand(ul blas.Uplo, n, ldab, kb int, rnd *rand.Rand) (blas64.Symmetri
 	return sym, band
 }
 
+// randBand returns a random m×n band matrix with kl sub-diagonals and ku
+// super-diagonals in row-major band storage with stride ldab, together with
+// the equivalent dense matrix. Elements of the band storage that lie outside
+// the matrix, and the ldab-(kl+ku+1) trailing elements of each row, are set
+// to NaN.
+func randBand(m, n, kl, ku, ldab int, rnd *rand.Rand) (ab []float64, a blas64.General) {
+	ab = make([]float64, m*ldab)
+	for i := range ab {
+		ab[i] = math.NaN()
+	}
+	a = zeros(m, n, max(1, n))
+	for i := 0; i < m; i++ {
+		for j := max(0, i-kl); j < min(n, i+ku+1); j++ {
+			v := rnd.NormFloat64()
+			ab[i*ldab+kl+j-i] = v
+			a.Data[i*a.Stride+j] = v
+		}
+	}
+	return ab, a
+}
+
 // symToSymBand takes the data in a Symmetric matrix and returns a
 // SymmetricBanded matrix.
 func symToSymBand(ul blas.Uplo, a []float64, n, lda, kb, ldab int) []float64 {
@@ -1514,3 +1554,16 @@ func constructGSVPresults(n, p, m, k, l int, a, b blas64.General) (zeroA, zeroB
 
 	return zeroA, zeroB
 }
+
+// intsEqual returns whether a and b have the same length and elements.
+func intsEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i, v := range a {
+		if v != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -17,6 +17,9 @@ var (
 	_ NonZeroDoer    = bandDense
 	_ RowNonZeroDoer = bandDense
 	_ ColNonZeroDoer = bandDense
+
+	_ RowViewer = bandDense
+	_ ColViewer = bandDense
 )
 
 // BandDense represents a band matrix in dense storage format.
@@ -226,3 +229,114 @@ func (b *BandDense) DoColNonZero(j int, fn func(i, j int, v float64)) {
 		}
 	}
 }
+
+// RowView returns a Vector reflecting row i of the matrix. Elements of the
+// row within the band are backed by the matrix data, and the remaining
+// elements are zero. The returned Vector implements VecNonZeroDoer.
+func (b *BandDense) RowView(i int) Vector {
+	if i < 0 || b.mat.Rows <= i {
+		panic(ErrRowAccess)
+	}
+	lo := max(0, i-b.mat.KL)
+	hi := min(b.mat.Cols, i+b.mat.KU+1)
+	if hi <= lo {
+		return &bandVec{n: b.mat.Cols}
+	}
+	off := i*b.mat.Stride + lo + b.mat.KL - i
+	return &bandVec{
+		n:    b.mat.Cols,
+		lo:   lo,
+		hi:   hi,
+		inc:  1,
+		data: b.mat.Data[off : off+hi-lo],
+	}
+}
+
+// ColView returns a Vector reflecting column j of the matrix. Elements of
+// the column within the band are backed by the matrix data, and the
+// remaining elements are zero. The returned Vector implements
+// VecNonZeroDoer.
+func (b *BandDense) ColView(j int) Vector {
+	if j < 0 || b.mat.Cols <= j {
+		panic(ErrColAccess)
+	}
+	lo := max(0, j-b.mat.KU)
+	hi := min(b.mat.Rows, j+b.mat.KL+1)
+	if hi <= lo {
+		return &bandVec{n: b.mat.Rows}
+	}
+	// Moving down a column moves one row down
+	// and one position left within the band.
+	inc := b.mat.Stride - 1
+	off := lo*b.mat.Stride + j + b.mat.KL - lo
+	return &bandVec{
+		n:    b.mat.Rows,
+		lo:   lo,
+		hi:   hi,
+		inc:  inc,
+		data: b.mat.Data[off : off+(hi-lo-1)*inc+1],
+	}
+}
+
+var (
+	bandVector *bandVec
+	_          Vector         = bandVector
+	_          VectorAtVec    = bandVector
+	_          VecNonZeroDoer = bandVector
+)
+
+// bandVec is a row or column of a band matrix. Element i of the vector is
+// data[(i-lo)*inc] for lo <= i < hi, and zero otherwise.
+type bandVec struct {
+	n      int
+	lo, hi int
+	inc    int
+	data   []float64
+}
+
+// Dims returns the number of rows and columns in the vector.
+func (v *bandVec) Dims() (r, c int) {
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *bandVec) Len() int {
+	return v.n
+}
+
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *bandVec) At(i, j int) float64 {
+	if j != 0 {
+		panic(ErrColAccess)
+	}
+	return v.AtVec(i)
+}
+
+// AtVec returns the element at row i.
+// It panics if i is out of bounds.
+func (v *bandVec) AtVec(i int) float64 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	if i < v.lo || v.hi <= i {
+		return 0
+	}
+	return v.data[(i-v.lo)*v.inc]
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (v *bandVec) T() Matrix {
+	return Transpose{v}
+}
+
+// DoVecNonZero calls the function fn for each of the non-zero elements of v.
+// The function fn takes an index and the element value of v at i.
+func (v *bandVec) DoVecNonZero(fn func(i int, v float64)) {
+	for i := v.lo; i < v.hi; i++ {
+		e := v.data[(i-v.lo)*v.inc]
+		if e != 0 {
+			fn(i, e)
+		}
+	}
+}
@@ -375,3 +375,65 @@ func (b bandImplicit) At(i, j int) float64 {
 	}
 	return b.val(i, j)
 }
+
+func TestBandViews(t *testing.T) {
+	for _, b := range []*BandDense{
+		NewBandDense(6, 6, 1, 2, nil),
+		NewBandDense(6, 4, 2, 0, nil),
+		NewBandDense(3, 6, 0, 3, nil),
+		NewBandDense(7, 3, 1, 1, nil),
+		NewDiagonalRect(4, 4, nil),
+	} {
+		r, c := b.Dims()
+		kl, ku := b.Bandwidth()
+		for i := 0; i < r; i++ {
+			for j := max(0, i-kl); j < min(c, i+ku+1); j++ {
+				b.SetBand(i, j, float64(i*c+j+1))
+			}
+		}
+		for i := 0; i < r; i++ {
+			row := b.RowView(i)
+			if row.Len() != c {
+				t.Errorf("unexpected row length for kl=%d ku=%d: got %d want %d", kl, ku, row.Len(), c)
+			}
+			checkBandView(t, row, func(j int) float64 { return b.At(i, j) }, "row", i)
+		}
+		for j := 0; j < c; j++ {
+			col := b.ColView(j)
+			if col.Len() != r {
+				t.Errorf("unexpected column length for kl=%d ku=%d: got %d want %d", kl, ku, col.Len(), r)
+			}
+			checkBandView(t, col, func(i int) float64 { return b.At(i, j) }, "column", j)
+		}
+
+		// Views reflect changes to the matrix.
+		b.SetBand(0, 0, -1)
+		if b.RowView(0).At(0, 0) != -1 || b.ColView(0).At(0, 0) != -1 {
+			t.Errorf("band view for kl=%d ku=%d not backed by matrix data", kl, ku)
+		}
+	}
+}
+
+func checkBandView(t *testing.T, v Vector, want func(int) float64, kind string, idx int) {
+	for k := 0; k < v.Len(); k++ {
+		if got := v.(VectorAtVec).AtVec(k); got != want(k) {
+			t.Errorf("unexpected %s %d element %d: got %v want %v", kind, idx, k, got, want(k))
+		}
+	}
+	var n int
+	v.(VecNonZeroDoer).DoVecNonZero(func(k int, e float64) {
+		n++
+		if e != want(k) {
+			t.Errorf("unexpected non-zero %s %d element %d: got %v want %v", kind, idx, k, e, want(k))
+		}
+	})
+	var nnz int
+	for k := 0; k < v.Len(); k++ {
+		if want(k) != 0 {
+			nnz++
+		}
+	}
+	if n != nnz {
+		t.Errorf("unexpected number of non-zero elements in %s %d: got %d want %d", kind, idx, n, nnz)
+	}
+}
@@ -35,10 +35,10 @@ type Cholesky struct {
 // updateCond updates the condition number of the Cholesky decomposition. If
 // norm > 0, then that norm is used as the norm of the original matrix A, otherwise
 // the norm is estimated from the decomposition.
-func (c *Cholesky) updateCond(norm float64) {
+func (c *Cholesky) updateCond(norm float64, ws *Workspace) {
 	n := c.chol.mat.N
-	work := getFloats(3*n, false)
-	defer putFloats(work)
+	work := ws.getFloats(3*n, false)
+	defer ws.putFloats(work)
 	if norm < 0 {
 		// This is an approximation. By the definition of a norm,
 		//  |AB| <= |A| |B|.
@@ -52,9 +52,9 @@ func (c *Cholesky) updateCond(norm float64) {
 		norm = unorm * lnorm
 	}
 	sym := c.chol.asSymBlas()
-	iwork := getInts(n, false)
+	iwork := ws.getInts(n, false)
 	v := lapack64.Pocon(sym, norm, work, iwork)
-	putInts(iwork)
+	ws.putInts(iwork)
 	c.cond = 1 / v
 }
 
@@ -67,21 +67,32 @@ func (c *Cholesky) Cond() float64 {
 // whether the matrix is positive definite. If Factorize returns false, the
 // factorization must not be used.
 func (c *Cholesky) Factorize(a Symmetric) (ok bool) {
+	return c.factorize(a, nil)
+}
+
+// FactorizeWork is like Factorize, but obtains temporary storage from ws.
+// If ws is nil, FactorizeWork is equivalent to Factorize.
+func (c *Cholesky) FactorizeWork(a Symmetric, ws *Workspace) (ok bool) {
+	return c.factorize(a, ws)
+}
+
+func (c *Cholesky) factorize(a Symmetric, ws *Workspace) (ok bool) {
 	n := a.Symmetric()
 	if c.isZero() {
 		c.chol = NewTriDense(n, Upper, nil)
 	} else {
-		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+		c.chol.Reset()
+		c.chol.reuseAs(n, Upper)
 	}
 	copySymIntoTriangle(c.chol, a)
 
 	sym := c.chol.asSymBlas()
-	work := getFloats(c.chol.mat.N, false)
+	work := ws.getFloats(c.chol.mat.N, false)
 	norm := lapack64.Lansy(CondNorm, sym, work)
-	putFloats(work)
+	ws.putFloats(work)
 	_, ok = lapack64.Potrf(sym)
 	if ok {
-		c.updateCond(norm)
+		c.updateCond(norm, ws)
 	} else {
 		c.Reset()
 	}
@@ -111,7 +122,7 @@ func (c *Cholesky) SetFromU(t *TriDense) {
 		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
 	}
 	c.chol.Copy(t)
-	c.updateCond(-1)
+	c.updateCond(-1, nil)
 }
 
 // Clone makes a copy of the input Cholesky into the receiver, overwriting the
@@ -402,7 +413,7 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 					c, s)
 			}
 		}
-		c.updateCond(-1)
+		c.updateCond(-1, nil)
 		return true
 	}
 
@@ -463,7 +474,7 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 		}
 	}
 	if ok {
-		c.updateCond(-1)
+		c.updateCond(-1, nil)
 	} else {
 		c.Reset()
 	}
@@ -14,6 +14,11 @@ const (
 	Lower TriKind = false
 )
 
+// Spectral is the norm order specifying the spectral norm, the largest
+// singular value of a matrix, in calls to Norm and Cond. It is distinct from
+// the norm order 2, which specifies the Frobenius norm in Norm.
+const Spectral = -2
+
 // SVDKind specifies the treatment of singular vectors during an SVD
 // factorization.
 type SVDKind int
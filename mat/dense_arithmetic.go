@@ -261,6 +261,16 @@ func (m *Dense) Inverse(a Matrix) error {
 // Mul takes the matrix product of a and b, placing the result in the receiver.
 // If the number of columns in a does not equal the number of rows in b, Mul will panic.
 func (m *Dense) Mul(a, b Matrix) {
+	m.mul(a, b, nil)
+}
+
+// MulWork is like Mul, but obtains temporary storage from ws.
+// If ws is nil, MulWork is equivalent to Mul.
+func (m *Dense) MulWork(a, b Matrix, ws *Workspace) {
+	m.mul(a, b, ws)
+}
+
+func (m *Dense) mul(a, b Matrix, ws *Workspace) {
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
 
@@ -271,13 +281,14 @@ func (m *Dense) Mul(a, b Matrix) {
 	aU, aTrans := untranspose(a)
 	bU, bTrans := untranspose(b)
 	m.reuseAs(ar, bc)
-	var restore func()
-	if m == aU {
-		m, restore = m.isolatedWorkspace(aU)
-		defer restore()
-	} else if m == bU {
-		m, restore = m.isolatedWorkspace(bU)
-		defer restore()
+	var dst *Dense
+	if m == aU || m == bU {
+		dst = m
+		m = ws.getWorkspace(ar, bc, false)
+		defer func() {
+			dst.Copy(m)
+			ws.putWorkspace(m)
+		}()
 	}
 	aT := blas.NoTrans
 	if aTrans {
@@ -294,12 +305,12 @@ func (m *Dense) Mul(a, b Matrix) {
 	// C^T = B^T * A.
 	if aUrm, ok := aU.(RawMatrixer); ok {
 		amat := aUrm.RawMatrix()
-		if restore == nil {
+		if dst == nil {
 			m.checkOverlap(amat)
 		}
 		if bUrm, ok := bU.(RawMatrixer); ok {
 			bmat := bUrm.RawMatrix()
-			if restore == nil {
+			if dst == nil {
 				m.checkOverlap(bmat)
 			}
 			blas64.Gemm(aT, bT, 1, amat, bmat, 0, m.mat)
@@ -308,10 +319,10 @@ func (m *Dense) Mul(a, b Matrix) {
 		if bU, ok := bU.(RawSymmetricer); ok {
 			bmat := bU.RawSymmetric()
 			if aTrans {
-				c := getWorkspace(ac, ar, false)
+				c := ws.getWorkspace(ac, ar, false)
 				blas64.Symm(blas.Left, 1, bmat, amat, 0, c.mat)
 				strictCopy(m, c.T())
-				putWorkspace(c)
+				ws.putWorkspace(c)
 				return
 			}
 			blas64.Symm(blas.Right, 1, bmat, amat, 0, m.mat)
@@ -321,7 +332,7 @@ func (m *Dense) Mul(a, b Matrix) {
 			// Trmm updates in place, so copy aU first.
 			bmat := bU.RawTriangular()
 			if aTrans {
-				c := getWorkspace(ac, ar, false)
+				c := ws.getWorkspace(ac, ar, false)
 				var tmp Dense
 				tmp.SetRawMatrix(amat)
 				c.Copy(&tmp)
@@ -331,7 +342,7 @@ func (m *Dense) Mul(a, b Matrix) {
 				}
 				blas64.Trmm(blas.Left, bT, 1, bmat, c.mat)
 				strictCopy(m, c.T())
-				putWorkspace(c)
+				ws.putWorkspace(c)
 				return
 			}
 			m.Copy(a)
@@ -363,16 +374,16 @@ func (m *Dense) Mul(a, b Matrix) {
 	}
 	if bUrm, ok := bU.(RawMatrixer); ok {
 		bmat := bUrm.RawMatrix()
-		if restore == nil {
+		if dst == nil {
 			m.checkOverlap(bmat)
 		}
 		if aU, ok := aU.(RawSymmetricer); ok {
 			amat := aU.RawSymmetric()
 			if bTrans {
-				c := getWorkspace(bc, br, false)
+				c := ws.getWorkspace(bc, br, false)
 				blas64.Symm(blas.Right, 1, amat, bmat, 0, c.mat)
 				strictCopy(m, c.T())
-				putWorkspace(c)
+				ws.putWorkspace(c)
 				return
 			}
 			blas64.Symm(blas.Left, 1, amat, bmat, 0, m.mat)
@@ -382,7 +393,7 @@ func (m *Dense) Mul(a, b Matrix) {
 			// Trmm updates in place, so copy bU first.
 			amat := aU.RawTriangular()
 			if bTrans {
-				c := getWorkspace(bc, br, false)
+				c := ws.getWorkspace(bc, br, false)
 				var tmp Dense
 				tmp.SetRawMatrix(bmat)
 				c.Copy(&tmp)
@@ -392,7 +403,7 @@ func (m *Dense) Mul(a, b Matrix) {
 				}
 				blas64.Trmm(blas.Right, aT, 1, amat, c.mat)
 				strictCopy(m, c.T())
-				putWorkspace(c)
+				ws.putWorkspace(c)
 				return
 			}
 			m.Copy(b)
@@ -429,8 +440,8 @@ func (m *Dense) Mul(a, b Matrix) {
 		}
 	}
 
-	row := getFloats(ac, false)
-	defer putFloats(row)
+	row := ws.getFloats(ac, false)
+	defer ws.putFloats(row)
 	for r := 0; r < ar; r++ {
 		for i := range row {
 			row[i] = a.At(r, i)
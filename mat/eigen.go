@@ -5,6 +5,8 @@
 package mat
 
 import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
@@ -33,21 +35,94 @@ type EigenSym struct {
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, methods that require a successful factorization will panic.
 func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
+	return e.factorize(a, vectors, nil)
+}
+
+// FactorizeWork is like Factorize, but obtains temporary storage from ws.
+// If ws is nil, FactorizeWork is equivalent to Factorize.
+func (e *EigenSym) FactorizeWork(a Symmetric, vectors bool, ws *Workspace) (ok bool) {
+	return e.factorize(a, vectors, ws)
+}
+
+func (e *EigenSym) factorize(a Symmetric, vectors bool, ws *Workspace) (ok bool) {
 	n := a.Symmetric()
-	sd := NewSymDense(n, nil)
+	// The eigenvectors are computed in place, so reuse the storage
+	// of any previous decomposition for the copy of a.
+	if e.vectors == nil {
+		e.vectors = NewDense(n, n, nil)
+	} else {
+		e.vectors.Reset()
+		e.vectors.reuseAs(n, n)
+	}
+	sd := SymDense{
+		mat: blas64.Symmetric{
+			N:      n,
+			Stride: n,
+			Data:   e.vectors.mat.Data,
+			Uplo:   blas.Upper,
+		},
+		cap: n,
+	}
 	sd.CopySym(a)
 
 	jobz := lapack.EVJob(lapack.None)
 	if vectors {
 		jobz = lapack.ComputeEV
 	}
-	w := make([]float64, n)
-	work := []float64{0}
+	w := use(e.values, n)
+	work := ws.getFloats(1, false)
 	lapack64.Syev(jobz, sd.mat, w, work, -1)
+	lwork := int(work[0])
+	ws.putFloats(work)
 
-	work = getFloats(int(work[0]), false)
+	work = ws.getFloats(lwork, false)
 	ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
-	putFloats(work)
+	ws.putFloats(work)
+	if !ok {
+		e.vectorsComputed = false
+		e.values = nil
+		e.vectors = nil
+		return false
+	}
+	e.vectorsComputed = vectors
+	e.values = w
+	return true
+}
+
+// FactorizeTridiag computes the eigenvalue decomposition of the n×n symmetric
+// tridiagonal matrix with diagonal elements diag and off-diagonal elements
+// offDiag, where n = len(diag). offDiag must have length n-1, otherwise
+// FactorizeTridiag will panic. If the vectors input argument is false, the
+// eigenvectors are not computed. The input slices are not modified.
+//
+// FactorizeTridiag returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeTridiag(diag, offDiag []float64, vectors bool) (ok bool) {
+	n := len(diag)
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	if len(offDiag) != n-1 {
+		panic(ErrSliceLengthMismatch)
+	}
+	w := make([]float64, n)
+	copy(w, diag)
+	off := getFloats(n-1, false)
+	copy(off, offDiag)
+
+	jobz := lapack.EVJob(lapack.None)
+	var z *Dense
+	if vectors {
+		jobz = lapack.ComputeEV
+		z = NewDense(n, n, nil)
+		work := getFloats(max(1, 2*n-2), false)
+		ok = lapack64.Stev(jobz, w, off, z.mat, work)
+		putFloats(work)
+	} else {
+		ok = lapack64.Stev(jobz, w, off, blas64.General{}, nil)
+	}
+	putFloats(off)
 	if !ok {
 		e.vectorsComputed = false
 		e.values = nil
@@ -56,7 +131,7 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 	}
 	e.vectorsComputed = vectors
 	e.values = w
-	e.vectors = NewDense(n, n, sd.mat.Data)
+	e.vectors = z
 	return true
 }
 
@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 
@@ -162,3 +163,97 @@ func TestSymEigen(t *testing.T) {
 		}
 	}
 }
+
+func TestSymEigenTridiag(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 70} {
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		e := make([]float64, n-1)
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+		s := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			s.SetSym(i, i, d[i])
+			if i < n-1 {
+				s.SetSym(i, i+1, e[i])
+			}
+		}
+		dCopy := make([]float64, n)
+		copy(dCopy, d)
+		eCopy := make([]float64, n-1)
+		copy(eCopy, e)
+
+		var es EigenSym
+		ok := es.FactorizeTridiag(d, e, true)
+		if !ok {
+			t.Errorf("n=%d: bad factorization", n)
+			continue
+		}
+		if !floats.Equal(d, dCopy) || !floats.Equal(e, eCopy) {
+			t.Errorf("n=%d: input slices modified", n)
+		}
+
+		var want EigenSym
+		want.Factorize(s, false)
+		if !floats.EqualApprox(es.Values(nil), want.Values(nil), 1e-12) {
+			t.Errorf("n=%d: eigenvalue mismatch with dense decomposition", n)
+		}
+
+		var vecs Dense
+		vecs.EigenvectorsSym(&es)
+		if !isOrthonormal(&vecs, 1e-12) {
+			t.Errorf("n=%d: eigenvectors not orthonormal", n)
+		}
+		for i := 0; i < n; i++ {
+			v := NewVecDense(n, Col(nil, i, &vecs))
+			var m VecDense
+			m.MulVec(s, v)
+			var scal VecDense
+			scal.ScaleVec(es.values[i], v)
+			if !EqualApprox(&m, &scal, 1e-12) {
+				t.Errorf("n=%d: eigenvalue %d does not match eigenvector", n, i)
+			}
+		}
+
+		var es2 EigenSym
+		es2.FactorizeTridiag(d, e, false)
+		if !floats.EqualApprox(es2.Values(nil), es.Values(nil), 1e-14) {
+			t.Errorf("n=%d: eigenvalue mismatch when no vectors computed", n)
+		}
+	}
+
+	// Compute a Gauss-Legendre quadrature rule with the Golub-Welsch
+	// algorithm and check that it integrates polynomials exactly.
+	const n = 10
+	diag := make([]float64, n)
+	off := make([]float64, n-1)
+	for i := range off {
+		k := float64(i + 1)
+		off[i] = k / math.Sqrt(4*k*k-1)
+	}
+	var es EigenSym
+	if !es.FactorizeTridiag(diag, off, true) {
+		t.Fatal("bad factorization of Jacobi matrix")
+	}
+	var vecs Dense
+	vecs.EigenvectorsSym(&es)
+	x := es.Values(nil)
+	for deg := 0; deg < 2*n; deg++ {
+		var got float64
+		for i, xi := range x {
+			w := 2 * vecs.At(0, i) * vecs.At(0, i)
+			got += w * math.Pow(xi, float64(deg))
+		}
+		var want float64
+		if deg%2 == 0 {
+			want = 2 / float64(deg+1)
+		}
+		if math.Abs(got-want) > 1e-14 {
+			t.Errorf("unexpected quadrature of x^%d: got %v, want %v", deg, got, want)
+		}
+	}
+}
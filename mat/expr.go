@@ -0,0 +1,380 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Expr is a lazily evaluated matrix expression. An Expr records a directed
+// acyclic graph of matrix operations over Matrix operands without performing
+// any arithmetic. The expression is computed by a call to Dense.Eval, which
+// fuses the recorded operations so that, where possible, the result is formed
+// by single BLAS calls and without intermediate matrices. For example
+//  NewExpr(a).Mul(NewExpr(b)).Scale(alpha).Add(NewExpr(c).Scale(beta))
+// is evaluated as a single Dgemm call when the destination is c.
+//
+// The shapes of the operands are checked when the expression is built, and
+// the Expr methods panic with ErrShape if they do not match. The operands
+// are read when the expression is evaluated, not when it is built.
+//
+// Expr values are immutable and may be shared between expressions.
+type Expr struct {
+	op   exprOp
+	r, c int
+
+	// m is the operand of a leaf node.
+	m Matrix
+
+	// alpha is the scale factor of a scale node.
+	alpha float64
+
+	// a and b are the operands of interior nodes.
+	a, b *Expr
+}
+
+type exprOp int
+
+const (
+	exprLeaf exprOp = iota
+	exprScale
+	exprTrans
+	exprMul
+	exprAdd
+	exprMulElem
+)
+
+// NewExpr returns an expression holding the matrix a.
+func NewExpr(a Matrix) *Expr {
+	r, c := a.Dims()
+	return &Expr{op: exprLeaf, r: r, c: c, m: a}
+}
+
+// Dims returns the dimensions of the matrix the expression evaluates to.
+func (e *Expr) Dims() (r, c int) {
+	return e.r, e.c
+}
+
+// Mul returns the expression for the matrix product e * b. If the number of
+// columns in e does not equal the number of rows in b, Mul will panic.
+func (e *Expr) Mul(b *Expr) *Expr {
+	if e.c != b.r {
+		panic(ErrShape)
+	}
+	return &Expr{op: exprMul, r: e.r, c: b.c, a: e, b: b}
+}
+
+// Add returns the expression for the element-wise sum e + b. If e and b do not
+// have the same dimensions, Add will panic.
+func (e *Expr) Add(b *Expr) *Expr {
+	if e.r != b.r || e.c != b.c {
+		panic(ErrShape)
+	}
+	return &Expr{op: exprAdd, r: e.r, c: e.c, a: e, b: b}
+}
+
+// MulElem returns the expression for the element-wise product of e and b. If
+// e and b do not have the same dimensions, MulElem will panic.
+func (e *Expr) MulElem(b *Expr) *Expr {
+	if e.r != b.r || e.c != b.c {
+		panic(ErrShape)
+	}
+	return &Expr{op: exprMulElem, r: e.r, c: e.c, a: e, b: b}
+}
+
+// Scale returns the expression for the matrix e scaled by f.
+func (e *Expr) Scale(f float64) *Expr {
+	return &Expr{op: exprScale, r: e.r, c: e.c, alpha: f, a: e}
+}
+
+// Transpose returns the expression for the transpose of e.
+func (e *Expr) Transpose() *Expr {
+	return &Expr{op: exprTrans, r: e.c, c: e.r, a: e}
+}
+
+// Eval evaluates the expression e, placing the result in the receiver.
+//
+// The expression is rewritten as a sum of scaled terms, each of which is a
+// single operand, a matrix product or an element-wise product. Matrix products
+// are computed with a single Dgemm call that also accumulates into the
+// receiver, and the remaining terms are accumulated in a single pass over the
+// receiver each. Operands that are not RawMatrixers and sub-expressions used
+// as factors of a product are evaluated into temporary workspace first.
+//
+// The receiver may be one of the operands of the expression. If the receiver
+// is used only as an untransposed summand, as in
+//  c = alpha * a * b + beta * c,
+// the expression is evaluated in place. Otherwise it is evaluated into
+// temporary workspace which is then copied into the receiver.
+func (m *Dense) Eval(e *Expr) {
+	m.reuseAs(e.r, e.c)
+
+	terms := e.terms(nil, 1, false)
+
+	// Find whether the receiver can be used as the accumulator.
+	inPlace := -1
+	var overlaps int
+	e.walkLeaves(func(a Matrix) {
+		aU, _ := untranspose(a)
+		if rm, ok := aU.(RawMatrixer); ok && generalsOverlap(m.mat, rm.RawMatrix()) {
+			overlaps++
+		}
+	})
+	if overlaps == 1 {
+		for i, t := range terms {
+			if t.kind != exprLeaf || t.aTrans {
+				continue
+			}
+			aU, trans := untranspose(t.a.m)
+			if trans {
+				continue
+			}
+			if rm, ok := aU.(RawMatrixer); ok && generalsIdentical(m.mat, rm.RawMatrix()) {
+				inPlace = i
+				break
+			}
+		}
+	}
+
+	if overlaps > 0 && inPlace < 0 {
+		w := getWorkspace(e.r, e.c, false)
+		w.evalTerms(terms, -1)
+		m.Copy(w)
+		putWorkspace(w)
+		return
+	}
+	m.evalTerms(terms, inPlace)
+}
+
+// exprTerm is a scaled summand of an expression. For leaf terms only a is used.
+type exprTerm struct {
+	kind   exprOp
+	alpha  float64
+	a, b   *Expr
+	aTrans bool
+	bTrans bool
+}
+
+// terms appends the summands of alpha * op(e) to dst, where op(e) is e or its
+// transpose, and returns the result.
+func (e *Expr) terms(dst []exprTerm, alpha float64, trans bool) []exprTerm {
+	switch e.op {
+	default:
+		panic("mat: invalid expression")
+	case exprLeaf:
+		return append(dst, exprTerm{kind: exprLeaf, alpha: alpha, a: e, aTrans: trans})
+	case exprScale:
+		return e.a.terms(dst, alpha*e.alpha, trans)
+	case exprTrans:
+		return e.a.terms(dst, alpha, !trans)
+	case exprAdd:
+		dst = e.a.terms(dst, alpha, trans)
+		return e.b.terms(dst, alpha, trans)
+	case exprMul:
+		if trans {
+			// (A * B)^T = B^T * A^T.
+			return append(dst, exprTerm{kind: exprMul, alpha: alpha, a: e.b, b: e.a, aTrans: true, bTrans: true})
+		}
+		return append(dst, exprTerm{kind: exprMul, alpha: alpha, a: e.a, b: e.b})
+	case exprMulElem:
+		return append(dst, exprTerm{kind: exprMulElem, alpha: alpha, a: e.a, b: e.b, aTrans: trans, bTrans: trans})
+	}
+}
+
+// walkLeaves calls fn for each leaf operand of e.
+func (e *Expr) walkLeaves(fn func(Matrix)) {
+	if e.op == exprLeaf {
+		fn(e.m)
+		return
+	}
+	e.a.walkLeaves(fn)
+	if e.b != nil {
+		e.b.walkLeaves(fn)
+	}
+}
+
+// exprOperand is an operand of a term resolved to raw storage.
+type exprOperand struct {
+	mat   blas64.General
+	trans bool
+	alpha float64
+	work  *Dense
+}
+
+// operand resolves alpha * op(e), where op(e) is e or its transpose, to a
+// general matrix, folding scale and transpose nodes. Sub-expressions and
+// operands that are not RawMatrixers are evaluated into workspace, which
+// must be returned with release.
+func (e *Expr) operand(alpha float64, trans bool) exprOperand {
+	for {
+		switch e.op {
+		case exprScale:
+			alpha *= e.alpha
+			e = e.a
+			continue
+		case exprTrans:
+			trans = !trans
+			e = e.a
+			continue
+		}
+		break
+	}
+	if e.op == exprLeaf {
+		aU, aTrans := untranspose(e.m)
+		if rm, ok := aU.(RawMatrixer); ok {
+			return exprOperand{mat: rm.RawMatrix(), trans: trans != aTrans, alpha: alpha}
+		}
+		w := getWorkspace(e.r, e.c, false)
+		w.Copy(e.m)
+		return exprOperand{mat: w.mat, trans: trans, alpha: alpha, work: w}
+	}
+	w := getWorkspace(e.r, e.c, false)
+	w.Eval(e)
+	return exprOperand{mat: w.mat, trans: trans, alpha: alpha, work: w}
+}
+
+// release returns the workspace held by the operand, if any.
+func (o exprOperand) release() {
+	if o.work != nil {
+		putWorkspace(o.work)
+	}
+}
+
+// at returns the (i, j) element of op(o) without the scale factor.
+func (o exprOperand) at(i, j int) float64 {
+	if o.trans {
+		return o.mat.Data[j*o.mat.Stride+i]
+	}
+	return o.mat.Data[i*o.mat.Stride+j]
+}
+
+// evalTerms computes the sum of the terms into the receiver, which must not
+// overlap any of the operands except for the leaf term at index inPlace, if
+// inPlace is not negative.
+//
+// During evaluation the partial sum is beta times the contents of the
+// receiver, so that scaling of the partial sum can be folded into the next
+// update.
+func (m *Dense) evalTerms(terms []exprTerm, inPlace int) {
+	var (
+		initialized bool
+		beta        = 1.0
+	)
+	if inPlace >= 0 {
+		initialized = true
+		beta = terms[inPlace].alpha
+	} else {
+		// Initialize the receiver from a leaf term with a copy so that the
+		// scaling can be folded into a following Dgemm.
+		for i, t := range terms {
+			if t.kind != exprLeaf {
+				continue
+			}
+			o := t.a.operand(t.alpha, t.aTrans)
+			if o.trans {
+				for r := 0; r < m.mat.Rows; r++ {
+					blas64.Implementation().Dcopy(m.mat.Cols, o.mat.Data[r:], o.mat.Stride, m.mat.Data[r*m.mat.Stride:], 1)
+				}
+			} else {
+				for r := 0; r < m.mat.Rows; r++ {
+					copy(m.mat.Data[r*m.mat.Stride:r*m.mat.Stride+m.mat.Cols], o.mat.Data[r*o.mat.Stride:])
+				}
+			}
+			o.release()
+			initialized = true
+			beta = o.alpha
+			inPlace = i
+			break
+		}
+	}
+
+	// Accumulate matrix products with Dgemm.
+	for _, t := range terms {
+		if t.kind != exprMul {
+			continue
+		}
+		a := t.a.operand(t.alpha, t.aTrans)
+		b := t.b.operand(1, t.bTrans)
+		aT := blas.NoTrans
+		if a.trans {
+			aT = blas.Trans
+		}
+		bT := blas.NoTrans
+		if b.trans {
+			bT = blas.Trans
+		}
+		if !initialized {
+			beta = 0
+		}
+		blas64.Gemm(aT, bT, a.alpha*b.alpha, a.mat, b.mat, beta, m.mat)
+		a.release()
+		b.release()
+		initialized = true
+		beta = 1
+	}
+
+	// Accumulate the remaining element-wise terms.
+	for i, t := range terms {
+		if i == inPlace || t.kind == exprMul {
+			continue
+		}
+		a := t.a.operand(t.alpha, t.aTrans)
+		alpha := a.alpha
+		var b exprOperand
+		if t.kind == exprMulElem {
+			b = t.b.operand(1, t.bTrans)
+			alpha *= b.alpha
+		}
+		for r := 0; r < m.mat.Rows; r++ {
+			row := m.mat.Data[r*m.mat.Stride : r*m.mat.Stride+m.mat.Cols]
+			for c := range row {
+				v := a.at(r, c)
+				if t.kind == exprMulElem {
+					v *= b.at(r, c)
+				}
+				if initialized {
+					row[c] = beta*row[c] + alpha*v
+				} else {
+					row[c] = alpha * v
+				}
+			}
+		}
+		a.release()
+		if t.kind == exprMulElem {
+			b.release()
+		}
+		initialized = true
+		beta = 1
+	}
+
+	if beta != 1 {
+		for r := 0; r < m.mat.Rows; r++ {
+			blas64.Implementation().Dscal(m.mat.Cols, beta, m.mat.Data[r*m.mat.Stride:], 1)
+		}
+	}
+}
+
+// generalsOverlap returns whether the data of a and b may overlap. It is
+// conservative and only compares the extents of the backing data.
+func generalsOverlap(a, b blas64.General) bool {
+	if len(a.Data) == 0 || len(b.Data) == 0 {
+		return false
+	}
+	off := offset(a.Data[:1], b.Data[:1])
+	if off >= 0 {
+		return off < len(a.Data)
+	}
+	return -off < len(b.Data)
+}
+
+// generalsIdentical returns whether a and b describe the same matrix in
+// memory.
+func generalsIdentical(a, b blas64.General) bool {
+	if len(a.Data) == 0 || len(b.Data) == 0 {
+		return false
+	}
+	return &a.Data[0] == &b.Data[0] && a.Rows == b.Rows && a.Cols == b.Cols && a.Stride == b.Stride
+}
@@ -0,0 +1,289 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomDense(r, c int, rnd *rand.Rand) *Dense {
+	m := NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	return m
+}
+
+func TestExprEval(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randomDense(3, 4, rnd)
+	b := randomDense(4, 5, rnd)
+	c := randomDense(3, 5, rnd)
+	d := randomDense(3, 5, rnd)
+	s := NewSymDense(4, nil)
+	for i := 0; i < 4; i++ {
+		for j := i; j < 4; j++ {
+			s.SetSym(i, j, rnd.NormFloat64())
+		}
+	}
+
+	for i, test := range []struct {
+		expr *Expr
+		want func() *Dense
+	}{
+		{
+			expr: NewExpr(a),
+			want: func() *Dense { return DenseCopyOf(a) },
+		},
+		{
+			expr: NewExpr(a).Scale(3),
+			want: func() *Dense {
+				var m Dense
+				m.Scale(3, a)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(a).Transpose(),
+			want: func() *Dense { return DenseCopyOf(a.T()) },
+		},
+		{
+			expr: NewExpr(a.T()).Transpose(),
+			want: func() *Dense { return DenseCopyOf(a) },
+		},
+		{
+			expr: NewExpr(a).Mul(NewExpr(b)),
+			want: func() *Dense {
+				var m Dense
+				m.Mul(a, b)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(a).Mul(NewExpr(b)).Scale(2).Add(NewExpr(c).Scale(-0.5)),
+			want: func() *Dense {
+				var m, tmp Dense
+				m.Mul(a, b)
+				m.Scale(2, &m)
+				tmp.Scale(-0.5, c)
+				m.Add(&m, &tmp)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(c).Add(NewExpr(a).Mul(NewExpr(b))).Add(NewExpr(a).Scale(2).Mul(NewExpr(b))),
+			want: func() *Dense {
+				var m Dense
+				m.Mul(a, b)
+				m.Scale(3, &m)
+				m.Add(&m, c)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(a).Mul(NewExpr(b)).Transpose(),
+			want: func() *Dense {
+				var m Dense
+				m.Mul(a, b)
+				return DenseCopyOf(m.T())
+			},
+		},
+		{
+			expr: NewExpr(b.T()).Mul(NewExpr(a.T())).Add(NewExpr(d.T())),
+			want: func() *Dense {
+				var m Dense
+				m.Mul(a, b)
+				m.Add(&m, d)
+				return DenseCopyOf(m.T())
+			},
+		},
+		{
+			expr: NewExpr(c).MulElem(NewExpr(d)).Scale(2).Add(NewExpr(c)),
+			want: func() *Dense {
+				var m Dense
+				m.MulElem(c, d)
+				m.Scale(2, &m)
+				m.Add(&m, c)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(c).Add(NewExpr(d)).MulElem(NewExpr(a).Mul(NewExpr(b))),
+			want: func() *Dense {
+				var m, p Dense
+				m.Add(c, d)
+				p.Mul(a, b)
+				m.MulElem(&m, &p)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(a).Mul(NewExpr(s)).Mul(NewExpr(b)).Add(NewExpr(c).Transpose().Transpose()),
+			want: func() *Dense {
+				var as, m Dense
+				as.Mul(a, s)
+				m.Mul(&as, b)
+				m.Add(&m, c)
+				return &m
+			},
+		},
+		{
+			expr: NewExpr(a).Mul(NewExpr(b)).Scale(0),
+			want: func() *Dense { return NewDense(3, 5, nil) },
+		},
+	} {
+		want := test.want()
+		var got Dense
+		got.Eval(test.expr)
+		if !EqualApprox(&got, want, 1e-12) {
+			t.Errorf("test %d: unexpected result:\ngot:\n%v\nwant:\n%v", i, Formatted(&got), Formatted(want))
+		}
+
+		// Evaluate into a sized receiver.
+		r, cols := test.expr.Dims()
+		sized := NewDense(r, cols, nil)
+		for k := range sized.mat.Data {
+			sized.mat.Data[k] = rnd.NormFloat64()
+		}
+		sized.Eval(test.expr)
+		if !EqualApprox(sized, want, 1e-12) {
+			t.Errorf("test %d: unexpected result with sized receiver", i)
+		}
+	}
+}
+
+func TestExprEvalAliased(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randomDense(4, 4, rnd)
+	b := randomDense(4, 4, rnd)
+	c := randomDense(4, 4, rnd)
+
+	for i, test := range []struct {
+		build func(c *Dense) *Expr
+		want  func(c *Dense) *Dense
+	}{
+		{
+			// In-place update of the receiver.
+			build: func(c *Dense) *Expr {
+				return NewExpr(a).Mul(NewExpr(b)).Scale(2).Add(NewExpr(c).Scale(3))
+			},
+			want: func(c *Dense) *Dense {
+				var m, tmp Dense
+				m.Mul(a, b)
+				m.Scale(2, &m)
+				tmp.Scale(3, c)
+				m.Add(&m, &tmp)
+				return &m
+			},
+		},
+		{
+			// The receiver is a factor.
+			build: func(c *Dense) *Expr {
+				return NewExpr(c).Mul(NewExpr(b)).Add(NewExpr(a))
+			},
+			want: func(c *Dense) *Dense {
+				var m Dense
+				m.Mul(c, b)
+				m.Add(&m, a)
+				return &m
+			},
+		},
+		{
+			// The receiver is transposed.
+			build: func(c *Dense) *Expr {
+				return NewExpr(c).Transpose().Add(NewExpr(a))
+			},
+			want: func(c *Dense) *Dense {
+				var m Dense
+				m.Add(c.T(), a)
+				return &m
+			},
+		},
+		{
+			// The receiver is used twice.
+			build: func(c *Dense) *Expr {
+				return NewExpr(c).MulElem(NewExpr(c)).Add(NewExpr(c))
+			},
+			want: func(c *Dense) *Dense {
+				var m Dense
+				m.MulElem(c, c)
+				m.Add(&m, c)
+				return &m
+			},
+		},
+		{
+			// The receiver overlaps a view.
+			build: func(c *Dense) *Expr {
+				return NewExpr(c.Slice(0, 2, 0, 4)).Transpose().Mul(NewExpr(c.Slice(2, 4, 0, 4)))
+			},
+			want: func(c *Dense) *Dense {
+				var m Dense
+				m.Mul(c.Slice(0, 2, 0, 4).T(), c.Slice(2, 4, 0, 4))
+				return &m
+			},
+		},
+	} {
+		dst := DenseCopyOf(c)
+		want := test.want(dst)
+		dst.Eval(test.build(dst))
+		if !EqualApprox(dst, want, 1e-12) {
+			t.Errorf("test %d: unexpected result", i)
+		}
+	}
+}
+
+func TestExprShape(t *testing.T) {
+	a := NewDense(3, 4, nil)
+	b := NewDense(3, 5, nil)
+	for _, fn := range []func(){
+		func() { NewExpr(a).Mul(NewExpr(b)) },
+		func() { NewExpr(a).Add(NewExpr(b)) },
+		func() { NewExpr(a).MulElem(NewExpr(b)) },
+		func() { NewExpr(a).Transpose().Add(NewExpr(a)) },
+	} {
+		panicked, message := panics(fn)
+		if !panicked || message != ErrShape.Error() {
+			t.Errorf("expected shape panic when building expression, got %q", message)
+		}
+	}
+	if panicked, _ := panics(func() { NewExpr(a).Transpose().Mul(NewExpr(b)) }); panicked {
+		t.Errorf("unexpected panic for conformant expression")
+	}
+	var dst Dense
+	dst.Eval(NewExpr(a).Transpose().Mul(NewExpr(b)))
+	if r, c := dst.Dims(); r != 4 || c != 5 {
+		t.Errorf("unexpected dimensions: got %d×%d, want 4×5", r, c)
+	}
+}
+
+func BenchmarkExprGemm(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	x := randomDense(100, 100, rnd)
+	y := randomDense(100, 100, rnd)
+	z := randomDense(100, 100, rnd)
+	e := NewExpr(x).Mul(NewExpr(y)).Scale(2).Add(NewExpr(z).Scale(0.5))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		z.Eval(e)
+	}
+}
+
+func BenchmarkExprGemmUnfused(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	x := randomDense(100, 100, rnd)
+	y := randomDense(100, 100, rnd)
+	z := randomDense(100, 100, rnd)
+	var tmp Dense
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tmp.Mul(x, y)
+		tmp.Scale(2, &tmp)
+		z.Scale(0.5, z)
+		z.Add(&tmp, z)
+	}
+}
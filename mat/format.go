@@ -6,6 +6,7 @@ package mat
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
@@ -27,8 +28,20 @@ type formatter struct {
 	margin  int
 	dot     byte
 	squeeze bool
+	syntax  formatSyntax
 }
 
+// formatSyntax specifies the textual syntax used for formatting.
+type formatSyntax int
+
+const (
+	defaultSyntax formatSyntax = iota
+	matlabSyntax
+	pythonSyntax
+	latexSyntax
+	csvSyntax
+)
+
 // FormatOption is a functional option for matrix formatting.
 type FormatOption func(*formatter)
 
@@ -56,8 +69,45 @@ func Squeeze() FormatOption {
 	return func(f *formatter) { f.squeeze = true }
 }
 
+// FormatMATLAB sets the printing behaviour to output a MATLAB matrix literal,
+// for example [1 2; 3 4]. If the '#' verb flag is used, each row is printed
+// on a separate line with aligned columns. The Excerpt option and the ' ' verb
+// flag are ignored. The output can be read back with ParseMATLAB.
+func FormatMATLAB() FormatOption {
+	return func(f *formatter) { f.syntax = matlabSyntax }
+}
+
+// FormatPython sets the printing behaviour to output a NumPy array literal,
+// for example np.array([[1, 2], [3, 4]]). If the '#' verb flag is used, each
+// row is printed on a separate line with aligned columns. The Excerpt option
+// and the ' ' verb flag are ignored. The output can be read back with
+// ParsePython.
+func FormatPython() FormatOption {
+	return func(f *formatter) { f.syntax = pythonSyntax }
+}
+
+// FormatLaTeX sets the printing behaviour to output a LaTeX bmatrix
+// environment with each row on a separate line. If the '#' verb flag is used,
+// the columns are aligned. The Excerpt option and the ' ' verb flag are
+// ignored.
+func FormatLaTeX() FormatOption {
+	return func(f *formatter) { f.syntax = latexSyntax }
+}
+
+// FormatCSV sets the printing behaviour to output comma-separated values with
+// each row of the matrix on a separate line. The Excerpt option, the ' ' and
+// '#' verb flags and the width are ignored. The output can be read back with
+// ParseCSV.
+func FormatCSV() FormatOption {
+	return func(f *formatter) { f.syntax = csvSyntax }
+}
+
 // Format satisfies the fmt.Formatter interface.
 func (f formatter) Format(fs fmt.State, c rune) {
+	if f.syntax != defaultSyntax {
+		formatSyntaxed(f.matrix, f.syntax, f.prefix, f.squeeze, fs, c)
+		return
+	}
 	if c == 'v' && fs.Flag('#') {
 		fmt.Fprintf(fs, "%#v", f.matrix)
 		return
@@ -104,11 +154,15 @@ func format(m Matrix, prefix string, margin int, dot byte, squeeze bool, fs fmt.
 	}
 	switch c {
 	case 'v', 'e', 'E', 'f', 'F', 'g', 'G':
-		if c == 'v' {
-			buf, maxWidth = maxCellWidth(m, 'g', printed, prec, widths)
-		} else {
-			buf, maxWidth = maxCellWidth(m, c, printed, prec, widths)
+		// Note that the 'F' verb is a synonym for 'f' in fmt, but
+		// strconv does not accept it.
+		switch c {
+		case 'v':
+			c = 'g'
+		case 'F':
+			c = 'f'
 		}
+		buf, maxWidth = maxCellWidth(m, c, printed, prec, widths)
 	default:
 		fmt.Fprintf(fs, "%%!%c(%T=Dims(%d, %d))", c, m, rows, cols)
 		return
@@ -164,11 +218,7 @@ func format(m Matrix, prefix string, margin int, dot byte, squeeze bool, fs fmt.
 				buf = buf[:1]
 				buf[0] = dot
 			} else {
-				if c == 'v' {
-					buf = strconv.AppendFloat(buf[:0], v, 'g', prec, 64)
-				} else {
-					buf = strconv.AppendFloat(buf[:0], v, byte(c), prec, 64)
-				}
+				buf = strconv.AppendFloat(buf[:0], v, byte(c), prec, 64)
 			}
 			if fs.Flag('-') {
 				fs.Write(buf)
@@ -193,6 +243,178 @@ func format(m Matrix, prefix string, margin int, dot byte, squeeze bool, fs fmt.
 	}
 }
 
+// formatSyntaxed prints m to fs using the given syntax. The format character c
+// and precision are interpreted as for format. Lines after the first are
+// prefixed with prefix. If squeeze is true and columns are aligned, column
+// widths are determined on a per-column basis.
+func formatSyntaxed(m Matrix, syntax formatSyntax, prefix string, squeeze bool, fs fmt.State, c rune) {
+	rows, cols := m.Dims()
+
+	switch c {
+	case 'v':
+		c = 'g'
+	case 'F':
+		c = 'f'
+	case 'e', 'E', 'f', 'g', 'G':
+	default:
+		fmt.Fprintf(fs, "%%!%c(%T=Dims(%d, %d))", c, m, rows, cols)
+		return
+	}
+	prec, pOk := fs.Precision()
+	if !pOk {
+		prec = -1
+	}
+
+	// Render all the elements so that columns can be aligned.
+	cells := make([]string, rows*cols)
+	var buf []byte
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			buf = appendSyntaxFloat(buf[:0], m.At(i, j), syntax, byte(c), prec)
+			cells[i*cols+j] = string(buf)
+		}
+	}
+	align := fs.Flag('#') && syntax != csvSyntax
+	widths := make([]int, cols)
+	if align {
+		width, _ := fs.Width()
+		for j := range widths {
+			widths[j] = width
+		}
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				widths[j] = max(widths[j], len(cells[i*cols+j]))
+			}
+		}
+		if !squeeze {
+			var w int
+			for _, v := range widths {
+				w = max(w, v)
+			}
+			for j := range widths {
+				widths[j] = w
+			}
+		}
+	}
+	cell := func(i, j int) {
+		s := cells[i*cols+j]
+		if !align || len(s) >= widths[j] {
+			fmt.Fprint(fs, s)
+			return
+		}
+		pad := fmt.Sprintf("%*s", widths[j]-len(s), "")
+		if fs.Flag('-') {
+			fmt.Fprint(fs, s, pad)
+		} else {
+			fmt.Fprint(fs, pad, s)
+		}
+	}
+
+	switch syntax {
+	case matlabSyntax:
+		fmt.Fprint(fs, "[")
+		for i := 0; i < rows; i++ {
+			if i > 0 {
+				if align {
+					fmt.Fprintf(fs, "\n%s ", prefix)
+				} else {
+					fmt.Fprint(fs, "; ")
+				}
+			}
+			for j := 0; j < cols; j++ {
+				if j > 0 {
+					fmt.Fprint(fs, " ")
+				}
+				cell(i, j)
+			}
+		}
+		fmt.Fprint(fs, "]")
+
+	case pythonSyntax:
+		fmt.Fprint(fs, "np.array([")
+		for i := 0; i < rows; i++ {
+			if i > 0 {
+				if align {
+					fmt.Fprintf(fs, ",\n%s          ", prefix)
+				} else {
+					fmt.Fprint(fs, ", ")
+				}
+			}
+			fmt.Fprint(fs, "[")
+			for j := 0; j < cols; j++ {
+				if j > 0 {
+					fmt.Fprint(fs, ", ")
+				}
+				cell(i, j)
+			}
+			fmt.Fprint(fs, "]")
+		}
+		fmt.Fprint(fs, "])")
+
+	case latexSyntax:
+		fmt.Fprintf(fs, "\\begin{bmatrix}\n%s", prefix)
+		for i := 0; i < rows; i++ {
+			for j := 0; j < cols; j++ {
+				if j > 0 {
+					fmt.Fprint(fs, " & ")
+				}
+				cell(i, j)
+			}
+			if i < rows-1 {
+				fmt.Fprint(fs, " \\\\")
+			}
+			fmt.Fprintf(fs, "\n%s", prefix)
+		}
+		fmt.Fprint(fs, "\\end{bmatrix}")
+
+	case csvSyntax:
+		for i := 0; i < rows; i++ {
+			if i > 0 {
+				fmt.Fprintf(fs, "\n%s", prefix)
+			}
+			for j := 0; j < cols; j++ {
+				if j > 0 {
+					fmt.Fprint(fs, ",")
+				}
+				cell(i, j)
+			}
+		}
+
+	default:
+		panic("mat: invalid format syntax")
+	}
+}
+
+// appendSyntaxFloat appends the representation of v in the given syntax to
+// buf using the format character c and precision prec.
+func appendSyntaxFloat(buf []byte, v float64, syntax formatSyntax, c byte, prec int) []byte {
+	switch {
+	case math.IsNaN(v):
+		switch syntax {
+		case matlabSyntax:
+			return append(buf, "NaN"...)
+		case pythonSyntax:
+			return append(buf, "np.nan"...)
+		case latexSyntax:
+			return append(buf, `\mathrm{NaN}`...)
+		}
+	case math.IsInf(v, 0):
+		if v < 0 {
+			buf = append(buf, '-')
+		}
+		switch syntax {
+		case matlabSyntax:
+			return append(buf, "Inf"...)
+		case pythonSyntax:
+			return append(buf, "np.inf"...)
+		case latexSyntax:
+			return append(buf, `\infty`...)
+		}
+		return append(buf, "Inf"...)
+	}
+	return strconv.AppendFloat(buf, v, c, prec, 64)
+}
+
 func maxCellWidth(m Matrix, c rune, printed, prec int, w widther) ([]byte, int) {
 	var (
 		buf        = make([]byte, 0, 64)
@@ -110,3 +110,28 @@ func ExampleExcerpt() {
 	//  [ 0   1   2  ...  ...  97  98  99]
 
 }
+
+func ExampleFormatMATLAB() {
+	a := mat.NewDense(2, 3, []float64{1, 2.5, 3, 0, 4, -5})
+
+	// Print the matrix as literals that can be pasted into other languages.
+	fmt.Printf("matlab = %v\n", mat.Formatted(a, mat.FormatMATLAB()))
+	fmt.Printf("python = %.2f\n", mat.Formatted(a, mat.FormatPython()))
+	fmt.Printf("%v\n", mat.Formatted(a, mat.FormatLaTeX()))
+
+	// The MATLAB, Python and CSV forms can be parsed back into a Dense.
+	b, err := mat.ParseMATLAB(fmt.Sprint(mat.Formatted(a, mat.FormatMATLAB())))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Printf("equal = %t\n", mat.Equal(a, b))
+
+	// Output:
+	// matlab = [1 2.5 3; 0 4 -5]
+	// python = np.array([[1.00, 2.50, 3.00], [0.00, 4.00, -5.00]])
+	// \begin{bmatrix}
+	// 1 & 2.5 & 3 \\
+	// 0 & 4 & -5
+	// \end{bmatrix}
+	// equal = true
+}
@@ -79,6 +79,7 @@ func TestFormat(t *testing.T) {
 			[]rp{
 				{"%v", "⎡                 0                   1  1.4142135623730951⎤\n⎣1.7320508075688772                   2    2.23606797749979⎦"},
 				{"%.2f", "⎡0.00  1.00  1.41⎤\n⎣1.73  2.00  2.24⎦"},
+				{"%.2F", "⎡0.00  1.00  1.41⎤\n⎣1.73  2.00  2.24⎦"},
 				{"% f", "⎡                 .                   1  1.4142135623730951⎤\n⎣1.7320508075688772                   2    2.23606797749979⎦"},
 				{"%#v", "&mat.Dense{mat:blas64.General{Rows:2, Cols:3, Stride:3, Data:[]float64{0, 1, 1.4142135623730951, 1.7320508075688772, 2, 2.23606797749979}}, capRows:2, capCols:3}"},
 			},
@@ -148,3 +149,42 @@ func TestFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatSyntax(t *testing.T) {
+	a := NewDense(2, 3, []float64{1, -2.5, 3, 0.125, 1e6, -10})
+	for _, test := range []struct {
+		opt    FormatOption
+		format string
+		want   string
+	}{
+		{FormatMATLAB(), "%v", "[1 -2.5 3; 0.125 1e+06 -10]"},
+		{FormatMATLAB(), "%.2f", "[1.00 -2.50 3.00; 0.12 1000000.00 -10.00]"},
+		{FormatMATLAB(), "%.2F", "[1.00 -2.50 3.00; 0.12 1000000.00 -10.00]"},
+		{FormatMATLAB(), "%#v", "[    1  -2.5     3\n 0.125 1e+06   -10]"},
+		{FormatPython(), "%v", "np.array([[1, -2.5, 3], [0.125, 1e+06, -10]])"},
+		{FormatPython(), "%.3g", "np.array([[1, -2.5, 3], [0.125, 1e+06, -10]])"},
+		{FormatPython(), "%#v", "np.array([[    1,  -2.5,     3],\n          [0.125, 1e+06,   -10]])"},
+		{FormatLaTeX(), "%v", "\\begin{bmatrix}\n1 & -2.5 & 3 \\\\\n0.125 & 1e+06 & -10\n\\end{bmatrix}"},
+		{FormatLaTeX(), "%.1e", "\\begin{bmatrix}\n1.0e+00 & -2.5e+00 & 3.0e+00 \\\\\n1.2e-01 & 1.0e+06 & -1.0e+01\n\\end{bmatrix}"},
+		{FormatCSV(), "%v", "1,-2.5,3\n0.125,1e+06,-10"},
+		{FormatCSV(), "%#v", "1,-2.5,3\n0.125,1e+06,-10"},
+		{FormatCSV(), "%s", "%!s(*mat.Dense=Dims(2, 3))"},
+	} {
+		got := fmt.Sprintf(test.format, Formatted(a, test.opt))
+		if got != test.want {
+			t.Errorf("unexpected output for %q:\ngot:\n%s\nwant:\n%s", test.format, got, test.want)
+		}
+	}
+
+	// Check that the prefix is applied to each line after the first.
+	got := fmt.Sprintf("%#v", Formatted(a, FormatMATLAB(), Prefix("\t")))
+	want := "[    1  -2.5     3\n\t 0.125 1e+06   -10]"
+	if got != want {
+		t.Errorf("unexpected output with prefix:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+	got = fmt.Sprintf("%#v", Formatted(a, FormatMATLAB(), Squeeze()))
+	want = "[    1  -2.5   3\n 0.125 1e+06 -10]"
+	if got != want {
+		t.Errorf("unexpected squeezed output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
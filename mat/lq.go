@@ -20,7 +20,7 @@ type LQ struct {
 	cond float64
 }
 
-func (lq *LQ) updateCond(norm lapack.MatrixNorm) {
+func (lq *LQ) updateCond(norm lapack.MatrixNorm, ws *Workspace) {
 	// Since A = L*Q, and Q is orthogonal, we get for the condition number κ
 	//  κ(A) := |A| |A^-1| = |L*Q| |(L*Q)^-1| = |L| |Q^T * L^-1|
 	//        = |L| |L^-1| = κ(L),
@@ -29,13 +29,13 @@ func (lq *LQ) updateCond(norm lapack.MatrixNorm) {
 	// is not the case for CondNorm. Hopefully the error is negligible: κ
 	// is only a qualitative measure anyway.
 	m := lq.lq.mat.Rows
-	work := getFloats(3*m, false)
-	iwork := getInts(m, false)
+	work := ws.getFloats(3*m, false)
+	iwork := ws.getInts(m, false)
 	l := lq.lq.asTriDense(m, blas.NonUnit, blas.Lower)
 	v := lapack64.Trcon(norm, l.mat, work, iwork)
 	lq.cond = 1 / v
-	putFloats(work)
-	putInts(iwork)
+	ws.putFloats(work)
+	ws.putInts(iwork)
 }
 
 // Factorize computes the LQ factorization of an m×n matrix a where n <= m. The LQ
@@ -45,26 +45,34 @@ func (lq *LQ) updateCond(norm lapack.MatrixNorm) {
 // The matrix Q is an orthonormal n×n matrix, and L is an m×n upper triangular matrix.
 // L and Q can be extracted from the LTo and QTo methods.
 func (lq *LQ) Factorize(a Matrix) {
-	lq.factorize(a, CondNorm)
+	lq.factorize(a, CondNorm, nil)
 }
 
-func (lq *LQ) factorize(a Matrix, norm lapack.MatrixNorm) {
+func (lq *LQ) factorize(a Matrix, norm lapack.MatrixNorm, ws *Workspace) {
 	m, n := a.Dims()
 	if m > n {
 		panic(ErrShape)
 	}
 	k := min(m, n)
 	if lq.lq == nil {
-		lq.lq = &Dense{}
+		lq.lq = NewDense(m, n, nil)
+	} else {
+		lq.lq.Reset()
+		lq.lq.reuseAs(m, n)
 	}
-	lq.lq.Clone(a)
-	work := []float64{0}
-	lq.tau = make([]float64, k)
+	lq.lq.Copy(a)
+	if cap(lq.tau) < k {
+		lq.tau = make([]float64, k)
+	}
+	lq.tau = lq.tau[:k]
+	work := ws.getFloats(1, false)
 	lapack64.Gelqf(lq.lq.mat, lq.tau, work, -1)
-	work = getFloats(int(work[0]), false)
+	lwork := int(work[0])
+	ws.putFloats(work)
+	work = ws.getFloats(lwork, false)
 	lapack64.Gelqf(lq.lq.mat, lq.tau, work, len(work))
-	putFloats(work)
-	lq.updateCond(norm)
+	ws.putFloats(work)
+	lq.updateCond(norm, ws)
 }
 
 // Cond returns the condition number for the factorized matrix.
@@ -150,6 +158,10 @@ func (lq *LQ) QTo(dst *Dense) *Dense {
 //  If trans == true, find X such that ||A*X - b||_2 is minimized.
 // The solution matrix, X, is stored in place into m.
 func (lq *LQ) Solve(m *Dense, trans bool, b Matrix) error {
+	return lq.solve(m, trans, b, nil)
+}
+
+func (lq *LQ) solve(m *Dense, trans bool, b Matrix, ws *Workspace) error {
 	r, c := lq.lq.Dims()
 	br, bc := b.Dims()
 
@@ -170,15 +182,12 @@ func (lq *LQ) Solve(m *Dense, trans bool, b Matrix) error {
 	}
 	// Do not need to worry about overlap between m and b because x has its own
 	// independent storage.
-	x := getWorkspace(max(r, c), bc, false)
+	x := ws.getWorkspace(max(r, c), bc, false)
+	defer ws.putWorkspace(x)
 	x.Copy(b)
 	t := lq.lq.asTriDense(lq.lq.mat.Rows, blas.NonUnit, blas.Lower).mat
 	if trans {
-		work := []float64{0}
-		lapack64.Ormlq(blas.Left, blas.NoTrans, lq.lq.mat, lq.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormlq(blas.Left, blas.NoTrans, lq.lq.mat, lq.tau, x.mat, work, len(work))
-		putFloats(work)
+		lq.ormlq(blas.NoTrans, x, ws)
 
 		ok := lapack64.Trtrs(blas.Trans, t, x.mat)
 		if !ok {
@@ -192,21 +201,28 @@ func (lq *LQ) Solve(m *Dense, trans bool, b Matrix) error {
 		for i := r; i < c; i++ {
 			zero(x.mat.Data[i*x.mat.Stride : i*x.mat.Stride+bc])
 		}
-		work := []float64{0}
-		lapack64.Ormlq(blas.Left, blas.Trans, lq.lq.mat, lq.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormlq(blas.Left, blas.Trans, lq.lq.mat, lq.tau, x.mat, work, len(work))
-		putFloats(work)
+		lq.ormlq(blas.Trans, x, ws)
 	}
 	// M was set above to be the correct size for the result.
 	m.Copy(x)
-	putWorkspace(x)
 	if lq.cond > ConditionTolerance {
 		return Condition(lq.cond)
 	}
 	return nil
 }
 
+// ormlq multiplies x from the left by Q or Q^T depending on trans.
+func (lq *LQ) ormlq(trans blas.Transpose, x *Dense, ws *Workspace) {
+	work := ws.getFloats(1, false)
+	lapack64.Ormlq(blas.Left, trans, lq.lq.mat, lq.tau, x.mat, work, -1)
+	lwork := int(work[0])
+	ws.putFloats(work)
+
+	work = ws.getFloats(lwork, false)
+	lapack64.Ormlq(blas.Left, trans, lq.lq.mat, lq.tau, x.mat, work, len(work))
+	ws.putFloats(work)
+}
+
 // SolveVec finds a minimum-norm solution to a system of linear equations.
 // Please see LQ.Solve for the full documentation.
 func (lq *LQ) SolveVec(v *VecDense, trans bool, b *VecDense) error {
@@ -25,12 +25,12 @@ type LU struct {
 
 // updateCond updates the stored condition number of the matrix. anorm is the
 // norm of the original matrix. If anorm is negative it will be estimated.
-func (lu *LU) updateCond(anorm float64, norm lapack.MatrixNorm) {
+func (lu *LU) updateCond(anorm float64, norm lapack.MatrixNorm, ws *Workspace) {
 	n := lu.lu.mat.Cols
-	work := getFloats(4*n, false)
-	defer putFloats(work)
-	iwork := getInts(n, false)
-	defer putInts(iwork)
+	work := ws.getFloats(4*n, false)
+	defer ws.putFloats(work)
+	iwork := ws.getInts(n, false)
+	defer ws.putInts(iwork)
 	if anorm < 0 {
 		// This is an approximation. By the definition of a norm,
 		//  |AB| <= |A| |B|.
@@ -57,10 +57,16 @@ func (lu *LU) updateCond(anorm float64, norm lapack.MatrixNorm) {
 // factors can be extracted from the factorization using the Permutation method
 // on Dense, and the LU LTo and UTo methods.
 func (lu *LU) Factorize(a Matrix) {
-	lu.factorize(a, CondNorm)
+	lu.factorize(a, CondNorm, nil)
 }
 
-func (lu *LU) factorize(a Matrix, norm lapack.MatrixNorm) {
+// FactorizeWork is like Factorize, but obtains temporary storage from ws.
+// If ws is nil, FactorizeWork is equivalent to Factorize.
+func (lu *LU) FactorizeWork(a Matrix, ws *Workspace) {
+	lu.factorize(a, CondNorm, ws)
+}
+
+func (lu *LU) factorize(a Matrix, norm lapack.MatrixNorm, ws *Workspace) {
 	r, c := a.Dims()
 	if r != c {
 		panic(ErrSquare)
@@ -76,11 +82,11 @@ func (lu *LU) factorize(a Matrix, norm lapack.MatrixNorm) {
 		lu.pivot = make([]int, r)
 	}
 	lu.pivot = lu.pivot[:r]
-	work := getFloats(r, false)
+	work := ws.getFloats(r, false)
 	anorm := lapack64.Lange(norm, lu.lu.mat, work)
-	putFloats(work)
+	ws.putFloats(work)
 	lapack64.Getrf(lu.lu.mat, lu.pivot)
-	lu.updateCond(anorm, norm)
+	lu.updateCond(anorm, norm, ws)
 }
 
 // Cond returns the condition number for the factorized matrix.
@@ -108,7 +114,11 @@ func (lu *LU) isZero() bool {
 // Det returns the determinant of the matrix that has been factorized. In many
 // expressions, using LogDet will be more numerically stable.
 func (lu *LU) Det() float64 {
-	det, sign := lu.LogDet()
+	return lu.det(nil)
+}
+
+func (lu *LU) det(ws *Workspace) float64 {
+	det, sign := lu.logDet(ws)
 	return math.Exp(det) * sign
 }
 
@@ -116,9 +126,13 @@ func (lu *LU) Det() float64 {
 // for the matrix that has been factorized. Numerical stability in product and
 // division expressions is generally improved by working in log space.
 func (lu *LU) LogDet() (det float64, sign float64) {
+	return lu.logDet(nil)
+}
+
+func (lu *LU) logDet(ws *Workspace) (det float64, sign float64) {
 	_, n := lu.lu.Dims()
-	logDiag := getFloats(n, false)
-	defer putFloats(logDiag)
+	logDiag := ws.getFloats(n, false)
+	defer ws.putFloats(logDiag)
 	sign = 1.0
 	for i := 0; i < n; i++ {
 		v := lu.lu.at(i, i)
@@ -222,7 +236,7 @@ func (lu *LU) RankOne(orig *LU, alpha float64, x, y *VecDense) {
 			lum.Data[j*lum.Stride+i] += gamma * tmp
 		}
 	}
-	lu.updateCond(-1, CondNorm)
+	lu.updateCond(-1, CondNorm, nil)
 }
 
 // LTo extracts the lower triangular matrix from an LU factorization.
@@ -291,6 +305,16 @@ func (m *Dense) Permutation(r int, swaps []int) {
 // If A is singular or near-singular a Condition error is returned. Please see
 // the documentation for Condition for more information.
 func (lu *LU) Solve(m *Dense, trans bool, b Matrix) error {
+	return lu.solve(m, trans, b, nil)
+}
+
+// SolveWork is like Solve, but obtains temporary storage from ws.
+// If ws is nil, SolveWork is equivalent to Solve.
+func (lu *LU) SolveWork(m *Dense, trans bool, b Matrix, ws *Workspace) error {
+	return lu.solve(m, trans, b, ws)
+}
+
+func (lu *LU) solve(m *Dense, trans bool, b Matrix, ws *Workspace) error {
 	_, n := lu.lu.Dims()
 	br, bc := b.Dims()
 	if br != n {
@@ -298,16 +322,19 @@ func (lu *LU) Solve(m *Dense, trans bool, b Matrix) error {
 	}
 	// TODO(btracey): Should test the condition number instead of testing that
 	// the determinant is exactly zero.
-	if lu.Det() == 0 {
+	if lu.det(ws) == 0 {
 		return Condition(math.Inf(1))
 	}
 
 	m.reuseAs(n, bc)
 	bU, _ := untranspose(b)
-	var restore func()
 	if m == bU {
-		m, restore = m.isolatedWorkspace(bU)
-		defer restore()
+		dst := m
+		m = ws.getWorkspace(n, bc, false)
+		defer func() {
+			dst.Copy(m)
+			ws.putWorkspace(m)
+		}()
 	} else if rm, ok := bU.(RawMatrixer); ok {
 		m.checkOverlap(rm.RawMatrix())
 	}
@@ -334,6 +361,16 @@ func (lu *LU) Solve(m *Dense, trans bool, b Matrix) error {
 // If A is singular or near-singular a Condition error is returned. Please see
 // the documentation for Condition for more information.
 func (lu *LU) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	return lu.solveVec(v, trans, b, nil)
+}
+
+// SolveVecWork is like SolveVec, but obtains temporary storage from ws.
+// If ws is nil, SolveVecWork is equivalent to SolveVec.
+func (lu *LU) SolveVecWork(v *VecDense, trans bool, b *VecDense, ws *Workspace) error {
+	return lu.solveVec(v, trans, b, ws)
+}
+
+func (lu *LU) solveVec(v *VecDense, trans bool, b *VecDense, ws *Workspace) error {
 	_, n := lu.lu.Dims()
 	bn := b.Len()
 	if bn != n {
@@ -344,15 +381,18 @@ func (lu *LU) SolveVec(v *VecDense, trans bool, b *VecDense) error {
 	}
 	// TODO(btracey): Should test the condition number instead of testing that
 	// the determinant is exactly zero.
-	if lu.Det() == 0 {
+	if lu.det(ws) == 0 {
 		return Condition(math.Inf(1))
 	}
 
 	v.reuseAs(n)
-	var restore func()
 	if v == b {
-		v, restore = v.isolatedWorkspace(b)
-		defer restore()
+		dst := v
+		v = ws.getWorkspaceVec(n, false)
+		defer func() {
+			dst.CopyVec(v)
+			ws.putWorkspaceVec(v)
+		}()
 	}
 	v.CopyVec(b)
 	vMat := blas64.General{
@@ -0,0 +1,145 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matgen
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Correlation returns a random n×n correlation matrix drawn from the LKJ
+// distribution with shape parameter eta, whose density is proportional to
+//  det(C)^(eta-1).
+// eta == 1 gives matrices distributed uniformly over the set of correlation
+// matrices, and larger values of eta concentrate the distribution around the
+// identity. Each off-diagonal element has the distribution of 2*X-1 where X
+// is Beta distributed with both parameters eta-1+n/2.
+//
+// The matrix is generated by the vine method described in
+//  Lewandowski, D., Kurowicka, D. and Joe, H. Generating random correlation
+//  matrices based on vines and extended onion method. Journal of Multivariate
+//  Analysis 100(9), 1989-2001 (2009).
+//
+// Correlation panics if eta is not positive.
+func Correlation(n int, eta float64, src rand.Source) *mat.SymDense {
+	if !(eta > 0) {
+		panic(badEta)
+	}
+	rnd := newRand(src)
+
+	// p holds the partial correlations in its upper triangle.
+	p := make([]float64, n*n)
+	c := mat.NewSymDense(n, nil)
+	beta := eta + float64(n-1)/2
+	for k := 0; k < n; k++ {
+		c.SetSym(k, k, 1)
+		beta -= 0.5
+		for i := k + 1; i < n; i++ {
+			pki := 2*distuv.Beta{Alpha: beta, Beta: beta, Source: rnd}.Rand() - 1
+			p[k*n+i] = pki
+			// Convert the partial correlation to a correlation.
+			for l := k - 1; l >= 0; l-- {
+				pli := p[l*n+i]
+				plk := p[l*n+k]
+				pki = pki*math.Sqrt((1-pli*pli)*(1-plk*plk)) + pli*plk
+			}
+			c.SetSym(k, i, pki)
+		}
+	}
+	return c
+}
+
+// CorrelationEig returns a random correlation matrix with the eigenvalues
+// in eig scaled so that their sum is len(eig). The elements of eig must be
+// non-negative and not all zero.
+//
+// A symmetric matrix with the scaled eigenvalues is generated by Symmetric
+// and reduced to unit diagonal by a sequence of plane rotations as described in
+//  Davies, P. I. and Higham, N. J. Numerically stable generation of correlation
+//  matrices and their factors. BIT 40(4), 640-651 (2000).
+//
+// CorrelationEig panics if any element of eig is negative or all are zero.
+func CorrelationEig(eig []float64, src rand.Source) *mat.SymDense {
+	n := len(eig)
+	var sum float64
+	for _, v := range eig {
+		if !(v >= 0) {
+			panic(badValue)
+		}
+		sum += v
+	}
+	if sum == 0 {
+		panic(badValue)
+	}
+	scaled := make([]float64, n)
+	for i, v := range eig {
+		scaled[i] = v * float64(n) / sum
+	}
+	s := Symmetric(scaled, src)
+	a := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a[i*n+j] = s.At(i, j)
+		}
+	}
+
+	fixed := make([]bool, n)
+	for step := 0; step < n-1; step++ {
+		// Find a pair of unfixed diagonal elements straddling 1.
+		i, j := -1, -1
+		for k := 0; k < n; k++ {
+			if fixed[k] {
+				continue
+			}
+			d := a[k*n+k]
+			if d < 1 && i < 0 {
+				i = k
+			}
+			if d > 1 && j < 0 {
+				j = k
+			}
+		}
+		if i < 0 || j < 0 {
+			break
+		}
+
+		// Compute the rotation that sets a[i,i] to 1 by solving
+		//  (a_jj-1)*t^2 - 2*a_ij*t + (a_ii-1) = 0
+		// for t = s/c, choosing the smaller root for stability.
+		aii := a[i*n+i]
+		ajj := a[j*n+j]
+		aij := a[i*n+j]
+		disc := math.Sqrt(aij*aij - (aii-1)*(ajj-1))
+		t := (aii - 1) / (aij + math.Copysign(disc, aij))
+		c := 1 / math.Sqrt(1+t*t)
+		sn := c * t
+
+		// Apply the rotation to the columns and rows i and j.
+		for r := 0; r < n; r++ {
+			ari, arj := a[r*n+i], a[r*n+j]
+			a[r*n+i] = c*ari - sn*arj
+			a[r*n+j] = sn*ari + c*arj
+		}
+		for r := 0; r < n; r++ {
+			air, ajr := a[i*n+r], a[j*n+r]
+			a[i*n+r] = c*air - sn*ajr
+			a[j*n+r] = sn*air + c*ajr
+		}
+		a[i*n+i] = 1
+		fixed[i] = true
+	}
+
+	corr := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		corr.SetSym(i, i, 1)
+		for j := i + 1; j < n; j++ {
+			corr.SetSym(i, j, (a[i*n+j]+a[j*n+i])/2)
+		}
+	}
+	return corr
+}
@@ -0,0 +1,18 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package matgen provides generators of random structured matrices for
+// testing and simulation.
+//
+// The generators follow the approach of the LAPACK test matrix generators
+// such as DLATMS: a matrix with prescribed eigenvalues or singular values is
+// formed by multiplying a diagonal matrix on either side by random orthogonal
+// matrices distributed according to the Haar measure. The Spectrum function
+// computes sets of eigenvalues or singular values with a given condition
+// number in the same way as DLATM1.
+//
+// All random values are drawn from the rand.Source passed to each generator,
+// so the matrices generated are reproducible. If the source is nil, a source
+// seeded from the default math/rand source is used.
+package matgen // import "gonum.org/v1/gonum/mat/matgen"
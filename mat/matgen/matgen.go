@@ -0,0 +1,286 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matgen
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	badMode     = "matgen: invalid mode"
+	badCond     = "matgen: condition number less than one"
+	badSliceLen = "matgen: bad slice length"
+	badValue    = "matgen: invalid spectrum value"
+	badEta      = "matgen: eta not positive"
+)
+
+// Mode specifies how the values of a spectrum are computed by Spectrum.
+type Mode int
+
+const (
+	// OneLarge specifies that the first value is 1 and all of the
+	// others are 1/cond.
+	OneLarge Mode = iota + 1
+	// OneSmall specifies that the last value is 1/cond and all of the
+	// others are 1.
+	OneSmall
+	// Geometric specifies that the values decrease geometrically from
+	// 1 to 1/cond.
+	Geometric
+	// Arithmetic specifies that the values decrease arithmetically from
+	// 1 to 1/cond.
+	Arithmetic
+	// LogUniform specifies that the values are random numbers in the
+	// interval [1/cond, 1] whose logarithms are uniformly distributed.
+	LogUniform
+)
+
+// newRand returns a random number generator drawing from src, or from a
+// source seeded from the default math/rand source if src is nil.
+func newRand(src rand.Source) *rand.Rand {
+	if src == nil {
+		src = rand.NewSource(rand.Int63())
+	}
+	return rand.New(src)
+}
+
+// Spectrum fills dst with positive values whose largest is 1 and whose ratio
+// of largest to smallest is cond, placed as specified by mode. The values are
+// suitable for use as the eigenvalues or singular values of a matrix with
+// condition number cond. src is used only when mode is LogUniform, in which
+// case the extreme values are not necessarily attained.
+//
+// Spectrum panics if mode is not a valid Mode or cond is less than 1.
+func Spectrum(dst []float64, mode Mode, cond float64, src rand.Source) {
+	if mode < OneLarge || LogUniform < mode {
+		panic(badMode)
+	}
+	if !(cond >= 1) {
+		panic(badCond)
+	}
+	n := len(dst)
+	if n == 0 {
+		return
+	}
+	switch mode {
+	case OneLarge:
+		dst[0] = 1
+		for i := 1; i < n; i++ {
+			dst[i] = 1 / cond
+		}
+	case OneSmall:
+		for i := 0; i < n-1; i++ {
+			dst[i] = 1
+		}
+		dst[n-1] = 1 / cond
+	case Geometric:
+		dst[0] = 1
+		for i := 1; i < n; i++ {
+			dst[i] = math.Pow(cond, -float64(i)/float64(n-1))
+		}
+	case Arithmetic:
+		dst[0] = 1
+		if n > 1 {
+			condInv := 1 / cond
+			alpha := (1 - condInv) / float64(n-1)
+			for i := 1; i < n; i++ {
+				dst[i] = float64(n-i-1)*alpha + condInv
+			}
+		}
+	case LogUniform:
+		rnd := newRand(src)
+		alpha := math.Log(1 / cond)
+		for i := range dst {
+			dst[i] = math.Exp(alpha * rnd.Float64())
+		}
+	}
+}
+
+// Orthogonal returns a random n×n orthogonal matrix distributed according to
+// the Haar measure on the orthogonal group.
+//
+// The matrix is the orthogonal factor of the QR factorization of a matrix of
+// independent standard normal values, with the signs of its columns chosen so
+// that the diagonal of the triangular factor is positive, as described in
+//  Mezzadri, F. How to generate random matrices from the classical compact
+//  groups. Notices of the AMS 54(5), 592-604 (2007).
+func Orthogonal(n int, src rand.Source) *mat.Dense {
+	return orthogonal(n, newRand(src))
+}
+
+func orthogonal(n int, rnd *rand.Rand) *mat.Dense {
+	data := make([]float64, n*n)
+	for i := range data {
+		data[i] = rnd.NormFloat64()
+	}
+	var qr mat.QR
+	qr.Factorize(mat.NewDense(n, n, data))
+	q := qr.QTo(nil)
+	r := qr.RTo(nil)
+	for j := 0; j < n; j++ {
+		if r.At(j, j) < 0 {
+			for i := 0; i < n; i++ {
+				q.Set(i, j, -q.At(i, j))
+			}
+		}
+	}
+	return q
+}
+
+// Symmetric returns a random symmetric matrix with the eigenvalues in eig.
+// The matrix is
+//  A = Q * diag(eig) * Q^T
+// where Q is a random orthogonal matrix distributed according to the Haar
+// measure, so its eigenvectors are uniformly distributed.
+func Symmetric(eig []float64, src rand.Source) *mat.SymDense {
+	return symmetric(eig, newRand(src))
+}
+
+func symmetric(eig []float64, rnd *rand.Rand) *mat.SymDense {
+	n := len(eig)
+	q := orthogonal(n, rnd)
+	var qd mat.Dense
+	qd.Clone(q)
+	for j, v := range eig {
+		for i := 0; i < n; i++ {
+			qd.Set(i, j, v*qd.At(i, j))
+		}
+	}
+	var a mat.Dense
+	a.Mul(&qd, q.T())
+	s := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		s.SetSym(i, i, a.At(i, i))
+		for j := i + 1; j < n; j++ {
+			s.SetSym(i, j, (a.At(i, j)+a.At(j, i))/2)
+		}
+	}
+	return s
+}
+
+// SymPosDef returns a random n×n symmetric positive definite matrix with
+// condition number cond, whose eigenvalues decrease geometrically from 1
+// to 1/cond. Symmetric may be used with Spectrum to generate symmetric
+// positive definite matrices with other distributions of eigenvalues.
+//
+// SymPosDef panics if cond is less than 1.
+func SymPosDef(n int, cond float64, src rand.Source) *mat.SymDense {
+	eig := make([]float64, n)
+	Spectrum(eig, Geometric, cond, nil)
+	return Symmetric(eig, src)
+}
+
+// General returns a random m×n matrix with the singular values in sv which
+// must have length min(m, n). The matrix is
+//  A = U * Σ * V^T
+// where Σ is the m×n diagonal matrix holding sv, and U and V are random
+// orthogonal matrices distributed according to the Haar measure.
+//
+// General panics if len(sv) != min(m, n) or any element of sv is negative.
+func General(m, n int, sv []float64, src rand.Source) *mat.Dense {
+	return general(m, n, sv, newRand(src))
+}
+
+func general(m, n int, sv []float64, rnd *rand.Rand) *mat.Dense {
+	k := min(m, n)
+	if len(sv) != k {
+		panic(badSliceLen)
+	}
+	for _, v := range sv {
+		if !(v >= 0) {
+			panic(badValue)
+		}
+	}
+	u := orthogonal(m, rnd)
+	v := orthogonal(n, rnd)
+	us := mat.NewDense(m, k, nil)
+	for j, s := range sv {
+		for i := 0; i < m; i++ {
+			us.Set(i, j, s*u.At(i, j))
+		}
+	}
+	a := mat.NewDense(m, n, nil)
+	a.Mul(us, v.Slice(0, n, 0, k).T())
+	return a
+}
+
+// Triangular returns a random triangular matrix of the given kind with the
+// singular values in sv. The matrix is the triangular factor of the QR
+// factorization, or the transpose of the factor for a lower triangular matrix,
+// of a matrix generated by General.
+//
+// Triangular panics if any element of sv is negative.
+func Triangular(kind mat.TriKind, sv []float64, src rand.Source) *mat.TriDense {
+	n := len(sv)
+	var qr mat.QR
+	qr.Factorize(general(n, n, sv, newRand(src)))
+	r := qr.RTo(nil)
+	t := mat.NewTriDense(n, kind, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			if kind == mat.Upper {
+				t.SetTri(i, j, r.At(i, j))
+			} else {
+				t.SetTri(j, i, r.At(i, j))
+			}
+		}
+	}
+	return t
+}
+
+// Band returns a random m×n band matrix with kl sub-diagonals and ku
+// super-diagonals whose elements within the band are independent standard
+// normal values.
+func Band(m, n, kl, ku int, src rand.Source) *mat.BandDense {
+	rnd := newRand(src)
+	b := mat.NewBandDense(m, n, kl, ku, nil)
+	for i := 0; i < m; i++ {
+		for j := max(0, i-kl); j < min(n, i+ku+1); j++ {
+			b.SetBand(i, j, rnd.NormFloat64())
+		}
+	}
+	return b
+}
+
+// SymBandPosDef returns a random n×n symmetric positive definite band matrix
+// with k super-diagonals. The elements off the diagonal are independent
+// standard normal values, and each diagonal element is one plus the sum of the
+// absolute values of the off-diagonal elements in its row plus the absolute
+// value of a standard normal value, so the matrix is strictly diagonally
+// dominant.
+func SymBandPosDef(n, k int, src rand.Source) *mat.SymBandDense {
+	rnd := newRand(src)
+	b := mat.NewSymBandDense(n, k, nil)
+	rowSum := make([]float64, n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < min(n, i+k+1); j++ {
+			v := rnd.NormFloat64()
+			b.SetSymBand(i, j, v)
+			rowSum[i] += math.Abs(v)
+			rowSum[j] += math.Abs(v)
+		}
+	}
+	for i, s := range rowSum {
+		b.SetSymBand(i, i, 1+s+math.Abs(rnd.NormFloat64()))
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
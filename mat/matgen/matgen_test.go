@@ -0,0 +1,270 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package matgen
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestSpectrum(t *testing.T) {
+	for _, mode := range []Mode{OneLarge, OneSmall, Geometric, Arithmetic, LogUniform} {
+		for _, n := range []int{1, 2, 5, 10} {
+			for _, cond := range []float64{1, 10, 1e8} {
+				d := make([]float64, n)
+				Spectrum(d, mode, cond, rand.NewSource(1))
+				max := floats.Max(d)
+				min := floats.Min(d)
+				if max > 1 || min < 1/cond*(1-1e-14) {
+					t.Errorf("mode=%v,n=%d,cond=%v: values out of range: %v", mode, n, cond, d)
+				}
+				if mode == LogUniform || n == 1 {
+					continue
+				}
+				if math.Abs(max/min-cond) > 1e-10*cond {
+					t.Errorf("mode=%v,n=%d,cond=%v: unexpected condition number: got %v", mode, n, cond, max/min)
+				}
+			}
+		}
+	}
+}
+
+func TestOrthogonal(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, n := range []int{1, 2, 3, 10, 30} {
+		q := Orthogonal(n, src)
+		var qtq mat.Dense
+		qtq.Mul(q.T(), q)
+		if !mat.EqualApprox(&qtq, eye(n), 1e-13) {
+			t.Errorf("n=%d: matrix not orthogonal", n)
+		}
+	}
+
+	// The diagonal elements of a Haar distributed orthogonal matrix have
+	// zero mean and variance 1/n. Matrices from an unadjusted QR
+	// factorization have a positively biased diagonal.
+	const (
+		n      = 4
+		trials = 5000
+	)
+	var mean, meanSq float64
+	for i := 0; i < trials; i++ {
+		q := Orthogonal(n, src)
+		for j := 0; j < n; j++ {
+			v := q.At(j, j)
+			mean += v
+			meanSq += v * v
+		}
+	}
+	mean /= n * trials
+	meanSq /= n * trials
+	if math.Abs(mean) > 0.02 {
+		t.Errorf("unexpected mean of diagonal: got %v want 0", mean)
+	}
+	if math.Abs(meanSq-1.0/n) > 0.01 {
+		t.Errorf("unexpected mean square of diagonal: got %v want %v", meanSq, 1.0/n)
+	}
+}
+
+func TestSymmetric(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, n := range []int{1, 2, 5, 20} {
+		eig := make([]float64, n)
+		for i := range eig {
+			eig[i] = float64(i) - float64(n)/2
+		}
+		a := Symmetric(eig, src)
+		if got := eigenvalues(t, a); !floats.EqualApprox(got, eig, 1e-12) {
+			t.Errorf("n=%d: unexpected eigenvalues: got %v want %v", n, got, eig)
+		}
+	}
+}
+
+func TestSymPosDef(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, n := range []int{1, 2, 5, 20} {
+		for _, cond := range []float64{1, 100, 1e6} {
+			a := SymPosDef(n, cond, src)
+			var chol mat.Cholesky
+			if !chol.Factorize(a) {
+				t.Errorf("n=%d,cond=%v: matrix not positive definite", n, cond)
+				continue
+			}
+			if n == 1 {
+				continue
+			}
+			eig := eigenvalues(t, a)
+			if got := eig[n-1] / eig[0]; math.Abs(got-cond) > 1e-6*cond {
+				t.Errorf("n=%d,cond=%v: unexpected condition number: got %v", n, cond, got)
+			}
+		}
+	}
+}
+
+func TestGeneral(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, dims := range [][2]int{{1, 1}, {3, 3}, {5, 3}, {3, 5}, {20, 10}} {
+		m, n := dims[0], dims[1]
+		sv := make([]float64, min(m, n))
+		Spectrum(sv, Arithmetic, 1000, nil)
+		a := General(m, n, sv, src)
+		if r, c := a.Dims(); r != m || c != n {
+			t.Errorf("m=%d,n=%d: unexpected dimensions %d×%d", m, n, r, c)
+		}
+		var svd mat.SVD
+		svd.Factorize(a, mat.SVDNone)
+		if got := svd.Values(nil); !floats.EqualApprox(got, sv, 1e-12) {
+			t.Errorf("m=%d,n=%d: unexpected singular values: got %v want %v", m, n, got, sv)
+		}
+	}
+}
+
+func TestTriangular(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, kind := range []mat.TriKind{mat.Upper, mat.Lower} {
+		for _, n := range []int{1, 2, 5, 20} {
+			sv := make([]float64, n)
+			Spectrum(sv, Geometric, 1e4, nil)
+			a := Triangular(kind, sv, src)
+			if _, k := a.Triangle(); k != kind {
+				t.Errorf("kind=%v,n=%d: unexpected kind", kind, n)
+			}
+			var svd mat.SVD
+			svd.Factorize(a, mat.SVDNone)
+			if got := svd.Values(nil); !floats.EqualApprox(got, sv, 1e-10) {
+				t.Errorf("kind=%v,n=%d: unexpected singular values: got %v want %v", kind, n, got, sv)
+			}
+		}
+	}
+}
+
+func TestBand(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, test := range []struct{ m, n, kl, ku int }{
+		{1, 1, 0, 0}, {5, 5, 1, 2}, {6, 4, 2, 1}, {4, 6, 0, 3},
+	} {
+		b := Band(test.m, test.n, test.kl, test.ku, src)
+		for i := 0; i < test.m; i++ {
+			for j := 0; j < test.n; j++ {
+				in := j >= i-test.kl && j <= i+test.ku
+				if v := b.At(i, j); (v != 0) != in {
+					t.Errorf("%+v: unexpected value %v at (%d,%d)", test, v, i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestSymBandPosDef(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, test := range []struct{ n, k int }{{1, 0}, {5, 0}, {5, 2}, {10, 9}, {30, 3}} {
+		b := SymBandPosDef(test.n, test.k, src)
+		if _, ku := b.Bandwidth(); ku != test.k {
+			t.Errorf("%+v: unexpected bandwidth %d", test, ku)
+		}
+		var chol mat.Cholesky
+		if !chol.Factorize(b) {
+			t.Errorf("%+v: matrix not positive definite", test)
+		}
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, n := range []int{1, 2, 4, 10} {
+		for _, eta := range []float64{0.5, 1, 5} {
+			c := Correlation(n, eta, src)
+			checkCorrelation(t, c, "Correlation")
+		}
+	}
+
+	// Each off-diagonal element of an LKJ distributed matrix has mean zero
+	// and variance 1/(2*eta+n-1).
+	const (
+		n      = 4
+		eta    = 2.0
+		trials = 5000
+	)
+	var mean, meanSq float64
+	for i := 0; i < trials; i++ {
+		c := Correlation(n, eta, src)
+		v := c.At(1, 3)
+		mean += v
+		meanSq += v * v
+	}
+	mean /= trials
+	meanSq /= trials
+	if math.Abs(mean) > 0.02 {
+		t.Errorf("unexpected mean: got %v want 0", mean)
+	}
+	if want := 1 / (2*eta + n - 1); math.Abs(meanSq-want) > 0.01 {
+		t.Errorf("unexpected variance: got %v want %v", meanSq, want)
+	}
+}
+
+func TestCorrelationEig(t *testing.T) {
+	src := rand.NewSource(1)
+	for _, eig := range [][]float64{
+		{1},
+		{1, 3},
+		{0, 0, 3},
+		{1, 2, 3, 4, 5},
+		{1e-4, 1e-3, 1e-2, 0.1, 1, 10},
+	} {
+		c := CorrelationEig(eig, src)
+		checkCorrelation(t, c, "CorrelationEig")
+		var sum float64
+		for _, v := range eig {
+			sum += v
+		}
+		want := make([]float64, len(eig))
+		for i, v := range eig {
+			want[i] = v * float64(len(eig)) / sum
+		}
+		sort.Float64s(want)
+		if got := eigenvalues(t, c); !floats.EqualApprox(got, want, 1e-10) {
+			t.Errorf("unexpected eigenvalues: got %v want %v", got, want)
+		}
+	}
+}
+
+func checkCorrelation(t *testing.T, c *mat.SymDense, name string) {
+	n := c.Symmetric()
+	for i := 0; i < n; i++ {
+		if c.At(i, i) != 1 {
+			t.Errorf("%s: diagonal element %d not one: %v", name, i, c.At(i, i))
+		}
+		for j := i + 1; j < n; j++ {
+			if math.Abs(c.At(i, j)) > 1 {
+				t.Errorf("%s: element (%d,%d) out of range: %v", name, i, j, c.At(i, j))
+			}
+		}
+	}
+	if eig := eigenvalues(t, c); eig[0] < -1e-12 {
+		t.Errorf("%s: matrix not positive semi-definite: min eigenvalue %v", name, eig[0])
+	}
+}
+
+// eigenvalues returns the eigenvalues of a in ascending order.
+func eigenvalues(t *testing.T, a mat.Symmetric) []float64 {
+	var ed mat.EigenSym
+	if !ed.Factorize(a, false) {
+		t.Fatal("eigendecomposition failed")
+	}
+	return ed.Values(nil)
+}
+
+func eye(n int) *mat.Dense {
+	d := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		d.Set(i, i, 1)
+	}
+	return d
+}
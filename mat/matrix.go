@@ -200,6 +200,22 @@ type ColNonZeroDoer interface {
 	DoColNonZero(j int, fn func(i, j int, v float64))
 }
 
+// A VecNonZeroDoer can call a function for each non-zero element of the
+// receiver vector. The parameters of the function are the element index and
+// its value. VecNonZeroDoer is implemented by vectors that do not store all
+// of their elements, such as the row and column views of a BandDense.
+type VecNonZeroDoer interface {
+	DoVecNonZero(func(i int, v float64))
+}
+
+// A VectorAtVec is a Vector that can return the element at index i without
+// the column index required by At. Functions taking a Vector use AtVec when
+// it is available and fall back to At(i, 0) otherwise.
+type VectorAtVec interface {
+	Vector
+	AtVec(i int) float64
+}
+
 // TODO(btracey): Consider adding CopyCol/CopyRow if the behavior seems useful.
 // TODO(btracey): Add in fast paths to Row/Col for the other concrete types
 // (TriDense, etc.) as well as relevant interfaces (RowColer, RawRowViewer, etc.)
@@ -273,9 +289,15 @@ func Row(dst []float64, i int, a Matrix) []float64 {
 }
 
 // Cond returns the condition number of the given matrix under the given norm.
-// The condition number must be based on the 1-norm, 2-norm or ∞-norm.
+// The condition number must be based on the 1-norm, 2-norm or ∞-norm. The
+// 2-norm may be specified either as 2 or as Spectral.
 // Cond will panic with matrix.ErrShape if the matrix has zero size.
 //
+// The 1-norm and ∞-norm condition numbers of square triangular, band and
+// symmetric band matrices are estimated from factorizations that take
+// advantage of the structure of the matrix. The returned value is +Inf
+// if the matrix is singular to working precision.
+//
 // BUG(btracey): The computation of the 1-norm and ∞-norm for non-square matrices
 // is innacurate, although is typically the right order of magnitude. See
 // https://github.com/xianyi/OpenBLAS/issues/636. While the value returned will
@@ -292,7 +314,7 @@ func Cond(a Matrix, norm float64) float64 {
 		panic("mat: bad norm value")
 	case 1:
 		lnorm = lapack.MaxColumnSum
-	case 2:
+	case 2, Spectral:
 		var svd SVD
 		ok := svd.Factorize(a, SVDNone)
 		if !ok {
@@ -304,23 +326,141 @@ func Cond(a Matrix, norm float64) float64 {
 	}
 
 	if m == n {
+		aU, aTrans := untranspose(a)
+		switch rma := aU.(type) {
+		case RawTriangular:
+			return condTriangular(rma.RawTriangular(), transNorm(lnorm, aTrans))
+		case RawSymBander:
+			return condSymBand(rma.RawSymBand())
+		case RawBander:
+			return condBand(rma.RawBand(), transNorm(lnorm, aTrans))
+		}
+
 		// Use the LU decomposition to compute the condition number.
 		var lu LU
-		lu.factorize(a, lnorm)
+		lu.factorize(a, lnorm, nil)
 		return lu.Cond()
 	}
 	if m > n {
 		// Use the QR factorization to compute the condition number.
 		var qr QR
-		qr.factorize(a, lnorm)
+		qr.factorize(a, lnorm, nil)
 		return qr.Cond()
 	}
 	// Use the LQ factorization to compute the condition number.
 	var lq LQ
-	lq.factorize(a, lnorm)
+	lq.factorize(a, lnorm, nil)
 	return lq.Cond()
 }
 
+// transNorm returns the lapack norm that gives the same value for the
+// transpose of a matrix as norm gives for the matrix if aTrans is true.
+func transNorm(norm lapack.MatrixNorm, aTrans bool) lapack.MatrixNorm {
+	if !aTrans {
+		return norm
+	}
+	switch norm {
+	case lapack.MaxColumnSum:
+		return lapack.MaxRowSum
+	case lapack.MaxRowSum:
+		return lapack.MaxColumnSum
+	}
+	return norm
+}
+
+// condTriangular returns the condition number of the triangular matrix t
+// under the given norm.
+func condTriangular(t blas64.Triangular, norm lapack.MatrixNorm) float64 {
+	work := getFloats(3*t.N, false)
+	defer putFloats(work)
+	iwork := getInts(t.N, false)
+	defer putInts(iwork)
+	return 1 / lapack64.Trcon(norm, t, work, iwork)
+}
+
+// condSymBand returns the condition number of the symmetric band matrix a.
+// The 1-norm and ∞-norm condition numbers of a symmetric matrix are equal.
+func condSymBand(a blas64.SymmetricBand) float64 {
+	n := a.N
+	work := getFloats(3*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+
+	anorm := lapack64.Lansb(lapack.MaxColumnSum, a, work)
+	chol := blas64.SymmetricBand{
+		N:      n,
+		K:      a.K,
+		Stride: a.K + 1,
+		Data:   getFloats(n*(a.K+1), false),
+		Uplo:   a.Uplo,
+	}
+	defer putFloats(chol.Data)
+	for i := 0; i < n; i++ {
+		copy(chol.Data[i*chol.Stride:i*chol.Stride+a.K+1], a.Data[i*a.Stride:i*a.Stride+a.K+1])
+	}
+	if _, ok := lapack64.Pbtrf(chol); ok {
+		return 1 / lapack64.Pbcon(chol, anorm, work, iwork)
+	}
+
+	// The matrix is not positive definite, so use the band LU factorization.
+	k := a.K
+	lu := blas64.Band{
+		Rows:   n,
+		Cols:   n,
+		KL:     k,
+		KU:     k,
+		Stride: 3*k + 1,
+		Data:   getFloats(n*(3*k+1), false),
+	}
+	defer putFloats(lu.Data)
+	sb := &SymBandDense{mat: a}
+	for i := 0; i < n; i++ {
+		for j := max(0, i-k); j < min(n, i+k+1); j++ {
+			lu.Data[i*lu.Stride+k+j-i] = sb.at(i, j)
+		}
+	}
+	return condBandLU(lu, lapack.MaxColumnSum, anorm, work, iwork)
+}
+
+// condBand returns the condition number of the square band matrix a under
+// the given norm.
+func condBand(a blas64.Band, norm lapack.MatrixNorm) float64 {
+	n := a.Rows
+	work := getFloats(3*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+
+	anorm := lapack64.Langb(norm, a, work)
+	// Room is needed for the kl super-diagonals of fill-in.
+	lu := blas64.Band{
+		Rows:   n,
+		Cols:   n,
+		KL:     a.KL,
+		KU:     a.KU,
+		Stride: 2*a.KL + a.KU + 1,
+	}
+	lu.Data = getFloats(n*lu.Stride, false)
+	defer putFloats(lu.Data)
+	w := a.KL + a.KU + 1
+	for i := 0; i < n; i++ {
+		copy(lu.Data[i*lu.Stride:i*lu.Stride+w], a.Data[i*a.Stride:i*a.Stride+w])
+	}
+	return condBandLU(lu, norm, anorm, work, iwork)
+}
+
+// condBandLU factorizes the band matrix lu in place and returns its condition
+// number given its norm, anorm.
+func condBandLU(lu blas64.Band, norm lapack.MatrixNorm, anorm float64, work []float64, iwork []int) float64 {
+	ipiv := getInts(lu.Rows, false)
+	defer putInts(ipiv)
+	if !lapack64.Gbtrf(lu, ipiv) {
+		return math.Inf(1)
+	}
+	return 1 / lapack64.Gbcon(norm, lu, ipiv, anorm, work, iwork)
+}
+
 // Det returns the determinant of the matrix a. In many expressions using LogDet
 // will be more numerically stable.
 func Det(a Matrix) float64 {
@@ -342,8 +482,19 @@ func Dot(a, b Vector) float64 {
 		}
 	}
 	var sum float64
+	if _, ok := a.(VecNonZeroDoer); !ok {
+		a, b = b, a
+	}
+	atB := atVec(b)
+	if nz, ok := a.(VecNonZeroDoer); ok {
+		nz.DoVecNonZero(func(i int, v float64) {
+			sum += v * atB(i)
+		})
+		return sum
+	}
+	atA := atVec(a)
 	for i := 0; i < la; i++ {
-		sum += a.At(i, 0) * b.At(i, 0)
+		sum += atA(i) * atB(i)
 	}
 	return sum
 }
@@ -655,9 +806,10 @@ func Min(a Matrix) float64 {
 // https://en.wikipedia.org/wiki/Matrix_norm for the definition of an induced norm.
 //
 // Valid norms are:
-//    1 - The maximum absolute column sum
-//    2 - Frobenius norm, the square root of the sum of the squares of the elements.
-//  Inf - The maximum absolute row sum.
+//         1 - The maximum absolute column sum
+//         2 - Frobenius norm, the square root of the sum of the squares of the elements.
+//       Inf - The maximum absolute row sum.
+//  Spectral - The spectral norm, the largest singular value of the matrix.
 // Norm will panic with ErrNormOrder if an illegal norm order is specified and
 // with matrix.ErrShape if the matrix has zero size.
 func Norm(a Matrix, norm float64) float64 {
@@ -665,6 +817,9 @@ func Norm(a Matrix, norm float64) float64 {
 	if r == 0 || c == 0 {
 		panic(ErrShape)
 	}
+	if norm == Spectral {
+		return spectralNorm(a)
+	}
 	aU, aTrans := untranspose(a)
 	var work []float64
 	switch rma := aU.(type) {
@@ -692,6 +847,22 @@ func Norm(a Matrix, norm float64) float64 {
 			defer putFloats(work)
 		}
 		return lapack64.Lansy(n, rm, work)
+	case RawBander:
+		rm := rma.RawBand()
+		n := normLapack(norm, aTrans)
+		if n == lapack.MaxColumnSum {
+			work = getFloats(rm.Cols, false)
+			defer putFloats(work)
+		}
+		return lapack64.Langb(n, rm, work)
+	case RawSymBander:
+		rm := rma.RawSymBand()
+		n := normLapack(norm, aTrans)
+		if n == lapack.MaxRowSum || n == lapack.MaxColumnSum {
+			work = getFloats(rm.N, false)
+			defer putFloats(work)
+		}
+		return lapack64.Lansb(n, rm, work)
 	case *VecDense:
 		rv := rma.RawVector()
 		switch norm {
@@ -752,6 +923,30 @@ func Norm(a Matrix, norm float64) float64 {
 	}
 }
 
+// spectralNorm returns the largest singular value of a.
+func spectralNorm(a Matrix) float64 {
+	r, c := a.Dims()
+	if r == 1 || c == 1 {
+		// The spectral norm of a vector is its Euclidean norm.
+		return Norm(a, 2)
+	}
+	if s, ok := a.(Symmetric); ok {
+		// The singular values of a symmetric matrix are the absolute
+		// values of its eigenvalues.
+		var eig EigenSym
+		if !eig.Factorize(s, false) {
+			return math.NaN()
+		}
+		values := eig.Values(nil)
+		return math.Max(-values[0], values[len(values)-1])
+	}
+	var svd SVD
+	if !svd.Factorize(a, SVDNone) {
+		return math.NaN()
+	}
+	return svd.Values(nil)[0]
+}
+
 // normLapack converts the float64 norm input in Norm to a lapack.MatrixNorm.
 func normLapack(norm float64, aTrans bool) lapack.MatrixNorm {
 	switch norm {
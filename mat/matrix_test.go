@@ -7,6 +7,7 @@ package mat
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"reflect"
 	"testing"
 
@@ -261,6 +262,32 @@ func TestCond(t *testing.T) {
 		}
 	}
 
+	for i, test := range []struct {
+		a    *Dense
+		cond float64
+	}{
+		{
+			a: NewDense(3, 3, []float64{
+				8, 1, 6,
+				3, 5, 7,
+				4, 9, 2,
+			}),
+			cond: 4.330127018922192,
+		},
+		{
+			a: NewDense(3, 3, []float64{
+				5, 6, 7,
+				8, -2, 1,
+				7, 7, 7}),
+			cond: 21.662689498448440,
+		},
+	} {
+		cond := Cond(test.a, Spectral)
+		if !floats.EqualWithinAbsOrRel(test.cond, cond, 1e-13, 1e-13) {
+			t.Errorf("Case %d: spectral norm mismatch. Want %v, got %v", i, test.cond, cond)
+		}
+	}
+
 	for _, test := range []struct {
 		name string
 		norm float64
@@ -284,10 +311,119 @@ func TestCond(t *testing.T) {
 		denseComparison := func(a *Dense) interface{} {
 			return Cond(a, test.norm)
 		}
-		testOneInputFunc(t, test.name, f, denseComparison, sameAnswerFloatApproxTol(1e-12), isAnyType, isAnySize)
+		legal := isAnyType
+		if test.norm != 2 {
+			// The 1-norm and ∞-norm condition numbers of triangular
+			// matrices are estimated without an LU factorization and
+			// are checked in TestCondStructured.
+			legal = isNotTriangular
+		}
+		testOneInputFunc(t, test.name, f, denseComparison, sameAnswerFloatApproxTol(1e-12), legal, isAnySize)
 	}
 }
 
+func isNotTriangular(a Matrix) bool {
+	_, ok := a.(Triangular)
+	return !ok
+}
+
+func TestCondStructured(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// condExact returns the condition number of a under the given norm
+	// computed from the explicit inverse.
+	condExact := func(a Matrix, norm float64) float64 {
+		var inv Dense
+		err := inv.Inverse(a)
+		if err != nil {
+			t.Fatalf("unexpected error computing inverse: %v", err)
+		}
+		return Norm(a, norm) * Norm(&inv, norm)
+	}
+	// check verifies that the condition number estimate of a lies within
+	// the bounds of the exact condition number. The estimate is a lower
+	// bound that is rarely smaller by more than a factor of 3.
+	check := func(name string, a Matrix) {
+		orig := DenseCopyOf(a)
+		for _, norm := range []float64{1, math.Inf(1)} {
+			got := Cond(a, norm)
+			want := condExact(a, norm)
+			if got > want*(1+1e-10) || got < want/3 {
+				t.Errorf("%s: unexpected condition number for norm=%v: got:%v want:%v", name, norm, got, want)
+			}
+			if !Equal(a, orig) {
+				t.Errorf("%s: unexpected mutation of input matrix", name)
+			}
+		}
+	}
+
+	for _, n := range []int{1, 3, 5, 10} {
+		for _, kind := range []TriKind{Upper, Lower} {
+			tri := NewTriDense(n, kind, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (kind == Upper && j >= i) || (kind == Lower && j <= i) {
+						tri.SetTri(i, j, rnd.NormFloat64())
+					}
+				}
+				tri.SetTri(i, i, tri.At(i, i)+math.Copysign(2, tri.At(i, i)))
+			}
+			check(fmt.Sprintf("TriDense n=%d kind=%v", n, kind), tri)
+			check(fmt.Sprintf("TriDense^T n=%d kind=%v", n, kind), tri.T())
+		}
+
+		for _, kl := range []int{0, 1, 2} {
+			for _, ku := range []int{0, 1, 3} {
+				if kl >= n || ku >= n {
+					continue
+				}
+				b := NewBandDense(n, n, kl, ku, nil)
+				for i := 0; i < n; i++ {
+					for j := max(0, i-kl); j < min(n, i+ku+1); j++ {
+						b.SetBand(i, j, rnd.NormFloat64())
+					}
+					b.SetBand(i, i, b.At(i, i)+math.Copysign(2, b.At(i, i)))
+				}
+				name := fmt.Sprintf("BandDense n=%d kl=%d ku=%d", n, kl, ku)
+				check(name, b)
+				check(name+" transposed", b.T())
+
+			}
+		}
+
+		for _, k := range []int{0, 1, 2} {
+			if k >= n {
+				continue
+			}
+			for _, posdef := range []bool{true, false} {
+				b := NewSymBandDense(n, k, nil)
+				for i := 0; i < n; i++ {
+					for j := i + 1; j < min(n, i+k+1); j++ {
+						b.SetSymBand(i, j, rnd.NormFloat64())
+					}
+					d := float64(2*k + 1)
+					if !posdef && i%2 == 1 {
+						d = -d
+					}
+					b.SetSymBand(i, i, d)
+				}
+				check(fmt.Sprintf("SymBandDense n=%d k=%d posdef=%t", n, k, posdef), b)
+			}
+		}
+	}
+
+	// A singular band matrix has an infinite condition number.
+	b := NewBandDense(3, 3, 1, 1, []float64{
+		0, 1, 2,
+		2, 4, 0,
+		0, 0, 1,
+	})
+	if cond := Cond(b, 1); !math.IsInf(cond, 1) {
+		t.Errorf("unexpected condition number for singular band matrix: got:%v want:+Inf", cond)
+	}
+
+}
+
 func TestDet(t *testing.T) {
 	for c, test := range []struct {
 		a   *Dense
@@ -477,6 +613,16 @@ func TestNorm(t *testing.T) {
 			ord:  math.Inf(1),
 			norm: 15,
 		},
+		{
+			a:    [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11, 12}},
+			ord:  Spectral,
+			norm: 25.46240743603639,
+		},
+		{
+			a:    [][]float64{{1, -2, -2}, {-4, 5, 6}},
+			ord:  Spectral,
+			norm: 9.262929834456557,
+		},
 	} {
 		a := NewDense(flatten(test.a))
 		if math.Abs(Norm(a, test.ord)-test.norm) > 1e-14 {
@@ -491,6 +637,7 @@ func TestNorm(t *testing.T) {
 		{"NormOne", 1},
 		{"NormTwo", 2},
 		{"NormInf", math.Inf(1)},
+		{"NormSpectral", Spectral},
 	} {
 		f := func(a Matrix) interface{} {
 			return Norm(a, test.norm)
@@ -502,6 +649,64 @@ func TestNorm(t *testing.T) {
 	}
 }
 
+func TestNormBand(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c, kl, ku int
+	}{
+		{1, 1, 0, 0},
+		{3, 3, 0, 0},
+		{4, 4, 1, 2},
+		{5, 3, 2, 1},
+		{3, 5, 1, 3},
+		{6, 6, 5, 5},
+	} {
+		b := NewBandDense(test.r, test.c, test.kl, test.ku, nil)
+		for i := 0; i < test.r; i++ {
+			for j := max(0, i-test.kl); j < min(test.c, i+test.ku+1); j++ {
+				b.SetBand(i, j, rnd.NormFloat64())
+			}
+		}
+		for _, norm := range []float64{1, 2, math.Inf(1), Spectral} {
+			for _, a := range []Matrix{b, b.T()} {
+				got := Norm(a, norm)
+				want := Norm(DenseCopyOf(a), norm)
+				if !floats.EqualWithinAbsOrRel(got, want, 1e-14, 1e-14) {
+					t.Errorf("unexpected norm for %d×%d band with kl=%d, ku=%d, norm=%v: got:%v want:%v",
+						test.r, test.c, test.kl, test.ku, norm, got, want)
+				}
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		n, k int
+	}{
+		{1, 0},
+		{3, 0},
+		{4, 1},
+		{5, 2},
+		{6, 5},
+	} {
+		b := NewSymBandDense(test.n, test.k, nil)
+		for i := 0; i < test.n; i++ {
+			for j := i; j < min(test.n, i+test.k+1); j++ {
+				b.SetSymBand(i, j, rnd.NormFloat64())
+			}
+		}
+		for _, norm := range []float64{1, 2, math.Inf(1), Spectral} {
+			for _, a := range []Matrix{b, b.T()} {
+				got := Norm(a, norm)
+				want := Norm(DenseCopyOf(a), norm)
+				if !floats.EqualWithinAbsOrRel(got, want, 1e-13, 1e-13) {
+					t.Errorf("unexpected norm for %d×%d symmetric band with k=%d, norm=%v: got:%v want:%v",
+						test.n, test.n, test.k, norm, got, want)
+				}
+			}
+		}
+	}
+}
+
 func TestNormZero(t *testing.T) {
 	for _, a := range []Matrix{
 		&Dense{},
@@ -0,0 +1,207 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+var errRagged = errors.New("mat: rows have different lengths")
+
+// ParseMATLAB parses a MATLAB matrix literal such as
+//  [1 2 3; 4 5 6]
+// into a new Dense. Rows are separated by semicolons or new lines and the
+// elements of a row by white space or commas. An ellipsis continues a row
+// on the next line, and any text following it on the same line is ignored.
+// Inf, -Inf and NaN are accepted, as is a trailing semicolon after the
+// closing bracket. The output of Formatted with the FormatMATLAB option can
+// be read by ParseMATLAB.
+func ParseMATLAB(s string) (*Dense, error) {
+	s = joinContinuations(s)
+	s = strings.TrimSpace(s)
+	s = strings.TrimSpace(strings.TrimSuffix(s, ";"))
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, errors.New("mat: MATLAB literal not enclosed in brackets")
+	}
+	s = s[1 : len(s)-1]
+
+	var rows [][]float64
+	for _, line := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' }) {
+		fields := strings.FieldsFunc(line, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\r' })
+		if len(fields) == 0 {
+			continue
+		}
+		row := make([]float64, len(fields))
+		for i, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("mat: invalid MATLAB element %q", f)
+			}
+			row[i] = v
+		}
+		rows = append(rows, row)
+	}
+	return denseFromRows(rows)
+}
+
+// joinContinuations returns s with each MATLAB line continuation, an
+// ellipsis and the remainder of its line, replaced by a space.
+func joinContinuations(s string) string {
+	for {
+		i := strings.Index(s, "...")
+		if i < 0 {
+			return s
+		}
+		end := strings.IndexByte(s[i:], '\n')
+		if end < 0 {
+			return s[:i]
+		}
+		s = s[:i] + " " + s[i+end+1:]
+	}
+}
+
+// ParsePython parses a NumPy array literal such as
+//  np.array([[1, 2, 3], [4, 5, 6]])
+// into a new Dense. The np.array( and ) wrapper is optional, and a one
+// dimensional list is read as a row vector. np.inf, -np.inf and np.nan are
+// accepted. A dtype keyword argument to np.array is accepted, but the
+// elements are always read as float64. The output of Formatted with the
+// FormatPython option can be read by ParsePython.
+func ParsePython(s string) (*Dense, error) {
+	s = strings.TrimSpace(s)
+	for _, pre := range []string{"np.array(", "numpy.array("} {
+		if strings.HasPrefix(s, pre) {
+			if !strings.HasSuffix(s, ")") {
+				return nil, errors.New("mat: unterminated NumPy array literal")
+			}
+			s = strings.TrimSpace(s[len(pre) : len(s)-1])
+			if end := strings.LastIndexByte(s, ']'); end >= 0 {
+				arg := strings.TrimSpace(s[end+1:])
+				if arg != "" {
+					arg = strings.TrimSpace(strings.TrimPrefix(arg, ","))
+					if !strings.HasPrefix(arg, "dtype") || !strings.HasPrefix(strings.TrimSpace(arg[len("dtype"):]), "=") {
+						return nil, fmt.Errorf("mat: unsupported NumPy array argument: %q", arg)
+					}
+					s = s[:end+1]
+				}
+			}
+			break
+		}
+	}
+	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
+		return nil, errors.New("mat: Python literal not enclosed in brackets")
+	}
+	s = strings.TrimSpace(s[1 : len(s)-1])
+
+	var lists []string
+	if !strings.HasPrefix(s, "[") {
+		// A one dimensional list.
+		lists = []string{s}
+	} else {
+		for len(s) != 0 {
+			if s[0] != '[' {
+				return nil, fmt.Errorf("mat: unexpected text in Python literal: %q", s)
+			}
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, errors.New("mat: unterminated row in Python literal")
+			}
+			lists = append(lists, s[1:end])
+			s = strings.TrimSpace(s[end+1:])
+			if strings.HasPrefix(s, ",") {
+				s = strings.TrimSpace(s[1:])
+			}
+		}
+	}
+
+	rows := make([][]float64, 0, len(lists))
+	for _, l := range lists {
+		if strings.ContainsAny(l, "[]") {
+			return nil, errors.New("mat: Python literal nested too deeply")
+		}
+		fields := strings.Split(l, ",")
+		if n := len(fields); n > 1 && strings.TrimSpace(fields[n-1]) == "" {
+			// Allow a trailing comma.
+			fields = fields[:n-1]
+		}
+		row := make([]float64, len(fields))
+		for i, f := range fields {
+			f = strings.TrimSpace(f)
+			v, err := parsePythonFloat(f)
+			if err != nil {
+				return nil, fmt.Errorf("mat: invalid Python element %q", f)
+			}
+			row[i] = v
+		}
+		rows = append(rows, row)
+	}
+	return denseFromRows(rows)
+}
+
+// parsePythonFloat parses a Python float literal, including the NumPy
+// constants np.inf and np.nan.
+func parsePythonFloat(s string) (float64, error) {
+	var sign string
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+	for _, pre := range []string{"np.", "numpy."} {
+		if c := strings.TrimPrefix(s, pre); c != s && (c == "inf" || c == "nan") {
+			s = c
+			break
+		}
+	}
+	return strconv.ParseFloat(sign+s, 64)
+}
+
+// ParseCSV reads comma-separated values from r into a new Dense. Each record
+// is a row of the matrix and all records must have the same number of fields.
+// Leading and trailing white space around fields is ignored. The output of
+// Formatted with the FormatCSV option can be read by ParseCSV.
+func ParseCSV(r io.Reader) (*Dense, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	records, err := cr.ReadAll()
+	if err != nil {
+		if perr, ok := err.(*csv.ParseError); ok && perr.Err == csv.ErrFieldCount {
+			return nil, errRagged
+		}
+		return nil, err
+	}
+	rows := make([][]float64, len(records))
+	for i, rec := range records {
+		row := make([]float64, len(rec))
+		for j, f := range rec {
+			v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+			if err != nil {
+				return nil, fmt.Errorf("mat: invalid CSV element %q on line %d", f, i+1)
+			}
+			row[j] = v
+		}
+		rows[i] = row
+	}
+	return denseFromRows(rows)
+}
+
+// denseFromRows returns a new Dense holding the given rows.
+func denseFromRows(rows [][]float64) (*Dense, error) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, ErrZeroLength
+	}
+	c := len(rows[0])
+	data := make([]float64, 0, len(rows)*c)
+	for _, row := range rows {
+		if len(row) != c {
+			return nil, errRagged
+		}
+		data = append(data, row...)
+	}
+	return NewDense(len(rows), c, data), nil
+}
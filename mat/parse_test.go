@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseRoundTrip(t *testing.T) {
+	for _, m := range []*Dense{
+		NewDense(1, 1, []float64{math.Pi}),
+		NewDense(1, 4, []float64{1, 2, 3, 4}),
+		NewDense(3, 1, []float64{1, 2, 3}),
+		NewDense(2, 3, []float64{1, -2.5, 3, 0.125, 1e6, -1e-300}),
+		NewDense(2, 2, []float64{math.Inf(1), math.Inf(-1), 0, -0.1}),
+	} {
+		for _, verb := range []string{"%v", "%#v", "%g", "%e", "%.17g", "%f", "%F"} {
+			for _, test := range []struct {
+				name  string
+				opt   FormatOption
+				parse func(string) (*Dense, error)
+			}{
+				{"MATLAB", FormatMATLAB(), ParseMATLAB},
+				{"Python", FormatPython(), ParsePython},
+				{"CSV", FormatCSV(), func(s string) (*Dense, error) { return ParseCSV(strings.NewReader(s)) }},
+			} {
+				s := fmt.Sprintf(verb, Formatted(m, test.opt))
+				got, err := test.parse(s)
+				if err != nil {
+					t.Errorf("%s %s: unexpected error parsing %q: %v", test.name, verb, s, err)
+					continue
+				}
+				if !Equal(got, m) {
+					t.Errorf("%s %s: round trip mismatch for %q:\ngot:  %v\nwant: %v", test.name, verb, s, got.RawMatrix().Data, m.RawMatrix().Data)
+				}
+			}
+		}
+	}
+
+	// NaN values do not compare equal, so check them separately.
+	m := NewDense(1, 2, []float64{math.NaN(), 1})
+	for _, test := range []struct {
+		opt   FormatOption
+		parse func(string) (*Dense, error)
+	}{
+		{FormatMATLAB(), ParseMATLAB},
+		{FormatPython(), ParsePython},
+		{FormatCSV(), func(s string) (*Dense, error) { return ParseCSV(strings.NewReader(s)) }},
+	} {
+		got, err := test.parse(fmt.Sprint(Formatted(m, test.opt)))
+		if err != nil {
+			t.Errorf("unexpected error parsing NaN: %v", err)
+			continue
+		}
+		if !math.IsNaN(got.At(0, 0)) || got.At(0, 1) != 1 {
+			t.Errorf("unexpected result parsing NaN: %v", got.RawMatrix().Data)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	for _, test := range []struct {
+		s     string
+		parse func(string) (*Dense, error)
+		want  *Dense
+		err   bool
+	}{
+		{s: "[1, 2, 3\n4, 5, 6];", parse: ParseMATLAB, want: NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})},
+		{s: "  [ 1 2 ;\n 3 4 ; ]  ", parse: ParseMATLAB, want: NewDense(2, 2, []float64{1, 2, 3, 4})},
+		{s: "[-Inf inf NaN]", parse: ParseMATLAB, want: NewDense(1, 3, []float64{math.Inf(-1), math.Inf(1), math.NaN()})},
+		{s: "[1 2; 3]", parse: ParseMATLAB, err: true},
+		{s: "[1 x]", parse: ParseMATLAB, err: true},
+		{s: "[]", parse: ParseMATLAB, err: true},
+		{s: "1 2", parse: ParseMATLAB, err: true},
+		{s: "[1 2 ... first row\n 3; 4 5 ...\n6]", parse: ParseMATLAB, want: NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6})},
+		{s: "[1 2 ...]", parse: ParseMATLAB, err: true},
+
+		{s: "[[1, 2], [3, 4],]", parse: ParsePython, want: NewDense(2, 2, []float64{1, 2, 3, 4})},
+		{s: "numpy.array([1.5, -np.inf, np.nan])", parse: ParsePython, want: NewDense(1, 3, []float64{1.5, math.Inf(-1), math.NaN()})},
+		{s: "np.array([[1, 2],\n          [3, 4]])", parse: ParsePython, want: NewDense(2, 2, []float64{1, 2, 3, 4})},
+		{s: "[[1, 2], [3]]", parse: ParsePython, err: true},
+		{s: "[[[1]]]", parse: ParsePython, err: true},
+		{s: "np.array([[1, 2]]", parse: ParsePython, err: true},
+		{s: "[np.1]", parse: ParsePython, err: true},
+		{s: "np.array([[1, 2], [3, 4]], dtype=np.float64)", parse: ParsePython, want: NewDense(2, 2, []float64{1, 2, 3, 4})},
+		{s: "np.array([1, 2] , dtype = 'int32')", parse: ParsePython, want: NewDense(1, 2, []float64{1, 2})},
+		{s: "np.array([1, 2], copy=True)", parse: ParsePython, err: true},
+
+		{s: "1, 2\n3, 4\n", parse: func(s string) (*Dense, error) { return ParseCSV(strings.NewReader(s)) }, want: NewDense(2, 2, []float64{1, 2, 3, 4})},
+		{s: "1,2\n3\n", parse: func(s string) (*Dense, error) { return ParseCSV(strings.NewReader(s)) }, err: true},
+		{s: "", parse: func(s string) (*Dense, error) { return ParseCSV(strings.NewReader(s)) }, err: true},
+	} {
+		got, err := test.parse(test.s)
+		if test.err {
+			if err == nil {
+				t.Errorf("expected error parsing %q", test.s)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", test.s, err)
+			continue
+		}
+		if !sameData(got, test.want) {
+			t.Errorf("unexpected result parsing %q: got %v, want %v", test.s, got.RawMatrix().Data, test.want.RawMatrix().Data)
+		}
+	}
+}
+
+// sameData returns whether a and b have the same shape and elements, treating
+// NaN values as equal.
+func sameData(a, b *Dense) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			x, y := a.At(i, j), b.At(i, j)
+			if x != y && !(math.IsNaN(x) && math.IsNaN(y)) {
+				return false
+			}
+		}
+	}
+	return true
+}
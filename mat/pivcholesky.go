@@ -0,0 +1,315 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badPivotedCholesky = "mat: invalid pivoted Cholesky factorization"
+
+// PivotedCholesky is a type for creating and using the Cholesky factorization
+// with complete pivoting of a symmetric positive semidefinite matrix A. The
+// factorization has the form
+//  P * A * P^T = U^T * U
+// where P is a permutation matrix (see Pivot) and U is an upper trapezoidal
+// matrix whose leading rank rows are non-zero. The rank of the factorization
+// is the numerical rank of A as determined by the tolerance passed to
+// Factorize.
+//
+// PivotedCholesky methods may only be called on a value that has been
+// initialized by a call to Factorize. Calls to methods of an uninitialized
+// PivotedCholesky will panic.
+type PivotedCholesky struct {
+	chol *TriDense
+	piv  []int
+	rank int
+	cond float64
+}
+
+// Factorize calculates the Cholesky decomposition with complete pivoting of
+// the symmetric positive semidefinite matrix A and returns the computed
+// numerical rank of A.
+//
+// The factorization stops when the largest remaining diagonal element of the
+// Schur complement is less than or equal to tol, although the first pivot is
+// always accepted when it is positive. If tol is negative, a default tolerance
+// of n*eps*max(A[i,i]) is used.
+//
+// The rank is also truncated at the first non-positive pivot, so Factorize does
+// not detect whether A is positive semidefinite. In particular, the returned
+// rank is zero if A has no positive diagonal element.
+func (c *PivotedCholesky) Factorize(a Symmetric, tol float64) (rank int) {
+	n := a.Symmetric()
+	if c.isZero() {
+		c.chol = NewTriDense(n, Upper, nil)
+	} else {
+		c.chol = NewTriDense(n, Upper, use(c.chol.mat.Data, n*n))
+	}
+	copySymIntoTriangle(c.chol, a)
+	c.piv = useInt(c.piv, n)
+
+	sym := c.chol.asSymBlas()
+	work := getFloats(2*n, false)
+	_, rank, _ = lapack64.Pstrf(sym, c.piv, tol, work)
+	putFloats(work)
+
+	// Clear the unfactorized trailing block so that the receiver
+	// holds the partial factor only.
+	for i := rank; i < n; i++ {
+		zero(c.chol.mat.Data[i*c.chol.mat.Stride+i : i*c.chol.mat.Stride+n])
+	}
+	c.rank = rank
+	c.updateCond()
+	return rank
+}
+
+// updateCond updates the condition number of the leading rank×rank block
+// of the factorized matrix.
+func (c *PivotedCholesky) updateCond() {
+	if c.rank == 0 {
+		c.cond = math.Inf(1)
+		return
+	}
+	u := blas64.Triangular{
+		Uplo:   blas.Upper,
+		Diag:   blas.NonUnit,
+		N:      c.rank,
+		Stride: c.chol.mat.Stride,
+		Data:   c.chol.mat.Data,
+	}
+	work := getFloats(3*c.rank, false)
+	iwork := getInts(c.rank, false)
+	// The leading block of A is U11^T * U11 so its condition number
+	// is the square of the condition number of U11.
+	v := lapack64.Trcon(CondNorm, u, work, iwork)
+	putInts(iwork)
+	putFloats(work)
+	c.cond = 1 / (v * v)
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (c *PivotedCholesky) Reset() {
+	if !c.isZero() {
+		c.chol.Reset()
+	}
+	c.piv = c.piv[:0]
+	c.rank = 0
+	c.cond = math.Inf(1)
+}
+
+// Size returns the dimension of the factorized matrix.
+func (c *PivotedCholesky) Size() int {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	return c.chol.mat.N
+}
+
+// Rank returns the numerical rank of the factorized matrix.
+func (c *PivotedCholesky) Rank() int {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	return c.rank
+}
+
+// Cond returns the condition number of the leading rank×rank block of the
+// permuted factorized matrix.
+func (c *PivotedCholesky) Cond() float64 {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	return c.cond
+}
+
+// Pivot returns pivot indices that enable the construction of the permutation
+// matrix P (see Dense.Permutation). Row k of P*A*P^T is row piv[k] of A. If
+// piv == nil, then new memory will be allocated, otherwise the length of the
+// input must be equal to the size of the factorized matrix.
+func (c *PivotedCholesky) Pivot(piv []int) []int {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if piv == nil {
+		piv = make([]int, n)
+	}
+	if len(piv) != n {
+		panic(badSliceLength)
+	}
+	copy(piv, c.piv)
+	return piv
+}
+
+// UTo extracts the rank×n upper trapezoidal partial factor U from a pivoted
+// Cholesky decomposition into dst and returns the result. If dst is nil a new
+// Dense is allocated.
+//  P * A * P^T ≈ U^T * U.
+// UTo will panic if the rank of the factorization is zero.
+func (c *PivotedCholesky) UTo(dst *Dense) *Dense {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewDense(c.rank, n, nil)
+	} else {
+		dst.reuseAsZeroed(c.rank, n)
+	}
+	for i := 0; i < c.rank; i++ {
+		zero(dst.mat.Data[i*dst.mat.Stride : i*dst.mat.Stride+i])
+		copy(dst.mat.Data[i*dst.mat.Stride+i:i*dst.mat.Stride+n], c.chol.mat.Data[i*c.chol.mat.Stride+i:i*c.chol.mat.Stride+n])
+	}
+	return dst
+}
+
+// LowRankTo extracts the n×rank matrix F of the low-rank approximation
+//  A ≈ F * F^T
+// where F = P^T * U^T, into dst and returns the result. If dst is nil a new
+// Dense is allocated. LowRankTo will panic if the rank of the factorization
+// is zero.
+func (c *PivotedCholesky) LowRankTo(dst *Dense) *Dense {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewDense(n, c.rank, nil)
+	} else {
+		dst.reuseAsZeroed(n, c.rank)
+	}
+	for k, p := range c.piv {
+		row := dst.mat.Data[p*dst.mat.Stride : p*dst.mat.Stride+c.rank]
+		for i := range row {
+			if i <= k {
+				row[i] = c.chol.mat.Data[i*c.chol.mat.Stride+k]
+			} else {
+				row[i] = 0
+			}
+		}
+	}
+	return dst
+}
+
+// To reconstructs the rank-limited approximation of the original positive
+// semidefinite matrix given its pivoted Cholesky decomposition into dst and
+// returns the result. If dst is nil a new SymDense is allocated.
+func (c *PivotedCholesky) To(dst *SymDense) *SymDense {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if dst == nil {
+		dst = NewSymDense(n, make([]float64, n*n))
+	} else {
+		dst.reuseAs(n)
+	}
+	if c.rank == 0 {
+		for i := 0; i < n; i++ {
+			zero(dst.mat.Data[i*dst.mat.Stride+i : i*dst.mat.Stride+n])
+		}
+		return dst
+	}
+	f := getWorkspace(n, c.rank, false)
+	c.LowRankTo(f)
+	dst.SymOuterK(1, f)
+	putWorkspace(f)
+	return dst
+}
+
+// Solve finds a matrix m that solves A * m = b where A is represented by the
+// pivoted Cholesky decomposition, placing the result in m.
+//
+// Only the leading rank×rank block of the permuted factorization is used, and
+// the rows of P*m beyond the rank are set to zero. If A has full rank, m is the
+// unique solution. Otherwise, if the columns of b lie in the range of A, m is a
+// solution of the rank deficient system.
+//
+// If the leading block is ill-conditioned a Condition error is returned.
+// Please see the documentation for Condition for more information.
+func (c *PivotedCholesky) Solve(m *Dense, b Matrix) error {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	// Permute b into the work matrix.
+	work := getWorkspace(n, bn, true)
+	for k, p := range c.piv {
+		row := work.mat.Data[k*work.mat.Stride : k*work.mat.Stride+bn]
+		for j := range row {
+			row[j] = b.At(p, j)
+		}
+	}
+	if c.rank > 0 {
+		u := blas64.Triangular{
+			Uplo:   blas.Upper,
+			Diag:   blas.NonUnit,
+			N:      c.rank,
+			Stride: c.chol.mat.Stride,
+			Data:   c.chol.mat.Data,
+		}
+		w := blas64.General{
+			Rows:   c.rank,
+			Cols:   bn,
+			Stride: work.mat.Stride,
+			Data:   work.mat.Data,
+		}
+		blas64.Trsm(blas.Left, blas.Trans, 1, u, w)
+		blas64.Trsm(blas.Left, blas.NoTrans, 1, u, w)
+	}
+	for i := c.rank; i < n; i++ {
+		zero(work.mat.Data[i*work.mat.Stride : i*work.mat.Stride+bn])
+	}
+
+	// Undo the permutation into m.
+	m.reuseAs(n, bn)
+	for k, p := range c.piv {
+		copy(m.mat.Data[p*m.mat.Stride:p*m.mat.Stride+bn], work.mat.Data[k*work.mat.Stride:k*work.mat.Stride+bn])
+	}
+	putWorkspace(work)
+	if c.cond > ConditionTolerance {
+		return Condition(c.cond)
+	}
+	return nil
+}
+
+// SolveVec finds a vector v that solves A * v = b where A is represented by
+// the pivoted Cholesky decomposition, placing the result in v. See Solve for
+// the treatment of rank deficient matrices.
+func (c *PivotedCholesky) SolveVec(v, b *VecDense) error {
+	if !c.valid() {
+		panic(badPivotedCholesky)
+	}
+	n := c.chol.mat.N
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	var m Dense
+	err := c.Solve(&m, b)
+	v.reuseAs(n)
+	for i := 0; i < n; i++ {
+		v.SetVec(i, m.mat.Data[i*m.mat.Stride])
+	}
+	return err
+}
+
+func (c *PivotedCholesky) isZero() bool {
+	return c.chol == nil
+}
+
+func (c *PivotedCholesky) valid() bool {
+	return !c.isZero() && !c.chol.IsZero()
+}
@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// randSymPSD returns a random n×n symmetric positive semidefinite matrix
+// with the given rank.
+func randSymPSD(rnd *rand.Rand, n, rank int) *SymDense {
+	g := NewDense(n, rank, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < rank; j++ {
+			g.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	var a SymDense
+	a.SymOuterK(1, g)
+	return &a
+}
+
+func TestPivotedCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, rank int
+	}{
+		{n: 1, rank: 1},
+		{n: 3, rank: 3},
+		{n: 5, rank: 2},
+		{n: 10, rank: 10},
+		{n: 10, rank: 7},
+		{n: 50, rank: 12},
+		{n: 100, rank: 100},
+		{n: 150, rank: 40},
+	} {
+		n := test.n
+		a := randSymPSD(rnd, n, test.rank)
+		for _, chol := range []*PivotedCholesky{
+			{},
+			{chol: NewTriDense(n, true, nil)},
+			{chol: NewTriDense(n+1, true, nil)},
+		} {
+			rank := chol.Factorize(a, -1)
+			if rank != test.rank {
+				t.Errorf("n=%d: unexpected rank: got %d, want %d", n, rank, test.rank)
+				continue
+			}
+			if chol.Rank() != rank {
+				t.Errorf("n=%d: Rank mismatch: got %d, want %d", n, chol.Rank(), rank)
+			}
+			if chol.Size() != n {
+				t.Errorf("n=%d: Size mismatch: got %d, want %d", n, chol.Size(), n)
+			}
+
+			// Check that P * A * P^T = U^T * U.
+			var p Dense
+			p.Permutation(n, chol.Pivot(nil))
+			var pap, tmp Dense
+			tmp.Mul(&p, a)
+			pap.Mul(&tmp, p.T())
+			u := chol.UTo(nil)
+			if r, c := u.Dims(); r != rank || c != n {
+				t.Errorf("n=%d: unexpected dimensions of U: got %d×%d, want %d×%d", n, r, c, rank, n)
+			}
+			var utu Dense
+			utu.Mul(u.T(), u)
+			if !EqualApprox(&utu, &pap, 1e-12*float64(n)) {
+				t.Errorf("n=%d: unexpected factor product", n)
+			}
+
+			// Check that A = F * F^T.
+			f := chol.LowRankTo(nil)
+			var fft Dense
+			fft.Mul(f, f.T())
+			if !EqualApprox(&fft, a, 1e-12*float64(n)) {
+				t.Errorf("n=%d: unexpected low-rank approximation", n)
+			}
+			if !EqualApprox(chol.To(nil), a, 1e-12*float64(n)) {
+				t.Errorf("n=%d: unexpected reconstruction", n)
+			}
+
+			// Check that solving with b in the range of A recovers b.
+			x := NewDense(n, 3, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < 3; j++ {
+					x.Set(i, j, rnd.NormFloat64())
+				}
+			}
+			var b Dense
+			b.Mul(a, x)
+			var got Dense
+			err := chol.Solve(&got, &b)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error from Solve: %v", n, err)
+			}
+			var ax Dense
+			ax.Mul(a, &got)
+			if !EqualApprox(&ax, &b, 1e-8*float64(n)) {
+				t.Errorf("n=%d: unexpected solution from Solve", n)
+			}
+			if rank == n && !EqualApprox(&got, x, 1e-8*float64(n)) {
+				t.Errorf("n=%d: solution mismatch for full rank matrix", n)
+			}
+
+			xv := NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				xv.SetVec(i, x.At(i, 0))
+			}
+			bv := NewVecDense(n, nil)
+			bv.MulVec(a, xv)
+			var gotv VecDense
+			err = chol.SolveVec(&gotv, bv)
+			if err != nil {
+				t.Errorf("n=%d: unexpected error from SolveVec: %v", n, err)
+			}
+			var axv VecDense
+			axv.MulVec(a, &gotv)
+			if !EqualApprox(&axv, bv, 1e-8*float64(n)) {
+				t.Errorf("n=%d: unexpected solution from SolveVec", n)
+			}
+		}
+	}
+}
+
+func TestPivotedCholeskyTolerance(t *testing.T) {
+	// Diagonal matrix with known spectrum.
+	a := NewSymDense(4, []float64{
+		1, 0, 0, 0,
+		0, 1e-3, 0, 0,
+		0, 0, 10, 0,
+		0, 0, 0, 1e-8,
+	})
+	var chol PivotedCholesky
+	for _, test := range []struct {
+		tol  float64
+		rank int
+	}{
+		{tol: -1, rank: 4},
+		{tol: 1e-6, rank: 3},
+		{tol: 1e-2, rank: 2},
+		{tol: 5, rank: 1},
+		// The first positive pivot is always accepted.
+		{tol: 100, rank: 1},
+	} {
+		rank := chol.Factorize(a, test.tol)
+		if rank != test.rank {
+			t.Errorf("tol=%v: unexpected rank: got %d, want %d", test.tol, rank, test.rank)
+		}
+	}
+	piv := chol.Pivot(nil)
+	chol.Factorize(a, -1)
+	chol.Pivot(piv)
+	want := []int{2, 0, 1, 3}
+	for i, p := range piv {
+		if p != want[i] {
+			t.Errorf("unexpected pivot: got %v, want %v", piv, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import "math"
+
+const badPolar = "mat: invalid polar decomposition"
+
+// Polar is a type for creating and using the polar decomposition of a matrix.
+// The polar decomposition of an m×n matrix A has the form
+//  A = U * P
+// where U is an m×n matrix and P is an n×n symmetric positive semidefinite
+// matrix. If m >= n the columns of U are orthonormal, otherwise its rows are
+// orthonormal. P is always unique and is the square root of A^T * A, and U is
+// unique if A has full rank.
+//
+// Among the matrices with orthonormal columns (or rows), U is the closest to
+// A in the Frobenius norm.
+type Polar struct {
+	u *Dense
+	p *SymDense
+}
+
+// Factorize computes the polar decomposition of a from its singular value
+// decomposition
+//  A = W * Σ * V^T,
+// as U = W * V^T and P = V * Σ * V^T. Factorize returns whether the
+// decomposition succeeded. If the decomposition failed, methods that require
+// a successful factorization will panic.
+func (p *Polar) Factorize(a Matrix) (ok bool) {
+	m, n := a.Dims()
+	var svd SVD
+	if !svd.Factorize(a, SVDThin) {
+		p.u = nil
+		p.p = nil
+		return false
+	}
+	s := svd.Values(nil)
+	w := getWorkspace(m, len(s), false)
+	v := getWorkspace(n, len(s), false)
+	svd.UTo(w)
+	svd.VTo(v)
+
+	if p.u == nil {
+		p.u = &Dense{}
+	} else {
+		p.u.Reset()
+	}
+	p.u.Mul(w, v.T())
+
+	// P = (V * Σ^{1/2}) * (V * Σ^{1/2})^T.
+	for j, sv := range s {
+		f := math.Sqrt(sv)
+		for i := 0; i < n; i++ {
+			v.mat.Data[i*v.mat.Stride+j] *= f
+		}
+	}
+	if p.p == nil {
+		p.p = &SymDense{}
+	} else {
+		p.p.Reset()
+	}
+	p.p.SymOuterK(1, v)
+
+	putWorkspace(w)
+	putWorkspace(v)
+	return true
+}
+
+// UTo extracts the m×n factor U of the polar decomposition into dst and
+// returns the result. If dst is nil a new Dense is allocated.
+func (p *Polar) UTo(dst *Dense) *Dense {
+	if p.u == nil {
+		panic(badPolar)
+	}
+	r, c := p.u.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAs(r, c)
+	}
+	dst.Copy(p.u)
+	return dst
+}
+
+// PTo extracts the n×n symmetric positive semidefinite factor P of the polar
+// decomposition into dst and returns the result. If dst is nil a new SymDense
+// is allocated.
+func (p *Polar) PTo(dst *SymDense) *SymDense {
+	if p.p == nil {
+		panic(badPolar)
+	}
+	n := p.p.Symmetric()
+	if dst == nil {
+		dst = NewSymDense(n, nil)
+	} else {
+		dst.reuseAs(n)
+	}
+	dst.CopySym(p.p)
+	return dst
+}
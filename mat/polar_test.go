@@ -0,0 +1,68 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPolar(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{3, 3},
+		{5, 3},
+		{3, 5},
+		{10, 10},
+	} {
+		m, n := test.m, test.n
+		a := randomDense(m, n, rnd)
+		var p Polar
+		if !p.Factorize(a) {
+			t.Errorf("polar factorization failed for %d×%d", m, n)
+			continue
+		}
+		u := p.UTo(nil)
+		pf := p.PTo(nil)
+
+		var got Dense
+		got.Mul(u, pf)
+		if !EqualApprox(&got, a, 1e-12) {
+			t.Errorf("U*P does not equal A for %d×%d", m, n)
+		}
+
+		// Check the orthonormality of the columns or rows of U.
+		var utu Dense
+		if m >= n {
+			utu.Mul(u.T(), u)
+		} else {
+			utu.Mul(u, u.T())
+		}
+		k := min(m, n)
+		if !EqualApprox(&utu, eye(k), 1e-12) {
+			t.Errorf("U is not orthonormal for %d×%d", m, n)
+		}
+
+		// P is the positive semidefinite square root of A^T*A.
+		var es EigenSym
+		if !es.Factorize(pf, false) {
+			t.Fatalf("eigendecomposition of P failed")
+		}
+		for _, v := range es.Values(nil) {
+			if v < -1e-12 {
+				t.Errorf("P is not positive semidefinite for %d×%d: eigenvalue %v", m, n, v)
+			}
+		}
+		var ata, pp Dense
+		ata.Mul(a.T(), a)
+		pp.Mul(pf, pf)
+		if !EqualApprox(&pp, &ata, 1e-10) {
+			t.Errorf("P*P does not equal A^T*A for %d×%d", m, n)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+const badProcrustes = "mat: invalid Procrustes solution"
+
+// OrthogonalProcrustes sets the receiver to the n×n orthogonal matrix Ω that
+// minimizes
+//  ‖A * Ω - B‖_F
+// where A and B are m×n matrices. If A and B do not have the same dimensions
+// OrthogonalProcrustes will panic.
+//
+// The solution is Ω = U * V^T where A^T * B = U * Σ * V^T is the singular value
+// decomposition of A^T * B. OrthogonalProcrustes returns whether the
+// decomposition succeeded. Ω may be a reflection. Procrustes.Fit can be used
+// to restrict the solution to rotations.
+func (m *Dense) OrthogonalProcrustes(a, b Matrix) (ok bool) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	c := getWorkspace(ac, bc, false)
+	c.Mul(a.T(), b)
+	var svd SVD
+	ok = svd.Factorize(c, SVDFull)
+	putWorkspace(c)
+	if !ok {
+		return false
+	}
+	var u, v Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+	m.Mul(&u, v.T())
+	return true
+}
+
+// Procrustes is a type for computing and using the similarity transform that
+// best maps one set of points onto another. Given n paired points x_i and y_i
+// in d dimensions and non-negative weights w_i, Procrustes finds the rotation
+// R, scale s and translation t that minimize the weighted sum of squared
+// residuals
+//  Σ_i w_i * ‖y_i - (s * R * x_i + t)‖^2.
+// When the weights are equal and s is fixed at one this is solved by the
+// Kabsch algorithm.
+type Procrustes struct {
+	r     *Dense
+	scale float64
+	t     *VecDense
+	rss   float64
+}
+
+// Fit computes the transform that best maps the points held in the rows of x
+// onto the corresponding rows of y. x and y must both be n×d, otherwise Fit
+// will panic with ErrShape. If weights is not nil it must have length n and
+// hold non-negative values with a positive sum, otherwise all points are
+// given equal weight.
+//
+// If scaling is false the scale is fixed at one. If reflection is false R is
+// restricted to proper rotations, so that det(R) = 1, otherwise R may be any
+// orthogonal matrix.
+//
+// Fit returns whether the computation succeeded. It fails if the singular
+// value decomposition fails, or if scaling is requested and the weighted
+// points of x are all equal.
+func (p *Procrustes) Fit(x, y Matrix, weights []float64, scaling, reflection bool) (ok bool) {
+	n, d := x.Dims()
+	if r, c := y.Dims(); r != n || c != d {
+		panic(ErrShape)
+	}
+	if n == 0 || d == 0 {
+		panic(ErrZeroLength)
+	}
+	if weights != nil && len(weights) != n {
+		panic(ErrSliceLengthMismatch)
+	}
+	p.r = nil
+
+	// Compute the weighted centroids.
+	w := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+		return weights[i]
+	}
+	var sumW float64
+	mx := make([]float64, d)
+	my := make([]float64, d)
+	for i := 0; i < n; i++ {
+		wi := w(i)
+		if wi < 0 {
+			panic("mat: negative weight")
+		}
+		sumW += wi
+		for j := 0; j < d; j++ {
+			mx[j] += wi * x.At(i, j)
+			my[j] += wi * y.At(i, j)
+		}
+	}
+	if sumW <= 0 {
+		panic("mat: weights sum to zero")
+	}
+	for j := range mx {
+		mx[j] /= sumW
+		my[j] /= sumW
+	}
+
+	// Form the centered point sets, weighting the rows of x, and the
+	// weighted variance of x.
+	xc := getWorkspace(n, d, false)
+	yc := getWorkspace(n, d, false)
+	var varX float64
+	for i := 0; i < n; i++ {
+		wi := w(i)
+		for j := 0; j < d; j++ {
+			v := x.At(i, j) - mx[j]
+			varX += wi * v * v
+			xc.mat.Data[i*xc.mat.Stride+j] = wi * v
+			yc.mat.Data[i*yc.mat.Stride+j] = y.At(i, j) - my[j]
+		}
+	}
+
+	// The cross-covariance matrix of y and x.
+	c := getWorkspace(d, d, false)
+	c.Mul(yc.T(), xc)
+	putWorkspace(xc)
+	putWorkspace(yc)
+	var svd SVD
+	ok = svd.Factorize(c, SVDFull)
+	putWorkspace(c)
+	if !ok {
+		return false
+	}
+	s := svd.Values(nil)
+	var u, v Dense
+	svd.UTo(&u)
+	svd.VTo(&v)
+
+	rot := &Dense{}
+	rot.Mul(&u, v.T())
+	trace := 0.0
+	for _, sv := range s {
+		trace += sv
+	}
+	if !reflection && Det(rot) < 0 {
+		// Flip the direction associated with the smallest singular
+		// value to obtain the closest proper rotation.
+		for i := 0; i < d; i++ {
+			u.Set(i, d-1, -u.At(i, d-1))
+		}
+		rot.Mul(&u, v.T())
+		trace -= 2 * s[d-1]
+	}
+
+	scale := 1.0
+	if scaling {
+		if varX == 0 {
+			return false
+		}
+		scale = trace / varX
+	}
+
+	// t = my - s * R * mx.
+	t := NewVecDense(d, nil)
+	t.MulVec(rot, NewVecDense(d, mx))
+	t.ScaleVec(-scale, t)
+	t.AddVec(t, NewVecDense(d, my))
+
+	p.r = rot
+	p.scale = scale
+	p.t = t
+	p.rss = 0
+	for i := 0; i < n; i++ {
+		wi := w(i)
+		if wi == 0 {
+			continue
+		}
+		for j := 0; j < d; j++ {
+			v := y.At(i, j) - t.At(j, 0)
+			for k := 0; k < d; k++ {
+				v -= scale * rot.At(j, k) * x.At(i, k)
+			}
+			p.rss += wi * v * v
+		}
+	}
+	return true
+}
+
+// RotationTo extracts the d×d orthogonal matrix R of the fitted transform into
+// dst and returns the result. If dst is nil a new Dense is allocated.
+func (p *Procrustes) RotationTo(dst *Dense) *Dense {
+	if p.r == nil {
+		panic(badProcrustes)
+	}
+	d, _ := p.r.Dims()
+	if dst == nil {
+		dst = NewDense(d, d, nil)
+	} else {
+		dst.reuseAs(d, d)
+	}
+	dst.Copy(p.r)
+	return dst
+}
+
+// Scale returns the scale s of the fitted transform.
+func (p *Procrustes) Scale() float64 {
+	if p.r == nil {
+		panic(badProcrustes)
+	}
+	return p.scale
+}
+
+// TranslationTo extracts the translation t of the fitted transform into dst
+// and returns the result. If dst is nil a new VecDense is allocated.
+func (p *Procrustes) TranslationTo(dst *VecDense) *VecDense {
+	if p.r == nil {
+		panic(badProcrustes)
+	}
+	d := p.t.Len()
+	if dst == nil {
+		dst = NewVecDense(d, nil)
+	} else {
+		dst.reuseAs(d)
+	}
+	dst.CopyVec(p.t)
+	return dst
+}
+
+// Residual returns the weighted sum of squared residuals of the fitted
+// transform.
+func (p *Procrustes) Residual() float64 {
+	if p.r == nil {
+		panic(badProcrustes)
+	}
+	return p.rss
+}
+
+// TransformTo applies the fitted transform to the points held in the rows of
+// x, placing the result in dst and returning it. If dst is nil a new Dense is
+// allocated. x must have the same number of columns as the points used in the
+// fit, otherwise TransformTo will panic.
+func (p *Procrustes) TransformTo(dst *Dense, x Matrix) *Dense {
+	if p.r == nil {
+		panic(badProcrustes)
+	}
+	n, d := x.Dims()
+	if rd, _ := p.r.Dims(); d != rd {
+		panic(ErrShape)
+	}
+	if dst == nil {
+		dst = NewDense(n, d, nil)
+	} else {
+		dst.reuseAs(n, d)
+	}
+	// Y = s * X * R^T + 1 * t^T.
+	tmp := getWorkspace(n, d, false)
+	tmp.Mul(x, p.r.T())
+	for i := 0; i < n; i++ {
+		for j := 0; j < d; j++ {
+			tmp.mat.Data[i*tmp.mat.Stride+j] = p.scale*tmp.mat.Data[i*tmp.mat.Stride+j] + p.t.At(j, 0)
+		}
+	}
+	dst.Copy(tmp)
+	putWorkspace(tmp)
+	return dst
+}
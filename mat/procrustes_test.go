@@ -0,0 +1,188 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomRotation returns a random d×d orthogonal matrix with determinant det,
+// which must be 1 or -1.
+func randomRotation(d int, det float64, rnd *rand.Rand) *Dense {
+	var qr QR
+	qr.Factorize(randomDense(d, d, rnd))
+	q := qr.QTo(nil)
+	if Det(q)*det < 0 {
+		for i := 0; i < d; i++ {
+			q.Set(i, 0, -q.At(i, 0))
+		}
+	}
+	return q
+}
+
+// transformRows returns s * x * r^T + 1 * t^T.
+func transformRows(x, r Matrix, s float64, t []float64) *Dense {
+	var y Dense
+	y.Mul(x, r.T())
+	y.Apply(func(i, j int, v float64) float64 { return s*v + t[j] }, &y)
+	return &y
+}
+
+func TestOrthogonalProcrustes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+		det  float64
+	}{
+		{5, 2, 1},
+		{5, 3, -1},
+		{10, 4, 1},
+		{4, 4, -1},
+	} {
+		a := randomDense(test.m, test.n, rnd)
+		omega := randomRotation(test.n, test.det, rnd)
+		var b Dense
+		b.Mul(a, omega)
+
+		var got Dense
+		if !got.OrthogonalProcrustes(a, &b) {
+			t.Errorf("unexpected failure for %d×%d", test.m, test.n)
+			continue
+		}
+		if !EqualApprox(&got, omega, 1e-12) {
+			t.Errorf("unexpected solution for %d×%d:\ngot  %v\nwant %v", test.m, test.n, Formatted(&got), Formatted(omega))
+		}
+	}
+}
+
+func TestProcrustes(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, d    int
+		scale   float64
+		scaling bool
+	}{
+		{n: 3, d: 2, scale: 1},
+		{n: 10, d: 3, scale: 1},
+		{n: 10, d: 3, scale: 2.5, scaling: true},
+		{n: 20, d: 4, scale: 0.3, scaling: true},
+	} {
+		x := randomDense(test.n, test.d, rnd)
+		r := randomRotation(test.d, 1, rnd)
+		tr := make([]float64, test.d)
+		for i := range tr {
+			tr[i] = 10 * rnd.NormFloat64()
+		}
+		y := transformRows(x, r, test.scale, tr)
+
+		for _, reflection := range []bool{false, true} {
+			var p Procrustes
+			if !p.Fit(x, y, nil, test.scaling, reflection) {
+				t.Errorf("unexpected failure for n=%d d=%d", test.n, test.d)
+				continue
+			}
+			if got := p.RotationTo(nil); !EqualApprox(got, r, 1e-10) {
+				t.Errorf("unexpected rotation for n=%d d=%d:\ngot  %v\nwant %v", test.n, test.d, Formatted(got), Formatted(r))
+			}
+			if got := p.Scale(); math.Abs(got-test.scale) > 1e-10 {
+				t.Errorf("unexpected scale for n=%d d=%d: got %v want %v", test.n, test.d, got, test.scale)
+			}
+			if got := p.TranslationTo(nil); !EqualApprox(got, NewVecDense(test.d, tr), 1e-10) {
+				t.Errorf("unexpected translation for n=%d d=%d: got %v want %v", test.n, test.d, got.RawVector().Data, tr)
+			}
+			if got := p.Residual(); got > 1e-18*float64(test.n) {
+				t.Errorf("unexpected residual for exact fit: %v", got)
+			}
+			if got := p.TransformTo(nil, x); !EqualApprox(got, y, 1e-10) {
+				t.Errorf("transformed points do not match for n=%d d=%d", test.n, test.d)
+			}
+		}
+	}
+}
+
+func TestProcrustesReflection(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n, d = 10, 3
+	x := randomDense(n, d, rnd)
+	r := randomRotation(d, -1, rnd)
+	y := transformRows(x, r, 1, make([]float64, d))
+
+	var p Procrustes
+	if !p.Fit(x, y, nil, false, true) {
+		t.Fatal("unexpected failure")
+	}
+	if got := p.RotationTo(nil); !EqualApprox(got, r, 1e-10) {
+		t.Errorf("unexpected reflection:\ngot  %v\nwant %v", Formatted(got), Formatted(r))
+	}
+
+	// The reflection guard must give a proper rotation with a larger residual.
+	if !p.Fit(x, y, nil, false, false) {
+		t.Fatal("unexpected failure")
+	}
+	if det := Det(p.RotationTo(nil)); math.Abs(det-1) > 1e-12 {
+		t.Errorf("rotation has determinant %v, want 1", det)
+	}
+	if p.Residual() < 1e-6 {
+		t.Errorf("unexpected small residual for guarded fit: %v", p.Residual())
+	}
+
+	// No proper rotation without translation achieves a smaller residual.
+	best := p.Residual()
+	for trial := 0; trial < 100; trial++ {
+		q := randomRotation(d, 1, rnd)
+		var diff Dense
+		diff.Sub(transformRows(x, q, 1, make([]float64, d)), y)
+		var rss float64
+		for i := 0; i < n; i++ {
+			for j := 0; j < d; j++ {
+				rss += diff.At(i, j) * diff.At(i, j)
+			}
+		}
+		if rss < best-1e-10 {
+			t.Errorf("random rotation improves on guarded fit: %v < %v", rss, best)
+			break
+		}
+	}
+}
+
+func TestProcrustesWeighted(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n, d = 12, 3
+	x := randomDense(n, d, rnd)
+	r := randomRotation(d, 1, rnd)
+	tr := []float64{1, -2, 3}
+	y := transformRows(x, r, 2, tr)
+
+	// Corrupt some points and give them zero weight.
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 0.5 + rnd.Float64()
+		if i%4 == 0 {
+			w[i] = 0
+			for j := 0; j < d; j++ {
+				y.Set(i, j, 100*rnd.NormFloat64())
+			}
+		}
+	}
+
+	var p Procrustes
+	if !p.Fit(x, y, w, true, false) {
+		t.Fatal("unexpected failure")
+	}
+	if got := p.RotationTo(nil); !EqualApprox(got, r, 1e-10) {
+		t.Errorf("unexpected rotation:\ngot  %v\nwant %v", Formatted(got), Formatted(r))
+	}
+	if got := p.Scale(); math.Abs(got-2) > 1e-10 {
+		t.Errorf("unexpected scale: got %v want 2", got)
+	}
+	if got := p.TranslationTo(nil); !EqualApprox(got, NewVecDense(d, tr), 1e-10) {
+		t.Errorf("unexpected translation: got %v want %v", got.RawVector().Data, tr)
+	}
+	if got := p.Residual(); got > 1e-16 {
+		t.Errorf("unexpected residual: %v", got)
+	}
+}
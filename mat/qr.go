@@ -20,7 +20,7 @@ type QR struct {
 	cond float64
 }
 
-func (qr *QR) updateCond(norm lapack.MatrixNorm) {
+func (qr *QR) updateCond(norm lapack.MatrixNorm, ws *Workspace) {
 	// Since A = Q*R, and Q is orthogonal, we get for the condition number κ
 	//  κ(A) := |A| |A^-1| = |Q*R| |(Q*R)^-1| = |R| |R^-1 * Q^T|
 	//        = |R| |R^-1| = κ(R),
@@ -29,12 +29,12 @@ func (qr *QR) updateCond(norm lapack.MatrixNorm) {
 	// is not the case for CondNorm. Hopefully the error is negligible: κ
 	// is only a qualitative measure anyway.
 	n := qr.qr.mat.Cols
-	work := getFloats(3*n, false)
-	iwork := getInts(n, false)
+	work := ws.getFloats(3*n, false)
+	iwork := ws.getInts(n, false)
 	r := qr.qr.asTriDense(n, blas.NonUnit, blas.Upper)
 	v := lapack64.Trcon(norm, r.mat, work, iwork)
-	putFloats(work)
-	putInts(iwork)
+	ws.putFloats(work)
+	ws.putInts(iwork)
 	qr.cond = 1 / v
 }
 
@@ -45,27 +45,41 @@ func (qr *QR) updateCond(norm lapack.MatrixNorm) {
 // The matrix Q is an orthonormal m×m matrix, and R is an m×n upper triangular matrix.
 // Q and R can be extracted using the QTo and RTo methods.
 func (qr *QR) Factorize(a Matrix) {
-	qr.factorize(a, CondNorm)
+	qr.factorize(a, CondNorm, nil)
+}
+
+// FactorizeWork is like Factorize, but obtains temporary storage from ws.
+// If ws is nil, FactorizeWork is equivalent to Factorize.
+func (qr *QR) FactorizeWork(a Matrix, ws *Workspace) {
+	qr.factorize(a, CondNorm, ws)
 }
 
-func (qr *QR) factorize(a Matrix, norm lapack.MatrixNorm) {
+func (qr *QR) factorize(a Matrix, norm lapack.MatrixNorm, ws *Workspace) {
 	m, n := a.Dims()
 	if m < n {
 		panic(ErrShape)
 	}
 	k := min(m, n)
 	if qr.qr == nil {
-		qr.qr = &Dense{}
+		qr.qr = NewDense(m, n, nil)
+	} else {
+		qr.qr.Reset()
+		qr.qr.reuseAs(m, n)
 	}
-	qr.qr.Clone(a)
-	work := []float64{0}
-	qr.tau = make([]float64, k)
+	qr.qr.Copy(a)
+	if cap(qr.tau) < k {
+		qr.tau = make([]float64, k)
+	}
+	qr.tau = qr.tau[:k]
+	work := ws.getFloats(1, false)
 	lapack64.Geqrf(qr.qr.mat, qr.tau, work, -1)
+	lwork := int(work[0])
+	ws.putFloats(work)
 
-	work = getFloats(int(work[0]), false)
+	work = ws.getFloats(lwork, false)
 	lapack64.Geqrf(qr.qr.mat, qr.tau, work, len(work))
-	putFloats(work)
-	qr.updateCond(norm)
+	ws.putFloats(work)
+	qr.updateCond(norm, ws)
 }
 
 // Cond returns the condition number for the factorized matrix.
@@ -146,6 +160,16 @@ func (qr *QR) QTo(dst *Dense) *Dense {
 //  If trans == true, find the minimum norm solution of A^T * X = b.
 // The solution matrix, X, is stored in place into m.
 func (qr *QR) Solve(m *Dense, trans bool, b Matrix) error {
+	return qr.solve(m, trans, b, nil)
+}
+
+// SolveWork is like Solve, but obtains temporary storage from ws.
+// If ws is nil, SolveWork is equivalent to Solve.
+func (qr *QR) SolveWork(m *Dense, trans bool, b Matrix, ws *Workspace) error {
+	return qr.solve(m, trans, b, ws)
+}
+
+func (qr *QR) solve(m *Dense, trans bool, b Matrix, ws *Workspace) error {
 	r, c := qr.qr.Dims()
 	br, bc := b.Dims()
 
@@ -166,56 +190,93 @@ func (qr *QR) Solve(m *Dense, trans bool, b Matrix) error {
 	}
 	// Do not need to worry about overlap between m and b because x has its own
 	// independent storage.
-	x := getWorkspace(max(r, c), bc, false)
+	x := ws.getWorkspace(max(r, c), bc, false)
+	defer ws.putWorkspace(x)
 	x.Copy(b)
+	if !qr.solveInPlace(x, trans, ws) {
+		return Condition(math.Inf(1))
+	}
+	// M was set above to be the correct size for the result.
+	m.Copy(x)
+	if qr.cond > ConditionTolerance {
+		return Condition(qr.cond)
+	}
+	return nil
+}
+
+// solveInPlace overwrites the right-hand side held in the leading rows of x
+// with the solution. x must have max(m, n) rows. solveInPlace returns false
+// if the triangular factor is singular.
+func (qr *QR) solveInPlace(x *Dense, trans bool, ws *Workspace) bool {
+	r, c := qr.qr.Dims()
+	bc := x.mat.Cols
 	t := qr.qr.asTriDense(qr.qr.mat.Cols, blas.NonUnit, blas.Upper).mat
 	if trans {
 		ok := lapack64.Trtrs(blas.Trans, t, x.mat)
 		if !ok {
-			return Condition(math.Inf(1))
+			return false
 		}
 		for i := c; i < r; i++ {
 			zero(x.mat.Data[i*x.mat.Stride : i*x.mat.Stride+bc])
 		}
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, x.mat, work, len(work))
-		putFloats(work)
-	} else {
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
-		putFloats(work)
-
-		ok := lapack64.Trtrs(blas.NoTrans, t, x.mat)
-		if !ok {
-			return Condition(math.Inf(1))
-		}
-	}
-	// M was set above to be the correct size for the result.
-	m.Copy(x)
-	putWorkspace(x)
-	if qr.cond > ConditionTolerance {
-		return Condition(qr.cond)
+		qr.ormqr(blas.NoTrans, x, ws)
+		return true
 	}
-	return nil
+	qr.ormqr(blas.Trans, x, ws)
+	return lapack64.Trtrs(blas.NoTrans, t, x.mat)
+}
+
+// ormqr multiplies x from the left by Q or Q^T depending on trans.
+func (qr *QR) ormqr(trans blas.Transpose, x *Dense, ws *Workspace) {
+	work := ws.getFloats(1, false)
+	lapack64.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, -1)
+	lwork := int(work[0])
+	ws.putFloats(work)
+
+	work = ws.getFloats(lwork, false)
+	lapack64.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
+	ws.putFloats(work)
 }
 
 // SolveVec finds a minimum-norm solution to a system of linear equations.
 // Please see QR.Solve for the full documentation.
 func (qr *QR) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	return qr.solveVec(v, trans, b, nil)
+}
+
+// SolveVecWork is like SolveVec, but obtains temporary storage from ws.
+// If ws is nil, SolveVecWork is equivalent to SolveVec.
+func (qr *QR) SolveVecWork(v *VecDense, trans bool, b *VecDense, ws *Workspace) error {
+	return qr.solveVec(v, trans, b, ws)
+}
+
+func (qr *QR) solveVec(v *VecDense, trans bool, b *VecDense, ws *Workspace) error {
 	if v != b {
 		v.checkOverlap(b.mat)
 	}
 	r, c := qr.qr.Dims()
-	// The Solve implementation is non-trivial, so rather than duplicate the code,
-	// instead recast the VecDenses as Dense and call the matrix code.
+	bn := b.Len()
+	n := c
 	if trans {
-		v.reuseAs(r)
-	} else {
-		v.reuseAs(c)
+		if c != bn {
+			panic(ErrShape)
+		}
+		n = r
+	} else if r != bn {
+		panic(ErrShape)
+	}
+	v.reuseAs(n)
+	// As in Solve, x holds both the right-hand side and the solution.
+	x := ws.getWorkspace(max(r, c), 1, false)
+	defer ws.putWorkspace(x)
+	xvec := blas64.Vector{Inc: 1, Data: x.mat.Data}
+	blas64.Copy(bn, b.mat, xvec)
+	if !qr.solveInPlace(x, trans, ws) {
+		return Condition(math.Inf(1))
+	}
+	blas64.Copy(n, xvec, v.mat)
+	if qr.cond > ConditionTolerance {
+		return Condition(qr.cond)
 	}
-	return qr.Solve(v.asDense(), trans, b.asDense())
+	return nil
 }
@@ -5,8 +5,11 @@
 package mat
 
 import (
+	"math"
+
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
@@ -19,6 +22,17 @@ import (
 //  - if m < n, find the minimum norm solution of A * X = B.
 // The solution matrix, X, is stored in-place into the receiver.
 func (m *Dense) Solve(a, b Matrix) error {
+	return m.solve(a, b, nil)
+}
+
+// SolveWork is like Solve, but obtains temporary storage, including the
+// storage for the factorization of a, from ws. If ws is nil, SolveWork is
+// equivalent to Solve.
+func (m *Dense) SolveWork(a, b Matrix, ws *Workspace) error {
+	return m.solve(a, b, ws)
+}
+
+func (m *Dense) solve(a, b Matrix, ws *Workspace) error {
 	ar, ac := a.Dims()
 	br, bc := b.Dims()
 	if ar != br {
@@ -41,10 +55,10 @@ func (m *Dense) Solve(a, b Matrix) error {
 		case RawMatrixer:
 			if m != bU || bTrans {
 				if m == bU || m.checkOverlap(rm.RawMatrix()) {
-					tmp := getWorkspace(br, bc, false)
+					tmp := ws.getWorkspace(br, bc, false)
 					tmp.Copy(b)
 					m.Copy(tmp)
-					putWorkspace(tmp)
+					ws.putWorkspace(tmp)
 					break
 				}
 				m.Copy(b)
@@ -54,20 +68,20 @@ func (m *Dense) Solve(a, b Matrix) error {
 				m.Copy(b)
 			} else if bTrans {
 				// m and b share data so Copy cannot be used directly.
-				tmp := getWorkspace(br, bc, false)
+				tmp := ws.getWorkspace(br, bc, false)
 				tmp.Copy(b)
 				m.Copy(tmp)
-				putWorkspace(tmp)
+				ws.putWorkspace(tmp)
 			}
 		}
 
 		rm := rma.RawTriangular()
 		blas64.Trsm(side, tA, 1, rm, m.mat)
-		work := getFloats(3*rm.N, false)
-		iwork := getInts(rm.N, false)
+		work := ws.getFloats(3*rm.N, false)
+		iwork := ws.getInts(rm.N, false)
 		cond := lapack64.Trcon(CondNorm, rm, work, iwork)
-		putFloats(work)
-		putInts(iwork)
+		ws.putFloats(work)
+		ws.putInts(iwork)
 		if cond > ConditionTolerance {
 			return Condition(cond)
 		}
@@ -89,17 +103,17 @@ func (m *Dense) Solve(a, b Matrix) error {
 			}
 			return nil
 		}
-		var lu LU
-		lu.Factorize(a)
-		return lu.Solve(m, false, b)
+		lu := ws.getLU()
+		lu.factorize(a, CondNorm, ws)
+		return lu.solve(m, false, b, ws)
 	case ar > ac:
-		var qr QR
-		qr.Factorize(a)
-		return qr.Solve(m, false, b)
+		qr := ws.getQR()
+		qr.factorize(a, CondNorm, ws)
+		return qr.solve(m, false, b, ws)
 	default:
-		var lq LQ
-		lq.Factorize(a)
-		return lq.Solve(m, false, b)
+		lq := ws.getLQ()
+		lq.factorize(a, CondNorm, ws)
+		return lq.solve(m, false, b, ws)
 	}
 }
 
@@ -125,3 +139,194 @@ func (v *VecDense) SolveVec(a Matrix, b *VecDense) error {
 	}
 	return m.Solve(a, bm)
 }
+
+// SolveInfo holds the error estimates and scaling computed by SolveRefined and
+// SolveSymRefined.
+type SolveInfo struct {
+	// FErr holds the estimated forward error bound for each column of
+	// the solution X, that is, an upper bound on the largest element of
+	// X_j - XTRUE_j divided by the largest element of X_j, where XTRUE is
+	// the exact solution.
+	FErr []float64
+
+	// BErr holds the componentwise relative backward error of each column
+	// of X, that is, the smallest relative change in any element of A or
+	// B that makes X_j an exact solution.
+	BErr []float64
+
+	// RCond is the estimate of the reciprocal condition number of the
+	// matrix A after equilibration.
+	RCond float64
+
+	// R and C hold the row and column scale factors that were applied
+	// to A so that the factorized matrix was diag(R) * A * diag(C). R is
+	// nil if the rows were not scaled and C is nil if the columns were
+	// not scaled.
+	R, C []float64
+}
+
+// SolveRefined solves the square system of linear equations A * X = B using
+// the LU factorization of A, storing X in the receiver. If equilibrate is true,
+// the rows and columns of A are scaled before factorization when A is badly
+// scaled. The solution is improved by iterative refinement, and the returned
+// SolveInfo holds error bounds for it along with the condition estimate and
+// the scale factors that were used.
+//
+// If A is exactly singular, a Condition error of +Inf is returned and the
+// receiver does not contain a solution. If A is near-singular, a Condition
+// error is returned but the solution is computed. Please see the
+// documentation for Condition for more information.
+func (m *Dense) SolveRefined(a, b Matrix, equilibrate bool) (SolveInfo, error) {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	// Gesvx overwrites a and b, so work on copies. This also protects
+	// against aliasing between the receiver and the inputs.
+	aw := getWorkspace(n, n, false)
+	defer putWorkspace(aw)
+	aw.Copy(a)
+	bw := getWorkspace(n, bc, false)
+	defer putWorkspace(bw)
+	bw.Copy(b)
+	af := getWorkspace(n, n, false)
+	defer putWorkspace(af)
+	m.reuseAs(n, bc)
+
+	ipiv := getInts(n, false)
+	defer putInts(ipiv)
+	work := getFloats(4*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+
+	fact := lapack.FactorizeOnly
+	if equilibrate {
+		fact = lapack.EquilibrateFactorize
+	}
+	r := make([]float64, n)
+	cs := make([]float64, n)
+	info := SolveInfo{
+		FErr: make([]float64, bc),
+		BErr: make([]float64, bc),
+	}
+	equed, rcond, _, ok := lapack64.Gesvx(fact, blas.NoTrans, aw.mat, af.mat, ipiv, lapack.NoEquilibration, r, cs, bw.mat, m.mat, info.FErr, info.BErr, work, iwork)
+	info.RCond = rcond
+	switch equed {
+	case lapack.RowEquilibration:
+		info.R = r
+	case lapack.ColumnEquilibration:
+		info.C = cs
+	case lapack.RowColEquilibration:
+		info.R = r
+		info.C = cs
+	}
+	if !ok {
+		return info, Condition(math.Inf(1))
+	}
+	if cond := 1 / rcond; cond > ConditionTolerance {
+		return info, Condition(cond)
+	}
+	return info, nil
+}
+
+// SolveSymRefined solves the system of linear equations A * X = B, where A is
+// symmetric positive definite, using the Cholesky factorization of A, storing X
+// in the receiver. If equilibrate is true, A is scaled symmetrically before
+// factorization when it is badly scaled. The solution is improved by
+// iterative refinement, and the returned SolveInfo holds error bounds for it
+// along with the condition estimate and the scale factors that were used. When
+// A has been scaled, R and C in the returned SolveInfo hold the same factors.
+//
+// If A is not positive definite, ErrNotPSD is returned and the receiver does
+// not contain a solution. If A is near-singular, a Condition error is returned
+// but the solution is computed. Please see the documentation for Condition for
+// more information.
+func (m *Dense) SolveSymRefined(a Symmetric, b Matrix, equilibrate bool) (SolveInfo, error) {
+	n := a.Symmetric()
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+
+	aw := getWorkspaceSym(n, false)
+	defer putWorkspaceSym(aw)
+	aw.CopySym(a)
+	bw := getWorkspace(n, bc, false)
+	defer putWorkspace(bw)
+	bw.Copy(b)
+	m.reuseAs(n, bc)
+
+	work := getFloats(3*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+
+	info := SolveInfo{
+		FErr: make([]float64, bc),
+		BErr: make([]float64, bc),
+	}
+
+	// Equilibrate A and B if requested and worth doing.
+	var s []float64
+	scond := 1.0
+	if equilibrate {
+		s = make([]float64, n)
+		var amax float64
+		var ok bool
+		scond, amax, ok = lapack64.Poequ(aw.mat, s)
+		if !ok {
+			return info, ErrNotPSD
+		}
+		if lapack64.Laqsy(aw.mat, s, scond, amax) == lapack.NoEquilibration {
+			s = nil
+		} else {
+			for i, v := range s {
+				row := bw.mat.Data[i*bw.mat.Stride : i*bw.mat.Stride+bc]
+				for j := range row {
+					row[j] *= v
+				}
+			}
+			info.R = s
+			info.C = s
+		}
+	}
+
+	// Compute the Cholesky factorization of the equilibrated A.
+	af := getWorkspaceSym(n, false)
+	defer putWorkspaceSym(af)
+	af.CopySym(aw)
+	anorm := lapack64.Lansy(CondNorm, aw.mat, work)
+	t, ok := lapack64.Potrf(af.mat)
+	if !ok {
+		return info, ErrNotPSD
+	}
+	info.RCond = lapack64.Pocon(af.mat, anorm, work, iwork)
+
+	// Solve and refine.
+	m.Copy(bw)
+	lapack64.Potrs(t, m.mat)
+	lapack64.Porfs(aw.mat, t, bw.mat, m.mat, info.FErr, info.BErr, work, iwork)
+
+	// Transform the solution to that of the original system.
+	if s != nil {
+		for i, v := range s {
+			row := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+bc]
+			for j := range row {
+				row[j] *= v
+			}
+		}
+		for j := range info.FErr {
+			info.FErr[j] /= scond
+		}
+	}
+	if cond := 1 / info.RCond; cond > ConditionTolerance {
+		return info, Condition(cond)
+	}
+	return info, nil
+}
@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -294,3 +295,182 @@ func TestSolveVec(t *testing.T) {
 	}
 	testTwoInput(t, "SolveVec", &VecDense{}, method, denseComparison, legalTypesNotVecVec, legalSizeSolve, 1e-12)
 }
+
+func TestSolveRefined(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, bc int
+	}{
+		{1, 1},
+		{3, 1},
+		{5, 5},
+		{10, 3},
+		{20, 1},
+	} {
+		n := test.n
+		bc := test.bc
+		for _, equilibrate := range []bool{false, true} {
+			// Construct a badly scaled matrix.
+			a := NewDense(n, n, nil)
+			rs := make([]float64, n)
+			cs := make([]float64, n)
+			for i := range rs {
+				rs[i] = math.Pow(10, float64(rnd.Intn(11)-5))
+				cs[i] = math.Pow(10, float64(rnd.Intn(11)-5))
+			}
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					a.Set(i, j, rs[i]*rnd.NormFloat64()*cs[j])
+				}
+			}
+			want := NewDense(n, bc, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < bc; j++ {
+					want.Set(i, j, rnd.NormFloat64())
+				}
+			}
+			var b Dense
+			b.Mul(a, want)
+
+			var x Dense
+			info, err := x.SolveRefined(a, &b, equilibrate)
+			if err != nil {
+				// Without scaling, the condition number of A may
+				// be large enough to be reported.
+				if _, ok := err.(Condition); equilibrate || !ok {
+					t.Errorf("unexpected error for n=%d, equilibrate=%t: %v", n, equilibrate, err)
+					continue
+				}
+			}
+			if equilibrate && info.RCond < 1e-8 {
+				t.Errorf("unexpected small reciprocal condition number after equilibration for n=%d: %v", n, info.RCond)
+			}
+			if !equilibrate && (info.R != nil || info.C != nil) {
+				t.Errorf("unexpected scaling for n=%d without equilibration", n)
+			}
+			if info.RCond <= 0 || info.RCond > 1 {
+				t.Errorf("unexpected reciprocal condition number for n=%d: %v", n, info.RCond)
+			}
+			if len(info.FErr) != bc || len(info.BErr) != bc {
+				t.Errorf("unexpected error estimate lengths for n=%d", n)
+				continue
+			}
+			for j := 0; j < bc; j++ {
+				if info.BErr[j] > 1e-14 {
+					t.Errorf("backward error too large for n=%d, column %d: %v", n, j, info.BErr[j])
+				}
+				var diff, xmax float64
+				for i := 0; i < n; i++ {
+					diff = math.Max(diff, math.Abs(x.At(i, j)-want.At(i, j)))
+					xmax = math.Max(xmax, math.Abs(x.At(i, j)))
+				}
+				if diff/xmax > info.FErr[j] {
+					t.Errorf("forward error bound exceeded for n=%d, column %d: error=%v, bound=%v", n, j, diff/xmax, info.FErr[j])
+				}
+			}
+		}
+	}
+
+	// Check that a singular matrix is reported.
+	a := NewDense(3, 3, []float64{
+		1, 2, 3,
+		4, 5, 6,
+		7, 8, 9,
+	})
+	a.Set(2, 0, 0)
+	a.Set(2, 1, 0)
+	a.Set(2, 2, 0)
+	b := NewDense(3, 1, []float64{1, 2, 3})
+	var x Dense
+	_, err := x.SolveRefined(a, b, true)
+	if c, ok := err.(Condition); !ok || !math.IsInf(float64(c), 1) {
+		t.Errorf("unexpected error for singular matrix: %v", err)
+	}
+}
+
+func TestSolveSymRefined(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, bc int
+	}{
+		{1, 1},
+		{3, 1},
+		{5, 5},
+		{10, 3},
+		{20, 1},
+	} {
+		n := test.n
+		bc := test.bc
+		for _, equilibrate := range []bool{false, true} {
+			// Construct a badly scaled positive definite matrix.
+			g := NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					g.Set(i, j, rnd.NormFloat64())
+				}
+			}
+			s := make([]float64, n)
+			for i := range s {
+				s[i] = math.Pow(10, float64(rnd.Intn(7)-3))
+			}
+			a := NewSymDense(n, nil)
+			for i := 0; i < n; i++ {
+				for j := i; j < n; j++ {
+					var v float64
+					for k := 0; k < n; k++ {
+						v += g.At(k, i) * g.At(k, j)
+					}
+					if i == j {
+						v += float64(n)
+					}
+					a.SetSym(i, j, s[i]*v*s[j])
+				}
+			}
+			want := NewDense(n, bc, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < bc; j++ {
+					want.Set(i, j, rnd.NormFloat64())
+				}
+			}
+			var b Dense
+			b.Mul(a, want)
+
+			var x Dense
+			info, err := x.SolveSymRefined(a, &b, equilibrate)
+			if err != nil {
+				t.Errorf("unexpected error for n=%d, equilibrate=%t: %v", n, equilibrate, err)
+				continue
+			}
+			if !equilibrate && (info.R != nil || info.C != nil) {
+				t.Errorf("unexpected scaling for n=%d without equilibration", n)
+			}
+			for j := 0; j < bc; j++ {
+				if info.BErr[j] > 1e-14 {
+					t.Errorf("backward error too large for n=%d, column %d: %v", n, j, info.BErr[j])
+				}
+				var diff, xmax float64
+				for i := 0; i < n; i++ {
+					diff = math.Max(diff, math.Abs(x.At(i, j)-want.At(i, j)))
+					xmax = math.Max(xmax, math.Abs(x.At(i, j)))
+				}
+				if diff/xmax > info.FErr[j] {
+					t.Errorf("forward error bound exceeded for n=%d, column %d: error=%v, bound=%v", n, j, diff/xmax, info.FErr[j])
+				}
+			}
+		}
+	}
+
+	// Check that an indefinite matrix is reported.
+	a := NewSymDense(2, []float64{
+		1, 2,
+		2, 1,
+	})
+	b := NewDense(2, 1, []float64{1, 2})
+	var x Dense
+	for _, equilibrate := range []bool{false, true} {
+		_, err := x.SolveSymRefined(a, b, equilibrate)
+		if err != ErrNotPSD {
+			t.Errorf("unexpected error for indefinite matrix with equilibrate=%t: %v", equilibrate, err)
+		}
+	}
+}
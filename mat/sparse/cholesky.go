@@ -0,0 +1,375 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import "math"
+
+// SymbolicCholesky is the symbolic analysis of a sparse Cholesky
+// factorization. It holds the fill-reducing ordering, the elimination tree
+// and the column counts of the Cholesky factor and depends only on the
+// pattern of the upper triangle of the analyzed matrix.
+type SymbolicCholesky struct {
+	n      int
+	perm   []int // perm[k] is the row and column of A in position k.
+	pinv   []int // pinv is the inverse of perm.
+	parent []int // parent is the elimination tree of P*A*P^T.
+	colPtr []int // colPtr and rowIdx are the pattern of L.
+	rowIdx []int
+}
+
+// NewSymbolicCholesky computes the symbolic Cholesky factorization of the
+// square matrix a using the provided ordering. Only the upper triangle of a
+// is used. NewSymbolicCholesky will panic if a is not square.
+func NewSymbolicCholesky(a *CSC, ord Ordering) *SymbolicCholesky {
+	if a.rows != a.cols {
+		panic(badSquare)
+	}
+	n := a.cols
+	perm := symmetricOrder(ord, a)
+	pinv := make([]int, n)
+	for k, i := range perm {
+		pinv[i] = k
+	}
+	s := &SymbolicCholesky{n: n, perm: perm, pinv: pinv}
+
+	c := permuteTriangle(a, pinv, false, false)
+	s.parent = etree(c)
+
+	// Count the entries in each column of L by traversing the row subtrees
+	// of the elimination tree.
+	counts := make([]int, n)
+	mark := make([]int, n)
+	stack := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for k := 0; k < n; k++ {
+		counts[k]++
+		top := ereach(c, k, s.parent, mark, stack)
+		for _, j := range stack[top:] {
+			counts[j]++
+		}
+	}
+	s.colPtr = make([]int, n+1)
+	for j, cnt := range counts {
+		s.colPtr[j+1] = s.colPtr[j] + cnt
+	}
+
+	// Fill in the row indices of L column by column. Traversing the row
+	// subtrees in increasing order of k leaves the diagonal first and the
+	// row indices of each column sorted.
+	s.rowIdx = make([]int, s.colPtr[n])
+	next := counts[:n]
+	copy(next, s.colPtr[:n])
+	for i := range mark {
+		mark[i] = -1
+	}
+	for k := 0; k < n; k++ {
+		s.rowIdx[next[k]] = k
+		next[k]++
+		top := ereach(c, k, s.parent, mark, stack)
+		for _, j := range stack[top:] {
+			s.rowIdx[next[j]] = k
+			next[j]++
+		}
+	}
+	return s
+}
+
+// Size returns the dimension of the analyzed matrix.
+func (s *SymbolicCholesky) Size() int {
+	return s.n
+}
+
+// NNZ returns the number of entries in the Cholesky factor L, including
+// the diagonal.
+func (s *SymbolicCholesky) NNZ() int {
+	return s.colPtr[s.n]
+}
+
+// Perm returns the fill-reducing permutation. Row and column k of P*A*P^T
+// are row and column perm[k] of A. If perm is nil, new memory will be
+// allocated, otherwise the length of the input must be equal to the size of
+// the analyzed matrix.
+func (s *SymbolicCholesky) Perm(perm []int) []int {
+	if perm == nil {
+		perm = make([]int, s.n)
+	}
+	if len(perm) != s.n {
+		panic(badSliceLen)
+	}
+	copy(perm, s.perm)
+	return perm
+}
+
+// Cholesky is a sparse Cholesky factorization of a symmetric positive
+// definite matrix A. The factorization has the form
+//  P * A * P^T = L * L^T
+// where P is the fill-reducing permutation of the symbolic analysis and L is
+// a sparse lower triangular matrix.
+type Cholesky struct {
+	sym *SymbolicCholesky
+	l   *CSC
+}
+
+// Factorize computes the numeric Cholesky factorization of the symmetric
+// positive definite matrix a using the symbolic analysis sym, which must
+// have been computed from a matrix with the same pattern as a. Only the
+// upper triangle of a is used. Factorize returns whether the factorization
+// succeeded. If it returns false, a is not positive definite and the
+// receiver must not be used for solving.
+//
+// Factorize computes the factor one column at a time by the left-looking
+// method. Column j of L is updated by each column k < j for which L[j,k] is
+// non-zero; these are the descendants of j in the elimination tree that lie
+// in the row subtree of j.
+func (c *Cholesky) Factorize(a *CSC, sym *SymbolicCholesky) (ok bool) {
+	n := sym.n
+	if a.rows != n || a.cols != n {
+		panic(badPattern)
+	}
+	c.sym = sym
+	nnz := sym.NNZ()
+	if c.l == nil || cap(c.l.data) < nnz {
+		c.l = &CSC{data: make([]float64, nnz)}
+	}
+	l := c.l
+	l.rows, l.cols = n, n
+	l.colPtr = sym.colPtr
+	l.rowIdx = sym.rowIdx
+	l.data = l.data[:nnz]
+
+	up := permuteTriangle(a, sym.pinv, false, false)
+	lo := permuteTriangle(a, sym.pinv, true, true)
+	var (
+		x     = make([]float64, n)
+		mark  = make([]int, n)
+		stack = make([]int, n)
+		next  = make([]int, n) // next[k] is the position of the next row of column k to update with.
+	)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for j := 0; j < n; j++ {
+		// Scatter the lower part of column j into x.
+		for p := lo.colPtr[j]; p < lo.colPtr[j+1]; p++ {
+			x[lo.rowIdx[p]] += lo.data[p]
+		}
+
+		// Subtract L[j:,k] * L[j,k] for each column k in the pattern
+		// of row j of L.
+		top := ereach(up, j, sym.parent, mark, stack)
+		for _, k := range stack[top:] {
+			p := next[k]
+			if p >= sym.colPtr[k+1] || l.rowIdx[p] != j {
+				panic(badPattern)
+			}
+			ljk := l.data[p]
+			for ; p < sym.colPtr[k+1]; p++ {
+				x[l.rowIdx[p]] -= l.data[p] * ljk
+			}
+			next[k]++
+		}
+
+		// Scale column j by the square root of its diagonal.
+		p := sym.colPtr[j]
+		d := x[j]
+		x[j] = 0
+		if d <= 0 || math.IsNaN(d) {
+			c.l = nil
+			return false
+		}
+		ljj := math.Sqrt(d)
+		l.data[p] = ljj
+		for p++; p < sym.colPtr[j+1]; p++ {
+			i := l.rowIdx[p]
+			l.data[p] = x[i] / ljj
+			x[i] = 0
+		}
+		next[j] = sym.colPtr[j] + 1
+	}
+	return true
+}
+
+// L returns the lower triangular Cholesky factor of P*A*P^T. The returned
+// matrix shares storage with the receiver and must not be modified.
+func (c *Cholesky) L() *CSC {
+	if c.l == nil {
+		panic(badFactorized)
+	}
+	return c.l
+}
+
+// LogDet returns the log of the determinant of the factorized matrix.
+func (c *Cholesky) LogDet() float64 {
+	if c.l == nil {
+		panic(badFactorized)
+	}
+	var det float64
+	for j := 0; j < c.sym.n; j++ {
+		det += 2 * math.Log(c.l.data[c.l.colPtr[j]])
+	}
+	return det
+}
+
+// SolveTo solves A * x = b where A is the factorized matrix, placing the
+// result in dst. dst and b must have length equal to the size of A. dst and
+// b may be the same slice.
+func (c *Cholesky) SolveTo(dst, b []float64) {
+	if c.l == nil {
+		panic(badFactorized)
+	}
+	n := c.sym.n
+	if len(dst) != n || len(b) != n {
+		panic(badSliceLen)
+	}
+	l := c.l
+	y := make([]float64, n)
+	for k, i := range c.sym.perm {
+		y[k] = b[i]
+	}
+	// Solve L * z = y.
+	for j := 0; j < n; j++ {
+		p := l.colPtr[j]
+		y[j] /= l.data[p]
+		for p++; p < l.colPtr[j+1]; p++ {
+			y[l.rowIdx[p]] -= l.data[p] * y[j]
+		}
+	}
+	// Solve L^T * y = z.
+	for j := n - 1; j >= 0; j-- {
+		p := l.colPtr[j]
+		v := y[j]
+		for q := p + 1; q < l.colPtr[j+1]; q++ {
+			v -= l.data[q] * y[l.rowIdx[q]]
+		}
+		y[j] = v / l.data[p]
+	}
+	for k, i := range c.sym.perm {
+		dst[i] = y[k]
+	}
+}
+
+// permuteTriangle returns the upper triangle of P*A*P^T, or the lower
+// triangle if lower is true, where P is the permutation with inverse pinv
+// and A is the symmetric matrix given by the upper triangle of a. The row
+// indices of the returned matrix are not sorted. If values is false only
+// the pattern is computed.
+func permuteTriangle(a *CSC, pinv []int, lower, values bool) *CSC {
+	n := a.cols
+	colPtr := make([]int, n+1)
+	for j := 0; j < n; j++ {
+		for p := a.colPtr[j]; p < a.colPtr[j+1]; p++ {
+			i := a.rowIdx[p]
+			if i > j {
+				continue
+			}
+			col := max(pinv[i], pinv[j])
+			if lower {
+				col = min(pinv[i], pinv[j])
+			}
+			colPtr[col+1]++
+		}
+	}
+	for j := 0; j < n; j++ {
+		colPtr[j+1] += colPtr[j]
+	}
+	next := make([]int, n)
+	copy(next, colPtr)
+	rowIdx := make([]int, colPtr[n])
+	var data []float64
+	if values {
+		data = make([]float64, colPtr[n])
+	}
+	for j := 0; j < n; j++ {
+		for p := a.colPtr[j]; p < a.colPtr[j+1]; p++ {
+			i := a.rowIdx[p]
+			if i > j {
+				continue
+			}
+			i2, j2 := pinv[i], pinv[j]
+			if (i2 > j2) != lower {
+				i2, j2 = j2, i2
+			}
+			q := next[j2]
+			rowIdx[q] = i2
+			if values {
+				data[q] = a.data[p]
+			}
+			next[j2]++
+		}
+	}
+	return &CSC{rows: n, cols: n, colPtr: colPtr, rowIdx: rowIdx, data: data}
+}
+
+// etree returns the elimination tree of the symmetric matrix whose pattern
+// is given by the upper triangle of a. The parent of a root is -1.
+func etree(a *CSC) []int {
+	n := a.cols
+	parent := make([]int, n)
+	ancestor := make([]int, n)
+	for k := 0; k < n; k++ {
+		parent[k] = -1
+		ancestor[k] = -1
+		for p := a.colPtr[k]; p < a.colPtr[k+1]; p++ {
+			// Walk from i to the root of its current subtree,
+			// compressing the path to k.
+			for i := a.rowIdx[p]; i != -1 && i < k; {
+				inext := ancestor[i]
+				ancestor[i] = k
+				if inext == -1 {
+					parent[i] = k
+				}
+				i = inext
+			}
+		}
+	}
+	return parent
+}
+
+// ereach computes the pattern of row k of the Cholesky factor of the
+// symmetric matrix whose pattern is given by the upper triangle of a, using
+// the elimination tree parent. The pattern is returned in stack[top:] in
+// topological order, so that each column precedes its ancestors. Entries of
+// mark equal to k are overwritten, and mark must not otherwise contain k.
+func ereach(a *CSC, k int, parent, mark, stack []int) (top int) {
+	n := a.cols
+	top = n
+	mark[k] = k
+	for p := a.colPtr[k]; p < a.colPtr[k+1]; p++ {
+		i := a.rowIdx[p]
+		if i > k {
+			continue
+		}
+		// Collect the path from i to the first marked ancestor at the
+		// bottom of the stack and then move it to the top.
+		var length int
+		for ; mark[i] != k; i = parent[i] {
+			stack[length] = i
+			length++
+			mark[i] = k
+		}
+		for length > 0 {
+			length--
+			top--
+			stack[top] = stack[length]
+		}
+	}
+	return top
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,227 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		a    *CSC
+	}{
+		{name: "laplacian 1", a: laplacian2D(1)},
+		{name: "laplacian 5", a: laplacian2D(5)},
+		{name: "laplacian 12", a: laplacian2D(12)},
+		{name: "random 10", a: randomDiagDominant(rnd, 10, 0.3, true)},
+		{name: "random 50", a: randomDiagDominant(rnd, 50, 0.05, true)},
+	} {
+		n, _ := test.a.Dims()
+		dense := mat.DenseCopyOf(test.a)
+		var want mat.Cholesky
+		if !want.Factorize(mat.NewSymDense(n, dense.RawMatrix().Data)) {
+			t.Fatalf("%s: dense factorization failed", test.name)
+		}
+		b := make([]float64, n)
+		for i := range b {
+			b[i] = rnd.NormFloat64()
+		}
+		var xWant mat.VecDense
+		xWant.SolveVec(dense, mat.NewVecDense(n, b))
+
+		for _, ord := range []Ordering{Natural, MinDegree} {
+			sym := NewSymbolicCholesky(test.a, ord)
+			if !isPermutation(sym.Perm(nil)) {
+				t.Errorf("%s ordering %d: invalid permutation %v", test.name, ord, sym.Perm(nil))
+			}
+			var c Cholesky
+			if !c.Factorize(test.a, sym) {
+				t.Errorf("%s ordering %d: unexpected factorization failure", test.name, ord)
+				continue
+			}
+			if got := c.L().NNZ(); got != sym.NNZ() {
+				t.Errorf("%s ordering %d: mismatched factor size: got %d want %d", test.name, ord, got, sym.NNZ())
+			}
+			if !sortedLower(c.L()) {
+				t.Errorf("%s ordering %d: factor columns not sorted with the diagonal first", test.name, ord)
+			}
+
+			// Check P*A*P^T = L*L^T.
+			perm := sym.Perm(nil)
+			pa := mat.NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					pa.Set(i, j, dense.At(perm[i], perm[j]))
+				}
+			}
+			l := mat.DenseCopyOf(c.L())
+			var llt mat.Dense
+			llt.Mul(l, l.T())
+			if !mat.EqualApprox(&llt, pa, 1e-12) {
+				t.Errorf("%s ordering %d: P*A*P^T != L*L^T", test.name, ord)
+			}
+
+			got := make([]float64, n)
+			c.SolveTo(got, b)
+			if !floats.EqualApprox(got, xWant.RawVector().Data, 1e-10) {
+				t.Errorf("%s ordering %d: unexpected solution", test.name, ord)
+			}
+			if got, want := c.LogDet(), want.LogDet(); math.Abs(got-want) > 1e-10*math.Max(1, math.Abs(want)) {
+				t.Errorf("%s ordering %d: unexpected log determinant: got %v want %v", test.name, ord, got, want)
+			}
+		}
+	}
+}
+
+// sortedLower returns whether each column j of the lower triangular matrix
+// l starts with the diagonal and has strictly increasing row indices.
+func sortedLower(l *CSC) bool {
+	for j := 0; j < l.cols; j++ {
+		lo, hi := l.colPtr[j], l.colPtr[j+1]
+		if lo == hi || l.rowIdx[lo] != j {
+			return false
+		}
+		for p := lo + 1; p < hi; p++ {
+			if l.rowIdx[p] <= l.rowIdx[p-1] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestCholeskyNotPD(t *testing.T) {
+	tr := NewTriplet(3, 3)
+	tr.Append(0, 0, 1)
+	tr.Append(0, 1, 2)
+	tr.Append(1, 0, 2)
+	tr.Append(1, 1, 1)
+	tr.Append(2, 2, 1)
+	a := tr.CSC()
+	var c Cholesky
+	if c.Factorize(a, NewSymbolicCholesky(a, MinDegree)) {
+		t.Errorf("expected failure for indefinite matrix")
+	}
+}
+
+func TestCholeskyReuseSymbolic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := laplacian2D(6)
+	n, _ := a.Dims()
+	sym := NewSymbolicCholesky(a, MinDegree)
+	var c Cholesky
+	for trial := 0; trial < 3; trial++ {
+		// Scale the values without changing the pattern.
+		s := &CSC{rows: n, cols: n, colPtr: a.colPtr, rowIdx: a.rowIdx, data: make([]float64, len(a.data))}
+		f := 1 + rnd.Float64()
+		copy(s.data, a.data)
+		floats.Scale(f, s.data)
+		if !c.Factorize(s, sym) {
+			t.Fatalf("unexpected factorization failure")
+		}
+		b := make([]float64, n)
+		for i := range b {
+			b[i] = rnd.NormFloat64()
+		}
+		x := make([]float64, n)
+		c.SolveTo(x, b)
+		ax := make([]float64, n)
+		s.MulVecTo(ax, x)
+		if !floats.EqualApprox(ax, b, 1e-12) {
+			t.Errorf("trial %d: unexpected residual", trial)
+		}
+	}
+}
+
+func TestMinDegreeFill(t *testing.T) {
+	for _, m := range []int{10, 20, 30} {
+		a := laplacian2D(m)
+		natural := NewSymbolicCholesky(a, Natural).NNZ()
+		amd := NewSymbolicCholesky(a, MinDegree).NNZ()
+		if amd >= natural {
+			t.Errorf("minimum degree fill not less than natural fill on %d×%d grid: %d >= %d", m, m, amd, natural)
+		}
+	}
+}
+
+func TestMinDegreeNoFill(t *testing.T) {
+	// Each of these graphs has an elimination order with no fill, found
+	// by eliminating vertices of degree one, so the factor has exactly one
+	// entry for each vertex and one for each edge.
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name  string
+		n     int
+		edges func(n int) [][2]int
+	}{
+		{
+			name: "arrow",
+			n:    20,
+			edges: func(n int) [][2]int {
+				var e [][2]int
+				for i := 1; i < n; i++ {
+					e = append(e, [2]int{0, i})
+				}
+				return e
+			},
+		},
+		{
+			name: "permuted path",
+			n:    50,
+			edges: func(n int) [][2]int {
+				p := rnd.Perm(n)
+				var e [][2]int
+				for i := 1; i < n; i++ {
+					e = append(e, [2]int{p[i-1], p[i]})
+				}
+				return e
+			},
+		},
+		{
+			name: "random tree",
+			n:    100,
+			edges: func(n int) [][2]int {
+				var e [][2]int
+				for i := 1; i < n; i++ {
+					e = append(e, [2]int{rnd.Intn(i), i})
+				}
+				return e
+			},
+		},
+	} {
+		edges := test.edges(test.n)
+		tr := NewTriplet(test.n, test.n)
+		for i := 0; i < test.n; i++ {
+			tr.Append(i, i, float64(test.n))
+		}
+		for _, e := range edges {
+			tr.Append(e[0], e[1], -1)
+			tr.Append(e[1], e[0], -1)
+		}
+		a := tr.CSC()
+		want := test.n + len(edges)
+		if got := NewSymbolicCholesky(a, MinDegree).NNZ(); got != want {
+			t.Errorf("%s: unexpected factor size with minimum degree ordering: got %d want %d", test.name, got, want)
+		}
+	}
+}
+
+func isPermutation(p []int) bool {
+	seen := make([]bool, len(p))
+	for _, v := range p {
+		if v < 0 || len(p) <= v || seen[v] {
+			return false
+		}
+		seen[v] = true
+	}
+	return true
+}
@@ -0,0 +1,248 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	badIndex      = "sparse: index out of range"
+	badStructure  = "sparse: invalid compressed column structure"
+	badDimension  = "sparse: invalid dimension"
+	badSquare     = "sparse: matrix is not square"
+	badPattern    = "sparse: matrix pattern does not match symbolic analysis"
+	badSliceLen   = "sparse: bad slice length"
+	badFactorized = "sparse: matrix not factorized"
+)
+
+var _ mat.Matrix = (*CSC)(nil)
+
+// CSC is a sparse matrix in compressed sparse column format. The row indices
+// of the entries in column j are held in rowIdx[colPtr[j]:colPtr[j+1]] in
+// increasing order, and the corresponding values in the same range of data.
+type CSC struct {
+	rows, cols int
+	colPtr     []int
+	rowIdx     []int
+	data       []float64
+}
+
+// NewCSC returns a new r×c CSC matrix using the provided compressed column
+// structure. The slices are used directly as the backing data of the matrix.
+// colPtr must have length c+1 with colPtr[0] == 0 and be non-decreasing, and
+// rowIdx and data must have length colPtr[c]. The row indices within each
+// column must be strictly increasing and less than r. NewCSC will panic if
+// these conditions are not met.
+func NewCSC(r, c int, colPtr, rowIdx []int, data []float64) *CSC {
+	if r < 0 || c < 0 {
+		panic(badDimension)
+	}
+	if len(colPtr) != c+1 || colPtr[0] != 0 {
+		panic(badStructure)
+	}
+	nnz := colPtr[c]
+	if len(rowIdx) != nnz || len(data) != nnz {
+		panic(badStructure)
+	}
+	for j := 0; j < c; j++ {
+		if colPtr[j] > colPtr[j+1] {
+			panic(badStructure)
+		}
+		for p := colPtr[j]; p < colPtr[j+1]; p++ {
+			i := rowIdx[p]
+			if i < 0 || r <= i || (p > colPtr[j] && i <= rowIdx[p-1]) {
+				panic(badStructure)
+			}
+		}
+	}
+	return &CSC{rows: r, cols: c, colPtr: colPtr, rowIdx: rowIdx, data: data}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSC) Dims() (r, c int) {
+	return m.rows, m.cols
+}
+
+// At returns the element of the matrix at row i and column j.
+func (m *CSC) At(i, j int) float64 {
+	if uint(i) >= uint(m.rows) || uint(j) >= uint(m.cols) {
+		panic(badIndex)
+	}
+	lo, hi := m.colPtr[j], m.colPtr[j+1]
+	p := lo + sort.SearchInts(m.rowIdx[lo:hi], i)
+	if p < hi && m.rowIdx[p] == i {
+		return m.data[p]
+	}
+	return 0
+}
+
+// T performs an implicit transpose by returning the receiver inside a
+// Transpose.
+func (m *CSC) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
+// NNZ returns the number of stored entries in the matrix.
+func (m *CSC) NNZ() int {
+	return m.colPtr[m.cols]
+}
+
+// MulVecTo computes dst = A * x, where A is the receiver. dst must have
+// length equal to the number of rows of A and x must have length equal to
+// the number of columns of A, otherwise MulVecTo will panic. dst and x must
+// not overlap.
+func (m *CSC) MulVecTo(dst, x []float64) {
+	if len(dst) != m.rows || len(x) != m.cols {
+		panic(badSliceLen)
+	}
+	for i := range dst {
+		dst[i] = 0
+	}
+	for j, xj := range x {
+		if xj == 0 {
+			continue
+		}
+		for p := m.colPtr[j]; p < m.colPtr[j+1]; p++ {
+			dst[m.rowIdx[p]] += m.data[p] * xj
+		}
+	}
+}
+
+// Triplet is a sparse matrix in coordinate format. It is used to assemble a
+// matrix entry by entry before conversion to compressed column format.
+type Triplet struct {
+	rows, cols int
+	i, j       []int
+	v          []float64
+}
+
+// NewTriplet returns a new empty r×c Triplet matrix.
+func NewTriplet(r, c int) *Triplet {
+	if r < 0 || c < 0 {
+		panic(badDimension)
+	}
+	return &Triplet{rows: r, cols: c}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (t *Triplet) Dims() (r, c int) {
+	return t.rows, t.cols
+}
+
+// Append adds v to the element at row i and column j. Entries appended more
+// than once at the same position are summed.
+func (t *Triplet) Append(i, j int, v float64) {
+	if uint(i) >= uint(t.rows) || uint(j) >= uint(t.cols) {
+		panic(badIndex)
+	}
+	t.i = append(t.i, i)
+	t.j = append(t.j, j)
+	t.v = append(t.v, v)
+}
+
+// CSC returns the matrix converted to compressed sparse column format.
+// Duplicate entries are summed. Explicitly stored zeros are kept.
+func (t *Triplet) CSC() *CSC {
+	// Count the entries in each row and bucket them by row, then scatter the
+	// row-sorted entries into columns so that each column is sorted by row.
+	rowPtr := make([]int, t.rows+1)
+	for _, i := range t.i {
+		rowPtr[i+1]++
+	}
+	for i := 0; i < t.rows; i++ {
+		rowPtr[i+1] += rowPtr[i]
+	}
+	next := make([]int, t.rows)
+	copy(next, rowPtr)
+	byRow := make([]int, len(t.i))
+	for k, i := range t.i {
+		byRow[next[i]] = k
+		next[i]++
+	}
+
+	colPtr := make([]int, t.cols+1)
+	for _, j := range t.j {
+		colPtr[j+1]++
+	}
+	for j := 0; j < t.cols; j++ {
+		colPtr[j+1] += colPtr[j]
+	}
+	next = make([]int, t.cols)
+	copy(next, colPtr)
+	rowIdx := make([]int, len(t.i))
+	data := make([]float64, len(t.i))
+	for _, k := range byRow {
+		j := t.j[k]
+		p := next[j]
+		rowIdx[p] = t.i[k]
+		data[p] = t.v[k]
+		next[j]++
+	}
+
+	// Sum duplicates and compact in place.
+	var nnz int
+	for j := 0; j < t.cols; j++ {
+		start := nnz
+		for p := colPtr[j]; p < colPtr[j+1]; p++ {
+			if nnz > start && rowIdx[nnz-1] == rowIdx[p] {
+				data[nnz-1] += data[p]
+				continue
+			}
+			rowIdx[nnz] = rowIdx[p]
+			data[nnz] = data[p]
+			nnz++
+		}
+		colPtr[j] = start
+	}
+	colPtr[t.cols] = nnz
+	return &CSC{rows: t.rows, cols: t.cols, colPtr: colPtr, rowIdx: rowIdx[:nnz:nnz], data: data[:nnz:nnz]}
+}
+
+// transposePattern returns the column pointers and row indices of the
+// pattern of the transpose of m.
+func (m *CSC) transposePattern() (colPtr, rowIdx []int) {
+	colPtr = make([]int, m.rows+1)
+	for _, i := range m.rowIdx[:m.NNZ()] {
+		colPtr[i+1]++
+	}
+	for i := 0; i < m.rows; i++ {
+		colPtr[i+1] += colPtr[i]
+	}
+	next := make([]int, m.rows)
+	copy(next, colPtr)
+	rowIdx = make([]int, m.NNZ())
+	for j := 0; j < m.cols; j++ {
+		for p := m.colPtr[j]; p < m.colPtr[j+1]; p++ {
+			i := m.rowIdx[p]
+			rowIdx[next[i]] = j
+			next[i]++
+		}
+	}
+	return colPtr, rowIdx
+}
+
+// sortColumns sorts the entries of each column of m by row index.
+func (m *CSC) sortColumns() {
+	for j := 0; j < m.cols; j++ {
+		lo, hi := m.colPtr[j], m.colPtr[j+1]
+		sort.Sort(column{rowIdx: m.rowIdx[lo:hi], data: m.data[lo:hi]})
+	}
+}
+
+// column is a sort.Interface for the entries of a column of a CSC matrix.
+type column struct {
+	rowIdx []int
+	data   []float64
+}
+
+func (c column) Len() int           { return len(c.rowIdx) }
+func (c column) Less(i, j int) bool { return c.rowIdx[i] < c.rowIdx[j] }
+func (c column) Swap(i, j int) {
+	c.rowIdx[i], c.rowIdx[j] = c.rowIdx[j], c.rowIdx[i]
+	c.data[i], c.data[j] = c.data[j], c.data[i]
+}
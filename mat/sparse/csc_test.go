@@ -0,0 +1,159 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestTriplet(t *testing.T) {
+	for _, test := range []struct {
+		r, c    int
+		entries [][3]float64
+		want    *mat.Dense
+	}{
+		{
+			r: 3, c: 2,
+			entries: [][3]float64{{2, 1, 4}, {0, 0, 1}, {2, 1, 1}, {1, 0, -2}},
+			want:    mat.NewDense(3, 2, []float64{1, 0, -2, 0, 0, 5}),
+		},
+		{
+			r: 2, c: 3,
+			entries: [][3]float64{{1, 2, 3}, {0, 2, 7}, {1, 2, -3}},
+			want:    mat.NewDense(2, 3, []float64{0, 0, 7, 0, 0, 0}),
+		},
+	} {
+		tr := NewTriplet(test.r, test.c)
+		for _, e := range test.entries {
+			tr.Append(int(e[0]), int(e[1]), e[2])
+		}
+		m := tr.CSC()
+		if !mat.Equal(m, test.want) {
+			t.Errorf("unexpected matrix: got\n%v\nwant\n%v", mat.Formatted(m), mat.Formatted(test.want))
+		}
+		// Validate the structure.
+		NewCSC(m.rows, m.cols, m.colPtr, m.rowIdx, m.data)
+	}
+}
+
+func TestCSCMulVecTo(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ r, c int }{{1, 1}, {5, 3}, {3, 7}, {20, 20}} {
+		a := randomSparse(rnd, test.r, test.c, 0.3)
+		x := make([]float64, test.c)
+		for i := range x {
+			x[i] = rnd.NormFloat64()
+		}
+		got := make([]float64, test.r)
+		a.MulVecTo(got, x)
+
+		var want mat.VecDense
+		want.MulVec(a, mat.NewVecDense(test.c, x))
+		if !floats.EqualApprox(got, want.RawVector().Data, 1e-14) {
+			t.Errorf("unexpected product for %d×%d: got %v want %v", test.r, test.c, got, want.RawVector().Data)
+		}
+	}
+}
+
+func TestNewCSCPanics(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		colPtr []int
+		rowIdx []int
+	}{
+		{name: "unsorted", colPtr: []int{0, 2, 2}, rowIdx: []int{1, 0}},
+		{name: "duplicate", colPtr: []int{0, 2, 2}, rowIdx: []int{1, 1}},
+		{name: "range", colPtr: []int{0, 1, 1}, rowIdx: []int{2}},
+		{name: "decreasing", colPtr: []int{0, 1, 0}, rowIdx: []int{0}},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for %s structure", test.name)
+				}
+			}()
+			NewCSC(2, 2, test.colPtr, test.rowIdx, make([]float64, len(test.rowIdx)))
+		}()
+	}
+}
+
+// randomSparse returns a random r×c matrix with each element non-zero with
+// the given probability.
+func randomSparse(rnd *rand.Rand, r, c int, density float64) *CSC {
+	t := NewTriplet(r, c)
+	for j := 0; j < c; j++ {
+		for i := 0; i < r; i++ {
+			if rnd.Float64() < density {
+				t.Append(i, j, rnd.NormFloat64())
+			}
+		}
+	}
+	return t.CSC()
+}
+
+// laplacian2D returns the five point finite difference Laplacian on an m×m
+// grid.
+func laplacian2D(m int) *CSC {
+	n := m * m
+	t := NewTriplet(n, n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			k := i*m + j
+			t.Append(k, k, 4)
+			if i > 0 {
+				t.Append(k, k-m, -1)
+			}
+			if i < m-1 {
+				t.Append(k, k+m, -1)
+			}
+			if j > 0 {
+				t.Append(k, k-1, -1)
+			}
+			if j < m-1 {
+				t.Append(k, k+1, -1)
+			}
+		}
+	}
+	return t.CSC()
+}
+
+// randomDiagDominant returns a random n×n strictly diagonally dominant
+// matrix. If sym is true the matrix is symmetric.
+func randomDiagDominant(rnd *rand.Rand, n int, density float64, sym bool) *CSC {
+	t := NewTriplet(n, n)
+	rowSum := make([]float64, n)
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			if i == j || rnd.Float64() >= density {
+				continue
+			}
+			if sym && i > j {
+				continue
+			}
+			v := rnd.NormFloat64()
+			t.Append(i, j, v)
+			rowSum[i] += abs(v)
+			if sym {
+				t.Append(j, i, v)
+				rowSum[j] += abs(v)
+			}
+		}
+	}
+	for i, s := range rowSum {
+		t.Append(i, i, s+1+rnd.Float64())
+	}
+	return t.CSC()
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,20 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sparse provides compressed sparse column matrices and direct
+// factorizations of them.
+//
+// Matrices are stored in compressed sparse column (CSC) format and are most
+// easily constructed by appending entries to a Triplet. The sparse Cholesky
+// and LU factorizations are computed in two phases. The symbolic phase
+// computes a fill-reducing ordering and the structure of the factors and
+// depends only on the sparsity pattern of the matrix, so it may be reused
+// for any number of matrices with the same pattern. The numeric phase
+// computes the values of the factors.
+//
+// The fill-reducing orderings are approximate minimum degree orderings
+// computed on a quotient graph. For the Cholesky factorization the ordering
+// is computed from the pattern of A, and for the LU factorization it is a
+// column ordering computed from the pattern of A^T*A without forming it.
+package sparse // import "gonum.org/v1/gonum/mat/sparse"
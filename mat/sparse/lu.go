@@ -0,0 +1,310 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import "math"
+
+// SymbolicLU is the symbolic analysis of a sparse LU factorization. It holds
+// the fill-reducing column ordering and depends only on the pattern of the
+// analyzed matrix.
+type SymbolicLU struct {
+	n   int
+	q   []int // q[k] is the column of A in position k.
+	nnz int   // nnz is the number of entries in the analyzed matrix.
+}
+
+// NewSymbolicLU computes the symbolic LU factorization of the square matrix a
+// using the provided column ordering. NewSymbolicLU will panic if a is not
+// square.
+func NewSymbolicLU(a *CSC, ord Ordering) *SymbolicLU {
+	if a.rows != a.cols {
+		panic(badSquare)
+	}
+	return &SymbolicLU{n: a.cols, q: columnOrder(ord, a), nnz: a.NNZ()}
+}
+
+// Size returns the dimension of the analyzed matrix.
+func (s *SymbolicLU) Size() int {
+	return s.n
+}
+
+// ColPerm returns the fill-reducing column permutation. Column k of A*Q is
+// column q[k] of A. If q is nil, new memory will be allocated, otherwise the
+// length of the input must be equal to the size of the analyzed matrix.
+func (s *SymbolicLU) ColPerm(q []int) []int {
+	if q == nil {
+		q = make([]int, s.n)
+	}
+	if len(q) != s.n {
+		panic(badSliceLen)
+	}
+	copy(q, s.q)
+	return q
+}
+
+// LU is a sparse LU factorization of a square matrix A. The factorization
+// has the form
+//  P * A * Q = L * U
+// where Q is the fill-reducing column permutation of the symbolic analysis,
+// P is a row permutation chosen by partial pivoting, L is a sparse unit lower
+// triangular matrix and U is a sparse upper triangular matrix.
+type LU struct {
+	sym  *SymbolicLU
+	pinv []int // pinv[i] is the position of row i of A in P*A.
+	l, u *CSC
+}
+
+// Factorize computes the numeric LU factorization of a using the symbolic
+// analysis sym, which must have been computed for a matrix of the same size
+// as a. Factorize returns whether the factorization succeeded. If it returns
+// false, a is singular and the receiver must not be used for solving.
+//
+// Factorize uses the left-looking method of Gilbert and Peierls, in which
+// each column of L and U is found by a sparse triangular solve with the
+// previously computed columns of L, followed by a choice of the pivot with
+// the largest magnitude in the remaining part of the column.
+func (f *LU) Factorize(a *CSC, sym *SymbolicLU) (ok bool) {
+	n := sym.n
+	if a.rows != n || a.cols != n {
+		panic(badPattern)
+	}
+	f.sym = sym
+	f.l, f.u = nil, nil
+
+	est := 4*sym.nnz + n
+	var (
+		lp   = make([]int, n+1)
+		li   = make([]int, 0, est)
+		lx   = make([]float64, 0, est)
+		up   = make([]int, n+1)
+		ui   = make([]int, 0, est)
+		ux   = make([]float64, 0, est)
+		pinv = make([]int, n)
+
+		x     = make([]float64, n)
+		mark  = make([]int, n)
+		stack = make([]int, n)
+		pos   = make([]int, n)
+		order = make([]int, n)
+	)
+	for i := range pinv {
+		pinv[i] = -1
+		mark[i] = -1
+	}
+	for k := 0; k < n; k++ {
+		lp[k] = len(li)
+		up[k] = len(ui)
+		col := sym.q[k]
+
+		// Solve L[:,:k] * x = A[:,q[k]] over the reach of the column.
+		top := reach(a, col, lp, li, pinv, k, mark, stack, pos, order)
+		for p := a.colPtr[col]; p < a.colPtr[col+1]; p++ {
+			x[a.rowIdx[p]] += a.data[p]
+		}
+		for _, j := range order[top:] {
+			c := pinv[j]
+			if c < 0 {
+				continue
+			}
+			// The diagonal of L is stored first and is one.
+			for p := lp[c] + 1; p < lp[c+1]; p++ {
+				x[li[p]] -= lx[p] * x[j]
+			}
+		}
+
+		// Choose the pivot and store the column of U.
+		ipiv := -1
+		var amax float64
+		for _, i := range order[top:] {
+			if pinv[i] < 0 {
+				if v := math.Abs(x[i]); v > amax || ipiv < 0 {
+					amax = v
+					ipiv = i
+				}
+			} else {
+				ui = append(ui, pinv[i])
+				ux = append(ux, x[i])
+			}
+		}
+		if ipiv < 0 || amax == 0 || math.IsNaN(amax) {
+			for _, i := range order[top:] {
+				x[i] = 0
+			}
+			return false
+		}
+		piv := x[ipiv]
+		ui = append(ui, k)
+		ux = append(ux, piv)
+		pinv[ipiv] = k
+
+		// Store the column of L with the unit diagonal first.
+		li = append(li, ipiv)
+		lx = append(lx, 1)
+		for _, i := range order[top:] {
+			if pinv[i] < 0 {
+				li = append(li, i)
+				lx = append(lx, x[i]/piv)
+			}
+			x[i] = 0
+		}
+	}
+	lp[n] = len(li)
+	up[n] = len(ui)
+
+	// Renumber the rows of L into pivot order.
+	for p, i := range li {
+		li[p] = pinv[i]
+	}
+	f.pinv = pinv
+	f.l = &CSC{rows: n, cols: n, colPtr: lp, rowIdx: li, data: lx}
+	f.u = &CSC{rows: n, cols: n, colPtr: up, rowIdx: ui, data: ux}
+	// Sorting the columns keeps the unit diagonal of L first and the
+	// diagonal of U last since they are the extreme rows of each column.
+	f.l.sortColumns()
+	f.u.sortColumns()
+	return true
+}
+
+// NNZ returns the total number of entries in the factors L and U, including
+// the diagonals.
+func (f *LU) NNZ() int {
+	if f.l == nil {
+		panic(badFactorized)
+	}
+	return f.l.NNZ() + f.u.NNZ()
+}
+
+// RowPerm returns the row permutation chosen by partial pivoting. Row k of
+// P*A is row p[k] of A. If p is nil, new memory will be allocated, otherwise
+// the length of the input must be equal to the size of the factorized matrix.
+func (f *LU) RowPerm(p []int) []int {
+	if f.l == nil {
+		panic(badFactorized)
+	}
+	n := f.sym.n
+	if p == nil {
+		p = make([]int, n)
+	}
+	if len(p) != n {
+		panic(badSliceLen)
+	}
+	for i, k := range f.pinv {
+		p[k] = i
+	}
+	return p
+}
+
+// SolveTo solves A * x = b, or A^T * x = b if trans is true, where A is the
+// factorized matrix, placing the result in dst. dst and b must have length
+// equal to the size of A. dst and b may be the same slice.
+func (f *LU) SolveTo(dst []float64, trans bool, b []float64) {
+	if f.l == nil {
+		panic(badFactorized)
+	}
+	n := f.sym.n
+	if len(dst) != n || len(b) != n {
+		panic(badSliceLen)
+	}
+	l, u := f.l, f.u
+	y := make([]float64, n)
+	if !trans {
+		for i, k := range f.pinv {
+			y[k] = b[i]
+		}
+		// Solve L * z = P * b.
+		for j := 0; j < n; j++ {
+			for p := l.colPtr[j] + 1; p < l.colPtr[j+1]; p++ {
+				y[l.rowIdx[p]] -= l.data[p] * y[j]
+			}
+		}
+		// Solve U * y = z. The diagonal of U is stored last.
+		for j := n - 1; j >= 0; j-- {
+			d := u.colPtr[j+1] - 1
+			y[j] /= u.data[d]
+			for p := u.colPtr[j]; p < d; p++ {
+				y[u.rowIdx[p]] -= u.data[p] * y[j]
+			}
+		}
+		for k, j := range f.sym.q {
+			dst[j] = y[k]
+		}
+		return
+	}
+
+	for k, j := range f.sym.q {
+		y[k] = b[j]
+	}
+	// Solve U^T * z = Q^T * b.
+	for j := 0; j < n; j++ {
+		d := u.colPtr[j+1] - 1
+		v := y[j]
+		for p := u.colPtr[j]; p < d; p++ {
+			v -= u.data[p] * y[u.rowIdx[p]]
+		}
+		y[j] = v / u.data[d]
+	}
+	// Solve L^T * y = z.
+	for j := n - 1; j >= 0; j-- {
+		v := y[j]
+		for p := l.colPtr[j] + 1; p < l.colPtr[j+1]; p++ {
+			v -= l.data[p] * y[l.rowIdx[p]]
+		}
+		y[j] = v
+	}
+	for i, k := range f.pinv {
+		dst[i] = y[k]
+	}
+}
+
+// reach computes the rows of the solution of the sparse triangular system
+// L[:,:k] * x = A[:,col] that may be non-zero, where the rows of the partial
+// factor L are numbered as in A and pinv maps the pivotal rows to their
+// columns of L. The rows are returned in order[top:] in topological order,
+// so that each row precedes the rows it updates. Entries of mark equal to k
+// are overwritten, and mark must not otherwise contain k.
+func reach(a *CSC, col int, lp, li, pinv []int, k int, mark, stack, pos, order []int) (top int) {
+	n := a.rows
+	top = n
+	for p := a.colPtr[col]; p < a.colPtr[col+1]; p++ {
+		start := a.rowIdx[p]
+		if mark[start] == k {
+			continue
+		}
+		// Depth-first search from start in the graph of L.
+		head := 0
+		stack[0] = start
+		for head >= 0 {
+			j := stack[head]
+			c := pinv[j]
+			if mark[j] != k {
+				mark[j] = k
+				if c < 0 {
+					pos[j] = 0
+				} else {
+					pos[j] = lp[c] + 1
+				}
+			}
+			done := true
+			if c >= 0 {
+				for end := lp[c+1]; pos[j] < end; {
+					i := li[pos[j]]
+					pos[j]++
+					if mark[i] != k {
+						head++
+						stack[head] = i
+						done = false
+						break
+					}
+				}
+			}
+			if done {
+				head--
+				top--
+				order[top] = j
+			}
+		}
+	}
+	return top
+}
@@ -0,0 +1,128 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name string
+		a    *CSC
+	}{
+		{name: "laplacian 1", a: laplacian2D(1)},
+		{name: "laplacian 8", a: laplacian2D(8)},
+		{name: "dominant 10", a: randomDiagDominant(rnd, 10, 0.3, false)},
+		{name: "dominant 60", a: randomDiagDominant(rnd, 60, 0.05, false)},
+		{name: "random 8", a: randomSparse(rnd, 8, 8, 0.6)},
+		{name: "random 30", a: randomSparse(rnd, 30, 30, 0.3)},
+	} {
+		n, _ := test.a.Dims()
+		dense := mat.DenseCopyOf(test.a)
+		var want mat.LU
+		want.Factorize(dense)
+		if want.Cond() > 1e10 {
+			t.Logf("%s: skipping ill-conditioned matrix", test.name)
+			continue
+		}
+
+		b := make([]float64, n)
+		for i := range b {
+			b[i] = rnd.NormFloat64()
+		}
+		for _, ord := range []Ordering{Natural, MinDegree} {
+			sym := NewSymbolicLU(test.a, ord)
+			q := sym.ColPerm(nil)
+			if !isPermutation(q) {
+				t.Errorf("%s ordering %d: invalid column permutation %v", test.name, ord, q)
+			}
+			var f LU
+			if !f.Factorize(test.a, sym) {
+				t.Errorf("%s ordering %d: unexpected factorization failure", test.name, ord)
+				continue
+			}
+			p := f.RowPerm(nil)
+			if !isPermutation(p) {
+				t.Errorf("%s ordering %d: invalid row permutation %v", test.name, ord, p)
+			}
+
+			// Check P*A*Q = L*U.
+			paq := mat.NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					paq.Set(i, j, dense.At(p[i], q[j]))
+				}
+			}
+			var lu mat.Dense
+			lu.Mul(f.l, f.u)
+			if !mat.EqualApprox(&lu, paq, 1e-12) {
+				t.Errorf("%s ordering %d: P*A*Q != L*U", test.name, ord)
+			}
+
+			for _, trans := range []bool{false, true} {
+				var xWant mat.VecDense
+				var err error
+				if trans {
+					err = xWant.SolveVec(dense.T(), mat.NewVecDense(n, b))
+				} else {
+					err = xWant.SolveVec(dense, mat.NewVecDense(n, b))
+				}
+				if err != nil {
+					t.Fatalf("%s: unexpected dense solve error: %v", test.name, err)
+				}
+				got := make([]float64, n)
+				f.SolveTo(got, trans, b)
+				if !floats.EqualApprox(got, xWant.RawVector().Data, 1e-9) {
+					t.Errorf("%s ordering %d trans %t: unexpected solution", test.name, ord, trans)
+				}
+			}
+		}
+	}
+}
+
+func TestLUSingular(t *testing.T) {
+	tr := NewTriplet(3, 3)
+	tr.Append(0, 0, 1)
+	tr.Append(1, 0, 2)
+	tr.Append(0, 1, 2)
+	tr.Append(1, 1, 4)
+	tr.Append(2, 2, 1)
+	a := tr.CSC()
+	for _, ord := range []Ordering{Natural, MinDegree} {
+		var f LU
+		if f.Factorize(a, NewSymbolicLU(a, ord)) {
+			t.Errorf("ordering %d: expected failure for singular matrix", ord)
+		}
+	}
+}
+
+func TestLUMinDegreeFill(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := laplacian2D(15)
+	// Randomly permute the columns so that the natural ordering is poor.
+	n, _ := a.Dims()
+	perm := rnd.Perm(n)
+	tr := NewTriplet(n, n)
+	for j := 0; j < n; j++ {
+		for p := a.colPtr[j]; p < a.colPtr[j+1]; p++ {
+			tr.Append(a.rowIdx[p], perm[j], a.data[p])
+		}
+	}
+	a = tr.CSC()
+
+	var natural, amd LU
+	if !natural.Factorize(a, NewSymbolicLU(a, Natural)) || !amd.Factorize(a, NewSymbolicLU(a, MinDegree)) {
+		t.Fatal("unexpected factorization failure")
+	}
+	if amd.NNZ() >= natural.NNZ() {
+		t.Errorf("minimum degree fill not less than natural fill: %d >= %d", amd.NNZ(), natural.NNZ())
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+// Ordering specifies the fill-reducing ordering used by a symbolic
+// factorization.
+type Ordering int
+
+const (
+	// Natural uses the rows and columns of the matrix in their
+	// original order.
+	Natural Ordering = iota
+
+	// MinDegree uses an approximate minimum degree ordering.
+	MinDegree
+)
+
+// symmetricOrder returns the elimination order of the symmetric matrix whose
+// pattern is given by the upper triangle of a. Element k of the returned
+// slice is the index of the row and column of a that is eliminated at step k.
+func symmetricOrder(kind Ordering, a *CSC) []int {
+	n := a.cols
+	switch kind {
+	default:
+		panic("sparse: unknown ordering")
+	case Natural:
+		return naturalOrder(n)
+	case MinDegree:
+	}
+	adj := make([][]int, n)
+	for j := 0; j < n; j++ {
+		for p := a.colPtr[j]; p < a.colPtr[j+1]; p++ {
+			i := a.rowIdx[p]
+			if i < j {
+				adj[i] = append(adj[i], j)
+				adj[j] = append(adj[j], i)
+			}
+		}
+	}
+	return minDegree(n, adj, nil)
+}
+
+// columnOrder returns a column elimination order for the matrix a chosen to
+// reduce fill in the Cholesky factor of A^T*A, which bounds the fill in the
+// LU factorization of a with partial pivoting. Element k of the returned
+// slice is the index of the column of a that is eliminated at step k.
+func columnOrder(kind Ordering, a *CSC) []int {
+	n := a.cols
+	switch kind {
+	default:
+		panic("sparse: unknown ordering")
+	case Natural:
+		return naturalOrder(n)
+	case MinDegree:
+	}
+	// Each row of a is a clique in the graph of A^T*A, so the rows are
+	// used as the initial elements of the quotient graph.
+	rowPtr, colIdx := a.transposePattern()
+	elems := make([][]int, a.rows)
+	for i := range elems {
+		elems[i] = colIdx[rowPtr[i]:rowPtr[i+1]]
+	}
+	return minDegree(n, make([][]int, n), elems)
+}
+
+func naturalOrder(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	return perm
+}
+
+// minDegree returns an approximate minimum degree elimination order of the
+// graph with n vertices given by the adjacency lists in adj and the cliques
+// in elems.
+//
+// The elimination is simulated on a quotient graph. Each eliminated vertex
+// becomes an element representing the clique formed by its neighbors, and
+// elements adjacent to the eliminated vertex are absorbed into it, so the
+// storage required does not grow beyond that of the original graph. The
+// degrees of the vertices adjacent to the new element are updated with the
+// approximate external degree bound of Amestoy, Davis and Duff.
+func minDegree(n int, adj, elems [][]int) []int {
+	// Vertices are numbered 0 to n-1. The element formed by eliminating
+	// vertex v is numbered v and the initial elements are numbered from n.
+	nn := n + len(elems)
+	var (
+		varAdj    = make([][]int, n)
+		varElem   = make([][]int, n)
+		varAlive  = make([]bool, n)
+		elemVars  = make([][]int, nn)
+		elemAlive = make([]bool, nn)
+
+		deg  = make([]int, n)
+		head = make([]int, n)
+		next = make([]int, n)
+		prev = make([]int, n)
+
+		mark  = make([]int, nn)
+		wmark = make([]int, nn)
+		w     = make([]int, nn)
+		stamp int
+	)
+
+	for v := range varAlive {
+		varAlive[v] = true
+	}
+	for r, l := range elems {
+		e := n + r
+		stamp++
+		for _, v := range l {
+			if mark[v] == stamp {
+				continue
+			}
+			mark[v] = stamp
+			elemVars[e] = append(elemVars[e], v)
+			varElem[v] = append(varElem[v], e)
+		}
+		elemAlive[e] = len(elemVars[e]) != 0
+	}
+	for v, l := range adj {
+		stamp++
+		mark[v] = stamp
+		for _, u := range l {
+			if mark[u] == stamp {
+				continue
+			}
+			mark[u] = stamp
+			varAdj[v] = append(varAdj[v], u)
+		}
+	}
+
+	// Compute the initial degrees.
+	for i := range head {
+		head[i] = -1
+	}
+	insert := func(v int) {
+		d := deg[v]
+		prev[v] = -1
+		next[v] = head[d]
+		if head[d] >= 0 {
+			prev[head[d]] = v
+		}
+		head[d] = v
+	}
+	remove := func(v int) {
+		if prev[v] >= 0 {
+			next[prev[v]] = next[v]
+		} else {
+			head[deg[v]] = next[v]
+		}
+		if next[v] >= 0 {
+			prev[next[v]] = prev[v]
+		}
+	}
+	for v := 0; v < n; v++ {
+		stamp++
+		mark[v] = stamp
+		d := len(varAdj[v])
+		for _, u := range varAdj[v] {
+			mark[u] = stamp
+		}
+		for _, e := range varElem[v] {
+			for _, u := range elemVars[e] {
+				if mark[u] != stamp {
+					mark[u] = stamp
+					d++
+				}
+			}
+		}
+		deg[v] = d
+		insert(v)
+	}
+
+	order := make([]int, 0, n)
+	var (
+		minDeg int
+		lp     []int
+	)
+	for k := 0; k < n; k++ {
+		for head[minDeg] < 0 {
+			minDeg++
+		}
+		p := head[minDeg]
+		remove(p)
+		varAlive[p] = false
+		order = append(order, p)
+
+		// Form the new element from the vertices adjacent to p and the
+		// vertices of the elements adjacent to p, absorbing those elements.
+		stamp++
+		lp = lp[:0]
+		for _, v := range varAdj[p] {
+			if varAlive[v] && mark[v] != stamp {
+				mark[v] = stamp
+				lp = append(lp, v)
+			}
+		}
+		for _, e := range varElem[p] {
+			if !elemAlive[e] {
+				continue
+			}
+			for _, v := range elemVars[e] {
+				if varAlive[v] && mark[v] != stamp {
+					mark[v] = stamp
+					lp = append(lp, v)
+				}
+			}
+			elemAlive[e] = false
+			elemVars[e] = nil
+		}
+		varAdj[p] = nil
+		varElem[p] = nil
+		if len(lp) == 0 {
+			continue
+		}
+		elemVars[p] = append([]int(nil), lp...)
+		elemAlive[p] = true
+
+		// Compute |Le \ Lp| for the elements adjacent to the new element,
+		// first removing the eliminated vertices from Le so that they
+		// are not counted in the external degrees.
+		for _, i := range lp {
+			remove(i)
+			for _, e := range varElem[i] {
+				if !elemAlive[e] {
+					continue
+				}
+				if wmark[e] != stamp {
+					wmark[e] = stamp
+					le := elemVars[e][:0]
+					for _, v := range elemVars[e] {
+						if varAlive[v] {
+							le = append(le, v)
+						}
+					}
+					elemVars[e] = le
+					w[e] = len(le)
+				}
+				w[e]--
+			}
+		}
+
+		// Prune the adjacency of the vertices of the new element and
+		// update their approximate degrees.
+		ext := len(lp) - 1
+		bound := n - k - 2
+		for _, i := range lp {
+			a := varAdj[i][:0]
+			for _, v := range varAdj[i] {
+				if varAlive[v] && mark[v] != stamp {
+					a = append(a, v)
+				}
+			}
+			varAdj[i] = a
+
+			d := len(a) + ext
+			es := varElem[i][:0]
+			for _, e := range varElem[i] {
+				if !elemAlive[e] {
+					continue
+				}
+				if w[e] == 0 {
+					// Le is a subset of Lp, so e is absorbed.
+					elemAlive[e] = false
+					elemVars[e] = nil
+					continue
+				}
+				d += w[e]
+				es = append(es, e)
+			}
+			varElem[i] = append(es, p)
+
+			if d2 := deg[i] + ext; d2 < d {
+				d = d2
+			}
+			if d > bound {
+				d = bound
+			}
+			if d < 0 {
+				d = 0
+			}
+			deg[i] = d
+			insert(i)
+			if d < minDeg {
+				minDeg = d
+			}
+		}
+	}
+	return order
+}
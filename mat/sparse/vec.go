@@ -0,0 +1,108 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	_ mat.Vector         = (*Vec)(nil)
+	_ mat.VectorAtVec    = (*Vec)(nil)
+	_ mat.VecNonZeroDoer = (*Vec)(nil)
+	_ mat.ColViewer      = (*CSC)(nil)
+)
+
+// Vec is a sparse vector. Only the indices and values of its stored entries
+// are held, in increasing order of index.
+type Vec struct {
+	n    int
+	idx  []int
+	data []float64
+}
+
+// NewVec returns a new sparse vector of length n with stored entries at the
+// indices in idx holding the values in data. The slices are used directly
+// as the backing data of the vector. idx and data must have the same length
+// and the indices must be strictly increasing and less than n, otherwise
+// NewVec will panic.
+func NewVec(n int, idx []int, data []float64) *Vec {
+	if n < 0 {
+		panic(badDimension)
+	}
+	if len(idx) != len(data) {
+		panic(badSliceLen)
+	}
+	for k, i := range idx {
+		if i < 0 || n <= i || (k > 0 && i <= idx[k-1]) {
+			panic(badStructure)
+		}
+	}
+	return &Vec{n: n, idx: idx, data: data}
+}
+
+// Dims returns the number of rows and columns in the vector.
+func (v *Vec) Dims() (r, c int) {
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *Vec) Len() int {
+	return v.n
+}
+
+// At returns the element at row i. At panics if j is not zero.
+func (v *Vec) At(i, j int) float64 {
+	if j != 0 {
+		panic(badIndex)
+	}
+	return v.AtVec(i)
+}
+
+// AtVec returns the element at index i.
+func (v *Vec) AtVec(i int) float64 {
+	if uint(i) >= uint(v.n) {
+		panic(badIndex)
+	}
+	k := sort.SearchInts(v.idx, i)
+	if k < len(v.idx) && v.idx[k] == i {
+		return v.data[k]
+	}
+	return 0
+}
+
+// T performs an implicit transpose by returning the receiver inside a
+// Transpose.
+func (v *Vec) T() mat.Matrix {
+	return mat.Transpose{Matrix: v}
+}
+
+// NNZ returns the number of stored entries in the vector.
+func (v *Vec) NNZ() int {
+	return len(v.idx)
+}
+
+// DoVecNonZero calls the function fn for each of the non-zero stored
+// entries of v. The function fn takes an index and the element value of v
+// at that index.
+func (v *Vec) DoVecNonZero(fn func(i int, v float64)) {
+	for k, i := range v.idx {
+		if v.data[k] != 0 {
+			fn(i, v.data[k])
+		}
+	}
+}
+
+// ColView returns a sparse vector reflecting column j of the matrix. The
+// returned vector is backed by the matrix data.
+func (m *CSC) ColView(j int) mat.Vector {
+	if uint(j) >= uint(m.cols) {
+		panic(badIndex)
+	}
+	lo, hi := m.colPtr[j], m.colPtr[j+1]
+	return &Vec{n: m.rows, idx: m.rowIdx[lo:hi], data: m.data[lo:hi]}
+}
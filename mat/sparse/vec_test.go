@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sparse
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestVec(t *testing.T) {
+	v := NewVec(6, []int{1, 3, 4}, []float64{2, 0, -3})
+	want := mat.NewVecDense(6, []float64{0, 2, 0, 0, -3, 0})
+	if !mat.Equal(v, want) {
+		t.Errorf("unexpected vector: got %v want %v", mat.Formatted(v.T()), mat.Formatted(want.T()))
+	}
+	if v.NNZ() != 3 {
+		t.Errorf("unexpected number of stored entries: got %d want 3", v.NNZ())
+	}
+	var idx []int
+	v.DoVecNonZero(func(i int, _ float64) { idx = append(idx, i) })
+	if len(idx) != 2 || idx[0] != 1 || idx[1] != 4 {
+		t.Errorf("unexpected non-zero indices: got %v want [1 4]", idx)
+	}
+	if got := mat.Dot(v, want); got != 13 {
+		t.Errorf("unexpected dot product: got %v want 13", got)
+	}
+	if got := mat.NormVec(v, 2); math.Abs(got-math.Sqrt(13)) > 1e-15 {
+		t.Errorf("unexpected norm: got %v want %v", got, math.Sqrt(13))
+	}
+
+	for _, test := range []struct {
+		n    int
+		idx  []int
+		data []float64
+	}{
+		{n: 3, idx: []int{0, 1}, data: []float64{1}},
+		{n: 3, idx: []int{1, 1}, data: []float64{1, 2}},
+		{n: 3, idx: []int{2, 1}, data: []float64{1, 2}},
+		{n: 3, idx: []int{3}, data: []float64{1}},
+		{n: -1},
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for n=%d idx=%v", test.n, test.idx)
+				}
+			}()
+			NewVec(test.n, test.idx, test.data)
+		}()
+	}
+}
+
+func TestCSCColView(t *testing.T) {
+	tr := NewTriplet(4, 3)
+	tr.Append(0, 0, 1)
+	tr.Append(2, 0, 2)
+	tr.Append(3, 1, 3)
+	tr.Append(1, 2, 4)
+	tr.Append(3, 2, 5)
+	m := tr.CSC()
+	for j := 0; j < 3; j++ {
+		col := m.ColView(j)
+		if !mat.Equal(col, mat.NewVecDense(4, mat.Col(nil, j, m))) {
+			t.Errorf("unexpected column %d: got %v", j, mat.Formatted(col.T()))
+		}
+	}
+}
@@ -39,6 +39,16 @@ type SVD struct {
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, routines that require a successful factorization will panic.
 func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
+	return svd.factorize(a, kind, nil)
+}
+
+// FactorizeWork is like Factorize, but obtains temporary storage from ws.
+// If ws is nil, FactorizeWork is equivalent to Factorize.
+func (svd *SVD) FactorizeWork(a Matrix, kind SVDKind, ws *Workspace) (ok bool) {
+	return svd.factorize(a, kind, ws)
+}
+
+func (svd *SVD) factorize(a Matrix, kind SVDKind, ws *Workspace) (ok bool) {
 	m, n := a.Dims()
 	var jobU, jobVT lapack.SVDJob
 	switch kind {
@@ -86,15 +96,19 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	}
 
 	// A is destroyed on call, so copy the matrix.
-	aCopy := DenseCopyOf(a)
+	aCopy := ws.getWorkspace(m, n, false)
+	defer ws.putWorkspace(aCopy)
+	aCopy.Copy(a)
 	svd.kind = kind
 	svd.s = use(svd.s, min(m, n))
 
-	work := []float64{0}
+	work := ws.getFloats(1, false)
 	lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
-	work = getFloats(int(work[0]), false)
+	lwork := int(work[0])
+	ws.putFloats(work)
+	work = ws.getFloats(lwork, false)
 	ok = lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
-	putFloats(work)
+	ws.putFloats(work)
 	if !ok {
 		svd.kind = 0
 	}
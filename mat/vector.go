@@ -5,6 +5,9 @@
 package mat
 
 import (
+	"math"
+	"sort"
+
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/internal/asm/f64"
@@ -13,9 +16,10 @@ import (
 var (
 	vector *VecDense
 
-	_ Matrix  = vector
-	_ Vector  = vector
-	_ Reseter = vector
+	_ Matrix      = vector
+	_ Vector      = vector
+	_ VectorAtVec = vector
+	_ Reseter     = vector
 )
 
 // Vector is a column vector.
@@ -24,6 +28,15 @@ type Vector interface {
 	Len() int
 }
 
+// atVec returns a function that returns element i of a, using AtVec if a
+// implements VectorAtVec.
+func atVec(a Vector) func(i int) float64 {
+	if a, ok := a.(VectorAtVec); ok {
+		return a.AtVec
+	}
+	return func(i int) float64 { return a.At(i, 0) }
+}
+
 // VecDense represents a column vector.
 type VecDense struct {
 	mat blas64.Vector
@@ -325,6 +338,221 @@ func (v *VecDense) DivElemVec(a, b *VecDense) {
 	}
 }
 
+// CumSumVec places the cumulative sum of the elements of a in the receiver,
+// so that element i of the receiver is the sum of elements 0 through i of a.
+func (v *VecDense) CumSumVec(a *VecDense) {
+	n := a.Len()
+	if v != a {
+		v.checkOverlap(a.mat)
+	}
+	v.reuseAs(n)
+	if n == 0 {
+		return
+	}
+
+	if v.mat.Inc == 1 && a.mat.Inc == 1 {
+		f64.CumSum(v.mat.Data[:n], a.mat.Data[:n])
+		return
+	}
+	var sum float64
+	for i := 0; i < n; i++ {
+		sum += a.mat.Data[i*a.mat.Inc]
+		v.mat.Data[i*v.mat.Inc] = sum
+	}
+}
+
+// CumProdVec places the cumulative product of the elements of a in the
+// receiver, so that element i of the receiver is the product of elements 0
+// through i of a.
+func (v *VecDense) CumProdVec(a *VecDense) {
+	n := a.Len()
+	if v != a {
+		v.checkOverlap(a.mat)
+	}
+	v.reuseAs(n)
+	if n == 0 {
+		return
+	}
+
+	if v.mat.Inc == 1 && a.mat.Inc == 1 {
+		f64.CumProd(v.mat.Data[:n], a.mat.Data[:n])
+		return
+	}
+	prod := 1.0
+	for i := 0; i < n; i++ {
+		prod *= a.mat.Data[i*a.mat.Inc]
+		v.mat.Data[i*v.mat.Inc] = prod
+	}
+}
+
+// ApplyVec applies the function fn to each of the elements of a, placing the
+// resulting vector in the receiver. The function fn takes an index and
+// element value and returns some function of that pair.
+func (v *VecDense) ApplyVec(fn func(i int, v float64) float64, a Vector) {
+	n := a.Len()
+	if a, ok := a.(*VecDense); ok {
+		if v != a {
+			v.checkOverlap(a.mat)
+		}
+		v.reuseAs(n)
+		for i := 0; i < n; i++ {
+			v.mat.Data[i*v.mat.Inc] = fn(i, a.mat.Data[i*a.mat.Inc])
+		}
+		return
+	}
+	v.reuseAs(n)
+	at := atVec(a)
+	for i := 0; i < n; i++ {
+		v.mat.Data[i*v.mat.Inc] = fn(i, at(i))
+	}
+}
+
+// SortVec places the elements of a in the receiver sorted into increasing
+// order.
+func (v *VecDense) SortVec(a *VecDense) {
+	n := a.Len()
+	if v != a {
+		v.checkOverlap(a.mat)
+		v.reuseAs(n)
+		v.CopyVec(a)
+	}
+	if v.mat.Inc == 1 {
+		sort.Float64s(v.mat.Data[:n])
+		return
+	}
+	work := getFloats(n, false)
+	defer putFloats(work)
+	blas64.Copy(n, v.mat, blas64.Vector{Inc: 1, Data: work})
+	sort.Float64s(work)
+	blas64.Copy(n, blas64.Vector{Inc: 1, Data: work}, v.mat)
+}
+
+// ConcatVec places the elements of a followed by the elements of b in the
+// receiver. ConcatVec will panic if the receiver is a or b, or if the
+// receiver is not empty and its length is not the sum of the lengths of a
+// and b.
+func (v *VecDense) ConcatVec(a, b Vector) {
+	if v == a || v == b {
+		panic(ErrShape)
+	}
+	la := a.Len()
+	lb := b.Len()
+	v.reuseAs(la + lb)
+	if la != 0 {
+		v.SliceVec(0, la).copyFrom(a)
+	}
+	if lb != 0 {
+		v.SliceVec(la, la+lb).copyFrom(b)
+	}
+}
+
+// copyFrom copies the elements of a into the receiver, which must have the
+// same length as a.
+func (v *VecDense) copyFrom(a Vector) {
+	if a, ok := a.(*VecDense); ok {
+		v.checkOverlap(a.mat)
+		v.CopyVec(a)
+		return
+	}
+	at := atVec(a)
+	for i := 0; i < v.n; i++ {
+		v.mat.Data[i*v.mat.Inc] = at(i)
+	}
+}
+
+// MaxIdx returns the index of the maximum element of a. If several elements
+// have the maximum value, the first such index is returned. MaxIdx panics
+// with ErrZeroLength if a has zero length.
+func MaxIdx(a Vector) int {
+	n := a.Len()
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	at := atVec(a)
+	var idx int
+	max := at(0)
+	for i := 1; i < n; i++ {
+		if v := at(i); v > max {
+			max = v
+			idx = i
+		}
+	}
+	return idx
+}
+
+// MinIdx returns the index of the minimum element of a. If several elements
+// have the minimum value, the first such index is returned. MinIdx panics
+// with ErrZeroLength if a has zero length.
+func MinIdx(a Vector) int {
+	n := a.Len()
+	if n == 0 {
+		panic(ErrZeroLength)
+	}
+	at := atVec(a)
+	var idx int
+	min := at(0)
+	for i := 1; i < n; i++ {
+		if v := at(i); v < min {
+			min = v
+			idx = i
+		}
+	}
+	return idx
+}
+
+// NormVec returns the L norm of the vector a, that is
+//  (\sum_i |a_i|^L)^(1/L)
+// for finite L, and the maximum absolute value of the elements of a when L
+// is +Inf. NormVec will panic with ErrNormOrder if L is not positive.
+//
+// If a implements VecNonZeroDoer, only its non-zero elements are visited.
+func NormVec(a Vector, L float64) float64 {
+	if !(L > 0) {
+		panic(ErrNormOrder)
+	}
+	n := a.Len()
+	if n == 0 {
+		return 0
+	}
+	if rv, ok := a.(RawVectorer); ok {
+		vec := rv.RawVector()
+		switch {
+		case L == 1:
+			return blas64.Asum(n, vec)
+		case L == 2:
+			return blas64.Nrm2(n, vec)
+		case math.IsInf(L, 1):
+			return math.Abs(vec.Data[blas64.Iamax(n, vec)*vec.Inc])
+		}
+	}
+
+	var norm float64
+	add := func(v float64) {
+		switch {
+		case L == 2:
+			norm = math.Hypot(norm, v)
+		case L == 1:
+			norm += math.Abs(v)
+		case math.IsInf(L, 1):
+			norm = math.Max(norm, math.Abs(v))
+		default:
+			norm += math.Pow(math.Abs(v), L)
+		}
+	}
+	if nz, ok := a.(VecNonZeroDoer); ok {
+		nz.DoVecNonZero(func(_ int, v float64) { add(v) })
+	} else {
+		at := atVec(a)
+		for i := 0; i < n; i++ {
+			add(at(i))
+		}
+	}
+	if L == 1 || L == 2 || math.IsInf(L, 1) {
+		return norm
+	}
+	return math.Pow(norm, 1/L)
+}
+
 // MulVec computes a * b. The result is stored into the receiver.
 // MulVec panics if the number of columns in a does not equal the number of rows in b.
 func (v *VecDense) MulVec(a Matrix, b *VecDense) {
@@ -483,6 +711,25 @@ func (v *VecDense) asGeneral() blas64.General {
 	}
 }
 
+// SliceVecInc returns a new VecDense that shares backing data with the
+// receiver. The returned vector holds every inc'th element of the receiver,
+// starting at element i and ending before element k. SliceVecInc panics
+// with ErrIndexOutOfRange if the slice is outside the capacity of the
+// receiver or inc is not positive.
+func (v *VecDense) SliceVecInc(i, k, inc int) *VecDense {
+	if i < 0 || k <= i || v.Cap() < k || inc <= 0 {
+		panic(ErrIndexOutOfRange)
+	}
+	n := (k - i + inc - 1) / inc
+	return &VecDense{
+		n: n,
+		mat: blas64.Vector{
+			Inc:  v.mat.Inc * inc,
+			Data: v.mat.Data[i*v.mat.Inc : (i+(n-1)*inc)*v.mat.Inc+1],
+		},
+	}
+}
+
 // ColViewOf reflects the column j of the RawMatrixer m, into the receiver
 // backed by the same underlying data. The length of the receiver must either be
 // zero or match the number of rows in m.
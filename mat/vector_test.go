@@ -5,6 +5,7 @@
 package mat
 
 import (
+	"math"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -411,6 +412,184 @@ func TestVecDenseDivElem(t *testing.T) {
 	}
 }
 
+func TestVecDenseCumSumProd(t *testing.T) {
+	for i, test := range []struct {
+		a        *VecDense
+		sum, pro []float64
+	}{
+		{
+			a:   NewVecDense(4, []float64{1, 2, -3, 0.5}),
+			sum: []float64{1, 3, 0, 0.5},
+			pro: []float64{1, 2, -6, -3},
+		},
+		{
+			a:   NewDense(3, 2, []float64{2, 9, 3, 9, 4, 9}).ColView(0).(*VecDense),
+			sum: []float64{2, 5, 9},
+			pro: []float64{2, 6, 24},
+		},
+	} {
+		var sum VecDense
+		sum.CumSumVec(test.a)
+		if !Equal(&sum, NewVecDense(len(test.sum), test.sum)) {
+			t.Errorf("unexpected cumulative sum for test %d: got: %v want: %v", i, sum.RawVector().Data, test.sum)
+		}
+		var pro VecDense
+		pro.CumProdVec(test.a)
+		if !Equal(&pro, NewVecDense(len(test.pro), test.pro)) {
+			t.Errorf("unexpected cumulative product for test %d: got: %v want: %v", i, pro.RawVector().Data, test.pro)
+		}
+
+		// Check in-place operation.
+		var a VecDense
+		a.CloneVec(test.a)
+		a.CumSumVec(&a)
+		if !Equal(&a, &sum) {
+			t.Errorf("unexpected in-place cumulative sum for test %d", i)
+		}
+	}
+}
+
+func TestVecDenseApplySort(t *testing.T) {
+	a := NewDense(4, 2, []float64{3, 0, -1, 0, 4, 0, 1, 0}).ColView(0)
+
+	var v VecDense
+	v.ApplyVec(func(i int, v float64) float64 { return v * float64(i) }, a)
+	if want := NewVecDense(4, []float64{0, -1, 8, 3}); !Equal(&v, want) {
+		t.Errorf("unexpected ApplyVec result: got: %v want: %v", v.RawVector().Data, want.RawVector().Data)
+	}
+
+	var s VecDense
+	s.SortVec(a.(*VecDense))
+	if want := NewVecDense(4, []float64{-1, 1, 3, 4}); !Equal(&s, want) {
+		t.Errorf("unexpected SortVec result: got: %v want: %v", s.RawVector().Data, want.RawVector().Data)
+	}
+	if got := a.At(0, 0); got != 3 {
+		t.Errorf("SortVec modified its input: got a[0]=%v", got)
+	}
+
+	// Check in-place sorting of a strided vector.
+	m := NewDense(3, 2, []float64{5, 0, 2, 0, 3, 0})
+	col := m.ColView(0).(*VecDense)
+	col.SortVec(col)
+	if want := NewDense(3, 2, []float64{2, 0, 3, 0, 5, 0}); !Equal(m, want) {
+		t.Errorf("unexpected in-place SortVec result: got: %v want: %v", m.RawMatrix().Data, want.RawMatrix().Data)
+	}
+}
+
+func TestVecDenseSliceVecInc(t *testing.T) {
+	v := NewVecDense(7, []float64{0, 1, 2, 3, 4, 5, 6})
+	for _, test := range []struct {
+		i, k, inc int
+		want      []float64
+	}{
+		{i: 0, k: 7, inc: 1, want: []float64{0, 1, 2, 3, 4, 5, 6}},
+		{i: 0, k: 7, inc: 2, want: []float64{0, 2, 4, 6}},
+		{i: 1, k: 7, inc: 2, want: []float64{1, 3, 5}},
+		{i: 1, k: 6, inc: 3, want: []float64{1, 4}},
+		{i: 2, k: 3, inc: 4, want: []float64{2}},
+	} {
+		s := v.SliceVecInc(test.i, test.k, test.inc)
+		if !Equal(s, NewVecDense(len(test.want), test.want)) {
+			t.Errorf("unexpected slice for i=%d k=%d inc=%d: got: %v want: %v",
+				test.i, test.k, test.inc, Row(nil, 0, s.T()), test.want)
+		}
+		s.SetVec(0, -1)
+		if v.AtVec(test.i) != -1 {
+			t.Errorf("slice for i=%d k=%d inc=%d does not share data with the receiver", test.i, test.k, test.inc)
+		}
+		v.SetVec(test.i, float64(test.i))
+	}
+
+	// Slicing a slice composes the increments.
+	s := v.SliceVecInc(1, 7, 2).SliceVecInc(0, 3, 2)
+	if want := NewVecDense(2, []float64{1, 5}); !Equal(s, want) {
+		t.Errorf("unexpected nested slice: got: %v want: %v", Row(nil, 0, s.T()), Row(nil, 0, want.T()))
+	}
+
+	for _, test := range []struct{ i, k, inc int }{
+		{i: -1, k: 2, inc: 1},
+		{i: 2, k: 2, inc: 1},
+		{i: 0, k: 8, inc: 1},
+		{i: 0, k: 3, inc: 0},
+	} {
+		if panicked, _ := panics(func() { v.SliceVecInc(test.i, test.k, test.inc) }); !panicked {
+			t.Errorf("expected panic for i=%d k=%d inc=%d", test.i, test.k, test.inc)
+		}
+	}
+}
+
+func TestVecDenseConcat(t *testing.T) {
+	a := NewVecDense(2, []float64{1, 2})
+	b := NewBandDense(3, 3, 1, 0, []float64{0, 3, 4, 5, 6, 7}).ColView(0)
+	var v VecDense
+	v.ConcatVec(a, b)
+	if want := NewVecDense(5, []float64{1, 2, 3, 4, 0}); !Equal(&v, want) {
+		t.Errorf("unexpected ConcatVec result: got: %v want: %v", v.RawVector().Data, want.RawVector().Data)
+	}
+	if panicked, _ := panics(func() { v.ConcatVec(&v, a) }); !panicked {
+		t.Error("expected panic for receiver used as input")
+	}
+}
+
+func TestVectorReductions(t *testing.T) {
+	dense := NewVecDense(5, []float64{0, -4, 3, 0, 1})
+	strided := NewDense(5, 2, []float64{0, 9, -4, 9, 3, 9, 0, 9, 1, 9}).ColView(0)
+	b := NewBandDense(5, 1, 4, 0, nil)
+	for i := 0; i < 5; i++ {
+		b.SetBand(i, 0, dense.AtVec(i))
+	}
+	band := b.ColView(0)
+	// basic does not implement VectorAtVec.
+	basic := &basicVector{m: []float64{0, -4, 3, 0, 1}}
+	for _, test := range []struct {
+		name string
+		v    Vector
+	}{
+		{name: "dense", v: dense},
+		{name: "strided", v: strided},
+		{name: "band", v: band},
+		{name: "basic", v: basic},
+	} {
+		if got := MaxIdx(test.v); got != 2 {
+			t.Errorf("%s: unexpected MaxIdx: got %d want 2", test.name, got)
+		}
+		if got := MinIdx(test.v); got != 1 {
+			t.Errorf("%s: unexpected MinIdx: got %d want 1", test.name, got)
+		}
+		for _, norm := range []struct {
+			L, want float64
+		}{
+			{L: 1, want: 8},
+			{L: 2, want: math.Sqrt(26)},
+			{L: 3, want: math.Cbrt(92)},
+			{L: 0.5, want: math.Pow(2+math.Sqrt(3)+1, 2)},
+			{L: math.Inf(1), want: 4},
+		} {
+			got := NormVec(test.v, norm.L)
+			if math.Abs(got-norm.want) > 1e-14*norm.want {
+				t.Errorf("%s: unexpected L%v norm: got %v want %v", test.name, norm.L, got, norm.want)
+			}
+		}
+		if got := Dot(test.v, dense); got != 26 {
+			t.Errorf("%s: unexpected Dot: got %v want 26", test.name, got)
+		}
+		if got := Dot(test.v, basic); got != 26 {
+			t.Errorf("%s: unexpected Dot with basic vector: got %v want 26", test.name, got)
+		}
+		var v VecDense
+		v.ApplyVec(func(_ int, v float64) float64 { return v }, test.v)
+		if !Equal(&v, dense) {
+			t.Errorf("%s: unexpected ApplyVec result: got %v want %v", test.name, v.RawVector().Data, dense.RawVector().Data)
+		}
+	}
+	if panicked, _ := panics(func() { NormVec(dense, 0) }); !panicked {
+		t.Error("expected panic for zero norm order")
+	}
+	if panicked, _ := panics(func() { MaxIdx(&VecDense{}) }); !panicked {
+		t.Error("expected panic for MaxIdx of empty vector")
+	}
+}
+
 func BenchmarkAddScaledVec10Inc1(b *testing.B)      { addScaledVecBench(b, 10, 1) }
 func BenchmarkAddScaledVec100Inc1(b *testing.B)     { addScaledVecBench(b, 100, 1) }
 func BenchmarkAddScaledVec1000Inc1(b *testing.B)    { addScaledVecBench(b, 1000, 1) }
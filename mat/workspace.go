@@ -0,0 +1,248 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Workspace holds temporary storage for matrix operations. Methods that take
+// a *Workspace, such as LU.FactorizeWork and Dense.MulWork, obtain their
+// temporary storage from it instead of from the package's global pools.
+// Once a Workspace has grown to hold the storage needed by a sequence of
+// operations, repeating the sequence with matrices of the same sizes does
+// not allocate.
+//
+// The zero value of a Workspace is ready to use. A Workspace must not be used
+// by more than one goroutine at a time, so concurrent callers should each
+// hold their own. A nil *Workspace is valid and uses the global pools.
+//
+// Storage held by a Workspace is retained for the life of the Workspace.
+type Workspace struct {
+	dense  [63][]*Dense
+	tri    [63][]*TriDense
+	vec    [63][]*VecDense
+	floats [63][][]float64
+	ints   [63][][]int
+
+	// lu, qr and lq hold the factorizations
+	// computed by Dense.SolveWork.
+	lu LU
+	qr QR
+	lq LQ
+}
+
+// getLU returns an LU for temporary use by the caller.
+func (w *Workspace) getLU() *LU {
+	if w == nil {
+		return &LU{}
+	}
+	return &w.lu
+}
+
+// getQR returns a QR for temporary use by the caller.
+func (w *Workspace) getQR() *QR {
+	if w == nil {
+		return &QR{}
+	}
+	return &w.qr
+}
+
+// getLQ returns an LQ for temporary use by the caller.
+func (w *Workspace) getLQ() *LQ {
+	if w == nil {
+		return &LQ{}
+	}
+	return &w.lq
+}
+
+// getWorkspace returns a *Dense of size r×c. If clear is true, the data slice
+// visible through the Matrix interface is zeroed.
+func (w *Workspace) getWorkspace(r, c int, clear bool) *Dense {
+	if w == nil {
+		return getWorkspace(r, c, clear)
+	}
+	l := uint64(r * c)
+	b := bits(l)
+	var d *Dense
+	if n := len(w.dense[b]); n > 0 {
+		d = w.dense[b][n-1]
+		w.dense[b] = w.dense[b][:n-1]
+	} else {
+		d = &Dense{mat: blas64.General{Data: make([]float64, 1<<b)}}
+	}
+	d.mat.Data = d.mat.Data[:l]
+	if clear {
+		zero(d.mat.Data)
+	}
+	d.mat.Rows = r
+	d.mat.Cols = c
+	d.mat.Stride = c
+	d.capRows = r
+	d.capCols = c
+	return d
+}
+
+// putWorkspace returns a *Dense obtained from getWorkspace to the receiver.
+// putWorkspace must not be called with a matrix where references to the
+// underlying data slice have been kept.
+func (w *Workspace) putWorkspace(d *Dense) {
+	if w == nil {
+		putWorkspace(d)
+		return
+	}
+	b := bits(uint64(cap(d.mat.Data)))
+	w.dense[b] = append(w.dense[b], d)
+}
+
+// getWorkspaceTri returns a *TriDense of size n. If clear is true, the data
+// slice visible through the Matrix interface is zeroed.
+func (w *Workspace) getWorkspaceTri(n int, kind TriKind, clear bool) *TriDense {
+	if w == nil {
+		return getWorkspaceTri(n, kind, clear)
+	}
+	l := uint64(n)
+	l *= l
+	b := bits(l)
+	var t *TriDense
+	if k := len(w.tri[b]); k > 0 {
+		t = w.tri[b][k-1]
+		w.tri[b] = w.tri[b][:k-1]
+	} else {
+		t = &TriDense{mat: blas64.Triangular{Data: make([]float64, 1<<b)}}
+	}
+	t.mat.Data = t.mat.Data[:l]
+	if clear {
+		zero(t.mat.Data)
+	}
+	t.mat.N = n
+	t.mat.Stride = n
+	if kind == Upper {
+		t.mat.Uplo = blas.Upper
+	} else if kind == Lower {
+		t.mat.Uplo = blas.Lower
+	} else {
+		panic(ErrTriangle)
+	}
+	t.mat.Diag = blas.NonUnit
+	t.cap = n
+	return t
+}
+
+// putWorkspaceTri returns a *TriDense obtained from getWorkspaceTri to the
+// receiver. putWorkspaceTri must not be called with a matrix where references
+// to the underlying data slice have been kept.
+func (w *Workspace) putWorkspaceTri(t *TriDense) {
+	if w == nil {
+		putWorkspaceTri(t)
+		return
+	}
+	b := bits(uint64(cap(t.mat.Data)))
+	w.tri[b] = append(w.tri[b], t)
+}
+
+// getWorkspaceVec returns a *VecDense of length n. If clear is true, the
+// data slice visible through the Matrix interface is zeroed.
+func (w *Workspace) getWorkspaceVec(n int, clear bool) *VecDense {
+	if w == nil {
+		return getWorkspaceVec(n, clear)
+	}
+	l := uint64(n)
+	b := bits(l)
+	var v *VecDense
+	if k := len(w.vec[b]); k > 0 {
+		v = w.vec[b][k-1]
+		w.vec[b] = w.vec[b][:k-1]
+	} else {
+		v = &VecDense{mat: blas64.Vector{Inc: 1, Data: make([]float64, 1<<b)}}
+	}
+	v.mat.Data = v.mat.Data[:l]
+	if clear {
+		zero(v.mat.Data)
+	}
+	v.n = n
+	return v
+}
+
+// putWorkspaceVec returns a *VecDense obtained from getWorkspaceVec to the
+// receiver. putWorkspaceVec must not be called with a vector where references
+// to the underlying data slice have been kept.
+func (w *Workspace) putWorkspaceVec(v *VecDense) {
+	if w == nil {
+		putWorkspaceVec(v)
+		return
+	}
+	b := bits(uint64(cap(v.mat.Data)))
+	w.vec[b] = append(w.vec[b], v)
+}
+
+// getFloats returns a []float64 of length l. If clear is true, the slice
+// visible is zeroed.
+func (w *Workspace) getFloats(l int, clear bool) []float64 {
+	if w == nil {
+		return getFloats(l, clear)
+	}
+	b := bits(uint64(l))
+	var s []float64
+	if n := len(w.floats[b]); n > 0 {
+		s = w.floats[b][n-1]
+		w.floats[b] = w.floats[b][:n-1]
+	} else {
+		s = make([]float64, 1<<b)
+	}
+	s = s[:l]
+	if clear {
+		zero(s)
+	}
+	return s
+}
+
+// putFloats returns a []float64 obtained from getFloats to the receiver.
+// putFloats must not be called with a slice where references to the
+// underlying data have been kept.
+func (w *Workspace) putFloats(s []float64) {
+	if w == nil {
+		putFloats(s)
+		return
+	}
+	b := bits(uint64(cap(s)))
+	w.floats[b] = append(w.floats[b], s)
+}
+
+// getInts returns a []int of length l. If clear is true, the slice visible
+// is zeroed.
+func (w *Workspace) getInts(l int, clear bool) []int {
+	if w == nil {
+		return getInts(l, clear)
+	}
+	b := bits(uint64(l))
+	var s []int
+	if n := len(w.ints[b]); n > 0 {
+		s = w.ints[b][n-1]
+		w.ints[b] = w.ints[b][:n-1]
+	} else {
+		s = make([]int, 1<<b)
+	}
+	s = s[:l]
+	if clear {
+		for i := range s {
+			s[i] = 0
+		}
+	}
+	return s
+}
+
+// putInts returns a []int obtained from getInts to the receiver. putInts must
+// not be called with a slice where references to the underlying data have
+// been kept.
+func (w *Workspace) putInts(s []int) {
+	if w == nil {
+		putInts(s)
+		return
+	}
+	b := bits(uint64(cap(s)))
+	w.ints[b] = append(w.ints[b], s)
+}
@@ -0,0 +1,173 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestWorkspaceMatchesPool(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var ws Workspace
+	for _, n := range []int{1, 3, 10, 17} {
+		a := randomDense(n, n, rnd)
+		b := randomDense(n, 3, rnd)
+		tall := randomDense(n+4, n, rnd)
+		wide := randomDense(n, n+4, rnd)
+		var spd SymDense
+		spd.SymOuterK(1, a)
+		for i := 0; i < n; i++ {
+			spd.SetSym(i, i, spd.At(i, i)+1)
+		}
+
+		for _, test := range []struct {
+			name string
+			a, b Matrix
+		}{
+			{name: "square", a: a, b: b},
+			{name: "tall", a: tall, b: randomDense(n+4, 3, rnd)},
+			{name: "wide", a: wide, b: b},
+			{name: "triangular", a: NewTriDense(n, Upper, spd.RawSymmetric().Data), b: b},
+		} {
+			var want, got Dense
+			errWant := want.Solve(test.a, test.b)
+			errGot := got.SolveWork(test.a, test.b, &ws)
+			if (errWant == nil) != (errGot == nil) {
+				t.Errorf("n=%d %s: mismatched Solve errors: want %v got %v", n, test.name, errWant, errGot)
+			}
+			if !EqualApprox(&got, &want, 1e-12) {
+				t.Errorf("n=%d %s: SolveWork result does not match Solve", n, test.name)
+			}
+		}
+
+		var want, got Dense
+		want.Mul(a, a.T())
+		got.MulWork(a, a.T(), &ws)
+		if !Equal(&got, &want) {
+			t.Errorf("n=%d: MulWork result does not match Mul", n)
+		}
+		got.Clone(a)
+		got.MulWork(&got, a, &ws)
+		want.Mul(a, a)
+		if !Equal(&got, &want) {
+			t.Errorf("n=%d: in-place MulWork result does not match Mul", n)
+		}
+
+		var lu, luWork LU
+		lu.Factorize(a)
+		luWork.FactorizeWork(a, &ws)
+		want.Reset()
+		got.Reset()
+		lu.Solve(&want, true, b)
+		luWork.SolveWork(&got, true, b, &ws)
+		if !Equal(&got, &want) {
+			t.Errorf("n=%d: LU.SolveWork result does not match LU.Solve", n)
+		}
+		got.Clone(b)
+		luWork.SolveWork(&got, true, &got, &ws)
+		if !Equal(&got, &want) {
+			t.Errorf("n=%d: in-place LU.SolveWork result does not match LU.Solve", n)
+		}
+
+		var qr, qrWork QR
+		qr.Factorize(tall)
+		qrWork.FactorizeWork(tall, &ws)
+		bv := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			bv.SetVec(i, rnd.NormFloat64())
+		}
+		var wantVec, gotVec VecDense
+		qr.SolveVec(&wantVec, true, bv)
+		qrWork.SolveVecWork(&gotVec, true, bv, &ws)
+		if !EqualApprox(&gotVec, &wantVec, 1e-12) {
+			t.Errorf("n=%d: QR.SolveVecWork result does not match QR.SolveVec", n)
+		}
+
+		var chol, cholWork Cholesky
+		chol.Factorize(&spd)
+		if !cholWork.FactorizeWork(&spd, &ws) {
+			t.Errorf("n=%d: unexpected Cholesky.FactorizeWork failure", n)
+		}
+		if chol.Cond() != cholWork.Cond() {
+			t.Errorf("n=%d: Cholesky condition number mismatch", n)
+		}
+
+		var svd, svdWork SVD
+		svd.Factorize(tall, SVDThin)
+		svdWork.FactorizeWork(tall, SVDThin, &ws)
+		if !Equal(NewVecDense(n, svdWork.Values(nil)), NewVecDense(n, svd.Values(nil))) {
+			t.Errorf("n=%d: SVD.FactorizeWork singular values do not match", n)
+		}
+
+		var es, esWork EigenSym
+		es.Factorize(&spd, true)
+		esWork.FactorizeWork(&spd, true, &ws)
+		want.Reset()
+		want.EigenvectorsSym(&es)
+		got.Reset()
+		got.EigenvectorsSym(&esWork)
+		if !Equal(&got, &want) {
+			t.Errorf("n=%d: EigenSym.FactorizeWork eigenvectors do not match", n)
+		}
+	}
+}
+
+func TestWorkspaceZeroAllocs(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n = 12
+	a := randomDense(n, n, rnd)
+	at := a.T()
+	tall := randomDense(n+4, n, rnd)
+	wide := randomDense(n, n+4, rnd)
+	b := randomDense(n, 3, rnd)
+	bTall := randomDense(n+4, 3, rnd)
+	bv := NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		bv.SetVec(i, rnd.NormFloat64())
+	}
+	var spd SymDense
+	spd.SymOuterK(1, a)
+	for i := 0; i < n; i++ {
+		spd.SetSym(i, i, spd.At(i, i)+1)
+	}
+	var sym Symmetric = &spd
+
+	var (
+		ws   Workspace
+		x, c Dense
+		xv   VecDense
+		lu   LU
+		qr   QR
+		chol Cholesky
+		svd  SVD
+		es   EigenSym
+	)
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{"LU.FactorizeWork", func() { lu.FactorizeWork(a, &ws) }},
+		{"LU.SolveWork", func() { lu.SolveWork(&x, false, b, &ws) }},
+		{"LU.SolveVecWork", func() { lu.SolveVecWork(&xv, false, bv, &ws) }},
+		{"QR.FactorizeWork", func() { qr.FactorizeWork(a, &ws) }},
+		{"QR.SolveWork", func() { qr.SolveWork(&x, false, b, &ws) }},
+		{"QR.SolveVecWork", func() { qr.SolveVecWork(&xv, false, bv, &ws) }},
+		{"Cholesky.FactorizeWork", func() { chol.FactorizeWork(sym, &ws) }},
+		{"SVD.FactorizeWork", func() { svd.FactorizeWork(tall, SVDThin, &ws) }},
+		{"EigenSym.FactorizeWork", func() { es.FactorizeWork(sym, true, &ws) }},
+		{"Dense.MulWork", func() { c.MulWork(a, at, &ws) }},
+		{"Dense.MulWork in place", func() { c.MulWork(&c, a, &ws) }},
+		{"Dense.SolveWork square", func() { x.SolveWork(a, b, &ws) }},
+		{"Dense.SolveWork tall", func() { x.Reset(); x.SolveWork(tall, bTall, &ws) }},
+		{"Dense.SolveWork wide", func() { x.Reset(); x.SolveWork(wide, b, &ws) }},
+	} {
+		// Warm up the workspace and the receivers.
+		test.fn()
+		if allocs := testing.AllocsPerRun(10, test.fn); allocs != 0 {
+			t.Errorf("%s: unexpected allocations after warm-up: got %v", test.name, allocs)
+		}
+	}
+}
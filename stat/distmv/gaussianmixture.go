@@ -0,0 +1,626 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// ErrSingularCovariance is returned by GaussianMixture fitting when the
+// covariance matrix of a component is not positive definite. Increasing the
+// regularization of the covariance matrices may avoid the error.
+var ErrSingularCovariance = errors.New("distmv: mixture component covariance not positive definite")
+
+// CovarianceType specifies the form of the covariance matrices of the
+// components of a GaussianMixture.
+type CovarianceType int
+
+const (
+	// FullCovariance specifies that each component has its own
+	// unconstrained covariance matrix.
+	FullCovariance CovarianceType = iota
+	// DiagonalCovariance specifies that each component has its own
+	// diagonal covariance matrix.
+	DiagonalCovariance
+	// TiedCovariance specifies that all components share the same
+	// unconstrained covariance matrix.
+	TiedCovariance
+)
+
+// GaussianMixtureSettings holds the settings for fitting a GaussianMixture
+// by expectation maximization.
+type GaussianMixtureSettings struct {
+	// Covariance is the form of the component covariance matrices.
+	Covariance CovarianceType
+
+	// Regularization is added to the diagonal of the covariance matrices
+	// to keep them positive definite.
+	Regularization float64
+
+	// MaxIterations is the maximum number of EM iterations.
+	MaxIterations int
+
+	// Tolerance is the convergence threshold on the increase of the
+	// weighted mean log-likelihood of the samples between iterations.
+	Tolerance float64
+}
+
+// DefaultGaussianMixtureSettings returns the default settings for fitting a
+// GaussianMixture.
+func DefaultGaussianMixtureSettings() *GaussianMixtureSettings {
+	return &GaussianMixtureSettings{
+		Covariance:     FullCovariance,
+		Regularization: 1e-6,
+		MaxIterations:  100,
+		Tolerance:      1e-6,
+	}
+}
+
+// GaussianMixture is a finite mixture of multivariate normal distributions.
+// Its pdf is given by
+//  p(x) = \sum_k π_k N(x; μ_k, Σ_k)
+// where π_k are the mixture weights, which are non-negative and sum to one.
+// Use NewGaussianMixture or FitGaussianMixture to construct.
+type GaussianMixture struct {
+	weights    []float64
+	logWeights []float64
+	components []*Normal
+	covType    CovarianceType
+	dim        int
+
+	src *rand.Rand
+}
+
+// NewGaussianMixture creates a new GaussianMixture with the given component
+// distributions and relative weights. If weights is nil, the components are
+// equally weighted, otherwise the weights are normalized to sum to one.
+// NewGaussianMixture panics if there are no components, if the components
+// do not all have the same dimension, if len(weights) != len(components) for
+// non-nil weights, or if the weights are negative or sum to zero.
+//
+// The components are treated as having FullCovariance for the purposes of
+// NumParameters and subsequent calls to Fit.
+func NewGaussianMixture(weights []float64, components []*Normal, src *rand.Rand) *GaussianMixture {
+	if len(components) == 0 {
+		panic("gaussianmixture: no components")
+	}
+	if weights != nil && len(weights) != len(components) {
+		panic(badInputLength)
+	}
+	dim := components[0].Dim()
+	for _, c := range components {
+		if c.Dim() != dim {
+			panic(badSizeMismatch)
+		}
+	}
+	w := make([]float64, len(components))
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			w[i] = weights[i]
+		}
+		if w[i] < 0 {
+			panic("gaussianmixture: negative weight")
+		}
+	}
+	g := &GaussianMixture{
+		components: make([]*Normal, len(components)),
+		dim:        dim,
+		src:        src,
+	}
+	copy(g.components, components)
+	g.setWeights(w)
+	return g
+}
+
+// setWeights normalizes w to sum to one and sets the weights of the mixture.
+func (g *GaussianMixture) setWeights(w []float64) {
+	sum := floats.Sum(w)
+	if !(sum > 0) {
+		panic("gaussianmixture: zero weights")
+	}
+	floats.Scale(1/sum, w)
+	g.weights = w
+	g.logWeights = make([]float64, len(w))
+	for i, v := range w {
+		g.logWeights[i] = math.Log(v)
+	}
+}
+
+// FitGaussianMixture returns a GaussianMixture with k components fitted to
+// the rows of x with relative weights by expectation maximization. The
+// initial component means are chosen by k-means++ seeding and the initial
+// covariances are computed from the nearest-mean assignment of the samples.
+// If weights is nil, then all the weights are 1. If settings is nil, the
+// values of DefaultGaussianMixtureSettings are used.
+//
+// The returned bool reports whether EM converged within the maximum number
+// of iterations. If a component covariance matrix is not positive definite,
+// ErrSingularCovariance is returned. FitGaussianMixture panics if k < 1, if
+// x has fewer than max(k, 2) rows, or if len(weights) != the number of rows
+// of x for non-nil weights.
+func FitGaussianMixture(x mat.Matrix, weights []float64, k int, settings *GaussianMixtureSettings, src *rand.Rand) (*GaussianMixture, bool, error) {
+	if settings == nil {
+		settings = DefaultGaussianMixtureSettings()
+	}
+	r, c := x.Dims()
+	if k < 1 {
+		panic("gaussianmixture: non-positive number of components")
+	}
+	if r < k || r < 2 {
+		panic("gaussianmixture: too few samples")
+	}
+	if weights != nil && len(weights) != r {
+		panic(badInputLength)
+	}
+	var nPos int
+	for i := 0; i < r; i++ {
+		if weights == nil || weights[i] > 0 {
+			nPos++
+		}
+	}
+	if nPos < k {
+		panic("gaussianmixture: too few samples")
+	}
+	var xd mat.Dense
+	xd.Clone(x)
+
+	// Assign each sample to the nearest k-means++ seed and use the
+	// assignment as the initial responsibilities. Each seed is assigned
+	// to its own cluster so that no cluster is empty.
+	seeds := kmeansPlusPlus(&xd, weights, k, src)
+	resp := mat.NewDense(r, k, nil)
+	for i := 0; i < r; i++ {
+		row := xd.RawRowView(i)
+		best := 0
+		bestDist := math.Inf(1)
+		for j, s := range seeds {
+			d := floats.Distance(row, xd.RawRowView(s), 2)
+			if d < bestDist {
+				best = j
+				bestDist = d
+			}
+		}
+		resp.Set(i, best, 1)
+	}
+	for j, s := range seeds {
+		for l := 0; l < k; l++ {
+			resp.Set(s, l, 0)
+		}
+		resp.Set(s, j, 1)
+	}
+
+	g := &GaussianMixture{
+		components: make([]*Normal, k),
+		covType:    settings.Covariance,
+		dim:        c,
+		src:        src,
+	}
+	if err := g.maximize(&xd, weights, resp, settings); err != nil {
+		return nil, false, err
+	}
+	converged, err := g.em(&xd, weights, resp, settings)
+	if err != nil {
+		return nil, false, err
+	}
+	return g, converged, nil
+}
+
+// kmeansPlusPlus returns the indices of k distinct rows of x with positive
+// weight chosen by k-means++ seeding. The first row is chosen with probability
+// proportional to its weight, and each subsequent row with probability
+// proportional to its weight times its squared distance to the nearest row
+// already chosen.
+func kmeansPlusPlus(x *mat.Dense, weights []float64, k int, src *rand.Rand) []int {
+	r, _ := x.Dims()
+	unifrnd := rand.Float64
+	if src != nil {
+		unifrnd = src.Float64
+	}
+	w := make([]float64, r)
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			w[i] = weights[i]
+		}
+	}
+	dist := make([]float64, r)
+	for i := range dist {
+		dist[i] = 1
+	}
+	chosen := make([]bool, r)
+	p := make([]float64, r)
+	cum := make([]float64, r)
+	seeds := make([]int, 0, k)
+	for len(seeds) < k {
+		for i := range p {
+			p[i] = w[i] * dist[i]
+		}
+		if !(floats.Sum(p) > 0) {
+			// All remaining rows coincide with a seed, so choose
+			// uniformly among the unchosen rows with positive weight.
+			for i := range p {
+				p[i] = 0
+				if !chosen[i] && w[i] > 0 {
+					p[i] = 1
+				}
+			}
+		}
+		floats.CumSum(cum, p)
+		u := unifrnd() * cum[r-1]
+		idx := sort.Search(r, func(i int) bool { return cum[i] > u })
+		for idx == r || p[idx] == 0 {
+			// Guard against rounding in the cumulative sum.
+			idx--
+		}
+		seeds = append(seeds, idx)
+		chosen[idx] = true
+		for i := range dist {
+			d := floats.Distance(x.RawRowView(i), x.RawRowView(idx), 2)
+			if len(seeds) == 1 || d*d < dist[i] {
+				dist[i] = d * d
+			}
+		}
+	}
+	return seeds
+}
+
+// Fit refines the parameters of the mixture by expectation maximization
+// starting from its current parameters, using the rows of x as samples with
+// relative weights. If weights is nil, then all the weights are 1. If
+// settings is nil, the values of DefaultGaussianMixtureSettings are used.
+// The number of components is not changed.
+//
+// The returned bool reports whether EM converged within the maximum number
+// of iterations. If a component covariance matrix is not positive definite,
+// ErrSingularCovariance is returned and the receiver is left unchanged.
+func (g *GaussianMixture) Fit(x mat.Matrix, weights []float64, settings *GaussianMixtureSettings) (bool, error) {
+	if settings == nil {
+		settings = DefaultGaussianMixtureSettings()
+	}
+	r, c := x.Dims()
+	if c != g.dim {
+		panic(badSizeMismatch)
+	}
+	if r < 2 {
+		panic("gaussianmixture: too few samples")
+	}
+	if weights != nil && len(weights) != r {
+		panic(badInputLength)
+	}
+	var xd mat.Dense
+	xd.Clone(x)
+	fit := &GaussianMixture{
+		weights:    make([]float64, len(g.weights)),
+		logWeights: make([]float64, len(g.logWeights)),
+		components: make([]*Normal, len(g.components)),
+		covType:    settings.Covariance,
+		dim:        g.dim,
+		src:        g.src,
+	}
+	copy(fit.weights, g.weights)
+	copy(fit.logWeights, g.logWeights)
+	copy(fit.components, g.components)
+	converged, err := fit.em(&xd, weights, mat.NewDense(r, len(g.components), nil), settings)
+	if err != nil {
+		return false, err
+	}
+	*g = *fit
+	return converged, nil
+}
+
+// em runs expectation maximization from the current parameters of the
+// mixture, using resp as working storage for the responsibilities.
+func (g *GaussianMixture) em(x *mat.Dense, weights []float64, resp *mat.Dense, settings *GaussianMixtureSettings) (bool, error) {
+	prev := math.Inf(-1)
+	for iter := 0; iter < settings.MaxIterations; iter++ {
+		ll := g.expect(x, weights, resp)
+		if ll-prev <= settings.Tolerance {
+			return true, nil
+		}
+		prev = ll
+		if err := g.maximize(x, weights, resp, settings); err != nil {
+			return false, err
+		}
+	}
+	ll := g.expect(x, weights, resp)
+	return ll-prev <= settings.Tolerance, nil
+}
+
+// expect stores the responsibilities of the components for the rows of x
+// into resp and returns the weighted mean log-likelihood of the rows.
+func (g *GaussianMixture) expect(x *mat.Dense, weights []float64, resp *mat.Dense) float64 {
+	r, _ := x.Dims()
+	var ll, sumW float64
+	for i := 0; i < r; i++ {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		lp := g.responsibilities(resp.RawRowView(i), x.RawRowView(i))
+		if w != 0 {
+			ll += w * lp
+		}
+		sumW += w
+	}
+	return ll / sumW
+}
+
+// maximize sets the parameters of the mixture to their weighted maximum
+// likelihood estimates given the responsibilities resp of the components for
+// the rows of x. Components with no responsibility for any row are left
+// unchanged with zero weight.
+func (g *GaussianMixture) maximize(x *mat.Dense, weights []float64, resp *mat.Dense, settings *GaussianMixtureSettings) error {
+	r, c := x.Dims()
+	k := len(g.components)
+	nk := make([]float64, k)
+	mus := make([][]float64, k)
+	covs := make([]*mat.SymDense, k)
+	w := make([]float64, r)
+	for j := 0; j < k; j++ {
+		for i := range w {
+			w[i] = resp.At(i, j)
+			if weights != nil {
+				w[i] *= weights[i]
+			}
+		}
+		nk[j] = floats.Sum(w)
+		if nk[j] == 0 {
+			continue
+		}
+		// Scale the weights to sum to the number of samples so that
+		// the unbiased estimate from CovarianceMatrix can be converted
+		// to the maximum likelihood estimate.
+		floats.Scale(float64(r)/nk[j], w)
+		mus[j] = make([]float64, c)
+		for l := range mus[j] {
+			mus[j][l] = stat.Mean(mat.Col(nil, l, x), w)
+		}
+		covs[j] = stat.CovarianceMatrix(nil, x, w)
+		covs[j].ScaleSym(float64(r-1)/float64(r), covs[j])
+		if settings.Covariance == DiagonalCovariance {
+			diag := mat.NewSymDense(c, nil)
+			for l := 0; l < c; l++ {
+				diag.SetSym(l, l, covs[j].At(l, l))
+			}
+			covs[j] = diag
+		}
+	}
+	if settings.Covariance == TiedCovariance {
+		tied := mat.NewSymDense(c, nil)
+		total := floats.Sum(nk)
+		for j, cov := range covs {
+			if cov != nil {
+				tied.AddSym(tied, scaledSym(nk[j]/total, cov))
+			}
+		}
+		for j := range covs {
+			if covs[j] != nil {
+				covs[j].CopySym(tied)
+			}
+		}
+	}
+	components := make([]*Normal, k)
+	for j := range components {
+		if nk[j] == 0 {
+			components[j] = g.components[j]
+			continue
+		}
+		addDiag(covs[j], settings.Regularization)
+		norm, ok := NewNormal(mus[j], covs[j], g.src)
+		if !ok {
+			return ErrSingularCovariance
+		}
+		components[j] = norm
+	}
+	g.components = components
+	g.setWeights(nk)
+	return nil
+}
+
+// addDiag adds v to the diagonal of a.
+func addDiag(a *mat.SymDense, v float64) {
+	n := a.Symmetric()
+	for i := 0; i < n; i++ {
+		a.SetSym(i, i, a.At(i, i)+v)
+	}
+}
+
+// scaledSym returns f*a.
+func scaledSym(f float64, a *mat.SymDense) *mat.SymDense {
+	var s mat.SymDense
+	s.ScaleSym(f, a)
+	return &s
+}
+
+// AIC returns the Akaike information criterion of the mixture for the rows
+// of x with relative weights,
+//  AIC = 2*NumParameters - 2*LogLikelihood.
+// If weights is nil, then all the weights are 1.
+func (g *GaussianMixture) AIC(x mat.Matrix, weights []float64) float64 {
+	return 2*float64(g.NumParameters()) - 2*g.LogLikelihood(x, weights)
+}
+
+// BIC returns the Bayesian information criterion of the mixture for the rows
+// of x with relative weights,
+//  BIC = NumParameters*log(n) - 2*LogLikelihood
+// where n is the sum of the weights. If weights is nil, then all the weights
+// are 1.
+func (g *GaussianMixture) BIC(x mat.Matrix, weights []float64) float64 {
+	r, _ := x.Dims()
+	n := float64(r)
+	if weights != nil {
+		n = floats.Sum(weights)
+	}
+	return float64(g.NumParameters())*math.Log(n) - 2*g.LogLikelihood(x, weights)
+}
+
+// Component returns the ith component of the mixture and its weight.
+func (g *GaussianMixture) Component(i int) (*Normal, float64) {
+	return g.components[i], g.weights[i]
+}
+
+// CovarianceMatrix returns the covariance matrix of the distribution. Upon
+// return, the value at element {i, j} of the covariance matrix is equal to
+// the covariance of the i^th and j^th variables.
+//  covariance(i, j) = E[(x_i - E[x_i])(x_j - E[x_j])]
+// If the input matrix is nil a new matrix is allocated, otherwise the result
+// is stored in-place into the input.
+func (g *GaussianMixture) CovarianceMatrix(s *mat.SymDense) *mat.SymDense {
+	if s == nil {
+		s = mat.NewSymDense(g.dim, nil)
+	} else if s.Symmetric() != g.dim {
+		panic("gaussianmixture: input matrix size mismatch")
+	}
+	s.ScaleSym(0, s)
+	for j, c := range g.components {
+		cov := c.CovarianceMatrix(nil)
+		cov.SymRankOne(cov, 1, mat.NewVecDense(g.dim, c.mu))
+		s.AddSym(s, scaledSym(g.weights[j], cov))
+	}
+	mean := mat.NewVecDense(g.dim, g.Mean(nil))
+	s.SymRankOne(s, -1, mean)
+	return s
+}
+
+// Dim returns the dimension of the distribution.
+func (g *GaussianMixture) Dim() int {
+	return g.dim
+}
+
+// Len returns the number of components in the mixture.
+func (g *GaussianMixture) Len() int {
+	return len(g.components)
+}
+
+// LogLikelihood returns the weighted sum of the log of the pdf of the
+// rows of x. If weights is nil, then all the weights are 1.
+func (g *GaussianMixture) LogLikelihood(x mat.Matrix, weights []float64) float64 {
+	r, _ := x.Dims()
+	if weights != nil && len(weights) != r {
+		panic(badInputLength)
+	}
+	row := make([]float64, g.dim)
+	var ll float64
+	for i := 0; i < r; i++ {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		if w == 0 {
+			continue
+		}
+		ll += w * g.LogProb(mat.Row(row, i, x))
+	}
+	return ll
+}
+
+// LogProb computes the log of the pdf of the point x.
+func (g *GaussianMixture) LogProb(x []float64) float64 {
+	if len(x) != g.dim {
+		panic(badSizeMismatch)
+	}
+	return g.responsibilities(make([]float64, len(g.components)), x)
+}
+
+// responsibilities stores the posterior probabilities of the components
+// given x into dst and returns the log of the pdf at x.
+func (g *GaussianMixture) responsibilities(dst, x []float64) float64 {
+	for j, c := range g.components {
+		if g.weights[j] == 0 {
+			dst[j] = math.Inf(-1)
+			continue
+		}
+		dst[j] = g.logWeights[j] + c.LogProb(x)
+	}
+	lp := floats.LogSumExp(dst)
+	for j, v := range dst {
+		dst[j] = math.Exp(v - lp)
+	}
+	return lp
+}
+
+// Mean returns the mean of the probability distribution at x. If the
+// input argument is nil, a new slice will be allocated, otherwise the result
+// will be put in-place into the receiver.
+func (g *GaussianMixture) Mean(x []float64) []float64 {
+	x = reuseAs(x, g.dim)
+	for i := range x {
+		x[i] = 0
+	}
+	for j, c := range g.components {
+		floats.AddScaled(x, g.weights[j], c.mu)
+	}
+	return x
+}
+
+// NumParameters returns the number of free parameters of the mixture given
+// its covariance type.
+func (g *GaussianMixture) NumParameters() int {
+	k := len(g.components)
+	d := g.dim
+	n := k - 1 + k*d
+	switch g.covType {
+	case FullCovariance:
+		n += k * d * (d + 1) / 2
+	case DiagonalCovariance:
+		n += k * d
+	case TiedCovariance:
+		n += d * (d + 1) / 2
+	default:
+		panic("gaussianmixture: unknown covariance type")
+	}
+	return n
+}
+
+// Prob computes the value of the probability density function at x.
+func (g *GaussianMixture) Prob(x []float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Rand generates a random number according to the distributon.
+// If the input slice is nil, new memory is allocated, otherwise the result is stored
+// in place.
+func (g *GaussianMixture) Rand(x []float64) []float64 {
+	var u float64
+	if g.src == nil {
+		u = rand.Float64()
+	} else {
+		u = g.src.Float64()
+	}
+	j := len(g.weights) - 1
+	var cum float64
+	for i, w := range g.weights {
+		cum += w
+		if u < cum {
+			j = i
+			break
+		}
+	}
+	for g.weights[j] == 0 {
+		j--
+	}
+	return g.components[j].Rand(x)
+}
+
+// Responsibilities returns the posterior probabilities of the components
+// of the mixture given the point x. If dst is nil, a new slice will be
+// allocated, otherwise the result is stored in place and len(dst) must
+// equal the number of components.
+func (g *GaussianMixture) Responsibilities(dst, x []float64) []float64 {
+	if len(x) != g.dim {
+		panic(badSizeMismatch)
+	}
+	dst = reuseAs(dst, len(g.components))
+	g.responsibilities(dst, x)
+	return dst
+}
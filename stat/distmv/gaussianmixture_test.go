@@ -0,0 +1,215 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func newTestMixture(t *testing.T, src *rand.Rand) *GaussianMixture {
+	var components []*Normal
+	for _, test := range []struct {
+		mu    []float64
+		sigma []float64
+	}{
+		{[]float64{0, 0}, []float64{1, 0.5, 0.5, 1}},
+		{[]float64{6, 1}, []float64{0.5, 0, 0, 2}},
+		{[]float64{-2, 7}, []float64{2, -0.8, -0.8, 1}},
+	} {
+		n, ok := NewNormal(test.mu, mat.NewSymDense(2, test.sigma), src)
+		if !ok {
+			t.Fatalf("bad test")
+		}
+		components = append(components, n)
+	}
+	return NewGaussianMixture([]float64{2, 5, 3}, components, src)
+}
+
+func TestGaussianMixtureProb(t *testing.T) {
+	g := newTestMixture(t, nil)
+	for _, x := range [][]float64{{0, 0}, {3, 2}, {-2, 7}, {10, -10}} {
+		var want float64
+		probs := make([]float64, g.Len())
+		for j := range probs {
+			c, w := g.Component(j)
+			probs[j] = w * c.Prob(x)
+			want += probs[j]
+		}
+		got := g.Prob(x)
+		if !floats.EqualWithinAbsOrRel(got, want, 1e-14, 1e-12) {
+			t.Errorf("Prob mismatch at %v: want %v, got %v", x, want, got)
+		}
+		if math.Abs(g.LogProb(x)-math.Log(want)) > 1e-12 {
+			t.Errorf("LogProb mismatch at %v: want %v, got %v", x, math.Log(want), g.LogProb(x))
+		}
+		floats.Scale(1/want, probs)
+		resp := g.Responsibilities(nil, x)
+		if !floats.EqualApprox(resp, probs, 1e-12) {
+			t.Errorf("Responsibilities mismatch at %v: want %v, got %v", x, probs, resp)
+		}
+	}
+}
+
+func TestGaussianMixtureRand(t *testing.T) {
+	g := newTestMixture(t, rand.New(rand.NewSource(1)))
+	const n = 100000
+	x := mat.NewDense(n, g.Dim(), nil)
+	for i := 0; i < n; i++ {
+		g.Rand(x.RawRowView(i))
+	}
+	mean := make([]float64, g.Dim())
+	for j := range mean {
+		mean[j] = stat.Mean(mat.Col(nil, j, x), nil)
+	}
+	if !floats.EqualApprox(mean, g.Mean(nil), 0.05) {
+		t.Errorf("Mean mismatch: want %v, got %v", g.Mean(nil), mean)
+	}
+	cov := stat.CovarianceMatrix(nil, x, nil)
+	if !mat.EqualApprox(cov, g.CovarianceMatrix(nil), 0.2) {
+		t.Errorf("Covariance mismatch: want %v, got %v", mat.Formatted(g.CovarianceMatrix(nil)), mat.Formatted(cov))
+	}
+}
+
+func sampleMixture(g *GaussianMixture, n int) *mat.Dense {
+	x := mat.NewDense(n, g.Dim(), nil)
+	for i := 0; i < n; i++ {
+		g.Rand(x.RawRowView(i))
+	}
+	return x
+}
+
+func TestFitGaussianMixture(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := newTestMixture(t, src)
+	x := sampleMixture(want, 20000)
+	for _, covType := range []CovarianceType{FullCovariance, DiagonalCovariance, TiedCovariance} {
+		settings := DefaultGaussianMixtureSettings()
+		settings.Covariance = covType
+		g, converged, err := FitGaussianMixture(x, nil, 3, settings, src)
+		if err != nil {
+			t.Fatalf("unexpected error for covariance type %v: %v", covType, err)
+		}
+		if !converged {
+			t.Errorf("EM did not converge for covariance type %v", covType)
+		}
+		for j := 0; j < want.Len(); j++ {
+			wc, ww := want.Component(j)
+			wmu := wc.Mean(nil)
+			// Find the fitted component with the closest mean.
+			var best int
+			for l := 1; l < g.Len(); l++ {
+				c, _ := g.Component(l)
+				bc, _ := g.Component(best)
+				if floats.Distance(c.Mean(nil), wmu, 2) < floats.Distance(bc.Mean(nil), wmu, 2) {
+					best = l
+				}
+			}
+			c, w := g.Component(best)
+			if !floats.EqualApprox(c.Mean(nil), wmu, 0.1) {
+				t.Errorf("Mean mismatch for covariance type %v: want %v, got %v", covType, wmu, c.Mean(nil))
+			}
+			if math.Abs(w-ww) > 0.02 {
+				t.Errorf("Weight mismatch for covariance type %v: want %v, got %v", covType, ww, w)
+			}
+			cov := c.CovarianceMatrix(nil)
+			switch covType {
+			case FullCovariance:
+				if !mat.EqualApprox(cov, wc.CovarianceMatrix(nil), 0.1) {
+					t.Errorf("Covariance mismatch: want %v, got %v", mat.Formatted(wc.CovarianceMatrix(nil)), mat.Formatted(cov))
+				}
+			case DiagonalCovariance:
+				if cov.At(0, 1) != 0 {
+					t.Errorf("Non-zero off-diagonal covariance: %v", mat.Formatted(cov))
+				}
+			case TiedCovariance:
+				c0, _ := g.Component(0)
+				if !mat.Equal(cov, c0.CovarianceMatrix(nil)) {
+					t.Errorf("Covariance not tied: %v, %v", mat.Formatted(cov), mat.Formatted(c0.CovarianceMatrix(nil)))
+				}
+			}
+		}
+	}
+}
+
+func TestGaussianMixtureFitWeights(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := sampleMixture(newTestMixture(t, src), 500)
+	r, c := x.Dims()
+
+	// Fitting with integer weights must be equivalent to fitting with
+	// repeated samples.
+	weights := make([]float64, r)
+	var rep [][]float64
+	for i := range weights {
+		weights[i] = float64(src.Intn(3))
+		for j := 0; j < int(weights[i]); j++ {
+			rep = append(rep, x.RawRowView(i))
+		}
+	}
+	xrep := mat.NewDense(len(rep), c, nil)
+	for i, row := range rep {
+		xrep.SetRow(i, row)
+	}
+
+	g1 := newTestMixture(t, nil)
+	g2 := newTestMixture(t, nil)
+	before := g1.LogLikelihood(x, weights)
+	if _, err := g1.Fit(x, weights, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := g2.Fit(xrep, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	after := g1.LogLikelihood(x, weights)
+	if after < before {
+		t.Errorf("Fit decreased the log-likelihood: before %v, after %v", before, after)
+	}
+	if !floats.EqualWithinAbsOrRel(after, g2.LogLikelihood(xrep, nil), 1e-8, 1e-8) {
+		t.Errorf("Log-likelihood mismatch between weighted and repeated samples: %v, %v", after, g2.LogLikelihood(xrep, nil))
+	}
+	for j := 0; j < g1.Len(); j++ {
+		c1, w1 := g1.Component(j)
+		c2, w2 := g2.Component(j)
+		if math.Abs(w1-w2) > 1e-8 || !floats.EqualApprox(c1.Mean(nil), c2.Mean(nil), 1e-8) ||
+			!mat.EqualApprox(c1.CovarianceMatrix(nil), c2.CovarianceMatrix(nil), 1e-8) {
+			t.Errorf("Component %d mismatch between weighted and repeated samples", j)
+		}
+	}
+}
+
+func TestGaussianMixtureBIC(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := sampleMixture(newTestMixture(t, src), 3000)
+	best := -1
+	bestBIC := math.Inf(1)
+	for k := 1; k <= 5; k++ {
+		g, _, err := FitGaussianMixture(x, nil, k, nil, src)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		wantParams := k - 1 + 2*k + 3*k
+		if g.NumParameters() != wantParams {
+			t.Errorf("NumParameters mismatch: want %v, got %v", wantParams, g.NumParameters())
+		}
+		aic := g.AIC(x, nil)
+		bic := g.BIC(x, nil)
+		if math.Abs(bic-aic-float64(wantParams)*(math.Log(3000)-2)) > 1e-8 {
+			t.Errorf("BIC and AIC inconsistent: %v, %v", bic, aic)
+		}
+		if bic < bestBIC {
+			best = k
+			bestBIC = bic
+		}
+	}
+	if best != 3 {
+		t.Errorf("BIC selected %d components, want 3", best)
+	}
+}
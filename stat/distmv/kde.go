@@ -0,0 +1,355 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/internal/golden"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// KDE is a multivariate kernel density estimate. Its pdf is given by
+//  p(x) = |H|^(-1/2) \sum_i w_i K(H^(-1/2) (x-x_i))
+// where x_i are the samples with normalized weights w_i, K is the kernel
+// and H is the bandwidth matrix. Use NewKDE to construct.
+//
+// For kernels with bounded support, the samples are stored in a k-d tree so
+// that evaluating the density only visits the samples within the support of
+// the kernel.
+type KDE struct {
+	x   mat.Dense
+	z   mat.Dense
+	w   []float64
+	cum []float64
+
+	kernel     Kernel
+	chol       mat.Cholesky
+	lower      mat.TriDense
+	logSqrtDet float64
+	radius     float64
+	tree       *kdTree
+	dim        int
+
+	src *rand.Rand
+}
+
+// NewKDE returns a kernel density estimate from the rows of x with relative
+// weights and the given kernel and bandwidth matrix. If weights is nil, then
+// all the weights are 1. If kernel is nil, GaussianKernel is used.
+//
+// NewKDE panics if x has no rows, if bandwidth.Symmetric() is not the number
+// of columns of x, if len(weights) is not the number of rows of x for non-nil
+// weights, or if any weight is negative or the weights sum to zero. If the
+// bandwidth matrix is not positive definite, the returned boolean is false.
+func NewKDE(x mat.Matrix, weights []float64, kernel Kernel, bandwidth mat.Symmetric, src *rand.Rand) (*KDE, bool) {
+	r, c := x.Dims()
+	if r == 0 {
+		panic(badZeroDimension)
+	}
+	if bandwidth.Symmetric() != c {
+		panic(badSizeMismatch)
+	}
+	if weights != nil && len(weights) != r {
+		panic(badInputLength)
+	}
+	if kernel == nil {
+		kernel = GaussianKernel{}
+	}
+	k := &KDE{
+		w:      make([]float64, r),
+		cum:    make([]float64, r),
+		kernel: kernel,
+		radius: kernel.Radius(c),
+		dim:    c,
+		src:    src,
+	}
+	if !k.chol.Factorize(bandwidth) {
+		return nil, false
+	}
+	k.chol.LTo(&k.lower)
+	k.logSqrtDet = 0.5 * k.chol.LogDet()
+	k.x.Clone(x)
+	for i := range k.w {
+		k.w[i] = 1
+		if weights != nil {
+			if weights[i] < 0 {
+				panic("kde: negative weight")
+			}
+			k.w[i] = weights[i]
+		}
+	}
+	sum := floats.Sum(k.w)
+	if !(sum > 0) {
+		panic("kde: zero weights")
+	}
+	floats.Scale(1/sum, k.w)
+	floats.CumSum(k.cum, k.w)
+
+	// Whiten the samples so that the kernel is radially symmetric.
+	var zt mat.Dense
+	zt.Solve(&k.lower, k.x.T())
+	k.z.Clone(zt.T())
+	if !math.IsInf(k.radius, 1) {
+		k.tree = newKDTree(&k.z)
+	}
+	return k, true
+}
+
+// CovarianceMatrix returns the covariance matrix of the distribution, which
+// is the weighted covariance of the samples plus the bandwidth matrix. Upon
+// return, the value at element {i, j} of the covariance matrix is equal to
+// the covariance of the i^th and j^th variables.
+//  covariance(i, j) = E[(x_i - E[x_i])(x_j - E[x_j])]
+// If the input matrix is nil a new matrix is allocated, otherwise the result
+// is stored in-place into the input.
+func (k *KDE) CovarianceMatrix(s *mat.SymDense) *mat.SymDense {
+	if s == nil {
+		s = mat.NewSymDense(k.dim, nil)
+	} else if s.Symmetric() != k.dim {
+		panic("kde: input matrix size mismatch")
+	}
+	mean := k.Mean(nil)
+	r, _ := k.x.Dims()
+	d := make([]float64, k.dim)
+	s.ScaleSym(0, s)
+	for i := 0; i < r; i++ {
+		floats.SubTo(d, k.x.RawRowView(i), mean)
+		s.SymRankOne(s, k.w[i], mat.NewVecDense(k.dim, d))
+	}
+	s.AddSym(s, k.chol.To(nil))
+	return s
+}
+
+// Dim returns the dimension of the distribution.
+func (k *KDE) Dim() int {
+	return k.dim
+}
+
+// LogProb computes the log of the pdf of the point x.
+func (k *KDE) LogProb(x []float64) float64 {
+	if len(x) != k.dim {
+		panic(badSizeMismatch)
+	}
+	z := make([]float64, k.dim)
+	k.whiten(z, x)
+	if k.tree != nil {
+		var sum float64
+		k.tree.within(z, k.radius, func(i int, d2 float64) {
+			sum += k.w[i] * math.Exp(k.kernel.LogProb(d2, k.dim))
+		})
+		return math.Log(sum) - k.logSqrtDet
+	}
+
+	// Compute the log of the sum stably by factoring out the largest term
+	// so that the density is accurate far in the tails of unbounded
+	// kernels.
+	r, _ := k.z.Dims()
+	lp := make([]float64, r)
+	for i := range lp {
+		if k.w[i] == 0 {
+			lp[i] = math.Inf(-1)
+			continue
+		}
+		var d2 float64
+		for j, v := range k.z.RawRowView(i) {
+			d := v - z[j]
+			d2 += d * d
+		}
+		lp[i] = math.Log(k.w[i]) + k.kernel.LogProb(d2, k.dim)
+	}
+	return floats.LogSumExp(lp) - k.logSqrtDet
+}
+
+// whiten stores L^-1 x into dst, where L is the Cholesky factor of the
+// bandwidth matrix.
+func (k *KDE) whiten(dst, x []float64) {
+	mat.NewVecDense(k.dim, dst).SolveVec(&k.lower, mat.NewVecDense(k.dim, x))
+}
+
+// Mean returns the mean of the probability distribution at x. If the
+// input argument is nil, a new slice will be allocated, otherwise the result
+// will be put in-place into the receiver.
+func (k *KDE) Mean(x []float64) []float64 {
+	x = reuseAs(x, k.dim)
+	for i := range x {
+		x[i] = 0
+	}
+	for i, w := range k.w {
+		floats.AddScaled(x, w, k.x.RawRowView(i))
+	}
+	return x
+}
+
+// Prob computes the value of the probability density function at x.
+func (k *KDE) Prob(x []float64) float64 {
+	return math.Exp(k.LogProb(x))
+}
+
+// Rand generates a random number according to the distributon.
+// If the input slice is nil, new memory is allocated, otherwise the result is stored
+// in place.
+func (k *KDE) Rand(x []float64) []float64 {
+	x = reuseAs(x, k.dim)
+	var rnd float64
+	if k.src == nil {
+		rnd = rand.Float64()
+	} else {
+		rnd = k.src.Float64()
+	}
+	i := sort.Search(len(k.cum), func(i int) bool { return k.cum[i] > rnd })
+	if i == len(k.cum) {
+		i = len(k.cum) - 1
+	}
+	for k.w[i] == 0 {
+		i--
+	}
+	u := make([]float64, k.dim)
+	k.kernel.Rand(u, k.src)
+	xv := mat.NewVecDense(k.dim, x)
+	xv.MulVec(&k.lower, mat.NewVecDense(k.dim, u))
+	floats.Add(x, k.x.RawRowView(i))
+	return x
+}
+
+// scaledCovariance returns the weighted covariance matrix of the rows of x
+// and the effective sample size. The weights are rescaled to sum to the
+// effective sample size, so that the covariance is unbiased for unequal
+// weights.
+func scaledCovariance(x mat.Matrix, weights []float64) (*mat.SymDense, float64) {
+	r, _ := x.Dims()
+	if weights == nil {
+		return stat.CovarianceMatrix(nil, x, nil), float64(r)
+	}
+	if len(weights) != r {
+		panic(badInputLength)
+	}
+	sum := floats.Sum(weights)
+	n := sum * sum / floats.Dot(weights, weights)
+	w := make([]float64, r)
+	copy(w, weights)
+	floats.Scale(n/sum, w)
+	return stat.CovarianceMatrix(nil, x, w), n
+}
+
+// ScottBandwidth returns the bandwidth matrix for a kernel density estimate
+// of the rows of x with relative weights given by Scott's rule,
+//  H = n^(-2/(d+4)) Σ
+// where Σ is the covariance of the samples, d is the dimension and n is the
+// effective sample size. If weights is nil, then all the weights are 1.
+func ScottBandwidth(x mat.Matrix, weights []float64) *mat.SymDense {
+	_, c := x.Dims()
+	cov, n := scaledCovariance(x, weights)
+	cov.ScaleSym(math.Pow(n, -2/float64(c+4)), cov)
+	return cov
+}
+
+// SilvermanBandwidth returns the bandwidth matrix for a kernel density
+// estimate of the rows of x with relative weights given by Silverman's rule
+// of thumb,
+//  H = (4/(d+2))^(2/(d+4)) n^(-2/(d+4)) Σ
+// where Σ is the covariance of the samples, d is the dimension and n is the
+// effective sample size. If weights is nil, then all the weights are 1.
+func SilvermanBandwidth(x mat.Matrix, weights []float64) *mat.SymDense {
+	_, c := x.Dims()
+	d := float64(c)
+	cov, n := scaledCovariance(x, weights)
+	cov.ScaleSym(math.Pow(4/(d+2)/n, 2/(d+4)), cov)
+	return cov
+}
+
+// CVBandwidth returns the bandwidth matrix for a kernel density estimate of
+// the rows of x with relative weights of the form H = h² Σ, where Σ is the
+// covariance of the samples, with the scale h chosen to maximize the
+// leave-one-out cross-validated log-likelihood
+//  \sum_i w_i log p_{-i}(x_i)
+// where p_{-i} is the estimate with the ith sample removed. If weights is nil,
+// then all the weights are 1. If kernel is nil, GaussianKernel is used.
+//
+// The scale is searched for within a factor of 20 of the scale given by
+// ScottBandwidth. CVBandwidth panics if the covariance of the samples is not
+// positive definite.
+func CVBandwidth(x mat.Matrix, weights []float64, kernel Kernel) *mat.SymDense {
+	r, c := x.Dims()
+	if r < 2 {
+		panic("kde: too few samples")
+	}
+	if kernel == nil {
+		kernel = GaussianKernel{}
+	}
+	cov, n := scaledCovariance(x, weights)
+	h0 := math.Pow(n, -1/float64(c+4))
+	w := make([]float64, r)
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			w[i] = weights[i]
+		}
+	}
+	sum := floats.Sum(w)
+
+	// Whiten the samples by the covariance so that the kernel with
+	// bandwidth h² Σ is the standard kernel scaled by h.
+	var chol mat.Cholesky
+	if !chol.Factorize(cov) {
+		panic("kde: sample covariance not positive definite")
+	}
+	var lower mat.TriDense
+	chol.LTo(&lower)
+	var zt, z mat.Dense
+	zt.Solve(&lower, x.T())
+	z.Clone(zt.T())
+	radius := kernel.Radius(c)
+	var tree *kdTree
+	if !math.IsInf(radius, 1) {
+		tree = newKDTree(&z)
+	}
+
+	cv := func(logH float64) float64 {
+		h := math.Exp(logH)
+		// The log determinant of the covariance does not depend on h
+		// and is omitted.
+		logNorm := float64(c) * logH
+		var ll float64
+		lp := make([]float64, 0, r)
+		for i := 0; i < r; i++ {
+			if w[i] == 0 || sum-w[i] <= 0 {
+				continue
+			}
+			zi := z.RawRowView(i)
+			lp = lp[:0]
+			add := func(j int, d2 float64) {
+				if j == i || w[j] == 0 {
+					return
+				}
+				lp = append(lp, math.Log(w[j])+kernel.LogProb(d2/(h*h), c))
+			}
+			if tree != nil {
+				tree.within(zi, radius*h, add)
+			} else {
+				for j := 0; j < r; j++ {
+					var d2 float64
+					for l, v := range z.RawRowView(j) {
+						d := v - zi[l]
+						d2 += d * d
+					}
+					add(j, d2)
+				}
+			}
+			if len(lp) == 0 {
+				return math.Inf(-1)
+			}
+			ll += w[i] * (floats.LogSumExp(lp) - logNorm - math.Log(sum-w[i]))
+		}
+		return ll
+	}
+	h := math.Exp(golden.Max(cv, math.Log(h0/20), math.Log(h0*20), 1e-6))
+	cov.ScaleSym(h*h, cov)
+	return cov
+}
@@ -0,0 +1,206 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func newTestKDESamples(n int, src *rand.Rand) *mat.Dense {
+	x := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		a := src.NormFloat64()
+		b := src.NormFloat64()
+		x.Set(i, 0, 1+a)
+		x.Set(i, 1, -2+0.6*a+0.5*b)
+	}
+	return x
+}
+
+func TestKDEProb(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := newTestKDESamples(200, src)
+	weights := make([]float64, 200)
+	for i := range weights {
+		weights[i] = src.Float64()
+	}
+	bandwidth := mat.NewSymDense(2, []float64{0.3, 0.1, 0.1, 0.2})
+	var chol mat.Cholesky
+	chol.Factorize(bandwidth)
+	var inv mat.SymDense
+	chol.InverseTo(&inv)
+	logDet := chol.LogDet()
+	for _, kernel := range []Kernel{
+		GaussianKernel{},
+		EpanechnikovKernel{},
+		UniformKernel{},
+	} {
+		for _, w := range [][]float64{nil, weights} {
+			k, ok := NewKDE(x, w, kernel, bandwidth, nil)
+			if !ok {
+				t.Fatalf("unexpected failure of NewKDE")
+			}
+			sum := 200.0
+			if w != nil {
+				sum = floats.Sum(w)
+			}
+			for _, q := range [][]float64{{1, -2}, {0, 0}, {2.5, -1}, {-1, -3.5}} {
+				var want float64
+				d := mat.NewVecDense(2, nil)
+				for i := 0; i < 200; i++ {
+					d.SubVec(mat.NewVecDense(2, q), mat.NewVecDense(2, x.RawRowView(i)))
+					d2 := mat.Inner(d, &inv, d)
+					wi := 1.0
+					if w != nil {
+						wi = w[i]
+					}
+					want += wi / sum * math.Exp(kernel.LogProb(d2, 2)-0.5*logDet)
+				}
+				got := k.Prob(q)
+				if !floats.EqualWithinAbsOrRel(got, want, 1e-14, 1e-10) {
+					t.Errorf("%T, weighted %t: Prob mismatch at %v: want %v, got %v", kernel, w != nil, q, want, got)
+				}
+				if want > 0 && math.Abs(k.LogProb(q)-math.Log(want)) > 1e-10 {
+					t.Errorf("%T, weighted %t: LogProb mismatch at %v: want %v, got %v", kernel, w != nil, q, math.Log(want), k.LogProb(q))
+				}
+			}
+		}
+	}
+
+	// The log density of the Gaussian kernel should remain finite far into
+	// the tails.
+	k, _ := NewKDE(x, nil, nil, bandwidth, nil)
+	lp := k.LogProb([]float64{100, 100})
+	if math.IsInf(lp, 0) || math.IsNaN(lp) {
+		t.Errorf("LogProb not finite in the tail: got %v", lp)
+	}
+}
+
+func TestKDEWeights(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := newTestKDESamples(50, src)
+	weights := make([]float64, 50)
+	var rep []float64
+	for i := range weights {
+		weights[i] = float64(src.Intn(4))
+		for j := 0; j < int(weights[i]); j++ {
+			rep = append(rep, x.RawRowView(i)...)
+		}
+	}
+	xRep := mat.NewDense(len(rep)/2, 2, rep)
+	bandwidth := mat.NewSymDense(2, []float64{0.3, 0.1, 0.1, 0.2})
+	for _, kernel := range []Kernel{
+		GaussianKernel{},
+		EpanechnikovKernel{},
+		UniformKernel{},
+	} {
+		kw, _ := NewKDE(x, weights, kernel, bandwidth, nil)
+		kr, _ := NewKDE(xRep, nil, kernel, bandwidth, nil)
+		for _, q := range [][]float64{{1, -2}, {0, 0}, {2.5, -1}} {
+			if !floats.EqualWithinAbsOrRel(kw.Prob(q), kr.Prob(q), 1e-14, 1e-12) {
+				t.Errorf("%T: weighted and repeated samples mismatch at %v: %v != %v", kernel, q, kw.Prob(q), kr.Prob(q))
+			}
+		}
+		if !floats.EqualApprox(kw.Mean(nil), kr.Mean(nil), 1e-12) {
+			t.Errorf("%T: mean mismatch", kernel)
+		}
+		if !mat.EqualApprox(kw.CovarianceMatrix(nil), kr.CovarianceMatrix(nil), 1e-12) {
+			t.Errorf("%T: covariance mismatch", kernel)
+		}
+	}
+}
+
+func TestKDERand(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := newTestKDESamples(100, src)
+	weights := make([]float64, 100)
+	for i := range weights {
+		weights[i] = src.Float64()
+	}
+	bandwidth := mat.NewSymDense(2, []float64{0.3, 0.1, 0.1, 0.2})
+	for _, kernel := range []Kernel{
+		GaussianKernel{},
+		EpanechnikovKernel{},
+		UniformKernel{},
+	} {
+		k, _ := NewKDE(x, weights, kernel, bandwidth, src)
+		const n = 100000
+		samples := mat.NewDense(n, 2, nil)
+		for i := 0; i < n; i++ {
+			k.Rand(samples.RawRowView(i))
+		}
+		mean := make([]float64, 2)
+		for j := range mean {
+			mean[j] = stat.Mean(mat.Col(nil, j, samples), nil)
+		}
+		if !floats.EqualApprox(mean, k.Mean(nil), 1e-2) {
+			t.Errorf("%T: mean mismatch: want %v, got %v", kernel, k.Mean(nil), mean)
+		}
+		cov := stat.CovarianceMatrix(nil, samples, nil)
+		if !mat.EqualApprox(cov, k.CovarianceMatrix(nil), 2e-2) {
+			t.Errorf("%T: covariance mismatch: want %v, got %v", kernel, mat.Formatted(k.CovarianceMatrix(nil)), mat.Formatted(cov))
+		}
+	}
+}
+
+func TestKDEBandwidth(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := newTestKDESamples(300, src)
+	cov := stat.CovarianceMatrix(nil, x, nil)
+
+	scott := ScottBandwidth(x, nil)
+	var want mat.SymDense
+	want.ScaleSym(math.Pow(300, -1.0/3), cov)
+	if !mat.EqualApprox(scott, &want, 1e-12) {
+		t.Errorf("Scott bandwidth mismatch: want %v, got %v", mat.Formatted(&want), mat.Formatted(scott))
+	}
+	// In two dimensions Silverman's factor (4/(d+2))^(2/(d+4)) is 1.
+	silverman := SilvermanBandwidth(x, nil)
+	if !mat.EqualApprox(silverman, &want, 1e-12) {
+		t.Errorf("Silverman bandwidth mismatch: want %v, got %v", mat.Formatted(&want), mat.Formatted(silverman))
+	}
+
+	// Equal weights give the same bandwidth as no weights.
+	ones := make([]float64, 300)
+	for i := range ones {
+		ones[i] = 2
+	}
+	if !mat.EqualApprox(ScottBandwidth(x, ones), scott, 1e-12) {
+		t.Errorf("Scott bandwidth mismatch for equal weights")
+	}
+
+	for _, kernel := range []Kernel{
+		GaussianKernel{},
+		EpanechnikovKernel{},
+		UniformKernel{},
+	} {
+		cv := CVBandwidth(x, nil, kernel)
+		// The cross-validated bandwidth is a multiple of the sample
+		// covariance within a reasonable factor of Scott's rule.
+		ratio := cv.At(0, 0) / scott.At(0, 0)
+		if ratio < 0.1 || ratio > 10 {
+			t.Errorf("%T: cross-validated bandwidth far from Scott's rule: ratio %v", kernel, ratio)
+		}
+		var scaled mat.SymDense
+		scaled.ScaleSym(ratio, scott)
+		if !mat.EqualApprox(cv, &scaled, 1e-10) {
+			t.Errorf("%T: cross-validated bandwidth not a multiple of the covariance", kernel)
+		}
+	}
+}
+
+func TestKDEBadBandwidth(t *testing.T) {
+	x := mat.NewDense(3, 2, []float64{1, 2, 3, 4, 5, 7})
+	_, ok := NewKDE(x, nil, nil, mat.NewSymDense(2, []float64{1, 2, 2, 1}), nil)
+	if ok {
+		t.Errorf("expected failure for bandwidth that is not positive definite")
+	}
+}
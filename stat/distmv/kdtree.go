@@ -0,0 +1,136 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// kdLeafSize is the maximum number of points in a leaf of a kdTree.
+const kdLeafSize = 16
+
+// kdTree is a k-d tree over the rows of a matrix supporting fixed-radius
+// neighbour queries.
+type kdTree struct {
+	points *mat.Dense
+	idx    []int
+	root   *kdNode
+}
+
+// kdNode is a node of a kdTree holding the points idx[lo:hi] within the
+// bounding box [min, max].
+type kdNode struct {
+	lo, hi      int
+	min, max    []float64
+	left, right *kdNode
+}
+
+// newKDTree returns a k-d tree over the rows of points.
+func newKDTree(points *mat.Dense) *kdTree {
+	r, _ := points.Dims()
+	t := &kdTree{
+		points: points,
+		idx:    make([]int, r),
+	}
+	for i := range t.idx {
+		t.idx[i] = i
+	}
+	t.root = t.build(0, r)
+	return t
+}
+
+// build returns a node holding the points idx[lo:hi], splitting at the median
+// of the dimension with the largest spread.
+func (t *kdTree) build(lo, hi int) *kdNode {
+	_, c := t.points.Dims()
+	n := &kdNode{
+		lo:  lo,
+		hi:  hi,
+		min: make([]float64, c),
+		max: make([]float64, c),
+	}
+	for j := range n.min {
+		n.min[j] = math.Inf(1)
+		n.max[j] = math.Inf(-1)
+	}
+	for _, i := range t.idx[lo:hi] {
+		for j, v := range t.points.RawRowView(i) {
+			n.min[j] = math.Min(n.min[j], v)
+			n.max[j] = math.Max(n.max[j], v)
+		}
+	}
+	if hi-lo <= kdLeafSize {
+		return n
+	}
+	var dim int
+	for j := range n.min {
+		if n.max[j]-n.min[j] > n.max[dim]-n.min[dim] {
+			dim = j
+		}
+	}
+	if n.max[dim] == n.min[dim] {
+		// All of the points coincide.
+		return n
+	}
+	sort.Sort(byDim{t: t, idx: t.idx[lo:hi], dim: dim})
+	mid := lo + (hi-lo)/2
+	n.left = t.build(lo, mid)
+	n.right = t.build(mid, hi)
+	return n
+}
+
+// byDim sorts point indices by the value of the points in a dimension.
+type byDim struct {
+	t   *kdTree
+	idx []int
+	dim int
+}
+
+func (b byDim) Len() int { return len(b.idx) }
+func (b byDim) Less(i, j int) bool {
+	return b.t.points.At(b.idx[i], b.dim) < b.t.points.At(b.idx[j], b.dim)
+}
+func (b byDim) Swap(i, j int) { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
+
+// within calls fn with the row index and squared distance of each point
+// within distance r of q.
+func (t *kdTree) within(q []float64, r float64, fn func(i int, d2 float64)) {
+	t.withinNode(t.root, q, r*r, fn)
+}
+
+func (t *kdTree) withinNode(n *kdNode, q []float64, r2 float64, fn func(i int, d2 float64)) {
+	var box float64
+	for j, v := range q {
+		var d float64
+		switch {
+		case v < n.min[j]:
+			d = n.min[j] - v
+		case v > n.max[j]:
+			d = v - n.max[j]
+		}
+		box += d * d
+	}
+	if box > r2 {
+		return
+	}
+	if n.left != nil {
+		t.withinNode(n.left, q, r2, fn)
+		t.withinNode(n.right, q, r2, fn)
+		return
+	}
+	for _, i := range t.idx[n.lo:n.hi] {
+		var d2 float64
+		for j, v := range t.points.RawRowView(i) {
+			d := v - q[j]
+			d2 += d * d
+		}
+		if d2 <= r2 {
+			fn(i, d2)
+		}
+	}
+}
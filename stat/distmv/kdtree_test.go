@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestKDTreeWithin(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, dim int
+	}{
+		{1, 1},
+		{10, 2},
+		{500, 1},
+		{500, 2},
+		{1000, 3},
+	} {
+		points := mat.NewDense(test.n, test.dim, nil)
+		for i := 0; i < test.n; i++ {
+			for j := 0; j < test.dim; j++ {
+				points.Set(i, j, src.NormFloat64())
+			}
+		}
+		// Add duplicated points to exercise splits of equal values.
+		if test.n > 1 {
+			points.SetRow(test.n-1, points.RawRowView(0))
+		}
+		tree := newKDTree(points)
+		for _, r := range []float64{0, 0.1, 0.5, 1, 10} {
+			for k := 0; k < 20; k++ {
+				q := make([]float64, test.dim)
+				for j := range q {
+					q[j] = 1.5 * src.NormFloat64()
+				}
+				if k == 0 {
+					copy(q, points.RawRowView(0))
+				}
+				var want []int
+				for i := 0; i < test.n; i++ {
+					var d2 float64
+					for j, v := range points.RawRowView(i) {
+						d := v - q[j]
+						d2 += d * d
+					}
+					if d2 <= r*r {
+						want = append(want, i)
+					}
+				}
+				var got []int
+				tree.within(q, r, func(i int, d2 float64) {
+					got = append(got, i)
+				})
+				sort.Ints(got)
+				if len(got) != len(want) {
+					t.Errorf("n=%d, dim=%d, r=%v: mismatched neighbour count: want %d, got %d", test.n, test.dim, r, len(want), len(got))
+					continue
+				}
+				for i := range got {
+					if got[i] != want[i] {
+						t.Errorf("n=%d, dim=%d, r=%v: neighbour mismatch: want %v, got %v", test.n, test.dim, r, want, got)
+						break
+					}
+				}
+			}
+		}
+	}
+}
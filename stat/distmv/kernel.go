@@ -0,0 +1,148 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Kernel is a radially symmetric probability density used as a smoothing
+// kernel in kernel density estimation. Kernels are scaled to have identity
+// covariance, so that the bandwidth matrix of a KDE is the covariance of its
+// kernel.
+type Kernel interface {
+	// LogProb returns the log of the density of the kernel in dim
+	// dimensions at a point with squared distance r2 from the origin.
+	LogProb(r2 float64, dim int) float64
+
+	// Radius returns the radius of the support of the kernel in dim
+	// dimensions, or +Inf if the support is unbounded.
+	Radius(dim int) float64
+
+	// Rand stores a random sample drawn from the kernel in len(x)
+	// dimensions into x using src. If src is nil, the global source in
+	// math/rand is used.
+	Rand(x []float64, src *rand.Rand)
+}
+
+// logUnitBallVolume returns the log of the volume of the unit ball in dim
+// dimensions.
+func logUnitBallVolume(dim int) float64 {
+	d := float64(dim)
+	lg, _ := math.Lgamma(d/2 + 1)
+	return d/2*math.Log(math.Pi) - lg
+}
+
+// randDirection stores a point drawn uniformly from the unit sphere into x.
+func randDirection(x []float64, src *rand.Rand) {
+	for {
+		for i := range x {
+			if src == nil {
+				x[i] = rand.NormFloat64()
+			} else {
+				x[i] = src.NormFloat64()
+			}
+		}
+		norm := floats.Norm(x, 2)
+		if norm > 0 {
+			floats.Scale(1/norm, x)
+			return
+		}
+	}
+}
+
+// GaussianKernel is the standard multivariate normal kernel.
+type GaussianKernel struct{}
+
+// LogProb returns the log of the density of the kernel in dim dimensions at
+// a point with squared distance r2 from the origin.
+func (GaussianKernel) LogProb(r2 float64, dim int) float64 {
+	return -0.5*float64(dim)*logTwoPi - 0.5*r2
+}
+
+// Radius returns +Inf.
+func (GaussianKernel) Radius(dim int) float64 {
+	return math.Inf(1)
+}
+
+// Rand stores a random sample drawn from the kernel into x.
+func (GaussianKernel) Rand(x []float64, src *rand.Rand) {
+	for i := range x {
+		if src == nil {
+			x[i] = rand.NormFloat64()
+		} else {
+			x[i] = src.NormFloat64()
+		}
+	}
+}
+
+// EpanechnikovKernel is the multivariate Epanechnikov kernel with density
+// proportional to 1-r²/(d+4) within the ball of radius √(d+4) in d
+// dimensions.
+type EpanechnikovKernel struct{}
+
+// LogProb returns the log of the density of the kernel in dim dimensions at
+// a point with squared distance r2 from the origin.
+func (EpanechnikovKernel) LogProb(r2 float64, dim int) float64 {
+	a2 := float64(dim + 4)
+	if r2 >= a2 {
+		return math.Inf(-1)
+	}
+	d := float64(dim)
+	// The density is (d+2)/(2 V_d a^d) (1 - r²/a²), where V_d is the
+	// volume of the unit ball and a is the radius of the support.
+	return math.Log((d+2)/2) - logUnitBallVolume(dim) - d/2*math.Log(a2) + math.Log1p(-r2/a2)
+}
+
+// Radius returns the radius of the support of the kernel, √(dim+4).
+func (EpanechnikovKernel) Radius(dim int) float64 {
+	return math.Sqrt(float64(dim + 4))
+}
+
+// Rand stores a random sample drawn from the kernel into x.
+func (EpanechnikovKernel) Rand(x []float64, src *rand.Rand) {
+	// The squared radius of the canonical kernel on the unit ball is
+	// Beta(d/2, 2) distributed.
+	d := len(x)
+	randDirection(x, src)
+	r2 := distuv.Beta{Alpha: float64(d) / 2, Beta: 2, Source: src}.Rand()
+	floats.Scale(math.Sqrt(r2*float64(d+4)), x)
+}
+
+// UniformKernel is the multivariate uniform kernel on the ball of radius
+// √(d+2) in d dimensions.
+type UniformKernel struct{}
+
+// LogProb returns the log of the density of the kernel in dim dimensions at
+// a point with squared distance r2 from the origin.
+func (UniformKernel) LogProb(r2 float64, dim int) float64 {
+	a2 := float64(dim + 2)
+	if r2 > a2 {
+		return math.Inf(-1)
+	}
+	return -logUnitBallVolume(dim) - float64(dim)/2*math.Log(a2)
+}
+
+// Radius returns the radius of the support of the kernel, √(dim+2).
+func (UniformKernel) Radius(dim int) float64 {
+	return math.Sqrt(float64(dim + 2))
+}
+
+// Rand stores a random sample drawn from the kernel into x.
+func (UniformKernel) Rand(x []float64, src *rand.Rand) {
+	d := len(x)
+	randDirection(x, src)
+	var u float64
+	if src == nil {
+		u = rand.Float64()
+	} else {
+		u = src.Float64()
+	}
+	floats.Scale(math.Sqrt(float64(d+2))*math.Pow(u, 1/float64(d)), x)
+}
@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distmv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestKernelMoments(t *testing.T) {
+	for _, kernel := range []Kernel{
+		GaussianKernel{},
+		EpanechnikovKernel{},
+		UniformKernel{},
+	} {
+		for dim := 1; dim <= 4; dim++ {
+			// Integrate the density and the squared radius over spherical
+			// shells using the composite midpoint rule.
+			radius := kernel.Radius(dim)
+			if math.IsInf(radius, 1) {
+				radius = 12
+			}
+			const n = 100000
+			dr := radius / n
+			// The surface area of the unit sphere is d times the volume
+			// of the unit ball.
+			logSurface := math.Log(float64(dim)) + logUnitBallVolume(dim)
+			var mass, r2Mean float64
+			for i := 0; i < n; i++ {
+				r := (float64(i) + 0.5) * dr
+				p := math.Exp(kernel.LogProb(r*r, dim)+logSurface) * math.Pow(r, float64(dim-1)) * dr
+				mass += p
+				r2Mean += r * r * p
+			}
+			if math.Abs(mass-1) > 1e-6 {
+				t.Errorf("%T dim %d: density does not integrate to 1, got %v", kernel, dim, mass)
+			}
+			if math.Abs(r2Mean-float64(dim)) > 1e-5 {
+				t.Errorf("%T dim %d: covariance is not identity, E[r²] = %v", kernel, dim, r2Mean)
+			}
+		}
+	}
+}
+
+func TestKernelRand(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, kernel := range []Kernel{
+		GaussianKernel{},
+		EpanechnikovKernel{},
+		UniformKernel{},
+	} {
+		for dim := 1; dim <= 3; dim++ {
+			const n = 100000
+			x := mat.NewDense(n, dim, nil)
+			radius := kernel.Radius(dim)
+			for i := 0; i < n; i++ {
+				row := x.RawRowView(i)
+				kernel.Rand(row, src)
+				if floats.Norm(row, 2) > radius {
+					t.Errorf("%T dim %d: sample outside support: %v", kernel, dim, row)
+				}
+			}
+			cov := stat.CovarianceMatrix(nil, x, nil)
+			want := mat.NewSymDense(dim, nil)
+			for i := 0; i < dim; i++ {
+				want.SetSym(i, i, 1)
+			}
+			if !mat.EqualApprox(cov, want, 2e-2) {
+				t.Errorf("%T dim %d: covariance mismatch: got %v", kernel, dim, mat.Formatted(cov))
+			}
+		}
+	}
+}
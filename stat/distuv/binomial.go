@@ -0,0 +1,328 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Binomial represents the binomial distribution, the distribution of the
+// number of successes in N independent trials that each succeed with
+// probability P. N must be a non-negative integer and P must be between
+// 0 and 1.
+// More information at https://en.wikipedia.org/wiki/Binomial_distribution.
+type Binomial struct {
+	N      float64
+	P      float64
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative distribution function at x.
+func (b Binomial) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	if x >= b.N {
+		return 1
+	}
+	k := math.Floor(x)
+	return mathext.RegIncBeta(b.N-k, k+1, 1-b.P)
+}
+
+// ConjugateUpdate updates the parameters of the distribution from the sufficient
+// statistics of a set of samples. The sufficient statistics, suffStat, have been
+// observed with nSamples observations. The prior values of the distribution are those
+// currently in the distribution, and have been observed with priorStrength samples.
+//
+// The number of trials, N, is taken to be known and is not modified. For the
+// binomial distribution, the sufficient statistic is the mean of the samples
+// divided by N, and the conjugate prior is a Beta distribution on P.
+// The prior is having seen priorStrength[0] samples with success probability
+// Binomial.P. As a result of this function, Binomial.P is updated based on the
+// weighted samples, and priorStrength is modified to include the new number of
+// samples observed.
+//
+// This function panics if len(suffStat) != 1 or len(priorStrength) != 1.
+func (b *Binomial) ConjugateUpdate(suffStat []float64, nSamples float64, priorStrength []float64) {
+	if len(suffStat) != 1 {
+		panic("binomial: incorrect suffStat length")
+	}
+	if len(priorStrength) != 1 {
+		panic("binomial: incorrect priorStrength length")
+	}
+
+	totalSamples := nSamples + priorStrength[0]
+
+	totalSum := nSamples * suffStat[0]
+	if !(priorStrength[0] == 0) {
+		totalSum += priorStrength[0] * b.P
+	}
+	b.P = totalSum / totalSamples
+	priorStrength[0] = totalSamples
+}
+
+// Entropy returns the entropy of the distribution.
+func (b Binomial) Entropy() float64 {
+	return discreteEntropy(b.LogProb, 0, b.N, b.Mode())
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (b Binomial) ExKurtosis() float64 {
+	v := b.P * (1 - b.P)
+	return (1 - 6*v) / (b.N * v)
+}
+
+// Fit sets the parameter P of the probability distribution from the
+// data samples x with relative weights w. The number of trials, N, is
+// not modified.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (b *Binomial) Fit(samples, weights []float64) {
+	suffStat := make([]float64, b.NumSuffStat())
+	nSamples := b.SuffStat(suffStat, samples, weights)
+	b.ConjugateUpdate(suffStat, nSamples, make([]float64, b.NumSuffStat()))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// mass function at x.
+func (b Binomial) LogProb(x float64) float64 {
+	if x < 0 || x > b.N || math.Floor(x) != x {
+		return math.Inf(-1)
+	}
+	switch b.P {
+	case 0:
+		if x == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	case 1:
+		if x == b.N {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	lg1, _ := math.Lgamma(b.N + 1)
+	lg2, _ := math.Lgamma(x + 1)
+	lg3, _ := math.Lgamma(b.N - x + 1)
+	return lg1 - lg2 - lg3 + x*math.Log(b.P) + (b.N-x)*math.Log1p(-b.P)
+}
+
+// Mean returns the mean of the probability distribution.
+func (b Binomial) Mean() float64 {
+	return b.N * b.P
+}
+
+// Median returns the median of the probability distribution.
+func (b Binomial) Median() float64 {
+	return b.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (b Binomial) Mode() float64 {
+	return math.Min(math.Floor((b.N+1)*b.P), b.N)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Binomial) NumParameters() int {
+	return 2
+}
+
+// NumSuffStat returns the number of sufficient statistics for the distribution.
+func (Binomial) NumSuffStat() int {
+	return 1
+}
+
+// Prob computes the value of the probability mass function at x.
+func (b Binomial) Prob(x float64) float64 {
+	return math.Exp(b.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative distribution function, that
+// is the smallest integer k such that CDF(k) >= p.
+func (b Binomial) Quantile(p float64) float64 {
+	if b.P == 0 {
+		// The distribution is concentrated at zero.
+		return discreteQuantile(b.CDF, p, 0, 0, 0)
+	}
+	return discreteQuantile(b.CDF, p, 0, b.N, b.Mean())
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (b Binomial) Rand() float64 {
+	unifrnd := rand.Float64
+	if b.Source != nil {
+		unifrnd = b.Source.Float64
+	}
+
+	// Sample the number of successes with the smaller probability, and
+	// use symmetry to recover the sample for P > 0.5.
+	p := b.P
+	if p > 0.5 {
+		p = 1 - p
+	}
+	n := b.N
+	var k float64
+	switch {
+	case p == 0:
+		k = 0
+	case n*p < 10:
+		k = binomialInversion(n, p, unifrnd)
+	default:
+		k = binomialBTRS(n, p, unifrnd)
+	}
+	if p != b.P {
+		return n - k
+	}
+	return k
+}
+
+// binomialInversion samples from the binomial distribution with parameters
+// n and p <= 0.5 by sequential search of the CDF, which needs n*p+1
+// probability evaluations on average.
+func binomialInversion(n, p float64, unifrnd func() float64) float64 {
+	q := 1 - p
+	s := p / q
+	f0 := math.Exp(n * math.Log1p(-p))
+	for {
+		u := unifrnd()
+		f := f0
+		for k := 0.0; k <= n; k++ {
+			if u < f {
+				return k
+			}
+			u -= f
+			f *= s * (n - k) / (k + 1)
+		}
+		// Round-off left u unassigned; try again.
+	}
+}
+
+// binomialBTRS samples from the binomial distribution with parameters n and
+// p <= 0.5 where n*p >= 10 using the transformed rejection method with squeeze
+// (BTRS) from
+//  Hörmann, Wolfgang. "The generation of binomial random variates."
+//  Journal of Statistical Computation and Simulation 46.1-2 (1993): 101-110.
+func binomialBTRS(n, p float64, unifrnd func() float64) float64 {
+	q := 1 - p
+	spq := math.Sqrt(n * p * q)
+	b := 1.15 + 2.53*spq
+	a := -0.0873 + 0.0248*b + 0.01*p
+	c := n*p + 0.5
+	vr := 0.92 - 4.2/b
+	alpha := (2.83 + 5.1/b) * spq
+	lpq := math.Log(p / q)
+	m := math.Floor((n + 1) * p)
+	lgm1, _ := math.Lgamma(m + 1)
+	lgm2, _ := math.Lgamma(n - m + 1)
+	h := lgm1 + lgm2
+	for {
+		u := unifrnd() - 0.5
+		v := unifrnd()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + c)
+		if k < 0 || k > n {
+			continue
+		}
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		v = math.Log(v * alpha / (a/(us*us) + b))
+		lgk1, _ := math.Lgamma(k + 1)
+		lgk2, _ := math.Lgamma(n - k + 1)
+		if v <= h-lgk1-lgk2+(k-m)*lpq {
+			return k
+		}
+	}
+}
+
+// Skewness returns the skewness of the distribution.
+func (b Binomial) Skewness() float64 {
+	return (1 - 2*b.P) / b.StdDev()
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (b Binomial) StdDev() float64 {
+	return math.Sqrt(b.Variance())
+}
+
+// SuffStat computes the sufficient statistics of set of samples to update
+// the distribution. The sufficient statistics are stored in place, and the
+// effective number of samples are returned.
+//
+// The binomial distribution with known N has one sufficient statistic, the
+// mean of the samples divided by N.
+//
+// If weights is nil, the weights are assumed to be 1, otherwise panics if
+// len(samples) != len(weights). Panics if len(suffStat) != NumSuffStat().
+func (b Binomial) SuffStat(suffStat, samples, weights []float64) (nSamples float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+
+	if len(suffStat) != b.NumSuffStat() {
+		panic(badSuffStat)
+	}
+
+	if len(weights) == 0 {
+		nSamples = float64(len(samples))
+	} else {
+		nSamples = floats.Sum(weights)
+	}
+
+	suffStat[0] = stat.Mean(samples, weights) / b.N
+	return nSamples
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (b Binomial) Survival(x float64) float64 {
+	if x < 0 {
+		return 1
+	}
+	if x >= b.N {
+		return 0
+	}
+	k := math.Floor(x)
+	return mathext.RegIncBeta(k+1, b.N-k, b.P)
+}
+
+// Variance returns the variance of the probability distribution.
+func (b Binomial) Variance() float64 {
+	return b.N * b.P * (1 - b.P)
+}
+
+// setParameters modifies the parameters of the distribution.
+func (b *Binomial) setParameters(p []Parameter) {
+	if len(p) != b.NumParameters() {
+		panic("binomial: incorrect number of parameters to set")
+	}
+	if p[0].Name != "N" {
+		panic("binomial: " + panicNameMismatch)
+	}
+	if p[1].Name != "P" {
+		panic("binomial: " + panicNameMismatch)
+	}
+	b.N = p[0].Value
+	b.P = p[1].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (b Binomial) parameters(p []Parameter) []Parameter {
+	nParam := b.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("binomial: improper parameter length")
+	}
+	p[0].Name = "N"
+	p[0].Value = b.N
+	p[1].Name = "P"
+	p[1].Value = b.P
+	return p
+}
@@ -0,0 +1,114 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBinomialProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     3,
+			prob:    0.2668279319999998,
+			cumProb: 0.6496107183999996,
+			logProb: -1.3211512777668892,
+		},
+		{
+			loc:     11,
+			prob:    0,
+			cumProb: 1,
+			logProb: math.Inf(-1),
+		},
+	}
+	testDistributionProbs(t, Binomial{N: 10, P: 0.3}, "Binomial", pts)
+}
+
+func TestBinomial(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Binomial{
+		{N: 1, P: 0.5, Source: src},
+		{N: 10, P: 0.3, Source: src},
+		{N: 20, P: 0.9, Source: src},
+		{N: 100, P: 0.25, Source: src},
+		{N: 1000, P: 0.6, Source: src},
+		{N: 50000, P: 0.001, Source: src},
+	} {
+		testDiscreteRand(t, dist, i)
+		testDiscreteDist(t, i, dist, 0, dist.N)
+	}
+}
+
+func TestBinomialDegenerate(t *testing.T) {
+	for _, test := range []struct {
+		p, want float64
+	}{
+		{p: 0, want: 0},
+		{p: 1, want: 8},
+	} {
+		b := Binomial{N: 8, P: test.p, Source: rand.New(rand.NewSource(1))}
+		for i := 0; i < 10; i++ {
+			if got := b.Rand(); got != test.want {
+				t.Errorf("unexpected sample for P = %v: want %v, got %v", test.p, test.want, got)
+			}
+		}
+		if got := b.Prob(test.want); got != 1 {
+			t.Errorf("unexpected probability for P = %v: want 1, got %v", test.p, got)
+		}
+	}
+}
+
+func TestBinomialConjugateUpdate(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	samps := randn(Binomial{N: 12, P: 0.4, Source: src}, 20)
+
+	// Updating incrementally must match updating all at once.
+	inc := Binomial{N: 12, P: 0.7}
+	prior := []float64{3}
+	stats := make([]float64, inc.NumSuffStat())
+	for _, x := range samps {
+		n := inc.SuffStat(stats, []float64{x}, nil)
+		inc.ConjugateUpdate(stats, n, prior)
+	}
+	all := Binomial{N: 12, P: 0.7}
+	n := all.SuffStat(stats, samps, nil)
+	all.ConjugateUpdate(stats, n, []float64{3})
+	if math.Abs(inc.P-all.P) > 1e-14 {
+		t.Errorf("incremental update mismatch: want %v, got %v", all.P, inc.P)
+	}
+	if prior[0] != 23 {
+		t.Errorf("unexpected prior strength: want 23, got %v", prior[0])
+	}
+	if inc.N != 12 {
+		t.Errorf("N modified by update: got %v", inc.N)
+	}
+
+	var sum float64
+	for _, x := range samps {
+		sum += x
+	}
+	want := (3*0.7 + sum/12) / 23
+	if math.Abs(all.P-want) > 1e-14 {
+		t.Errorf("unexpected updated P: want %v, got %v", want, all.P)
+	}
+}
+
+func TestBinomialFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Binomial{N: 30, P: 0.35, Source: src}
+	got := Binomial{N: 30}
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.P-want.P) > 0.005 {
+		t.Errorf("Fit mismatch: want P = %v, got %v", want.P, got.P)
+	}
+}
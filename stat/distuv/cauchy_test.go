@@ -0,0 +1,48 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestCauchyProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     0,
+			prob:    0.12732395447351627,
+			cumProb: 0.8524163823495667,
+			logProb: -2.061020617723555,
+		},
+		{
+			loc:     -1,
+			prob:    0.6366197723675814,
+			cumProb: 0.5,
+			logProb: -0.45158270528945466,
+		},
+	}
+	testDistributionProbs(t, Cauchy{Mu: -1, Scale: 0.5}, "Cauchy", pts)
+}
+
+func TestCauchy(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Cauchy{
+		{Mu: 0, Scale: 1, Source: src},
+		{Mu: 3, Scale: 0.2, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestCauchyScore(t *testing.T) {
+	for _, test := range []*Cauchy{
+		{Mu: 0, Scale: 1},
+		{Mu: -1, Scale: 0.5},
+	} {
+		testDerivParam(t, test)
+	}
+}
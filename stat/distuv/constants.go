@@ -17,6 +17,9 @@ const (
 
 	// Euler–Mascheroni constant.
 	eulerGamma = 0.5772156649015328606065120900824024310421593359399235988057672348848677267776646709369470632917467495146314472498070824809605
+
+	// gumbelSkewness is the skewness of the Gumbel distribution, 12*sqrt(6)*ζ(3)/π^3.
+	gumbelSkewness = 1.1395470994046486574927930193898461120875997958365518247216557100852480077060706
 )
 
 const (
@@ -0,0 +1,156 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import "math"
+
+// discreteQuantile returns the smallest integer k in [lo, hi] such that
+// cdf(k) >= p. The search starts from guess, which should be close to the
+// answer for efficiency, and hi may be +Inf for distributions with unbounded
+// support. If p is 1, discreteQuantile returns hi, which must be the top of
+// the support.
+//
+// The comparison with p allows for a small relative error in cdf so that the
+// quantile of a value returned by cdf is the value at which it was evaluated.
+func discreteQuantile(cdf func(float64) float64, p, lo, hi, guess float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	if p == 0 {
+		return lo
+	}
+	if p == 1 {
+		return hi
+	}
+	p *= 1 - 1e-12
+	k := math.Floor(guess)
+	if math.IsNaN(k) || math.IsInf(k, 0) || k < lo {
+		k = lo
+	}
+	if k > hi {
+		k = hi
+	}
+
+	// Find a bracket [a, b] with cdf(a) < p <= cdf(b) by stepping away from
+	// the guess with doubling step sizes.
+	var a, b float64
+	if cdf(k) >= p {
+		b = k
+		step := 1.0
+		for {
+			a = b - step
+			if a < lo {
+				if cdf(lo) >= p {
+					return lo
+				}
+				a = lo
+				break
+			}
+			if cdf(a) < p {
+				break
+			}
+			b = a
+			step *= 2
+		}
+	} else {
+		a = k
+		step := 1.0
+		for {
+			b = a + step
+			if b >= hi {
+				if math.IsInf(hi, 1) {
+					// The CDF has saturated below p.
+					return hi
+				}
+				b = hi
+				break
+			}
+			if cdf(b) >= p {
+				break
+			}
+			a = b
+			step *= 2
+		}
+	}
+
+	// Bisect the bracket, maintaining cdf(a) < p <= cdf(b).
+	for b-a > 1 {
+		m := math.Floor(a + (b-a)/2)
+		if cdf(m) >= p {
+			b = m
+		} else {
+			a = m
+		}
+	}
+	return b
+}
+
+// discreteEntropy returns the entropy of a unimodal discrete distribution
+// with support on the integers in [lo, hi] computed by summing the terms
+// outward from the mode until they are negligible.
+func discreteEntropy(logProb func(float64) float64, lo, hi, mode float64) float64 {
+	const tol = 1e-20
+	var h float64
+	for k := mode; k >= lo; k-- {
+		lp := logProb(k)
+		p := math.Exp(lp)
+		if p == 0 {
+			break
+		}
+		h -= p * lp
+		if p < tol && k < mode {
+			break
+		}
+	}
+	for k := mode + 1; k <= hi; k++ {
+		lp := logProb(k)
+		p := math.Exp(lp)
+		if p == 0 {
+			break
+		}
+		h -= p * lp
+		if p < tol {
+			break
+		}
+	}
+	return h
+}
+
+// chopDown returns a sample from a unimodal discrete distribution with support
+// on the integers in [lo, hi] by inversion, searching outward from the mode
+// and alternating between the two sides so that the expected number of
+// probability evaluations is proportional to the standard deviation.
+func chopDown(u float64, prob func(float64) float64, lo, hi, mode float64) float64 {
+	p := prob(mode)
+	if u <= p {
+		return mode
+	}
+	u -= p
+	down, up := mode-1, mode+1
+	for down >= lo || up <= hi {
+		var pUp, pDown float64
+		if up <= hi {
+			pUp = prob(up)
+			if u <= pUp {
+				return up
+			}
+			u -= pUp
+			up++
+		}
+		if down >= lo {
+			pDown = prob(down)
+			if u <= pDown {
+				return down
+			}
+			u -= pDown
+			down--
+		}
+		if pUp == 0 && pDown == 0 {
+			break
+		}
+	}
+	// Round-off left some probability unassigned; return the mode.
+	return mode
+}
@@ -0,0 +1,42 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDiscreteQuantileEnd(t *testing.T) {
+	hyper := Hypergeometric{N: 5000, K: 2000, Draws: 1000}
+	for _, test := range []struct {
+		name string
+		dist Quantiler
+		p    float64
+		want float64
+	}{
+		// Degenerate distributions are concentrated at zero.
+		{name: "Binomial N=10 P=0", dist: Binomial{N: 10, P: 0}, p: 1, want: 0},
+		{name: "Geometric P=1", dist: Geometric{P: 1}, p: 1, want: 0},
+		{name: "Poisson Lambda=0", dist: Poisson{Lambda: 0}, p: 1, want: 0},
+		{name: "NegativeBinomial P=1", dist: NegativeBinomial{R: 2, P: 1}, p: 1, want: 0},
+
+		// Otherwise Quantile(1) is the top of the support.
+		{name: "Binomial N=10 P=0.5", dist: Binomial{N: 10, P: 0.5}, p: 1, want: 10},
+		{name: "Binomial N=1000 P=0.5", dist: Binomial{N: 1000, P: 0.5}, p: 1, want: 1000},
+		{name: "Poisson Lambda=5", dist: Poisson{Lambda: 5}, p: 1, want: math.Inf(1)},
+		{name: "Geometric P=0.5", dist: Geometric{P: 0.5}, p: 1, want: math.Inf(1)},
+		{name: "NegativeBinomial R=2 P=0.5", dist: NegativeBinomial{R: 2, P: 0.5}, p: 1, want: math.Inf(1)},
+		{name: "Hypergeometric", dist: hyper, p: 1, want: 1000},
+
+		// The quantile of a value returned by CDF is the value at which
+		// it was evaluated.
+		{name: "Hypergeometric CDF(430)", dist: hyper, p: hyper.CDF(430), want: 430},
+	} {
+		if got := test.dist.Quantile(test.p); got != test.want {
+			t.Errorf("%s: unexpected Quantile(%v): got %v want %v", test.name, test.p, got, test.want)
+		}
+	}
+}
@@ -291,3 +291,250 @@ func testRandLogProbContinuous(t *testing.T, i int, min float64, x []float64, f
 		}
 	}
 }
+
+// testDiscreteRand checks that samples drawn from a discrete distribution are
+// consistent with its Prob, Mean and Variance. The sample skewness and excess
+// kurtosis of heavy-tailed distributions converge too slowly to be compared,
+// so these are checked exactly by testDiscreteDist instead.
+func testDiscreteRand(t *testing.T, f fullDist, i int) {
+	tol := 1e-2
+	const n = 1e6
+	x := make([]float64, n)
+	generateSamples(x, f)
+	sort.Float64s(x)
+
+	checkMean(t, i, x, f, tol)
+	checkVarAndStd(t, i, x, f, tol)
+	checkEntropy(t, i, x, f, tol)
+	checkProbDiscrete(t, i, x, f, tol)
+}
+
+// testDiscreteDist checks the functions of a discrete distribution with
+// support on the integers in [lo, hi] against direct sums over its Prob.
+// For an unbounded support the sums stop when the remaining probability is
+// negligible.
+func testDiscreteDist(t *testing.T, i int, d fullDist, lo, hi float64) {
+	const tol = 1e-10
+	var sum, mean, entropy float64
+	var ks, ps []float64
+	for k := lo; k <= hi; k++ {
+		p := d.Prob(k)
+		ks = append(ks, k)
+		ps = append(ps, p)
+		sum += p
+		mean += k * p
+		if p > 0 {
+			entropy -= p * d.LogProb(k)
+		}
+		cdf := d.CDF(k)
+		if !floats.EqualWithinAbsOrRel(cdf, sum, tol, tol) {
+			t.Errorf("CDF mismatch case %v at %v: want %v, got %v", i, k, sum, cdf)
+		}
+		if d.CDF(k+0.5) != cdf {
+			t.Errorf("CDF not constant between integers case %v at %v", i, k)
+		}
+		surv := d.Survival(k)
+		if math.Abs(1-cdf-surv) > tol {
+			t.Errorf("Survival/CDF mismatch case %v at %v: want %v, got %v", i, k, 1-cdf, surv)
+		}
+		if surv < 1e-17 {
+			break
+		}
+	}
+	if math.Abs(sum-1) > tol {
+		t.Errorf("Probability distribution doesn't sum to 1. Case %v: Got %v", i, sum)
+	}
+	if d.CDF(lo-1) != 0 || d.Survival(lo-1) != 1 {
+		t.Errorf("Unexpected CDF or Survival below the support case %v", i)
+	}
+
+	var m2, m3, m4 float64
+	for j, k := range ks {
+		dk := k - mean
+		m2 += dk * dk * ps[j]
+		m3 += dk * dk * dk * ps[j]
+		m4 += dk * dk * dk * dk * ps[j]
+	}
+	const momentTol = 1e-8
+	for _, test := range []struct {
+		name      string
+		want, got float64
+	}{
+		{"Mean", mean, d.Mean()},
+		{"Variance", m2, d.Variance()},
+		{"StdDev", math.Sqrt(m2), d.StdDev()},
+		{"Skewness", m3 / math.Pow(m2, 1.5), d.Skewness()},
+		{"ExKurtosis", m4/(m2*m2) - 3, d.ExKurtosis()},
+		{"Entropy", entropy, d.Entropy()},
+	} {
+		if !floats.EqualWithinAbsOrRel(test.got, test.want, momentTol, momentTol) {
+			t.Errorf("%s mismatch case %v: want %v, got %v", test.name, i, test.want, test.got)
+		}
+	}
+
+	for _, p := range []float64{0.001, 0.1, 0.25, 0.5, 0.75, 0.9, 0.999} {
+		k := d.Quantile(p)
+		if k < lo || k > hi || k != math.Floor(k) {
+			t.Errorf("Quantile out of support case %v: p = %v, got %v", i, p, k)
+			continue
+		}
+		if d.CDF(k) < p*(1-1e-12) {
+			t.Errorf("Quantile too small case %v: p = %v, CDF(%v) = %v", i, p, k, d.CDF(k))
+		}
+		if k > lo && d.CDF(k-1) >= p {
+			t.Errorf("Quantile too large case %v: p = %v, CDF(%v) = %v", i, p, k-1, d.CDF(k-1))
+		}
+	}
+	if med := d.Median(); med != d.Quantile(0.5) {
+		t.Errorf("Median mismatch case %v: want %v, got %v", i, d.Quantile(0.5), med)
+	}
+	if q := d.Quantile(0); q != lo {
+		t.Errorf("Quantile(0) mismatch case %v: want %v, got %v", i, lo, q)
+	}
+	if q := d.Quantile(1); q != hi {
+		t.Errorf("Quantile(1) mismatch case %v: want %v, got %v", i, hi, q)
+	}
+}
+
+// quantileIntegral returns an estimate of the expectation of g under the
+// distribution with quantile function q, computed as the integral of g(q(p))
+// for p in (0, 1). The interval is divided geometrically towards both ends
+// so that the integrable singularities of heavy-tailed distributions are
+// handled accurately.
+func quantileIntegral(q func(float64) float64, g func(float64) float64) float64 {
+	const n = 50
+	f := func(p float64) float64 { return g(q(p)) }
+	sum := quad.Fixed(f, 0.1, 0.9, n, nil, 0)
+	for j := 1; j <= 14; j++ {
+		a := math.Pow(10, -float64(j))
+		sum += quad.Fixed(f, a/10, a, n, nil, 0)
+		sum += quad.Fixed(f, 1-a, 1-a/10, n, nil, 0)
+	}
+	return sum
+}
+
+// testContinuousDist checks the functions of a continuous distribution. The
+// moments and entropy are checked against numerical integrals, and Prob is
+// checked against the CDF by integrating between quantiles. Samples are used
+// to check Rand, but only their mean and variance are compared when these are
+// finite, since the sample skewness and excess kurtosis of heavy-tailed
+// distributions converge too slowly.
+func testContinuousDist(t *testing.T, i int, d fullDist) {
+	const tol = 1e-2
+	x := make([]float64, 1e6)
+	generateSamples(x, d)
+	sort.Float64s(x)
+
+	finiteVar := !math.IsInf(d.Variance(), 0) && !math.IsNaN(d.Variance())
+	if finiteVar {
+		checkMean(t, i, x, d, tol)
+		checkVarAndStd(t, i, x, d, tol)
+	}
+	checkMedian(t, i, x, d, tol)
+	checkEntropy(t, i, x, d, tol)
+	checkQuantileCDFSurvival(t, i, x, d, tol)
+
+	// Check that Prob integrates to the differences of the CDF between
+	// quantiles and that LogProb is consistent with Prob.
+	ps := make([]float64, 99)
+	floats.Span(ps, 0.01, 0.99)
+	for j := 1; j < len(ps); j++ {
+		a, b := d.Quantile(ps[j-1]), d.Quantile(ps[j])
+		got := quad.Fixed(d.Prob, a, b, 100, nil, 0)
+		want := d.CDF(b) - d.CDF(a)
+		if math.Abs(got-want) > 1e-10 {
+			t.Errorf("Integral of PDF doesn't match CDF case %v on [%v, %v]: want %v, got %v", i, a, b, want, got)
+			break
+		}
+		if math.Abs(math.Log(d.Prob(a))-d.LogProb(a)) > 1e-14 {
+			t.Errorf("Prob and LogProb mismatch case %v at %v: want %v, got %v", i, a, math.Log(d.Prob(a)), d.LogProb(a))
+		}
+	}
+
+	const intTol = 1e-6
+	entropy := quantileIntegral(d.Quantile, func(x float64) float64 { return -d.LogProb(x) })
+	if !floats.EqualWithinAbsOrRel(entropy, d.Entropy(), intTol, intTol) {
+		t.Errorf("Entropy mismatch case %v: want %v, got %v", i, entropy, d.Entropy())
+	}
+	if !finiteVar {
+		return
+	}
+	mean := quantileIntegral(d.Quantile, func(x float64) float64 { return x })
+	moment := func(k float64) float64 {
+		return quantileIntegral(d.Quantile, func(x float64) float64 { return math.Pow(x-mean, k) })
+	}
+	m2 := moment(2)
+	for _, test := range []struct {
+		name      string
+		want, got float64
+	}{
+		{"Mean", mean, d.Mean()},
+		{"Variance", m2, d.Variance()},
+		{"Skewness", moment(3) / math.Pow(m2, 1.5), d.Skewness()},
+		{"ExKurtosis", moment(4)/(m2*m2) - 3, d.ExKurtosis()},
+	} {
+		if math.IsInf(test.got, 1) {
+			// The moment does not exist.
+			continue
+		}
+		if !floats.EqualWithinAbsOrRel(test.got, test.want, intTol, intTol) {
+			t.Errorf("%s mismatch case %v: want %v, got %v", test.name, i, test.want, test.got)
+		}
+	}
+}
+
+type univariateTester interface {
+	cumulantProber
+	Rander
+}
+
+// testUnivariateDist checks the consistency of the functions of a continuous
+// distribution that does not provide its moments. Prob is checked against
+// the CDF by integrating between quantiles to within probTol, and samples are
+// used to check Rand and the mean computed by integrating over the quantile
+// function.
+func testUnivariateDist(t *testing.T, i int, d univariateTester, probTol float64) {
+	const tol = 1e-2
+	x := make([]float64, 1e5)
+	generateSamples(x, d)
+	sort.Float64s(x)
+	checkQuantileCDFSurvival(t, i, x, d, tol)
+	checkProbLogProbSupport(t, i, x, d)
+	checkQuantileIntegrals(t, i, x, d, probTol)
+}
+
+// checkProbLogProbSupport checks that Prob and LogProb agree at the samples
+// in x and that the samples lie within the support of d.
+func checkProbLogProbSupport(t *testing.T, i int, x []float64, d univariateTester) {
+	for _, v := range x {
+		if math.Abs(math.Log(d.Prob(v))-d.LogProb(v)) > 1e-14 {
+			t.Errorf("Prob and LogProb mismatch case %v at %v: want %v, got %v", i, v, math.Log(d.Prob(v)), d.LogProb(v))
+			break
+		}
+		if d.CDF(v) < 0 || d.CDF(v) > 1 || d.Prob(v) <= 0 {
+			t.Errorf("Sample outside support case %v: x = %v", i, v)
+			break
+		}
+	}
+}
+
+// checkQuantileIntegrals checks the integral of Prob between percentiles to
+// within probTol, and the mean of the samples in x against the mean computed
+// by integrating over the quantile function.
+func checkQuantileIntegrals(t *testing.T, i int, x []float64, d univariateTester, probTol float64) {
+	for k := 1; k < 99; k++ {
+		lo := d.Quantile(float64(k) / 100)
+		hi := d.Quantile(float64(k+1) / 100)
+		q := quad.Fixed(d.Prob, lo, hi, 100, nil, 0)
+		if math.Abs(q-0.01) > probTol {
+			t.Errorf("Integral of PDF doesn't match quantile. Case %v. Want %v, got %v.", i, 0.01, q)
+			break
+		}
+	}
+
+	mean := quantileIntegral(d.Quantile, func(x float64) float64 { return x })
+	std := math.Sqrt(quantileIntegral(d.Quantile, func(x float64) float64 { return (x - mean) * (x - mean) }))
+	if math.Abs(mean-stat.Mean(x, nil)) > 5*std/math.Sqrt(float64(len(x))) {
+		t.Errorf("Mean mismatch case %v: want: %v, got: %v", i, mean, stat.Mean(x, nil))
+	}
+}
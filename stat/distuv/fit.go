@@ -0,0 +1,126 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import "math"
+
+// nelderMead returns an approximate minimizer of f found with the Nelder-Mead
+// simplex method starting from the simplex around x0 with the given initial
+// step sizes. f may return +Inf to indicate that a point is infeasible.
+//
+// The optimize package cannot be used here since it depends on this package
+// through distmv.
+func nelderMead(f func([]float64) float64, x0, step []float64) []float64 {
+	const (
+		reflection  = 1.0
+		expansion   = 2.0
+		contraction = 0.5
+		shrink      = 0.5
+		tol         = 1e-12
+	)
+	dim := len(x0)
+	maxIter := 1000 * dim
+
+	type vertex struct {
+		x []float64
+		f float64
+	}
+	simplex := make([]vertex, dim+1)
+	for i := range simplex {
+		x := make([]float64, dim)
+		copy(x, x0)
+		if i > 0 {
+			x[i-1] += step[i-1]
+		}
+		simplex[i] = vertex{x: x, f: f(x)}
+	}
+	// order sorts the simplex by increasing function value.
+	order := func() {
+		for i := 1; i < len(simplex); i++ {
+			for j := i; j > 0 && simplex[j].f < simplex[j-1].f; j-- {
+				simplex[j], simplex[j-1] = simplex[j-1], simplex[j]
+			}
+		}
+	}
+	centroid := make([]float64, dim)
+	trial := func(x []float64, scale float64) vertex {
+		worst := simplex[dim].x
+		for j := range x {
+			x[j] = centroid[j] + scale*(centroid[j]-worst[j])
+		}
+		return vertex{x: x, f: f(x)}
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		order()
+		best, worst := simplex[0], simplex[dim]
+		if math.Abs(worst.f-best.f) <= tol*(math.Abs(best.f)+tol) {
+			var size float64
+			for _, v := range simplex[1:] {
+				for j := range v.x {
+					size = math.Max(size, math.Abs(v.x[j]-best.x[j]))
+				}
+			}
+			if size <= math.Sqrt(tol) {
+				break
+			}
+		}
+
+		for j := range centroid {
+			centroid[j] = 0
+			for _, v := range simplex[:dim] {
+				centroid[j] += v.x[j]
+			}
+			centroid[j] /= float64(dim)
+		}
+
+		r := trial(make([]float64, dim), reflection)
+		switch {
+		case r.f < best.f:
+			e := trial(make([]float64, dim), expansion)
+			if e.f < r.f {
+				simplex[dim] = e
+			} else {
+				simplex[dim] = r
+			}
+		case r.f < simplex[dim-1].f:
+			simplex[dim] = r
+		default:
+			scale := -contraction
+			if r.f < worst.f {
+				scale = contraction * reflection
+			}
+			c := trial(make([]float64, dim), scale)
+			if c.f < math.Min(r.f, worst.f) {
+				simplex[dim] = c
+				continue
+			}
+			for _, v := range simplex[1:] {
+				for j := range v.x {
+					v.x[j] = best.x[j] + shrink*(v.x[j]-best.x[j])
+				}
+			}
+			for i := 1; i <= dim; i++ {
+				simplex[i].f = f(simplex[i].x)
+			}
+		}
+	}
+	order()
+	return simplex[0].x
+}
+
+// logLikelihood returns the weighted log-likelihood of the samples under
+// the distribution. If weights is nil, all the weights are 1.
+func logLikelihood(d LogProber, samples, weights []float64) float64 {
+	var ll float64
+	for i, x := range samples {
+		if weights == nil {
+			ll += d.LogProb(x)
+		} else if weights[i] != 0 {
+			ll += weights[i] * d.LogProb(x)
+		}
+	}
+	return ll
+}
@@ -0,0 +1,231 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Frechet implements the Fréchet (type II extreme value) distribution with
+// shape Alpha, scale S and location M. The support of the distribution is
+// (M, +∞).
+// More information at https://en.wikipedia.org/wiki/Fr%C3%A9chet_distribution.
+type Frechet struct {
+	// Alpha is the shape parameter. Valid range is (0,+∞).
+	Alpha float64
+	// S is the scale parameter. Valid range is (0,+∞).
+	S float64
+	// M is the location parameter, the minimum of the distribution.
+	M float64
+
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (f Frechet) CDF(x float64) float64 {
+	if x <= f.M {
+		return 0
+	}
+	return math.Exp(-math.Pow((x-f.M)/f.S, -f.Alpha))
+}
+
+// Entropy returns the entropy of the distribution.
+func (f Frechet) Entropy() float64 {
+	return 1 + eulerGamma/f.Alpha + eulerGamma + math.Log(f.S/f.Alpha)
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution. The excess
+// kurtosis is +Inf for Alpha <= 4.
+func (f Frechet) ExKurtosis() float64 {
+	if f.Alpha <= 4 {
+		return math.Inf(1)
+	}
+	g1 := math.Gamma(1 - 1/f.Alpha)
+	g2 := math.Gamma(1 - 2/f.Alpha)
+	g3 := math.Gamma(1 - 3/f.Alpha)
+	g4 := math.Gamma(1 - 4/f.Alpha)
+	v := g2 - g1*g1
+	return (g4-4*g3*g1+3*g2*g2)/(v*v) - 6
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (f Frechet) LogProb(x float64) float64 {
+	if x <= f.M {
+		return math.Inf(-1)
+	}
+	z := (x - f.M) / f.S
+	return math.Log(f.Alpha/f.S) - (1+f.Alpha)*math.Log(z) - math.Pow(z, -f.Alpha)
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// for Alpha <= 1.
+func (f Frechet) Mean() float64 {
+	if f.Alpha <= 1 {
+		return math.Inf(1)
+	}
+	return f.M + f.S*math.Gamma(1-1/f.Alpha)
+}
+
+// Median returns the median of the probability distribution.
+func (f Frechet) Median() float64 {
+	return f.M + f.S/math.Pow(ln2, 1/f.Alpha)
+}
+
+// Mode returns the mode of the probability distribution.
+func (f Frechet) Mode() float64 {
+	return f.M + f.S*math.Pow(f.Alpha/(1+f.Alpha), 1/f.Alpha)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Frechet) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (f Frechet) Prob(x float64) float64 {
+	return math.Exp(f.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (f Frechet) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	return f.M + f.S*math.Pow(-math.Log(p), -1/f.Alpha)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (f Frechet) Rand() float64 {
+	var rnd float64
+	if f.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = f.Source.ExpFloat64()
+	}
+	return f.M + f.S*math.Pow(rnd, -1/f.Alpha)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Alpha, ∂LogProb / ∂S, ∂LogProb / ∂M].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+//
+// Special cases:
+//  Score(x) = [NaN, NaN, NaN] for x <= M
+func (f Frechet) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, f.NumParameters())
+	}
+	if len(deriv) != f.NumParameters() {
+		panic(badLength)
+	}
+	if x <= f.M {
+		deriv[0] = math.NaN()
+		deriv[1] = math.NaN()
+		deriv[2] = math.NaN()
+		return deriv
+	}
+	z := (x - f.M) / f.S
+	logZ := math.Log(z)
+	zpow := math.Pow(z, -f.Alpha)
+	deriv[0] = 1/f.Alpha - logZ + zpow*logZ
+	deriv[1] = f.Alpha * (1 - zpow) / f.S
+	deriv[2] = (1 + f.Alpha - f.Alpha*zpow) / (f.S * z)
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+// Special cases:
+//  ScoreInput(x) = 0 for x <= M
+func (f Frechet) ScoreInput(x float64) float64 {
+	if x <= f.M {
+		return 0
+	}
+	z := (x - f.M) / f.S
+	return (f.Alpha*math.Pow(z, -f.Alpha) - 1 - f.Alpha) / (f.S * z)
+}
+
+// Skewness returns the skewness of the distribution. The skewness is +Inf
+// for Alpha <= 3.
+func (f Frechet) Skewness() float64 {
+	if f.Alpha <= 3 {
+		return math.Inf(1)
+	}
+	g1 := math.Gamma(1 - 1/f.Alpha)
+	g2 := math.Gamma(1 - 2/f.Alpha)
+	g3 := math.Gamma(1 - 3/f.Alpha)
+	return (g3 - 3*g2*g1 + 2*g1*g1*g1) / math.Pow(g2-g1*g1, 1.5)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (f Frechet) StdDev() float64 {
+	return math.Sqrt(f.Variance())
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (f Frechet) Survival(x float64) float64 {
+	if x <= f.M {
+		return 1
+	}
+	return -math.Expm1(-math.Pow((x-f.M)/f.S, -f.Alpha))
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf for Alpha <= 2.
+func (f Frechet) Variance() float64 {
+	if f.Alpha <= 2 {
+		return math.Inf(1)
+	}
+	g1 := math.Gamma(1 - 1/f.Alpha)
+	return f.S * f.S * (math.Gamma(1-2/f.Alpha) - g1*g1)
+}
+
+// setParameters modifies the parameters of the distribution.
+func (f *Frechet) setParameters(p []Parameter) {
+	if len(p) != f.NumParameters() {
+		panic("frechet: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Alpha" {
+		panic("frechet: " + panicNameMismatch)
+	}
+	if p[1].Name != "S" {
+		panic("frechet: " + panicNameMismatch)
+	}
+	if p[2].Name != "M" {
+		panic("frechet: " + panicNameMismatch)
+	}
+	f.Alpha = p[0].Value
+	f.S = p[1].Value
+	f.M = p[2].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (f Frechet) parameters(p []Parameter) []Parameter {
+	nParam := f.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("frechet: improper parameter length")
+	}
+	p[0].Name = "Alpha"
+	p[0].Value = f.Alpha
+	p[1].Name = "S"
+	p[1].Value = f.S
+	p[2].Name = "M"
+	p[2].Value = f.M
+	return p
+}
@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestFrechetProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     0.5,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     4,
+			prob:    0.22031617161656483,
+			cumProb: 0.7435670792059064,
+			logProb: -1.5126916206207894,
+		},
+	}
+	testDistributionProbs(t, Frechet{Alpha: 3, S: 2, M: 1}, "Frechet", pts)
+}
+
+func TestFrechet(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Frechet{
+		{Alpha: 12, S: 1, M: 0, Source: src},
+		{Alpha: 20, S: 3, M: -2, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestFrechetScore(t *testing.T) {
+	for _, test := range []*Frechet{
+		{Alpha: 3, S: 2, M: 1},
+		{Alpha: 0.8, S: 0.5, M: -1},
+	} {
+		testDerivParam(t, test)
+	}
+}
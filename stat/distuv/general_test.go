@@ -202,3 +202,35 @@ func testDerivParam(t *testing.T, d derivParamTester) {
 		}
 	}
 }
+
+type scoreInputTester interface {
+	LogProb(x float64) float64
+	Quantile(p float64) float64
+	ScoreInput(x float64) float64
+}
+
+// testScoreInput tests that ScoreInput matches the numerical derivative of
+// LogProb for a number of different quantiles along the distribution.
+func testScoreInput(t *testing.T, i int, d scoreInputTester) {
+	quantiles := make([]float64, 10)
+	floats.Span(quantiles, 0.1, 0.9)
+	for _, p := range quantiles {
+		x := d.Quantile(p)
+		want := fd.Derivative(d.LogProb, x, &fd.Settings{Formula: fd.Central})
+		got := d.ScoreInput(x)
+		if !floats.EqualWithinAbsOrRel(got, want, 1e-6, 1e-6) {
+			t.Errorf("ScoreInput mismatch case %v at %v: want %v, got %v", i, x, want, got)
+		}
+	}
+}
+
+// panics returns whether f panics.
+func panics(f func()) (b bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			b = true
+		}
+	}()
+	f()
+	return false
+}
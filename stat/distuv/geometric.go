@@ -0,0 +1,230 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Geometric represents the geometric distribution, the distribution of the
+// number of failures before the first success in a sequence of independent
+// trials that each succeed with probability P. The value of P must be greater
+// than 0 and at most 1.
+// More information at https://en.wikipedia.org/wiki/Geometric_distribution.
+type Geometric struct {
+	P      float64
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative distribution function at x.
+func (g Geometric) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1((math.Floor(x) + 1) * math.Log1p(-g.P))
+}
+
+// ConjugateUpdate updates the parameters of the distribution from the sufficient
+// statistics of a set of samples. The sufficient statistics, suffStat, have been
+// observed with nSamples observations. The prior values of the distribution are those
+// currently in the distribution, and have been observed with priorStrength samples.
+//
+// For the geometric distribution, the sufficient statistic is the success
+// probability implied by the mean of the samples, 1/(1+mean), and the conjugate
+// prior is a Beta distribution on P.
+// The prior is having seen priorStrength[0] samples with success probability
+// Geometric.P. As a result of this function, Geometric.P is updated based on the
+// weighted samples, and priorStrength is modified to include the new number of
+// samples observed.
+//
+// This function panics if len(suffStat) != 1 or len(priorStrength) != 1.
+func (g *Geometric) ConjugateUpdate(suffStat []float64, nSamples float64, priorStrength []float64) {
+	if len(suffStat) != 1 {
+		panic("geometric: incorrect suffStat length")
+	}
+	if len(priorStrength) != 1 {
+		panic("geometric: incorrect priorStrength length")
+	}
+
+	totalSamples := nSamples + priorStrength[0]
+
+	// Combine the sample and prior means of the number of failures.
+	totalSum := nSamples * (1/suffStat[0] - 1)
+	if !(priorStrength[0] == 0) {
+		totalSum += priorStrength[0] * (1/g.P - 1)
+	}
+	g.P = 1 / (1 + totalSum/totalSamples)
+	priorStrength[0] = totalSamples
+}
+
+// Entropy returns the entropy of the distribution.
+func (g Geometric) Entropy() float64 {
+	if g.P == 1 {
+		return 0
+	}
+	q := 1 - g.P
+	return (-q*math.Log(q) - g.P*math.Log(g.P)) / g.P
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (g Geometric) ExKurtosis() float64 {
+	return 6 + g.P*g.P/(1-g.P)
+}
+
+// Fit sets the parameters of the probability distribution from the
+// data samples x with relative weights w.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (g *Geometric) Fit(samples, weights []float64) {
+	suffStat := make([]float64, g.NumSuffStat())
+	nSamples := g.SuffStat(suffStat, samples, weights)
+	g.ConjugateUpdate(suffStat, nSamples, make([]float64, g.NumSuffStat()))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// mass function at x.
+func (g Geometric) LogProb(x float64) float64 {
+	if x < 0 || math.Floor(x) != x {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		return math.Log(g.P)
+	}
+	return x*math.Log1p(-g.P) + math.Log(g.P)
+}
+
+// Mean returns the mean of the probability distribution.
+func (g Geometric) Mean() float64 {
+	return (1 - g.P) / g.P
+}
+
+// Median returns the median of the probability distribution.
+func (g Geometric) Median() float64 {
+	return g.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (Geometric) Mode() float64 {
+	return 0
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Geometric) NumParameters() int {
+	return 1
+}
+
+// NumSuffStat returns the number of sufficient statistics for the distribution.
+func (Geometric) NumSuffStat() int {
+	return 1
+}
+
+// Prob computes the value of the probability mass function at x.
+func (g Geometric) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative distribution function, that
+// is the smallest integer k such that CDF(k) >= p.
+func (g Geometric) Quantile(p float64) float64 {
+	if g.P == 1 {
+		// The distribution is concentrated at zero.
+		return discreteQuantile(g.CDF, p, 0, 0, 0)
+	}
+	guess := math.Ceil(math.Log1p(-p)/math.Log1p(-g.P)) - 1
+	return discreteQuantile(g.CDF, p, 0, math.Inf(1), guess)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g Geometric) Rand() float64 {
+	if g.P == 1 {
+		return 0
+	}
+	var rnd float64
+	if g.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = g.Source.ExpFloat64()
+	}
+	return math.Floor(rnd / -math.Log1p(-g.P))
+}
+
+// Skewness returns the skewness of the distribution.
+func (g Geometric) Skewness() float64 {
+	return (2 - g.P) / math.Sqrt(1-g.P)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g Geometric) StdDev() float64 {
+	return math.Sqrt(1-g.P) / g.P
+}
+
+// SuffStat computes the sufficient statistics of set of samples to update
+// the distribution. The sufficient statistics are stored in place, and the
+// effective number of samples are returned.
+//
+// The geometric distribution has one sufficient statistic, the success
+// probability 1/(1+mean) implied by the mean of the samples.
+//
+// If weights is nil, the weights are assumed to be 1, otherwise panics if
+// len(samples) != len(weights). Panics if len(suffStat) != NumSuffStat().
+func (Geometric) SuffStat(suffStat, samples, weights []float64) (nSamples float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+
+	if len(suffStat) != (Geometric{}).NumSuffStat() {
+		panic(badSuffStat)
+	}
+
+	if len(weights) == 0 {
+		nSamples = float64(len(samples))
+	} else {
+		nSamples = floats.Sum(weights)
+	}
+
+	suffStat[0] = 1 / (1 + stat.Mean(samples, weights))
+	return nSamples
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g Geometric) Survival(x float64) float64 {
+	if x < 0 {
+		return 1
+	}
+	return math.Exp((math.Floor(x) + 1) * math.Log1p(-g.P))
+}
+
+// Variance returns the variance of the probability distribution.
+func (g Geometric) Variance() float64 {
+	return (1 - g.P) / (g.P * g.P)
+}
+
+// setParameters modifies the parameters of the distribution.
+func (g *Geometric) setParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("geometric: incorrect number of parameters to set")
+	}
+	if p[0].Name != "P" {
+		panic("geometric: " + panicNameMismatch)
+	}
+	g.P = p[0].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (g Geometric) parameters(p []Parameter) []Parameter {
+	nParam := g.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("geometric: improper parameter length")
+	}
+	p[0].Name = "P"
+	p[0].Value = g.P
+	return p
+}
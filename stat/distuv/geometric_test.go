@@ -0,0 +1,62 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeometricProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     0,
+			prob:    0.25,
+			cumProb: 0.25,
+			logProb: math.Log(0.25),
+		},
+		{
+			loc:     2,
+			prob:    0.140625,
+			cumProb: 0.578125,
+			logProb: math.Log(0.140625),
+		},
+	}
+	testDistributionProbs(t, Geometric{P: 0.25}, "Geometric", pts)
+}
+
+func TestGeometric(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Geometric{
+		{P: 0.05, Source: src},
+		{P: 0.3, Source: src},
+		{P: 0.5, Source: src},
+		{P: 0.9, Source: src},
+	} {
+		testDiscreteRand(t, dist, i)
+		testDiscreteDist(t, i, dist, 0, math.Inf(1))
+	}
+}
+
+func TestGeometricFitPrior(t *testing.T) {
+	testConjugateUpdate(t, func() ConjugateUpdater { return &Geometric{P: 0.35, Source: rand.New(rand.NewSource(1))} })
+}
+
+func TestGeometricFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Geometric{P: 0.2, Source: src}
+	var got Geometric
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.P-want.P) > 0.005 {
+		t.Errorf("Fit mismatch: want P = %v, got %v", want.P, got.P)
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// GeneralizedExtremeValue implements the generalized extreme value (GEV)
+// distribution with location Mu, scale Sigma and shape Xi. The Gumbel,
+// Fréchet and reversed Weibull distributions correspond to Xi == 0, Xi > 0
+// and Xi < 0 respectively. The support of the distribution is the set of x
+// for which 1 + Xi*(x-Mu)/Sigma > 0.
+// More information at https://en.wikipedia.org/wiki/Generalized_extreme_value_distribution.
+type GeneralizedExtremeValue struct {
+	Mu float64
+	// Sigma is the scale parameter. Valid range is (0,+∞).
+	Sigma float64
+	Xi    float64
+
+	Source *rand.Rand
+}
+
+// logT returns the logarithm of t(x) = (1+Xi*z)^(-1/Xi), or exp(-z) when
+// Xi == 0, where z = (x-Mu)/Sigma. The second return value is false if x is
+// outside the support of the distribution.
+func (g GeneralizedExtremeValue) logT(x float64) (float64, bool) {
+	z := (x - g.Mu) / g.Sigma
+	if g.Xi == 0 {
+		return -z, true
+	}
+	s := g.Xi * z
+	if s <= -1 {
+		return 0, false
+	}
+	return -math.Log1p(s) / g.Xi, true
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g GeneralizedExtremeValue) CDF(x float64) float64 {
+	logT, ok := g.logT(x)
+	if !ok {
+		if g.Xi > 0 {
+			return 0
+		}
+		return 1
+	}
+	return math.Exp(-math.Exp(logT))
+}
+
+// Entropy returns the entropy of the distribution.
+func (g GeneralizedExtremeValue) Entropy() float64 {
+	return math.Log(g.Sigma) + eulerGamma*g.Xi + eulerGamma + 1
+}
+
+// gammaMoments returns Γ(1-k*Xi) for k = 1, ..., 4.
+func (g GeneralizedExtremeValue) gammaMoments() (g1, g2, g3, g4 float64) {
+	return math.Gamma(1 - g.Xi), math.Gamma(1 - 2*g.Xi), math.Gamma(1 - 3*g.Xi), math.Gamma(1 - 4*g.Xi)
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution. The excess
+// kurtosis is +Inf for Xi >= 1/4.
+func (g GeneralizedExtremeValue) ExKurtosis() float64 {
+	switch {
+	case g.Xi == 0:
+		return 12.0 / 5
+	case g.Xi >= 0.25:
+		return math.Inf(1)
+	}
+	g1, g2, g3, g4 := g.gammaMoments()
+	v := g2 - g1*g1
+	return (g4-4*g3*g1+3*g2*g2)/(v*v) - 6
+}
+
+// Fit sets the parameters of the probability distribution to their maximum
+// likelihood estimates from the data samples x with relative weights w.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+//
+// The estimate of Xi is restricted to Xi > -1, since the likelihood is
+// unbounded for smaller values.
+func (g *GeneralizedExtremeValue) Fit(samples, weights []float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+	if len(samples) == 0 {
+		panic(badNoSamples)
+	}
+	// Start from the method of moments estimates for the Gumbel
+	// distribution, with a small positive shape so that the starting
+	// simplex spans both signs of Xi.
+	mean, std := stat.MeanStdDev(samples, weights)
+	sigma := std * math.Sqrt(6) / math.Pi
+	mu := mean - eulerGamma*sigma
+	x := nelderMead(func(x []float64) float64 {
+		if x[2] <= -1 {
+			return math.Inf(1)
+		}
+		d := GeneralizedExtremeValue{Mu: x[0], Sigma: math.Exp(x[1]), Xi: x[2]}
+		return -logLikelihood(d, samples, weights)
+	}, []float64{mu, math.Log(sigma), 0.05}, []float64{0.1 * sigma, 0.1, -0.1})
+	g.Mu = x[0]
+	g.Sigma = math.Exp(x[1])
+	g.Xi = x[2]
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g GeneralizedExtremeValue) LogProb(x float64) float64 {
+	logT, ok := g.logT(x)
+	if !ok {
+		return math.Inf(-1)
+	}
+	return -math.Log(g.Sigma) + (g.Xi+1)*logT - math.Exp(logT)
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// for Xi >= 1.
+func (g GeneralizedExtremeValue) Mean() float64 {
+	switch {
+	case g.Xi == 0:
+		return g.Mu + g.Sigma*eulerGamma
+	case g.Xi >= 1:
+		return math.Inf(1)
+	}
+	return g.Mu + g.Sigma*(math.Gamma(1-g.Xi)-1)/g.Xi
+}
+
+// Median returns the median of the probability distribution.
+func (g GeneralizedExtremeValue) Median() float64 {
+	if g.Xi == 0 {
+		return g.Mu - g.Sigma*math.Log(ln2)
+	}
+	return g.Mu + g.Sigma*math.Expm1(-g.Xi*math.Log(ln2))/g.Xi
+}
+
+// Mode returns the mode of the probability distribution.
+func (g GeneralizedExtremeValue) Mode() float64 {
+	if g.Xi == 0 {
+		return g.Mu
+	}
+	return g.Mu + g.Sigma*math.Expm1(-g.Xi*math.Log1p(g.Xi))/g.Xi
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (GeneralizedExtremeValue) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (g GeneralizedExtremeValue) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (g GeneralizedExtremeValue) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	return g.fromExp(-math.Log(p))
+}
+
+// fromExp returns the value of the distribution corresponding to the
+// value e of a standard exponential variate.
+func (g GeneralizedExtremeValue) fromExp(e float64) float64 {
+	if g.Xi == 0 {
+		return g.Mu - g.Sigma*math.Log(e)
+	}
+	return g.Mu + g.Sigma*math.Expm1(-g.Xi*math.Log(e))/g.Xi
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g GeneralizedExtremeValue) Rand() float64 {
+	var rnd float64
+	if g.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = g.Source.ExpFloat64()
+	}
+	return g.fromExp(rnd)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Mu, ∂LogProb / ∂Sigma, ∂LogProb / ∂Xi].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+//
+// Special cases:
+//  Score(x) = [NaN, NaN, NaN] for x outside the support
+func (g GeneralizedExtremeValue) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, g.NumParameters())
+	}
+	if len(deriv) != g.NumParameters() {
+		panic(badLength)
+	}
+	logT, ok := g.logT(x)
+	if !ok {
+		deriv[0] = math.NaN()
+		deriv[1] = math.NaN()
+		deriv[2] = math.NaN()
+		return deriv
+	}
+	z := (x - g.Mu) / g.Sigma
+	t := math.Exp(logT)
+	if g.Xi == 0 {
+		d := 1 - t
+		deriv[0] = d / g.Sigma
+		deriv[1] = (z*d - 1) / g.Sigma
+		deriv[2] = z*z*d/2 - z
+		return deriv
+	}
+	s := 1 + g.Xi*z
+	d := (g.Xi + 1 - t) / (g.Sigma * s)
+	deriv[0] = d
+	deriv[1] = z*d - 1/g.Sigma
+	deriv[2] = (1-t)*(math.Log1p(g.Xi*z)/(g.Xi*g.Xi)-z/(g.Xi*s)) - z/s
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+// Special cases:
+//  ScoreInput(x) = 0 for x outside the support
+func (g GeneralizedExtremeValue) ScoreInput(x float64) float64 {
+	logT, ok := g.logT(x)
+	if !ok {
+		return 0
+	}
+	t := math.Exp(logT)
+	if g.Xi == 0 {
+		return (t - 1) / g.Sigma
+	}
+	z := (x - g.Mu) / g.Sigma
+	return (t - g.Xi - 1) / (g.Sigma * (1 + g.Xi*z))
+}
+
+// Skewness returns the skewness of the distribution. The skewness is +Inf
+// for Xi >= 1/3.
+func (g GeneralizedExtremeValue) Skewness() float64 {
+	switch {
+	case g.Xi == 0:
+		return gumbelSkewness
+	case g.Xi >= 1.0/3:
+		return math.Inf(1)
+	}
+	g1, g2, g3, _ := g.gammaMoments()
+	skew := (g3 - 3*g2*g1 + 2*g1*g1*g1) / math.Pow(g2-g1*g1, 1.5)
+	if g.Xi < 0 {
+		return -skew
+	}
+	return skew
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g GeneralizedExtremeValue) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g GeneralizedExtremeValue) Survival(x float64) float64 {
+	logT, ok := g.logT(x)
+	if !ok {
+		if g.Xi > 0 {
+			return 1
+		}
+		return 0
+	}
+	return -math.Expm1(-math.Exp(logT))
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf for Xi >= 1/2.
+func (g GeneralizedExtremeValue) Variance() float64 {
+	switch {
+	case g.Xi == 0:
+		return g.Sigma * g.Sigma * math.Pi * math.Pi / 6
+	case g.Xi >= 0.5:
+		return math.Inf(1)
+	}
+	g1, g2, _, _ := g.gammaMoments()
+	return g.Sigma * g.Sigma * (g2 - g1*g1) / (g.Xi * g.Xi)
+}
+
+// setParameters modifies the parameters of the distribution.
+func (g *GeneralizedExtremeValue) setParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("gev: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("gev: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("gev: " + panicNameMismatch)
+	}
+	if p[2].Name != "Xi" {
+		panic("gev: " + panicNameMismatch)
+	}
+	g.Mu = p[0].Value
+	g.Sigma = p[1].Value
+	g.Xi = p[2].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (g GeneralizedExtremeValue) parameters(p []Parameter) []Parameter {
+	nParam := g.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("gev: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = g.Mu
+	p[1].Name = "Sigma"
+	p[1].Value = g.Sigma
+	p[2].Name = "Xi"
+	p[2].Value = g.Xi
+	return p
+}
@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeneralizedExtremeValueProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     2,
+			prob:    0.1493784857672421,
+			cumProb: 0.6690626526678188,
+			logProb: -1.901272020888353,
+		},
+		{
+			loc:     -7,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+	}
+	testDistributionProbs(t, GeneralizedExtremeValue{Mu: 0.5, Sigma: 1.5, Xi: 0.2}, "GEV", pts)
+
+	pts = []univariateProbPoint{
+		{
+			loc:     2,
+			prob:    0.21389763563323813,
+			cumProb: 0.7374543635627547,
+			logProb: -1.5422577165629185,
+		},
+		{
+			loc:     6,
+			prob:    0,
+			cumProb: 1,
+			logProb: math.Inf(-1),
+		},
+	}
+	testDistributionProbs(t, GeneralizedExtremeValue{Mu: 0.5, Sigma: 1.5, Xi: -0.3}, "GEV", pts)
+}
+
+func TestGeneralizedExtremeValue(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []GeneralizedExtremeValue{
+		{Mu: 0, Sigma: 1, Xi: 0, Source: src},
+		{Mu: 2, Sigma: 0.5, Xi: 0.05, Source: src},
+		{Mu: -1, Sigma: 2, Xi: -0.2, Source: src},
+		{Mu: 0, Sigma: 1, Xi: -0.6, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestGeneralizedExtremeValueGumbel(t *testing.T) {
+	gev := GeneralizedExtremeValue{Mu: 1, Sigma: 2}
+	gum := Gumbel{Mu: 1, Beta: 2}
+	for _, x := range []float64{-3, 0, 1, 2.5, 10} {
+		if math.Abs(gev.LogProb(x)-gum.LogProb(x)) > 1e-14 {
+			t.Errorf("LogProb mismatch with Gumbel at %v: want %v, got %v", x, gum.LogProb(x), gev.LogProb(x))
+		}
+		if math.Abs(gev.CDF(x)-gum.CDF(x)) > 1e-14 {
+			t.Errorf("CDF mismatch with Gumbel at %v: want %v, got %v", x, gum.CDF(x), gev.CDF(x))
+		}
+	}
+}
+
+func TestGeneralizedExtremeValueScore(t *testing.T) {
+	for _, test := range []*GeneralizedExtremeValue{
+		{Mu: 0.5, Sigma: 1.5, Xi: 0.2},
+		{Mu: 0.5, Sigma: 1.5, Xi: -0.3},
+		{Mu: 0, Sigma: 1, Xi: 0},
+	} {
+		testDerivParam(t, test)
+	}
+}
+
+func TestGeneralizedExtremeValueFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, want := range []GeneralizedExtremeValue{
+		{Mu: 3, Sigma: 1.5, Xi: 0.2, Source: src},
+		{Mu: -1, Sigma: 0.5, Xi: -0.25, Source: src},
+		{Mu: 0, Sigma: 2, Xi: 0, Source: src},
+	} {
+		var got GeneralizedExtremeValue
+		got.Fit(randn(want, 20000), nil)
+		if math.Abs(got.Mu-want.Mu) > 0.05*want.Sigma || math.Abs(got.Sigma-want.Sigma) > 0.05*want.Sigma || math.Abs(got.Xi-want.Xi) > 0.03 {
+			t.Errorf("Fit mismatch: want %v, got %v", want.parameters(nil), got.parameters(nil))
+		}
+	}
+}
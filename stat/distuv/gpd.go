@@ -0,0 +1,304 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// GeneralizedPareto implements the generalized Pareto distribution (GPD)
+// with location Mu, scale Sigma and shape Xi. The support of the distribution
+// is [Mu, +∞) for Xi >= 0 and [Mu, Mu-Sigma/Xi] for Xi < 0.
+//
+// The GPD is the limiting distribution of the excesses over a high threshold
+// Mu, and is used in peaks-over-threshold analyses.
+// More information at https://en.wikipedia.org/wiki/Generalized_Pareto_distribution.
+type GeneralizedPareto struct {
+	Mu float64
+	// Sigma is the scale parameter. Valid range is (0,+∞).
+	Sigma float64
+	Xi    float64
+
+	Source *rand.Rand
+}
+
+// logSurvival returns the logarithm of the survival function at x for x
+// at or above Mu. The second return value is false if x is above the upper
+// bound of the support.
+func (g GeneralizedPareto) logSurvival(x float64) (float64, bool) {
+	z := (x - g.Mu) / g.Sigma
+	if g.Xi == 0 {
+		return -z, true
+	}
+	s := g.Xi * z
+	if s <= -1 {
+		return math.Inf(-1), false
+	}
+	return -math.Log1p(s) / g.Xi, true
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g GeneralizedPareto) CDF(x float64) float64 {
+	if x < g.Mu {
+		return 0
+	}
+	logS, _ := g.logSurvival(x)
+	return -math.Expm1(logS)
+}
+
+// Entropy returns the entropy of the distribution.
+func (g GeneralizedPareto) Entropy() float64 {
+	return math.Log(g.Sigma) + g.Xi + 1
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution. The excess
+// kurtosis is +Inf for Xi >= 1/4.
+func (g GeneralizedPareto) ExKurtosis() float64 {
+	xi := g.Xi
+	if xi >= 0.25 {
+		return math.Inf(1)
+	}
+	return 3*(1-2*xi)*(2*xi*xi+xi+3)/((1-3*xi)*(1-4*xi)) - 3
+}
+
+// Fit sets the scale and shape parameters of the probability distribution to
+// their maximum likelihood estimates from the data samples x with relative
+// weights w. The location Mu is taken to be the known threshold and is not
+// modified. All samples must be at or above Mu.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+//
+// The estimate of Xi is restricted to Xi > -1, since the likelihood is
+// unbounded for smaller values.
+func (g *GeneralizedPareto) Fit(samples, weights []float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+	if len(samples) == 0 {
+		panic(badNoSamples)
+	}
+	// Start from the method of moments estimates, which satisfy
+	// mean^2/variance = 1 - 2*Xi and mean = Sigma/(1-Xi) for the
+	// excesses over Mu.
+	mean, variance := stat.MeanVariance(samples, weights)
+	mean -= g.Mu
+	xi := 0.5 * (1 - mean*mean/variance)
+	if math.IsNaN(xi) || xi <= -0.5 {
+		xi = 0
+	}
+	sigma := mean * (1 - xi)
+	mu := g.Mu
+	x := nelderMead(func(x []float64) float64 {
+		if x[1] <= -1 {
+			return math.Inf(1)
+		}
+		d := GeneralizedPareto{Mu: mu, Sigma: math.Exp(x[0]), Xi: x[1]}
+		return -logLikelihood(d, samples, weights)
+	}, []float64{math.Log(sigma), xi}, []float64{0.1, 0.1})
+	g.Sigma = math.Exp(x[0])
+	g.Xi = x[1]
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g GeneralizedPareto) LogProb(x float64) float64 {
+	if x < g.Mu {
+		return math.Inf(-1)
+	}
+	logS, ok := g.logSurvival(x)
+	if !ok {
+		return math.Inf(-1)
+	}
+	// The density is (1/Sigma) * S(x)^(1+Xi).
+	return -math.Log(g.Sigma) + (1+g.Xi)*logS
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// for Xi >= 1.
+func (g GeneralizedPareto) Mean() float64 {
+	if g.Xi >= 1 {
+		return math.Inf(1)
+	}
+	return g.Mu + g.Sigma/(1-g.Xi)
+}
+
+// Median returns the median of the probability distribution.
+func (g GeneralizedPareto) Median() float64 {
+	return g.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (g GeneralizedPareto) Mode() float64 {
+	return g.Mu
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (GeneralizedPareto) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability density function at x.
+func (g GeneralizedPareto) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (g GeneralizedPareto) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	return g.fromExp(-math.Log1p(-p))
+}
+
+// fromExp returns the value of the distribution corresponding to the
+// value e of a standard exponential variate.
+func (g GeneralizedPareto) fromExp(e float64) float64 {
+	if g.Xi == 0 {
+		return g.Mu + g.Sigma*e
+	}
+	return g.Mu + g.Sigma*math.Expm1(g.Xi*e)/g.Xi
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g GeneralizedPareto) Rand() float64 {
+	var rnd float64
+	if g.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = g.Source.ExpFloat64()
+	}
+	return g.fromExp(rnd)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Mu, ∂LogProb / ∂Sigma, ∂LogProb / ∂Xi].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+//
+// Special cases:
+//  Score(x) = [NaN, NaN, NaN] for x outside the support
+func (g GeneralizedPareto) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, g.NumParameters())
+	}
+	if len(deriv) != g.NumParameters() {
+		panic(badLength)
+	}
+	_, ok := g.logSurvival(x)
+	if x < g.Mu || !ok {
+		deriv[0] = math.NaN()
+		deriv[1] = math.NaN()
+		deriv[2] = math.NaN()
+		return deriv
+	}
+	z := (x - g.Mu) / g.Sigma
+	if g.Xi == 0 {
+		deriv[0] = 1 / g.Sigma
+		deriv[1] = (z - 1) / g.Sigma
+		deriv[2] = z*z/2 - z
+		return deriv
+	}
+	s := 1 + g.Xi*z
+	d := (1 + g.Xi) / (g.Sigma * s)
+	deriv[0] = d
+	deriv[1] = z*d - 1/g.Sigma
+	deriv[2] = math.Log1p(g.Xi*z)/(g.Xi*g.Xi) - (1+1/g.Xi)*z/s
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+// Special cases:
+//  ScoreInput(x) = 0 for x outside the support
+func (g GeneralizedPareto) ScoreInput(x float64) float64 {
+	_, ok := g.logSurvival(x)
+	if x < g.Mu || !ok {
+		return 0
+	}
+	z := (x - g.Mu) / g.Sigma
+	return -(1 + g.Xi) / (g.Sigma * (1 + g.Xi*z))
+}
+
+// Skewness returns the skewness of the distribution. The skewness is +Inf
+// for Xi >= 1/3.
+func (g GeneralizedPareto) Skewness() float64 {
+	xi := g.Xi
+	if xi >= 1.0/3 {
+		return math.Inf(1)
+	}
+	return 2 * (1 + xi) * math.Sqrt(1-2*xi) / (1 - 3*xi)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g GeneralizedPareto) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g GeneralizedPareto) Survival(x float64) float64 {
+	if x < g.Mu {
+		return 1
+	}
+	logS, _ := g.logSurvival(x)
+	return math.Exp(logS)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf for Xi >= 1/2.
+func (g GeneralizedPareto) Variance() float64 {
+	xi := g.Xi
+	if xi >= 0.5 {
+		return math.Inf(1)
+	}
+	return g.Sigma * g.Sigma / ((1 - xi) * (1 - xi) * (1 - 2*xi))
+}
+
+// setParameters modifies the parameters of the distribution.
+func (g *GeneralizedPareto) setParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("gpd: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("gpd: " + panicNameMismatch)
+	}
+	if p[1].Name != "Sigma" {
+		panic("gpd: " + panicNameMismatch)
+	}
+	if p[2].Name != "Xi" {
+		panic("gpd: " + panicNameMismatch)
+	}
+	g.Mu = p[0].Value
+	g.Sigma = p[1].Value
+	g.Xi = p[2].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (g GeneralizedPareto) parameters(p []Parameter) []Parameter {
+	nParam := g.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("gpd: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = g.Mu
+	p[1].Name = "Sigma"
+	p[1].Value = g.Sigma
+	p[2].Name = "Xi"
+	p[2].Value = g.Xi
+	return p
+}
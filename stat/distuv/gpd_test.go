@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGeneralizedParetoProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     0,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     1,
+			prob:    0.5,
+			cumProb: 0,
+			logProb: -math.Ln2,
+		},
+		{
+			loc:     3,
+			prob:    0.16384,
+			cumProb: 0.5904,
+			logProb: -1.808864937130994,
+		},
+	}
+	testDistributionProbs(t, GeneralizedPareto{Mu: 1, Sigma: 2, Xi: 0.25}, "GPD", pts)
+
+	pts = []univariateProbPoint{
+		{
+			loc:     6,
+			prob:    0,
+			cumProb: 1,
+			logProb: math.Inf(-1),
+		},
+	}
+	testDistributionProbs(t, GeneralizedPareto{Mu: 1, Sigma: 2, Xi: -0.5}, "GPD", pts)
+}
+
+func TestGeneralizedPareto(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []GeneralizedPareto{
+		{Mu: 0, Sigma: 1, Xi: 0, Source: src},
+		{Mu: 2, Sigma: 0.5, Xi: 0.05, Source: src},
+		{Mu: -1, Sigma: 2, Xi: -0.3, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestGeneralizedParetoScore(t *testing.T) {
+	for _, test := range []*GeneralizedPareto{
+		{Mu: 1, Sigma: 2, Xi: 0.25},
+		{Mu: 1, Sigma: 2, Xi: -0.3},
+		{Mu: 0, Sigma: 1, Xi: 0},
+	} {
+		testDerivParam(t, test)
+	}
+}
+
+func TestGeneralizedParetoFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, want := range []GeneralizedPareto{
+		{Mu: 10, Sigma: 1.5, Xi: 0.3, Source: src},
+		{Mu: 0, Sigma: 0.5, Xi: -0.2, Source: src},
+		{Mu: -2, Sigma: 2, Xi: 0, Source: src},
+	} {
+		got := GeneralizedPareto{Mu: want.Mu}
+		got.Fit(randn(want, 20000), nil)
+		if got.Mu != want.Mu {
+			t.Errorf("Fit modified Mu: want %v, got %v", want.Mu, got.Mu)
+		}
+		if math.Abs(got.Sigma-want.Sigma) > 0.05*want.Sigma || math.Abs(got.Xi-want.Xi) > 0.03 {
+			t.Errorf("Fit mismatch: want %v, got %v", want.parameters(nil), got.parameters(nil))
+		}
+	}
+}
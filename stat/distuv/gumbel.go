@@ -0,0 +1,195 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// Gumbel implements the Gumbel (type I extreme value) distribution of the
+// maximum with location Mu and scale Beta.
+// More information at https://en.wikipedia.org/wiki/Gumbel_distribution.
+type Gumbel struct {
+	Mu float64
+	// Beta is the scale parameter. Valid range is (0,+∞).
+	Beta float64
+
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (g Gumbel) CDF(x float64) float64 {
+	return math.Exp(-math.Exp(-(x - g.Mu) / g.Beta))
+}
+
+// Entropy returns the entropy of the distribution.
+func (g Gumbel) Entropy() float64 {
+	return math.Log(g.Beta) + eulerGamma + 1
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (Gumbel) ExKurtosis() float64 {
+	return 12.0 / 5
+}
+
+// Fit sets the parameters of the probability distribution to their maximum
+// likelihood estimates from the data samples x with relative weights w.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (g *Gumbel) Fit(samples, weights []float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+	if len(samples) == 0 {
+		panic(badNoSamples)
+	}
+	// Start from the method of moments estimates.
+	mean, std := stat.MeanStdDev(samples, weights)
+	beta := std * math.Sqrt(6) / math.Pi
+	mu := mean - eulerGamma*beta
+	x := nelderMead(func(x []float64) float64 {
+		return -logLikelihood(Gumbel{Mu: x[0], Beta: math.Exp(x[1])}, samples, weights)
+	}, []float64{mu, math.Log(beta)}, []float64{0.1 * beta, 0.1})
+	g.Mu = x[0]
+	g.Beta = math.Exp(x[1])
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (g Gumbel) LogProb(x float64) float64 {
+	z := (x - g.Mu) / g.Beta
+	return -math.Log(g.Beta) - z - math.Exp(-z)
+}
+
+// Mean returns the mean of the probability distribution.
+func (g Gumbel) Mean() float64 {
+	return g.Mu + g.Beta*eulerGamma
+}
+
+// Median returns the median of the probability distribution.
+func (g Gumbel) Median() float64 {
+	return g.Mu - g.Beta*math.Log(ln2)
+}
+
+// Mode returns the mode of the probability distribution.
+func (g Gumbel) Mode() float64 {
+	return g.Mu
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Gumbel) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (g Gumbel) Prob(x float64) float64 {
+	return math.Exp(g.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (g Gumbel) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	return g.Mu - g.Beta*math.Log(-math.Log(p))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (g Gumbel) Rand() float64 {
+	var rnd float64
+	if g.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = g.Source.ExpFloat64()
+	}
+	return g.Mu - g.Beta*math.Log(rnd)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Mu, ∂LogProb / ∂Beta].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+func (g Gumbel) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, g.NumParameters())
+	}
+	if len(deriv) != g.NumParameters() {
+		panic(badLength)
+	}
+	z := (x - g.Mu) / g.Beta
+	d := -math.Expm1(-z)
+	deriv[0] = d / g.Beta
+	deriv[1] = (z*d - 1) / g.Beta
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+func (g Gumbel) ScoreInput(x float64) float64 {
+	z := (x - g.Mu) / g.Beta
+	return math.Expm1(-z) / g.Beta
+}
+
+// Skewness returns the skewness of the distribution.
+func (Gumbel) Skewness() float64 {
+	return gumbelSkewness
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (g Gumbel) StdDev() float64 {
+	return g.Beta * math.Pi / math.Sqrt(6)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (g Gumbel) Survival(x float64) float64 {
+	return -math.Expm1(-math.Exp(-(x - g.Mu) / g.Beta))
+}
+
+// Variance returns the variance of the probability distribution.
+func (g Gumbel) Variance() float64 {
+	return g.Beta * g.Beta * math.Pi * math.Pi / 6
+}
+
+// setParameters modifies the parameters of the distribution.
+func (g *Gumbel) setParameters(p []Parameter) {
+	if len(p) != g.NumParameters() {
+		panic("gumbel: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("gumbel: " + panicNameMismatch)
+	}
+	if p[1].Name != "Beta" {
+		panic("gumbel: " + panicNameMismatch)
+	}
+	g.Mu = p[0].Value
+	g.Beta = p[1].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (g Gumbel) parameters(p []Parameter) []Parameter {
+	nParam := g.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("gumbel: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = g.Mu
+	p[1].Name = "Beta"
+	p[1].Value = g.Beta
+	return p
+}
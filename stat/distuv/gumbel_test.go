@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGumbelProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     3,
+			prob:    0.12732319002179124,
+			cumProb: 0.6922006275553464,
+			logProb: -2.0610266217313877,
+		},
+		{
+			loc:     1,
+			prob:    math.Exp(-1) / 2,
+			cumProb: math.Exp(-1),
+			logProb: -1 - math.Ln2,
+		},
+	}
+	testDistributionProbs(t, Gumbel{Mu: 1, Beta: 2}, "Gumbel", pts)
+}
+
+func TestGumbel(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Gumbel{
+		{Mu: 0, Beta: 1, Source: src},
+		{Mu: -3, Beta: 0.4, Source: src},
+		{Mu: 10, Beta: 5, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestGumbelScore(t *testing.T) {
+	for _, test := range []*Gumbel{
+		{Mu: 0, Beta: 1},
+		{Mu: 2, Beta: 0.3},
+	} {
+		testDerivParam(t, test)
+	}
+}
+
+func TestGumbelFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Gumbel{Mu: 3, Beta: 1.5, Source: src}
+	var got Gumbel
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.Mu-want.Mu) > 0.02 || math.Abs(got.Beta-want.Beta) > 0.02 {
+		t.Errorf("Fit mismatch: want %v, got %v", want.parameters(nil), got.parameters(nil))
+	}
+}
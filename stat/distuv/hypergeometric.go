@@ -0,0 +1,165 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Hypergeometric represents the hypergeometric distribution, the distribution
+// of the number of successes in Draws draws without replacement from a
+// population of size N that contains K successes. N, K and Draws must be
+// non-negative integers with K <= N and Draws <= N.
+// More information at https://en.wikipedia.org/wiki/Hypergeometric_distribution.
+type Hypergeometric struct {
+	N      float64
+	K      float64
+	Draws  float64
+	Source *rand.Rand
+}
+
+// bounds returns the smallest and largest values in the support of the
+// distribution.
+func (h Hypergeometric) bounds() (lo, hi float64) {
+	return math.Max(0, h.Draws+h.K-h.N), math.Min(h.Draws, h.K)
+}
+
+// CDF computes the value of the cumulative distribution function at x.
+func (h Hypergeometric) CDF(x float64) float64 {
+	lo, hi := h.bounds()
+	if x < lo {
+		return 0
+	}
+	if x >= hi {
+		return 1
+	}
+	k := math.Floor(x)
+	// Sum over the shorter of the two tails.
+	if k-lo <= hi-k {
+		return h.sum(lo, k)
+	}
+	return 1 - h.sum(k+1, hi)
+}
+
+// sum returns the total probability of the values in [a, b].
+func (h Hypergeometric) sum(a, b float64) float64 {
+	var s float64
+	for k := a; k <= b; k++ {
+		s += h.Prob(k)
+	}
+	return math.Min(s, 1)
+}
+
+// Entropy returns the entropy of the distribution.
+func (h Hypergeometric) Entropy() float64 {
+	lo, hi := h.bounds()
+	return discreteEntropy(h.LogProb, lo, hi, h.Mode())
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (h Hypergeometric) ExKurtosis() float64 {
+	n, k, d := h.N, h.K, h.Draws
+	num := (n-1)*n*n*(n*(n+1)-6*k*(n-k)-6*d*(n-d)) + 6*d*k*(n-k)*(n-d)*(5*n-6)
+	den := d * k * (n - k) * (n - d) * (n - 2) * (n - 3)
+	return num / den
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// mass function at x.
+func (h Hypergeometric) LogProb(x float64) float64 {
+	lo, hi := h.bounds()
+	if x < lo || x > hi || math.Floor(x) != x {
+		return math.Inf(-1)
+	}
+	return lchoose(h.K, x) + lchoose(h.N-h.K, h.Draws-x) - lchoose(h.N, h.Draws)
+}
+
+// lchoose returns the natural logarithm of the binomial coefficient n choose k.
+func lchoose(n, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
+
+// Mean returns the mean of the probability distribution.
+func (h Hypergeometric) Mean() float64 {
+	return h.Draws * h.K / h.N
+}
+
+// Median returns the median of the probability distribution.
+func (h Hypergeometric) Median() float64 {
+	return h.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (h Hypergeometric) Mode() float64 {
+	return math.Floor((h.Draws + 1) * (h.K + 1) / (h.N + 2))
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Hypergeometric) NumParameters() int {
+	return 3
+}
+
+// Prob computes the value of the probability mass function at x.
+func (h Hypergeometric) Prob(x float64) float64 {
+	return math.Exp(h.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative distribution function, that
+// is the smallest integer k such that CDF(k) >= p.
+func (h Hypergeometric) Quantile(p float64) float64 {
+	lo, hi := h.bounds()
+	return discreteQuantile(h.CDF, p, lo, hi, h.Mean())
+}
+
+// Rand returns a random sample drawn from the distribution.
+//
+// The sample is generated by inversion, searching outward from the mode.
+func (h Hypergeometric) Rand() float64 {
+	var u float64
+	if h.Source == nil {
+		u = rand.Float64()
+	} else {
+		u = h.Source.Float64()
+	}
+	lo, hi := h.bounds()
+	return chopDown(u, h.Prob, lo, hi, h.Mode())
+}
+
+// Skewness returns the skewness of the distribution.
+func (h Hypergeometric) Skewness() float64 {
+	n, k, d := h.N, h.K, h.Draws
+	return (n - 2*k) * math.Sqrt(n-1) * (n - 2*d) / (math.Sqrt(d*k*(n-k)*(n-d)) * (n - 2))
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (h Hypergeometric) StdDev() float64 {
+	return math.Sqrt(h.Variance())
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (h Hypergeometric) Survival(x float64) float64 {
+	lo, hi := h.bounds()
+	if x < lo {
+		return 1
+	}
+	if x >= hi {
+		return 0
+	}
+	k := math.Floor(x)
+	if hi-k <= k-lo {
+		return h.sum(k+1, hi)
+	}
+	return 1 - h.sum(lo, k)
+}
+
+// Variance returns the variance of the probability distribution.
+func (h Hypergeometric) Variance() float64 {
+	n, k, d := h.N, h.K, h.Draws
+	return d * k / n * (n - k) / n * (n - d) / (n - 1)
+}
@@ -0,0 +1,55 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestHypergeometricProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     0,
+			prob:    0.00010319917440660474,
+			cumProb: 0.00010319917440660474,
+			logProb: math.Log(0.00010319917440660474),
+		},
+		{
+			loc:     4,
+			prob:    0.35758513931888547,
+			cumProb: 0.608359133126935,
+			logProb: -1.0283817932608081,
+		},
+		{
+			loc:     8,
+			prob:    0,
+			cumProb: 1,
+			logProb: math.Inf(-1),
+		},
+	}
+	testDistributionProbs(t, Hypergeometric{N: 20, K: 7, Draws: 12}, "Hypergeometric", pts)
+}
+
+func TestHypergeometric(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Hypergeometric{
+		{N: 20, K: 7, Draws: 12, Source: src},
+		{N: 50, K: 45, Draws: 10, Source: src},
+		{N: 100, K: 30, Draws: 80, Source: src},
+		{N: 5000, K: 1000, Draws: 600, Source: src},
+	} {
+		testDiscreteRand(t, dist, i)
+		lo, hi := dist.bounds()
+		testDiscreteDist(t, i, dist, lo, hi)
+	}
+}
@@ -20,3 +20,18 @@ type RandLogProber interface {
 type Quantiler interface {
 	Quantile(p float64) float64
 }
+
+type CDFer interface {
+	CDF(x float64) float64
+}
+
+type Truncatable interface {
+	CDFer
+	LogProber
+	Quantiler
+}
+
+type Univariate interface {
+	Truncatable
+	Rander
+}
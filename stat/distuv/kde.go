@@ -0,0 +1,405 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/internal/fourier"
+	"gonum.org/v1/gonum/internal/golden"
+	"gonum.org/v1/gonum/stat"
+)
+
+// KDE is a univariate kernel density estimate. Its density is
+//  p(x) = \sum_i w_i K((x-x_i)/h) / h
+// where x_i are the samples with normalized weights w_i, K is the kernel and
+// h is the bandwidth. Use NewKDE to construct.
+//
+// The samples are kept sorted, so evaluation for kernels with bounded support
+// only visits the samples within the support of the kernel. ProbGrid
+// evaluates the density on an evenly spaced grid in time independent of the
+// number of samples.
+type KDE struct {
+	x   []float64
+	w   []float64
+	cum []float64
+
+	kernel    Kernel
+	bandwidth float64
+	radius    float64
+
+	src *rand.Rand
+}
+
+// NewKDE returns a kernel density estimate from the samples x with relative
+// weights and the given kernel and bandwidth. If weights is nil, then all
+// the weights are 1. If kernel is nil, GaussianKernel is used.
+//
+// NewKDE panics if len(x) == 0, if len(weights) != len(x) for non-nil
+// weights, if any weight is negative or the weights sum to zero, or if
+// bandwidth is not positive.
+func NewKDE(x, weights []float64, kernel Kernel, bandwidth float64, src *rand.Rand) *KDE {
+	if len(x) == 0 {
+		panic(badNoSamples)
+	}
+	if weights != nil && len(weights) != len(x) {
+		panic(badLength)
+	}
+	if !(bandwidth > 0) {
+		panic("kde: non-positive bandwidth")
+	}
+	if kernel == nil {
+		kernel = GaussianKernel{}
+	}
+	k := &KDE{
+		x:         make([]float64, len(x)),
+		w:         make([]float64, len(x)),
+		cum:       make([]float64, len(x)),
+		kernel:    kernel,
+		bandwidth: bandwidth,
+		radius:    kernel.Radius() * bandwidth,
+		src:       src,
+	}
+	copy(k.x, x)
+	for i := range k.w {
+		k.w[i] = 1
+		if weights != nil {
+			if weights[i] < 0 {
+				panic("kde: negative weight")
+			}
+			k.w[i] = weights[i]
+		}
+	}
+	stat.SortWeighted(k.x, k.w)
+	sum := floats.Sum(k.w)
+	if !(sum > 0) {
+		panic("kde: zero weights")
+	}
+	floats.Scale(1/sum, k.w)
+	floats.CumSum(k.cum, k.w)
+	return k
+}
+
+// Bandwidth returns the bandwidth of the kernel density estimate.
+func (k *KDE) Bandwidth() float64 {
+	return k.bandwidth
+}
+
+// window returns the range of indices of the samples within the support
+// of the kernel centred at x.
+func (k *KDE) window(x float64) (lo, hi int) {
+	if math.IsInf(k.radius, 1) {
+		return 0, len(k.x)
+	}
+	lo = sort.SearchFloat64s(k.x, x-k.radius)
+	hi = sort.Search(len(k.x), func(i int) bool { return k.x[i] > x+k.radius })
+	return lo, hi
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (k *KDE) CDF(x float64) float64 {
+	lo, hi := k.window(x)
+	var p float64
+	if lo > 0 {
+		p = k.cum[lo-1]
+	}
+	for i := lo; i < hi; i++ {
+		p += k.w[i] * k.kernel.CDF((x-k.x[i])/k.bandwidth)
+	}
+	return math.Min(1, p)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (k *KDE) LogProb(x float64) float64 {
+	lo, hi := k.window(x)
+	if lo == hi {
+		return math.Inf(-1)
+	}
+	// Compute the log of the sum stably by factoring out the largest term
+	// so that the density is accurate far in the tails of unbounded
+	// kernels.
+	lp := make([]float64, hi-lo)
+	for i := range lp {
+		w := k.w[lo+i]
+		if w == 0 {
+			lp[i] = math.Inf(-1)
+			continue
+		}
+		lp[i] = math.Log(w) + k.kernel.LogProb((x-k.x[lo+i])/k.bandwidth)
+	}
+	return floats.LogSumExp(lp) - math.Log(k.bandwidth)
+}
+
+// Mean returns the mean of the probability distribution.
+func (k *KDE) Mean() float64 {
+	return floats.Dot(k.x, k.w)
+}
+
+// Prob computes the value of the probability density function at x.
+func (k *KDE) Prob(x float64) float64 {
+	return math.Exp(k.LogProb(x))
+}
+
+// ProbGrid stores the values of the probability density function at the
+// len(dst) evenly spaced points from min to max inclusive into dst and
+// returns it. ProbGrid panics if len(dst) < 2 or if min >= max.
+//
+// The samples are linearly binned onto the grid and the binned counts are
+// convolved with the kernel using a fast Fourier transform, so the cost is
+// independent of the number of samples. The approximation error decreases
+// with the grid spacing relative to the bandwidth, quadratically for smooth
+// kernels. Kernels with unbounded support are truncated at 8.5 bandwidths.
+func (k *KDE) ProbGrid(dst []float64, min, max float64) []float64 {
+	m := len(dst)
+	if m < 2 {
+		panic("kde: grid too small")
+	}
+	if !(min < max) {
+		panic("kde: bad grid bounds")
+	}
+	delta := (max - min) / float64(m-1)
+	radius := math.Min(k.radius, 8.5*k.bandwidth)
+	l := int(math.Ceil(radius / delta))
+
+	// Linearly bin the samples onto the grid extended by the kernel
+	// radius on each side.
+	size := m + 2*l
+	n := 1
+	for n < size+l {
+		n *= 2
+	}
+	counts := make([]complex128, n)
+	origin := min - float64(l)*delta
+	for i, x := range k.x {
+		t := (x - origin) / delta
+		if t < 0 || t > float64(size-1) {
+			continue
+		}
+		j := int(t)
+		if j == size-1 {
+			counts[j] += complex(k.w[i], 0)
+			continue
+		}
+		f := t - float64(j)
+		counts[j] += complex(k.w[i]*(1-f), 0)
+		counts[j+1] += complex(k.w[i]*f, 0)
+	}
+
+	kern := make([]complex128, n)
+	for j := 0; j <= l; j++ {
+		v := math.Exp(k.kernel.LogProb(float64(j)*delta/k.bandwidth)) / k.bandwidth
+		kern[j] = complex(v, 0)
+		if j > 0 {
+			kern[n-j] = complex(v, 0)
+		}
+	}
+
+	fourier.FFT(counts, false)
+	fourier.FFT(kern, false)
+	for i := range counts {
+		counts[i] *= kern[i]
+	}
+	fourier.FFT(counts, true)
+	for i := range dst {
+		dst[i] = math.Max(0, real(counts[i+l])/float64(n))
+	}
+	return dst
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// The quantile is found by bisection of the CDF.
+func (k *KDE) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	radius := k.radius
+	if math.IsInf(radius, 1) {
+		switch p {
+		case 0:
+			return math.Inf(-1)
+		case 1:
+			return math.Inf(1)
+		}
+		// The CDF of the kernel is indistinguishable from 0 or 1
+		// beyond 40 bandwidths.
+		radius = 40 * k.bandwidth
+	}
+	lo := k.x[0] - radius
+	hi := k.x[len(k.x)-1] + radius
+	for {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if k.CDF(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (k *KDE) Rand() float64 {
+	var rnd float64
+	if k.src == nil {
+		rnd = rand.Float64()
+	} else {
+		rnd = k.src.Float64()
+	}
+	i := sort.Search(len(k.cum), func(i int) bool { return k.cum[i] > rnd })
+	if i == len(k.cum) {
+		i = len(k.cum) - 1
+	}
+	for k.w[i] == 0 {
+		i--
+	}
+	return k.x[i] + k.bandwidth*k.kernel.Rand(k.src)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (k *KDE) StdDev() float64 {
+	return math.Sqrt(k.Variance())
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (k *KDE) Survival(x float64) float64 {
+	lo, hi := k.window(x)
+	p := 1.0
+	if hi > 0 {
+		p -= k.cum[hi-1]
+	}
+	for i := lo; i < hi; i++ {
+		p += k.w[i] * k.kernel.CDF((k.x[i]-x)/k.bandwidth)
+	}
+	return math.Min(1, math.Max(0, p))
+}
+
+// Variance returns the variance of the probability distribution, which is
+// the weighted variance of the samples plus the squared bandwidth.
+func (k *KDE) Variance() float64 {
+	mean := k.Mean()
+	var v float64
+	for i, x := range k.x {
+		d := x - mean
+		v += k.w[i] * d * d
+	}
+	return v + k.bandwidth*k.bandwidth
+}
+
+// effectiveSize returns the effective sample size, (\sum_i w_i)^2 / \sum_i w_i^2.
+func effectiveSize(x, weights []float64) float64 {
+	if weights == nil {
+		return float64(len(x))
+	}
+	sum := floats.Sum(weights)
+	return sum * sum / floats.Dot(weights, weights)
+}
+
+// ScottBandwidth returns the bandwidth for a kernel density estimate of the
+// samples x with relative weights given by Scott's rule,
+//  h = 1.06 σ n^(-1/5)
+// where σ is the standard deviation of the samples and n is the effective
+// sample size. If weights is nil, then all the weights are 1.
+func ScottBandwidth(x, weights []float64) float64 {
+	if weights != nil && len(weights) != len(x) {
+		panic(badLength)
+	}
+	std := stat.StdDev(x, weights)
+	return 1.06 * std * math.Pow(effectiveSize(x, weights), -0.2)
+}
+
+// SilvermanBandwidth returns the bandwidth for a kernel density estimate of
+// the samples x with relative weights given by Silverman's rule of thumb,
+//  h = 0.9 min(σ, IQR/1.34) n^(-1/5)
+// where σ is the standard deviation and IQR is the interquartile range of the
+// samples, and n is the effective sample size. The rule is robust to outliers
+// and multimodality. If weights is nil, then all the weights are 1.
+func SilvermanBandwidth(x, weights []float64) float64 {
+	if weights != nil && len(weights) != len(x) {
+		panic(badLength)
+	}
+	std := stat.StdDev(x, weights)
+	xs := make([]float64, len(x))
+	copy(xs, x)
+	var ws []float64
+	if weights != nil {
+		ws = make([]float64, len(weights))
+		copy(ws, weights)
+	}
+	stat.SortWeighted(xs, ws)
+	iqr := stat.Quantile(0.75, stat.Empirical, xs, ws) - stat.Quantile(0.25, stat.Empirical, xs, ws)
+	s := std
+	if iqr > 0 {
+		s = math.Min(std, iqr/1.34)
+	}
+	return 0.9 * s * math.Pow(effectiveSize(x, weights), -0.2)
+}
+
+// CVBandwidth returns the bandwidth for a kernel density estimate of the
+// samples x with relative weights that maximizes the leave-one-out
+// cross-validated log-likelihood
+//  \sum_i w_i log p_{-i}(x_i)
+// where p_{-i} is the estimate with the ith sample removed. If weights is nil,
+// then all the weights are 1. If kernel is nil, GaussianKernel is used.
+//
+// The bandwidth is searched for within a factor of 20 of the bandwidth given
+// by SilvermanBandwidth. The cost of each evaluation of the likelihood is
+// quadratic in the number of samples for kernels with unbounded support.
+func CVBandwidth(x, weights []float64, kernel Kernel) float64 {
+	if weights != nil && len(weights) != len(x) {
+		panic(badLength)
+	}
+	if len(x) < 2 {
+		panic(badNoSamples)
+	}
+	if kernel == nil {
+		kernel = GaussianKernel{}
+	}
+	h0 := SilvermanBandwidth(x, weights)
+	if !(h0 > 0) {
+		panic("kde: samples have no spread")
+	}
+	xs := make([]float64, len(x))
+	copy(xs, x)
+	ws := make([]float64, len(x))
+	for i := range ws {
+		ws[i] = 1
+		if weights != nil {
+			ws[i] = weights[i]
+		}
+	}
+	stat.SortWeighted(xs, ws)
+	sum := floats.Sum(ws)
+	cv := func(logH float64) float64 {
+		h := math.Exp(logH)
+		radius := kernel.Radius() * h
+		var ll float64
+		lo := 0
+		for i, xi := range xs {
+			if ws[i] == 0 || sum-ws[i] <= 0 {
+				continue
+			}
+			for lo < len(xs) && xs[lo] < xi-radius {
+				lo++
+			}
+			var p float64
+			for j := lo; j < len(xs) && xs[j] <= xi+radius; j++ {
+				if j == i {
+					continue
+				}
+				p += ws[j] * math.Exp(kernel.LogProb((xi-xs[j])/h))
+			}
+			ll += ws[i] * math.Log(p/(h*(sum-ws[i])))
+		}
+		return ll
+	}
+	return math.Exp(golden.Max(cv, math.Log(h0/20), math.Log(h0*20), 1e-6))
+}
@@ -0,0 +1,194 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+var testKernels = []Kernel{
+	GaussianKernel{},
+	EpanechnikovKernel{},
+	UniformKernel{},
+	TriangularKernel{},
+	BiweightKernel{},
+}
+
+func TestKDE(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 200)
+	weights := make([]float64, len(x))
+	for i := range x {
+		if i%3 == 0 {
+			x[i] = 4 + 0.5*src.NormFloat64()
+		} else {
+			x[i] = src.NormFloat64()
+		}
+		weights[i] = src.Float64()
+	}
+	for i, kernel := range testKernels {
+		for _, w := range [][]float64{nil, weights} {
+			h := SilvermanBandwidth(x, w)
+			k := NewKDE(x, w, kernel, h, src)
+			if k.Bandwidth() != h {
+				t.Errorf("Bandwidth mismatch case %v: want %v, got %v", i, h, k.Bandwidth())
+			}
+			sumW := float64(len(x))
+			if w != nil {
+				sumW = floats.Sum(w)
+			}
+			for _, v := range []float64{-2, 0, 1.3, 4, 7} {
+				var want float64
+				for j, xj := range x {
+					wj := 1.0
+					if w != nil {
+						wj = w[j]
+					}
+					want += wj * math.Exp(kernel.LogProb((v-xj)/h)) / h
+				}
+				want /= sumW
+				if got := k.Prob(v); !floats.EqualWithinAbsOrRel(got, want, 1e-14, 1e-12) {
+					t.Errorf("Prob mismatch case %v at %v: want %v, got %v", i, v, want, got)
+				}
+			}
+			// The density of kernels that are not smooth is not
+			// integrated accurately by Gaussian quadrature.
+			testKDEDist(t, i, k, 1e-4)
+
+			samples := make([]float64, 1e5)
+			generateSamples(samples, k)
+			checkMean(t, i, samples, k, 1e-2)
+			checkVarAndStd(t, i, samples, k, 2e-2)
+		}
+	}
+}
+
+// testKDEDist performs the checks of testUnivariateDist, but checks Prob and
+// LogProb only at an evenly thinned subset of the samples, since evaluating
+// the density of a KDE visits every data point.
+func testKDEDist(t *testing.T, i int, k *KDE, probTol float64) {
+	const (
+		tol  = 1e-2
+		thin = 100
+	)
+	x := make([]float64, 1e5)
+	generateSamples(x, k)
+	sort.Float64s(x)
+	checkQuantileCDFSurvival(t, i, x, k, tol)
+	sub := make([]float64, 0, len(x)/thin)
+	for j := 0; j < len(x); j += thin {
+		sub = append(sub, x[j])
+	}
+	checkProbLogProbSupport(t, i, sub, k)
+	checkQuantileIntegrals(t, i, x, k, probTol)
+}
+
+func TestKDETail(t *testing.T) {
+	k := NewKDE([]float64{0, 1}, nil, nil, 0.1, nil)
+	// The density far in the tail underflows, but its log must not.
+	want := math.Log(0.5) + GaussianKernel{}.LogProb(100/0.1) - math.Log(0.1)
+	if got := k.LogProb(-100); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Errorf("LogProb mismatch in the tail: want %v, got %v", want, got)
+	}
+}
+
+func TestKDEWeights(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 50)
+	weights := make([]float64, len(x))
+	var rep []float64
+	for i := range x {
+		x[i] = src.NormFloat64()
+		weights[i] = float64(src.Intn(4))
+		for j := 0; j < int(weights[i]); j++ {
+			rep = append(rep, x[i])
+		}
+	}
+	for i, kernel := range testKernels {
+		k1 := NewKDE(x, weights, kernel, 0.3, nil)
+		k2 := NewKDE(rep, nil, kernel, 0.3, nil)
+		for _, v := range []float64{-1, 0, 0.2, 1.5} {
+			if !floats.EqualWithinAbsOrRel(k1.Prob(v), k2.Prob(v), 1e-14, 1e-12) {
+				t.Errorf("Prob mismatch case %v at %v: %v, %v", i, v, k1.Prob(v), k2.Prob(v))
+			}
+			if !floats.EqualWithinAbsOrRel(k1.CDF(v), k2.CDF(v), 1e-14, 1e-12) {
+				t.Errorf("CDF mismatch case %v at %v: %v, %v", i, v, k1.CDF(v), k2.CDF(v))
+			}
+		}
+	}
+}
+
+func TestKDEProbGrid(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = src.NormFloat64()
+	}
+	for i, kernel := range testKernels {
+		k := NewKDE(x, nil, kernel, 0.3, nil)
+		for _, test := range []struct {
+			n        int
+			min, max float64
+			tol      float64
+		}{
+			{n: 1001, min: -5, max: 5, tol: 5e-3},
+			{n: 401, min: -1, max: 2, tol: 5e-3},
+			{n: 50, min: -3, max: 3, tol: 3e-2},
+		} {
+			grid := k.ProbGrid(make([]float64, test.n), test.min, test.max)
+			delta := (test.max - test.min) / float64(test.n-1)
+			for j, got := range grid {
+				v := test.min + float64(j)*delta
+				if want := k.Prob(v); math.Abs(got-want) > test.tol {
+					t.Errorf("ProbGrid mismatch case %v at %v: want %v, got %v", i, v, want, got)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestKDEBandwidth(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = 2 * src.NormFloat64()
+	}
+	std := stat.StdDev(x, nil)
+	if got, want := ScottBandwidth(x, nil), 1.06*std*math.Pow(1000, -0.2); got != want {
+		t.Errorf("Scott bandwidth mismatch: want %v, got %v", want, got)
+	}
+	sorted := make([]float64, len(x))
+	copy(sorted, x)
+	stat.SortWeighted(sorted, nil)
+	iqr := stat.Quantile(0.75, stat.Empirical, sorted, nil) - stat.Quantile(0.25, stat.Empirical, sorted, nil)
+	if got, want := SilvermanBandwidth(x, nil), 0.9*math.Min(std, iqr/1.34)*math.Pow(1000, -0.2); got != want {
+		t.Errorf("Silverman bandwidth mismatch: want %v, got %v", want, got)
+	}
+
+	// The optimal bandwidth for normal data is close to Scott's rule.
+	for _, kernel := range []Kernel{GaussianKernel{}, EpanechnikovKernel{}} {
+		h := CVBandwidth(x, nil, kernel)
+		if ratio := h / ScottBandwidth(x, nil); ratio < 0.6 || ratio > 1.6 {
+			t.Errorf("Cross-validated bandwidth %v far from Scott's rule %v", h, ScottBandwidth(x, nil))
+		}
+	}
+
+	// Unit weights must not change the bandwidths.
+	ones := make([]float64, len(x))
+	floats.AddConst(1, ones)
+	if got, want := SilvermanBandwidth(x, ones), SilvermanBandwidth(x, nil); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+		t.Errorf("Weighted Silverman bandwidth mismatch: want %v, got %v", want, got)
+	}
+	if got, want := CVBandwidth(x[:200], ones[:200], nil), CVBandwidth(x[:200], nil, nil); !floats.EqualWithinAbsOrRel(got, want, 1e-6, 1e-6) {
+		t.Errorf("Weighted cross-validated bandwidth mismatch: want %v, got %v", want, got)
+	}
+}
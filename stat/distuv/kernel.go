@@ -0,0 +1,217 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Kernel is a symmetric probability density used as a smoothing kernel in
+// kernel density estimation. Kernels are scaled to have unit variance, so
+// that the bandwidth of a KDE is the standard deviation of its kernel.
+type Kernel interface {
+	// LogProb returns the log of the density of the kernel at u.
+	LogProb(u float64) float64
+
+	// CDF returns the cumulative distribution function of the kernel at u.
+	CDF(u float64) float64
+
+	// Radius returns the radius of the support of the kernel, or +Inf if
+	// the support is unbounded.
+	Radius() float64
+
+	// Rand returns a random sample drawn from the kernel using src. If src
+	// is nil, the global source in math/rand is used.
+	Rand(src *rand.Rand) float64
+}
+
+// unifFrom returns the uniform variate generator of src, or of the global
+// source in math/rand if src is nil.
+func unifFrom(src *rand.Rand) func() float64 {
+	if src == nil {
+		return rand.Float64
+	}
+	return src.Float64
+}
+
+// GaussianKernel is the standard normal kernel.
+type GaussianKernel struct{}
+
+// LogProb returns the log of the density of the kernel at u.
+func (GaussianKernel) LogProb(u float64) float64 {
+	return -0.5*u*u - logRoot2Pi
+}
+
+// CDF returns the cumulative distribution function of the kernel at u.
+func (GaussianKernel) CDF(u float64) float64 {
+	return 0.5 * math.Erfc(-u/math.Sqrt2)
+}
+
+// Radius returns +Inf.
+func (GaussianKernel) Radius() float64 {
+	return math.Inf(1)
+}
+
+// Rand returns a random sample drawn from the kernel.
+func (GaussianKernel) Rand(src *rand.Rand) float64 {
+	if src == nil {
+		return rand.NormFloat64()
+	}
+	return src.NormFloat64()
+}
+
+const (
+	epanechnikovRadius = 2.23606797749978969640917366873127623544061835961152572427089 // √5
+	uniformRadius      = 1.73205080756887729352744634150587236694280525381038062805581 // √3
+	triangularRadius   = 2.44948974278317809819728407470589139196594748065667012843269 // √6
+	biweightRadius     = 2.64575131106459059050161575363926042571025918308245018036833 // √7
+)
+
+// EpanechnikovKernel is the Epanechnikov kernel with density proportional to
+// 1-u²/5 for |u| < √5. It is the kernel with the smallest asymptotic mean
+// integrated squared error.
+type EpanechnikovKernel struct{}
+
+// LogProb returns the log of the density of the kernel at u.
+func (EpanechnikovKernel) LogProb(u float64) float64 {
+	t := u / epanechnikovRadius
+	if math.Abs(t) >= 1 {
+		return math.Inf(-1)
+	}
+	return math.Log(0.75/epanechnikovRadius) + math.Log1p(-t*t)
+}
+
+// CDF returns the cumulative distribution function of the kernel at u.
+func (EpanechnikovKernel) CDF(u float64) float64 {
+	t := math.Max(-1, math.Min(1, u/epanechnikovRadius))
+	return 0.5 + 0.75*t - 0.25*t*t*t
+}
+
+// Radius returns the radius of the support of the kernel, √5.
+func (EpanechnikovKernel) Radius() float64 {
+	return epanechnikovRadius
+}
+
+// Rand returns a random sample drawn from the kernel.
+func (EpanechnikovKernel) Rand(src *rand.Rand) float64 {
+	// The median of three uniform variates on [-1, 1], taking the second
+	// when the third has the largest magnitude, follows the canonical
+	// Epanechnikov distribution.
+	unifrnd := unifFrom(src)
+	u1 := 2*unifrnd() - 1
+	u2 := 2*unifrnd() - 1
+	u3 := 2*unifrnd() - 1
+	if math.Abs(u3) >= math.Abs(u2) && math.Abs(u3) >= math.Abs(u1) {
+		return epanechnikovRadius * u2
+	}
+	return epanechnikovRadius * u3
+}
+
+// UniformKernel is the uniform kernel on [-√3, √3].
+type UniformKernel struct{}
+
+// LogProb returns the log of the density of the kernel at u.
+func (UniformKernel) LogProb(u float64) float64 {
+	if math.Abs(u) > uniformRadius {
+		return math.Inf(-1)
+	}
+	return -math.Log(2 * uniformRadius)
+}
+
+// CDF returns the cumulative distribution function of the kernel at u.
+func (UniformKernel) CDF(u float64) float64 {
+	t := math.Max(-1, math.Min(1, u/uniformRadius))
+	return 0.5 * (1 + t)
+}
+
+// Radius returns the radius of the support of the kernel, √3.
+func (UniformKernel) Radius() float64 {
+	return uniformRadius
+}
+
+// Rand returns a random sample drawn from the kernel.
+func (UniformKernel) Rand(src *rand.Rand) float64 {
+	return uniformRadius * (2*unifFrom(src)() - 1)
+}
+
+// TriangularKernel is the triangular kernel with density proportional to
+// 1-|u|/√6 for |u| < √6.
+type TriangularKernel struct{}
+
+// LogProb returns the log of the density of the kernel at u.
+func (TriangularKernel) LogProb(u float64) float64 {
+	t := math.Abs(u) / triangularRadius
+	if t >= 1 {
+		return math.Inf(-1)
+	}
+	return math.Log1p(-t) - math.Log(triangularRadius)
+}
+
+// CDF returns the cumulative distribution function of the kernel at u.
+func (TriangularKernel) CDF(u float64) float64 {
+	t := math.Max(-1, math.Min(1, u/triangularRadius))
+	if t < 0 {
+		return 0.5 * (1 + t) * (1 + t)
+	}
+	return 1 - 0.5*(1-t)*(1-t)
+}
+
+// Radius returns the radius of the support of the kernel, √6.
+func (TriangularKernel) Radius() float64 {
+	return triangularRadius
+}
+
+// Rand returns a random sample drawn from the kernel.
+func (TriangularKernel) Rand(src *rand.Rand) float64 {
+	unifrnd := unifFrom(src)
+	return triangularRadius * (unifrnd() - unifrnd())
+}
+
+// BiweightKernel is the biweight (quartic) kernel with density proportional
+// to (1-u²/7)² for |u| < √7.
+type BiweightKernel struct{}
+
+// LogProb returns the log of the density of the kernel at u.
+func (BiweightKernel) LogProb(u float64) float64 {
+	t := u / biweightRadius
+	if math.Abs(t) >= 1 {
+		return math.Inf(-1)
+	}
+	return math.Log(15.0/(16*biweightRadius)) + 2*math.Log1p(-t*t)
+}
+
+// CDF returns the cumulative distribution function of the kernel at u.
+func (BiweightKernel) CDF(u float64) float64 {
+	t := u / biweightRadius
+	switch {
+	case t <= -1:
+		return 0
+	case t >= 1:
+		return 1
+	}
+	t2 := t * t
+	return 0.5 + 15.0/16*t*(1-2*t2/3+t2*t2/5)
+}
+
+// Radius returns the radius of the support of the kernel, √7.
+func (BiweightKernel) Radius() float64 {
+	return biweightRadius
+}
+
+// Rand returns a random sample drawn from the kernel.
+func (BiweightKernel) Rand(src *rand.Rand) float64 {
+	// The median of five uniform variates on [0, 1] is Beta(3, 3)
+	// distributed, which is the canonical biweight distribution on
+	// [0, 1].
+	unifrnd := unifFrom(src)
+	var u [5]float64
+	for i := range u {
+		u[i] = unifrnd()
+	}
+	sort.Float64s(u[:])
+	return biweightRadius * (2*u[2] - 1)
+}
@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/integrate/quad"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestKernels(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		name   string
+		kernel Kernel
+	}{
+		{"Gaussian", GaussianKernel{}},
+		{"Epanechnikov", EpanechnikovKernel{}},
+		{"Uniform", UniformKernel{}},
+		{"Triangular", TriangularKernel{}},
+		{"Biweight", BiweightKernel{}},
+	} {
+		k := test.kernel
+		r := k.Radius()
+		lim := math.Min(r, 40)
+		prob := func(u float64) float64 { return math.Exp(k.LogProb(u)) }
+
+		// Integrate each half separately so that the kink at zero of the
+		// triangular kernel falls on an end point.
+		mass := quad.Fixed(prob, -lim, 0, 1000, nil, 0) + quad.Fixed(prob, 0, lim, 1000, nil, 0)
+		if math.Abs(mass-1) > 1e-10 {
+			t.Errorf("%s kernel does not integrate to 1: got %v", test.name, mass)
+		}
+		sq := func(u float64) float64 { return u * u * prob(u) }
+		variance := quad.Fixed(sq, -lim, 0, 1000, nil, 0) + quad.Fixed(sq, 0, lim, 1000, nil, 0)
+		if math.Abs(variance-1) > 1e-10 {
+			t.Errorf("%s kernel does not have unit variance: got %v", test.name, variance)
+		}
+		if !math.IsInf(r, 1) {
+			if k.LogProb(r*1.0001) != math.Inf(-1) || k.CDF(-r) != 0 || k.CDF(r) != 1 {
+				t.Errorf("%s kernel non-zero outside its support", test.name)
+			}
+		}
+		for _, u := range []float64{-1.5, -0.3, 0, 0.7, 2} {
+			v := math.Max(-lim, math.Min(lim, u))
+			want := quad.Fixed(prob, -lim, math.Min(v, 0), 1000, nil, 0)
+			if v > 0 {
+				want += quad.Fixed(prob, 0, v, 1000, nil, 0)
+			}
+			if got := k.CDF(u); math.Abs(got-want) > 1e-10 {
+				t.Errorf("%s kernel CDF mismatch at %v: want %v, got %v", test.name, u, want, got)
+			}
+		}
+
+		x := make([]float64, 1e5)
+		for i := range x {
+			x[i] = k.Rand(src)
+		}
+		mean, variance := stat.MeanVariance(x, nil)
+		if math.Abs(mean) > 0.02 || math.Abs(variance-1) > 0.02 {
+			t.Errorf("%s kernel sample moments mismatch: mean %v, variance %v", test.name, mean, variance)
+		}
+		if !math.IsInf(r, 1) && (floats.Min(x) < -r || floats.Max(x) > r) {
+			t.Errorf("%s kernel sample outside support", test.name)
+		}
+		for _, u := range []float64{-1, 0.5} {
+			var n int
+			for _, v := range x {
+				if v <= u {
+					n++
+				}
+			}
+			if got := float64(n) / float64(len(x)); math.Abs(got-k.CDF(u)) > 0.01 {
+				t.Errorf("%s kernel empirical CDF mismatch at %v: want %v, got %v", test.name, u, k.CDF(u), got)
+			}
+		}
+	}
+}
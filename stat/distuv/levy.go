@@ -0,0 +1,202 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mathext"
+)
+
+// Levy implements the Lévy distribution with location Mu and scale C. The
+// support of the distribution is (Mu, +∞). The mean and variance of the
+// Lévy distribution are infinite, and its skewness and excess kurtosis
+// are not defined.
+// More information at https://en.wikipedia.org/wiki/L%C3%A9vy_distribution.
+type Levy struct {
+	Mu float64
+	// C is the scale parameter. Valid range is (0,+∞).
+	C float64
+
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (l Levy) CDF(x float64) float64 {
+	if x <= l.Mu {
+		return 0
+	}
+	return math.Erfc(math.Sqrt(l.C / (2 * (x - l.Mu))))
+}
+
+// Entropy returns the entropy of the distribution.
+func (l Levy) Entropy() float64 {
+	return (1 + 3*eulerGamma + math.Log(16*math.Pi*l.C*l.C)) / 2
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution, which is
+// undefined for the Lévy distribution. ExKurtosis returns NaN.
+func (Levy) ExKurtosis() float64 {
+	return math.NaN()
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (l Levy) LogProb(x float64) float64 {
+	if x <= l.Mu {
+		return math.Inf(-1)
+	}
+	z := x - l.Mu
+	return 0.5*math.Log(l.C/(2*math.Pi)) - l.C/(2*z) - 1.5*math.Log(z)
+}
+
+// Mean returns the mean of the probability distribution, which is +Inf.
+func (Levy) Mean() float64 {
+	return math.Inf(1)
+}
+
+// Median returns the median of the probability distribution.
+func (l Levy) Median() float64 {
+	return l.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (l Levy) Mode() float64 {
+	return l.Mu + l.C/3
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Levy) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (l Levy) Prob(x float64) float64 {
+	return math.Exp(l.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (l Levy) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	// erfcinv(p) = -Φ⁻¹(p/2)/√2, so 2*erfcinv(p)² = Φ⁻¹(p/2)².
+	z := mathext.NormalQuantile(p / 2)
+	return l.Mu + l.C/(z*z)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (l Levy) Rand() float64 {
+	var rnd float64
+	if l.Source == nil {
+		rnd = rand.NormFloat64()
+	} else {
+		rnd = l.Source.NormFloat64()
+	}
+	return l.Mu + l.C/(rnd*rnd)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Mu, ∂LogProb / ∂C].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+//
+// Special cases:
+//  Score(x) = [NaN, NaN] for x <= Mu
+func (l Levy) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, l.NumParameters())
+	}
+	if len(deriv) != l.NumParameters() {
+		panic(badLength)
+	}
+	if x <= l.Mu {
+		deriv[0] = math.NaN()
+		deriv[1] = math.NaN()
+		return deriv
+	}
+	z := x - l.Mu
+	deriv[0] = 1.5/z - l.C/(2*z*z)
+	deriv[1] = 1/(2*l.C) - 1/(2*z)
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+// Special cases:
+//  ScoreInput(x) = 0 for x <= Mu
+func (l Levy) ScoreInput(x float64) float64 {
+	if x <= l.Mu {
+		return 0
+	}
+	z := x - l.Mu
+	return l.C/(2*z*z) - 1.5/z
+}
+
+// Skewness returns the skewness of the distribution, which is undefined for
+// the Lévy distribution. Skewness returns NaN.
+func (Levy) Skewness() float64 {
+	return math.NaN()
+}
+
+// StdDev returns the standard deviation of the probability distribution,
+// which is +Inf.
+func (Levy) StdDev() float64 {
+	return math.Inf(1)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (l Levy) Survival(x float64) float64 {
+	if x <= l.Mu {
+		return 1
+	}
+	return math.Erf(math.Sqrt(l.C / (2 * (x - l.Mu))))
+}
+
+// Variance returns the variance of the probability distribution, which is
+// +Inf.
+func (Levy) Variance() float64 {
+	return math.Inf(1)
+}
+
+// setParameters modifies the parameters of the distribution.
+func (l *Levy) setParameters(p []Parameter) {
+	if len(p) != l.NumParameters() {
+		panic("levy: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Mu" {
+		panic("levy: " + panicNameMismatch)
+	}
+	if p[1].Name != "C" {
+		panic("levy: " + panicNameMismatch)
+	}
+	l.Mu = p[0].Value
+	l.C = p[1].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (l Levy) parameters(p []Parameter) []Parameter {
+	nParam := l.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("levy: improper parameter length")
+	}
+	p[0].Name = "Mu"
+	p[0].Value = l.Mu
+	p[1].Name = "C"
+	p[1].Value = l.C
+	return p
+}
@@ -0,0 +1,62 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLevyProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     3,
+			prob:    0.07779977737854325,
+			cumProb: 0.41421617824252516,
+			logProb: -2.553616709260198,
+		},
+	}
+	testDistributionProbs(t, Levy{Mu: 0, C: 2}, "Levy", pts)
+}
+
+func TestLevy(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Levy{
+		{Mu: 0, C: 1, Source: src},
+		{Mu: -2, C: 0.3, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestLevyQuantile(t *testing.T) {
+	l := Levy{Mu: 0, C: 2}
+	if got := l.Quantile(0.41421617824252516); math.Abs(got-3) > 1e-12 {
+		t.Errorf("unexpected Quantile: got %v want 3", got)
+	}
+	if got := l.Quantile(0); got != 0 {
+		t.Errorf("unexpected Quantile(0): got %v want 0", got)
+	}
+	if got := l.Quantile(1); !math.IsInf(got, 1) {
+		t.Errorf("unexpected Quantile(1): got %v want +Inf", got)
+	}
+}
+
+func TestLevyScore(t *testing.T) {
+	for _, test := range []*Levy{
+		{Mu: 0, C: 1},
+		{Mu: 1, C: 3},
+	} {
+		testDerivParam(t, test)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import "math"
+
+// LocationScale is the distribution of Loc + Scale*X where X is distributed
+// according to Dist.
+type LocationScale struct {
+	Dist Univariate
+	Loc  float64
+	// Scale is the scale parameter. Valid range is (0,+∞).
+	Scale float64
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (l LocationScale) CDF(x float64) float64 {
+	return l.Dist.CDF((x - l.Loc) / l.Scale)
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (l LocationScale) LogProb(x float64) float64 {
+	return l.Dist.LogProb((x-l.Loc)/l.Scale) - math.Log(l.Scale)
+}
+
+// Prob computes the value of the probability density function at x.
+func (l LocationScale) Prob(x float64) float64 {
+	return math.Exp(l.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (l LocationScale) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	return l.Loc + l.Scale*l.Dist.Quantile(p)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (l LocationScale) Rand() float64 {
+	return l.Loc + l.Scale*l.Dist.Rand()
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+// If Dist does not implement a Survival method, 1-CDF is returned.
+func (l LocationScale) Survival(x float64) float64 {
+	z := (x - l.Loc) / l.Scale
+	if s, ok := l.Dist.(survivaler); ok {
+		return s.Survival(z)
+	}
+	return 1 - l.Dist.CDF(z)
+}
@@ -0,0 +1,50 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestLocationScale(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, test := range []struct {
+		dist LocationScale
+		want cumulantProber
+	}{
+		{
+			dist: LocationScale{Dist: Normal{Mu: 0, Sigma: 1, Source: src}, Loc: 2, Scale: 3},
+			want: Normal{Mu: 2, Sigma: 3},
+		},
+		{
+			dist: LocationScale{Dist: Exponential{Rate: 1, Source: src}, Loc: 0, Scale: 0.5},
+			want: Exponential{Rate: 2},
+		},
+		{
+			dist: LocationScale{Dist: Gumbel{Mu: 0, Beta: 1, Source: src}, Loc: -1, Scale: 2.5},
+			want: Gumbel{Mu: -1, Beta: 2.5},
+		},
+	} {
+		for _, p := range []float64{0.01, 0.2, 0.5, 0.7, 0.99} {
+			x := test.want.Quantile(p)
+			if got := test.dist.Quantile(p); !floats.EqualWithinAbsOrRel(got, x, 1e-12, 1e-12) {
+				t.Errorf("Quantile mismatch case %v: want %v, got %v", i, x, got)
+			}
+			if got, want := test.dist.LogProb(x), test.want.LogProb(x); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+				t.Errorf("LogProb mismatch case %v: want %v, got %v", i, want, got)
+			}
+			if got, want := test.dist.CDF(x), test.want.CDF(x); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+				t.Errorf("CDF mismatch case %v: want %v, got %v", i, want, got)
+			}
+			if got, want := test.dist.Survival(x), test.want.Survival(x); !floats.EqualWithinAbsOrRel(got, want, 1e-12, 1e-12) {
+				t.Errorf("Survival mismatch case %v: want %v, got %v", i, want, got)
+			}
+		}
+		testUnivariateDist(t, i, test.dist, 1e-8)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLogisticProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     2,
+			prob:    0.5,
+			cumProb: 0.5,
+			logProb: -math.Ln2,
+		},
+		{
+			loc:     3,
+			prob:    0.209987170807013,
+			cumProb: 0.8807970779778823,
+			logProb: -1.5607088415259995,
+		},
+	}
+	testDistributionProbs(t, Logistic{Mu: 2, Scale: 0.5}, "Logistic", pts)
+}
+
+func TestLogistic(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Logistic{
+		{Mu: 0, Scale: 1, Source: src},
+		{Mu: 2, Scale: 0.5, Source: src},
+		{Mu: -10, Scale: 4, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestLogisticScore(t *testing.T) {
+	for _, test := range []*Logistic{
+		{Mu: 0, Scale: 1},
+		{Mu: 2, Scale: 0.5},
+	} {
+		testDerivParam(t, test)
+	}
+}
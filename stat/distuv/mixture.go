@@ -0,0 +1,196 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Mixture is a finite mixture of univariate distributions. The density of
+// the mixture is the weighted sum of the densities of its components.
+type Mixture struct {
+	components []Univariate
+	weights    []float64
+	logWeights []float64
+	cumWeights []float64
+
+	src *rand.Rand
+}
+
+// NewMixture returns a mixture of the given component distributions with
+// relative weights specified by weights. If weights is nil, the components
+// are equally weighted. The weights are normalized to sum to one.
+//
+// NewMixture panics if there are no components, if len(weights) does not
+// equal len(components) for non-nil weights, or if the weights are negative
+// or sum to zero. The components are sampled from using their own sources
+// of randomness, and src is used to select the component.
+func NewMixture(components []Univariate, weights []float64, src *rand.Rand) *Mixture {
+	if len(components) == 0 {
+		panic("mixture: no components")
+	}
+	if weights != nil && len(weights) != len(components) {
+		panic(badLength)
+	}
+	m := &Mixture{
+		components: make([]Univariate, len(components)),
+		weights:    make([]float64, len(components)),
+		logWeights: make([]float64, len(components)),
+		cumWeights: make([]float64, len(components)),
+		src:        src,
+	}
+	copy(m.components, components)
+	var sum float64
+	for i := range components {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		if w < 0 || math.IsNaN(w) {
+			panic("mixture: bad weight")
+		}
+		m.weights[i] = w
+		sum += w
+	}
+	if !(sum > 0) || math.IsInf(sum, 1) {
+		panic("mixture: bad weight")
+	}
+	var cum float64
+	for i, w := range m.weights {
+		w /= sum
+		m.weights[i] = w
+		m.logWeights[i] = math.Log(w)
+		cum += w
+		m.cumWeights[i] = cum
+	}
+	return m
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (m *Mixture) CDF(x float64) float64 {
+	var p float64
+	for i, c := range m.components {
+		if m.weights[i] == 0 {
+			continue
+		}
+		p += m.weights[i] * c.CDF(x)
+	}
+	return math.Min(1, p)
+}
+
+// Component returns the ith component of the mixture and its normalized
+// weight.
+func (m *Mixture) Component(i int) (Univariate, float64) {
+	return m.components[i], m.weights[i]
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (m *Mixture) LogProb(x float64) float64 {
+	// Compute the log of the sum of the weighted densities stably by
+	// factoring out the largest term.
+	max := math.Inf(-1)
+	for i, c := range m.components {
+		if m.weights[i] == 0 {
+			continue
+		}
+		max = math.Max(max, m.logWeights[i]+c.LogProb(x))
+	}
+	if math.IsInf(max, 0) {
+		return max
+	}
+	var sum float64
+	for i, c := range m.components {
+		if m.weights[i] == 0 {
+			continue
+		}
+		sum += math.Exp(m.logWeights[i] + c.LogProb(x) - max)
+	}
+	return max + math.Log(sum)
+}
+
+// Len returns the number of components in the mixture.
+func (m *Mixture) Len() int {
+	return len(m.components)
+}
+
+// Prob computes the value of the probability density function at x.
+func (m *Mixture) Prob(x float64) float64 {
+	return math.Exp(m.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+// The quantile is found by bisection of the CDF between the smallest and
+// largest quantiles of the components at p.
+func (m *Mixture) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	lo := math.Inf(1)
+	hi := math.Inf(-1)
+	for i, c := range m.components {
+		if m.weights[i] == 0 {
+			continue
+		}
+		q := c.Quantile(p)
+		lo = math.Min(lo, q)
+		hi = math.Max(hi, q)
+	}
+	switch {
+	case p == 0:
+		return lo
+	case p == 1:
+		return hi
+	}
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if m.CDF(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (m *Mixture) Rand() float64 {
+	var rnd float64
+	if m.src == nil {
+		rnd = rand.Float64()
+	} else {
+		rnd = m.src.Float64()
+	}
+	i := sort.Search(len(m.cumWeights), func(i int) bool { return m.cumWeights[i] > rnd })
+	if i == len(m.cumWeights) {
+		// Guard against the final cumulative weight rounding below one.
+		i = len(m.cumWeights) - 1
+		for m.weights[i] == 0 {
+			i--
+		}
+	}
+	return m.components[i].Rand()
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (m *Mixture) Survival(x float64) float64 {
+	var p float64
+	for i, c := range m.components {
+		if m.weights[i] == 0 {
+			continue
+		}
+		if s, ok := c.(survivaler); ok {
+			p += m.weights[i] * s.Survival(x)
+		} else {
+			p += m.weights[i] * (1 - c.CDF(x))
+		}
+	}
+	return math.Min(1, p)
+}
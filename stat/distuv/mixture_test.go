@@ -0,0 +1,100 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestMixture(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, test := range []struct {
+		components []Univariate
+		weights    []float64
+	}{
+		{
+			components: []Univariate{
+				Normal{Mu: -2, Sigma: 1, Source: src},
+				Normal{Mu: 3, Sigma: 0.5, Source: src},
+			},
+			weights: []float64{1, 3},
+		},
+		{
+			components: []Univariate{
+				Normal{Mu: 0, Sigma: 1, Source: src},
+				Normal{Mu: 0, Sigma: 5, Source: src},
+				Normal{Mu: 1, Sigma: 0.1, Source: src},
+			},
+		},
+		{
+			components: []Univariate{
+				Exponential{Rate: 2, Source: src},
+				NewTruncated(Normal{Mu: 0, Sigma: 1}, 1, 2, src),
+				Gamma{Alpha: 3, Beta: 1, Source: src},
+			},
+			weights: []float64{0.2, 0, 0.8},
+		},
+	} {
+		m := NewMixture(test.components, test.weights, src)
+		if m.Len() != len(test.components) {
+			t.Errorf("Len mismatch case %v: want %v, got %v", i, len(test.components), m.Len())
+		}
+		weights := make([]float64, len(test.components))
+		for j := range weights {
+			weights[j] = 1
+			if test.weights != nil {
+				weights[j] = test.weights[j]
+			}
+		}
+		floats.Scale(1/floats.Sum(weights), weights)
+
+		for _, x := range []float64{-3, -0.5, 0.5, 1.5, 3, 4} {
+			var prob, cdf float64
+			for j, c := range test.components {
+				prob += weights[j] * math.Exp(c.LogProb(x))
+				cdf += weights[j] * c.CDF(x)
+			}
+			if got := m.Prob(x); !floats.EqualWithinAbsOrRel(got, prob, 1e-14, 1e-14) {
+				t.Errorf("Prob mismatch case %v at %v: want %v, got %v", i, x, prob, got)
+			}
+			if got := m.CDF(x); !floats.EqualWithinAbsOrRel(got, cdf, 1e-14, 1e-14) {
+				t.Errorf("CDF mismatch case %v at %v: want %v, got %v", i, x, cdf, got)
+			}
+		}
+		for _, p := range []float64{0.001, 0.2, 0.5, 0.8, 0.999} {
+			if got := m.CDF(m.Quantile(p)); !floats.EqualWithinAbsOrRel(got, p, 1e-12, 1e-12) {
+				t.Errorf("Quantile/CDF mismatch case %v: want %v, got %v", i, p, got)
+			}
+		}
+		for j := range test.components {
+			if _, w := m.Component(j); w != weights[j] {
+				t.Errorf("Weight mismatch case %v component %v: want %v, got %v", i, j, weights[j], w)
+			}
+		}
+		testUnivariateDist(t, i, m, 1e-8)
+	}
+}
+
+func TestMixturePanics(t *testing.T) {
+	n := Normal{Mu: 0, Sigma: 1}
+	for _, test := range []struct {
+		name       string
+		components []Univariate
+		weights    []float64
+	}{
+		{"no components", nil, nil},
+		{"length mismatch", []Univariate{n, n}, []float64{1}},
+		{"negative weight", []Univariate{n, n}, []float64{1, -1}},
+		{"zero weights", []Univariate{n, n}, []float64{0, 0}},
+	} {
+		if !panics(func() { NewMixture(test.components, test.weights, nil) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
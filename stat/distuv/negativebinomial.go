@@ -0,0 +1,244 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/stat"
+)
+
+// NegativeBinomial represents the negative binomial distribution, the
+// distribution of the number of failures before the R-th success in a
+// sequence of independent trials that each succeed with probability P.
+// R must be greater than 0 and need not be an integer. P must be greater
+// than 0 and at most 1.
+// More information at https://en.wikipedia.org/wiki/Negative_binomial_distribution.
+type NegativeBinomial struct {
+	R      float64
+	P      float64
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative distribution function at x.
+func (nb NegativeBinomial) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return mathext.RegIncBeta(nb.R, math.Floor(x)+1, nb.P)
+}
+
+// ConjugateUpdate updates the parameters of the distribution from the sufficient
+// statistics of a set of samples. The sufficient statistics, suffStat, have been
+// observed with nSamples observations. The prior values of the distribution are those
+// currently in the distribution, and have been observed with priorStrength samples.
+//
+// The number of successes, R, is taken to be known and is not modified. For the
+// negative binomial distribution, the sufficient statistic is the success
+// probability implied by the mean of the samples, R/(R+mean), and the conjugate
+// prior is a Beta distribution on P.
+// The prior is having seen priorStrength[0] samples with success probability
+// NegativeBinomial.P. As a result of this function, NegativeBinomial.P is
+// updated based on the weighted samples, and priorStrength is modified to
+// include the new number of samples observed.
+//
+// This function panics if len(suffStat) != 1 or len(priorStrength) != 1.
+func (nb *NegativeBinomial) ConjugateUpdate(suffStat []float64, nSamples float64, priorStrength []float64) {
+	if len(suffStat) != 1 {
+		panic("negativebinomial: incorrect suffStat length")
+	}
+	if len(priorStrength) != 1 {
+		panic("negativebinomial: incorrect priorStrength length")
+	}
+
+	totalSamples := nSamples + priorStrength[0]
+
+	// Combine the sample and prior means of the number of failures.
+	totalSum := nSamples * nb.R * (1/suffStat[0] - 1)
+	if !(priorStrength[0] == 0) {
+		totalSum += priorStrength[0] * nb.R * (1/nb.P - 1)
+	}
+	nb.P = nb.R / (nb.R + totalSum/totalSamples)
+	priorStrength[0] = totalSamples
+}
+
+// Entropy returns the entropy of the distribution.
+func (nb NegativeBinomial) Entropy() float64 {
+	return discreteEntropy(nb.LogProb, 0, math.Inf(1), nb.Mode())
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (nb NegativeBinomial) ExKurtosis() float64 {
+	return 6/nb.R + nb.P*nb.P/((1-nb.P)*nb.R)
+}
+
+// Fit sets the parameter P of the probability distribution from the
+// data samples x with relative weights w. The number of successes, R, is
+// not modified.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (nb *NegativeBinomial) Fit(samples, weights []float64) {
+	suffStat := make([]float64, nb.NumSuffStat())
+	nSamples := nb.SuffStat(suffStat, samples, weights)
+	nb.ConjugateUpdate(suffStat, nSamples, make([]float64, nb.NumSuffStat()))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// mass function at x.
+func (nb NegativeBinomial) LogProb(x float64) float64 {
+	if x < 0 || math.Floor(x) != x {
+		return math.Inf(-1)
+	}
+	if nb.P == 1 {
+		if x == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	lg1, _ := math.Lgamma(x + nb.R)
+	lg2, _ := math.Lgamma(x + 1)
+	lg3, _ := math.Lgamma(nb.R)
+	return lg1 - lg2 - lg3 + nb.R*math.Log(nb.P) + x*math.Log1p(-nb.P)
+}
+
+// Mean returns the mean of the probability distribution.
+func (nb NegativeBinomial) Mean() float64 {
+	return nb.R * (1 - nb.P) / nb.P
+}
+
+// Median returns the median of the probability distribution.
+func (nb NegativeBinomial) Median() float64 {
+	return nb.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (nb NegativeBinomial) Mode() float64 {
+	if nb.R <= 1 {
+		return 0
+	}
+	return math.Floor((nb.R - 1) * (1 - nb.P) / nb.P)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (NegativeBinomial) NumParameters() int {
+	return 2
+}
+
+// NumSuffStat returns the number of sufficient statistics for the distribution.
+func (NegativeBinomial) NumSuffStat() int {
+	return 1
+}
+
+// Prob computes the value of the probability mass function at x.
+func (nb NegativeBinomial) Prob(x float64) float64 {
+	return math.Exp(nb.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative distribution function, that
+// is the smallest integer k such that CDF(k) >= p.
+func (nb NegativeBinomial) Quantile(p float64) float64 {
+	if nb.P == 1 {
+		// The distribution is concentrated at zero.
+		return discreteQuantile(nb.CDF, p, 0, 0, 0)
+	}
+	return discreteQuantile(nb.CDF, p, 0, math.Inf(1), nb.Mean())
+}
+
+// Rand returns a random sample drawn from the distribution.
+//
+// The sample is generated as a Poisson variate whose rate is drawn from a
+// Gamma distribution with shape R and rate P/(1-P).
+func (nb NegativeBinomial) Rand() float64 {
+	if nb.P == 1 {
+		return 0
+	}
+	lambda := Gamma{Alpha: nb.R, Beta: nb.P / (1 - nb.P), Source: nb.Source}.Rand()
+	return Poisson{Lambda: lambda, Source: nb.Source}.Rand()
+}
+
+// Skewness returns the skewness of the distribution.
+func (nb NegativeBinomial) Skewness() float64 {
+	return (2 - nb.P) / math.Sqrt((1-nb.P)*nb.R)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (nb NegativeBinomial) StdDev() float64 {
+	return math.Sqrt(nb.Variance())
+}
+
+// SuffStat computes the sufficient statistics of set of samples to update
+// the distribution. The sufficient statistics are stored in place, and the
+// effective number of samples are returned.
+//
+// The negative binomial distribution with known R has one sufficient
+// statistic, the success probability R/(R+mean) implied by the mean of
+// the samples.
+//
+// If weights is nil, the weights are assumed to be 1, otherwise panics if
+// len(samples) != len(weights). Panics if len(suffStat) != NumSuffStat().
+func (nb NegativeBinomial) SuffStat(suffStat, samples, weights []float64) (nSamples float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+
+	if len(suffStat) != nb.NumSuffStat() {
+		panic(badSuffStat)
+	}
+
+	if len(weights) == 0 {
+		nSamples = float64(len(samples))
+	} else {
+		nSamples = floats.Sum(weights)
+	}
+
+	suffStat[0] = nb.R / (nb.R + stat.Mean(samples, weights))
+	return nSamples
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (nb NegativeBinomial) Survival(x float64) float64 {
+	if x < 0 {
+		return 1
+	}
+	return mathext.RegIncBeta(math.Floor(x)+1, nb.R, 1-nb.P)
+}
+
+// Variance returns the variance of the probability distribution.
+func (nb NegativeBinomial) Variance() float64 {
+	return nb.R * (1 - nb.P) / (nb.P * nb.P)
+}
+
+// setParameters modifies the parameters of the distribution.
+func (nb *NegativeBinomial) setParameters(p []Parameter) {
+	if len(p) != nb.NumParameters() {
+		panic("negativebinomial: incorrect number of parameters to set")
+	}
+	if p[0].Name != "R" {
+		panic("negativebinomial: " + panicNameMismatch)
+	}
+	if p[1].Name != "P" {
+		panic("negativebinomial: " + panicNameMismatch)
+	}
+	nb.R = p[0].Value
+	nb.P = p[1].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (nb NegativeBinomial) parameters(p []Parameter) []Parameter {
+	nParam := nb.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("negativebinomial: improper parameter length")
+	}
+	p[0].Name = "R"
+	p[0].Value = nb.R
+	p[1].Name = "P"
+	p[1].Value = nb.P
+	return p
+}
@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNegativeBinomialProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     0,
+			prob:    0.10119288512538817,
+			cumProb: 0.10119288512538817,
+			logProb: math.Log(0.10119288512538817),
+		},
+		{
+			loc:     3,
+			prob:    0.14344091466523762,
+			cumProb: 0.5558019215511942,
+			logProb: -1.941832073065618,
+		},
+	}
+	testDistributionProbs(t, NegativeBinomial{R: 2.5, P: 0.4}, "NegativeBinomial", pts)
+}
+
+func TestNegativeBinomial(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []NegativeBinomial{
+		{R: 1, P: 0.5, Source: src},
+		{R: 2.5, P: 0.4, Source: src},
+		{R: 10, P: 0.7, Source: src},
+		{R: 0.5, P: 0.2, Source: src},
+		{R: 40, P: 0.1, Source: src},
+	} {
+		testDiscreteRand(t, dist, i)
+		testDiscreteDist(t, i, dist, 0, math.Inf(1))
+	}
+}
+
+func TestNegativeBinomialConjugateUpdate(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	samps := randn(NegativeBinomial{R: 3, P: 0.4, Source: src}, 20)
+
+	// Updating incrementally must match updating all at once.
+	inc := NegativeBinomial{R: 3, P: 0.6}
+	prior := []float64{2}
+	stats := make([]float64, inc.NumSuffStat())
+	for _, x := range samps {
+		n := inc.SuffStat(stats, []float64{x}, nil)
+		inc.ConjugateUpdate(stats, n, prior)
+	}
+	all := NegativeBinomial{R: 3, P: 0.6}
+	n := all.SuffStat(stats, samps, nil)
+	all.ConjugateUpdate(stats, n, []float64{2})
+	if math.Abs(inc.P-all.P) > 1e-14 {
+		t.Errorf("incremental update mismatch: want %v, got %v", all.P, inc.P)
+	}
+	if prior[0] != 22 {
+		t.Errorf("unexpected prior strength: want 22, got %v", prior[0])
+	}
+	if inc.R != 3 {
+		t.Errorf("R modified by update: got %v", inc.R)
+	}
+}
+
+func TestNegativeBinomialFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := NegativeBinomial{R: 4, P: 0.3, Source: src}
+	got := NegativeBinomial{R: 4}
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.P-want.P) > 0.005 {
+		t.Errorf("Fit mismatch: want P = %v, got %v", want.P, got.P)
+	}
+}
@@ -28,7 +28,7 @@ type Normal struct {
 
 // CDF computes the value of the cumulative density function at x.
 func (n Normal) CDF(x float64) float64 {
-	return 0.5 * (1 + math.Erf((x-n.Mu)/(n.Sigma*math.Sqrt2)))
+	return 0.5 * math.Erfc(-(x-n.Mu)/(n.Sigma*math.Sqrt2))
 }
 
 // ConjugateUpdate updates the parameters of the distribution from the sufficient
@@ -215,7 +215,7 @@ func (Normal) SuffStat(suffStat, samples, weights []float64) (nSamples float64)
 
 // Survival returns the survival function (complementary CDF) at x.
 func (n Normal) Survival(x float64) float64 {
-	return 0.5 * (1 - math.Erf((x-n.Mu)/(n.Sigma*math.Sqrt2)))
+	return 0.5 * math.Erfc((x-n.Mu)/(n.Sigma*math.Sqrt2))
 }
 
 // setParameters modifies the parameters of the distribution.
@@ -147,6 +147,30 @@ func TestNormalQuantile(t *testing.T) {
 	}
 }
 
+func TestNormalTail(t *testing.T) {
+	// Computing the tail probabilities as 1-erf loses all precision
+	// beyond a few standard deviations from the mean.
+	for _, test := range []struct {
+		z, want float64
+	}{
+		{z: 5, want: 2.866515718791939e-07},
+		{z: 10, want: 7.619853024160526e-24},
+		{z: 20, want: 2.7536241186062337e-89},
+		{z: 30, want: 4.906713927148187e-198},
+	} {
+		for _, n := range []Normal{{Mu: 0, Sigma: 1}, {Mu: -3, Sigma: 2}} {
+			x := n.Mu + n.Sigma*test.z
+			if got := n.Survival(x); !floats.EqualWithinRel(got, test.want, 1e-12) {
+				t.Errorf("Survival mismatch for %+v at %v: want %v, got %v", n, x, test.want, got)
+			}
+			x = n.Mu - n.Sigma*test.z
+			if got := n.CDF(x); !floats.EqualWithinRel(got, test.want, 1e-12) {
+				t.Errorf("CDF mismatch for %+v at %v: want %v, got %v", n, x, test.want, got)
+			}
+		}
+	}
+}
+
 func TestNormFitPanic(t *testing.T) {
 	n := Normal{Mu: 0, Sigma: 1}
 	defer func() {
@@ -0,0 +1,237 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// Pareto implements the Pareto (Type I) probability distribution with scale
+// Xm and shape Alpha. The support of the distribution is [Xm, +∞).
+// More information at https://en.wikipedia.org/wiki/Pareto_distribution.
+type Pareto struct {
+	// Xm is the scale parameter, the minimum value of the distribution.
+	// Valid range is (0,+∞).
+	Xm float64
+	// Alpha is the shape parameter. Valid range is (0,+∞).
+	Alpha float64
+
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (p Pareto) CDF(x float64) float64 {
+	if x < p.Xm {
+		return 0
+	}
+	return -math.Expm1(p.Alpha * math.Log(p.Xm/x))
+}
+
+// Entropy returns the entropy of the distribution.
+func (p Pareto) Entropy() float64 {
+	return math.Log(p.Xm/p.Alpha) + 1/p.Alpha + 1
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution. The excess
+// kurtosis is defined only for Alpha > 4, and NaN is returned otherwise.
+func (p Pareto) ExKurtosis() float64 {
+	a := p.Alpha
+	if a <= 4 {
+		return math.NaN()
+	}
+	return 6 * (a*a*a + a*a - 6*a - 2) / (a * (a - 3) * (a - 4))
+}
+
+// Fit sets the parameters of the probability distribution to their maximum
+// likelihood estimates from the data samples x with relative weights w.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (p *Pareto) Fit(samples, weights []float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+	if len(samples) == 0 {
+		panic(badNoSamples)
+	}
+	xm := floats.Min(samples)
+	var sumW, sumLog float64
+	for i, x := range samples {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		sumW += w
+		sumLog += w * math.Log(x/xm)
+	}
+	p.Xm = xm
+	p.Alpha = sumW / sumLog
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (p Pareto) LogProb(x float64) float64 {
+	if x < p.Xm {
+		return math.Inf(-1)
+	}
+	return math.Log(p.Alpha) + p.Alpha*math.Log(p.Xm) - (p.Alpha+1)*math.Log(x)
+}
+
+// Mean returns the mean of the probability distribution. The mean is +Inf
+// for Alpha <= 1.
+func (p Pareto) Mean() float64 {
+	if p.Alpha <= 1 {
+		return math.Inf(1)
+	}
+	return p.Alpha * p.Xm / (p.Alpha - 1)
+}
+
+// Median returns the median of the probability distribution.
+func (p Pareto) Median() float64 {
+	return p.Xm * math.Pow(2, 1/p.Alpha)
+}
+
+// Mode returns the mode of the probability distribution.
+func (p Pareto) Mode() float64 {
+	return p.Xm
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Pareto) NumParameters() int {
+	return 2
+}
+
+// Prob computes the value of the probability density function at x.
+func (p Pareto) Prob(x float64) float64 {
+	return math.Exp(p.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (p Pareto) Quantile(prob float64) float64 {
+	if prob < 0 || prob > 1 {
+		panic(badPercentile)
+	}
+	return p.Xm * math.Exp(-math.Log1p(-prob)/p.Alpha)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (p Pareto) Rand() float64 {
+	var rnd float64
+	if p.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = p.Source.ExpFloat64()
+	}
+	return p.Xm * math.Exp(rnd/p.Alpha)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Xm, ∂LogProb / ∂Alpha].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+//
+// Special cases:
+//  Score(x) = [NaN, NaN] for x < Xm
+func (p Pareto) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, p.NumParameters())
+	}
+	if len(deriv) != p.NumParameters() {
+		panic(badLength)
+	}
+	if x < p.Xm {
+		deriv[0] = math.NaN()
+		deriv[1] = math.NaN()
+		return deriv
+	}
+	deriv[0] = p.Alpha / p.Xm
+	deriv[1] = 1/p.Alpha + math.Log(p.Xm/x)
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+// Special cases:
+//  ScoreInput(x) = 0 for x < Xm
+func (p Pareto) ScoreInput(x float64) float64 {
+	if x < p.Xm {
+		return 0
+	}
+	return -(p.Alpha + 1) / x
+}
+
+// Skewness returns the skewness of the distribution. The skewness is defined
+// only for Alpha > 3, and NaN is returned otherwise.
+func (p Pareto) Skewness() float64 {
+	a := p.Alpha
+	if a <= 3 {
+		return math.NaN()
+	}
+	return 2 * (1 + a) / (a - 3) * math.Sqrt((a-2)/a)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (p Pareto) StdDev() float64 {
+	return math.Sqrt(p.Variance())
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (p Pareto) Survival(x float64) float64 {
+	if x < p.Xm {
+		return 1
+	}
+	return math.Pow(p.Xm/x, p.Alpha)
+}
+
+// Variance returns the variance of the probability distribution. The variance
+// is +Inf for Alpha <= 2.
+func (p Pareto) Variance() float64 {
+	a := p.Alpha
+	if a <= 2 {
+		return math.Inf(1)
+	}
+	return p.Xm * p.Xm * a / ((a - 1) * (a - 1) * (a - 2))
+}
+
+// setParameters modifies the parameters of the distribution.
+func (p *Pareto) setParameters(param []Parameter) {
+	if len(param) != p.NumParameters() {
+		panic("pareto: incorrect number of parameters to set")
+	}
+	if param[0].Name != "Xm" {
+		panic("pareto: " + panicNameMismatch)
+	}
+	if param[1].Name != "Alpha" {
+		panic("pareto: " + panicNameMismatch)
+	}
+	p.Xm = param[0].Value
+	p.Alpha = param[1].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (p Pareto) parameters(param []Parameter) []Parameter {
+	nParam := p.NumParameters()
+	if param == nil {
+		param = make([]Parameter, nParam)
+	} else if len(param) != nParam {
+		panic("pareto: improper parameter length")
+	}
+	param[0].Name = "Xm"
+	param[0].Value = p.Xm
+	param[1].Name = "Alpha"
+	param[1].Value = p.Alpha
+	return param
+}
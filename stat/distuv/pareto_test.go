@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestParetoProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     0.5,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     1,
+			prob:    3,
+			cumProb: 0,
+			logProb: math.Log(3),
+		},
+		{
+			loc:     2,
+			prob:    0.1875,
+			cumProb: 0.875,
+			logProb: math.Log(0.1875),
+		},
+	}
+	testDistributionProbs(t, Pareto{Xm: 1, Alpha: 3}, "Pareto", pts)
+}
+
+func TestPareto(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Pareto{
+		{Xm: 1, Alpha: 12, Source: src},
+		{Xm: 0.3, Alpha: 20, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestParetoScore(t *testing.T) {
+	for _, test := range []*Pareto{
+		{Xm: 1, Alpha: 3},
+		{Xm: 2.5, Alpha: 0.5},
+	} {
+		testDerivParam(t, test)
+	}
+}
+
+func TestParetoFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Pareto{Xm: 2, Alpha: 2.5, Source: src}
+	var got Pareto
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.Xm-want.Xm) > 1e-3 || math.Abs(got.Alpha-want.Alpha) > 0.05 {
+		t.Errorf("Fit mismatch: want %v, got %v", want.parameters(nil), got.parameters(nil))
+	}
+}
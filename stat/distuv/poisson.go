@@ -0,0 +1,261 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Poisson represents the Poisson distribution, the distribution of the number
+// of events occurring in a fixed interval when events occur independently at
+// a constant average rate Lambda. The value of Lambda must be non-negative.
+// When Lambda is 0 the distribution is a point mass at zero.
+// More information at https://en.wikipedia.org/wiki/Poisson_distribution.
+type Poisson struct {
+	Lambda float64
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative distribution function at x.
+func (p Poisson) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return mathext.GammaIncComp(math.Floor(x)+1, p.Lambda)
+}
+
+// ConjugateUpdate updates the parameters of the distribution from the sufficient
+// statistics of a set of samples. The sufficient statistics, suffStat, have been
+// observed with nSamples observations. The prior values of the distribution are those
+// currently in the distribution, and have been observed with priorStrength samples.
+//
+// For the Poisson distribution, the sufficient statistic is the mean of the
+// samples, and the conjugate prior is a Gamma distribution on Lambda.
+// The prior is having seen priorStrength[0] samples with mean Poisson.Lambda.
+// As a result of this function, Poisson.Lambda is updated based on the weighted
+// samples, and priorStrength is modified to include the new number of samples observed.
+//
+// This function panics if len(suffStat) != 1 or len(priorStrength) != 1.
+func (p *Poisson) ConjugateUpdate(suffStat []float64, nSamples float64, priorStrength []float64) {
+	if len(suffStat) != 1 {
+		panic("poisson: incorrect suffStat length")
+	}
+	if len(priorStrength) != 1 {
+		panic("poisson: incorrect priorStrength length")
+	}
+
+	totalSamples := nSamples + priorStrength[0]
+
+	totalSum := nSamples * suffStat[0]
+	if !(priorStrength[0] == 0) {
+		totalSum += priorStrength[0] * p.Lambda
+	}
+	p.Lambda = totalSum / totalSamples
+	priorStrength[0] = totalSamples
+}
+
+// Entropy returns the entropy of the distribution.
+func (p Poisson) Entropy() float64 {
+	return discreteEntropy(p.LogProb, 0, math.Inf(1), p.Mode())
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (p Poisson) ExKurtosis() float64 {
+	return 1 / p.Lambda
+}
+
+// Fit sets the parameters of the probability distribution from the
+// data samples x with relative weights w.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (p *Poisson) Fit(samples, weights []float64) {
+	suffStat := make([]float64, p.NumSuffStat())
+	nSamples := p.SuffStat(suffStat, samples, weights)
+	p.ConjugateUpdate(suffStat, nSamples, make([]float64, p.NumSuffStat()))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// mass function at x.
+func (p Poisson) LogProb(x float64) float64 {
+	if x < 0 || math.Floor(x) != x {
+		return math.Inf(-1)
+	}
+	if p.Lambda == 0 {
+		// Avoid evaluating 0 * log(0) at x == 0.
+		if x == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	lg, _ := math.Lgamma(x + 1)
+	return x*math.Log(p.Lambda) - p.Lambda - lg
+}
+
+// Mean returns the mean of the probability distribution.
+func (p Poisson) Mean() float64 {
+	return p.Lambda
+}
+
+// Median returns the median of the probability distribution.
+func (p Poisson) Median() float64 {
+	return p.Quantile(0.5)
+}
+
+// Mode returns the mode of the probability distribution.
+func (p Poisson) Mode() float64 {
+	return math.Floor(p.Lambda)
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Poisson) NumParameters() int {
+	return 1
+}
+
+// NumSuffStat returns the number of sufficient statistics for the distribution.
+func (Poisson) NumSuffStat() int {
+	return 1
+}
+
+// Prob computes the value of the probability mass function at x.
+func (p Poisson) Prob(x float64) float64 {
+	return math.Exp(p.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative distribution function, that
+// is the smallest integer k such that CDF(k) >= p.
+func (p Poisson) Quantile(prob float64) float64 {
+	if p.Lambda == 0 {
+		// The distribution is concentrated at zero.
+		return discreteQuantile(p.CDF, prob, 0, 0, 0)
+	}
+	return discreteQuantile(p.CDF, prob, 0, math.Inf(1), p.Lambda)
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (p Poisson) Rand() float64 {
+	unifrnd := rand.Float64
+	if p.Source != nil {
+		unifrnd = p.Source.Float64
+	}
+
+	if p.Lambda < 10 {
+		// Use Knuth's multiplication method, which needs Lambda+1
+		// uniform variates on average.
+		limit := math.Exp(-p.Lambda)
+		var k float64
+		prod := unifrnd()
+		for prod > limit {
+			k++
+			prod *= unifrnd()
+		}
+		return k
+	}
+
+	// Use the transformed rejection method with squeeze (PTRS) from
+	//  Hörmann, Wolfgang. "The transformed rejection method for generating
+	//  Poisson random variables." Insurance: Mathematics and Economics
+	//  12.1 (1993): 39-45.
+	logLambda := math.Log(p.Lambda)
+	b := 0.931 + 2.53*math.Sqrt(p.Lambda)
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := unifrnd() - 0.5
+		v := unifrnd()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + p.Lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v*invAlpha/(a/(us*us)+b)) <= -p.Lambda+k*logLambda-lg {
+			return k
+		}
+	}
+}
+
+// Skewness returns the skewness of the distribution.
+func (p Poisson) Skewness() float64 {
+	return 1 / math.Sqrt(p.Lambda)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (p Poisson) StdDev() float64 {
+	return math.Sqrt(p.Lambda)
+}
+
+// SuffStat computes the sufficient statistics of set of samples to update
+// the distribution. The sufficient statistics are stored in place, and the
+// effective number of samples are returned.
+//
+// The Poisson distribution has one sufficient statistic, the mean of the
+// samples.
+//
+// If weights is nil, the weights are assumed to be 1, otherwise panics if
+// len(samples) != len(weights). Panics if len(suffStat) != NumSuffStat().
+func (Poisson) SuffStat(suffStat, samples, weights []float64) (nSamples float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+
+	if len(suffStat) != (Poisson{}).NumSuffStat() {
+		panic(badSuffStat)
+	}
+
+	if len(weights) == 0 {
+		nSamples = float64(len(samples))
+	} else {
+		nSamples = floats.Sum(weights)
+	}
+
+	suffStat[0] = stat.Mean(samples, weights)
+	return nSamples
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (p Poisson) Survival(x float64) float64 {
+	if x < 0 {
+		return 1
+	}
+	return mathext.GammaInc(math.Floor(x)+1, p.Lambda)
+}
+
+// Variance returns the variance of the probability distribution.
+func (p Poisson) Variance() float64 {
+	return p.Lambda
+}
+
+// setParameters modifies the parameters of the distribution.
+func (p *Poisson) setParameters(param []Parameter) {
+	if len(param) != p.NumParameters() {
+		panic("poisson: incorrect number of parameters to set")
+	}
+	if param[0].Name != "Lambda" {
+		panic("poisson: " + panicNameMismatch)
+	}
+	p.Lambda = param[0].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (p Poisson) parameters(param []Parameter) []Parameter {
+	nParam := p.NumParameters()
+	if param == nil {
+		param = make([]Parameter, nParam)
+	} else if len(param) != nParam {
+		panic("poisson: improper parameter length")
+	}
+	param[0].Name = "Lambda"
+	param[0].Value = p.Lambda
+	return param
+}
@@ -0,0 +1,106 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPoissonProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     0,
+			prob:    math.Exp(-3),
+			cumProb: math.Exp(-3),
+			logProb: -3,
+		},
+		{
+			loc:     2,
+			prob:    0.22404180765538775,
+			cumProb: 0.42319008112684353,
+			logProb: -1.4959226032237258,
+		},
+		{
+			loc:     2.5,
+			prob:    0,
+			cumProb: 0.42319008112684353,
+			logProb: math.Inf(-1),
+		},
+	}
+	testDistributionProbs(t, Poisson{Lambda: 3}, "Poisson", pts)
+}
+
+func TestPoissonZeroLambda(t *testing.T) {
+	// With Lambda equal to zero, all the mass is at zero.
+	p := Poisson{Lambda: 0, Source: rand.New(rand.NewSource(1))}
+	for _, test := range []struct {
+		x, prob, logProb, cdf float64
+	}{
+		{x: -1, prob: 0, logProb: math.Inf(-1), cdf: 0},
+		{x: 0, prob: 1, logProb: 0, cdf: 1},
+		{x: 0.5, prob: 0, logProb: math.Inf(-1), cdf: 1},
+		{x: 1, prob: 0, logProb: math.Inf(-1), cdf: 1},
+		{x: 10, prob: 0, logProb: math.Inf(-1), cdf: 1},
+	} {
+		if got := p.Prob(test.x); got != test.prob {
+			t.Errorf("unexpected Prob at %v: got %v want %v", test.x, got, test.prob)
+		}
+		if got := p.LogProb(test.x); got != test.logProb {
+			t.Errorf("unexpected LogProb at %v: got %v want %v", test.x, got, test.logProb)
+		}
+		if got := p.CDF(test.x); got != test.cdf {
+			t.Errorf("unexpected CDF at %v: got %v want %v", test.x, got, test.cdf)
+		}
+	}
+	if got := p.Entropy(); got != 0 {
+		t.Errorf("unexpected Entropy: got %v want 0", got)
+	}
+	for _, prob := range []float64{0, 0.5, 1} {
+		if got := p.Quantile(prob); got != 0 {
+			t.Errorf("unexpected Quantile(%v): got %v want 0", prob, got)
+		}
+	}
+	for i := 0; i < 10; i++ {
+		if got := p.Rand(); got != 0 {
+			t.Errorf("unexpected Rand: got %v want 0", got)
+		}
+	}
+}
+
+func TestPoisson(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Poisson{
+		{Lambda: 0.5, Source: src},
+		{Lambda: 3, Source: src},
+		{Lambda: 9.9, Source: src},
+		{Lambda: 25, Source: src},
+		{Lambda: 200, Source: src},
+	} {
+		testDiscreteRand(t, dist, i)
+		testDiscreteDist(t, i, dist, 0, math.Inf(1))
+	}
+}
+
+func TestPoissonFitPrior(t *testing.T) {
+	testConjugateUpdate(t, func() ConjugateUpdater { return &Poisson{Lambda: 4.2, Source: rand.New(rand.NewSource(1))} })
+}
+
+func TestPoissonFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Poisson{Lambda: 7.5, Source: src}
+	var got Poisson
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.Lambda-want.Lambda) > 0.05 {
+		t.Errorf("Fit mismatch: want Lambda = %v, got %v", want.Lambda, got.Lambda)
+	}
+}
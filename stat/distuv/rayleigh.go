@@ -0,0 +1,264 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// Rayleigh implements the Rayleigh distribution with scale Sigma. The support
+// of the distribution is [0, +∞).
+// More information at https://en.wikipedia.org/wiki/Rayleigh_distribution.
+type Rayleigh struct {
+	// Sigma is the scale parameter. Valid range is (0,+∞).
+	Sigma float64
+
+	Source *rand.Rand
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (r Rayleigh) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1(-x * x / (2 * r.Sigma * r.Sigma))
+}
+
+// ConjugateUpdate updates the parameters of the distribution from the sufficient
+// statistics of a set of samples. The sufficient statistics, suffStat, have been
+// observed with nSamples observations. The prior values of the distribution are those
+// currently in the distribution, and have been observed with priorStrength samples.
+//
+// For the Rayleigh distribution, the sufficient statistic is the mean of the
+// squares of the samples, and the conjugate prior is an inverse gamma
+// distribution on Sigma^2.
+// The prior is having seen priorStrength[0] samples with mean square 2*Rayleigh.Sigma^2.
+// As a result of this function, Rayleigh.Sigma is updated based on the weighted
+// samples, and priorStrength is modified to include the new number of samples observed.
+//
+// This function panics if len(suffStat) != 1 or len(priorStrength) != 1.
+func (r *Rayleigh) ConjugateUpdate(suffStat []float64, nSamples float64, priorStrength []float64) {
+	if len(suffStat) != 1 {
+		panic("rayleigh: incorrect suffStat length")
+	}
+	if len(priorStrength) != 1 {
+		panic("rayleigh: incorrect priorStrength length")
+	}
+
+	totalSamples := nSamples + priorStrength[0]
+
+	totalSum := nSamples * suffStat[0]
+	if !(priorStrength[0] == 0) {
+		totalSum += priorStrength[0] * 2 * r.Sigma * r.Sigma
+	}
+	r.Sigma = math.Sqrt(totalSum / (2 * totalSamples))
+	priorStrength[0] = totalSamples
+}
+
+// Entropy returns the entropy of the distribution.
+func (r Rayleigh) Entropy() float64 {
+	return 1 + math.Log(r.Sigma/math.Sqrt2) + eulerGamma/2
+}
+
+// ExKurtosis returns the excess kurtosis of the distribution.
+func (Rayleigh) ExKurtosis() float64 {
+	return -(6*math.Pi*math.Pi - 24*math.Pi + 16) / ((4 - math.Pi) * (4 - math.Pi))
+}
+
+// Fit sets the parameters of the probability distribution from the
+// data samples x with relative weights w.
+// If weights is nil, then all the weights are 1.
+// If weights is not nil, then the len(weights) must equal len(samples).
+func (r *Rayleigh) Fit(samples, weights []float64) {
+	suffStat := make([]float64, r.NumSuffStat())
+	nSamples := r.SuffStat(suffStat, samples, weights)
+	r.ConjugateUpdate(suffStat, nSamples, make([]float64, r.NumSuffStat()))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (r Rayleigh) LogProb(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(x) - 2*math.Log(r.Sigma) - x*x/(2*r.Sigma*r.Sigma)
+}
+
+// Mean returns the mean of the probability distribution.
+func (r Rayleigh) Mean() float64 {
+	return r.Sigma * math.Sqrt(math.Pi/2)
+}
+
+// Median returns the median of the probability distribution.
+func (r Rayleigh) Median() float64 {
+	return r.Sigma * math.Sqrt(2*ln2)
+}
+
+// Mode returns the mode of the probability distribution.
+func (r Rayleigh) Mode() float64 {
+	return r.Sigma
+}
+
+// NumParameters returns the number of parameters in the distribution.
+func (Rayleigh) NumParameters() int {
+	return 1
+}
+
+// NumSuffStat returns the number of sufficient statistics for the distribution.
+func (Rayleigh) NumSuffStat() int {
+	return 1
+}
+
+// Prob computes the value of the probability density function at x.
+func (r Rayleigh) Prob(x float64) float64 {
+	return math.Exp(r.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+func (r Rayleigh) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	return r.Sigma * math.Sqrt(-2*math.Log1p(-p))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (r Rayleigh) Rand() float64 {
+	var rnd float64
+	if r.Source == nil {
+		rnd = rand.ExpFloat64()
+	} else {
+		rnd = r.Source.ExpFloat64()
+	}
+	return r.Sigma * math.Sqrt(2*rnd)
+}
+
+// Score returns the score function with respect to the parameters of the
+// distribution at the input location x. The score function is the derivative
+// of the log-likelihood at x with respect to the parameters
+//  (∂/∂θ) log(p(x;θ))
+// If deriv is non-nil, len(deriv) must equal the number of parameters otherwise
+// Score will panic, and the derivative is stored in-place into deriv. If deriv
+// is nil a new slice will be allocated and returned.
+//
+// The order is [∂LogProb / ∂Sigma].
+//
+// For more information, see https://en.wikipedia.org/wiki/Score_%28statistics%29.
+//
+// Special cases:
+//  Score(x) = [NaN] for x < 0
+func (r Rayleigh) Score(deriv []float64, x float64) []float64 {
+	if deriv == nil {
+		deriv = make([]float64, r.NumParameters())
+	}
+	if len(deriv) != r.NumParameters() {
+		panic(badLength)
+	}
+	if x < 0 {
+		deriv[0] = math.NaN()
+		return deriv
+	}
+	deriv[0] = (x*x/(r.Sigma*r.Sigma) - 2) / r.Sigma
+	return deriv
+}
+
+// ScoreInput returns the score function with respect to the input of the
+// distribution at the input location specified by x. The score function is the
+// derivative of the log-likelihood
+//  (d/dx) log(p(x)) .
+// Special cases:
+//  ScoreInput(x) = 0 for x < 0
+func (r Rayleigh) ScoreInput(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return 1/x - x/(r.Sigma*r.Sigma)
+}
+
+// Skewness returns the skewness of the distribution.
+func (Rayleigh) Skewness() float64 {
+	return 2 * math.Sqrt(math.Pi) * (math.Pi - 3) / math.Pow(4-math.Pi, 1.5)
+}
+
+// StdDev returns the standard deviation of the probability distribution.
+func (r Rayleigh) StdDev() float64 {
+	return math.Sqrt(r.Variance())
+}
+
+// SuffStat computes the sufficient statistics of set of samples to update
+// the distribution. The sufficient statistics are stored in place, and the
+// effective number of samples are returned.
+//
+// The Rayleigh distribution has one sufficient statistic, the mean of the
+// squares of the samples.
+//
+// If weights is nil, the weights are assumed to be 1, otherwise panics if
+// len(samples) != len(weights). Panics if len(suffStat) != NumSuffStat().
+func (Rayleigh) SuffStat(suffStat, samples, weights []float64) (nSamples float64) {
+	if len(weights) != 0 && len(samples) != len(weights) {
+		panic(badLength)
+	}
+
+	if len(suffStat) != (Rayleigh{}).NumSuffStat() {
+		panic(badSuffStat)
+	}
+
+	if len(weights) == 0 {
+		nSamples = float64(len(samples))
+	} else {
+		nSamples = floats.Sum(weights)
+	}
+
+	var sum float64
+	for i, x := range samples {
+		if weights == nil {
+			sum += x * x
+		} else {
+			sum += weights[i] * x * x
+		}
+	}
+	suffStat[0] = sum / nSamples
+	return nSamples
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (r Rayleigh) Survival(x float64) float64 {
+	if x < 0 {
+		return 1
+	}
+	return math.Exp(-x * x / (2 * r.Sigma * r.Sigma))
+}
+
+// Variance returns the variance of the probability distribution.
+func (r Rayleigh) Variance() float64 {
+	return (4 - math.Pi) / 2 * r.Sigma * r.Sigma
+}
+
+// setParameters modifies the parameters of the distribution.
+func (r *Rayleigh) setParameters(p []Parameter) {
+	if len(p) != r.NumParameters() {
+		panic("rayleigh: incorrect number of parameters to set")
+	}
+	if p[0].Name != "Sigma" {
+		panic("rayleigh: " + panicNameMismatch)
+	}
+	r.Sigma = p[0].Value
+}
+
+// parameters returns the parameters of the distribution.
+func (r Rayleigh) parameters(p []Parameter) []Parameter {
+	nParam := r.NumParameters()
+	if p == nil {
+		p = make([]Parameter, nParam)
+	} else if len(p) != nParam {
+		panic("rayleigh: improper parameter length")
+	}
+	p[0].Name = "Sigma"
+	p[0].Value = r.Sigma
+	return p
+}
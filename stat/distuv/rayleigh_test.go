@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestRayleighProb(t *testing.T) {
+	pts := []univariateProbPoint{
+		{
+			loc:     -1,
+			prob:    0,
+			cumProb: 0,
+			logProb: math.Inf(-1),
+		},
+		{
+			loc:     3,
+			prob:    0.2434893505187623,
+			cumProb: 0.6753475326416503,
+			logProb: -1.4126820724517808,
+		},
+	}
+	testDistributionProbs(t, Rayleigh{Sigma: 2}, "Rayleigh", pts)
+}
+
+func TestRayleigh(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, dist := range []Rayleigh{
+		{Sigma: 1, Source: src},
+		{Sigma: 0.3, Source: src},
+		{Sigma: 7, Source: src},
+	} {
+		testContinuousDist(t, i, dist)
+		testScoreInput(t, i, dist)
+	}
+}
+
+func TestRayleighScore(t *testing.T) {
+	for _, test := range []*Rayleigh{
+		{Sigma: 1},
+		{Sigma: 2.5},
+	} {
+		testDerivParam(t, test)
+	}
+}
+
+func TestRayleighFitPrior(t *testing.T) {
+	testConjugateUpdate(t, func() ConjugateUpdater { return &Rayleigh{Sigma: 1.7, Source: rand.New(rand.NewSource(1))} })
+}
+
+func TestRayleighFit(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := Rayleigh{Sigma: 2.5, Source: src}
+	var got Rayleigh
+	got.Fit(randn(want, 100000), nil)
+	if math.Abs(got.Sigma-want.Sigma) > 0.01 {
+		t.Errorf("Fit mismatch: want Sigma = %v, got %v", want.Sigma, got.Sigma)
+	}
+}
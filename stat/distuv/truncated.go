@@ -0,0 +1,355 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+)
+
+// survivaler is a distribution with a survival function. The survival
+// function is used in preference to 1-CDF when truncating to an upper tail.
+type survivaler interface {
+	Survival(x float64) float64
+}
+
+// Truncated is a univariate distribution restricted to the interval
+// [min, max]. The density of the truncated distribution is proportional to
+// the density of the underlying distribution within the interval and zero
+// outside it.
+//
+// Samples are generated by inversion of the CDF of the underlying
+// distribution. If the underlying distribution is a Normal, a specialised
+// rejection sampler is used instead, and probabilities are computed relative
+// to the density at the end of the interval nearest the mean, so that both
+// remain accurate and efficient for intervals far in the tails of the
+// distribution.
+type Truncated struct {
+	dist     Truncatable
+	min, max float64
+
+	// upper specifies that the probabilities lo and hi are the values
+	// of the survival function rather than the CDF at min and max.
+	upper  bool
+	lo, hi float64
+	z      float64
+	logZ   float64
+
+	normal *Normal
+	src    *rand.Rand
+
+	// tail specifies that the distribution is a Normal truncated to an
+	// interval that does not contain its mean. The standardized interval,
+	// reflected about the mean if reflect is true, is [a, b] with
+	// 0 <= a < b, and qa and qb are the values of the standard normal
+	// survival function at a and b divided by the density at a.
+	tail, reflect bool
+	a, b, qa, qb  float64
+}
+
+// NewTruncated returns the distribution dist truncated to the interval
+// [min, max]. The bounds may be infinite. NewTruncated panics if min >= max
+// or if dist has no probability mass within the interval.
+//
+// If dist implements a Survival method, it is used to compute the
+// probabilities of intervals in the upper tail of the distribution.
+func NewTruncated(dist Truncatable, min, max float64, src *rand.Rand) *Truncated {
+	if !(min < max) {
+		panic("truncated: bad bounds")
+	}
+	t := &Truncated{
+		dist: dist,
+		min:  min,
+		max:  max,
+		src:  src,
+	}
+	switch n := dist.(type) {
+	case Normal:
+		t.normal = &n
+	case *Normal:
+		nc := *n
+		t.normal = &nc
+	}
+	if t.normal != nil {
+		a := (min - t.normal.Mu) / t.normal.Sigma
+		b := (max - t.normal.Mu) / t.normal.Sigma
+		switch {
+		case a >= 0:
+			t.tail, t.a, t.b = true, a, b
+		case b <= 0:
+			t.tail, t.reflect, t.a, t.b = true, true, -b, -a
+		}
+	}
+	if t.tail {
+		t.qa = normalMillsRatio(t.a)
+		t.qb = t.scaledSurvival(t.b)
+		if !(t.qa > t.qb) {
+			panic("truncated: no probability mass in interval")
+		}
+		t.logZ = negLogRoot2Pi - t.a*t.a/2 + math.Log(t.qa-t.qb)
+		t.z = math.Exp(t.logZ)
+		return t
+	}
+	t.lo = dist.CDF(min)
+	t.hi = dist.CDF(max)
+	if s, ok := dist.(survivaler); ok && t.lo > 0.5 {
+		t.upper = true
+		t.lo = s.Survival(min)
+		t.hi = s.Survival(max)
+		t.z = t.lo - t.hi
+	} else {
+		t.z = t.hi - t.lo
+	}
+	if !(t.z > 0) {
+		panic("truncated: no probability mass in interval")
+	}
+	t.logZ = math.Log(t.z)
+	return t
+}
+
+// Bounds returns the lower and upper bounds of the truncation interval.
+func (t *Truncated) Bounds() (min, max float64) {
+	return t.min, t.max
+}
+
+// CDF computes the value of the cumulative density function at x.
+func (t *Truncated) CDF(x float64) float64 {
+	switch {
+	case x < t.min:
+		return 0
+	case x >= t.max:
+		return 1
+	}
+	var p float64
+	switch {
+	case t.tail:
+		s := t.scaledSurvival(t.standardize(x))
+		if t.reflect {
+			p = (s - t.qb) / (t.qa - t.qb)
+		} else {
+			p = (t.qa - s) / (t.qa - t.qb)
+		}
+	case t.upper:
+		p = (t.lo - t.dist.(survivaler).Survival(x)) / t.z
+	default:
+		p = (t.dist.CDF(x) - t.lo) / t.z
+	}
+	return math.Max(0, math.Min(1, p))
+}
+
+// LogProb computes the natural logarithm of the value of the probability
+// density function at x.
+func (t *Truncated) LogProb(x float64) float64 {
+	if x < t.min || x > t.max {
+		return math.Inf(-1)
+	}
+	return t.dist.LogProb(x) - t.logZ
+}
+
+// Prob computes the value of the probability density function at x.
+func (t *Truncated) Prob(x float64) float64 {
+	return math.Exp(t.LogProb(x))
+}
+
+// Quantile returns the inverse of the cumulative probability distribution.
+//
+// The accuracy of Quantile is limited by the accuracy of the Quantile method
+// of the underlying distribution for probabilities close to 0 or 1.
+func (t *Truncated) Quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		panic(badPercentile)
+	}
+	var x float64
+	switch {
+	case t.tail && t.reflect:
+		z := t.tailQuantile(t.qb + p*(t.qa-t.qb))
+		x = t.normal.Mu - t.normal.Sigma*z
+	case t.tail:
+		z := t.tailQuantile(t.qa - p*(t.qa-t.qb))
+		x = t.normal.Mu + t.normal.Sigma*z
+	case t.upper:
+		x = t.dist.Quantile(1 - (t.lo - p*t.z))
+	default:
+		x = t.dist.Quantile(t.lo + p*t.z)
+	}
+	return math.Max(t.min, math.Min(t.max, x))
+}
+
+// Rand returns a random sample drawn from the distribution.
+func (t *Truncated) Rand() float64 {
+	if t.normal != nil {
+		a := (t.min - t.normal.Mu) / t.normal.Sigma
+		b := (t.max - t.normal.Mu) / t.normal.Sigma
+		unifrnd, normrnd, exprnd := rand.Float64, rand.NormFloat64, rand.ExpFloat64
+		if t.src != nil {
+			unifrnd, normrnd, exprnd = t.src.Float64, t.src.NormFloat64, t.src.ExpFloat64
+		}
+		z := truncatedStdNormal(a, b, unifrnd, normrnd, exprnd)
+		return math.Max(t.min, math.Min(t.max, t.normal.Mu+t.normal.Sigma*z))
+	}
+	var rnd float64
+	if t.src == nil {
+		rnd = rand.Float64()
+	} else {
+		rnd = t.src.Float64()
+	}
+	return t.Quantile(rnd)
+}
+
+// Survival returns the survival function (complementary CDF) at x.
+func (t *Truncated) Survival(x float64) float64 {
+	switch {
+	case x < t.min:
+		return 1
+	case x >= t.max:
+		return 0
+	}
+	var p float64
+	switch {
+	case t.tail:
+		s := t.scaledSurvival(t.standardize(x))
+		if t.reflect {
+			p = (t.qa - s) / (t.qa - t.qb)
+		} else {
+			p = (s - t.qb) / (t.qa - t.qb)
+		}
+	case t.upper:
+		p = (t.dist.(survivaler).Survival(x) - t.hi) / t.z
+	default:
+		p = (t.hi - t.dist.CDF(x)) / t.z
+	}
+	return math.Max(0, math.Min(1, p))
+}
+
+// standardize returns the standardized value of x for a truncated Normal,
+// reflected about the mean if the interval is in the lower tail.
+func (t *Truncated) standardize(x float64) float64 {
+	z := (x - t.normal.Mu) / t.normal.Sigma
+	if t.reflect {
+		return -z
+	}
+	return z
+}
+
+// scaledSurvival returns the value of the standard normal survival function
+// at z >= a divided by the standard normal density at a.
+func (t *Truncated) scaledSurvival(z float64) float64 {
+	if math.IsInf(z, 1) {
+		return 0
+	}
+	return normalMillsRatio(z) * math.Exp((t.a-z)*(t.a+z)/2)
+}
+
+// tailQuantile returns the z in [a, b] at which scaledSurvival is equal to s.
+// The log of the survival function is concave and decreasing, so Newton's
+// method converges monotonically after the first step.
+func (t *Truncated) tailQuantile(s float64) float64 {
+	switch {
+	case s >= t.qa:
+		return t.a
+	case s <= t.qb:
+		return t.b
+	}
+	logS := math.Log(s)
+	z := t.a
+	for i := 0; i < 100; i++ {
+		r := normalMillsRatio(z)
+		// The derivative of the log of the survival function is -1/r.
+		step := (math.Log(r) + (t.a-z)*(t.a+z)/2 - logS) * r
+		next := math.Min(t.b, z+step)
+		if math.Abs(next-z) <= 1e-15*math.Max(1, math.Abs(z)) {
+			return next
+		}
+		z = next
+	}
+	return z
+}
+
+// normalMillsRatio returns the ratio of the survival function to the density
+// of the standard normal distribution at z >= 0.
+func normalMillsRatio(z float64) float64 {
+	if math.IsInf(z, 1) {
+		return 0
+	}
+	if z < 26 {
+		return 0.5 * math.Erfc(z/math.Sqrt2) / math.Exp(negLogRoot2Pi-z*z/2)
+	}
+	// Evaluate the continued fraction
+	//  1/(z + 1/(z + 2/(z + 3/(z + ...))))
+	// from a fixed depth, which is sufficient for large z.
+	f := z
+	for k := 40; k >= 1; k-- {
+		f = z + float64(k)/f
+	}
+	return 1 / f
+}
+
+// truncatedStdNormal returns a sample from the standard normal distribution
+// truncated to the interval [a, b], using the rejection samplers of
+//  Robert, C. P. "Simulation of truncated normal variables." Statistics and
+//  Computing 5.2 (1995): 121-125.
+func truncatedStdNormal(a, b float64, unifrnd, normrnd, exprnd func() float64) float64 {
+	switch {
+	case a >= 0:
+		return truncatedStdNormalTail(a, b, unifrnd, normrnd, exprnd)
+	case b <= 0:
+		return -truncatedStdNormalTail(-b, -a, unifrnd, normrnd, exprnd)
+	}
+	// The interval contains zero. Rejection from a normal proposal is
+	// efficient for wide intervals and from a uniform proposal for narrow
+	// intervals.
+	if b-a >= math.Sqrt(2*math.Pi) {
+		for {
+			z := normrnd()
+			if a <= z && z <= b {
+				return z
+			}
+		}
+	}
+	for {
+		z := a + (b-a)*unifrnd()
+		if unifrnd() <= math.Exp(-z*z/2) {
+			return z
+		}
+	}
+}
+
+// truncatedStdNormalTail returns a sample from the standard normal
+// distribution truncated to the interval [a, b] with 0 <= a < b.
+func truncatedStdNormalTail(a, b float64, unifrnd, normrnd, exprnd func() float64) float64 {
+	s := math.Sqrt(a*a + 4)
+	// Use a uniform proposal if the interval is narrow enough that it is
+	// more efficient than the exponential proposal.
+	if b-a < 2*math.Sqrt(math.E)/(a+s)*math.Exp((a*a-a*s)/4) {
+		for {
+			z := a + (b-a)*unifrnd()
+			if unifrnd() <= math.Exp((a*a-z*z)/2) {
+				return z
+			}
+		}
+	}
+	if a < 0.25 {
+		// Rejection from the half-normal distribution accepts more
+		// than half of the proposals.
+		for {
+			z := math.Abs(normrnd())
+			if a <= z && z <= b {
+				return z
+			}
+		}
+	}
+	// Rejection from a shifted exponential with the optimal rate.
+	alpha := (a + s) / 2
+	for {
+		z := a + exprnd()/alpha
+		if z > b {
+			continue
+		}
+		d := z - alpha
+		if unifrnd() <= math.Exp(-d*d/2) {
+			return z
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package distuv
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/integrate/quad"
+)
+
+func TestTruncatedNormal(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, test := range []struct {
+		dist     Normal
+		min, max float64
+	}{
+		{Normal{Mu: 0, Sigma: 1}, 0, math.Inf(1)},
+		{Normal{Mu: 0, Sigma: 1}, math.Inf(-1), 0.5},
+		{Normal{Mu: 1, Sigma: 2}, -1, 2},
+		{Normal{Mu: 0, Sigma: 1}, -0.2, 0.3},
+		{Normal{Mu: 0, Sigma: 1}, 0.1, 3},
+		{Normal{Mu: 0, Sigma: 1}, 2, 2.2},
+		{Normal{Mu: 0, Sigma: 1}, 10, math.Inf(1)},
+		{Normal{Mu: 0, Sigma: 1}, 10, 10.05},
+		{Normal{Mu: 3, Sigma: 0.5}, math.Inf(-1), -1},
+		{Normal{Mu: 0, Sigma: 1}, -3, -2.5},
+	} {
+		d := NewTruncated(test.dist, test.min, test.max, src)
+		testUnivariateDist(t, i, d, 1e-8)
+
+		// Compare the mean with the closed form expression.
+		a := (test.min - test.dist.Mu) / test.dist.Sigma
+		b := (test.max - test.dist.Mu) / test.dist.Sigma
+		unit := Normal{Mu: 0, Sigma: 1}
+		var z float64
+		if a > 0 {
+			z = unit.Survival(a) - unit.Survival(b)
+		} else {
+			z = unit.CDF(b) - unit.CDF(a)
+		}
+		want := test.dist.Mu + test.dist.Sigma*(unit.Prob(a)-unit.Prob(b))/z
+		got := quantileIntegral(d.Quantile, func(x float64) float64 { return x })
+		if !floats.EqualWithinAbsOrRel(got, want, 1e-8, 1e-8) {
+			t.Errorf("Mean mismatch case %v: want %v, got %v", i, want, got)
+		}
+
+		// Check the density and distribution functions against the
+		// underlying distribution.
+		for _, p := range []float64{0.01, 0.3, 0.5, 0.9} {
+			x := d.Quantile(p)
+			if x < test.min || x > test.max {
+				t.Errorf("Quantile out of bounds case %v: %v not in [%v, %v]", i, x, test.min, test.max)
+			}
+			if got := d.CDF(x); !floats.EqualWithinAbsOrRel(got, p, 1e-10, 1e-10) {
+				t.Errorf("Quantile/CDF mismatch case %v: want %v, got %v", i, p, got)
+			}
+			if got, want := d.LogProb(x), test.dist.LogProb(x)-math.Log(z); !floats.EqualWithinAbsOrRel(got, want, 1e-10, 1e-10) {
+				t.Errorf("LogProb mismatch case %v: want %v, got %v", i, want, got)
+			}
+		}
+		if d.Prob(test.min-1) != 0 || d.CDF(test.min-1) != 0 || d.Survival(test.max+1) != 0 || d.CDF(test.max+1) != 1 {
+			t.Errorf("Nonzero probability outside bounds case %v", i)
+		}
+	}
+}
+
+func TestTruncatedNormalFarTail(t *testing.T) {
+	// The probability mass in these intervals underflows, so the
+	// distribution functions are checked against the closed form mean
+	// and the normalization of the density.
+	src := rand.New(rand.NewSource(1))
+	for i, test := range []struct {
+		dist     Normal
+		min, max float64
+	}{
+		{Normal{Mu: 0, Sigma: 1}, 39, 40},
+		{Normal{Mu: 0, Sigma: 1}, 40, 50},
+		{Normal{Mu: 0, Sigma: 1}, -50, -40},
+		{Normal{Mu: 0, Sigma: 1}, 60, math.Inf(1)},
+		{Normal{Mu: 2, Sigma: 0.5}, math.Inf(-1), -30},
+	} {
+		d := NewTruncated(test.dist, test.min, test.max, src)
+		testUnivariateDist(t, i, d, 1e-8)
+
+		if got := quad.Fixed(d.Prob, d.Quantile(0), d.Quantile(1), 1000, nil, 0); math.Abs(got-1) > 1e-8 {
+			t.Errorf("Density not normalized case %v: got %v", i, got)
+		}
+
+		// The mean is mu + sigma*(φ(a)-φ(b))/Z, with a and b the
+		// standardized bounds nearest and furthest from the mean and
+		// Z the probability mass, computed relative to φ(a). The Mills
+		// ratios za and zb are approximated by a short continued
+		// fraction, which is accurate to double precision for a > 30.
+		a := (test.min - test.dist.Mu) / test.dist.Sigma
+		b := (test.max - test.dist.Mu) / test.dist.Sigma
+		sign := 1.0
+		if b < 0 {
+			a, b, sign = -b, -a, -1
+		}
+		rb := math.Exp((a - b) * (a + b) / 2)
+		zb := 0.0
+		if !math.IsInf(b, 1) {
+			zb = rb / (b + 1/(b+2/(b+3/(b+4/b))))
+		}
+		za := 1 / (a + 1/(a+2/(a+3/(a+4/a))))
+		want := test.dist.Mu + sign*test.dist.Sigma*(1-rb)/(za-zb)
+		got := quantileIntegral(d.Quantile, func(x float64) float64 { return x })
+		if !floats.EqualWithinAbsOrRel(got, want, 1e-8, 1e-8) {
+			t.Errorf("Mean mismatch case %v: want %v, got %v", i, want, got)
+		}
+
+		for _, p := range []float64{1e-6, 0.01, 0.3, 0.5, 0.9, 1 - 1e-6} {
+			x := d.Quantile(p)
+			if x < test.min || x > test.max {
+				t.Errorf("Quantile out of bounds case %v: %v not in [%v, %v]", i, x, test.min, test.max)
+			}
+			if got := d.CDF(x); !floats.EqualWithinAbsOrRel(got, p, 1e-10, 1e-10) {
+				t.Errorf("Quantile/CDF mismatch case %v: want %v, got %v", i, p, got)
+			}
+			if got := d.Survival(x); !floats.EqualWithinAbsOrRel(got, 1-p, 1e-10, 1e-10) {
+				t.Errorf("Quantile/Survival mismatch case %v: want %v, got %v", i, 1-p, got)
+			}
+		}
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for i, test := range []struct {
+		dist     Truncatable
+		min, max float64
+	}{
+		{Gamma{Alpha: 2, Beta: 1, Source: src}, 1, 3},
+		{Exponential{Rate: 1, Source: src}, 5, math.Inf(1)},
+		{Weibull{K: 1.5, Lambda: 2, Source: src}, 0, 1},
+		{&Normal{Mu: 0, Sigma: 1}, -1, 1},
+	} {
+		testUnivariateDist(t, i, NewTruncated(test.dist, test.min, test.max, src), 1e-8)
+	}
+}
+
+func TestTruncatedPanics(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		dist     Truncatable
+		min, max float64
+	}{
+		{"bad bounds", Normal{Mu: 0, Sigma: 1}, 1, 1},
+		{"no mass", Uniform{Min: 0, Max: 1}, 2, 3},
+	} {
+		if !panics(func() { NewTruncated(test.dist, test.min, test.max, nil) }) {
+			t.Errorf("expected panic for %s", test.name)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// OneWayANOVA performs the one-way analysis of variance test of the null
+// hypothesis that the means of the populations from which the groups are
+// drawn are equal, assuming normal populations with equal variance. The
+// statistic is the ratio of the between-group and within-group mean
+// squares,
+//  F = (\sum_i n_i (mean_i - mean)^2 / (k-1)) / (\sum_{i,j} (x_ij - mean_i)^2 / (N-k))
+// which is F-distributed with k-1 and N-k degrees of freedom under the null
+// hypothesis for k groups with N values in total.
+//
+// OneWayANOVA panics if there are fewer than two groups, if any group is
+// empty, or if there are no more values than groups.
+func OneWayANOVA(groups ...[]float64) Result {
+	k := len(groups)
+	if k < 2 {
+		panic(tooFewSamples)
+	}
+	var n int
+	var sum float64
+	means := make([]float64, k)
+	for i, g := range groups {
+		if len(g) == 0 {
+			panic(tooFewSamples)
+		}
+		means[i] = stat.Mean(g, nil)
+		sum += means[i] * float64(len(g))
+		n += len(g)
+	}
+	if n <= k {
+		panic(tooFewSamples)
+	}
+	mean := sum / float64(n)
+	var between, within float64
+	for i, g := range groups {
+		d := means[i] - mean
+		between += float64(len(g)) * d * d
+		for _, v := range g {
+			d := v - means[i]
+			within += d * d
+		}
+	}
+	d1 := float64(k - 1)
+	d2 := float64(n - k)
+	f := (between / d1) / (within / d2)
+	return Result{
+		Statistic:   f,
+		PValue:      distuv.F{D1: d1, D2: d2}.Survival(f),
+		DoF:         d1,
+		DenomDoF:    d2,
+		Alternative: Greater,
+	}
+}
+
+// Levene performs Levene's test of the null hypothesis that the variances
+// of the populations from which the groups are drawn are equal. The
+// statistic is the one-way ANOVA F statistic of the absolute deviations of
+// the values from their group means.
+//
+// Levene panics under the same conditions as OneWayANOVA.
+func Levene(groups ...[]float64) Result {
+	return levene(groups, func(g []float64) float64 {
+		return stat.Mean(g, nil)
+	})
+}
+
+// BrownForsythe performs the Brown–Forsythe test of the null hypothesis
+// that the variances of the populations from which the groups are drawn are
+// equal. It is the variant of Levene's test using the absolute deviations
+// from the group medians, which is robust to non-normal populations.
+//
+// BrownForsythe panics under the same conditions as OneWayANOVA.
+func BrownForsythe(groups ...[]float64) Result {
+	return levene(groups, median)
+}
+
+// levene returns the one-way ANOVA of the absolute deviations of the values
+// in the groups from the group centers.
+func levene(groups [][]float64, center func([]float64) float64) Result {
+	dev := make([][]float64, len(groups))
+	for i, g := range groups {
+		if len(g) == 0 {
+			panic(tooFewSamples)
+		}
+		c := center(g)
+		dev[i] = make([]float64, len(g))
+		for j, v := range g {
+			dev[i][j] = math.Abs(v - c)
+		}
+	}
+	return OneWayANOVA(dev...)
+}
+
+// median returns the median of x, the mean of the two middle values if
+// len(x) is even.
+func median(x []float64) float64 {
+	s := sorted(x)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
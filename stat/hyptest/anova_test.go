@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// The PlantGrowth data set.
+var (
+	plantCtrl = []float64{4.17, 5.58, 5.18, 6.11, 4.50, 4.61, 5.17, 4.53, 5.33, 5.14}
+	plantTrt1 = []float64{4.81, 4.17, 4.41, 3.59, 5.87, 3.83, 6.03, 4.89, 4.32, 4.69}
+	plantTrt2 = []float64{6.31, 5.12, 5.54, 5.50, 5.37, 5.29, 4.92, 6.15, 5.80, 5.26}
+)
+
+func TestOneWayANOVA(t *testing.T) {
+	// Reference values from R.
+	got := OneWayANOVA(plantCtrl, plantTrt1, plantTrt2)
+	checkResult(t, "ANOVA", got, 4.846088, 0.01590996, 2, 1e-5)
+	if got.DenomDoF != 27 {
+		t.Errorf("denominator degrees of freedom mismatch: want 27, got %v", got.DenomDoF)
+	}
+
+	// The F statistic of two groups is the square of the pooled t
+	// statistic.
+	tt := TwoSampleTTest(plantCtrl, plantTrt1, TwoSided)
+	f := OneWayANOVA(plantCtrl, plantTrt1)
+	if math.Abs(f.Statistic-tt.Statistic*tt.Statistic) > 1e-12 {
+		t.Errorf("F statistic mismatch: want %v, got %v", tt.Statistic*tt.Statistic, f.Statistic)
+	}
+	if math.Abs(f.PValue-tt.PValue) > 1e-12 {
+		t.Errorf("p-value mismatch: want %v, got %v", tt.PValue, f.PValue)
+	}
+
+	if !panics(func() { OneWayANOVA(plantCtrl) }) {
+		t.Errorf("expected panic for one group")
+	}
+	if !panics(func() { OneWayANOVA(plantCtrl, nil) }) {
+		t.Errorf("expected panic for empty group")
+	}
+}
+
+func TestLevene(t *testing.T) {
+	// Reference values from R.
+	got := BrownForsythe(plantCtrl, plantTrt1, plantTrt2)
+	checkResult(t, "Brown-Forsythe", got, 1.1192, 0.3412, 2, 1e-4)
+	if got.DenomDoF != 27 {
+		t.Errorf("denominator degrees of freedom mismatch: want 27, got %v", got.DenomDoF)
+	}
+
+	groups := [][]float64{plantCtrl, plantTrt1, plantTrt2}
+	dev := make([][]float64, len(groups))
+	for i, g := range groups {
+		m := stat.Mean(g, nil)
+		for _, v := range g {
+			dev[i] = append(dev[i], math.Abs(v-m))
+		}
+	}
+	want := OneWayANOVA(dev...)
+	if got := Levene(groups...); got != want {
+		t.Errorf("Levene mismatch: want %+v, got %+v", want, got)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	for _, test := range []struct {
+		x    []float64
+		want float64
+	}{
+		{[]float64{3}, 3},
+		{[]float64{3, 1, 2}, 2},
+		{[]float64{4, 1, 3, 2}, 2.5},
+	} {
+		if got := median(test.x); got != test.want {
+			t.Errorf("median mismatch for %v: want %v, got %v", test.x, test.want, got)
+		}
+	}
+}
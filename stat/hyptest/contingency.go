@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ChiSquareGoodnessOfFit performs Pearson's chi-square test of the null
+// hypothesis that the observed counts obs are drawn from the categorical
+// distribution with expected counts exp. The statistic is
+//  χ² = \sum_i (obs_i - exp_i)^2 / exp_i
+// which is asymptotically chi-square distributed with len(obs)-1-estimated
+// degrees of freedom, where estimated is the number of parameters of the
+// expected distribution that were estimated from the data. The expected
+// counts should have the same total as the observed counts.
+//
+// ChiSquareGoodnessOfFit panics if len(obs) != len(exp) or if there are no
+// degrees of freedom.
+func ChiSquareGoodnessOfFit(obs, exp []float64, estimated int) Result {
+	if len(obs) != len(exp) {
+		panic(badLength)
+	}
+	dof := float64(len(obs) - 1 - estimated)
+	if dof < 1 {
+		panic(tooFewSamples)
+	}
+	chi2 := stat.ChiSquare(obs, exp)
+	return Result{
+		Statistic:   chi2,
+		PValue:      distuv.ChiSquared{K: dof}.Survival(chi2),
+		DoF:         dof,
+		Alternative: Greater,
+	}
+}
+
+// ChiSquareIndependence performs Pearson's chi-square test of the null
+// hypothesis that the row and column variables of the contingency table of
+// counts are independent. The expected count of each cell is the product of
+// its row and column totals divided by the grand total, and the statistic
+// is asymptotically chi-square distributed with (r-1)(c-1) degrees of
+// freedom for an r×c table.
+//
+// If correction is true and the table is 2×2, Yates's continuity correction
+// is applied.
+//
+// ChiSquareIndependence panics if the table has fewer than two rows or
+// columns.
+func ChiSquareIndependence(table mat.Matrix, correction bool) Result {
+	r, c := table.Dims()
+	if r < 2 || c < 2 {
+		panic(tooFewSamples)
+	}
+	rows := make([]float64, r)
+	cols := make([]float64, c)
+	var total float64
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := table.At(i, j)
+			rows[i] += v
+			cols[j] += v
+			total += v
+		}
+	}
+	yates := correction && r == 2 && c == 2
+	var chi2 float64
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			e := rows[i] * cols[j] / total
+			d := math.Abs(table.At(i, j) - e)
+			if yates {
+				d -= math.Min(0.5, d)
+			}
+			chi2 += d * d / e
+		}
+	}
+	dof := float64((r - 1) * (c - 1))
+	return Result{
+		Statistic:   chi2,
+		PValue:      distuv.ChiSquared{K: dof}.Survival(chi2),
+		DoF:         dof,
+		Alternative: Greater,
+	}
+}
+
+// FisherExact performs Fisher's exact test of the null hypothesis that the
+// row and column variables of the 2×2 contingency table of counts are
+// independent. The statistic is the sample odds ratio
+//  (table[0][0] * table[1][1]) / (table[0][1] * table[1][0])
+// and the p-value is computed from the hypergeometric distribution of
+// table[0][0] conditional on the row and column totals. For the Greater
+// alternative, the odds ratio is greater than one. The two-sided p-value is
+// the total probability of the tables that are no more probable than the
+// observed table.
+//
+// The odds ratio is +Inf if table[0][1] or table[1][0] is zero and the
+// other two entries are not, and NaN if a zero in table[0][0] or
+// table[1][1] meets a zero in table[0][1] or table[1][0]. The p-value is
+// valid in both cases.
+//
+// FisherExact panics if the table is not 2×2 or if its entries are not
+// non-negative integers.
+func FisherExact(table mat.Matrix, alt Alternative) Result {
+	r, c := table.Dims()
+	if r != 2 || c != 2 {
+		panic("hyptest: table not 2×2")
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			v := table.At(i, j)
+			if v < 0 || v != math.Floor(v) {
+				panic("hyptest: bad count")
+			}
+		}
+	}
+	a, b := table.At(0, 0), table.At(0, 1)
+	cc, d := table.At(1, 0), table.At(1, 1)
+	dist := distuv.Hypergeometric{
+		N:     a + b + cc + d,
+		K:     a + cc,
+		Draws: a + b,
+	}
+	lo := math.Max(0, dist.Draws+dist.K-dist.N)
+	hi := math.Min(dist.Draws, dist.K)
+	pObs := dist.Prob(a)
+
+	var lower, upper, two float64
+	for x := lo; x <= hi; x++ {
+		p := dist.Prob(x)
+		if x <= a {
+			lower += p
+		}
+		if x >= a {
+			upper += p
+		}
+		// Allow for rounding error in the comparison with the observed
+		// table.
+		if p <= pObs*(1+1e-7) {
+			two += p
+		}
+	}
+	var pv float64
+	switch alt {
+	case TwoSided:
+		pv = two
+	case Less:
+		pv = lower
+	case Greater:
+		pv = upper
+	default:
+		panic(badAlternative)
+	}
+	return Result{
+		Statistic:   a * d / (b * cc),
+		PValue:      math.Min(1, pv),
+		Alternative: alt,
+	}
+}
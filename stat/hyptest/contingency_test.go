@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestChiSquareGoodnessOfFit(t *testing.T) {
+	got := ChiSquareGoodnessOfFit([]float64{20, 15, 25}, []float64{20, 20, 20}, 0)
+	// The chi-square distribution with two degrees of freedom has
+	// survival function exp(-x/2).
+	checkResult(t, "goodness of fit", got, 2.5, math.Exp(-1.25), 2, 1e-12)
+	if got.Alternative != Greater {
+		t.Errorf("unexpected alternative: %v", got.Alternative)
+	}
+	got = ChiSquareGoodnessOfFit([]float64{20, 15, 25}, []float64{20, 20, 20}, 1)
+	if got.DoF != 1 {
+		t.Errorf("degrees of freedom mismatch: want 1, got %v", got.DoF)
+	}
+	if !panics(func() { ChiSquareGoodnessOfFit([]float64{1, 2}, []float64{1, 2}, 1) }) {
+		t.Errorf("expected panic for no degrees of freedom")
+	}
+}
+
+func TestChiSquareIndependence(t *testing.T) {
+	// Reference values from R.
+	table := mat.NewDense(2, 3, []float64{
+		762, 327, 468,
+		484, 239, 477,
+	})
+	checkResult(t, "independence", ChiSquareIndependence(table, true), 30.07015, 2.953589e-07, 2, 1e-5)
+
+	// Yates's corrected statistic for a 2×2 table is
+	//  N (|ad - bc| - N/2)^2 / (r_1 r_2 c_1 c_2).
+	a, b, c, d := 10.0, 5.0, 3.0, 12.0
+	table = mat.NewDense(2, 2, []float64{a, b, c, d})
+	n := a + b + c + d
+	e := math.Abs(a*d-b*c) - n/2
+	want := n * e * e / ((a + b) * (c + d) * (a + c) * (b + d))
+	got := ChiSquareIndependence(table, true)
+	if math.Abs(got.Statistic-want) > 1e-12 {
+		t.Errorf("Yates statistic mismatch: want %v, got %v", want, got.Statistic)
+	}
+	e = math.Abs(a*d - b*c)
+	want = n * e * e / ((a + b) * (c + d) * (a + c) * (b + d))
+	got = ChiSquareIndependence(table, false)
+	if math.Abs(got.Statistic-want) > 1e-12 {
+		t.Errorf("uncorrected statistic mismatch: want %v, got %v", want, got.Statistic)
+	}
+}
+
+func TestFisherExact(t *testing.T) {
+	// Fisher's tea tasting experiment. Reference values from R.
+	table := mat.NewDense(2, 2, []float64{3, 1, 1, 3})
+	checkResult(t, "greater", FisherExact(table, Greater), 9, 0.2428571, 0, 1e-6)
+	checkResult(t, "two-sided", FisherExact(table, TwoSided), 9, 0.4857143, 0, 1e-6)
+	checkResult(t, "less", FisherExact(table, Less), 9, 0.9857143, 0, 1e-6)
+
+	// A zero off the diagonal gives an infinite odds ratio, and zeros on
+	// and off the diagonal give NaN, but the p-values are valid.
+	res := FisherExact(mat.NewDense(2, 2, []float64{3, 0, 1, 4}), Greater)
+	if !math.IsInf(res.Statistic, 1) || math.Abs(res.PValue-4.0/56) > 1e-12 {
+		t.Errorf("unexpected result for zero off-diagonal count: got %+v, want odds ratio +Inf and p-value %v", res, 4.0/56)
+	}
+	res = FisherExact(mat.NewDense(2, 2, []float64{3, 0, 1, 4}), TwoSided)
+	if math.Abs(res.PValue-8.0/56) > 1e-12 {
+		t.Errorf("unexpected two-sided p-value for zero off-diagonal count: got %v, want %v", res.PValue, 8.0/56)
+	}
+	res = FisherExact(mat.NewDense(2, 2, []float64{0, 0, 2, 3}), TwoSided)
+	if !math.IsNaN(res.Statistic) || res.PValue != 1 {
+		t.Errorf("unexpected result for empty row: got %+v, want odds ratio NaN and p-value 1", res)
+	}
+
+	if !panics(func() { FisherExact(mat.NewDense(2, 2, []float64{1.5, 1, 1, 1}), TwoSided) }) {
+		t.Errorf("expected panic for non-integer count")
+	}
+	if !panics(func() { FisherExact(mat.NewDense(2, 3, nil), TwoSided) }) {
+		t.Errorf("expected panic for table that is not 2×2")
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hyptest provides classical statistical hypothesis tests.
+//
+// Each test returns a Result holding the value of the test statistic, the
+// p-value under the null hypothesis, the degrees of freedom of the null
+// distribution where applicable, and the alternative hypothesis tested.
+package hyptest // import "gonum.org/v1/gonum/stat/hyptest"
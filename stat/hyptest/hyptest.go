@@ -0,0 +1,141 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	badAlternative = "hyptest: bad alternative"
+	badMethod      = "hyptest: bad method"
+	badLength      = "hyptest: slice length mismatch"
+	tooFewSamples  = "hyptest: too few samples"
+)
+
+// Alternative specifies the alternative hypothesis of a test.
+type Alternative int
+
+const (
+	// TwoSided is the alternative that the parameter of interest differs
+	// from its value under the null hypothesis.
+	TwoSided Alternative = iota
+	// Less is the alternative that the parameter of interest is less than
+	// its value under the null hypothesis.
+	Less
+	// Greater is the alternative that the parameter of interest is greater
+	// than its value under the null hypothesis.
+	Greater
+)
+
+// String returns the name of the alternative.
+func (a Alternative) String() string {
+	switch a {
+	case TwoSided:
+		return "two-sided"
+	case Less:
+		return "less"
+	case Greater:
+		return "greater"
+	}
+	return "unknown alternative"
+}
+
+// Method specifies how the p-value of a nonparametric test is computed.
+type Method int
+
+const (
+	// Auto uses the exact null distribution for small samples without
+	// ties and the asymptotic distribution otherwise.
+	Auto Method = iota
+	// Exact uses the exact null distribution of the statistic.
+	Exact
+	// Asymptotic uses the large-sample approximation to the null
+	// distribution of the statistic.
+	Asymptotic
+)
+
+// Result is the outcome of a hypothesis test.
+type Result struct {
+	// Statistic is the value of the test statistic.
+	Statistic float64
+
+	// PValue is the probability under the null hypothesis of a
+	// statistic at least as extreme as the one observed.
+	PValue float64
+
+	// DoF is the degrees of freedom of the null distribution of the
+	// statistic, or the numerator degrees of freedom for F-distributed
+	// statistics. DoF is zero if the null distribution has no degrees of
+	// freedom.
+	DoF float64
+
+	// DenomDoF is the denominator degrees of freedom for F-distributed
+	// statistics and zero otherwise.
+	DenomDoF float64
+
+	// Alternative is the alternative hypothesis of the test.
+	Alternative Alternative
+}
+
+// pValue returns the p-value for the alternative given the probabilities of
+// a statistic at most and at least as large as the one observed under the
+// null hypothesis.
+func pValue(alt Alternative, lower, upper float64) float64 {
+	switch alt {
+	case TwoSided:
+		return math.Min(1, 2*math.Min(lower, upper))
+	case Less:
+		return math.Min(1, lower)
+	case Greater:
+		return math.Min(1, upper)
+	}
+	panic(badAlternative)
+}
+
+// rank returns the ranks of the values in x, with tied values receiving the
+// mean of the ranks they span, and the tie correction \sum_i (t_i^3 - t_i)
+// where t_i are the sizes of the groups of tied values.
+func rank(x []float64) (ranks []float64, ties float64) {
+	idx := make([]int, len(x))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Sort(byValue{x, idx})
+	ranks = make([]float64, len(x))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && x[idx[j]] == x[idx[i]] {
+			j++
+		}
+		r := float64(i+j+1) / 2
+		for _, k := range idx[i:j] {
+			ranks[k] = r
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	return ranks, ties
+}
+
+// byValue sorts indices by the values they refer to.
+type byValue struct {
+	x   []float64
+	idx []int
+}
+
+func (b byValue) Len() int           { return len(b.idx) }
+func (b byValue) Less(i, j int) bool { return b.x[b.idx[i]] < b.x[b.idx[j]] }
+func (b byValue) Swap(i, j int)      { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
+
+// sorted returns a sorted copy of x.
+func sorted(x []float64) []float64 {
+	s := make([]float64, len(x))
+	copy(s, x)
+	sort.Float64s(s)
+	return s
+}
@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// checkResult checks the statistic, p-value and degrees of freedom of a test
+// result against values with the given number of significant figures.
+func checkResult(t *testing.T, name string, got Result, stat, p, dof float64, tol float64) {
+	if !floats.EqualWithinRel(got.Statistic, stat, tol) {
+		t.Errorf("%s: statistic mismatch: want %v, got %v", name, stat, got.Statistic)
+	}
+	if !floats.EqualWithinRel(got.PValue, p, tol) {
+		t.Errorf("%s: p-value mismatch: want %v, got %v", name, p, got.PValue)
+	}
+	if !floats.EqualWithinRel(got.DoF, dof, tol) {
+		t.Errorf("%s: degrees of freedom mismatch: want %v, got %v", name, dof, got.DoF)
+	}
+}
+
+func TestRank(t *testing.T) {
+	for _, test := range []struct {
+		x     []float64
+		ranks []float64
+		ties  float64
+	}{
+		{
+			x:     []float64{3, 1, 2},
+			ranks: []float64{3, 1, 2},
+		},
+		{
+			x:     []float64{2, 1, 2, 5, 2, 1},
+			ranks: []float64{4, 1.5, 4, 6, 4, 1.5},
+			ties:  24 + 6,
+		},
+	} {
+		ranks, ties := rank(test.x)
+		if !floats.Equal(ranks, test.ranks) {
+			t.Errorf("rank mismatch for %v: want %v, got %v", test.x, test.ranks, ranks)
+		}
+		if ties != test.ties {
+			t.Errorf("tie correction mismatch for %v: want %v, got %v", test.x, test.ties, ties)
+		}
+	}
+}
+
+func TestPValue(t *testing.T) {
+	for _, test := range []struct {
+		alt          Alternative
+		lower, upper float64
+		want         float64
+	}{
+		{TwoSided, 0.2, 0.9, 0.4},
+		{TwoSided, 0.7, 0.6, 1},
+		{Less, 0.2, 0.9, 0.2},
+		{Greater, 0.2, 0.9, 0.9},
+	} {
+		if got := pValue(test.alt, test.lower, test.upper); math.Abs(got-test.want) > 1e-15 {
+			t.Errorf("%v: p-value mismatch: want %v, got %v", test.alt, test.want, got)
+		}
+	}
+	if !panics(func() { pValue(Alternative(3), 0.5, 0.5) }) {
+		t.Errorf("expected panic for bad alternative")
+	}
+}
+
+func panics(f func()) (b bool) {
+	defer func() {
+		err := recover()
+		if err != nil {
+			b = true
+		}
+	}()
+	f()
+	return
+}
@@ -0,0 +1,357 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// KolmogorovSmirnov performs the one-sample Kolmogorov–Smirnov test of the
+// null hypothesis that x is drawn from the continuous distribution with the
+// given cumulative distribution function. The statistic is the largest
+// difference between the empirical distribution function F_n of x and cdf,
+//  D = sup |F_n(t) - cdf(t)|
+// for the TwoSided alternative. For the Greater alternative, that F_n lies
+// above cdf somewhere, the statistic is D+ = sup F_n(t) - cdf(t), and for
+// the Less alternative it is D- = sup cdf(t) - F_n(t).
+//
+// If method is Auto, the exact null distribution is used when x has at most
+// 100 values and the asymptotic Kolmogorov distribution is used otherwise.
+// The exact two-sided distribution is computed with the algorithm of
+//  Marsaglia, G., Tsang, W. W. and Wang, J. "Evaluating Kolmogorov's
+//  distribution." Journal of Statistical Software 8.18 (2003): 1-4.
+//
+// KolmogorovSmirnov panics if x is empty.
+func KolmogorovSmirnov(x []float64, cdf func(float64) float64, alt Alternative, method Method) Result {
+	n := len(x)
+	if n == 0 {
+		panic(tooFewSamples)
+	}
+	s := sorted(x)
+	var dPlus, dMinus float64
+	for i, v := range s {
+		f := cdf(v)
+		dPlus = math.Max(dPlus, float64(i+1)/float64(n)-f)
+		dMinus = math.Max(dMinus, f-float64(i)/float64(n))
+	}
+	var d float64
+	switch alt {
+	case TwoSided:
+		d = math.Max(dPlus, dMinus)
+	case Less:
+		d = dMinus
+	case Greater:
+		d = dPlus
+	default:
+		panic(badAlternative)
+	}
+
+	var p float64
+	if useExact(method, n <= 100) {
+		if alt == TwoSided {
+			p = 1 - kolmogorovCDF(n, d)
+		} else {
+			p = smirnovSurvival(n, d)
+		}
+	} else {
+		if alt == TwoSided {
+			p = kolmogorovSurvival(math.Sqrt(float64(n)) * d)
+		} else {
+			p = math.Exp(-2 * float64(n) * d * d)
+		}
+	}
+	return Result{
+		Statistic:   d,
+		PValue:      math.Max(0, math.Min(1, p)),
+		Alternative: alt,
+	}
+}
+
+// TwoSampleKolmogorovSmirnov performs the two-sample Kolmogorov–Smirnov test
+// of the null hypothesis that x and y are drawn from the same continuous
+// distribution. The statistic is the largest difference between the
+// empirical distribution functions F_x and F_y of the samples,
+//  D = sup |F_x(t) - F_y(t)|
+// for the TwoSided alternative. For the Greater alternative, that F_x lies
+// above F_y somewhere, the statistic is D+ = sup F_x(t) - F_y(t), and for
+// the Less alternative it is D- = sup F_y(t) - F_x(t).
+//
+// If method is Auto, the exact null distribution is used when the product
+// of the sample sizes is at most 10000 and the asymptotic Kolmogorov
+// distribution is used otherwise. The exact distribution is computed by
+// enumerating the lattice paths of the empirical distribution functions and
+// is conservative in the presence of ties.
+//
+// TwoSampleKolmogorovSmirnov panics if x or y is empty.
+func TwoSampleKolmogorovSmirnov(x, y []float64, alt Alternative, method Method) Result {
+	nx, ny := len(x), len(y)
+	if nx == 0 || ny == 0 {
+		panic(tooFewSamples)
+	}
+	sx := sorted(x)
+	sy := sorted(y)
+
+	// Work with the integer differences nx*ny*(F_x - F_y) at the end of
+	// each group of tied values to compare the statistic exactly with the
+	// lattice paths of the null distribution.
+	var dPlus, dMinus int
+	for i, j := 0, 0; i < nx || j < ny; {
+		var v float64
+		switch {
+		case i == nx:
+			v = sy[j]
+		case j == ny:
+			v = sx[i]
+		default:
+			v = math.Min(sx[i], sy[j])
+		}
+		for i < nx && sx[i] == v {
+			i++
+		}
+		for j < ny && sy[j] == v {
+			j++
+		}
+		diff := i*ny - j*nx
+		if diff > dPlus {
+			dPlus = diff
+		}
+		if -diff > dMinus {
+			dMinus = -diff
+		}
+	}
+	var k int
+	switch alt {
+	case TwoSided:
+		k = dPlus
+		if dMinus > k {
+			k = dMinus
+		}
+	case Less:
+		k = dMinus
+	case Greater:
+		k = dPlus
+	default:
+		panic(badAlternative)
+	}
+	d := float64(k) / float64(nx*ny)
+
+	var p float64
+	if useExact(method, nx*ny <= 10000) {
+		p = twoSampleKSSurvival(nx, ny, k, alt)
+	} else {
+		en := math.Sqrt(float64(nx) * float64(ny) / float64(nx+ny))
+		if alt == TwoSided {
+			p = kolmogorovSurvival(en * d)
+		} else {
+			p = math.Exp(-2 * en * en * d * d)
+		}
+	}
+	return Result{
+		Statistic:   d,
+		PValue:      math.Max(0, math.Min(1, p)),
+		Alternative: alt,
+	}
+}
+
+// kolmogorovSurvival returns the survival function of the Kolmogorov
+// distribution, the limiting distribution of √n D.
+func kolmogorovSurvival(t float64) float64 {
+	if t <= 0 {
+		return 1
+	}
+	if t < 1 {
+		// Use the series
+		//  P(K <= t) = √(2π)/t \sum_{k>=1} exp(-(2k-1)²π²/(8t²))
+		// which converges quickly for small t.
+		var s float64
+		for k := 1; k <= 100; k++ {
+			m := float64(2*k - 1)
+			term := math.Exp(-m * m * math.Pi * math.Pi / (8 * t * t))
+			s += term
+			if term <= 1e-17*s {
+				break
+			}
+		}
+		return 1 - math.Sqrt(2*math.Pi)/t*s
+	}
+	// P(K > t) = 2 \sum_{k>=1} (-1)^(k-1) exp(-2k²t²)
+	var s float64
+	sign := 1.0
+	for k := 1; k <= 100; k++ {
+		kf := float64(k)
+		term := math.Exp(-2 * kf * kf * t * t)
+		s += sign * term
+		if term <= 1e-17*s {
+			break
+		}
+		sign = -sign
+	}
+	return math.Max(0, math.Min(1, 2*s))
+}
+
+// smirnovSurvival returns the exact probability that the one-sided
+// Kolmogorov–Smirnov statistic for n samples is at least d, given by the
+// Birnbaum–Tingey formula
+//  P(D+ >= d) = d \sum_{j=0}^{⌊n(1-d)⌋} C(n,j) (1-d-j/n)^(n-j) (d+j/n)^(j-1)
+func smirnovSurvival(n int, d float64) float64 {
+	switch {
+	case d <= 0:
+		return 1
+	case d >= 1:
+		return 0
+	}
+	nf := float64(n)
+	lgn, _ := math.Lgamma(nf + 1)
+	var s float64
+	for j := 0; j <= int(math.Floor(nf*(1-d))); j++ {
+		jf := float64(j)
+		lgj, _ := math.Lgamma(jf + 1)
+		lgnj, _ := math.Lgamma(nf - jf + 1)
+		a := 1 - d - jf/nf
+		if a <= 0 {
+			continue
+		}
+		s += math.Exp(lgn - lgj - lgnj + (nf-jf)*math.Log(a) + (jf-1)*math.Log(d+jf/nf))
+	}
+	return d * s
+}
+
+// kolmogorovCDF returns the exact probability that the two-sided
+// Kolmogorov–Smirnov statistic for n samples is less than d using the
+// method of Marsaglia, Tsang and Wang.
+func kolmogorovCDF(n int, d float64) float64 {
+	switch {
+	case d <= 0:
+		return 0
+	case d >= 1:
+		return 1
+	}
+	nd := float64(n) * d
+	k := int(nd) + 1
+	m := 2*k - 1
+	h := float64(k) - nd
+	hm := mat.NewDense(m, m, nil)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 >= 0 {
+				hm.Set(i, j, 1)
+			}
+		}
+	}
+	for i := 0; i < m; i++ {
+		hm.Set(i, 0, hm.At(i, 0)-math.Pow(h, float64(i+1)))
+		hm.Set(m-1, i, hm.At(m-1, i)-math.Pow(h, float64(m-i)))
+	}
+	if 2*h-1 > 0 {
+		hm.Set(m-1, 0, hm.At(m-1, 0)+math.Pow(2*h-1, float64(m)))
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			if i-j+1 > 0 {
+				lg, _ := math.Lgamma(float64(i - j + 2))
+				hm.Set(i, j, hm.At(i, j)/math.Exp(lg))
+			}
+		}
+	}
+
+	// Raise H to the nth power by repeated squaring, rescaling the
+	// products to avoid overflow.
+	var pow *mat.Dense
+	var logPow float64
+	base := hm
+	var logBase float64
+	for e := n; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			if pow == nil {
+				pow = mat.DenseCopyOf(base)
+				logPow = logBase
+			} else {
+				var prod mat.Dense
+				prod.Mul(pow, base)
+				pow = &prod
+				logPow += logBase + rescale(pow)
+			}
+		}
+		if e > 1 {
+			var sq mat.Dense
+			sq.Mul(base, base)
+			base = &sq
+			logBase = 2*logBase + rescale(base)
+		}
+	}
+	s := pow.At(k-1, k-1)
+	if s <= 0 {
+		return 0
+	}
+	lgn, _ := math.Lgamma(float64(n) + 1)
+	return math.Min(1, math.Exp(math.Log(s)+logPow+lgn-float64(n)*math.Log(float64(n))))
+}
+
+// rescale divides a by its largest absolute element and returns the log of
+// the scale factor.
+func rescale(a *mat.Dense) float64 {
+	max := mat.Max(a)
+	if min := -mat.Min(a); min > max {
+		max = min
+	}
+	if max == 0 {
+		return 0
+	}
+	a.Scale(1/max, a)
+	return math.Log(max)
+}
+
+// twoSampleKSSurvival returns the exact probability under the null
+// hypothesis that the integer two-sample Kolmogorov–Smirnov statistic
+// nx*ny*D for the alternative is at least k.
+func twoSampleKSSurvival(nx, ny, k int, alt Alternative) float64 {
+	if k <= 0 {
+		return 1
+	}
+	hit := func(i, j int) bool {
+		diff := i*ny - j*nx
+		switch alt {
+		case Less:
+			diff = -diff
+		case TwoSided:
+			if diff < 0 {
+				diff = -diff
+			}
+		}
+		return diff >= k
+	}
+	// Propagate the probability of the random interleaving of the samples
+	// along the lattice, absorbing the paths that reach the boundary.
+	cur := make([]float64, ny+1)
+	next := make([]float64, ny+1)
+	cur[0] = 1
+	var p float64
+	for i := 0; i <= nx; i++ {
+		for j := range next {
+			next[j] = 0
+		}
+		for j := 0; j <= ny; j++ {
+			q := cur[j]
+			if q == 0 {
+				continue
+			}
+			if hit(i, j) {
+				p += q
+				continue
+			}
+			rem := float64(nx + ny - i - j)
+			if i < nx {
+				next[j] += q * float64(nx-i) / rem
+			}
+			if j < ny {
+				cur[j+1] += q * float64(ny-j) / rem
+			}
+		}
+		cur, next = next, cur
+	}
+	return p
+}
@@ -0,0 +1,218 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/stat/combin"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func uniformCDF(x float64) float64 {
+	return math.Max(0, math.Min(1, x))
+}
+
+func TestKolmogorovSmirnovStatistic(t *testing.T) {
+	x := []float64{0.9, 0.2, 0.3}
+	for _, test := range []struct {
+		alt  Alternative
+		want float64
+	}{
+		{TwoSided, 1.1 / 3},
+		{Greater, 1.1 / 3},
+		{Less, 0.7 / 3},
+	} {
+		got := KolmogorovSmirnov(x, uniformCDF, test.alt, Auto).Statistic
+		if math.Abs(got-test.want) > 1e-14 {
+			t.Errorf("%v: statistic mismatch: want %v, got %v", test.alt, test.want, got)
+		}
+	}
+}
+
+func TestKolmogorovCDF(t *testing.T) {
+	for _, d := range []float64{0.5, 0.6, 0.75, 0.99} {
+		if got := kolmogorovCDF(1, d); math.Abs(got-(2*d-1)) > 1e-14 {
+			t.Errorf("n=1, d=%v: CDF mismatch: want %v, got %v", d, 2*d-1, got)
+		}
+	}
+	// Critical values at the 5% level.
+	for _, test := range []struct {
+		n int
+		d float64
+	}{
+		{5, 0.56328},
+		{10, 0.40925},
+		{20, 0.29408},
+	} {
+		if got := kolmogorovCDF(test.n, test.d); math.Abs(got-0.95) > 1e-4 {
+			t.Errorf("n=%d: CDF mismatch at critical value: want 0.95, got %v", test.n, got)
+		}
+	}
+	// The exact distribution approaches the asymptotic distribution with
+	// the finite sample correction of Stephens in the upper tail.
+	for _, d := range []float64{0.12, 0.14, 0.16} {
+		exact := 1 - kolmogorovCDF(100, d)
+		asymp := kolmogorovSurvival((10 + 0.12 + 0.011) * d)
+		if math.Abs(exact-asymp) > 2e-3 {
+			t.Errorf("d=%v: exact and asymptotic survival mismatch: %v != %v", d, exact, asymp)
+		}
+	}
+}
+
+func TestKolmogorovSurvival(t *testing.T) {
+	// The two series agree where they are both accurate.
+	for _, x := range []float64{0.8, 0.9, 1, 1.1} {
+		var small float64
+		for k := 1; k <= 100; k++ {
+			m := float64(2*k - 1)
+			small += math.Exp(-m * m * math.Pi * math.Pi / (8 * x * x))
+		}
+		small = 1 - math.Sqrt(2*math.Pi)/x*small
+		var large float64
+		for k := 1; k <= 100; k++ {
+			kf := float64(k)
+			large += 2 * math.Pow(-1, kf-1) * math.Exp(-2*kf*kf*x*x)
+		}
+		if math.Abs(small-large) > 1e-14 {
+			t.Errorf("series mismatch at %v: %v != %v", x, small, large)
+		}
+		if got := kolmogorovSurvival(x); math.Abs(got-large) > 1e-14 {
+			t.Errorf("survival mismatch at %v: want %v, got %v", x, large, got)
+		}
+	}
+}
+
+func TestSmirnovSurvival(t *testing.T) {
+	for _, d := range []float64{0.1, 0.5, 0.9} {
+		if got := smirnovSurvival(1, d); math.Abs(got-(1-d)) > 1e-14 {
+			t.Errorf("n=1, d=%v: survival mismatch: want %v, got %v", d, 1-d, got)
+		}
+	}
+	src := rand.New(rand.NewSource(1))
+	const (
+		n       = 6
+		samples = 100000
+	)
+	ds := []float64{0.2, 0.35, 0.5}
+	counts := make([]float64, len(ds))
+	x := make([]float64, n)
+	for i := 0; i < samples; i++ {
+		for j := range x {
+			x[j] = src.Float64()
+		}
+		dPlus := KolmogorovSmirnov(x, uniformCDF, Greater, Asymptotic).Statistic
+		for k, d := range ds {
+			if dPlus >= d {
+				counts[k]++
+			}
+		}
+	}
+	for k, d := range ds {
+		want := counts[k] / samples
+		if got := smirnovSurvival(n, d); math.Abs(got-want) > 5e-3 {
+			t.Errorf("n=%d, d=%v: survival mismatch: want %v, got %v", n, d, want, got)
+		}
+	}
+}
+
+func TestKolmogorovSmirnov(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 50)
+	for i := range x {
+		x[i] = src.NormFloat64()
+	}
+	cdf := distuv.UnitNormal.CDF
+	for _, alt := range []Alternative{TwoSided, Less, Greater} {
+		exact := KolmogorovSmirnov(x, cdf, alt, Exact)
+		asymp := KolmogorovSmirnov(x, cdf, alt, Asymptotic)
+		if exact.Statistic != asymp.Statistic {
+			t.Errorf("%v: statistic mismatch", alt)
+		}
+		if math.Abs(exact.PValue-asymp.PValue) > 0.05 {
+			t.Errorf("%v: exact and asymptotic p-value mismatch: %v != %v", alt, exact.PValue, asymp.PValue)
+		}
+	}
+	// A shifted sample is rejected.
+	for i := range x {
+		x[i] += 1
+	}
+	if p := KolmogorovSmirnov(x, cdf, TwoSided, Auto).PValue; p > 1e-4 {
+		t.Errorf("shifted sample not rejected: p-value %v", p)
+	}
+	if p := KolmogorovSmirnov(x, cdf, Less, Auto).PValue; p > 1e-4 {
+		t.Errorf("shifted sample not rejected: p-value %v", p)
+	}
+}
+
+func TestTwoSampleKolmogorovSmirnov(t *testing.T) {
+	// Compare the exact distribution with enumeration of the orderings
+	// of the combined samples.
+	for _, test := range []struct {
+		x, y []float64
+	}{
+		{
+			x: []float64{0.1, 0.5, 0.7},
+			y: []float64{0.2, 0.3, 0.4, 0.8},
+		},
+		{
+			x: []float64{1, 2, 3, 4, 5, 6},
+			y: []float64{3.5, 4.5, 7, 8, 9},
+		},
+		{
+			x: []float64{5, 6, 7, 8},
+			y: []float64{1, 2, 3, 4},
+		},
+	} {
+		nx, ny := len(test.x), len(test.y)
+		for _, alt := range []Alternative{TwoSided, Less, Greater} {
+			got := TwoSampleKolmogorovSmirnov(test.x, test.y, alt, Exact)
+			var count, total float64
+			for _, c := range combin.Combinations(nx+ny, nx) {
+				perm := make([]float64, nx+ny)
+				for _, pos := range c {
+					perm[pos] = 1
+				}
+				var px, py []float64
+				for i, v := range perm {
+					if v == 1 {
+						px = append(px, float64(i))
+					} else {
+						py = append(py, float64(i))
+					}
+				}
+				d := TwoSampleKolmogorovSmirnov(px, py, alt, Asymptotic).Statistic
+				if d >= got.Statistic-1e-12 {
+					count++
+				}
+				total++
+			}
+			want := count / total
+			if math.Abs(got.PValue-want) > 1e-12 {
+				t.Errorf("%v, %v, %v: p-value mismatch: want %v, got %v", test.x, test.y, alt, want, got.PValue)
+			}
+		}
+	}
+
+	// The exact distribution approaches the asymptotic distribution.
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 100)
+	y := make([]float64, 90)
+	for i := range x {
+		x[i] = src.NormFloat64() + 0.2
+	}
+	for i := range y {
+		y[i] = src.NormFloat64()
+	}
+	for _, alt := range []Alternative{TwoSided, Less, Greater} {
+		exact := TwoSampleKolmogorovSmirnov(x, y, alt, Exact)
+		asymp := TwoSampleKolmogorovSmirnov(x, y, alt, Asymptotic)
+		if math.Abs(exact.PValue-asymp.PValue) > 0.03 {
+			t.Errorf("%v: exact and asymptotic p-value mismatch: %v != %v", alt, exact.PValue, asymp.PValue)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// MannWhitneyU performs the Mann–Whitney U test, also known as the Wilcoxon
+// rank-sum test, of the null hypothesis that the populations from which x
+// and y are drawn are identical. For the Greater alternative, values drawn
+// from the population of x tend to be larger than those from y. The
+// statistic is
+//  U = \sum_{i,j} [x_i > y_j] + 0.5 [x_i = y_j]
+// the number of pairs in which the value from x is the larger.
+//
+// If method is Auto, the exact null distribution of U is used when both
+// samples have fewer than 50 values and there are no ties, and the normal
+// approximation with tie and continuity corrections is used otherwise. The
+// exact distribution does not account for ties.
+//
+// MannWhitneyU panics if x or y is empty.
+func MannWhitneyU(x, y []float64, alt Alternative, method Method) Result {
+	nx, ny := len(x), len(y)
+	if nx == 0 || ny == 0 {
+		panic(tooFewSamples)
+	}
+	all := make([]float64, 0, nx+ny)
+	all = append(all, x...)
+	all = append(all, y...)
+	ranks, ties := rank(all)
+	u := floats.Sum(ranks[:nx]) - float64(nx*(nx+1))/2
+
+	var lower, upper float64
+	if useExact(method, nx < 50 && ny < 50 && ties == 0) {
+		lower, upper = exactTails(mannWhitneyCounts(nx, ny), u)
+	} else {
+		n := float64(nx + ny)
+		mu := float64(nx*ny) / 2
+		sigma := math.Sqrt(float64(nx*ny) / 12 * (n + 1 - ties/(n*(n-1))))
+		lower, upper = normalTails(u, mu, sigma)
+	}
+	return Result{
+		Statistic:   u,
+		PValue:      pValue(alt, lower, upper),
+		Alternative: alt,
+	}
+}
+
+// WilcoxonSignedRank performs the Wilcoxon signed-rank test of the null
+// hypothesis that the distribution of the differences x[i]-y[i] is
+// symmetric about zero. If y is nil, the differences are the values of x.
+// For the Greater alternative, the differences tend to be positive. The
+// statistic is the sum of the ranks of the absolute values of the positive
+// differences. Zero differences are discarded.
+//
+// If method is Auto, the exact null distribution of the statistic is used
+// when there are fewer than 50 differences and there are no ties or zero
+// differences, and the normal approximation with tie and continuity
+// corrections is used otherwise. The exact distribution does not account
+// for ties.
+//
+// WilcoxonSignedRank panics if y is not nil and len(x) != len(y), or if
+// all the differences are zero.
+func WilcoxonSignedRank(x, y []float64, alt Alternative, method Method) Result {
+	if y != nil && len(x) != len(y) {
+		panic(badLength)
+	}
+	var d []float64
+	for i, v := range x {
+		if y != nil {
+			v -= y[i]
+		}
+		if v != 0 {
+			d = append(d, v)
+		}
+	}
+	n := len(d)
+	if n == 0 {
+		panic(tooFewSamples)
+	}
+	abs := make([]float64, n)
+	for i, v := range d {
+		abs[i] = math.Abs(v)
+	}
+	ranks, ties := rank(abs)
+	var v float64
+	for i, r := range ranks {
+		if d[i] > 0 {
+			v += r
+		}
+	}
+
+	var lower, upper float64
+	if useExact(method, n < 50 && ties == 0 && n == len(x)) {
+		lower, upper = exactTails(signedRankCounts(n), v)
+	} else {
+		nf := float64(n)
+		mu := nf * (nf + 1) / 4
+		sigma := math.Sqrt(nf*(nf+1)*(2*nf+1)/24 - ties/48)
+		lower, upper = normalTails(v, mu, sigma)
+	}
+	return Result{
+		Statistic:   v,
+		PValue:      pValue(alt, lower, upper),
+		Alternative: alt,
+	}
+}
+
+// useExact returns whether the exact null distribution should be used for
+// the method, where auto is the choice made for the Auto method.
+func useExact(method Method, auto bool) bool {
+	switch method {
+	case Auto:
+		return auto
+	case Exact:
+		return true
+	case Asymptotic:
+		return false
+	}
+	panic(badMethod)
+}
+
+// exactTails returns the probabilities of a statistic at most and at least
+// s for a null distribution on the integers with relative frequencies given
+// by counts.
+func exactTails(counts []float64, s float64) (lower, upper float64) {
+	total := floats.Sum(counts)
+	for i, c := range counts {
+		if float64(i) <= s {
+			lower += c
+		}
+		if float64(i) >= s {
+			upper += c
+		}
+	}
+	return lower / total, upper / total
+}
+
+// normalTails returns the probabilities of a statistic at most and at least
+// s under a normal approximation to a discrete null distribution with mean
+// mu and standard deviation sigma, using a continuity correction.
+func normalTails(s, mu, sigma float64) (lower, upper float64) {
+	return distuv.UnitNormal.CDF((s - mu + 0.5) / sigma), distuv.UnitNormal.Survival((s - mu - 0.5) / sigma)
+}
+
+// mannWhitneyCounts returns the number of arrangements of m values from one
+// sample and n from another for which the Mann–Whitney statistic takes each
+// of the values 0, 1, ..., mn.
+func mannWhitneyCounts(m, n int) []float64 {
+	// The number of arrangements f_{i,j}(u) of i and j values satisfies
+	//  f_{i,j}(u) = f_{i-1,j}(u-j) + f_{i,j-1}(u)
+	// according to whether the largest value is from the first or second
+	// sample.
+	prev := make([][]float64, n+1)
+	for j := range prev {
+		prev[j] = []float64{1}
+	}
+	for i := 1; i <= m; i++ {
+		cur := make([][]float64, n+1)
+		cur[0] = []float64{1}
+		for j := 1; j <= n; j++ {
+			f := make([]float64, i*j+1)
+			for u, c := range prev[j] {
+				f[u+j] += c
+			}
+			for u, c := range cur[j-1] {
+				f[u] += c
+			}
+			cur[j] = f
+		}
+		prev = cur
+	}
+	return prev[n]
+}
+
+// signedRankCounts returns the number of subsets of {1, ..., n} with each of
+// the sums 0, 1, ..., n(n+1)/2.
+func signedRankCounts(n int) []float64 {
+	counts := make([]float64, n*(n+1)/2+1)
+	counts[0] = 1
+	max := 0
+	for k := 1; k <= n; k++ {
+		max += k
+		for s := max; s >= k; s-- {
+			counts[s] += counts[s-k]
+		}
+	}
+	return counts
+}
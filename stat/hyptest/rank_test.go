@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/stat/combin"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	// Reference values from R.
+	x := []float64{0.80, 0.83, 1.89, 1.04, 1.45, 1.38, 1.91, 1.64, 0.73, 1.46}
+	y := []float64{1.15, 0.88, 0.90, 0.74, 1.21}
+	checkResult(t, "greater", MannWhitneyU(x, y, Greater, Auto), 35, 0.1272, 0, 1e-3)
+	checkResult(t, "two-sided", MannWhitneyU(x, y, TwoSided, Auto), 35, 0.2544, 0, 1e-3)
+	checkResult(t, "less", MannWhitneyU(y, x, Less, Auto), 15, 0.1272, 0, 1e-3)
+
+	// The exact and asymptotic p-values agree for large samples.
+	src := rand.New(rand.NewSource(1))
+	x = make([]float64, 40)
+	y = make([]float64, 45)
+	for i := range x {
+		x[i] = src.NormFloat64() + 0.3
+	}
+	for i := range y {
+		y[i] = src.NormFloat64()
+	}
+	for _, alt := range []Alternative{TwoSided, Less, Greater} {
+		exact := MannWhitneyU(x, y, alt, Exact)
+		asymp := MannWhitneyU(x, y, alt, Asymptotic)
+		if exact.Statistic != asymp.Statistic {
+			t.Errorf("%v: statistic mismatch", alt)
+		}
+		if math.Abs(exact.PValue-asymp.PValue) > 5e-3 {
+			t.Errorf("%v: exact and asymptotic p-value mismatch: %v != %v", alt, exact.PValue, asymp.PValue)
+		}
+	}
+}
+
+func TestMannWhitneyCounts(t *testing.T) {
+	for m := 1; m <= 5; m++ {
+		for n := 1; n <= 5; n++ {
+			// Enumerate the positions of the first sample in the
+			// ordered combined sample.
+			want := make([]float64, m*n+1)
+			for _, c := range combin.Combinations(m+n, m) {
+				var u int
+				for i, pos := range c {
+					u += pos - i
+				}
+				want[u]++
+			}
+			got := mannWhitneyCounts(m, n)
+			if len(got) != len(want) {
+				t.Errorf("m=%d, n=%d: length mismatch", m, n)
+				continue
+			}
+			for u := range got {
+				if got[u] != want[u] {
+					t.Errorf("m=%d, n=%d: count mismatch: want %v, got %v", m, n, want, got)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestWilcoxonSignedRank(t *testing.T) {
+	// Reference values from R.
+	x := []float64{1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30}
+	y := []float64{0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29}
+	checkResult(t, "greater", WilcoxonSignedRank(x, y, Greater, Auto), 40, 0.01953, 0, 1e-3)
+	checkResult(t, "two-sided", WilcoxonSignedRank(x, y, TwoSided, Auto), 40, 0.03906, 0, 1e-3)
+
+	d := make([]float64, len(x))
+	for i := range d {
+		d[i] = x[i] - y[i]
+	}
+	if got := WilcoxonSignedRank(d, nil, Greater, Auto); got != WilcoxonSignedRank(x, y, Greater, Auto) {
+		t.Errorf("one-sample and paired results mismatch")
+	}
+
+	// The exact and asymptotic p-values agree for large samples.
+	src := rand.New(rand.NewSource(1))
+	d = make([]float64, 45)
+	for i := range d {
+		d[i] = src.NormFloat64() + 0.2
+	}
+	for _, alt := range []Alternative{TwoSided, Less, Greater} {
+		exact := WilcoxonSignedRank(d, nil, alt, Exact)
+		asymp := WilcoxonSignedRank(d, nil, alt, Asymptotic)
+		if math.Abs(exact.PValue-asymp.PValue) > 5e-3 {
+			t.Errorf("%v: exact and asymptotic p-value mismatch: %v != %v", alt, exact.PValue, asymp.PValue)
+		}
+	}
+
+	if !panics(func() { WilcoxonSignedRank([]float64{1, 2}, []float64{1, 2}, TwoSided, Auto) }) {
+		t.Errorf("expected panic for zero differences")
+	}
+}
+
+func TestSignedRankCounts(t *testing.T) {
+	for n := 1; n <= 10; n++ {
+		want := make([]float64, n*(n+1)/2+1)
+		for mask := 0; mask < 1<<uint(n); mask++ {
+			var s int
+			for k := 0; k < n; k++ {
+				if mask&(1<<uint(k)) != 0 {
+					s += k + 1
+				}
+			}
+			want[s]++
+		}
+		got := signedRankCounts(n)
+		for s := range got {
+			if got[s] != want[s] {
+				t.Errorf("n=%d: count mismatch: want %v, got %v", n, want, got)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// ShapiroWilk performs the Shapiro–Wilk test of the null hypothesis that x
+// is drawn from a normal distribution. The statistic is
+//  W = (\sum_i a_i x_(i))^2 / \sum_i (x_i - mean(x))^2
+// where x_(i) are the ordered values and a_i are the coefficients of the
+// best linear unbiased estimate of the standard deviation from normal order
+// statistics. Small values of W indicate departure from normality.
+//
+// The coefficients and the p-value are computed with the approximations of
+//  Royston, P. "Remark AS R94: A remark on algorithm AS 181: The W-test for
+//  normality." Journal of the Royal Statistical Society. Series C (Applied
+//  Statistics) 44.4 (1995): 547-551.
+// which are valid for 3 <= len(x) <= 5000.
+//
+// ShapiroWilk panics if len(x) < 3 or len(x) > 5000, or if all the values
+// of x are equal.
+func ShapiroWilk(x []float64) Result {
+	n := len(x)
+	if n < 3 {
+		panic(tooFewSamples)
+	}
+	if n > 5000 {
+		panic("hyptest: too many samples")
+	}
+	s := sorted(x)
+	if s[0] == s[n-1] {
+		panic("hyptest: zero range")
+	}
+
+	a := shapiroWilkCoeffs(n)
+	var mean float64
+	for _, v := range s {
+		mean += v
+	}
+	mean /= float64(n)
+	var num, ss float64
+	for i, c := range a {
+		num += c * (s[n-1-i] - s[i])
+	}
+	for _, v := range s {
+		d := v - mean
+		ss += d * d
+	}
+	w := math.Min(1, num*num/ss)
+	return Result{
+		Statistic:   w,
+		PValue:      shapiroWilkPValue(w, n),
+		Alternative: Less,
+	}
+}
+
+// poly evaluates the polynomial with coefficients c in increasing order at x.
+func poly(c []float64, x float64) float64 {
+	var y float64
+	for i := len(c) - 1; i >= 0; i-- {
+		y = y*x + c[i]
+	}
+	return y
+}
+
+// shapiroWilkCoeffs returns the first n/2 coefficients of the Shapiro–Wilk
+// statistic for n values. The remaining coefficients are antisymmetric.
+func shapiroWilkCoeffs(n int) []float64 {
+	nn2 := n / 2
+	a := make([]float64, nn2)
+	if n == 3 {
+		a[0] = math.Sqrt2 / 2
+		return a
+	}
+	c1 := []float64{0, 0.221157, -0.147981, -2.07119, 4.434685, -2.706056}
+	c2 := []float64{0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633}
+
+	an := float64(n)
+	m := make([]float64, nn2)
+	var summ2 float64
+	for i := range m {
+		m[i] = mathext.NormalQuantile((float64(i+1) - 0.375) / (an + 0.25))
+		summ2 += m[i] * m[i]
+	}
+	summ2 *= 2
+	ssumm2 := math.Sqrt(summ2)
+	rsn := 1 / math.Sqrt(an)
+	a1 := poly(c1, rsn) - m[0]/ssumm2
+
+	i1 := 1
+	var fac float64
+	if n > 5 {
+		i1 = 2
+		a2 := -m[1]/ssumm2 + poly(c2, rsn)
+		fac = math.Sqrt((summ2 - 2*m[0]*m[0] - 2*m[1]*m[1]) / (1 - 2*a1*a1 - 2*a2*a2))
+		a[1] = a2
+	} else {
+		fac = math.Sqrt((summ2 - 2*m[0]*m[0]) / (1 - 2*a1*a1))
+	}
+	a[0] = a1
+	for i := i1; i < nn2; i++ {
+		a[i] = -m[i] / fac
+	}
+	return a
+}
+
+// shapiroWilkPValue returns the p-value of the Shapiro–Wilk statistic w for
+// n values.
+func shapiroWilkPValue(w float64, n int) float64 {
+	if n == 3 {
+		// The exact distribution for three values.
+		const (
+			pi6  = 6 / math.Pi
+			stqr = math.Pi / 3
+		)
+		return math.Max(0, math.Min(1, pi6*(math.Asin(math.Sqrt(w))-stqr)))
+	}
+	an := float64(n)
+	y := math.Log(1 - w)
+	var mu, sigma float64
+	if n <= 11 {
+		gamma := -2.273 + 0.459*an
+		if y >= gamma {
+			return 0
+		}
+		y = -math.Log(gamma - y)
+		mu = poly([]float64{0.544, -0.39978, 0.025054, -6.714e-4}, an)
+		sigma = math.Exp(poly([]float64{1.3822, -0.77857, 0.062767, -0.0020322}, an))
+	} else {
+		xx := math.Log(an)
+		mu = poly([]float64{-1.5861, -0.31082, -0.083751, 0.0038915}, xx)
+		sigma = math.Exp(poly([]float64{-0.4803, -0.082676, 0.0030302}, xx))
+	}
+	return distuv.Normal{Mu: mu, Sigma: sigma}.Survival(y)
+}
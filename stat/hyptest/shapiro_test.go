@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestShapiroWilk(t *testing.T) {
+	// Equally spaced values are the most normal sample of size three.
+	got := ShapiroWilk([]float64{1, 2, 3})
+	if math.Abs(got.Statistic-1) > 1e-14 || math.Abs(got.PValue-1) > 1e-14 {
+		t.Errorf("unexpected result for equally spaced values: %+v", got)
+	}
+
+	// Weights of 11 men from Shapiro and Wilk (1965), who report W = 0.79.
+	got = ShapiroWilk([]float64{148, 154, 158, 160, 161, 162, 166, 170, 182, 195, 236})
+	if math.Abs(got.Statistic-0.79) > 0.01 {
+		t.Errorf("statistic mismatch: want 0.79, got %v", got.Statistic)
+	}
+	if got.PValue > 0.01 {
+		t.Errorf("non-normal sample not rejected: p-value %v", got.PValue)
+	}
+
+	// The p-values are uniformly distributed for normal samples, and small
+	// for exponential samples.
+	src := rand.New(rand.NewSource(1))
+	for _, n := range []int{5, 20, 100} {
+		const reps = 4000
+		x := make([]float64, n)
+		var rejectNormal, rejectExp int
+		for r := 0; r < reps; r++ {
+			for i := range x {
+				x[i] = src.NormFloat64()
+			}
+			if ShapiroWilk(x).PValue < 0.05 {
+				rejectNormal++
+			}
+			for i := range x {
+				x[i] = src.ExpFloat64()
+			}
+			if ShapiroWilk(x).PValue < 0.05 {
+				rejectExp++
+			}
+		}
+		if rate := float64(rejectNormal) / reps; math.Abs(rate-0.05) > 0.012 {
+			t.Errorf("n=%d: rejection rate for normal samples far from 0.05: %v", n, rate)
+		}
+		if n == 100 && rejectExp != reps {
+			t.Errorf("n=%d: exponential samples not rejected: rate %v", n, float64(rejectExp)/reps)
+		}
+	}
+
+	if !panics(func() { ShapiroWilk([]float64{1, 2}) }) {
+		t.Errorf("expected panic for too few samples")
+	}
+	if !panics(func() { ShapiroWilk([]float64{1, 1, 1}) }) {
+		t.Errorf("expected panic for zero range")
+	}
+}
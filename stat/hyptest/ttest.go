@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// TTest performs the one-sample Student's t-test of the null hypothesis that
+// the mean of the population from which x is drawn is mu. The statistic is
+//  t = (mean(x) - mu) / (s / √n)
+// where s is the sample standard deviation, which is t-distributed with n-1
+// degrees of freedom under the null hypothesis.
+//
+// TTest panics if len(x) < 2.
+func TTest(x []float64, mu float64, alt Alternative) Result {
+	if len(x) < 2 {
+		panic(tooFewSamples)
+	}
+	n := float64(len(x))
+	mean, variance := stat.MeanVariance(x, nil)
+	t := (mean - mu) / math.Sqrt(variance/n)
+	return tResult(t, n-1, alt)
+}
+
+// PairedTTest performs the paired Student's t-test of the null hypothesis
+// that the mean of the differences x[i]-y[i] is zero. It is equivalent to
+// the one-sample t-test of the differences.
+//
+// PairedTTest panics if len(x) != len(y) or len(x) < 2.
+func PairedTTest(x, y []float64, alt Alternative) Result {
+	if len(x) != len(y) {
+		panic(badLength)
+	}
+	d := make([]float64, len(x))
+	for i, v := range x {
+		d[i] = v - y[i]
+	}
+	return TTest(d, 0, alt)
+}
+
+// TwoSampleTTest performs Student's two-sample t-test of the null hypothesis
+// that the means of the populations from which x and y are drawn are equal,
+// assuming that the populations have equal variance. The statistic is
+//  t = (mean(x) - mean(y)) / (s_p √(1/n_x + 1/n_y))
+// where s_p is the pooled standard deviation, which is t-distributed with
+// n_x+n_y-2 degrees of freedom under the null hypothesis.
+//
+// TwoSampleTTest panics if len(x) < 2 or len(y) < 2.
+func TwoSampleTTest(x, y []float64, alt Alternative) Result {
+	if len(x) < 2 || len(y) < 2 {
+		panic(tooFewSamples)
+	}
+	nx, ny := float64(len(x)), float64(len(y))
+	mx, vx := stat.MeanVariance(x, nil)
+	my, vy := stat.MeanVariance(y, nil)
+	dof := nx + ny - 2
+	pooled := ((nx-1)*vx + (ny-1)*vy) / dof
+	t := (mx - my) / math.Sqrt(pooled*(1/nx+1/ny))
+	return tResult(t, dof, alt)
+}
+
+// WelchTTest performs Welch's two-sample t-test of the null hypothesis that
+// the means of the populations from which x and y are drawn are equal,
+// without assuming that the populations have equal variance. The statistic
+//  t = (mean(x) - mean(y)) / √(s_x²/n_x + s_y²/n_y)
+// is approximately t-distributed under the null hypothesis with degrees of
+// freedom given by the Welch–Satterthwaite equation.
+//
+// WelchTTest panics if len(x) < 2 or len(y) < 2.
+func WelchTTest(x, y []float64, alt Alternative) Result {
+	if len(x) < 2 || len(y) < 2 {
+		panic(tooFewSamples)
+	}
+	nx, ny := float64(len(x)), float64(len(y))
+	mx, vx := stat.MeanVariance(x, nil)
+	my, vy := stat.MeanVariance(y, nil)
+	sx := vx / nx
+	sy := vy / ny
+	t := (mx - my) / math.Sqrt(sx+sy)
+	dof := (sx + sy) * (sx + sy) / (sx*sx/(nx-1) + sy*sy/(ny-1))
+	return tResult(t, dof, alt)
+}
+
+// tResult returns the result of a test with a t-distributed statistic.
+func tResult(t, dof float64, alt Alternative) Result {
+	dist := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: dof}
+	return Result{
+		Statistic:   t,
+		PValue:      pValue(alt, dist.CDF(t), dist.Survival(t)),
+		DoF:         dof,
+		Alternative: alt,
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hyptest
+
+import (
+	"math"
+	"testing"
+)
+
+// Student's sleep data.
+var (
+	sleep1 = []float64{0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0}
+	sleep2 = []float64{1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4}
+)
+
+func TestTTest(t *testing.T) {
+	// Reference values from R.
+	checkResult(t, "Welch", WelchTTest(sleep1, sleep2, TwoSided), -1.8608, 0.07939, 17.776, 1e-4)
+	checkResult(t, "pooled", TwoSampleTTest(sleep1, sleep2, TwoSided), -1.8608, 0.07919, 18, 1e-4)
+	checkResult(t, "paired", PairedTTest(sleep1, sleep2, TwoSided), -4.0621, 0.002833, 9, 1e-4)
+
+	// One-sided p-values are halves of the two-sided p-value.
+	two := PairedTTest(sleep1, sleep2, TwoSided).PValue
+	if got := PairedTTest(sleep1, sleep2, Less).PValue; math.Abs(got-two/2) > 1e-14 {
+		t.Errorf("one-sided p-value mismatch: want %v, got %v", two/2, got)
+	}
+	if got := PairedTTest(sleep1, sleep2, Greater).PValue; math.Abs(got-(1-two/2)) > 1e-14 {
+		t.Errorf("one-sided p-value mismatch: want %v, got %v", 1-two/2, got)
+	}
+	// The paired test is the one-sample test of the differences.
+	d := make([]float64, len(sleep1))
+	for i := range d {
+		d[i] = sleep2[i] - sleep1[i]
+	}
+	checkResult(t, "one-sample", TTest(d, 0, TwoSided), 4.0621, 0.002833, 9, 1e-4)
+
+	if !panics(func() { TTest([]float64{1}, 0, TwoSided) }) {
+		t.Errorf("expected panic for too few samples")
+	}
+	if !panics(func() { PairedTTest(sleep1, sleep2[1:], TwoSided) }) {
+		t.Errorf("expected panic for length mismatch")
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Covariance accumulates the weighted mean and covariance matrix of a stream
+// of vectors.
+type Covariance struct {
+	dim  int
+	w    float64
+	mean []float64
+
+	// comom is the weighted sum of the outer products of the deviations
+	// from the mean.
+	comom *mat.SymDense
+
+	diff []float64
+}
+
+// NewCovariance returns an empty accumulator of vectors of length dim.
+// NewCovariance panics if dim is not positive.
+func NewCovariance(dim int) *Covariance {
+	if dim <= 0 {
+		panic(nonPosDim)
+	}
+	return &Covariance{
+		dim:   dim,
+		mean:  make([]float64, dim),
+		comom: mat.NewSymDense(dim, nil),
+		diff:  make([]float64, dim),
+	}
+}
+
+// Add adds the vector x with unit weight to the accumulator. Add panics if
+// len(x) is not equal to the dimension of the accumulator.
+func (c *Covariance) Add(x []float64) {
+	c.AddWeighted(x, 1)
+}
+
+// AddWeighted adds the vector x with the given weight to the accumulator.
+// AddWeighted panics if len(x) is not equal to the dimension of the
+// accumulator or if the weight is negative.
+func (c *Covariance) AddWeighted(x []float64, weight float64) {
+	if len(x) != c.dim {
+		panic(badLength)
+	}
+	if weight < 0 {
+		panic(badWeight)
+	}
+	if weight == 0 {
+		return
+	}
+	w := c.w + weight
+	floats.SubTo(c.diff, x, c.mean)
+	floats.AddScaled(c.mean, weight/w, c.diff)
+	c.comom.SymRankOne(c.comom, weight*c.w/w, mat.NewVecDense(c.dim, c.diff))
+	c.w = w
+}
+
+// CorrelationMatrix returns the correlation matrix of the vectors. If dst is
+// nil, a new matrix is allocated, otherwise the result is stored in-place
+// into dst, which must have the dimension of the accumulator.
+func (c *Covariance) CorrelationMatrix(dst *mat.SymDense) *mat.SymDense {
+	dst = c.CovarianceMatrix(dst)
+	for i := 0; i < c.dim; i++ {
+		c.diff[i] = 1 / math.Sqrt(dst.At(i, i))
+	}
+	for i := 0; i < c.dim; i++ {
+		for j := i; j < c.dim; j++ {
+			dst.SetSym(i, j, dst.At(i, j)*c.diff[i]*c.diff[j])
+		}
+	}
+	return dst
+}
+
+// CovarianceMatrix returns the unbiased weighted covariance matrix of the
+// vectors, as computed by stat.CovarianceMatrix. If dst is nil, a new matrix
+// is allocated, otherwise the result is stored in-place into dst, which must
+// have the dimension of the accumulator.
+func (c *Covariance) CovarianceMatrix(dst *mat.SymDense) *mat.SymDense {
+	if dst == nil {
+		dst = mat.NewSymDense(c.dim, nil)
+	} else if dst.Symmetric() != c.dim {
+		panic(badLength)
+	}
+	dst.ScaleSym(1/(c.w-1), c.comom)
+	return dst
+}
+
+// Dim returns the dimension of the vectors.
+func (c *Covariance) Dim() int {
+	return c.dim
+}
+
+// Mean returns the weighted mean of the vectors. If the input slice is nil,
+// a new slice will be allocated, otherwise the result is stored in-place
+// into dst.
+func (c *Covariance) Mean(dst []float64) []float64 {
+	if dst == nil {
+		dst = make([]float64, c.dim)
+	}
+	if len(dst) != c.dim {
+		panic(badLength)
+	}
+	copy(dst, c.mean)
+	return dst
+}
+
+// Merge adds the vectors accumulated by o to the receiver. Merge panics if
+// the dimensions of the accumulators differ.
+func (c *Covariance) Merge(o *Covariance) {
+	if o.dim != c.dim {
+		panic(badLength)
+	}
+	if o.w == 0 {
+		return
+	}
+	w := c.w + o.w
+	floats.SubTo(c.diff, o.mean, c.mean)
+	c.comom.AddSym(c.comom, o.comom)
+	c.comom.SymRankOne(c.comom, c.w*o.w/w, mat.NewVecDense(c.dim, c.diff))
+	floats.AddScaled(c.mean, o.w/w, c.diff)
+	c.w = w
+}
+
+// Reset removes all the vectors from the accumulator.
+func (c *Covariance) Reset() {
+	c.w = 0
+	for i := range c.mean {
+		c.mean[i] = 0
+	}
+	c.comom.ScaleSym(0, c.comom)
+}
+
+// Weight returns the sum of the weights of the vectors added.
+func (c *Covariance) Weight() float64 {
+	return c.w
+}
@@ -0,0 +1,93 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestCovariance(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n, dim = 500, 3
+	x := mat.NewDense(n, dim, nil)
+	for i := 0; i < n; i++ {
+		z := src.NormFloat64()
+		x.Set(i, 0, 100+z)
+		x.Set(i, 1, -50+2*z+src.NormFloat64())
+		x.Set(i, 2, src.ExpFloat64())
+	}
+	for _, weights := range [][]float64{nil, make([]float64, n)} {
+		for i := range weights {
+			weights[i] = 2 * src.Float64()
+		}
+		c := NewCovariance(dim)
+		for i := 0; i < n; i++ {
+			if weights == nil {
+				c.Add(x.RawRowView(i))
+			} else {
+				c.AddWeighted(x.RawRowView(i), weights[i])
+			}
+		}
+
+		mean := make([]float64, dim)
+		for j := range mean {
+			mean[j] = stat.Mean(mat.Col(nil, j, x), weights)
+		}
+		if !floats.EqualApprox(c.Mean(nil), mean, 1e-10) {
+			t.Errorf("Mean mismatch: want %v, got %v", mean, c.Mean(nil))
+		}
+		want := stat.CovarianceMatrix(nil, x, weights)
+		if got := c.CovarianceMatrix(nil); !mat.EqualApprox(got, want, 1e-10) {
+			t.Errorf("CovarianceMatrix mismatch: want %v, got %v", mat.Formatted(want), mat.Formatted(got))
+		}
+		wantCorr := stat.CorrelationMatrix(nil, x, weights)
+		if got := c.CorrelationMatrix(nil); !mat.EqualApprox(got, wantCorr, 1e-10) {
+			t.Errorf("CorrelationMatrix mismatch: want %v, got %v", mat.Formatted(wantCorr), mat.Formatted(got))
+		}
+	}
+}
+
+func TestCovarianceMerge(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n, dim = 300, 2
+	x := mat.NewDense(n, dim, nil)
+	for i := 0; i < n; i++ {
+		x.Set(i, 0, src.NormFloat64())
+		x.Set(i, 1, x.At(i, 0)+src.NormFloat64())
+	}
+	whole := NewCovariance(dim)
+	for i := 0; i < n; i++ {
+		whole.Add(x.RawRowView(i))
+	}
+
+	merged := NewCovariance(dim)
+	for _, bounds := range [][2]int{{0, 0}, {0, 1}, {1, 120}, {120, 300}} {
+		part := NewCovariance(dim)
+		for i := bounds[0]; i < bounds[1]; i++ {
+			part.Add(x.RawRowView(i))
+		}
+		merged.Merge(part)
+	}
+	if merged.Weight() != whole.Weight() {
+		t.Errorf("Weight mismatch: want %v, got %v", whole.Weight(), merged.Weight())
+	}
+	if !floats.EqualApprox(merged.Mean(nil), whole.Mean(nil), 1e-12) {
+		t.Errorf("Mean mismatch after merge: want %v, got %v", whole.Mean(nil), merged.Mean(nil))
+	}
+	if !mat.EqualApprox(merged.CovarianceMatrix(nil), whole.CovarianceMatrix(nil), 1e-12) {
+		t.Errorf("CovarianceMatrix mismatch after merge: want %v, got %v",
+			mat.Formatted(whole.CovarianceMatrix(nil)), mat.Formatted(merged.CovarianceMatrix(nil)))
+	}
+
+	merged.Reset()
+	if merged.Weight() != 0 || floats.Norm(merged.Mean(nil), 1) != 0 {
+		t.Errorf("Reset did not empty the accumulator")
+	}
+}
@@ -0,0 +1,12 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package online provides accumulators of statistics over streams of data.
+//
+// The accumulators update their statistics in constant time and memory per
+// sample, so the full sample never needs to be held in memory. Accumulators
+// are not safe for concurrent use, but those with a Merge method may be
+// used to accumulate disjoint parts of a stream in separate goroutines and
+// then be combined.
+package online // import "gonum.org/v1/gonum/stat/online"
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import "math"
+
+// ExpWeighted accumulates the exponentially weighted moving mean and
+// variance of a stream of values. After each value x is added the
+// statistics are updated by
+//  δ = x - mean
+//  mean += α δ
+//  variance = (1-α) (variance + α δ²)
+// as described in
+//  Finch, T. "Incremental calculation of weighted mean and variance."
+//  University of Cambridge Computing Service (2009).
+// The first value initializes the mean, with zero variance.
+//
+// The statistics depend on the order of the values, so ExpWeighted
+// accumulators cannot be merged.
+type ExpWeighted struct {
+	alpha    float64
+	mean     float64
+	variance float64
+	n        int
+}
+
+// NewExpWeighted returns an empty accumulator with smoothing factor alpha,
+// the weight given to the most recent value. A smoothing factor corresponding
+// to a half-life of h values is 1 - 2^(-1/h). NewExpWeighted panics if alpha
+// is not in (0, 1].
+func NewExpWeighted(alpha float64) *ExpWeighted {
+	if !(alpha > 0 && alpha <= 1) {
+		panic(badAlpha)
+	}
+	return &ExpWeighted{alpha: alpha}
+}
+
+// Add adds the value x to the accumulator.
+func (e *ExpWeighted) Add(x float64) {
+	e.n++
+	if e.n == 1 {
+		e.mean = x
+		return
+	}
+	diff := x - e.mean
+	incr := e.alpha * diff
+	e.mean += incr
+	e.variance = (1 - e.alpha) * (e.variance + diff*incr)
+}
+
+// Alpha returns the smoothing factor of the accumulator.
+func (e *ExpWeighted) Alpha() float64 {
+	return e.alpha
+}
+
+// Count returns the number of values added.
+func (e *ExpWeighted) Count() int {
+	return e.n
+}
+
+// Mean returns the exponentially weighted mean of the values, or NaN if no
+// values have been added.
+func (e *ExpWeighted) Mean() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+	return e.mean
+}
+
+// Reset removes all the values from the accumulator.
+func (e *ExpWeighted) Reset() {
+	e.mean = 0
+	e.variance = 0
+	e.n = 0
+}
+
+// StdDev returns the exponentially weighted standard deviation of the
+// values.
+func (e *ExpWeighted) StdDev() float64 {
+	return math.Sqrt(e.Variance())
+}
+
+// Variance returns the exponentially weighted variance of the values, or
+// NaN if no values have been added.
+func (e *ExpWeighted) Variance() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+	return e.variance
+}
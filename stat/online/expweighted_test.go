@@ -0,0 +1,70 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestExpWeighted(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, alpha := range []float64{0.01, 0.1, 0.5, 1} {
+		e := NewExpWeighted(alpha)
+		if !math.IsNaN(e.Mean()) || !math.IsNaN(e.Variance()) {
+			t.Errorf("Statistics of empty accumulator not NaN")
+		}
+		x := make([]float64, 200)
+		for i := range x {
+			x[i] = 10 + src.NormFloat64()
+		}
+		for n := 1; n <= len(x); n++ {
+			e.Add(x[n-1])
+
+			// The accumulated statistics are the weighted mean and
+			// population variance with the first value weighted
+			// (1-α)^(n-1) and each later value i weighted
+			// α(1-α)^(n-i).
+			weights := make([]float64, n)
+			weights[0] = math.Pow(1-alpha, float64(n-1))
+			for i := 1; i < n; i++ {
+				weights[i] = alpha * math.Pow(1-alpha, float64(n-1-i))
+			}
+			mean := stat.Mean(x[:n], weights)
+			var variance float64
+			for i, w := range weights {
+				d := x[i] - mean
+				variance += w * d * d
+			}
+			if !floats.EqualWithinAbsOrRel(e.Mean(), mean, 1e-12, 1e-12) {
+				t.Errorf("Mean mismatch for alpha=%v n=%d: want %v, got %v", alpha, n, mean, e.Mean())
+			}
+			if !floats.EqualWithinAbsOrRel(e.Variance(), variance, 1e-12, 1e-10) {
+				t.Errorf("Variance mismatch for alpha=%v n=%d: want %v, got %v", alpha, n, variance, e.Variance())
+			}
+		}
+		if e.Count() != len(x) {
+			t.Errorf("Count mismatch: want %d, got %d", len(x), e.Count())
+		}
+	}
+}
+
+func TestExpWeightedTracks(t *testing.T) {
+	// After a level shift the moving mean converges to the new level.
+	e := NewExpWeighted(1 - math.Pow(2, -1.0/10))
+	for i := 0; i < 100; i++ {
+		e.Add(0)
+	}
+	for i := 0; i < 10; i++ {
+		e.Add(1)
+	}
+	if math.Abs(e.Mean()-0.5) > 1e-12 {
+		t.Errorf("Mean after one half-life: want 0.5, got %v", e.Mean())
+	}
+}
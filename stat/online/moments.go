@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import "math"
+
+// Moments accumulates the weighted mean, variance, skewness and kurtosis of
+// a stream of values. The central moments are updated with the numerically
+// stable formulae of
+//  Pébay, P. "Formulas for robust, one-pass parallel computation of
+//  covariances and arbitrary-order statistical moments." Sandia Report
+//  SAND2008-6212 (2008).
+// which generalize Welford's algorithm for the variance.
+//
+// The zero value of Moments is an empty accumulator ready to use.
+type Moments struct {
+	w    float64
+	mean float64
+
+	// m2, m3 and m4 are the weighted sums of the second, third and
+	// fourth powers of the deviations from the mean.
+	m2, m3, m4 float64
+
+	min, max float64
+}
+
+// Add adds the value x with unit weight to the accumulator.
+func (m *Moments) Add(x float64) {
+	m.AddWeighted(x, 1)
+}
+
+// AddWeighted adds the value x with the given weight to the accumulator.
+// AddWeighted panics if the weight is negative.
+func (m *Moments) AddWeighted(x, weight float64) {
+	if weight < 0 {
+		panic(badWeight)
+	}
+	if weight == 0 {
+		return
+	}
+	m.merge(weight, x, 0, 0, 0, x, x)
+}
+
+// Merge adds the values accumulated by o to the receiver.
+func (m *Moments) Merge(o *Moments) {
+	if o.w == 0 {
+		return
+	}
+	m.merge(o.w, o.mean, o.m2, o.m3, o.m4, o.min, o.max)
+}
+
+// merge combines the receiver with the moments of a second set of values.
+func (m *Moments) merge(wb, meanb, m2b, m3b, m4b, minb, maxb float64) {
+	if m.w == 0 {
+		m.w, m.mean, m.m2, m.m3, m.m4 = wb, meanb, m2b, m3b, m4b
+		m.min, m.max = minb, maxb
+		return
+	}
+	wa := m.w
+	w := wa + wb
+	d := meanb - m.mean
+	dw := d / w
+	dw2 := dw * dw
+
+	m4 := m.m4 + m4b +
+		d*dw*dw2*wa*wb*(wa*wa-wa*wb+wb*wb) +
+		6*dw2*(wa*wa*m2b+wb*wb*m.m2) +
+		4*dw*(wa*m3b-wb*m.m3)
+	m3 := m.m3 + m3b +
+		d*dw2*wa*wb*(wa-wb) +
+		3*dw*(wa*m2b-wb*m.m2)
+	m.m2 += m2b + d*dw*wa*wb
+	m.m3 = m3
+	m.m4 = m4
+	m.mean += dw * wb
+	m.w = w
+	m.min = math.Min(m.min, minb)
+	m.max = math.Max(m.max, maxb)
+}
+
+// ExKurtosis returns the sample excess kurtosis of the values, with the same
+// bias correction as stat.ExKurtosis.
+func (m *Moments) ExKurtosis() float64 {
+	n := m.w
+	v := m.Variance()
+	mul := ((n + 1) / (n - 1)) * (n / (n - 2)) * (1 / (n - 3))
+	offset := 3 * ((n - 1) / (n - 2)) * ((n - 1) / (n - 3))
+	return m.m4/(v*v)*mul - offset
+}
+
+// Max returns the largest value added, or NaN if no values have been added.
+func (m *Moments) Max() float64 {
+	if m.w == 0 {
+		return math.NaN()
+	}
+	return m.max
+}
+
+// Mean returns the weighted mean of the values, or NaN if no values have
+// been added.
+func (m *Moments) Mean() float64 {
+	if m.w == 0 {
+		return math.NaN()
+	}
+	return m.mean
+}
+
+// Min returns the smallest value added, or NaN if no values have been added.
+func (m *Moments) Min() float64 {
+	if m.w == 0 {
+		return math.NaN()
+	}
+	return m.min
+}
+
+// Reset removes all the values from the accumulator.
+func (m *Moments) Reset() {
+	*m = Moments{}
+}
+
+// Skew returns the sample skewness of the values, with the same bias
+// correction as stat.Skew.
+func (m *Moments) Skew() float64 {
+	n := m.w
+	s := m.StdDev()
+	return m.m3 / (s * s * s) * (n / (n - 1)) / (n - 2)
+}
+
+// StdDev returns the sample standard deviation of the values.
+func (m *Moments) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
+
+// Variance returns the unbiased weighted sample variance of the values,
+//  \sum_i w_i (x_i - mean)^2 / (\sum_i w_i - 1)
+// as computed by stat.Variance.
+func (m *Moments) Variance() float64 {
+	return m.m2 / (m.w - 1)
+}
+
+// Weight returns the sum of the weights of the values added.
+func (m *Moments) Weight() float64 {
+	return m.w
+}
@@ -0,0 +1,113 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestMoments(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n        int
+		weighted bool
+	}{
+		{n: 10},
+		{n: 1000},
+		{n: 10, weighted: true},
+		{n: 1000, weighted: true},
+	} {
+		x := make([]float64, test.n)
+		for i := range x {
+			x[i] = 1e3 + src.ExpFloat64()
+		}
+		var weights []float64
+		if test.weighted {
+			weights = make([]float64, test.n)
+			for i := range weights {
+				weights[i] = 3 * src.Float64()
+			}
+		}
+
+		var m Moments
+		for i, v := range x {
+			if weights == nil {
+				m.Add(v)
+			} else {
+				m.AddWeighted(v, weights[i])
+			}
+		}
+		for _, stats := range []struct {
+			name      string
+			want, got float64
+		}{
+			{"Mean", stat.Mean(x, weights), m.Mean()},
+			{"Variance", stat.Variance(x, weights), m.Variance()},
+			{"StdDev", stat.StdDev(x, weights), m.StdDev()},
+			{"Skew", stat.Skew(x, weights), m.Skew()},
+			{"ExKurtosis", stat.ExKurtosis(x, weights), m.ExKurtosis()},
+			{"Min", floats.Min(x), m.Min()},
+			{"Max", floats.Max(x), m.Max()},
+		} {
+			if !floats.EqualWithinAbsOrRel(stats.got, stats.want, 1e-10, 1e-10) {
+				t.Errorf("%s mismatch for n=%d weighted=%t: want %v, got %v",
+					stats.name, test.n, test.weighted, stats.want, stats.got)
+			}
+		}
+		if weights == nil && m.Weight() != float64(test.n) {
+			t.Errorf("Weight mismatch: want %v, got %v", test.n, m.Weight())
+		}
+	}
+}
+
+func TestMomentsMerge(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = src.NormFloat64()
+	}
+	var whole Moments
+	for _, v := range x {
+		whole.Add(v)
+	}
+
+	// Accumulate uneven chunks separately and merge them.
+	var merged Moments
+	for _, bounds := range [][2]int{{0, 0}, {0, 3}, {3, 400}, {400, 401}, {401, 1000}} {
+		var part Moments
+		for _, v := range x[bounds[0]:bounds[1]] {
+			part.Add(v)
+		}
+		merged.Merge(&part)
+	}
+	if merged.Weight() != whole.Weight() {
+		t.Errorf("Weight mismatch: want %v, got %v", whole.Weight(), merged.Weight())
+	}
+	for _, stats := range []struct {
+		name      string
+		want, got float64
+	}{
+		{"Mean", whole.Mean(), merged.Mean()},
+		{"Variance", whole.Variance(), merged.Variance()},
+		{"Skew", whole.Skew(), merged.Skew()},
+		{"ExKurtosis", whole.ExKurtosis(), merged.ExKurtosis()},
+		{"Min", whole.Min(), merged.Min()},
+		{"Max", whole.Max(), merged.Max()},
+	} {
+		if !floats.EqualWithinAbsOrRel(stats.got, stats.want, 1e-12, 1e-12) {
+			t.Errorf("%s mismatch after merge: want %v, got %v", stats.name, stats.want, stats.got)
+		}
+	}
+
+	merged.Reset()
+	if !math.IsNaN(merged.Mean()) || merged.Weight() != 0 {
+		t.Errorf("Reset did not empty the accumulator")
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+const (
+	badAlpha       = "online: alpha out of range"
+	badCompression = "online: compression less than one"
+	badLength      = "online: slice length mismatch"
+	badProbability = "online: probability out of range"
+	badWeight      = "online: negative weight"
+	nonPosDim      = "online: non-positive dimension"
+)
@@ -0,0 +1,295 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import (
+	"math"
+	"sort"
+)
+
+// TDigest is a mergeable sketch of a stream of values that estimates the
+// quantiles and cumulative distribution of the values. It implements the
+// merging t-digest of
+//  Dunning, T. and Ertl, O. "Computing extremely accurate quantiles using
+//  t-digests." arXiv:1902.04023 (2019).
+// using the arcsine scale function, which gives estimates whose error in
+// the tails is proportional to q(1-q).
+//
+// The memory used by a TDigest is bounded by a small multiple of its
+// compression, independent of the number of values added.
+//
+// The zero value of TDigest is an empty digest with a compression of 100
+// ready to use.
+type TDigest struct {
+	// compression is the compression of the digest. A value of zero
+	// means defaultCompression.
+	compression float64
+
+	// centroids is sorted by mean and holds total weight.
+	centroids []centroid
+	total     float64
+
+	// buffer holds values not yet merged into centroids.
+	buffer []centroid
+
+	min, max float64
+}
+
+// defaultCompression is the compression of the zero value of TDigest.
+const defaultCompression = 100
+
+// centroid is a cluster of values summarized by their mean and total weight.
+type centroid struct {
+	mean, weight float64
+}
+
+// byMean sorts centroids by increasing mean.
+type byMean []centroid
+
+func (c byMean) Len() int           { return len(c) }
+func (c byMean) Less(i, j int) bool { return c[i].mean < c[j].mean }
+func (c byMean) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
+
+// NewTDigest returns an empty t-digest with the given compression. The
+// compression bounds the number of centroids kept by the digest and so
+// trades memory for accuracy; a value of 100 gives quantile estimates
+// accurate to a fraction of a percent. NewTDigest panics if compression is
+// less than one.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression >= 1) {
+		panic(badCompression)
+	}
+	return &TDigest{compression: compression}
+}
+
+// Add adds the value x with unit weight to the digest.
+func (t *TDigest) Add(x float64) {
+	t.AddWeighted(x, 1)
+}
+
+// AddWeighted adds the value x with the given weight to the digest.
+// AddWeighted panics if the weight is negative.
+func (t *TDigest) AddWeighted(x, weight float64) {
+	if weight < 0 {
+		panic(badWeight)
+	}
+	if weight == 0 {
+		return
+	}
+	if t.empty() {
+		t.min, t.max = x, x
+	} else {
+		t.min = math.Min(t.min, x)
+		t.max = math.Max(t.max, x)
+	}
+	t.buffer = append(t.buffer, centroid{mean: x, weight: weight})
+	if len(t.buffer) >= t.bufferSize() {
+		t.flush()
+	}
+}
+
+// bufferSize returns the number of unmerged values held before the buffer
+// is merged into the centroids.
+func (t *TDigest) bufferSize() int {
+	return 5 * int(math.Ceil(t.delta()))
+}
+
+// delta returns the compression of the digest.
+func (t *TDigest) delta() float64 {
+	if t.compression == 0 {
+		return defaultCompression
+	}
+	return t.compression
+}
+
+// empty returns whether no values have been added to the digest.
+func (t *TDigest) empty() bool {
+	return len(t.centroids) == 0 && len(t.buffer) == 0
+}
+
+// flush merges the buffered values into the centroids.
+func (t *TDigest) flush() {
+	if len(t.buffer) == 0 {
+		return
+	}
+	all := append(t.buffer, t.centroids...)
+	sort.Sort(byMean(all))
+	var total float64
+	for _, c := range all {
+		total += c.weight
+	}
+
+	// Greedily merge adjacent centroids while the merged centroid spans
+	// no more than one unit of the scale function.
+	merged := t.centroids[:0]
+	cur := all[0]
+	var before float64
+	limit := total * t.qLimit(0)
+	for _, c := range all[1:] {
+		if before+cur.weight+c.weight <= limit {
+			cur.weight += c.weight
+			cur.mean += (c.mean - cur.mean) * c.weight / cur.weight
+			continue
+		}
+		merged = append(merged, cur)
+		before += cur.weight
+		limit = total * t.qLimit(before/total)
+		cur = c
+	}
+	merged = append(merged, cur)
+
+	t.centroids = merged
+	t.total = total
+	t.buffer = all[:0]
+}
+
+// qLimit returns the largest quantile that a centroid starting at quantile
+// q may reach, that is k⁻¹(k(q)+1) for the arcsine scale function
+//  k(q) = δ/(2π) asin(2q-1).
+func (t *TDigest) qLimit(q float64) float64 {
+	delta := t.delta()
+	k := delta/(2*math.Pi)*math.Asin(2*q-1) + 1
+	if k >= delta/4 {
+		return 1
+	}
+	return (math.Sin(2*math.Pi*k/delta) + 1) / 2
+}
+
+// CDF returns the estimated fraction of the weight of the values that are
+// less than or equal to x. CDF returns NaN if the digest is empty.
+func (t *TDigest) CDF(x float64) float64 {
+	t.flush()
+	c := t.centroids
+	switch {
+	case len(c) == 0:
+		return math.NaN()
+	case x < t.min:
+		return 0
+	case x >= t.max:
+		return 1
+	case len(c) == 1:
+		if t.max == t.min {
+			return 1
+		}
+		return (x - t.min) / (t.max - t.min)
+	}
+
+	// The weight of each centroid is taken to be spread evenly around
+	// its mean, so the cumulative weight at the mean of a centroid is
+	// the weight before it plus half its own weight.
+	n := len(c)
+	if x < c[0].mean {
+		return interpolate(x, t.min, c[0].mean, 0, c[0].weight/2) / t.total
+	}
+	if x >= c[n-1].mean {
+		return interpolate(x, c[n-1].mean, t.max, t.total-c[n-1].weight/2, t.total) / t.total
+	}
+	var before float64
+	for i := 0; i < n-1; i++ {
+		if x < c[i+1].mean {
+			lo := before + c[i].weight/2
+			hi := lo + (c[i].weight+c[i+1].weight)/2
+			return interpolate(x, c[i].mean, c[i+1].mean, lo, hi) / t.total
+		}
+		before += c[i].weight
+	}
+	panic("unreachable")
+}
+
+// Max returns the largest value added, or NaN if the digest is empty.
+func (t *TDigest) Max() float64 {
+	if t.Weight() == 0 {
+		return math.NaN()
+	}
+	return t.max
+}
+
+// Merge adds the values summarized by o to the receiver. The merged digest
+// keeps the compression of the receiver.
+func (t *TDigest) Merge(o *TDigest) {
+	o.flush()
+	if len(o.centroids) == 0 {
+		return
+	}
+	if t.empty() {
+		t.min, t.max = o.min, o.max
+	} else {
+		t.min = math.Min(t.min, o.min)
+		t.max = math.Max(t.max, o.max)
+	}
+	t.buffer = append(t.buffer, o.centroids...)
+	t.flush()
+}
+
+// Min returns the smallest value added, or NaN if the digest is empty.
+func (t *TDigest) Min() float64 {
+	if t.Weight() == 0 {
+		return math.NaN()
+	}
+	return t.min
+}
+
+// Quantile returns the estimated p quantile of the values. Quantile panics
+// if p is not in [0, 1] and returns NaN if the digest is empty.
+func (t *TDigest) Quantile(p float64) float64 {
+	if !(p >= 0 && p <= 1) {
+		panic(badProbability)
+	}
+	t.flush()
+	c := t.centroids
+	n := len(c)
+	switch {
+	case n == 0:
+		return math.NaN()
+	case p == 0:
+		return t.min
+	case p == 1:
+		return t.max
+	case n == 1:
+		return interpolate(p, 0, 1, t.min, t.max)
+	}
+
+	idx := p * t.total
+	if idx < c[0].weight/2 {
+		return interpolate(idx, 0, c[0].weight/2, t.min, c[0].mean)
+	}
+	if idx >= t.total-c[n-1].weight/2 {
+		return interpolate(idx, t.total-c[n-1].weight/2, t.total, c[n-1].mean, t.max)
+	}
+	lo := c[0].weight / 2
+	for i := 0; i < n-1; i++ {
+		hi := lo + (c[i].weight+c[i+1].weight)/2
+		if idx < hi {
+			return interpolate(idx, lo, hi, c[i].mean, c[i+1].mean)
+		}
+		lo = hi
+	}
+	return c[n-1].mean
+}
+
+// Reset removes all the values from the digest.
+func (t *TDigest) Reset() {
+	t.centroids = t.centroids[:0]
+	t.buffer = t.buffer[:0]
+	t.total = 0
+}
+
+// Weight returns the sum of the weights of the values added.
+func (t *TDigest) Weight() float64 {
+	w := t.total
+	for _, c := range t.buffer {
+		w += c.weight
+	}
+	return w
+}
+
+// interpolate returns the value at x of the line through (x0, y0) and
+// (x1, y1).
+func interpolate(x, x0, x1, y0, y1 float64) float64 {
+	if x1 == x0 {
+		return y0
+	}
+	return y0 + (x-x0)*(y1-y0)/(x1-x0)
+}
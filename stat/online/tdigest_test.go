@@ -0,0 +1,186 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package online
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// rankError returns the difference between p and the fraction of the
+// sorted values that are less than q.
+func rankError(sorted []float64, p, q float64) float64 {
+	return math.Abs(float64(sort.SearchFloat64s(sorted, q))/float64(len(sorted)) - p)
+}
+
+func TestTDigest(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n = 100000
+	for _, test := range []struct {
+		name string
+		rand func() float64
+	}{
+		{"normal", src.NormFloat64},
+		{"exponential", src.ExpFloat64},
+		{"uniform", src.Float64},
+	} {
+		x := make([]float64, n)
+		td := NewTDigest(100)
+		for i := range x {
+			x[i] = test.rand()
+			td.Add(x[i])
+		}
+		sorted := make([]float64, n)
+		copy(sorted, x)
+		sort.Float64s(sorted)
+
+		if td.Weight() != n {
+			t.Errorf("Weight mismatch for %s: want %v, got %v", test.name, n, td.Weight())
+		}
+		if td.Min() != sorted[0] || td.Max() != sorted[n-1] {
+			t.Errorf("Extrema mismatch for %s: want [%v, %v], got [%v, %v]",
+				test.name, sorted[0], sorted[n-1], td.Min(), td.Max())
+		}
+		if len(td.centroids) > 100 {
+			t.Errorf("Too many centroids for %s: %d", test.name, len(td.centroids))
+		}
+		for _, p := range []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999} {
+			q := td.Quantile(p)
+			// The arcsine scale function bounds the rank error
+			// more tightly in the tails.
+			tol := 0.02 * math.Sqrt(p*(1-p))
+			if e := rankError(sorted, p, q); e > tol {
+				t.Errorf("Quantile rank error for %s at p=%v: %v > %v", test.name, p, e, tol)
+			}
+			if cdf := td.CDF(q); math.Abs(cdf-p) > 1e-8 {
+				t.Errorf("CDF not inverse of Quantile for %s at p=%v: got %v", test.name, p, cdf)
+			}
+		}
+		if td.Quantile(0) != sorted[0] || td.Quantile(1) != sorted[n-1] {
+			t.Errorf("Quantile at 0 and 1 not the extrema for %s", test.name)
+		}
+		if td.CDF(sorted[0]-1) != 0 || td.CDF(sorted[n-1]) != 1 {
+			t.Errorf("CDF outside the range of the values not 0 or 1 for %s", test.name)
+		}
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n, parts = 100000, 8
+	x := make([]float64, n)
+	merged := NewTDigest(100)
+	for k := 0; k < parts; k++ {
+		td := NewTDigest(100)
+		for i := k * n / parts; i < (k+1)*n/parts; i++ {
+			x[i] = src.NormFloat64()
+			td.Add(x[i])
+		}
+		merged.Merge(td)
+	}
+	sort.Float64s(x)
+	if merged.Weight() != n {
+		t.Errorf("Weight mismatch: want %v, got %v", n, merged.Weight())
+	}
+	if merged.Min() != x[0] || merged.Max() != x[n-1] {
+		t.Errorf("Extrema mismatch: want [%v, %v], got [%v, %v]", x[0], x[n-1], merged.Min(), merged.Max())
+	}
+	for _, p := range []float64{0.001, 0.01, 0.1, 0.5, 0.9, 0.99, 0.999} {
+		tol := 0.02 * math.Sqrt(p*(1-p))
+		if e := rankError(x, p, merged.Quantile(p)); e > tol {
+			t.Errorf("Quantile rank error after merge at p=%v: %v > %v", p, e, tol)
+		}
+	}
+}
+
+func TestTDigestWeighted(t *testing.T) {
+	// Adding a value with integer weight is equivalent to adding it
+	// repeatedly.
+	src := rand.New(rand.NewSource(1))
+	weighted := NewTDigest(50)
+	repeated := NewTDigest(50)
+	for i := 0; i < 10000; i++ {
+		v := src.NormFloat64()
+		w := src.Intn(4)
+		weighted.AddWeighted(v, float64(w))
+		for j := 0; j < w; j++ {
+			repeated.Add(v)
+		}
+	}
+	if weighted.Weight() != repeated.Weight() {
+		t.Errorf("Weight mismatch: %v, %v", weighted.Weight(), repeated.Weight())
+	}
+	for _, p := range []float64{0.01, 0.1, 0.5, 0.9, 0.99} {
+		a, b := weighted.Quantile(p), repeated.Quantile(p)
+		if !floats.EqualWithinAbs(a, b, 0.02) {
+			t.Errorf("Quantile mismatch at p=%v: weighted %v, repeated %v", p, a, b)
+		}
+	}
+}
+
+func TestTDigestSmall(t *testing.T) {
+	td := NewTDigest(100)
+	if !math.IsNaN(td.Quantile(0.5)) || !math.IsNaN(td.CDF(0)) {
+		t.Errorf("Statistics of empty digest not NaN")
+	}
+	td.Add(3)
+	if td.Quantile(0.5) != 3 || td.CDF(2) != 0 || td.CDF(3) != 1 {
+		t.Errorf("Unexpected statistics for a single value")
+	}
+	for _, v := range []float64{1, 2, 4, 5} {
+		td.Add(v)
+	}
+	// With every value held in its own centroid the median is exact.
+	if got := td.Quantile(0.5); got != 3 {
+		t.Errorf("Median mismatch: want 3, got %v", got)
+	}
+	td.Reset()
+	if td.Weight() != 0 || !math.IsNaN(td.Min()) {
+		t.Errorf("Reset did not empty the digest")
+	}
+}
+
+func TestTDigestZeroValue(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n = 10000
+	var zero, merged TDigest
+	want := NewTDigest(defaultCompression)
+	part := NewTDigest(defaultCompression)
+	for i := 0; i < n; i++ {
+		// All the values are negative, so a maximum that starts at
+		// zero would be wrong.
+		v := -1 - src.ExpFloat64()
+		zero.Add(v)
+		want.Add(v)
+		if i < n/2 {
+			part.Add(v)
+		}
+	}
+	merged.Merge(part)
+	if zero.Min() != want.Min() || zero.Max() != want.Max() {
+		t.Errorf("Extrema mismatch: want [%v, %v], got [%v, %v]", want.Min(), want.Max(), zero.Min(), zero.Max())
+	}
+	if merged.Min() != part.Min() || merged.Max() != part.Max() {
+		t.Errorf("Extrema mismatch after merge: want [%v, %v], got [%v, %v]", part.Min(), part.Max(), merged.Min(), merged.Max())
+	}
+	for _, p := range []float64{0, 0.01, 0.5, 0.99, 1} {
+		if got, w := zero.Quantile(p), want.Quantile(p); got != w {
+			t.Errorf("Quantile mismatch at p=%v: want %v, got %v", p, w, got)
+		}
+	}
+	if len(zero.centroids) != len(want.centroids) {
+		t.Errorf("Centroid count mismatch: want %d, got %d", len(want.centroids), len(zero.centroids))
+	}
+
+	zero.Reset()
+	zero.Add(-5)
+	if zero.Min() != -5 || zero.Max() != -5 {
+		t.Errorf("Extrema mismatch after reset: want [-5, -5], got [%v, %v]", zero.Min(), zero.Max())
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Family is the distribution of the response of a generalized linear model,
+// from the exponential dispersion family, with variance φ V(μ)/w for mean μ,
+// dispersion φ and prior weight w.
+type Family interface {
+	// CanonicalLink returns the canonical link of the family.
+	CanonicalLink() Link
+
+	// Variance returns the variance function V(μ).
+	Variance(mu float64) float64
+
+	// Deviance returns the unit deviance of an observation y with mean μ.
+	Deviance(y, mu float64) float64
+
+	// Start returns the initial estimate of the mean of an observation y
+	// with prior weight w.
+	Start(y, w float64) float64
+
+	// FixedDispersion returns whether the dispersion φ is fixed at one
+	// rather than estimated from the data.
+	FixedDispersion() bool
+
+	// LogLikelihood returns the log-likelihood of the observations y with
+	// means mu and prior weights w, given the total deviance of the fit.
+	// For families with a free dispersion, the dispersion is estimated
+	// from the deviance.
+	LogLikelihood(y, mu, w []float64, deviance float64) float64
+}
+
+// xlogy returns x log(y), which is zero if x is zero.
+func xlogy(x, y float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	return x * math.Log(y)
+}
+
+// Gaussian is the normal family, with V(μ) = 1.
+type Gaussian struct{}
+
+// CanonicalLink returns IdentityLink.
+func (Gaussian) CanonicalLink() Link { return IdentityLink{} }
+
+// Variance returns 1.
+func (Gaussian) Variance(mu float64) float64 { return 1 }
+
+// Deviance returns (y-μ)².
+func (Gaussian) Deviance(y, mu float64) float64 { return (y - mu) * (y - mu) }
+
+// Start returns y.
+func (Gaussian) Start(y, w float64) float64 { return y }
+
+// FixedDispersion returns false.
+func (Gaussian) FixedDispersion() bool { return false }
+
+// LogLikelihood returns the log-likelihood of the observations at the
+// maximum likelihood estimate of the variance, the deviance divided by the
+// number of observations with positive weight.
+func (Gaussian) LogLikelihood(y, mu, w []float64, deviance float64) float64 {
+	var n, sumLogW float64
+	for _, v := range w {
+		if v > 0 {
+			n++
+			sumLogW += math.Log(v)
+		}
+	}
+	return -0.5 * (n*(math.Log(2*math.Pi*deviance/n)+1) - sumLogW)
+}
+
+// Binomial is the binomial family for proportions of successes, with
+// V(μ) = μ(1-μ). The prior weights are the numbers of trials.
+type Binomial struct{}
+
+// CanonicalLink returns LogitLink.
+func (Binomial) CanonicalLink() Link { return LogitLink{} }
+
+// Variance returns μ(1-μ).
+func (Binomial) Variance(mu float64) float64 { return mu * (1 - mu) }
+
+// Deviance returns 2(y log(y/μ) + (1-y) log((1-y)/(1-μ))).
+func (Binomial) Deviance(y, mu float64) float64 {
+	return 2 * (xlogy(y, y/mu) + xlogy(1-y, (1-y)/(1-mu)))
+}
+
+// Start returns (w y + 1/2)/(w + 1).
+func (Binomial) Start(y, w float64) float64 { return (w*y + 0.5) / (w + 1) }
+
+// FixedDispersion returns true.
+func (Binomial) FixedDispersion() bool { return true }
+
+// LogLikelihood returns the binomial log-likelihood of the observed numbers
+// of successes w y in w trials.
+func (Binomial) LogLikelihood(y, mu, w []float64, deviance float64) float64 {
+	var ll float64
+	for i, v := range w {
+		if v > 0 {
+			n := math.Floor(v + 0.5)
+			ll += distuv.Binomial{N: n, P: mu[i]}.LogProb(math.Floor(v*y[i] + 0.5))
+		}
+	}
+	return ll
+}
+
+// Poisson is the Poisson family for counts, with V(μ) = μ.
+type Poisson struct{}
+
+// CanonicalLink returns LogLink.
+func (Poisson) CanonicalLink() Link { return LogLink{} }
+
+// Variance returns μ.
+func (Poisson) Variance(mu float64) float64 { return mu }
+
+// Deviance returns 2(y log(y/μ) - (y-μ)).
+func (Poisson) Deviance(y, mu float64) float64 { return 2 * (xlogy(y, y/mu) - (y - mu)) }
+
+// Start returns y + 1/10.
+func (Poisson) Start(y, w float64) float64 { return y + 0.1 }
+
+// FixedDispersion returns true.
+func (Poisson) FixedDispersion() bool { return true }
+
+// LogLikelihood returns the weighted Poisson log-likelihood of the
+// observations.
+func (Poisson) LogLikelihood(y, mu, w []float64, deviance float64) float64 {
+	var ll float64
+	for i, v := range w {
+		if v > 0 {
+			ll += v * distuv.Poisson{Lambda: mu[i]}.LogProb(y[i])
+		}
+	}
+	return ll
+}
+
+// Gamma is the gamma family for positive responses, with V(μ) = μ².
+type Gamma struct{}
+
+// CanonicalLink returns InverseLink.
+func (Gamma) CanonicalLink() Link { return InverseLink{} }
+
+// Variance returns μ².
+func (Gamma) Variance(mu float64) float64 { return mu * mu }
+
+// Deviance returns -2(log(y/μ) - (y-μ)/μ).
+func (Gamma) Deviance(y, mu float64) float64 { return -2 * (math.Log(y/mu) - (y-mu)/mu) }
+
+// Start returns y.
+func (Gamma) Start(y, w float64) float64 { return y }
+
+// FixedDispersion returns false.
+func (Gamma) FixedDispersion() bool { return false }
+
+// LogLikelihood returns the weighted gamma log-likelihood of the
+// observations with the dispersion estimated as the deviance divided by the
+// sum of the weights.
+func (Gamma) LogLikelihood(y, mu, w []float64, deviance float64) float64 {
+	var sumW float64
+	for _, v := range w {
+		sumW += v
+	}
+	disp := deviance / sumW
+	var ll float64
+	for i, v := range w {
+		if v > 0 {
+			ll += v * distuv.Gamma{Alpha: 1 / disp, Beta: 1 / (mu[i] * disp)}.LogProb(y[i])
+		}
+	}
+	return ll
+}
@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+	"testing"
+
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+func TestFamilyDeviance(t *testing.T) {
+	for _, test := range []struct {
+		family Family
+		y, mu  []float64
+	}{
+		{Gaussian{}, []float64{-1, 0, 2.5}, []float64{-2, 0.5, 3}},
+		{Binomial{}, []float64{0, 0.3, 1}, []float64{0.2, 0.5, 0.9}},
+		{Poisson{}, []float64{0, 1, 7}, []float64{0.5, 2, 5}},
+		{Gamma{}, []float64{0.5, 1, 7}, []float64{0.7, 2, 5}},
+	} {
+		for i, y := range test.y {
+			if d := test.family.Deviance(y, y); math.Abs(d) > 1e-14 {
+				t.Errorf("%T: nonzero deviance at the mean %v: %v", test.family, y, d)
+			}
+			// The unit deviance is 2 \int_μ^y (y-t)/V(t) dt.
+			mu := test.mu[i]
+			const n = 100000
+			h := (y - mu) / n
+			var want float64
+			for k := 0; k < n; k++ {
+				s := mu + (float64(k)+0.5)*h
+				want += (y - s) / test.family.Variance(s) * h
+			}
+			want *= 2
+			if got := test.family.Deviance(y, mu); math.Abs(got-want) > 1e-6 {
+				t.Errorf("%T: deviance mismatch at y=%v, μ=%v: want %v, got %v", test.family, y, mu, want, got)
+			}
+		}
+	}
+}
+
+func TestFamilyLogLikelihood(t *testing.T) {
+	y := []float64{1, 3, 2, 5}
+	mu := []float64{1.5, 2.5, 2, 4}
+	w := []float64{1, 2, 1, 0.5}
+
+	// The Gaussian log-likelihood uses the maximum likelihood variance.
+	var dev float64
+	for i := range y {
+		dev += w[i] * Gaussian{}.Deviance(y[i], mu[i])
+	}
+	sigma2 := dev / float64(len(y))
+	var want float64
+	for i := range y {
+		want += distuv.Normal{Mu: mu[i], Sigma: math.Sqrt(sigma2 / w[i])}.LogProb(y[i])
+	}
+	if got := (Gaussian{}).LogLikelihood(y, mu, w, dev); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Gaussian log-likelihood mismatch: want %v, got %v", want, got)
+	}
+
+	want = 0
+	for i := range y {
+		want += w[i] * distuv.Poisson{Lambda: mu[i]}.LogProb(y[i])
+	}
+	if got := (Poisson{}).LogLikelihood(y, mu, w, 0); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Poisson log-likelihood mismatch: want %v, got %v", want, got)
+	}
+
+	// Binomial proportions with the numbers of trials as weights.
+	p := []float64{0.25, 0.5, 1}
+	pm := []float64{0.3, 0.4, 0.8}
+	trials := []float64{4, 6, 2}
+	want = 0
+	for i := range p {
+		want += distuv.Binomial{N: trials[i], P: pm[i]}.LogProb(p[i] * trials[i])
+	}
+	if got := (Binomial{}).LogLikelihood(p, pm, trials, 0); math.Abs(got-want) > 1e-12 {
+		t.Errorf("binomial log-likelihood mismatch: want %v, got %v", want, got)
+	}
+}
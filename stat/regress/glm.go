@@ -0,0 +1,443 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// GLMSettings are the settings for fitting a generalized linear model.
+type GLMSettings struct {
+	// Link is the link function of the model. If Link is nil, the
+	// canonical link of the family is used.
+	Link Link
+
+	// Offset is a known term added to the linear predictor of each
+	// observation. If Offset is nil, the offsets are zero.
+	Offset []float64
+
+	// MaxIterations is the maximum number of iterations of iteratively
+	// reweighted least squares.
+	MaxIterations int
+
+	// Tolerance is the convergence tolerance on the relative change in
+	// the deviance between iterations.
+	Tolerance float64
+}
+
+// DefaultGLMSettings returns the default settings for fitting a generalized
+// linear model, with the canonical link, no offsets, at most 25 iterations
+// and a tolerance of 1e-8.
+func DefaultGLMSettings() *GLMSettings {
+	return &GLMSettings{
+		MaxIterations: 25,
+		Tolerance:     1e-8,
+	}
+}
+
+// GLM is a generalized linear model, in which the response y has a
+// distribution from an exponential dispersion family with mean μ related to
+// the linear predictor by the link function g,
+//  g(μ) = X β + offset
+type GLM struct {
+	family    Family
+	link      Link
+	intercept bool
+
+	coef    []float64
+	xtwxInv *mat.SymDense
+	mu      []float64
+
+	dispersion   float64
+	deviance     float64
+	nullDeviance float64
+	logLik       float64
+	nobs         int
+}
+
+// FitGLM fits a generalized linear model of y on the columns of x with the
+// given family and prior weights by iteratively reweighted least squares.
+// If intercept is true, an intercept term is included in the model as the
+// first coefficient. If weights is nil, then all the weights are 1. For the
+// Binomial family, y holds the proportions of successes and weights the
+// numbers of trials. If settings is nil, DefaultGLMSettings is used.
+//
+// The returned boolean reports whether the iterations converged within
+// settings.MaxIterations. If a weighted least squares step is singular,
+// FitGLM returns a nil model and the error from the solve.
+//
+// FitGLM panics if len(y) is not the number of rows of x, if weights or
+// settings.Offset are not nil and have a length other than len(y), if any
+// weight is negative, if there are not more observations with positive
+// weight than coefficients, or if settings.MaxIterations is less than one.
+func FitGLM(x mat.Matrix, y, weights []float64, intercept bool, family Family, settings *GLMSettings) (*GLM, bool, error) {
+	r, _ := x.Dims()
+	if len(y) != r {
+		panic(badLength)
+	}
+	if settings == nil {
+		settings = DefaultGLMSettings()
+	}
+	if settings.MaxIterations < 1 {
+		panic("regress: bad iteration limit")
+	}
+	w, nobs := priorWeights(weights, r)
+	offset := settings.Offset
+	if offset == nil {
+		offset = make([]float64, r)
+	} else if len(offset) != r {
+		panic(badLength)
+	}
+	link := settings.Link
+	if link == nil {
+		link = family.CanonicalLink()
+	}
+	d := design(x, intercept)
+	_, p := d.Dims()
+	if nobs <= p {
+		panic(tooFewObs)
+	}
+
+	coef, xtwxInv, mu, dev, converged, err := irls(d, y, w, offset, family, link, settings)
+	if err != nil {
+		return nil, false, err
+	}
+	g := &GLM{
+		family:    family,
+		link:      link,
+		intercept: intercept,
+		coef:      coef,
+		xtwxInv:   xtwxInv,
+		mu:        mu,
+		deviance:  dev,
+		logLik:    family.LogLikelihood(y, mu, w, dev),
+		nobs:      nobs,
+	}
+
+	g.dispersion = 1
+	if !family.FixedDispersion() {
+		// Use the Pearson estimate of the dispersion.
+		var chi2 float64
+		for i, v := range y {
+			r := v - mu[i]
+			chi2 += w[i] * r * r / family.Variance(mu[i])
+		}
+		g.dispersion = chi2 / g.DoF()
+	}
+
+	if intercept {
+		ones := mat.NewDense(r, 1, nil)
+		for i := 0; i < r; i++ {
+			ones.Set(i, 0, 1)
+		}
+		_, _, _, g.nullDeviance, _, err = irls(ones, y, w, offset, family, link, settings)
+		if err != nil {
+			return nil, false, err
+		}
+	} else {
+		for i, v := range y {
+			g.nullDeviance += w[i] * family.Deviance(v, link.Inverse(offset[i]))
+		}
+	}
+	return g, converged, nil
+}
+
+// irls fits the coefficients of a generalized linear model with design
+// matrix d by iteratively reweighted least squares. It returns the
+// coefficients, the matrix (X^T W X)^-1 of the working weights at the fit, the
+// fitted means, the deviance and whether the iterations converged.
+func irls(d *mat.Dense, y, w, offset []float64, family Family, link Link, settings *GLMSettings) (coef []float64, xtwxInv *mat.SymDense, mu []float64, dev float64, converged bool, err error) {
+	n := len(y)
+	mu = make([]float64, n)
+	eta := make([]float64, n)
+	for i, v := range y {
+		mu[i] = family.Start(v, w[i])
+		eta[i] = link.Link(mu[i])
+	}
+	dev = deviance(family, y, mu, w)
+
+	z := make([]float64, n)
+	wt := make([]float64, n)
+	newEta := make([]float64, n)
+	newMu := make([]float64, n)
+	for iter := 0; iter < settings.MaxIterations; iter++ {
+		working(y, w, offset, eta, mu, family, link, z, wt)
+		beta, _, err := weightedLeastSquares(d, z, wt)
+		if err != nil {
+			return nil, nil, nil, 0, false, err
+		}
+		newDev := update(d, beta, offset, y, w, family, link, newEta, newMu)
+		// Halve the step while the new coefficients give an invalid fit.
+		for h := 0; coef != nil && h < 30 && (math.IsNaN(newDev) || math.IsInf(newDev, 0)); h++ {
+			for j := range beta {
+				beta[j] = (beta[j] + coef[j]) / 2
+			}
+			newDev = update(d, beta, offset, y, w, family, link, newEta, newMu)
+		}
+		coef = beta
+		copy(eta, newEta)
+		copy(mu, newMu)
+		converged = math.Abs(newDev-dev)/(math.Abs(newDev)+0.1) < settings.Tolerance
+		dev = newDev
+		if converged {
+			break
+		}
+	}
+
+	// Compute the covariance from the working weights at the final fit.
+	working(y, w, offset, eta, mu, family, link, z, wt)
+	_, xtwxInv, err = weightedLeastSquares(d, z, wt)
+	if err != nil {
+		return nil, nil, nil, 0, false, err
+	}
+	return coef, xtwxInv, mu, dev, converged, nil
+}
+
+// working stores the working response and working weights of iteratively
+// reweighted least squares for the linear predictors eta and means mu into
+// z and wt.
+func working(y, w, offset, eta, mu []float64, family Family, link Link, z, wt []float64) {
+	for i, v := range y {
+		g := link.Deriv(mu[i])
+		z[i] = eta[i] - offset[i] + (v-mu[i])*g
+		wt[i] = w[i] / (g * g * family.Variance(mu[i]))
+		if w[i] == 0 || math.IsNaN(wt[i]) || math.IsInf(wt[i], 0) {
+			z[i] = 0
+			wt[i] = 0
+		}
+	}
+}
+
+// update stores the linear predictors and means for the coefficients beta
+// into eta and mu and returns the deviance of the fit.
+func update(d *mat.Dense, beta, offset, y, w []float64, family Family, link Link, eta, mu []float64) float64 {
+	for i := range eta {
+		e := offset[i]
+		for j, c := range beta {
+			e += d.At(i, j) * c
+		}
+		eta[i] = e
+		mu[i] = link.Inverse(e)
+	}
+	return deviance(family, y, mu, w)
+}
+
+// deviance returns the total deviance of the observations y with means mu
+// and prior weights w.
+func deviance(family Family, y, mu, w []float64) float64 {
+	var dev float64
+	for i, v := range y {
+		if w[i] > 0 {
+			dev += w[i] * family.Deviance(v, mu[i])
+		}
+	}
+	return dev
+}
+
+// AIC returns the Akaike information criterion of the model,
+//  AIC = -2 log L + 2k
+// where k is the number of coefficients, plus one if the dispersion is
+// estimated.
+func (g *GLM) AIC() float64 {
+	return -2*g.logLik + 2*g.numParameters()
+}
+
+// BIC returns the Bayesian information criterion of the model,
+//  BIC = -2 log L + k log n
+// where k is the number of coefficients, plus one if the dispersion is
+// estimated, and n is the number of observations with positive weight.
+func (g *GLM) BIC() float64 {
+	return -2*g.logLik + g.numParameters()*math.Log(float64(g.nobs))
+}
+
+// numParameters returns the number of estimated parameters of the model.
+func (g *GLM) numParameters() float64 {
+	k := float64(len(g.coef))
+	if !g.family.FixedDispersion() {
+		k++
+	}
+	return k
+}
+
+// Coef returns the estimated coefficients of the model. If the model has an
+// intercept, it is the first coefficient. If the input slice is nil, a new
+// slice will be allocated, otherwise the result is stored in-place into dst.
+func (g *GLM) Coef(dst []float64) []float64 {
+	dst = reuseAs(dst, len(g.coef))
+	copy(dst, g.coef)
+	return dst
+}
+
+// ConfInt returns the lower and upper bounds of the Wald confidence
+// intervals of the coefficients at the given confidence level. The
+// intervals are based on the standard normal distribution if the dispersion
+// is fixed, and on the t distribution with DoF degrees of freedom
+// otherwise. If the input slices are nil, new slices will be allocated,
+// otherwise the results are stored in-place.
+//
+// ConfInt panics if level is not in (0, 1).
+func (g *GLM) ConfInt(lower, upper []float64, level float64) ([]float64, []float64) {
+	checkLevel(level)
+	lower = reuseAs(lower, len(g.coef))
+	upper = reuseAs(upper, len(g.coef))
+	q := g.quantile((1 + level) / 2)
+	for i, c := range g.coef {
+		se := math.Sqrt(g.dispersion * g.xtwxInv.At(i, i))
+		lower[i] = c - q*se
+		upper[i] = c + q*se
+	}
+	return lower, upper
+}
+
+// CovarianceMatrix returns the estimated covariance matrix of the
+// coefficients,
+//  φ (X^T W X)^-1
+// where φ is the dispersion and W are the working weights of the final
+// iteration. If the input matrix is nil a new matrix is allocated,
+// otherwise the result is stored in-place into the input.
+func (g *GLM) CovarianceMatrix(s *mat.SymDense) *mat.SymDense {
+	p := len(g.coef)
+	if s == nil {
+		s = mat.NewSymDense(p, nil)
+	} else if s.Symmetric() != p {
+		panic("regress: input matrix size mismatch")
+	}
+	s.ScaleSym(g.dispersion, g.xtwxInv)
+	return s
+}
+
+// Deviance returns the residual deviance of the model.
+func (g *GLM) Deviance() float64 {
+	return g.deviance
+}
+
+// Dispersion returns the dispersion φ of the model. It is one for families
+// with fixed dispersion and the Pearson chi-square statistic divided by the
+// residual degrees of freedom otherwise.
+func (g *GLM) Dispersion() float64 {
+	return g.dispersion
+}
+
+// DoF returns the residual degrees of freedom of the model, the number of
+// observations with positive weight less the number of coefficients.
+func (g *GLM) DoF() float64 {
+	return float64(g.nobs - len(g.coef))
+}
+
+// Fitted returns the fitted means of the observations. If the input slice is
+// nil, a new slice will be allocated, otherwise the result is stored
+// in-place into dst.
+func (g *GLM) Fitted(dst []float64) []float64 {
+	dst = reuseAs(dst, len(g.mu))
+	copy(dst, g.mu)
+	return dst
+}
+
+// LogLikelihood returns the log-likelihood of the model.
+func (g *GLM) LogLikelihood() float64 {
+	return g.logLik
+}
+
+// NullDeviance returns the deviance of the model with only an intercept, or
+// with no coefficients if the model has no intercept.
+func (g *GLM) NullDeviance() float64 {
+	return g.nullDeviance
+}
+
+// PValue returns the two-sided p-values of the Wald statistics of the
+// coefficients. The p-values are based on the standard normal distribution
+// if the dispersion is fixed, and on the t distribution with DoF degrees of
+// freedom otherwise. If the input slice is nil, a new slice will be
+// allocated, otherwise the result is stored in-place into dst.
+func (g *GLM) PValue(dst []float64) []float64 {
+	dst = g.WaldStat(dst)
+	for i, z := range dst {
+		dst[i] = 2 * g.survival(math.Abs(z))
+	}
+	return dst
+}
+
+// Predict returns the predicted mean response for the predictors x and the
+// given offset. x must not include the intercept term.
+func (g *GLM) Predict(x []float64, offset float64) float64 {
+	return g.link.Inverse(g.PredictLink(x, offset))
+}
+
+// PredictInterval returns the bounds of the Wald confidence interval for the
+// mean response at the predictors x and the given offset at the given
+// confidence level. The interval is computed on the scale of the linear
+// predictor and transformed by the inverse link. x must not include the
+// intercept term.
+//
+// PredictInterval panics if level is not in (0, 1).
+func (g *GLM) PredictInterval(x []float64, offset, level float64) (lower, upper float64) {
+	checkLevel(level)
+	row := designRow(x, len(g.coef), g.intercept)
+	eta := g.PredictLink(x, offset)
+	se := math.Sqrt(g.dispersion * quadForm(row, g.xtwxInv))
+	q := g.quantile((1 + level) / 2)
+	lower = g.link.Inverse(eta - q*se)
+	upper = g.link.Inverse(eta + q*se)
+	if lower > upper {
+		// The inverse link is decreasing.
+		lower, upper = upper, lower
+	}
+	return lower, upper
+}
+
+// PredictLink returns the linear predictor for the predictors x and the
+// given offset. x must not include the intercept term.
+func (g *GLM) PredictLink(x []float64, offset float64) float64 {
+	row := designRow(x, len(g.coef), g.intercept)
+	eta := offset
+	for i, c := range g.coef {
+		eta += row[i] * c
+	}
+	return eta
+}
+
+// StdErr returns the standard errors of the coefficients. If the input
+// slice is nil, a new slice will be allocated, otherwise the result is
+// stored in-place into dst.
+func (g *GLM) StdErr(dst []float64) []float64 {
+	dst = reuseAs(dst, len(g.coef))
+	for i := range dst {
+		dst[i] = math.Sqrt(g.dispersion * g.xtwxInv.At(i, i))
+	}
+	return dst
+}
+
+// WaldStat returns the Wald statistics of the coefficients, the ratio of
+// each coefficient to its standard error. If the input slice is nil, a new
+// slice will be allocated, otherwise the result is stored in-place into
+// dst.
+func (g *GLM) WaldStat(dst []float64) []float64 {
+	dst = g.StdErr(dst)
+	for i, c := range g.coef {
+		dst[i] = c / dst[i]
+	}
+	return dst
+}
+
+// quantile returns the quantile of the reference distribution of the Wald
+// statistics.
+func (g *GLM) quantile(p float64) float64 {
+	if g.family.FixedDispersion() {
+		return distuv.UnitNormal.Quantile(p)
+	}
+	return distuv.StudentsT{Mu: 0, Sigma: 1, Nu: g.DoF()}.Quantile(p)
+}
+
+// survival returns the survival function of the reference distribution of
+// the Wald statistics.
+func (g *GLM) survival(x float64) float64 {
+	if g.family.FixedDispersion() {
+		return distuv.UnitNormal.Survival(x)
+	}
+	return distuv.StudentsT{Mu: 0, Sigma: 1, Nu: g.DoF()}.Survival(x)
+}
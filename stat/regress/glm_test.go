@@ -0,0 +1,277 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestGLMPoissonDobson(t *testing.T) {
+	// Dobson (1990) randomized controlled trial. Reference values from R.
+	counts := []float64{18, 17, 15, 20, 10, 20, 25, 13, 12}
+	x := mat.NewDense(9, 4, nil)
+	for i := 0; i < 9; i++ {
+		outcome := i % 3
+		treatment := i / 3
+		if outcome > 0 {
+			x.Set(i, outcome-1, 1)
+		}
+		if treatment > 0 {
+			x.Set(i, 1+treatment, 1)
+		}
+	}
+	g, converged, err := FitGLM(x, counts, nil, true, Poisson{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !converged {
+		t.Errorf("IRLS did not converge")
+	}
+	if !floats.EqualApprox(g.Coef(nil), []float64{3.045, -0.4543, -0.2930, 0, 0}, 1e-3) {
+		t.Errorf("coefficient mismatch: got %v", g.Coef(nil))
+	}
+	if !floats.EqualApprox(g.StdErr(nil), []float64{0.1709, 0.2022, 0.1927, 0.2000, 0.2000}, 1e-4) {
+		t.Errorf("standard error mismatch: got %v", g.StdErr(nil))
+	}
+	for _, test := range []struct {
+		name      string
+		got, want float64
+		tol       float64
+	}{
+		{"null deviance", g.NullDeviance(), 10.5814, 1e-4},
+		{"deviance", g.Deviance(), 5.1291, 1e-4},
+		{"AIC", g.AIC(), 56.761, 1e-3},
+		{"dispersion", g.Dispersion(), 1, 0},
+		{"degrees of freedom", g.DoF(), 4, 0},
+	} {
+		if math.Abs(test.got-test.want) > test.tol {
+			t.Errorf("%s mismatch: want %v, got %v", test.name, test.want, test.got)
+		}
+	}
+
+	// The fitted values are the predictions.
+	fitted := g.Fitted(nil)
+	for i := 0; i < 9; i++ {
+		row := x.RawRowView(i)
+		if got := g.Predict(row, 0); math.Abs(got-fitted[i]) > 1e-12 {
+			t.Errorf("prediction mismatch for observation %d: want %v, got %v", i, fitted[i], got)
+		}
+		lower, upper := g.PredictInterval(row, 0, 0.95)
+		if !(lower < fitted[i] && fitted[i] < upper) {
+			t.Errorf("prediction interval [%v, %v] does not contain %v", lower, upper, fitted[i])
+		}
+	}
+
+	// A constant offset shifts the intercept.
+	settings := DefaultGLMSettings()
+	settings.Offset = make([]float64, 9)
+	for i := range settings.Offset {
+		settings.Offset[i] = 2
+	}
+	gOff, _, err := FitGLM(x, counts, nil, true, Poisson{}, settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := g.Coef(nil)
+	want[0] -= 2
+	if !floats.EqualApprox(gOff.Coef(nil), want, 1e-8) {
+		t.Errorf("coefficient mismatch with offset: want %v, got %v", want, gOff.Coef(nil))
+	}
+	if math.Abs(gOff.Deviance()-g.Deviance()) > 1e-8 || math.Abs(gOff.NullDeviance()-g.NullDeviance()) > 1e-8 {
+		t.Errorf("deviance changed by constant offset")
+	}
+	if got := gOff.Predict(x.RawRowView(0), 2); math.Abs(got-fitted[0]) > 1e-8 {
+		t.Errorf("prediction with offset mismatch: want %v, got %v", fitted[0], got)
+	}
+}
+
+func TestGLMGaussian(t *testing.T) {
+	x, y := plantGrowth()
+	l, err := FitLinear(x, y, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	g, converged, err := FitGLM(x, y, nil, true, Gaussian{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !converged {
+		t.Errorf("IRLS did not converge")
+	}
+	if !floats.EqualApprox(g.Coef(nil), l.Coef(nil), 1e-12) {
+		t.Errorf("coefficient mismatch: want %v, got %v", l.Coef(nil), g.Coef(nil))
+	}
+	if !floats.EqualApprox(g.StdErr(nil), l.StdErr(nil), 1e-12) {
+		t.Errorf("standard error mismatch: want %v, got %v", l.StdErr(nil), g.StdErr(nil))
+	}
+	if !floats.EqualApprox(g.PValue(nil), l.PValue(nil), 1e-12) {
+		t.Errorf("p-value mismatch: want %v, got %v", l.PValue(nil), g.PValue(nil))
+	}
+	if math.Abs(g.Dispersion()-l.Sigma()*l.Sigma()) > 1e-12 {
+		t.Errorf("dispersion mismatch: want %v, got %v", l.Sigma()*l.Sigma(), g.Dispersion())
+	}
+	resid := l.Residuals(nil)
+	if math.Abs(g.Deviance()-floats.Dot(resid, resid)) > 1e-12 {
+		t.Errorf("deviance mismatch: want %v, got %v", floats.Dot(resid, resid), g.Deviance())
+	}
+	gl, gu := g.ConfInt(nil, nil, 0.95)
+	ll, lu := l.ConfInt(nil, nil, 0.95)
+	if !floats.EqualApprox(gl, ll, 1e-12) || !floats.EqualApprox(gu, lu, 1e-12) {
+		t.Errorf("confidence interval mismatch")
+	}
+	// The log-likelihood is at the maximum likelihood estimate of the
+	// variance, and the variance is counted as a parameter.
+	n := float64(len(y))
+	logLik := -n / 2 * (math.Log(2*math.Pi*g.Deviance()/n) + 1)
+	if math.Abs(g.LogLikelihood()-logLik) > 1e-12 {
+		t.Errorf("log-likelihood mismatch: want %v, got %v", logLik, g.LogLikelihood())
+	}
+	if math.Abs(g.AIC()-(-2*logLik+8)) > 1e-12 {
+		t.Errorf("AIC mismatch: want %v, got %v", -2*logLik+8, g.AIC())
+	}
+}
+
+// score returns the score of the coefficients of the model,
+//  \sum_i w_i x_i (y_i - μ_i) / (V(μ_i) g'(μ_i))
+// which is zero at the maximum likelihood estimate.
+func score(d *mat.Dense, y, w, mu []float64, family Family, link Link) []float64 {
+	_, p := d.Dims()
+	s := make([]float64, p)
+	for i, v := range y {
+		f := w[i] * (v - mu[i]) / (family.Variance(mu[i]) * link.Deriv(mu[i]))
+		floats.AddScaled(s, f, d.RawRowView(i))
+	}
+	return s
+}
+
+func TestGLMScore(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n = 200
+	x := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		x.Set(i, 0, src.NormFloat64())
+		x.Set(i, 1, src.Float64())
+	}
+	eta := func(i int, b0, b1, b2 float64) float64 {
+		return b0 + b1*x.At(i, 0) + b2*x.At(i, 1)
+	}
+	trials := make([]float64, n)
+	props := make([]float64, n)
+	gammaY := make([]float64, n)
+	for i := 0; i < n; i++ {
+		trials[i] = float64(1 + src.Intn(10))
+		p := 1 / (1 + math.Exp(-eta(i, 0.2, 1, -0.5)))
+		var k float64
+		for j := 0; j < int(trials[i]); j++ {
+			if src.Float64() < p {
+				k++
+			}
+		}
+		props[i] = k / trials[i]
+		mu := math.Exp(eta(i, 0.5, 0.3, 0.2))
+		// Gamma variates with shape 4 and mean μ.
+		var s float64
+		for j := 0; j < 4; j++ {
+			s += src.ExpFloat64()
+		}
+		gammaY[i] = s / 4 * mu
+	}
+
+	for _, test := range []struct {
+		family Family
+		link   Link
+		y, w   []float64
+	}{
+		{Binomial{}, nil, props, trials},
+		{Binomial{}, ProbitLink{}, props, trials},
+		{Binomial{}, CLogLogLink{}, props, trials},
+		{Gamma{}, nil, gammaY, nil},
+		{Gamma{}, LogLink{}, gammaY, nil},
+		{Poisson{}, SqrtLink{}, gammaY, nil},
+	} {
+		settings := DefaultGLMSettings()
+		settings.Link = test.link
+		settings.Tolerance = 1e-14
+		g, converged, err := FitGLM(x, test.y, test.w, true, test.family, settings)
+		if err != nil {
+			t.Fatalf("%T, %T: unexpected error: %v", test.family, test.link, err)
+		}
+		if !converged {
+			t.Errorf("%T, %T: IRLS did not converge", test.family, test.link)
+		}
+		link := test.link
+		if link == nil {
+			link = test.family.CanonicalLink()
+		}
+		w := test.w
+		if w == nil {
+			w = make([]float64, n)
+			for i := range w {
+				w[i] = 1
+			}
+		}
+		s := score(design(x, true), test.y, w, g.Fitted(nil), test.family, link)
+		if floats.Norm(s, math.Inf(1)) > 1e-6 {
+			t.Errorf("%T, %T: nonzero score at the fit: %v", test.family, test.link, s)
+		}
+		if !(g.Deviance() < g.NullDeviance()) {
+			t.Errorf("%T, %T: deviance %v not less than null deviance %v", test.family, test.link, g.Deviance(), g.NullDeviance())
+		}
+		if math.Abs(g.AIC()+2*g.LogLikelihood()-2*g.numParameters()) > 1e-12 {
+			t.Errorf("%T, %T: AIC mismatch", test.family, test.link)
+		}
+	}
+}
+
+func TestGLMBinomialWeights(t *testing.T) {
+	// Grouped binomial data with the numbers of trials as weights give the
+	// same coefficients as the equivalent Bernoulli observations.
+	xg := mat.NewDense(4, 1, []float64{-1, 0, 1, 2})
+	successes := []float64{1, 3, 4, 7}
+	trials := []float64{5, 6, 6, 8}
+	props := make([]float64, 4)
+	for i := range props {
+		props[i] = successes[i] / trials[i]
+	}
+	var xb, yb []float64
+	for i := range trials {
+		for j := 0; j < int(trials[i]); j++ {
+			xb = append(xb, xg.At(i, 0))
+			if float64(j) < successes[i] {
+				yb = append(yb, 1)
+			} else {
+				yb = append(yb, 0)
+			}
+		}
+	}
+	settings := DefaultGLMSettings()
+	settings.Tolerance = 1e-14
+	grouped, _, err := FitGLM(xg, props, trials, true, Binomial{}, settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bernoulli, _, err := FitGLM(mat.NewDense(len(xb), 1, xb), yb, nil, true, Binomial{}, settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !floats.EqualApprox(grouped.Coef(nil), bernoulli.Coef(nil), 1e-8) {
+		t.Errorf("coefficient mismatch: want %v, got %v", bernoulli.Coef(nil), grouped.Coef(nil))
+	}
+	if !floats.EqualApprox(grouped.StdErr(nil), bernoulli.StdErr(nil), 1e-8) {
+		t.Errorf("standard error mismatch: want %v, got %v", bernoulli.StdErr(nil), grouped.StdErr(nil))
+	}
+	// The deviances differ by a constant, so the differences from the
+	// null deviance agree.
+	dg := grouped.NullDeviance() - grouped.Deviance()
+	db := bernoulli.NullDeviance() - bernoulli.Deviance()
+	if math.Abs(dg-db) > 1e-8 {
+		t.Errorf("deviance reduction mismatch: want %v, got %v", db, dg)
+	}
+}
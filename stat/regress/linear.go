@@ -0,0 +1,260 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/gonum/stat/hyptest"
+)
+
+// Linear is a multiple linear regression model
+//  y = X β + ε
+// fitted by weighted least squares, where the errors ε_i are independent
+// with mean zero and variance σ²/w_i for prior weights w_i.
+type Linear struct {
+	coef      []float64
+	cov       *mat.SymDense
+	resid     []float64
+	intercept bool
+
+	rss, tss float64
+	nobs     int
+}
+
+// FitLinear fits a linear regression of y on the columns of x with the
+// given prior weights by least squares, using the QR factorization of the
+// weighted design matrix. If intercept is true, an intercept term is
+// included in the model as the first coefficient. If weights is nil, then
+// all the weights are 1. Observations with zero weight do not contribute to
+// the fit.
+//
+// FitLinear panics if len(y) is not the number of rows of x, if weights is
+// not nil and len(weights) != len(y), if any weight is negative, or if
+// there are not more observations with positive weight than coefficients.
+// If the design matrix is rank deficient, FitLinear returns a nil model and
+// the error from the solve.
+func FitLinear(x mat.Matrix, y, weights []float64, intercept bool) (*Linear, error) {
+	r, _ := x.Dims()
+	if len(y) != r {
+		panic(badLength)
+	}
+	w, nobs := priorWeights(weights, r)
+	d := design(x, intercept)
+	_, p := d.Dims()
+	if nobs <= p {
+		panic(tooFewObs)
+	}
+	coef, xtwxInv, err := weightedLeastSquares(d, y, w)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Linear{
+		coef:      coef,
+		resid:     make([]float64, r),
+		intercept: intercept,
+		nobs:      nobs,
+	}
+	var sw, swy float64
+	for i := 0; i < r; i++ {
+		var fit float64
+		for j, c := range coef {
+			fit += d.At(i, j) * c
+		}
+		l.resid[i] = y[i] - fit
+		l.rss += w[i] * l.resid[i] * l.resid[i]
+		sw += w[i]
+		swy += w[i] * y[i]
+	}
+	// The total sum of squares is about the mean when the model has an
+	// intercept and about zero otherwise.
+	var mean float64
+	if intercept {
+		mean = swy / sw
+	}
+	for i, v := range y {
+		dv := v - mean
+		l.tss += w[i] * dv * dv
+	}
+	l.cov = mat.NewSymDense(p, nil)
+	l.cov.ScaleSym(l.rss/l.DoF(), xtwxInv)
+	return l, nil
+}
+
+// AdjustedRSquared returns the coefficient of determination adjusted for the
+// number of coefficients,
+//  1 - (1 - R²) (n - k) / (n - p)
+// where n is the number of observations, p is the number of coefficients,
+// and k is one if the model has an intercept and zero otherwise.
+func (l *Linear) AdjustedRSquared() float64 {
+	var k float64
+	if l.intercept {
+		k = 1
+	}
+	n := float64(l.nobs)
+	return 1 - (1-l.RSquared())*(n-k)/l.DoF()
+}
+
+// Coef returns the estimated coefficients of the model. If the model has an
+// intercept, it is the first coefficient. If the input slice is nil, a new
+// slice will be allocated, otherwise the result is stored in-place into dst.
+func (l *Linear) Coef(dst []float64) []float64 {
+	dst = reuseAs(dst, len(l.coef))
+	copy(dst, l.coef)
+	return dst
+}
+
+// ConfInt returns the lower and upper bounds of the confidence intervals of
+// the coefficients at the given confidence level, based on the t
+// distribution with DoF degrees of freedom. If the input slices are nil, new
+// slices will be allocated, otherwise the results are stored in-place.
+//
+// ConfInt panics if level is not in (0, 1).
+func (l *Linear) ConfInt(lower, upper []float64, level float64) ([]float64, []float64) {
+	checkLevel(level)
+	lower = reuseAs(lower, len(l.coef))
+	upper = reuseAs(upper, len(l.coef))
+	q := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: l.DoF()}.Quantile((1 + level) / 2)
+	for i, c := range l.coef {
+		se := math.Sqrt(l.cov.At(i, i))
+		lower[i] = c - q*se
+		upper[i] = c + q*se
+	}
+	return lower, upper
+}
+
+// CovarianceMatrix returns the estimated covariance matrix of the
+// coefficients,
+//  σ² (X^T W X)^-1
+// If the input matrix is nil a new matrix is allocated, otherwise the result
+// is stored in-place into the input.
+func (l *Linear) CovarianceMatrix(s *mat.SymDense) *mat.SymDense {
+	p := len(l.coef)
+	if s == nil {
+		s = mat.NewSymDense(p, nil)
+	} else if s.Symmetric() != p {
+		panic("regress: input matrix size mismatch")
+	}
+	s.CopySym(l.cov)
+	return s
+}
+
+// DoF returns the residual degrees of freedom of the model, the number of
+// observations with positive weight less the number of coefficients.
+func (l *Linear) DoF() float64 {
+	return float64(l.nobs - len(l.coef))
+}
+
+// FTest returns the F test of the null hypothesis that all the coefficients
+// other than the intercept are zero. If the model has no intercept, the
+// null hypothesis is that all the coefficients are zero.
+func (l *Linear) FTest() hyptest.Result {
+	d1 := float64(len(l.coef))
+	if l.intercept {
+		d1--
+	}
+	d2 := l.DoF()
+	f := ((l.tss - l.rss) / d1) / (l.rss / d2)
+	return hyptest.Result{
+		Statistic:   f,
+		PValue:      distuv.F{D1: d1, D2: d2}.Survival(f),
+		DoF:         d1,
+		DenomDoF:    d2,
+		Alternative: hyptest.Greater,
+	}
+}
+
+// PValue returns the two-sided p-values of the t statistics of the
+// coefficients. If the input slice is nil, a new slice will be allocated,
+// otherwise the result is stored in-place into dst.
+func (l *Linear) PValue(dst []float64) []float64 {
+	dst = l.TStat(dst)
+	dist := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: l.DoF()}
+	for i, t := range dst {
+		dst[i] = 2 * dist.Survival(math.Abs(t))
+	}
+	return dst
+}
+
+// Predict returns the predicted response for the predictors x. x must not
+// include the intercept term.
+func (l *Linear) Predict(x []float64) float64 {
+	row := designRow(x, len(l.coef), l.intercept)
+	var y float64
+	for i, c := range l.coef {
+		y += row[i] * c
+	}
+	return y
+}
+
+// PredictInterval returns the bounds of the interval for the response at the
+// predictors x at the given confidence level. If newObs is false, the
+// interval is the confidence interval for the mean response. If newObs is
+// true, the interval is the prediction interval for a new observation with
+// unit weight, which includes the variance of the error term. x must not
+// include the intercept term.
+//
+// PredictInterval panics if level is not in (0, 1).
+func (l *Linear) PredictInterval(x []float64, level float64, newObs bool) (lower, upper float64) {
+	checkLevel(level)
+	row := designRow(x, len(l.coef), l.intercept)
+	y := l.Predict(x)
+	v := quadForm(row, l.cov)
+	if newObs {
+		v += l.rss / l.DoF()
+	}
+	q := distuv.StudentsT{Mu: 0, Sigma: 1, Nu: l.DoF()}.Quantile((1 + level) / 2)
+	se := math.Sqrt(v)
+	return y - q*se, y + q*se
+}
+
+// RSquared returns the coefficient of determination of the model,
+//  R² = 1 - RSS/TSS
+// where RSS is the weighted residual sum of squares and TSS is the weighted
+// total sum of squares about the mean of y, or about zero if the model has
+// no intercept.
+func (l *Linear) RSquared() float64 {
+	return 1 - l.rss/l.tss
+}
+
+// Residuals returns the residuals y - X β of the observations. If the input
+// slice is nil, a new slice will be allocated, otherwise the result is
+// stored in-place into dst.
+func (l *Linear) Residuals(dst []float64) []float64 {
+	dst = reuseAs(dst, len(l.resid))
+	copy(dst, l.resid)
+	return dst
+}
+
+// Sigma returns the residual standard error, the estimate of σ,
+//  σ = √(RSS / DoF)
+func (l *Linear) Sigma() float64 {
+	return math.Sqrt(l.rss / l.DoF())
+}
+
+// StdErr returns the standard errors of the coefficients. If the input
+// slice is nil, a new slice will be allocated, otherwise the result is
+// stored in-place into dst.
+func (l *Linear) StdErr(dst []float64) []float64 {
+	dst = reuseAs(dst, len(l.coef))
+	for i := range dst {
+		dst[i] = math.Sqrt(l.cov.At(i, i))
+	}
+	return dst
+}
+
+// TStat returns the t statistics of the coefficients, the ratio of each
+// coefficient to its standard error. If the input slice is nil, a new slice
+// will be allocated, otherwise the result is stored in-place into dst.
+func (l *Linear) TStat(dst []float64) []float64 {
+	dst = l.StdErr(dst)
+	for i, c := range l.coef {
+		dst[i] = c / dst[i]
+	}
+	return dst
+}
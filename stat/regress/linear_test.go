@@ -0,0 +1,192 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+// plantGrowth returns the PlantGrowth data set with treatment indicator
+// predictors.
+func plantGrowth() (*mat.Dense, []float64) {
+	y := []float64{
+		4.17, 5.58, 5.18, 6.11, 4.50, 4.61, 5.17, 4.53, 5.33, 5.14,
+		4.81, 4.17, 4.41, 3.59, 5.87, 3.83, 6.03, 4.89, 4.32, 4.69,
+		6.31, 5.12, 5.54, 5.50, 5.37, 5.29, 4.92, 6.15, 5.80, 5.26,
+	}
+	x := mat.NewDense(30, 2, nil)
+	for i := 10; i < 20; i++ {
+		x.Set(i, 0, 1)
+	}
+	for i := 20; i < 30; i++ {
+		x.Set(i, 1, 1)
+	}
+	return x, y
+}
+
+func TestLinearPlantGrowth(t *testing.T) {
+	x, y := plantGrowth()
+	l, err := FitLinear(x, y, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Reference values from R.
+	for _, test := range []struct {
+		name      string
+		got, want []float64
+		tol       float64
+	}{
+		{"coefficients", l.Coef(nil), []float64{5.032, -0.371, 0.494}, 1e-12},
+		{"standard errors", l.StdErr(nil), []float64{0.1971, 0.2788, 0.2788}, 1e-4},
+		{"t statistics", l.TStat(nil), []float64{25.527, -1.331, 1.772}, 1e-3},
+		{"p-values", l.PValue(nil)[1:], []float64{0.1944, 0.0877}, 1e-4},
+	} {
+		if !floats.EqualApprox(test.got, test.want, test.tol) {
+			t.Errorf("%s mismatch: want %v, got %v", test.name, test.want, test.got)
+		}
+	}
+	for _, test := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"sigma", l.Sigma(), 0.6234},
+		{"R²", l.RSquared(), 0.2641},
+		{"adjusted R²", l.AdjustedRSquared(), 0.2096},
+		{"F statistic", l.FTest().Statistic, 4.846},
+		{"F p-value", l.FTest().PValue, 0.01591},
+	} {
+		if !floats.EqualWithinRel(test.got, test.want, 5e-4) {
+			t.Errorf("%s mismatch: want %v, got %v", test.name, test.want, test.got)
+		}
+	}
+	if l.DoF() != 27 {
+		t.Errorf("degrees of freedom mismatch: want 27, got %v", l.DoF())
+	}
+}
+
+func TestLinear(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n = 50
+	x := mat.NewDense(n, 3, nil)
+	y := make([]float64, n)
+	weights := make([]float64, n)
+	beta := []float64{1, -2, 0.5, 3}
+	for i := 0; i < n; i++ {
+		y[i] = beta[0]
+		for j := 0; j < 3; j++ {
+			v := src.NormFloat64()
+			x.Set(i, j, v)
+			y[i] += beta[j+1] * v
+		}
+		y[i] += 0.3 * src.NormFloat64()
+		weights[i] = 0.5 + src.Float64()
+	}
+
+	for _, w := range [][]float64{nil, weights} {
+		for _, intercept := range []bool{true, false} {
+			l, err := FitLinear(x, y, w, intercept)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			d := design(x, intercept)
+			_, p := d.Dims()
+			ww := make([]float64, n)
+			for i := range ww {
+				ww[i] = 1
+				if w != nil {
+					ww[i] = w[i]
+				}
+			}
+
+			// The coefficients solve the weighted normal equations.
+			xtwx := mat.NewSymDense(p, nil)
+			xtwy := make([]float64, p)
+			for i := 0; i < n; i++ {
+				row := d.RawRowView(i)
+				xtwx.SymRankOne(xtwx, ww[i], mat.NewVecDense(p, row))
+				floats.AddScaled(xtwy, ww[i]*y[i], row)
+			}
+			var want mat.VecDense
+			if err := want.SolveVec(xtwx, mat.NewVecDense(p, xtwy)); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			coef := l.Coef(nil)
+			if !floats.EqualApprox(coef, want.RawVector().Data, 1e-10) {
+				t.Errorf("intercept=%t, weighted=%t: coefficient mismatch: want %v, got %v", intercept, w != nil, want.RawVector().Data, coef)
+			}
+
+			// The covariance is σ² (X^T W X)^-1.
+			var inv mat.Dense
+			if err := inv.Inverse(xtwx); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			inv.Scale(l.Sigma()*l.Sigma(), &inv)
+			if !mat.EqualApprox(l.CovarianceMatrix(nil), &inv, 1e-10) {
+				t.Errorf("intercept=%t, weighted=%t: covariance mismatch", intercept, w != nil)
+			}
+
+			// The weighted residuals are orthogonal to the predictors.
+			resid := l.Residuals(nil)
+			for j := 0; j < p; j++ {
+				var s float64
+				for i := 0; i < n; i++ {
+					s += ww[i] * resid[i] * d.At(i, j)
+				}
+				if math.Abs(s) > 1e-10 {
+					t.Errorf("intercept=%t, weighted=%t: residuals not orthogonal to column %d: %v", intercept, w != nil, j, s)
+				}
+			}
+
+			// The confidence intervals are symmetric about the
+			// coefficients.
+			lower, upper := l.ConfInt(nil, nil, 0.95)
+			se := l.StdErr(nil)
+			for i := range coef {
+				if math.Abs((lower[i]+upper[i])/2-coef[i]) > 1e-12 || upper[i]-lower[i] < 2*se[i] {
+					t.Errorf("intercept=%t, weighted=%t: bad confidence interval [%v, %v] for %v", intercept, w != nil, lower[i], upper[i], coef[i])
+				}
+			}
+
+			// The prediction interval contains the confidence interval.
+			q := []float64{0.1, -0.2, 0.3}
+			yhat := l.Predict(q)
+			cl, cu := l.PredictInterval(q, 0.9, false)
+			pl, pu := l.PredictInterval(q, 0.9, true)
+			if !(pl < cl && cl < yhat && yhat < cu && cu < pu) {
+				t.Errorf("intercept=%t, weighted=%t: bad intervals: prediction [%v, %v], confidence [%v, %v], fit %v", intercept, w != nil, pl, pu, cl, cu, yhat)
+			}
+		}
+	}
+
+	// A single predictor agrees with stat.LinearRegression.
+	xs := mat.Col(nil, 0, x)
+	alpha, b := stat.LinearRegression(xs, y, weights, false)
+	l, err := FitLinear(mat.NewDense(n, 1, xs), y, weights, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !floats.EqualApprox(l.Coef(nil), []float64{alpha, b}, 1e-10) {
+		t.Errorf("coefficient mismatch with LinearRegression: want %v, got %v", []float64{alpha, b}, l.Coef(nil))
+	}
+	if r2 := stat.RSquared(xs, y, weights, alpha, b); math.Abs(l.RSquared()-r2) > 1e-10 {
+		t.Errorf("R² mismatch with RSquared: want %v, got %v", r2, l.RSquared())
+	}
+
+	// A rank deficient design is an error.
+	xd := mat.NewDense(n, 2, nil)
+	for i := 0; i < n; i++ {
+		xd.Set(i, 0, x.At(i, 0))
+		xd.Set(i, 1, 2*x.At(i, 0))
+	}
+	if _, err := FitLinear(xd, y, nil, true); err == nil {
+		t.Errorf("expected error for rank deficient design")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mathext"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Link is the link function of a generalized linear model, relating the mean
+// μ of the response to the linear predictor η = g(μ).
+type Link interface {
+	// Link returns the value of the linear predictor η = g(μ).
+	Link(mu float64) float64
+
+	// Inverse returns the mean μ = g^-1(η).
+	Inverse(eta float64) float64
+
+	// Deriv returns the derivative dη/dμ of the link function at μ.
+	Deriv(mu float64) float64
+}
+
+// epsilon is the smallest mean returned by links whose inverse is bounded
+// away from zero.
+const epsilon = 2.220446049250313e-16
+
+// IdentityLink is the link g(μ) = μ. It is the canonical link of the
+// Gaussian family.
+type IdentityLink struct{}
+
+// Link returns μ.
+func (IdentityLink) Link(mu float64) float64 { return mu }
+
+// Inverse returns η.
+func (IdentityLink) Inverse(eta float64) float64 { return eta }
+
+// Deriv returns 1.
+func (IdentityLink) Deriv(mu float64) float64 { return 1 }
+
+// LogLink is the link g(μ) = log(μ). It is the canonical link of the Poisson
+// family.
+type LogLink struct{}
+
+// Link returns log(μ).
+func (LogLink) Link(mu float64) float64 { return math.Log(mu) }
+
+// Inverse returns exp(η), bounded below by the machine epsilon.
+func (LogLink) Inverse(eta float64) float64 { return math.Max(math.Exp(eta), epsilon) }
+
+// Deriv returns 1/μ.
+func (LogLink) Deriv(mu float64) float64 { return 1 / mu }
+
+// LogitLink is the link g(μ) = log(μ/(1-μ)). It is the canonical link of the
+// binomial family.
+type LogitLink struct{}
+
+// Link returns log(μ/(1-μ)).
+func (LogitLink) Link(mu float64) float64 { return math.Log(mu / (1 - mu)) }
+
+// Inverse returns 1/(1+exp(-η)), bounded away from zero and one by the
+// machine epsilon.
+func (LogitLink) Inverse(eta float64) float64 {
+	const thresh = 36.04365338911715 // -log(epsilon)
+	eta = math.Max(-thresh, math.Min(thresh, eta))
+	return 1 / (1 + math.Exp(-eta))
+}
+
+// Deriv returns 1/(μ(1-μ)).
+func (LogitLink) Deriv(mu float64) float64 { return 1 / (mu * (1 - mu)) }
+
+// ProbitLink is the link g(μ) = Φ^-1(μ), where Φ is the cumulative
+// distribution function of the standard normal distribution.
+type ProbitLink struct{}
+
+// Link returns Φ^-1(μ).
+func (ProbitLink) Link(mu float64) float64 { return mathext.NormalQuantile(mu) }
+
+// Inverse returns Φ(η), bounded away from zero and one by the machine
+// epsilon.
+func (ProbitLink) Inverse(eta float64) float64 {
+	return math.Max(epsilon, math.Min(1-epsilon, distuv.UnitNormal.CDF(eta)))
+}
+
+// Deriv returns 1/φ(Φ^-1(μ)), where φ is the density of the standard normal
+// distribution.
+func (ProbitLink) Deriv(mu float64) float64 {
+	return 1 / distuv.UnitNormal.Prob(mathext.NormalQuantile(mu))
+}
+
+// CLogLogLink is the complementary log-log link g(μ) = log(-log(1-μ)).
+type CLogLogLink struct{}
+
+// Link returns log(-log(1-μ)).
+func (CLogLogLink) Link(mu float64) float64 { return math.Log(-math.Log1p(-mu)) }
+
+// Inverse returns 1-exp(-exp(η)), bounded away from zero and one by the
+// machine epsilon.
+func (CLogLogLink) Inverse(eta float64) float64 {
+	return math.Max(epsilon, math.Min(1-epsilon, -math.Expm1(-math.Exp(eta))))
+}
+
+// Deriv returns -1/((1-μ) log(1-μ)).
+func (CLogLogLink) Deriv(mu float64) float64 { return -1 / ((1 - mu) * math.Log1p(-mu)) }
+
+// InverseLink is the link g(μ) = 1/μ. It is the canonical link of the Gamma
+// family.
+type InverseLink struct{}
+
+// Link returns 1/μ.
+func (InverseLink) Link(mu float64) float64 { return 1 / mu }
+
+// Inverse returns 1/η.
+func (InverseLink) Inverse(eta float64) float64 { return 1 / eta }
+
+// Deriv returns -1/μ².
+func (InverseLink) Deriv(mu float64) float64 { return -1 / (mu * mu) }
+
+// SqrtLink is the link g(μ) = √μ.
+type SqrtLink struct{}
+
+// Link returns √μ.
+func (SqrtLink) Link(mu float64) float64 { return math.Sqrt(mu) }
+
+// Inverse returns η².
+func (SqrtLink) Inverse(eta float64) float64 { return eta * eta }
+
+// Deriv returns 1/(2√μ).
+func (SqrtLink) Deriv(mu float64) float64 { return 0.5 / math.Sqrt(mu) }
@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLink(t *testing.T) {
+	for _, test := range []struct {
+		link Link
+		mu   []float64
+	}{
+		{IdentityLink{}, []float64{-3, 0, 0.5, 10}},
+		{LogLink{}, []float64{0.01, 0.5, 1, 20}},
+		{LogitLink{}, []float64{0.01, 0.3, 0.5, 0.9}},
+		{ProbitLink{}, []float64{0.01, 0.3, 0.5, 0.9}},
+		{CLogLogLink{}, []float64{0.01, 0.3, 0.5, 0.9}},
+		{InverseLink{}, []float64{0.1, 0.5, 2, 10}},
+		{SqrtLink{}, []float64{0.1, 0.5, 2, 10}},
+	} {
+		for _, mu := range test.mu {
+			eta := test.link.Link(mu)
+			if got := test.link.Inverse(eta); math.Abs(got-mu) > 1e-12*math.Max(1, math.Abs(mu)) {
+				t.Errorf("%T: inverse mismatch at %v: got %v", test.link, mu, got)
+			}
+			const h = 1e-6
+			want := (test.link.Link(mu+h*mu) - test.link.Link(mu-h*mu)) / (2 * h * mu)
+			if mu == 0 {
+				want = (test.link.Link(h) - test.link.Link(-h)) / (2 * h)
+			}
+			if got := test.link.Deriv(mu); math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+				t.Errorf("%T: derivative mismatch at %v: want %v, got %v", test.link, mu, want, got)
+			}
+		}
+	}
+
+	// Inverse links of bounded means stay within their bounds.
+	for _, link := range []Link{LogitLink{}, ProbitLink{}, CLogLogLink{}} {
+		for _, eta := range []float64{-1000, 1000} {
+			mu := link.Inverse(eta)
+			if !(mu > 0 && mu < 1) {
+				t.Errorf("%T: mean out of bounds at %v: %v", link, eta, mu)
+			}
+		}
+	}
+	if mu := (LogLink{}).Inverse(-1000); !(mu > 0) {
+		t.Errorf("LogLink: mean not positive: %v", mu)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package regress
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const (
+	badLength     = "regress: slice length mismatch"
+	badLevel      = "regress: confidence level out of range"
+	badPredictors = "regress: predictor length mismatch"
+	badWeight     = "regress: negative weight"
+	tooFewObs     = "regress: too few observations"
+)
+
+// design returns the design matrix formed from x, with a leading column of
+// ones if intercept is true.
+func design(x mat.Matrix, intercept bool) *mat.Dense {
+	r, c := x.Dims()
+	if !intercept {
+		return mat.DenseCopyOf(x)
+	}
+	d := mat.NewDense(r, c+1, nil)
+	for i := 0; i < r; i++ {
+		d.Set(i, 0, 1)
+		for j := 0; j < c; j++ {
+			d.Set(i, j+1, x.At(i, j))
+		}
+	}
+	return d
+}
+
+// designRow returns the row of the design matrix for the predictors x.
+func designRow(x []float64, p int, intercept bool) []float64 {
+	if !intercept {
+		if len(x) != p {
+			panic(badPredictors)
+		}
+		return x
+	}
+	if len(x) != p-1 {
+		panic(badPredictors)
+	}
+	row := make([]float64, p)
+	row[0] = 1
+	copy(row[1:], x)
+	return row
+}
+
+// priorWeights returns a copy of weights, or a slice of ones if weights is
+// nil, and the number of positive weights.
+func priorWeights(weights []float64, n int) ([]float64, int) {
+	if weights != nil && len(weights) != n {
+		panic(badLength)
+	}
+	w := make([]float64, n)
+	var pos int
+	for i := range w {
+		w[i] = 1
+		if weights != nil {
+			if weights[i] < 0 {
+				panic(badWeight)
+			}
+			w[i] = weights[i]
+		}
+		if w[i] > 0 {
+			pos++
+		}
+	}
+	return w, pos
+}
+
+// weightedLeastSquares returns the coefficients β minimizing
+//  \sum_i w_i (z_i - x_i β)^2
+// and the matrix (X^T W X)^-1, computed from the QR factorization of
+// W^(1/2) X. If X^T W X is singular or near-singular, a Condition error is
+// returned.
+func weightedLeastSquares(x *mat.Dense, z, w []float64) ([]float64, *mat.SymDense, error) {
+	r, c := x.Dims()
+	xw := mat.NewDense(r, c, nil)
+	zw := mat.NewVecDense(r, nil)
+	for i := 0; i < r; i++ {
+		s := math.Sqrt(w[i])
+		for j := 0; j < c; j++ {
+			xw.Set(i, j, s*x.At(i, j))
+		}
+		zw.SetVec(i, s*z[i])
+	}
+	var qr mat.QR
+	qr.Factorize(xw)
+	var b mat.VecDense
+	if err := qr.SolveVec(&b, false, zw); err != nil {
+		return nil, nil, err
+	}
+	beta := make([]float64, c)
+	for i := range beta {
+		beta[i] = b.AtVec(i)
+	}
+
+	// (X^T W X)^-1 = R^-1 R^-T.
+	rm := qr.RTo(nil)
+	rt := mat.NewTriDense(c, mat.Upper, nil)
+	rt.Copy(rm.Slice(0, c, 0, c))
+	var rinv mat.TriDense
+	if err := rinv.InverseTri(rt); err != nil {
+		return nil, nil, err
+	}
+	cov := mat.NewSymDense(c, nil)
+	cov.SymOuterK(1, &rinv)
+	return beta, cov, nil
+}
+
+// quadForm returns x^T A x.
+func quadForm(x []float64, a mat.Symmetric) float64 {
+	v := mat.NewVecDense(len(x), x)
+	return mat.Inner(v, a, v)
+}
+
+// checkLevel panics if level is not in (0, 1).
+func checkLevel(level float64) {
+	if !(level > 0 && level < 1) {
+		panic(badLevel)
+	}
+}
+
+// reuseAs returns a slice of length n. If len(x) == n, x is returned. If x is
+// empty, x is resliced or a new slice is allocated. Otherwise reuseAs panics.
+func reuseAs(x []float64, n int) []float64 {
+	if len(x) == n {
+		return x
+	}
+	if len(x) == 0 {
+		if cap(x) >= n {
+			return x[:n]
+		}
+		return make([]float64, n)
+	}
+	panic(badLength)
+}
@@ -0,0 +1,194 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math/rand"
+	"sync"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// BootstrapSettings are the settings for drawing bootstrap resamples.
+type BootstrapSettings struct {
+	// Block is the length of the blocks of consecutive observations
+	// drawn by the moving block bootstrap of
+	//  Künsch, H. R. "The jackknife and the bootstrap for general
+	//  stationary observations." The Annals of Statistics 17.3 (1989):
+	//  1217-1241.
+	// which preserves the dependence of serially correlated data within
+	// blocks. If Block is zero or one, observations are drawn
+	// independently.
+	Block int
+
+	// Circular specifies that blocks wrap around from the last
+	// observation to the first, so that every observation is equally
+	// likely to be drawn. Circular is ignored if Block is zero or one.
+	Circular bool
+
+	// Concurrent is the number of goroutines used to evaluate the
+	// resamples. Each goroutine draws from its own random source, seeded
+	// from the source passed to the bootstrap function before any
+	// goroutine starts, so the result does not depend on scheduling. If
+	// Concurrent is zero or one, the resamples are evaluated in the
+	// calling goroutine.
+	Concurrent int
+}
+
+// Bootstrap returns the values of statistic on n bootstrap resamples of the
+// weighted sample x. Each resample has len(x) observations drawn with
+// replacement from x, each carrying its weight. If weights is nil, all the
+// weights are 1. If settings is nil, observations are drawn independently
+// in the calling goroutine. If src is nil, the global source in math/rand
+// is used.
+//
+// Observations are drawn uniformly, not in proportion to their weights, so
+// that each resample is a sample from the same design as x and statistic
+// applies the weights exactly as it does to x. The returned values then
+// estimate the sampling distribution of the weighted statistic itself.
+// Drawing in proportion to the weights and also passing them to statistic
+// would count them twice, and block resampling requires uniformly drawn
+// block starting points.
+//
+// If the input slice is nil, a new slice will be allocated, otherwise the
+// result is stored in-place into dst, which must have length n. If
+// settings.Concurrent is greater than one, statistic must be safe for
+// concurrent use.
+//
+// Bootstrap panics if n is less than one, if x is empty, if weights is not
+// nil and has length other than len(x), or if settings.Block is negative or
+// greater than len(x).
+func Bootstrap(dst []float64, n int, x, weights []float64, statistic Statistic, settings *BootstrapSettings, src *rand.Rand) []float64 {
+	return bootstrap(dst, n, newVectorSample(x, weights, statistic), settings, src)
+}
+
+// BootstrapRows returns the values of statistic on n bootstrap resamples of
+// the weighted sample of vectors in the rows of x, as described for
+// Bootstrap.
+func BootstrapRows(dst []float64, n int, x mat.Matrix, weights []float64, statistic MatrixStatistic, settings *BootstrapSettings, src *rand.Rand) []float64 {
+	return bootstrap(dst, n, newRowSample(x, weights, statistic), settings, src)
+}
+
+func bootstrap(dst []float64, n int, s sample, settings *BootstrapSettings, src *rand.Rand) []float64 {
+	if n < 1 {
+		panic(noResamples)
+	}
+	size := s.len()
+	if size == 0 {
+		panic(tooFewSamples)
+	}
+	dst = reuseAs(dst, n)
+	if settings == nil {
+		settings = &BootstrapSettings{}
+	}
+	block := settings.Block
+	if block < 0 || block > size {
+		panic(badBlock)
+	}
+	if block == 0 {
+		block = 1
+	}
+	circular := settings.Circular
+
+	workers := settings.Concurrent
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		bootstrapSerial(dst, s, block, circular, src)
+		return dst
+	}
+	var wg sync.WaitGroup
+	chunk := (n + workers - 1) / workers
+	for lo := 0; lo < n; lo += chunk {
+		hi := lo + chunk
+		if hi > n {
+			hi = n
+		}
+		var seed int64
+		if src == nil {
+			seed = rand.Int63()
+		} else {
+			seed = src.Int63()
+		}
+		wg.Add(1)
+		go func(dst []float64, s sample, src *rand.Rand) {
+			defer wg.Done()
+			bootstrapSerial(dst, s, block, circular, src)
+		}(dst[lo:hi], s.clone(), rand.New(rand.NewSource(seed)))
+	}
+	wg.Wait()
+	return dst
+}
+
+// bootstrapSerial stores the values of the statistic of s on len(dst)
+// bootstrap resamples into dst.
+func bootstrapSerial(dst []float64, s sample, block int, circular bool, src *rand.Rand) {
+	idx := make([]int, s.len())
+	for i := range dst {
+		drawBlocks(idx, block, circular, src)
+		dst[i] = s.eval(idx)
+	}
+}
+
+// drawBlocks fills idx with the indices of blocks of block consecutive
+// observations with uniformly drawn starting points, truncating the last
+// block. If circular is true, blocks wrap around from the last observation
+// to the first.
+func drawBlocks(idx []int, block int, circular bool, src *rand.Rand) {
+	n := len(idx)
+	starts := n - block + 1
+	if circular {
+		starts = n
+	}
+	for i := 0; i < n; i += block {
+		var start int
+		if src == nil {
+			start = rand.Intn(starts)
+		} else {
+			start = src.Intn(starts)
+		}
+		for j := 0; j < block && i+j < n; j++ {
+			idx[i+j] = (start + j) % n
+		}
+	}
+}
+
+// Jackknife returns the values of statistic on the len(x) samples formed by
+// leaving one observation out of the weighted sample x. If weights is nil,
+// all the weights are 1. If the input slice is nil, a new slice will be
+// allocated, otherwise the result is stored in-place into dst, which must
+// have length len(x). Jackknife panics if len(x) is less than two or if
+// weights is not nil and has length other than len(x).
+func Jackknife(dst, x, weights []float64, statistic Statistic) []float64 {
+	return jackknife(dst, newVectorSample(x, weights, statistic))
+}
+
+// JackknifeRows returns the values of statistic on the samples formed by
+// leaving one row out of the weighted sample of vectors in the rows of x,
+// as described for Jackknife.
+func JackknifeRows(dst []float64, x mat.Matrix, weights []float64, statistic MatrixStatistic) []float64 {
+	return jackknife(dst, newRowSample(x, weights, statistic))
+}
+
+func jackknife(dst []float64, s sample) []float64 {
+	n := s.len()
+	if n < 2 {
+		panic(tooFewSamples)
+	}
+	dst = reuseAs(dst, n)
+	idx := make([]int, n-1)
+	for i := range idx {
+		idx[i] = i + 1
+	}
+	for i := range dst {
+		// idx holds every index other than i.
+		dst[i] = s.eval(idx)
+		if i < n-1 {
+			idx[i] = i
+		}
+	}
+	return dst
+}
@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func mean(x, weights []float64) float64 {
+	return stat.Mean(x, weights)
+}
+
+func TestBootstrap(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = 10 + 3*src.NormFloat64()
+	}
+	// The bootstrap standard error of the mean is the population
+	// standard deviation of the sample divided by √n.
+	want := stat.StdDev(x, nil) * math.Sqrt(float64(len(x)-1)/float64(len(x))) / math.Sqrt(float64(len(x)))
+	for _, concurrent := range []int{0, 1, 4} {
+		settings := &BootstrapSettings{Concurrent: concurrent}
+		reps := Bootstrap(nil, 20000, x, nil, mean, settings, rand.New(rand.NewSource(2)))
+		if got := stat.StdDev(reps, nil); math.Abs(got-want) > 0.03*want {
+			t.Errorf("Bootstrap standard error mismatch with %d goroutines: want %v, got %v", concurrent, want, got)
+		}
+		if got := stat.Mean(reps, nil); math.Abs(got-stat.Mean(x, nil)) > 0.05*want {
+			t.Errorf("Bootstrap mean mismatch with %d goroutines: want %v, got %v", concurrent, stat.Mean(x, nil), got)
+		}
+		again := Bootstrap(nil, 20000, x, nil, mean, settings, rand.New(rand.NewSource(2)))
+		if !floats.Equal(reps, again) {
+			t.Errorf("Bootstrap not reproducible with %d goroutines", concurrent)
+		}
+	}
+
+	// Resamples of a matrix with a single column match resamples of the
+	// column.
+	m := mat.NewDense(len(x), 1, x)
+	rows := BootstrapRows(nil, 100, m, nil, func(x mat.Matrix, weights []float64) float64 {
+		return stat.Mean(mat.Col(nil, 0, x), weights)
+	}, nil, rand.New(rand.NewSource(3)))
+	vec := Bootstrap(nil, 100, x, nil, mean, nil, rand.New(rand.NewSource(3)))
+	if !floats.Equal(rows, vec) {
+		t.Errorf("BootstrapRows mismatch with Bootstrap")
+	}
+}
+
+func TestBootstrapWeights(t *testing.T) {
+	// Each resampled observation carries its own weight.
+	x := []float64{1, 2, 3, 4, 5, 6}
+	w := []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6}
+	src := rand.New(rand.NewSource(1))
+	Bootstrap(nil, 100, x, w, func(x, weights []float64) float64 {
+		if len(x) != 6 || len(weights) != 6 {
+			t.Fatalf("Unexpected resample size")
+		}
+		for i, v := range x {
+			if weights[i] != v/10 {
+				t.Errorf("Weight %v does not match observation %v", weights[i], v)
+			}
+		}
+		return 0
+	}, nil, src)
+}
+
+func TestBlockBootstrap(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, circular := range []bool{false, true} {
+		idx := make([]int, 23)
+		for trial := 0; trial < 100; trial++ {
+			drawBlocks(idx, 5, circular, src)
+			for i := 0; i < len(idx); i += 5 {
+				if !circular && idx[i] > len(idx)-5 {
+					t.Errorf("Block start %d out of range", idx[i])
+				}
+				for j := i + 1; j < i+5 && j < len(idx); j++ {
+					if idx[j] != (idx[j-1]+1)%len(idx) {
+						t.Errorf("Block not consecutive: %v", idx[i:j+1])
+					}
+				}
+			}
+		}
+	}
+
+	// The block bootstrap captures the long-run variance of the mean of
+	// an AR(1) series, σ²/(n(1-φ)²), which the independent bootstrap
+	// underestimates.
+	const n, phi = 2000, 0.6
+	x := make([]float64, n)
+	for i := 1; i < n; i++ {
+		x[i] = phi*x[i-1] + src.NormFloat64()
+	}
+	want := 1 / (math.Sqrt(n) * (1 - phi))
+	block := stat.StdDev(Bootstrap(nil, 2000, x, nil, mean, &BootstrapSettings{Block: 50, Circular: true}, src), nil)
+	if math.Abs(block-want) > 0.2*want {
+		t.Errorf("Block bootstrap standard error mismatch: want %v, got %v", want, block)
+	}
+	iid := stat.StdDev(Bootstrap(nil, 2000, x, nil, mean, nil, src), nil)
+	if iid > 0.6*want {
+		t.Errorf("Independent bootstrap standard error unexpectedly large: %v", iid)
+	}
+}
+
+func TestJackknife(t *testing.T) {
+	x := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+	w := []float64{1, 2, 1, 2, 1, 2, 1, 2}
+	sum := floats.Dot(x, w)
+	total := floats.Sum(w)
+	got := Jackknife(nil, x, w, mean)
+	m := mat.NewDense(len(x), 1, x)
+	rows := JackknifeRows(nil, m, w, func(x mat.Matrix, weights []float64) float64 {
+		return stat.Mean(mat.Col(nil, 0, x), weights)
+	})
+	for i := range x {
+		want := (sum - w[i]*x[i]) / (total - w[i])
+		if math.Abs(got[i]-want) > 1e-14 || math.Abs(rows[i]-want) > 1e-14 {
+			t.Errorf("Jackknife mismatch at %d: want %v, got %v and %v", i, want, got[i], rows[i])
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package resample provides bootstrap and permutation methods for inference
+// about arbitrary statistics.
+//
+// The functions in this package evaluate a statistic of a weighted sample on
+// many resamples of the data. Statistics are given as functions of the
+// observations and their weights, in the form of the functions in package
+// stat, for example
+//  func(x, weights []float64) float64 { return stat.Mean(x, weights) }
+// Samples of vectors are represented by the rows of a mat.Matrix.
+package resample // import "gonum.org/v1/gonum/stat/resample"
@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"sort"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// PercentileInterval returns the bootstrap percentile confidence interval
+// with the given confidence level, the (1-level)/2 and (1+level)/2
+// empirical quantiles of the bootstrap replicates of a statistic.
+// PercentileInterval panics if replicates is empty or if level is not in
+// (0, 1).
+func PercentileInterval(replicates []float64, level float64) (lower, upper float64) {
+	if !(level > 0 && level < 1) {
+		panic(badLevel)
+	}
+	if len(replicates) == 0 {
+		panic(noResamples)
+	}
+	sorted := sortedCopy(replicates)
+	alpha := (1 - level) / 2
+	return stat.Quantile(alpha, stat.Empirical, sorted, nil), stat.Quantile(1-alpha, stat.Empirical, sorted, nil)
+}
+
+// BCaInterval returns the bias-corrected and accelerated bootstrap
+// confidence interval with the given confidence level of
+//  Efron, B. "Better bootstrap confidence intervals." Journal of the
+//  American Statistical Association 82.397 (1987): 171-185.
+// estimate is the value of the statistic on the sample, replicates are its
+// values on bootstrap resamples as returned by Bootstrap, and jackknife are
+// its leave-one-out values as returned by Jackknife, from which the
+// acceleration is estimated. The interval is given by the quantiles of the
+// replicates at levels adjusted for the median bias of the replicates and
+// the rate of change of the standard error of the statistic.
+//
+// BCaInterval returns NaN limits if estimate is not within the range of the
+// replicates. It panics if replicates or jackknife is empty or if level is
+// not in (0, 1).
+func BCaInterval(replicates []float64, estimate float64, jackknife []float64, level float64) (lower, upper float64) {
+	if !(level > 0 && level < 1) {
+		panic(badLevel)
+	}
+	if len(replicates) == 0 || len(jackknife) == 0 {
+		panic(noResamples)
+	}
+
+	// The bias correction is the normal quantile of the fraction of
+	// replicates below the estimate, counting ties as half.
+	var below float64
+	for _, v := range replicates {
+		switch {
+		case v < estimate:
+			below++
+		case v == estimate:
+			below += 0.5
+		}
+	}
+	frac := below / float64(len(replicates))
+	if frac == 0 || frac == 1 {
+		return math.NaN(), math.NaN()
+	}
+	z0 := distuv.UnitNormal.Quantile(frac)
+
+	// The acceleration is estimated from the skewness of the jackknife
+	// influence values.
+	mean := stat.Mean(jackknife, nil)
+	var num, den float64
+	for _, v := range jackknife {
+		d := mean - v
+		num += d * d * d
+		den += d * d
+	}
+	var a float64
+	if den > 0 {
+		a = num / (6 * math.Pow(den, 1.5))
+	}
+
+	sorted := sortedCopy(replicates)
+	limit := func(p float64) float64 {
+		z := distuv.UnitNormal.Quantile(p)
+		adj := distuv.UnitNormal.CDF(z0 + (z0+z)/(1-a*(z0+z)))
+		if math.IsNaN(adj) {
+			return math.NaN()
+		}
+		return stat.Quantile(adj, stat.Empirical, sorted, nil)
+	}
+	return limit((1 - level) / 2), limit((1 + level) / 2)
+}
+
+// sortedCopy returns a sorted copy of x.
+func sortedCopy(x []float64) []float64 {
+	s := make([]float64, len(x))
+	copy(s, x)
+	sort.Float64s(s)
+	return s
+}
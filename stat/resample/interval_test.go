@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestPercentileInterval(t *testing.T) {
+	reps := make([]float64, 1000)
+	for i := range reps {
+		reps[i] = float64(1000 - i)
+	}
+	lower, upper := PercentileInterval(reps, 0.9)
+	if lower != 50 || upper != 950 {
+		t.Errorf("PercentileInterval mismatch: want [50, 950], got [%v, %v]", lower, upper)
+	}
+
+	// With no median bias and no acceleration the BCa interval is the
+	// percentile interval.
+	jack := []float64{-2, -1, 0, 1, 2}
+	bcaLower, bcaUpper := BCaInterval(reps, 500.5, jack, 0.9)
+	if math.Abs(bcaLower-lower) > 1e-12 || math.Abs(bcaUpper-upper) > 1e-12 {
+		t.Errorf("BCaInterval mismatch: want [%v, %v], got [%v, %v]", lower, upper, bcaLower, bcaUpper)
+	}
+	if l, u := BCaInterval(reps, 2000, jack, 0.9); !math.IsNaN(l) || !math.IsNaN(u) {
+		t.Errorf("BCaInterval not NaN for estimate outside the replicates")
+	}
+}
+
+func TestIntervalCoverage(t *testing.T) {
+	// The BCa interval for the mean of a skewed distribution has close to
+	// nominal coverage and corrects the asymmetry that the percentile
+	// interval misses.
+	src := rand.New(rand.NewSource(1))
+	const trials, n, level = 400, 40, 0.9
+	var coverPct, coverBCa, missLowBCa, missHighBCa int
+	for i := 0; i < trials; i++ {
+		x := make([]float64, n)
+		for j := range x {
+			x[j] = src.ExpFloat64()
+		}
+		est := stat.Mean(x, nil)
+		reps := Bootstrap(nil, 1000, x, nil, mean, nil, src)
+		l, u := PercentileInterval(reps, level)
+		if l <= 1 && 1 <= u {
+			coverPct++
+		}
+		l, u = BCaInterval(reps, est, Jackknife(nil, x, nil, mean), level)
+		switch {
+		case 1 < l:
+			missLowBCa++
+		case u < 1:
+			missHighBCa++
+		default:
+			coverBCa++
+		}
+	}
+	if rate := float64(coverBCa) / trials; rate < 0.85 || rate > 0.95 {
+		t.Errorf("BCa coverage %v, want about %v", rate, level)
+	}
+	if rate := float64(coverPct) / trials; rate < 0.8 || rate > 0.95 {
+		t.Errorf("Percentile coverage %v, want about %v", rate, level)
+	}
+	if math.Abs(float64(missLowBCa-missHighBCa)) > 0.06*trials {
+		t.Errorf("BCa misses unbalanced: %d below, %d above", missHighBCa, missLowBCa)
+	}
+}
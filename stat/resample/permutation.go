@@ -0,0 +1,167 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/combin"
+	"gonum.org/v1/gonum/stat/hyptest"
+	"gonum.org/v1/gonum/stat/sampleuv"
+)
+
+// PermutationTest performs a two-sample permutation test of the null
+// hypothesis that the weighted samples x and y are drawn from the same
+// distribution. The test statistic is the difference
+//  statistic(x, xWeights) - statistic(y, yWeights)
+// and its null distribution is obtained by reassigning the pooled
+// observations, each with its weight, to two groups of the original sizes.
+// Under the null hypothesis every assignment is equally likely whatever the
+// weights, so assignments are drawn uniformly by sampleuv.WithoutReplacement
+// rather than in proportion to the weights by sampleuv.Weighted. Drawing by
+// weight would favor assigning heavily weighted observations to x, and the
+// estimated p-value would not converge to the exact one.
+//
+// If the number of distinct assignments is at most n, all of them are
+// enumerated and the p-value is exact. Otherwise n assignments are drawn at
+// random and the p-value is estimated as (1+k)/(1+n), where k is the number
+// of drawn assignments with a statistic at least as extreme as the observed
+// one. Two-sided p-values are twice the smaller one-sided p-value. If src
+// is nil, the global source in math/rand is used.
+//
+// PermutationTest panics if either sample is empty, if exactly one of
+// xWeights and yWeights is nil, if a weights slice does not match the
+// length of its sample, if n is less than one, or if alt is not a known
+// hyptest.Alternative.
+func PermutationTest(x, xWeights, y, yWeights []float64, statistic Statistic, n int, alt hyptest.Alternative, src *rand.Rand) hyptest.Result {
+	if (xWeights == nil) != (yWeights == nil) {
+		panic(badWeights)
+	}
+	if xWeights != nil && (len(xWeights) != len(x) || len(yWeights) != len(y)) {
+		panic(badLength)
+	}
+	pooled := make([]float64, 0, len(x)+len(y))
+	pooled = append(append(pooled, x...), y...)
+	var weights []float64
+	if xWeights != nil {
+		weights = make([]float64, 0, len(x)+len(y))
+		weights = append(append(weights, xWeights...), yWeights...)
+	}
+	return permutationTest(newVectorSample(pooled, weights, statistic), len(x), n, alt, src)
+}
+
+// PermutationTestRows performs a two-sample permutation test of the null
+// hypothesis that the weighted samples of vectors in the rows of x and y
+// are drawn from the same distribution, as described for PermutationTest.
+// PermutationTestRows also panics if x and y have different numbers of
+// columns.
+func PermutationTestRows(x mat.Matrix, xWeights []float64, y mat.Matrix, yWeights []float64, statistic MatrixStatistic, n int, alt hyptest.Alternative, src *rand.Rand) hyptest.Result {
+	if (xWeights == nil) != (yWeights == nil) {
+		panic(badWeights)
+	}
+	rx, cx := x.Dims()
+	ry, cy := y.Dims()
+	if cx != cy {
+		panic(badLength)
+	}
+	if xWeights != nil && (len(xWeights) != rx || len(yWeights) != ry) {
+		panic(badLength)
+	}
+	pooled := mat.NewDense(rx+ry, cx, nil)
+	pooled.Slice(0, rx, 0, cx).(*mat.Dense).Copy(x)
+	pooled.Slice(rx, rx+ry, 0, cx).(*mat.Dense).Copy(y)
+	var weights []float64
+	if xWeights != nil {
+		weights = make([]float64, 0, rx+ry)
+		weights = append(append(weights, xWeights...), yWeights...)
+	}
+	return permutationTest(newRowSample(pooled, weights, statistic), rx, n, alt, src)
+}
+
+func permutationTest(s sample, nx, n int, alt hyptest.Alternative, src *rand.Rand) hyptest.Result {
+	size := s.len()
+	if nx < 1 || size-nx < 1 {
+		panic(tooFewSamples)
+	}
+	if n < 1 {
+		panic(noResamples)
+	}
+	switch alt {
+	case hyptest.TwoSided, hyptest.Less, hyptest.Greater:
+	default:
+		panic("resample: bad alternative")
+	}
+
+	idxX := make([]int, nx)
+	idxY := make([]int, size-nx)
+	inX := make([]bool, size)
+	diff := func() float64 {
+		for i := range inX {
+			inX[i] = false
+		}
+		for _, i := range idxX {
+			inX[i] = true
+		}
+		j := 0
+		for i, in := range inX {
+			if !in {
+				idxY[j] = i
+				j++
+			}
+		}
+		return s.eval(idxX) - s.eval(idxY)
+	}
+
+	for i := range idxX {
+		idxX[i] = i
+	}
+	t := diff()
+	// Permuted statistics equal to the observed statistic up to rounding
+	// are counted as ties.
+	tol := 1e-12 * math.Max(1, math.Abs(t))
+	var greater, less, total float64
+	count := func(v float64) {
+		if v >= t-tol {
+			greater++
+		}
+		if v <= t+tol {
+			less++
+		}
+		total++
+	}
+
+	var pg, pl float64
+	if combin.LogGeneralizedBinomial(float64(size), float64(nx)) <= math.Log(float64(n)) {
+		gen := combin.NewCombinationGenerator(size, nx)
+		for gen.Next() {
+			gen.Combination(idxX)
+			count(diff())
+		}
+		pg, pl = greater/total, less/total
+	} else {
+		for i := 0; i < n; i++ {
+			sampleuv.WithoutReplacement(idxX, size, src)
+			count(diff())
+		}
+		pg, pl = (greater+1)/(total+1), (less+1)/(total+1)
+	}
+
+	var p float64
+	switch alt {
+	case hyptest.TwoSided:
+		p = math.Min(1, 2*math.Min(pg, pl))
+	case hyptest.Less:
+		p = pl
+	case hyptest.Greater:
+		p = pg
+	}
+	return hyptest.Result{
+		Statistic:   t,
+		PValue:      p,
+		Alternative: alt,
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/hyptest"
+)
+
+func TestPermutationTestExact(t *testing.T) {
+	// Of the 20 assignments of 1, ..., 6 to two groups of three, only
+	// the observed one has a mean difference as small as -3.
+	x := []float64{1, 2, 3}
+	y := []float64{4, 5, 6}
+	for _, test := range []struct {
+		alt  hyptest.Alternative
+		want float64
+	}{
+		{hyptest.Less, 0.05},
+		{hyptest.Greater, 1},
+		{hyptest.TwoSided, 0.1},
+	} {
+		res := PermutationTest(x, nil, y, nil, mean, 1000, test.alt, nil)
+		if res.Statistic != -3 {
+			t.Errorf("Statistic mismatch: want -3, got %v", res.Statistic)
+		}
+		if math.Abs(res.PValue-test.want) > 1e-14 {
+			t.Errorf("PValue mismatch for %v: want %v, got %v", test.alt, test.want, res.PValue)
+		}
+		// Equal weights do not change the test.
+		w := []float64{2, 2, 2}
+		if got := PermutationTest(x, w, y, w, mean, 1000, test.alt, nil); got != res {
+			t.Errorf("Weighted test mismatch: want %+v, got %+v", res, got)
+		}
+	}
+
+	// Ties with the observed statistic are counted as at least as extreme.
+	res := PermutationTest([]float64{1, 1}, nil, []float64{1, 1}, nil, mean, 1000, hyptest.Greater, nil)
+	if res.PValue != 1 {
+		t.Errorf("PValue mismatch for tied samples: want 1, got %v", res.PValue)
+	}
+}
+
+func TestPermutationTestMonteCarlo(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	sample := func(n int, shift float64) []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = shift + src.NormFloat64()
+		}
+		return x
+	}
+
+	res := PermutationTest(sample(30, 1), nil, sample(40, 0), nil, mean, 2000, hyptest.Greater, src)
+	if res.PValue > 0.01 {
+		t.Errorf("Failed to detect shift: p-value %v", res.PValue)
+	}
+	if res.PValue < 1.0/2001 {
+		t.Errorf("PValue below the Monte Carlo minimum: %v", res.PValue)
+	}
+
+	// The Monte Carlo p-value agrees with the t-test for normal samples.
+	x, y := sample(25, 0.4), sample(25, 0)
+	perm := PermutationTest(x, nil, y, nil, mean, 20000, hyptest.TwoSided, src)
+	tt := hyptest.TwoSampleTTest(x, y, hyptest.TwoSided)
+	if math.Abs(perm.PValue-tt.PValue) > 0.02 {
+		t.Errorf("PValue mismatch with t-test: want %v, got %v", tt.PValue, perm.PValue)
+	}
+
+	// Assignments of weighted samples are drawn uniformly, so the Monte
+	// Carlo p-value converges to the enumerated one.
+	wx, wy := sample(6, 0.5), sample(6, 0)
+	xWeights := []float64{1, 5, 1, 0.2, 3, 1}
+	yWeights := []float64{2, 0.5, 1, 4, 1, 1}
+	exact := PermutationTest(wx, xWeights, wy, yWeights, mean, 1000, hyptest.Greater, src)
+	approx := PermutationTest(wx, xWeights, wy, yWeights, mean, 900, hyptest.Greater, src)
+	if math.Abs(exact.PValue-approx.PValue) > 0.05 {
+		t.Errorf("Weighted PValue mismatch with enumeration: want %v, got %v", exact.PValue, approx.PValue)
+	}
+
+	// Matrix samples with a single column match vector samples.
+	rows := PermutationTestRows(mat.NewDense(len(x), 1, x), nil, mat.NewDense(len(y), 1, y), nil,
+		func(x mat.Matrix, weights []float64) float64 {
+			return stat.Mean(mat.Col(nil, 0, x), weights)
+		}, 500, hyptest.TwoSided, rand.New(rand.NewSource(2)))
+	vec := PermutationTest(x, nil, y, nil, mean, 500, hyptest.TwoSided, rand.New(rand.NewSource(2)))
+	if rows != vec {
+		t.Errorf("PermutationTestRows mismatch: want %+v, got %+v", vec, rows)
+	}
+
+	// The rejection rate under the null is close to the nominal level.
+	const trials = 200
+	var reject int
+	for i := 0; i < trials; i++ {
+		if PermutationTest(sample(15, 0), nil, sample(20, 0), nil, mean, 200, hyptest.TwoSided, src).PValue < 0.1 {
+			reject++
+		}
+	}
+	if rate := float64(reject) / trials; rate < 0.05 || rate > 0.16 {
+		t.Errorf("Rejection rate under the null: %v", rate)
+	}
+}
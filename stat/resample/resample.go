@@ -0,0 +1,153 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import "gonum.org/v1/gonum/mat"
+
+const (
+	badBlock      = "resample: block length out of range"
+	badLength     = "resample: slice length mismatch"
+	badLevel      = "resample: confidence level out of range"
+	badWeights    = "resample: weights must be given for both or neither sample"
+	noResamples   = "resample: no resamples"
+	tooFewSamples = "resample: too few samples"
+)
+
+// Statistic is a statistic of the sample x with the given weights. If
+// weights is nil, all the weights are 1. A Statistic may modify x and
+// weights, but must not retain them.
+type Statistic func(x, weights []float64) float64
+
+// MatrixStatistic is a statistic of the sample of vectors in the rows of x
+// with the given weights. If weights is nil, all the weights are 1. A
+// MatrixStatistic must not retain x or weights.
+type MatrixStatistic func(x mat.Matrix, weights []float64) float64
+
+// sample is a set of weighted observations on which a statistic can be
+// evaluated for any selection of the observations.
+type sample interface {
+	// len returns the number of observations.
+	len() int
+
+	// eval returns the statistic of the observations with the given
+	// indices, repeated as often as they appear.
+	eval(idx []int) float64
+
+	// clone returns a copy of the sample that may be evaluated
+	// concurrently with the receiver.
+	clone() sample
+}
+
+// vectorSample is a sample of scalar observations.
+type vectorSample struct {
+	x, weights []float64
+	statistic  Statistic
+
+	xbuf, wbuf []float64
+}
+
+func newVectorSample(x, weights []float64, statistic Statistic) *vectorSample {
+	if weights != nil && len(weights) != len(x) {
+		panic(badLength)
+	}
+	s := &vectorSample{
+		x:         x,
+		weights:   weights,
+		statistic: statistic,
+		xbuf:      make([]float64, len(x)),
+	}
+	if weights != nil {
+		s.wbuf = make([]float64, len(x))
+	}
+	return s
+}
+
+func (s *vectorSample) len() int { return len(s.x) }
+
+func (s *vectorSample) eval(idx []int) float64 {
+	x := s.xbuf[:len(idx)]
+	for i, j := range idx {
+		x[i] = s.x[j]
+	}
+	var w []float64
+	if s.weights != nil {
+		w = s.wbuf[:len(idx)]
+		for i, j := range idx {
+			w[i] = s.weights[j]
+		}
+	}
+	return s.statistic(x, w)
+}
+
+func (s *vectorSample) clone() sample {
+	return newVectorSample(s.x, s.weights, s.statistic)
+}
+
+// rowSample is a sample of vector observations held in the rows of a
+// matrix.
+type rowSample struct {
+	x         mat.Matrix
+	weights   []float64
+	statistic MatrixStatistic
+
+	xbuf, wbuf []float64
+}
+
+func newRowSample(x mat.Matrix, weights []float64, statistic MatrixStatistic) *rowSample {
+	r, c := x.Dims()
+	if weights != nil && len(weights) != r {
+		panic(badLength)
+	}
+	s := &rowSample{
+		x:         x,
+		weights:   weights,
+		statistic: statistic,
+		xbuf:      make([]float64, r*c),
+	}
+	if weights != nil {
+		s.wbuf = make([]float64, r)
+	}
+	return s
+}
+
+func (s *rowSample) len() int {
+	r, _ := s.x.Dims()
+	return r
+}
+
+func (s *rowSample) eval(idx []int) float64 {
+	_, c := s.x.Dims()
+	x := mat.NewDense(len(idx), c, s.xbuf[:len(idx)*c])
+	for i, j := range idx {
+		row := x.RawRowView(i)
+		for k := range row {
+			row[k] = s.x.At(j, k)
+		}
+	}
+	var w []float64
+	if s.weights != nil {
+		w = s.wbuf[:len(idx)]
+		for i, j := range idx {
+			w[i] = s.weights[j]
+		}
+	}
+	return s.statistic(x, w)
+}
+
+func (s *rowSample) clone() sample {
+	return newRowSample(s.x, s.weights, s.statistic)
+}
+
+// reuseAs returns dst if it has length n, or a new slice of length n if dst
+// is nil. reuseAs panics if dst is not nil and has length other than n.
+func reuseAs(dst []float64, n int) []float64 {
+	if dst == nil {
+		return make([]float64, n)
+	}
+	if len(dst) != n {
+		panic(badLength)
+	}
+	return dst
+}
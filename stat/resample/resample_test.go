@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package resample
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestSampleEval(t *testing.T) {
+	x := []float64{1, 2, 4, 8, 16}
+	w := []float64{1, 0.5, 2, 1, 3}
+	m := mat.NewDense(len(x), 2, nil)
+	for i, v := range x {
+		m.Set(i, 0, v)
+		m.Set(i, 1, -v)
+	}
+	vec := newVectorSample(x, w, func(x, weights []float64) float64 {
+		return stat.Mean(x, weights)
+	})
+	rows := newRowSample(m, w, func(x mat.Matrix, weights []float64) float64 {
+		return stat.Mean(mat.Col(nil, 0, x), weights)
+	})
+	for _, test := range []struct {
+		idx  []int
+		want float64
+	}{
+		{[]int{0, 1, 2, 3, 4}, stat.Mean(x, w)},
+		{[]int{4}, 16},
+		{[]int{0, 0, 3}, (1 + 1 + 8) / 3.0},
+		{[]int{2, 4, 4}, (2*4 + 6*16) / 8.0},
+	} {
+		for name, s := range map[string]sample{"vector": vec, "rows": rows, "clone": vec.clone()} {
+			if got := s.eval(test.idx); got != test.want {
+				t.Errorf("eval mismatch for %s sample at %v: want %v, got %v", name, test.idx, test.want, got)
+			}
+		}
+	}
+}
@@ -63,3 +63,23 @@ func TestWithoutReplacement(t *testing.T) {
 		}
 	}
 }
+
+func TestWithoutReplacementPerm(t *testing.T) {
+	// When the permutation algorithm is used, the sample is the start of
+	// a single permutation drawn from the source, and no other random
+	// numbers are drawn.
+	const n, k = 10, 5
+	src := rand.New(rand.NewSource(1))
+	ref := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 10; trial++ {
+		idxs := make([]int, k)
+		WithoutReplacement(idxs, n, src)
+		want := ref.Perm(n)[:k]
+		for i, v := range want {
+			if idxs[i] != v {
+				t.Errorf("trial %d: unexpected sample: got %v want %v", trial, idxs, want)
+				break
+			}
+		}
+	}
+}
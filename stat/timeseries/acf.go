@@ -0,0 +1,141 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/gonum/stat/hyptest"
+)
+
+// Autocovariance returns the sample autocovariance of x at lags 0 through
+// maxLag,
+//  γ(k) = 1/n \sum_{t=0}^{n-k-1} (x_t - mean)(x_{t+k} - mean)
+// The divisor n rather than n-k ensures that the autocovariance sequence is
+// positive semi-definite. If the input slice is nil, a new slice will be
+// allocated, otherwise the result is stored in-place into dst, which must
+// have length maxLag+1. Autocovariance panics if maxLag is negative or not
+// less than len(x).
+func Autocovariance(dst, x []float64, maxLag int) []float64 {
+	n := len(x)
+	if maxLag < 0 || maxLag >= n {
+		panic(badLag)
+	}
+	dst = reuseAs(dst, maxLag+1)
+	m := stat.Mean(x, nil)
+	d := make([]float64, n)
+	for i, v := range x {
+		d[i] = v - m
+	}
+	for k := range dst {
+		dst[k] = floats.Dot(d[:n-k], d[k:]) / float64(n)
+	}
+	return dst
+}
+
+// ACF returns the sample autocorrelation function of x at lags 0 through
+// maxLag, the autocovariance normalized by the variance γ(0). If the input
+// slice is nil, a new slice will be allocated, otherwise the result is
+// stored in-place into dst, which must have length maxLag+1. ACF panics if
+// maxLag is negative or not less than len(x).
+func ACF(dst, x []float64, maxLag int) []float64 {
+	dst = Autocovariance(dst, x, maxLag)
+	floats.Scale(1/dst[0], dst)
+	return dst
+}
+
+// PACF returns the sample partial autocorrelation function of x at lags 1
+// through maxLag, computed from the sample autocovariance by the
+// Durbin-Levinson recursion. The partial autocorrelation at lag k is the
+// last coefficient of the Yule-Walker estimate of an autoregressive model
+// of order k. If the input slice is nil, a new slice will be allocated,
+// otherwise the result is stored in-place into dst, which must have length
+// maxLag. PACF panics if maxLag is not positive or not less than len(x).
+func PACF(dst, x []float64, maxLag int) []float64 {
+	if maxLag < 1 {
+		panic(badLag)
+	}
+	dst = reuseAs(dst, maxLag)
+	_, _ = durbinLevinson(dst, Autocovariance(nil, x, maxLag))
+	return dst
+}
+
+// YuleWalker returns the Yule-Walker estimates of the coefficients φ and
+// innovation variance σ² of the autoregressive model of order p,
+//  x_t - mean = \sum_{i=1}^p φ_i (x_{t-i} - mean) + ε_t
+// obtained by matching the model autocovariances to the sample
+// autocovariances of x. The estimated model is always stationary.
+// YuleWalker panics if p is negative or not less than len(x).
+func YuleWalker(x []float64, p int) (ar []float64, sigma2 float64) {
+	if p < 0 {
+		panic(badOrder)
+	}
+	if p >= len(x) {
+		panic(tooFewSamples)
+	}
+	return durbinLevinson(nil, Autocovariance(nil, x, p))
+}
+
+// durbinLevinson solves the Yule-Walker equations for the autoregressive
+// model of order len(acov)-1 with autocovariances acov. It returns the model
+// coefficients and innovation variance, and stores the partial
+// autocorrelations at lags 1 through len(acov)-1 into pacf if it is not nil.
+func durbinLevinson(pacf, acov []float64) (ar []float64, sigma2 float64) {
+	p := len(acov) - 1
+	ar = make([]float64, p)
+	prev := make([]float64, p)
+	sigma2 = acov[0]
+	for k := 1; k <= p; k++ {
+		num := acov[k]
+		for j := 1; j < k; j++ {
+			num -= prev[j-1] * acov[k-j]
+		}
+		phi := num / sigma2
+		ar[k-1] = phi
+		for j := 1; j < k; j++ {
+			ar[j-1] = prev[j-1] - phi*prev[k-j-1]
+		}
+		sigma2 *= 1 - phi*phi
+		if pacf != nil {
+			pacf[k-1] = phi
+		}
+		copy(prev, ar)
+	}
+	return ar, sigma2
+}
+
+// LjungBox performs the Ljung-Box portmanteau test of the null hypothesis
+// that the first lags autocorrelations of x are zero. The statistic is
+//  Q = n(n+2) \sum_{k=1}^lags r_k^2 / (n-k)
+// where r_k is the sample autocorrelation at lag k, and is compared with a
+// χ² distribution with lags-fitted degrees of freedom. When x holds the
+// residuals of a fitted ARMA(p, q) model, fitted should be p+q; otherwise it
+// should be zero.
+//
+// LjungBox panics if lags is not positive or not less than len(x), or if
+// fitted is negative or not less than lags.
+func LjungBox(x []float64, lags, fitted int) hyptest.Result {
+	if lags < 1 || lags >= len(x) {
+		panic(badLag)
+	}
+	if fitted < 0 || fitted >= lags {
+		panic(badOrder)
+	}
+	r := ACF(nil, x, lags)
+	n := float64(len(x))
+	var q float64
+	for k := 1; k <= lags; k++ {
+		q += r[k] * r[k] / (n - float64(k))
+	}
+	q *= n * (n + 2)
+	dof := float64(lags - fitted)
+	return hyptest.Result{
+		Statistic:   q,
+		PValue:      distuv.ChiSquared{K: dof}.Survival(q),
+		DoF:         dof,
+		Alternative: hyptest.Greater,
+	}
+}
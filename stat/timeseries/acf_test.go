@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestACF(t *testing.T) {
+	// Values from R's acf and pacf functions for 1:5.
+	x := []float64{1, 2, 3, 4, 5}
+	acf := ACF(nil, x, 4)
+	want := []float64{1, 0.4, -0.1, -0.4, -0.4}
+	if !floats.EqualApprox(acf, want, 1e-14) {
+		t.Errorf("ACF mismatch: want %v, got %v", want, acf)
+	}
+	acov := Autocovariance(nil, x, 4)
+	if !floats.EqualApprox(acov, []float64{2, 0.8, -0.2, -0.8, -0.8}, 1e-14) {
+		t.Errorf("Autocovariance mismatch: got %v", acov)
+	}
+	pacf := PACF(nil, x, 2)
+	if !floats.EqualApprox(pacf, []float64{0.4, -0.26 / 0.84}, 1e-14) {
+		t.Errorf("PACF mismatch: got %v", pacf)
+	}
+}
+
+func TestPACF(t *testing.T) {
+	// The partial autocorrelation at lag k is the last coefficient of the
+	// solution of the order k Yule-Walker equations.
+	src := rand.New(rand.NewSource(1))
+	x := simulateARMA([]float64{0.5, -0.3}, []float64{0.4}, 0, 1, 300, src)
+	const maxLag = 6
+	acov := Autocovariance(nil, x, maxLag)
+	pacf := PACF(nil, x, maxLag)
+	for k := 1; k <= maxLag; k++ {
+		gamma := mat.NewSymDense(k, nil)
+		for i := 0; i < k; i++ {
+			for j := i; j < k; j++ {
+				gamma.SetSym(i, j, acov[j-i])
+			}
+		}
+		var phi mat.VecDense
+		if err := phi.SolveVec(gamma, mat.NewVecDense(k, acov[1:k+1])); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if math.Abs(phi.AtVec(k-1)-pacf[k-1]) > 1e-12 {
+			t.Errorf("PACF mismatch at lag %d: want %v, got %v", k, phi.AtVec(k-1), pacf[k-1])
+		}
+		if k == maxLag {
+			ar, sigma2 := YuleWalker(x, k)
+			if !floats.EqualApprox(ar, phi.RawVector().Data, 1e-12) {
+				t.Errorf("YuleWalker mismatch: want %v, got %v", phi.RawVector().Data, ar)
+			}
+			want := acov[0] - floats.Dot(ar, acov[1:k+1])
+			if math.Abs(sigma2-want) > 1e-12 {
+				t.Errorf("YuleWalker variance mismatch: want %v, got %v", want, sigma2)
+			}
+		}
+	}
+}
+
+func TestYuleWalker(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	want := []float64{0.6, -0.3}
+	x := simulateARMA(want, nil, 10, 2, 5000, src)
+	ar, sigma2 := YuleWalker(x, 2)
+	if !floats.EqualApprox(ar, want, 0.05) {
+		t.Errorf("YuleWalker coefficients mismatch: want %v, got %v", want, ar)
+	}
+	if math.Abs(sigma2-4) > 0.2 {
+		t.Errorf("YuleWalker variance mismatch: want 4, got %v", sigma2)
+	}
+}
+
+func TestLjungBox(t *testing.T) {
+	// Value from R's Box.test(1:5, lag = 2, type = "Ljung-Box"); with two
+	// degrees of freedom the p-value is exp(-Q/2).
+	res := LjungBox([]float64{1, 2, 3, 4, 5}, 2, 0)
+	if math.Abs(res.Statistic-1.5166666666666666) > 1e-12 {
+		t.Errorf("Statistic mismatch: want 1.516667, got %v", res.Statistic)
+	}
+	if math.Abs(res.PValue-math.Exp(-res.Statistic/2)) > 1e-12 || res.DoF != 2 {
+		t.Errorf("PValue mismatch: want %v, got %v", math.Exp(-res.Statistic/2), res.PValue)
+	}
+
+	src := rand.New(rand.NewSource(1))
+	const trials = 400
+	var reject int
+	for i := 0; i < trials; i++ {
+		x := simulateARMA(nil, nil, 0, 1, 200, src)
+		if LjungBox(x, 10, 0).PValue < 0.05 {
+			reject++
+		}
+	}
+	if rate := float64(reject) / trials; rate < 0.02 || rate > 0.09 {
+		t.Errorf("Rejection rate for white noise: %v", rate)
+	}
+	x := simulateARMA([]float64{0.5}, nil, 0, 1, 200, src)
+	if p := LjungBox(x, 10, 0).PValue; p > 1e-6 {
+		t.Errorf("LjungBox failed to detect autocorrelation: p-value %v", p)
+	}
+}
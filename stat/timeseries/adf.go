@@ -0,0 +1,183 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distuv"
+	"gonum.org/v1/gonum/stat/hyptest"
+	"gonum.org/v1/gonum/stat/regress"
+)
+
+// Trend specifies the deterministic terms included in the regression of a
+// unit root test.
+type Trend int
+
+const (
+	// NoConstant includes no deterministic terms.
+	NoConstant Trend = iota
+	// Constant includes a constant term.
+	Constant
+	// ConstantTrend includes a constant and a linear time trend.
+	ConstantTrend
+)
+
+// AugmentedDickeyFuller performs the augmented Dickey-Fuller test of the
+// null hypothesis that x has a unit root against the alternative that it is
+// stationary. The test regresses the differenced series on the lagged
+// level, lags lagged differences and the deterministic terms given by
+// trend,
+//  Δx_t = c + βt + γ x_{t-1} + \sum_{i=1}^lags δ_i Δx_{t-i} + ε_t
+// and the statistic is the t-statistic of γ. The p-value is computed with
+// the response surface approximation of
+//  MacKinnon, J. G. "Approximate asymptotic distribution functions for
+//  unit-root and cointegration tests." Journal of Business and Economic
+//  Statistics 12.2 (1994): 167-176.
+//
+// If lags is negative, the number of lags is chosen to minimize the Akaike
+// information criterion among 0 through 12(n/100)^(1/4), with all the
+// candidate models fitted to the same observations. The number of lags
+// used is returned with the result.
+//
+// AugmentedDickeyFuller panics if x is too short for the regression or if
+// trend is not a known Trend.
+func AugmentedDickeyFuller(x []float64, lags int, trend Trend) (res hyptest.Result, usedLags int) {
+	var det int
+	switch trend {
+	case NoConstant:
+	case Constant:
+		det = 1
+	case ConstantTrend:
+		det = 2
+	default:
+		panic("timeseries: bad trend")
+	}
+	dx := Difference(nil, x, 1)
+	if lags < 0 {
+		maxLag := int(math.Ceil(12 * math.Pow(float64(len(x))/100, 0.25)))
+		// Keep enough observations to estimate the largest model.
+		if limit := (len(dx)-det-1)/2 - 1; maxLag > limit {
+			maxLag = limit
+		}
+		if maxLag < 0 {
+			panic(tooFewSamples)
+		}
+		bestAIC := math.Inf(1)
+		for l := 0; l <= maxLag; l++ {
+			fit, n := adfRegression(x, dx, l, maxLag, trend)
+			if fit == nil {
+				continue
+			}
+			var rss float64
+			for _, r := range fit.Residuals(nil) {
+				rss += r * r
+			}
+			k := float64(l + 1 + det)
+			aic := float64(n)*math.Log(rss/float64(n)) + 2*k
+			if aic < bestAIC {
+				bestAIC = aic
+				lags = l
+			}
+		}
+		if lags < 0 {
+			panic(tooFewSamples)
+		}
+	}
+	if len(dx)-lags <= lags+1+det {
+		panic(tooFewSamples)
+	}
+	fit, _ := adfRegression(x, dx, lags, lags, trend)
+	stat := math.NaN()
+	if fit != nil {
+		idx := 0
+		if trend != NoConstant {
+			idx = 1
+		}
+		stat = fit.TStat(nil)[idx]
+	}
+	return hyptest.Result{
+		Statistic:   stat,
+		PValue:      mackinnonPValue(stat, trend),
+		Alternative: hyptest.Less,
+	}, lags
+}
+
+// adfRegression fits the augmented Dickey-Fuller regression with the given
+// number of lags to the differences dx of x, using the observations
+// available when start lags are used. It returns the fit, or nil if the
+// regression is singular, and the number of observations.
+func adfRegression(x, dx []float64, lags, start int, trend Trend) (*regress.Linear, int) {
+	n := len(dx) - start
+	cols := 1 + lags
+	if trend == ConstantTrend {
+		cols++
+	}
+	design := mat.NewDense(n, cols, nil)
+	y := make([]float64, n)
+	for i := 0; i < n; i++ {
+		t := i + start
+		y[i] = dx[t]
+		row := design.RawRowView(i)
+		row[0] = x[t]
+		j := 1
+		if trend == ConstantTrend {
+			row[j] = float64(t + 1)
+			j++
+		}
+		for l := 1; l <= lags; l++ {
+			row[j] = dx[t-l]
+			j++
+		}
+	}
+	fit, err := regress.FitLinear(design, y, nil, trend != NoConstant)
+	if err != nil {
+		return nil, n
+	}
+	return fit, n
+}
+
+// MacKinnon (1994) response surface coefficients for the distribution of
+// the Dickey-Fuller τ statistic, indexed by Trend. Below tauStar the
+// p-value is Φ of a quadratic in the statistic with coefficients
+// tauSmallP, and above it Φ of a cubic with coefficients tauLargeP.
+var (
+	tauMin    = [...]float64{-19.04, -18.83, -16.18}
+	tauMax    = [...]float64{math.Inf(1), 2.74, 0.7}
+	tauStar   = [...]float64{-1.04, -1.61, -2.89}
+	tauSmallP = [...][3]float64{
+		{0.6344, 1.2378, 3.2496e-2},
+		{2.1659, 1.4412, 3.8269e-2},
+		{3.2512, 1.6047, 4.9588e-2},
+	}
+	tauLargeP = [...][4]float64{
+		{0.4797, 9.3557e-1, -0.6999e-1, 3.3066e-2},
+		{1.7339, 9.3202e-1, -1.2745e-1, -1.0368e-2},
+		{2.5261, 6.1654e-1, -3.7956e-1, -6.0285e-2},
+	}
+)
+
+// mackinnonPValue returns the approximate asymptotic p-value of the
+// Dickey-Fuller statistic tau.
+func mackinnonPValue(tau float64, trend Trend) float64 {
+	switch {
+	case math.IsNaN(tau):
+		return math.NaN()
+	case tau > tauMax[trend]:
+		return 1
+	case tau < tauMin[trend]:
+		return 0
+	}
+	var z float64
+	if tau <= tauStar[trend] {
+		c := tauSmallP[trend]
+		z = c[0] + tau*(c[1]+tau*c[2])
+	} else {
+		c := tauLargeP[trend]
+		z = c[0] + tau*(c[1]+tau*(c[2]+tau*c[3]))
+	}
+	return distuv.UnitNormal.CDF(z)
+}
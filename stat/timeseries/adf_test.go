@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMacKinnonPValue(t *testing.T) {
+	// Asymptotic critical values of the Dickey-Fuller τ statistic from
+	// Fuller (1976) and MacKinnon (1994).
+	for _, test := range []struct {
+		trend Trend
+		tau   float64
+		p     float64
+	}{
+		{NoConstant, -2.56, 0.01},
+		{NoConstant, -1.94, 0.05},
+		{NoConstant, -1.62, 0.10},
+		{Constant, -3.43, 0.01},
+		{Constant, -2.86, 0.05},
+		{Constant, -2.57, 0.10},
+		{ConstantTrend, -3.96, 0.01},
+		{ConstantTrend, -3.41, 0.05},
+		{ConstantTrend, -3.13, 0.10},
+	} {
+		got := mackinnonPValue(test.tau, test.trend)
+		if math.Abs(got-test.p) > 0.1*test.p {
+			t.Errorf("p-value mismatch for trend %d at τ=%v: want %v, got %v", test.trend, test.tau, test.p, got)
+		}
+	}
+	if mackinnonPValue(5, Constant) != 1 || mackinnonPValue(-30, Constant) != 0 {
+		t.Errorf("p-values outside the response surface not 0 or 1")
+	}
+}
+
+func TestAugmentedDickeyFuller(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+
+	// With no lags and no deterministic terms the statistic is the
+	// t-statistic of the regression of Δx_t on x_{t-1} through the origin.
+	x := simulateARMA([]float64{0.9}, nil, 0, 1, 100, src)
+	var sxx, sxy float64
+	for i := 1; i < len(x); i++ {
+		sxx += x[i-1] * x[i-1]
+		sxy += x[i-1] * (x[i] - x[i-1])
+	}
+	gamma := sxy / sxx
+	var rss float64
+	for i := 1; i < len(x); i++ {
+		r := x[i] - x[i-1] - gamma*x[i-1]
+		rss += r * r
+	}
+	want := gamma / math.Sqrt(rss/float64(len(x)-2)/sxx)
+	res, lags := AugmentedDickeyFuller(x, 0, NoConstant)
+	if lags != 0 || math.Abs(res.Statistic-want) > 1e-10 {
+		t.Errorf("Statistic mismatch: want %v, got %v", want, res.Statistic)
+	}
+
+	walk := make([]float64, 500)
+	for i := 1; i < len(walk); i++ {
+		walk[i] = walk[i-1] + src.NormFloat64()
+	}
+	stationary := simulateARMA([]float64{0.5}, []float64{0.3}, 0, 1, 500, src)
+	for _, trend := range []Trend{NoConstant, Constant, ConstantTrend} {
+		res, lags := AugmentedDickeyFuller(stationary, -1, trend)
+		if res.PValue > 0.01 {
+			t.Errorf("Failed to reject unit root for stationary series with trend %d: p-value %v", trend, res.PValue)
+		}
+		if lags < 0 || float64(lags) > math.Ceil(12*math.Pow(5, 0.25)) {
+			t.Errorf("Unexpected number of lags selected: %d", lags)
+		}
+		if res, _ := AugmentedDickeyFuller(walk, 4, trend); res.PValue < 0.05 {
+			t.Errorf("Rejected unit root for random walk with trend %d: p-value %v", trend, res.PValue)
+		}
+	}
+
+	// The rejection rate under the null is close to the nominal level.
+	const trials = 300
+	var reject int
+	for i := 0; i < trials; i++ {
+		x := make([]float64, 250)
+		for j := 1; j < len(x); j++ {
+			x[j] = x[j-1] + src.NormFloat64()
+		}
+		if res, _ := AugmentedDickeyFuller(x, 1, Constant); res.PValue < 0.05 {
+			reject++
+		}
+	}
+	if rate := float64(reject) / trials; rate < 0.02 || rate > 0.09 {
+		t.Errorf("Rejection rate under the null: %v", rate)
+	}
+}
@@ -0,0 +1,584 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/diff/fd"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/optimize"
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// EstimationMethod specifies how the parameters of an ARIMA model are
+// estimated.
+type EstimationMethod int
+
+const (
+	// MaximumLikelihood maximizes the exact Gaussian likelihood of the
+	// model, starting from the conditional sum of squares estimates.
+	MaximumLikelihood EstimationMethod = iota
+	// ConditionalSumOfSquares minimizes the sum of squared innovations
+	// conditional on the first p observations and on zero innovations
+	// before them.
+	ConditionalSumOfSquares
+	// YuleWalkerEquations matches the model autocovariances to the sample
+	// autocovariances. It may only be used for models without moving
+	// average terms.
+	YuleWalkerEquations
+)
+
+// ARIMASettings are the settings for fitting an ARIMA model.
+type ARIMASettings struct {
+	// Method is the estimation method.
+	Method EstimationMethod
+
+	// IncludeMean specifies whether the mean of the series is estimated.
+	// If IncludeMean is false the series is assumed to have zero mean.
+	// IncludeMean is ignored for models with differencing, which have no
+	// mean term.
+	IncludeMean bool
+
+	// Optimize holds the settings for the numerical optimization of the
+	// likelihood. If Optimize is nil, optimize.DefaultSettings is used.
+	Optimize *optimize.Settings
+}
+
+// DefaultARIMASettings returns the default settings for fitting an ARIMA
+// model, using maximum likelihood and estimating the mean of undifferenced
+// series.
+func DefaultARIMASettings() *ARIMASettings {
+	return &ARIMASettings{
+		Method:      MaximumLikelihood,
+		IncludeMean: true,
+	}
+}
+
+// ARIMA is an autoregressive integrated moving average model of order
+// (p, d, q). The d-th difference w_t of the series follows the stationary
+// and invertible ARMA(p, q) model
+//
+//  w_t - μ = \sum_{i=1}^p φ_i (w_{t-i} - μ) + ε_t + \sum_{j=1}^q θ_j ε_{t-j}
+//
+// where the innovations ε_t are independent with mean zero and variance σ².
+//
+// The coefficients of a fitted model are ordered φ_1, ..., φ_p, θ_1, ...,
+// θ_q, followed by μ if the mean was estimated.
+type ARIMA struct {
+	ar, ma  []float64
+	d       int
+	mu      float64
+	hasMean bool
+	sigma2  float64
+
+	logLik float64
+	nobs   int
+	cov    *mat.SymDense
+
+	x     []float64
+	resid []float64
+}
+
+// FitARIMA fits an ARIMA(p, d, q) model to the series x. If settings is
+// nil, DefaultARIMASettings is used.
+//
+// The returned boolean reports whether the optimization of the likelihood
+// converged; estimation by the Yule-Walker equations always converges. If
+// the optimization fails, FitARIMA returns a nil model and the error.
+//
+// FitARIMA panics if p, d or q is negative, if the series is too short to
+// estimate the model, or if the Yule-Walker equations are requested for a
+// model with moving average terms.
+func FitARIMA(x []float64, p, d, q int, settings *ARIMASettings) (*ARIMA, bool, error) {
+	if p < 0 || d < 0 || q < 0 {
+		panic(badOrder)
+	}
+	if settings == nil {
+		settings = DefaultARIMASettings()
+	}
+	if settings.Method == YuleWalkerEquations && q != 0 {
+		panic("timeseries: Yule-Walker estimation with moving average terms")
+	}
+	hasMean := settings.IncludeMean && d == 0
+	k := p + q
+	if hasMean {
+		k++
+	}
+	if len(x)-d <= p+k+1 {
+		panic(tooFewSamples)
+	}
+	w := Difference(nil, x, d)
+
+	m := &ARIMA{
+		d:       d,
+		hasMean: hasMean,
+		x:       append([]float64(nil), x...),
+		resid:   make([]float64, len(w)),
+	}
+
+	// Start from the Yule-Walker estimates of the autoregressive
+	// coefficients and no moving average terms.
+	params := make([]float64, k)
+	var mu float64
+	if hasMean {
+		mu = stat.Mean(w, nil)
+		params[k-1] = mu
+	}
+	c := make([]float64, len(w))
+	for i, v := range w {
+		c[i] = v - mu
+	}
+	acov := make([]float64, p+1)
+	for i := range acov {
+		acov[i] = floats.Dot(c[:len(c)-i], c[i:]) / float64(len(c))
+	}
+	ywAR, ywSigma2 := durbinLevinson(nil, acov)
+	copy(params, ywAR)
+
+	// The objectives are the negative log-likelihoods, with the
+	// innovation variance profiled out, divided by the number of
+	// observations.
+	css := func(params []float64) float64 {
+		ss, n := m.conditionalSS(nil, w, params, p, q)
+		return 0.5 * math.Log(ss/float64(n))
+	}
+	exact := func(params []float64) float64 {
+		ssq, sumLogF, n, ok := m.exactSS(nil, w, params, p, q)
+		if !ok {
+			return math.Inf(1)
+		}
+		return 0.5 * (math.Log(ssq/float64(n)) + sumLogF/float64(n))
+	}
+
+	converged := true
+	switch settings.Method {
+	case YuleWalkerEquations:
+		m.setParams(params, p, q)
+		m.sigma2 = ywSigma2
+		m.conditionalSS(m.resid, w, params, p, q)
+		ssq, sumLogF, n, _ := m.exactSS(nil, w, params, p, q)
+		m.nobs = n
+		m.logLik = -0.5 * (float64(n)*math.Log(2*math.Pi*m.sigma2) + sumLogF + ssq/m.sigma2)
+		m.setCovariance(css, params, len(w)-p)
+		return m, true, nil
+
+	case ConditionalSumOfSquares, MaximumLikelihood:
+		var err error
+		params, converged, err = minimize(css, params, settings.Optimize)
+		if err != nil {
+			return nil, false, err
+		}
+		if settings.Method == ConditionalSumOfSquares {
+			m.setParams(params, p, q)
+			ss, n := m.conditionalSS(m.resid, w, params, p, q)
+			m.nobs = n
+			m.sigma2 = ss / float64(n)
+			m.logLik = -0.5 * float64(n) * (math.Log(2*math.Pi*m.sigma2) + 1)
+			m.setCovariance(css, params, n)
+			return m, converged, nil
+		}
+
+	default:
+		panic("timeseries: bad estimation method")
+	}
+
+	// Maximize the exact likelihood over unconstrained parameters that
+	// map to stationary and invertible models.
+	u, ok := toUnconstrained(params, p, q)
+	if !ok {
+		params = make([]float64, k)
+		copy(params, ywAR)
+		if hasMean {
+			params[k-1] = mu
+		}
+		u, _ = toUnconstrained(params, p, q)
+	}
+	u, converged, err := minimize(func(u []float64) float64 {
+		return exact(fromUnconstrained(u, p, q))
+	}, u, settings.Optimize)
+	if err != nil {
+		return nil, false, err
+	}
+	params = fromUnconstrained(u, p, q)
+	m.setParams(params, p, q)
+	ssq, sumLogF, n, _ := m.exactSS(m.resid, w, params, p, q)
+	m.nobs = n
+	m.sigma2 = ssq / float64(n)
+	m.logLik = -0.5 * (float64(n)*math.Log(2*math.Pi*m.sigma2) + sumLogF + float64(n))
+	m.setCovariance(exact, params, n)
+	return m, converged, nil
+}
+
+// minimize minimizes f from x with BFGS using central difference gradients.
+// It returns the minimizing location and whether the optimization
+// converged.
+func minimize(f func([]float64) float64, x []float64, settings *optimize.Settings) ([]float64, bool, error) {
+	if len(x) == 0 {
+		return x, true, nil
+	}
+	grad := &fd.Settings{Formula: fd.Central}
+	problem := optimize.Problem{
+		Func: f,
+		Grad: func(dst, x []float64) {
+			fd.Gradient(dst, f, x, grad)
+		},
+	}
+	result, err := optimize.Local(problem, x, settings, &optimize.BFGS{})
+	if result == nil {
+		return nil, false, err
+	}
+	if math.IsInf(result.F, 0) || math.IsNaN(result.F) {
+		return nil, false, errNonFinite
+	}
+	return result.X, err == nil && !result.Status.Early(), nil
+}
+
+// errNonFinite is returned when the likelihood of an ARIMA model cannot be
+// evaluated at the optimum.
+var errNonFinite = errors.New("timeseries: non-finite likelihood")
+
+// toUnconstrained maps ARMA parameters to the unconstrained space in which
+// the exact likelihood is optimized. The autoregressive and moving average
+// coefficients are each mapped to their partial autocorrelations, which are
+// transformed by the inverse hyperbolic tangent. ok is false if the model
+// is not stationary and invertible.
+func toUnconstrained(params []float64, p, q int) (u []float64, ok bool) {
+	u = make([]float64, len(params))
+	copy(u, params)
+	pacf, ok := arToPACF(params[:p])
+	if !ok {
+		return nil, false
+	}
+	for i, v := range pacf {
+		u[i] = math.Atanh(v)
+	}
+	negMA := make([]float64, q)
+	for i, v := range params[p : p+q] {
+		negMA[i] = -v
+	}
+	pacf, ok = arToPACF(negMA)
+	if !ok {
+		return nil, false
+	}
+	for i, v := range pacf {
+		u[p+i] = math.Atanh(v)
+	}
+	return u, true
+}
+
+// fromUnconstrained is the inverse of toUnconstrained.
+func fromUnconstrained(u []float64, p, q int) []float64 {
+	params := make([]float64, len(u))
+	copy(params, u)
+	pacf := make([]float64, p+q)
+	for i := range pacf {
+		pacf[i] = math.Tanh(u[i])
+	}
+	copy(params, pacfToAR(pacf[:p]))
+	for i, v := range pacfToAR(pacf[p:]) {
+		params[p+i] = -v
+	}
+	return params
+}
+
+// setParams sets the coefficients of the model from params.
+func (m *ARIMA) setParams(params []float64, p, q int) {
+	m.ar = append([]float64(nil), params[:p]...)
+	m.ma = append([]float64(nil), params[p:p+q]...)
+	if m.hasMean {
+		m.mu = params[p+q]
+	}
+}
+
+// demeaned returns w less the mean given in params.
+func (m *ARIMA) demeaned(w, params []float64, p, q int) []float64 {
+	if !m.hasMean {
+		return w
+	}
+	mu := params[p+q]
+	c := make([]float64, len(w))
+	for i, v := range w {
+		c[i] = v - mu
+	}
+	return c
+}
+
+// conditionalSS returns the conditional sum of squared innovations of the
+// model with parameters params and the number of innovations summed, and
+// stores the innovations into resid if it is not nil.
+func (m *ARIMA) conditionalSS(resid, w, params []float64, p, q int) (ss float64, n int) {
+	c := m.demeaned(w, params, p, q)
+	ar := params[:p]
+	ma := params[p : p+q]
+	e := resid
+	if e == nil {
+		e = make([]float64, len(c))
+	}
+	for t := range c {
+		if t < p {
+			e[t] = 0
+			continue
+		}
+		v := c[t]
+		for i, phi := range ar {
+			v -= phi * c[t-i-1]
+		}
+		for j, theta := range ma {
+			if t-j-1 >= p {
+				v -= theta * e[t-j-1]
+			}
+		}
+		e[t] = v
+		ss += v * v
+	}
+	return ss, len(c) - p
+}
+
+// exactSS evaluates the exact likelihood of the model with parameters
+// params by the Kalman filter, returning its sufficient quantities as
+// described for armaKalman and the number of observations, and storing the
+// one-step prediction errors into innov if it is not nil.
+func (m *ARIMA) exactSS(innov, w, params []float64, p, q int) (ssq, sumLogF float64, n int, ok bool) {
+	c := m.demeaned(w, params, p, q)
+	ssq, sumLogF, ok = armaKalman(innov, c, params[:p], params[p:p+q])
+	return ssq, sumLogF, len(c), ok
+}
+
+// setCovariance sets the covariance matrix of the coefficients to the
+// inverse of the Hessian of the negative log-likelihood at params, where f
+// is the negative log-likelihood divided by the number of observations n.
+// The covariance is left nil if the Hessian is not positive definite.
+func (m *ARIMA) setCovariance(f func([]float64) float64, params []float64, n int) {
+	if len(params) == 0 {
+		return
+	}
+	hess := fd.Hessian(nil, f, params, nil)
+	hess.ScaleSym(float64(n), hess)
+	var chol mat.Cholesky
+	if !chol.Factorize(hess) {
+		return
+	}
+	m.cov = mat.NewSymDense(len(params), nil)
+	if err := chol.InverseTo(m.cov); err != nil {
+		m.cov = nil
+	}
+}
+
+// AIC returns the Akaike information criterion of the fitted model,
+//
+//  -2 log L + 2k
+//
+// where k counts the coefficients and the innovation variance.
+func (m *ARIMA) AIC() float64 {
+	return -2*m.logLik + 2*float64(m.numParameters())
+}
+
+// AR returns the autoregressive coefficients φ of the model. If the input
+// slice is nil, a new slice will be allocated, otherwise the result is
+// stored in-place into dst, which must have length p.
+func (m *ARIMA) AR(dst []float64) []float64 {
+	dst = reuseAs(dst, len(m.ar))
+	copy(dst, m.ar)
+	return dst
+}
+
+// BIC returns the Bayesian information criterion of the fitted model,
+//
+//  -2 log L + k log n
+//
+// where k counts the coefficients and the innovation variance and n is the
+// number of observations used in the likelihood.
+func (m *ARIMA) BIC() float64 {
+	return -2*m.logLik + float64(m.numParameters())*math.Log(float64(m.nobs))
+}
+
+// numParameters returns the number of estimated parameters, including the
+// innovation variance.
+func (m *ARIMA) numParameters() int {
+	k := len(m.ar) + len(m.ma) + 1
+	if m.hasMean {
+		k++
+	}
+	return k
+}
+
+// CovarianceMatrix returns the estimated asymptotic covariance matrix of
+// the coefficients, computed from the numerical Hessian of the objective
+// of the estimation method; the conditional sum of squares objective is
+// used for Yule-Walker estimates. If the input matrix is nil, a new matrix
+// will be allocated, otherwise the result is stored in-place into dst.
+// CovarianceMatrix returns nil if the Hessian is not positive definite.
+func (m *ARIMA) CovarianceMatrix(dst *mat.SymDense) *mat.SymDense {
+	if m.cov == nil {
+		return nil
+	}
+	k := m.cov.Symmetric()
+	if dst == nil {
+		dst = mat.NewSymDense(k, nil)
+	} else if dst.Symmetric() != k {
+		panic(badLength)
+	}
+	dst.CopySym(m.cov)
+	return dst
+}
+
+// Forecast returns the minimum mean squared error forecasts of the next h
+// values of the series and their standard errors. Forecasts are computed
+// from the infinite moving average representation of the model, using the
+// estimated innovations of the series for the moving average terms. If
+// mean or stderr is nil, a new slice will be allocated, otherwise the result
+// is stored in-place into it, and it must have length h.
+func (m *ARIMA) Forecast(mean, stderr []float64, h int) ([]float64, []float64) {
+	if h < 0 {
+		panic(badLag)
+	}
+	mean = reuseAs(mean, h)
+	stderr = reuseAs(stderr, h)
+
+	// The autoregressive polynomial of the undifferenced series is
+	// φ(B)(1-B)^d, with coefficients phi.
+	poly := make([]float64, len(m.ar)+1)
+	poly[0] = 1
+	for i, v := range m.ar {
+		poly[i+1] = -v
+	}
+	for k := 0; k < m.d; k++ {
+		next := make([]float64, len(poly)+1)
+		for i, v := range poly {
+			next[i] += v
+			next[i+1] -= v
+		}
+		poly = next
+	}
+	phi := make([]float64, len(poly)-1)
+	for i := range phi {
+		phi[i] = -poly[i+1]
+	}
+
+	n := len(m.x)
+	y := make([]float64, n+h)
+	for i, v := range m.x {
+		y[i] = v - m.mu
+	}
+	e := make([]float64, n+h)
+	copy(e[m.d:], m.resid)
+	for t := n; t < n+h; t++ {
+		var v float64
+		for i, c := range phi {
+			if t-i-1 >= 0 {
+				v += c * y[t-i-1]
+			}
+		}
+		for j, c := range m.ma {
+			v += c * e[t-j-1]
+		}
+		y[t] = v
+		mean[t-n] = v + m.mu
+	}
+
+	psi := make([]float64, h)
+	var sum float64
+	for j := range psi {
+		if j == 0 {
+			psi[j] = 1
+		} else {
+			if j <= len(m.ma) {
+				psi[j] = m.ma[j-1]
+			}
+			for i := 1; i <= j && i <= len(phi); i++ {
+				psi[j] += phi[i-1] * psi[j-i]
+			}
+		}
+		sum += psi[j] * psi[j]
+		stderr[j] = math.Sqrt(m.sigma2 * sum)
+	}
+	return mean, stderr
+}
+
+// ForecastInterval returns the lower and upper limits of the prediction
+// intervals with the given confidence level for the next h values of the
+// series, assuming Gaussian innovations. If lower or upper is nil, a new
+// slice will be allocated, otherwise the result is stored in-place into it,
+// and it must have length h. ForecastInterval panics if level is not in
+// (0, 1).
+func (m *ARIMA) ForecastInterval(lower, upper []float64, h int, level float64) ([]float64, []float64) {
+	if !(level > 0 && level < 1) {
+		panic(badLevel)
+	}
+	lower, upper = reuseAs(lower, h), reuseAs(upper, h)
+	mean, se := m.Forecast(nil, nil, h)
+	z := distuv.UnitNormal.Quantile(0.5 + level/2)
+	for i := range mean {
+		lower[i] = mean[i] - z*se[i]
+		upper[i] = mean[i] + z*se[i]
+	}
+	return lower, upper
+}
+
+// LogLikelihood returns the Gaussian log-likelihood of the fitted model. It
+// is the exact likelihood for models fitted by maximum likelihood or the
+// Yule-Walker equations, and the conditional likelihood for models fitted
+// by conditional sum of squares.
+func (m *ARIMA) LogLikelihood() float64 {
+	return m.logLik
+}
+
+// MA returns the moving average coefficients θ of the model. If the input
+// slice is nil, a new slice will be allocated, otherwise the result is
+// stored in-place into dst, which must have length q.
+func (m *ARIMA) MA(dst []float64) []float64 {
+	dst = reuseAs(dst, len(m.ma))
+	copy(dst, m.ma)
+	return dst
+}
+
+// Mean returns the mean μ of the differenced series, which is zero if the
+// mean was not estimated.
+func (m *ARIMA) Mean() float64 {
+	return m.mu
+}
+
+// Order returns the order (p, d, q) of the model.
+func (m *ARIMA) Order() (p, d, q int) {
+	return len(m.ar), m.d, len(m.ma)
+}
+
+// Residuals returns the estimated innovations of the differenced series.
+// For models fitted by maximum likelihood these are the one-step prediction
+// errors of the Kalman filter; otherwise they are the conditional
+// innovations, with the first p set to zero. If the input slice is nil, a
+// new slice will be allocated, otherwise the result is stored in-place into
+// dst, which must have length len(x)-d.
+func (m *ARIMA) Residuals(dst []float64) []float64 {
+	dst = reuseAs(dst, len(m.resid))
+	copy(dst, m.resid)
+	return dst
+}
+
+// StdErr returns the standard errors of the coefficients, the square roots
+// of the diagonal of CovarianceMatrix. The standard errors are NaN if the
+// covariance matrix is not available. If the input slice is nil, a new
+// slice will be allocated, otherwise the result is stored in-place into dst.
+func (m *ARIMA) StdErr(dst []float64) []float64 {
+	k := m.numParameters() - 1
+	dst = reuseAs(dst, k)
+	for i := range dst {
+		if m.cov == nil {
+			dst[i] = math.NaN()
+		} else {
+			dst[i] = math.Sqrt(m.cov.At(i, i))
+		}
+	}
+	return dst
+}
+
+// Variance returns the estimated variance σ² of the innovations.
+func (m *ARIMA) Variance() float64 {
+	return m.sigma2
+}
@@ -0,0 +1,219 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// simulateARMA returns n values of the ARMA model with the given
+// coefficients, mean and innovation standard deviation, after discarding a
+// burn-in period.
+func simulateARMA(ar, ma []float64, mu, sigma float64, n int, src *rand.Rand) []float64 {
+	const burn = 500
+	x := make([]float64, n+burn)
+	e := make([]float64, n+burn)
+	for t := range x {
+		e[t] = sigma * src.NormFloat64()
+		v := e[t]
+		for i, c := range ar {
+			if t-i-1 >= 0 {
+				v += c * x[t-i-1]
+			}
+		}
+		for j, c := range ma {
+			if t-j-1 >= 0 {
+				v += c * e[t-j-1]
+			}
+		}
+		x[t] = v
+	}
+	x = x[burn:]
+	for i := range x {
+		x[i] += mu
+	}
+	return x
+}
+
+func TestFitARIMA(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		ar, ma    []float64
+		mu, sigma float64
+		methods   []EstimationMethod
+	}{
+		{
+			ar: []float64{0.6, -0.3}, mu: 5, sigma: 2,
+			methods: []EstimationMethod{MaximumLikelihood, ConditionalSumOfSquares, YuleWalkerEquations},
+		},
+		{
+			ar: []float64{0.6}, ma: []float64{0.3}, mu: -1, sigma: 1,
+			methods: []EstimationMethod{MaximumLikelihood, ConditionalSumOfSquares},
+		},
+		{
+			ma: []float64{-0.5, 0.2}, mu: 0, sigma: 0.5,
+			methods: []EstimationMethod{MaximumLikelihood, ConditionalSumOfSquares},
+		},
+	} {
+		x := simulateARMA(test.ar, test.ma, test.mu, test.sigma, 3000, src)
+		p, q := len(test.ar), len(test.ma)
+		want := append(append(append([]float64(nil), test.ar...), test.ma...), test.mu)
+		for _, method := range test.methods {
+			settings := DefaultARIMASettings()
+			settings.Method = method
+			m, converged, err := FitARIMA(x, p, 0, q, settings)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !converged {
+				t.Errorf("Fit did not converge for method %d", method)
+			}
+			got := append(append(m.AR(nil), m.MA(nil)...), m.Mean())
+			se := m.StdErr(nil)
+			for i := range want {
+				if math.IsNaN(se[i]) || se[i] > 0.2 {
+					t.Errorf("Bad standard error for method %d: %v", method, se)
+				}
+				if math.Abs(got[i]-want[i]) > 4*se[i] {
+					t.Errorf("Coefficient mismatch for method %d: want %v, got %v (se %v)", method, want, got, se)
+					break
+				}
+			}
+			if math.Abs(m.Variance()-test.sigma*test.sigma) > 0.1*test.sigma*test.sigma {
+				t.Errorf("Variance mismatch for method %d: want %v, got %v", method, test.sigma*test.sigma, m.Variance())
+			}
+			// The residuals of a well specified model are white noise.
+			if res := LjungBox(m.Residuals(nil)[p:], 20, p+q); res.PValue < 0.01 {
+				t.Errorf("Residuals correlated for method %d: p-value %v", method, res.PValue)
+			}
+			k := float64(len(want) + 1)
+			if math.Abs(m.AIC()-(-2*m.LogLikelihood()+2*k)) > 1e-10 {
+				t.Errorf("AIC mismatch")
+			}
+		}
+	}
+}
+
+func TestFitARIMAMaximizesLikelihood(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	x := simulateARMA([]float64{0.8}, []float64{-0.4}, 2, 1, 200, src)
+	ml, _, err := FitARIMA(x, 1, 0, 1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params := append(append(ml.AR(nil), ml.MA(nil)...), ml.Mean())
+
+	// The exact likelihood with the innovation variance profiled out is
+	// smaller at any perturbation of the estimates.
+	profile := func(params []float64) float64 {
+		ssq, sumLogF, n, ok := ml.exactSS(nil, x, params, 1, 1)
+		if !ok {
+			return math.Inf(-1)
+		}
+		return -0.5 * (float64(n)*math.Log(2*math.Pi*ssq/float64(n)) + sumLogF + float64(n))
+	}
+	if math.Abs(profile(params)-ml.LogLikelihood()) > 1e-8 {
+		t.Errorf("LogLikelihood mismatch: want %v, got %v", profile(params), ml.LogLikelihood())
+	}
+	for i := range params {
+		for _, step := range []float64{-1e-3, 1e-3} {
+			perturbed := append([]float64(nil), params...)
+			perturbed[i] += step
+			if profile(perturbed) > ml.LogLikelihood() {
+				t.Errorf("Likelihood increased by perturbing parameter %d by %v", i, step)
+			}
+		}
+	}
+}
+
+func TestARIMAForecast(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const h = 10
+
+	// AR(1) forecasts decay geometrically to the mean.
+	x := simulateARMA([]float64{0.7}, nil, 3, 1, 500, src)
+	m, _, err := FitARIMA(x, 1, 0, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mean, se := m.Forecast(nil, nil, h)
+	phi, mu, sigma2 := m.AR(nil)[0], m.Mean(), m.Variance()
+	var sum float64
+	for k := 1; k <= h; k++ {
+		want := mu + math.Pow(phi, float64(k))*(x[len(x)-1]-mu)
+		sum += math.Pow(phi, float64(2*(k-1)))
+		if math.Abs(mean[k-1]-want) > 1e-10 {
+			t.Errorf("AR(1) forecast mismatch at horizon %d: want %v, got %v", k, want, mean[k-1])
+		}
+		if math.Abs(se[k-1]-math.Sqrt(sigma2*sum)) > 1e-10 {
+			t.Errorf("AR(1) standard error mismatch at horizon %d: want %v, got %v", k, math.Sqrt(sigma2*sum), se[k-1])
+		}
+	}
+
+	// Random walk forecasts are the last value with standard errors
+	// growing as the square root of the horizon.
+	walk := make([]float64, 300)
+	for i := 1; i < len(walk); i++ {
+		walk[i] = walk[i-1] + src.NormFloat64()
+	}
+	m, _, err = FitARIMA(walk, 0, 1, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := Difference(nil, walk, 1)
+	if want := floats.Dot(d, d) / float64(len(d)); math.Abs(m.Variance()-want) > 1e-12 {
+		t.Errorf("Random walk variance mismatch: want %v, got %v", want, m.Variance())
+	}
+	mean, se = m.Forecast(nil, nil, h)
+	for k := 1; k <= h; k++ {
+		if mean[k-1] != walk[len(walk)-1] {
+			t.Errorf("Random walk forecast mismatch at horizon %d: want %v, got %v", k, walk[len(walk)-1], mean[k-1])
+		}
+		if want := math.Sqrt(m.Variance() * float64(k)); math.Abs(se[k-1]-want) > 1e-12 {
+			t.Errorf("Random walk standard error mismatch at horizon %d: want %v, got %v", k, want, se[k-1])
+		}
+	}
+
+	// ARIMA(0, 1, 1) forecasts are constant beyond the first step, and
+	// equal to the last value plus θ times the last innovation.
+	e := make([]float64, 400)
+	y := make([]float64, 400)
+	for i := range y {
+		e[i] = src.NormFloat64()
+		if i > 0 {
+			y[i] = y[i-1] + e[i] + 0.5*e[i-1]
+		}
+	}
+	m, _, err = FitARIMA(y, 0, 1, 1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	theta := m.MA(nil)[0]
+	resid := m.Residuals(nil)
+	mean, se = m.Forecast(nil, nil, h)
+	want := y[len(y)-1] + theta*resid[len(resid)-1]
+	for k := 1; k <= h; k++ {
+		if math.Abs(mean[k-1]-want) > 1e-10 {
+			t.Errorf("ARIMA(0,1,1) forecast mismatch at horizon %d: want %v, got %v", k, want, mean[k-1])
+		}
+		wantSE := math.Sqrt(m.Variance() * (1 + float64(k-1)*(1+theta)*(1+theta)))
+		if math.Abs(se[k-1]-wantSE) > 1e-10 {
+			t.Errorf("ARIMA(0,1,1) standard error mismatch at horizon %d: want %v, got %v", k, wantSE, se[k-1])
+		}
+	}
+
+	lower, upper := m.ForecastInterval(nil, nil, h, 0.9)
+	z := distuv.UnitNormal.Quantile(0.95)
+	for k := range lower {
+		if math.Abs(lower[k]-(mean[k]-z*se[k])) > 1e-12 || math.Abs(upper[k]-(mean[k]+z*se[k])) > 1e-12 {
+			t.Errorf("ForecastInterval mismatch at horizon %d", k+1)
+		}
+	}
+}
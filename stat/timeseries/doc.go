@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timeseries provides methods for the analysis of univariate time
+// series: autocorrelation, tests for serial correlation and unit roots,
+// ARIMA modeling and forecasting, and spectral density estimation.
+//
+// A time series is represented as a slice of values observed at equally
+// spaced times, with the earliest observation first.
+package timeseries // import "gonum.org/v1/gonum/stat/timeseries"
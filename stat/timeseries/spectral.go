@@ -0,0 +1,139 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/internal/fourier"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Window is a data taper applied to a series segment before its Fourier
+// transform. A Window stores the weights of a taper of length len(w) into w.
+type Window func(w []float64)
+
+// Rectangular is the rectangular window, with all weights equal to one.
+func Rectangular(w []float64) {
+	for i := range w {
+		w[i] = 1
+	}
+}
+
+// Hann is the periodic Hann window,
+//  w_i = 1/2 - 1/2 cos(2πi/n)
+func Hann(w []float64) {
+	n := float64(len(w))
+	for i := range w {
+		w[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/n)
+	}
+}
+
+// Hamming is the periodic Hamming window,
+//  w_i = 0.54 - 0.46 cos(2πi/n)
+func Hamming(w []float64) {
+	n := float64(len(w))
+	for i := range w {
+		w[i] = 0.54 - 0.46*math.Cos(2*math.Pi*float64(i)/n)
+	}
+}
+
+// Periodogram returns the periodogram estimate of the one-sided spectral
+// density of x at the Fourier frequencies k/n cycles per sample for
+// k = 0, ..., n/2, where n = len(x). The mean of x is removed and the
+// result is tapered by window before the transform; if window is nil the
+// Rectangular window is used. The density is scaled so that its integral
+// over [0, 1/2] approximates the variance of x,
+//  S_k = c |\sum_t w_t (x_t - mean) exp(-2πikt/n)|^2 / \sum_t w_t^2
+// with c = 1 at frequencies 0 and 1/2 and c = 2 otherwise.
+//
+// If freq or power is nil, a new slice will be allocated, otherwise the
+// result is stored in-place into it, and it must have length n/2+1.
+// Periodogram panics if len(x) is less than two.
+func Periodogram(freq, power, x []float64, window Window) ([]float64, []float64) {
+	if len(x) < 2 {
+		panic(tooFewSamples)
+	}
+	if window == nil {
+		window = Rectangular
+	}
+	n := len(x)
+	freq = reuseAs(freq, n/2+1)
+	power = reuseAs(power, n/2+1)
+	for k := range freq {
+		freq[k] = float64(k) / float64(n)
+		power[k] = 0
+	}
+	w := make([]float64, n)
+	window(w)
+	addSegment(power, x, w)
+	return freq, power
+}
+
+// Welch returns Welch's estimate of the one-sided spectral density of x,
+// the average of the periodograms of segments of length segment, with
+// successive segments overlapping by overlap samples. The density is
+// estimated at the frequencies k/segment cycles per sample for
+// k = 0, ..., segment/2. The mean of each segment is removed and the
+// segment is tapered by window before its transform; if window is nil the
+// Hann window is used. The density is scaled as described for Periodogram.
+// See
+//  Welch, P. "The use of fast Fourier transform for the estimation of power
+//  spectra: a method based on time averaging over short, modified
+//  periodograms." IEEE Transactions on Audio and Electroacoustics 15.2
+//  (1967): 70-73.
+//
+// If freq or power is nil, a new slice will be allocated, otherwise the
+// result is stored in-place into it, and it must have length segment/2+1.
+// Welch panics if segment is less than two or greater than len(x), or if
+// overlap is negative or not less than segment.
+func Welch(freq, power, x []float64, segment, overlap int, window Window) ([]float64, []float64) {
+	if segment < 2 || segment > len(x) || overlap < 0 || overlap >= segment {
+		panic(badSegment)
+	}
+	if window == nil {
+		window = Hann
+	}
+	freq = reuseAs(freq, segment/2+1)
+	power = reuseAs(power, segment/2+1)
+	for k := range freq {
+		freq[k] = float64(k) / float64(segment)
+		power[k] = 0
+	}
+	w := make([]float64, segment)
+	window(w)
+	var count int
+	for start := 0; start+segment <= len(x); start += segment - overlap {
+		addSegment(power, x[start:start+segment], w)
+		count++
+	}
+	for k := range power {
+		power[k] /= float64(count)
+	}
+	return freq, power
+}
+
+// addSegment adds the one-sided periodogram of the segment x, tapered by
+// the window weights w, to power.
+func addSegment(power, x, w []float64) {
+	n := len(x)
+	m := stat.Mean(x, nil)
+	var norm float64
+	tapered := make([]complex128, n)
+	for i, v := range x {
+		tapered[i] = complex(w[i]*(v-m), 0)
+		norm += w[i] * w[i]
+	}
+	coef := fourier.DFT(tapered)
+	for k := range power {
+		a := cmplx.Abs(coef[k])
+		s := a * a / norm
+		if k != 0 && 2*k != n {
+			s *= 2
+		}
+		power[k] += s
+	}
+}
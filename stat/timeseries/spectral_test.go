@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/stat"
+)
+
+func TestPeriodogram(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	for _, n := range []int{100, 101, 128} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = 3 + 2*math.Cos(2*math.Pi*0.2*float64(i)+0.3) + 0.1*src.NormFloat64()
+		}
+		freq, power := Periodogram(nil, nil, x, nil)
+		if len(freq) != n/2+1 || freq[1] != 1/float64(n) {
+			t.Errorf("Unexpected frequencies for n=%d", n)
+		}
+		// The periodogram integrates to the population variance.
+		want := stat.Variance(x, nil) * float64(n-1) / float64(n)
+		if got := floats.Sum(power) / float64(n); math.Abs(got-want) > 1e-10 {
+			t.Errorf("Periodogram integral mismatch for n=%d: want %v, got %v", n, want, got)
+		}
+		peak := floats.MaxIdx(power)
+		if math.Abs(freq[peak]-0.2) > 1/float64(n) {
+			t.Errorf("Periodogram peak at %v, want 0.2", freq[peak])
+		}
+		if power[0] > 1e-20 {
+			t.Errorf("Periodogram at zero frequency not zero after removing the mean: %v", power[0])
+		}
+	}
+}
+
+func TestWelch(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+
+	// The spectral density of an AR(1) process with coefficient φ and unit
+	// innovation variance is 2/(1 + φ² - 2φ cos(2πf)) on [0, 1/2].
+	const phi = 0.5
+	x := simulateARMA([]float64{phi}, nil, 0, 1, 100000, src)
+	for _, window := range []Window{Hann, Hamming, Rectangular} {
+		freq, power := Welch(nil, nil, x, 256, 128, window)
+		if len(freq) != 129 {
+			t.Fatalf("Unexpected number of frequencies: %d", len(freq))
+		}
+		// Removing the mean of each segment biases the estimates at
+		// the lowest frequencies, which are not checked.
+		for k := 2; k < len(freq)-1; k++ {
+			want := 2 / (1 + phi*phi - 2*phi*math.Cos(2*math.Pi*freq[k]))
+			if math.Abs(power[k]-want) > 0.15*want {
+				t.Errorf("Welch estimate mismatch at f=%v: want %v, got %v", freq[k], want, power[k])
+			}
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// armaKalman evaluates the exact Gaussian likelihood of the zero mean
+// ARMA(p, q) model with coefficients ar and ma and unit innovation variance
+// for the series w. It uses the Kalman filter on the state space form of
+//  Harvey, A. C. "Forecasting, structural time series models and the Kalman
+//  filter." Cambridge University Press (1989).
+// with state dimension r = max(p, q+1), transition matrix T with ar in its
+// first column and ones on its superdiagonal, and disturbance loading
+// R = [1, θ_1, ..., θ_{r-1}]ᵀ.
+//
+// armaKalman returns the sum of the squared standardized one-step
+// prediction errors, the sum of the logs of their relative variances and,
+// if innov is not nil, stores the prediction errors into innov. The
+// likelihood of the model with innovation variance σ² is then
+//  -1/2 (n log(2πσ²) + sumLogF + ssq/σ²)
+// ok is false if the model is not stationary.
+func armaKalman(innov, w, ar, ma []float64) (ssq, sumLogF float64, ok bool) {
+	r := len(ar)
+	if len(ma)+1 > r {
+		r = len(ma) + 1
+	}
+	phi := make([]float64, r)
+	copy(phi, ar)
+	rv := make([]float64, r)
+	rv[0] = 1
+	copy(rv[1:], ma)
+
+	p, ok := stationaryCovariance(phi, rv)
+	if !ok {
+		return math.NaN(), math.NaN(), false
+	}
+	a := make([]float64, r)
+	k := make([]float64, r)
+	tp := make([]float64, r*r)
+	for t, v := range w {
+		v -= a[0]
+		f := p[0]
+		if !(f > 0) {
+			return math.NaN(), math.NaN(), false
+		}
+		ssq += v * v / f
+		sumLogF += math.Log(f)
+		if innov != nil {
+			innov[t] = v
+		}
+
+		// K = T P Zᵀ / F, a = T a + K v.
+		for i := 0; i < r; i++ {
+			k[i] = phi[i] * p[0]
+			if i+1 < r {
+				k[i] += p[(i+1)*r]
+			}
+			k[i] /= f
+		}
+		a0 := a[0]
+		for i := 0; i < r; i++ {
+			next := phi[i] * a0
+			if i+1 < r {
+				next += a[i+1]
+			}
+			a[i] = next + k[i]*v
+		}
+
+		// P = T P Tᵀ + R Rᵀ - F K Kᵀ, computed through TP = T P.
+		for i := 0; i < r; i++ {
+			for j := 0; j < r; j++ {
+				s := phi[i] * p[j]
+				if i+1 < r {
+					s += p[(i+1)*r+j]
+				}
+				tp[i*r+j] = s
+			}
+		}
+		for i := 0; i < r; i++ {
+			for j := 0; j < r; j++ {
+				s := tp[i*r] * phi[j]
+				if j+1 < r {
+					s += tp[i*r+j+1]
+				}
+				p[i*r+j] = s + rv[i]*rv[j] - f*k[i]*k[j]
+			}
+		}
+	}
+	return ssq, sumLogF, true
+}
+
+// stationaryCovariance returns the covariance P of the stationary
+// distribution of the state of the ARMA state space model with transition
+// matrix T and disturbance loading rv, the solution of
+//  P = T P Tᵀ + R Rᵀ
+// stored in row-major order. ok is false if the model is not stationary.
+func stationaryCovariance(phi, rv []float64) (p []float64, ok bool) {
+	if !stationary(phi) {
+		return nil, false
+	}
+	r := len(phi)
+	at := func(i, j int) float64 {
+		var v float64
+		if j == 0 {
+			v = phi[i]
+		}
+		if j == i+1 {
+			v++
+		}
+		return v
+	}
+	// Solve (I - T⊗T) vec(P) = vec(R Rᵀ).
+	n := r * r
+	a := mat.NewDense(n, n, nil)
+	b := mat.NewVecDense(n, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < r; j++ {
+			row := i*r + j
+			b.SetVec(row, rv[i]*rv[j])
+			for k := 0; k < r; k++ {
+				tik := at(i, k)
+				if tik == 0 {
+					continue
+				}
+				for l := 0; l < r; l++ {
+					a.Set(row, k*r+l, a.At(row, k*r+l)-tik*at(j, l))
+				}
+			}
+			a.Set(row, row, a.At(row, row)+1)
+		}
+	}
+	var vec mat.VecDense
+	if err := vec.SolveVec(a, b); err != nil {
+		return nil, false
+	}
+	return vec.RawVector().Data, true
+}
+
+// stationary returns whether the autoregressive polynomial
+//  1 - \sum_i ar_i z^i
+// has all its roots outside the unit circle, determined by the step-down
+// Durbin-Levinson recursion.
+func stationary(ar []float64) bool {
+	_, ok := arToPACF(ar)
+	return ok
+}
+
+// arToPACF returns the partial autocorrelations of the stationary
+// autoregressive model with coefficients ar. ok is false if the model is
+// not stationary.
+func arToPACF(ar []float64) (pacf []float64, ok bool) {
+	p := len(ar)
+	a := make([]float64, p)
+	copy(a, ar)
+	// Trailing zero coefficients do not affect stationarity.
+	for p > 0 && a[p-1] == 0 {
+		p--
+	}
+	pacf = make([]float64, len(ar))
+	prev := make([]float64, p)
+	for k := p; k >= 1; k-- {
+		c := a[k-1]
+		if math.Abs(c) >= 1 {
+			return nil, false
+		}
+		pacf[k-1] = c
+		copy(prev, a[:k])
+		for j := 1; j < k; j++ {
+			a[j-1] = (prev[j-1] + c*prev[k-j-1]) / (1 - c*c)
+		}
+	}
+	return pacf, true
+}
+
+// pacfToAR returns the coefficients of the autoregressive model with
+// partial autocorrelations pacf, all of which must lie in (-1, 1).
+func pacfToAR(pacf []float64) []float64 {
+	p := len(pacf)
+	ar := make([]float64, p)
+	prev := make([]float64, p)
+	for k := 1; k <= p; k++ {
+		c := pacf[k-1]
+		ar[k-1] = c
+		for j := 1; j < k; j++ {
+			ar[j-1] = prev[j-1] - c*prev[k-j-1]
+		}
+		copy(prev, ar)
+	}
+	return ar
+}
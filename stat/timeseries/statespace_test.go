@@ -0,0 +1,121 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/stat/distmv"
+)
+
+// armaAutocovariance returns the autocovariances at lags 0 through maxLag
+// of the ARMA model with unit innovation variance, computed from a long
+// truncation of its moving average representation.
+func armaAutocovariance(ar, ma []float64, maxLag int) []float64 {
+	const terms = 5000
+	psi := make([]float64, terms)
+	for j := range psi {
+		if j == 0 {
+			psi[j] = 1
+			continue
+		}
+		if j <= len(ma) {
+			psi[j] = ma[j-1]
+		}
+		for i := 1; i <= j && i <= len(ar); i++ {
+			psi[j] += ar[i-1] * psi[j-i]
+		}
+	}
+	acov := make([]float64, maxLag+1)
+	for k := range acov {
+		acov[k] = floats.Dot(psi[:terms-k], psi[k:])
+	}
+	return acov
+}
+
+func TestARMAKalman(t *testing.T) {
+	src := rand.New(rand.NewSource(1))
+	const n = 30
+	for _, test := range []struct {
+		ar, ma []float64
+	}{
+		{nil, nil},
+		{[]float64{0.7}, nil},
+		{nil, []float64{0.5}},
+		{[]float64{0.6}, []float64{-0.4}},
+		{[]float64{0.5, -0.3}, []float64{0.4, 0.2}},
+		{[]float64{0.2, 0.1, 0.3}, []float64{0.6}},
+		{[]float64{0.4}, []float64{0.3, 0, 0.2}},
+	} {
+		w := simulateARMA(test.ar, test.ma, 0, 1, n, src)
+		acov := armaAutocovariance(test.ar, test.ma, n-1)
+		cov := mat.NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				cov.SetSym(i, j, acov[j-i])
+			}
+		}
+		normal, ok := distmv.NewNormal(make([]float64, n), cov, nil)
+		if !ok {
+			t.Fatalf("bad test")
+		}
+		ssq, sumLogF, ok := armaKalman(nil, w, test.ar, test.ma)
+		if !ok {
+			t.Errorf("unexpected failure for ar=%v ma=%v", test.ar, test.ma)
+			continue
+		}
+		got := -0.5 * (n*math.Log(2*math.Pi) + sumLogF + ssq)
+		want := normal.LogProb(w)
+		if math.Abs(got-want) > 1e-8 {
+			t.Errorf("Log-likelihood mismatch for ar=%v ma=%v: want %v, got %v", test.ar, test.ma, want, got)
+		}
+	}
+
+	if _, _, ok := armaKalman(nil, []float64{1, 2, 3}, []float64{0.5, 0.6}, nil); ok {
+		t.Errorf("expected failure for non-stationary model")
+	}
+}
+
+func TestPACFTransform(t *testing.T) {
+	for _, test := range []struct {
+		ar         []float64
+		stationary bool
+	}{
+		{nil, true},
+		{[]float64{0.5}, true},
+		{[]float64{-1.2}, false},
+		{[]float64{0.5, 0.3}, true},
+		{[]float64{0.5, 0.6}, false},
+		{[]float64{1.5, -0.7}, true},
+		{[]float64{0.2, -0.1, 0.4}, true},
+		{[]float64{0.5, 0.3, 0}, true},
+	} {
+		pacf, ok := arToPACF(test.ar)
+		if ok != test.stationary {
+			t.Errorf("Stationarity mismatch for %v: want %t, got %t", test.ar, test.stationary, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if got := pacfToAR(pacf); !floats.EqualApprox(got, test.ar, 1e-14) {
+			t.Errorf("Round trip mismatch for %v: got %v", test.ar, got)
+		}
+		// The partial autocorrelations of the model match those
+		// computed from its autocovariances.
+		if len(test.ar) > 0 {
+			acov := armaAutocovariance(test.ar, nil, len(test.ar))
+			want := make([]float64, len(test.ar))
+			durbinLevinson(want, acov)
+			if !floats.EqualApprox(pacf, want, 1e-10) {
+				t.Errorf("PACF mismatch for %v: want %v, got %v", test.ar, want, pacf)
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+const (
+	badLag        = "timeseries: lag out of range"
+	badLength     = "timeseries: slice length mismatch"
+	badLevel      = "timeseries: confidence level out of range"
+	badOrder      = "timeseries: negative model order"
+	badSegment    = "timeseries: bad segment length or overlap"
+	tooFewSamples = "timeseries: too few samples"
+)
+
+// Difference returns the series x differenced d times, so that element i
+// of the result is the d-th difference ending at x[i+d]. If the input slice
+// is nil, a new slice will be allocated, otherwise the result is stored
+// in-place into dst, which must have length len(x)-d. Difference panics if
+// d is negative or not less than len(x).
+func Difference(dst, x []float64, d int) []float64 {
+	if d < 0 {
+		panic(badOrder)
+	}
+	if d >= len(x) {
+		panic(tooFewSamples)
+	}
+	dst = reuseAs(dst, len(x)-d)
+	if d == 0 {
+		copy(dst, x)
+		return dst
+	}
+	diff := make([]float64, len(x)-1)
+	for i := range diff {
+		diff[i] = x[i+1] - x[i]
+	}
+	for k := 1; k < d; k++ {
+		for i := 0; i < len(diff)-k; i++ {
+			diff[i] = diff[i+1] - diff[i]
+		}
+	}
+	copy(dst, diff)
+	return dst
+}
+
+// reuseAs returns dst if it has length n, or a new slice of length n if dst
+// is nil. reuseAs panics if dst is not nil and has length other than n.
+func reuseAs(dst []float64, n int) []float64 {
+	if dst == nil {
+		return make([]float64, n)
+	}
+	if len(dst) != n {
+		panic(badLength)
+	}
+	return dst
+}
@@ -0,0 +1,34 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timeseries
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+func TestDifference(t *testing.T) {
+	x := []float64{1, 4, 9, 16, 25, 36}
+	for _, test := range []struct {
+		d    int
+		want []float64
+	}{
+		{0, []float64{1, 4, 9, 16, 25, 36}},
+		{1, []float64{3, 5, 7, 9, 11}},
+		{2, []float64{2, 2, 2, 2}},
+		{3, []float64{0, 0, 0}},
+	} {
+		got := Difference(nil, x, test.d)
+		if !floats.Equal(got, test.want) {
+			t.Errorf("Difference mismatch for d=%d: want %v, got %v", test.d, test.want, got)
+		}
+		dst := make([]float64, len(x)-test.d)
+		Difference(dst, x, test.d)
+		if !floats.Equal(dst, test.want) {
+			t.Errorf("Difference mismatch in-place for d=%d: want %v, got %v", test.d, test.want, dst)
+		}
+	}
+}